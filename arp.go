@@ -0,0 +1,128 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// gratuitousARPIntervalUnit is the resolution of the Gratuitous ARP
+	// Interval LAN configuration parameter.
+	gratuitousARPIntervalUnit = time.Millisecond * 500
+
+	// completionCodeParameterNotSupported is returned by the Get LAN
+	// Configuration Parameters command for parameters the BMC does not
+	// implement.
+	completionCodeParameterNotSupported ipmi.CompletionCode = 0x80
+)
+
+// ARPConfig is the BMC-generated ARP configuration of a LAN channel, held in
+// the BMC-generated ARP Control and Gratuitous ARP Interval parameters. BMCs
+// sharing a NIC with the host generate gratuitous ARPs so switches continue to
+// forward its traffic; when the host or another BMC takes over the address,
+// these must stop, either permanently by disabling them here, or temporarily
+// with SuspendARPs().
+type ARPConfig struct {
+
+	// GratuitousARPs indicates the BMC sends gratuitous ARPs.
+	GratuitousARPs bool
+
+	// ARPResponses indicates the BMC responds to ARP requests.
+	ARPResponses bool
+
+	// GratuitousARPInterval is the time between gratuitous ARPs, with a
+	// resolution of 500ms.
+	GratuitousARPInterval time.Duration
+}
+
+// GetARPConfig retrieves the BMC-generated ARP configuration of a LAN
+// channel. Not all BMCs implement the Gratuitous ARP Interval parameter; if it
+// is not supported, GratuitousARPInterval is 0.
+func GetARPConfig(ctx context.Context, s Session, channel ipmi.Channel) (*ARPConfig, error) {
+	data, err := getLANParameter(ctx, s, channel,
+		ipmi.LANConfigurationParameterBMCGeneratedARPControl, 1)
+	if err != nil {
+		return nil, err
+	}
+	config := &ARPConfig{
+		GratuitousARPs: data[0]&(1<<1) != 0,
+		ARPResponses:   data[0]&1 != 0,
+	}
+	data, err = getLANParameter(ctx, s, channel,
+		ipmi.LANConfigurationParameterGratuitousARPInterval, 1)
+	switch {
+	case isParameterNotSupported(err):
+	case err != nil:
+		return nil, err
+	default:
+		config.GratuitousARPInterval = time.Duration(data[0]) *
+			gratuitousARPIntervalUnit
+	}
+	return config, nil
+}
+
+// SetARPConfig sets the BMC-generated ARP configuration of a LAN channel. The
+// interval is rounded to the nearest 500ms, and is only set if non-zero, so
+// it can be omitted for BMCs without the parameter. The longest interval is
+// 127.5 seconds.
+func SetARPConfig(ctx context.Context, s Session, channel ipmi.Channel, c *ARPConfig) error {
+	interval := math.Round(float64(c.GratuitousARPInterval) /
+		float64(gratuitousARPIntervalUnit))
+	if interval > math.MaxUint8 {
+		return fmt.Errorf("gratuitous ARP interval must be at most %v, got %v",
+			gratuitousARPIntervalUnit*math.MaxUint8, c.GratuitousARPInterval)
+	}
+	control := uint8(0)
+	if c.GratuitousARPs {
+		control |= 1 << 1
+	}
+	if c.ARPResponses {
+		control |= 1
+	}
+	if err := setLANParameter(channel,
+		ipmi.LANConfigurationParameterBMCGeneratedARPControl,
+		control)(ctx, s); err != nil {
+		return err
+	}
+	if c.GratuitousARPInterval == 0 {
+		return nil
+	}
+	return setLANParameter(channel,
+		ipmi.LANConfigurationParameterGratuitousARPInterval,
+		uint8(interval))(ctx, s)
+}
+
+// SuspendARPs temporarily stops the BMC generating gratuitous ARPs and/or
+// responding to ARP requests on a LAN channel, e.g. during IP address
+// takeover, returning the resulting ARP status. Sending a request with neither
+// field set resumes ARPs enabled by the channel's ARPConfig. The response is
+// a copy, owned by the caller.
+func SuspendARPs(ctx context.Context, s Session, req *ipmi.SuspendBMCARPsReq) (*ipmi.SuspendBMCARPsRsp, error) {
+	cmd := &ipmi.SuspendBMCARPsCmd{
+		Req: *req,
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	rsp := cmd.Rsp
+	rsp.BaseLayer = layers.BaseLayer{}
+	return &rsp, nil
+}
+
+// isParameterNotSupported returns whether err is a response to a Get LAN
+// Configuration Parameters command indicating the parameter is not
+// implemented. Some BMCs return Requested Data Not Present rather than the
+// command-specific code.
+func isParameterNotSupported(err error) bool {
+	codeErr := (*CompletionCodeError)(nil)
+	return errors.As(err, &codeErr) &&
+		(codeErr.Code == completionCodeParameterNotSupported ||
+			codeErr.Code == ipmi.CompletionCodeNotPresent)
+}
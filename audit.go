@@ -0,0 +1,82 @@
+package bmc
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// AuditRecord describes a destructive command sent within a session, i.e. one
+// with ipmi.OperationEffectDestructive, such as Chassis Control or initiating
+// Clear SEL.
+type AuditRecord struct {
+
+	// Time is when the command was submitted to the session.
+	Time time.Time
+
+	// Target is the address of the BMC the command was sent to.
+	Target string
+
+	// Command is the name of the command, e.g. "Chassis Control".
+	Command string
+
+	// Operation identifies the command on the wire.
+	Operation ipmi.Operation
+
+	// CompletionCode is the completion code returned by the BMC. It is only
+	// meaningful if a response was received, which may not be the case if Err
+	// is non-nil.
+	CompletionCode ipmi.CompletionCode
+
+	// Err is the error returned to the caller, or nil if the command
+	// succeeded. A timeout does not imply the BMC did not act on the command.
+	Err error
+
+	// Metadata is the RequestMetadata in the context the command was sent
+	// with, if any, identifying who the command was sent for.
+	Metadata RequestMetadata
+
+	// DryRun indicates the command was not sent, as the session was
+	// established with SessionOpts.DryRun. CompletionCode is that returned to
+	// the caller.
+	DryRun bool
+}
+
+// AuditSink receives a record of each destructive command sent within a
+// session, allowing daemons to keep an audit trail without wrapping every call
+// site. It is set via SessionOpts.
+type AuditSink interface {
+
+	// Audit is called after the outcome of the command is known, before it is
+	// returned to the caller. It is called synchronously while the session is
+	// locked, so must not send commands within the session, and should return
+	// quickly, e.g. by queueing the record. ctx is the context the command was
+	// sent with.
+	Audit(ctx context.Context, r *AuditRecord)
+}
+
+// audit passes a record of the command to the session's audit sink, if it has
+// one and the command is destructive.
+func (s *V2Session) audit(ctx context.Context, c ipmi.Command, start time.Time, code ipmi.CompletionCode, err error) {
+	if s.auditSink == nil || commandEffect(c) != ipmi.OperationEffectDestructive {
+		return
+	}
+	metadata, _ := RequestMetadataFromContext(ctx)
+	s.auditSink.Audit(ctx, &AuditRecord{
+		Time:           start,
+		Target:         s.demux.Address().String(),
+		Command:        c.Name(),
+		Operation:      *c.Operation(),
+		CompletionCode: code,
+		Err:            err,
+		Metadata:       metadata,
+		DryRun:         s.dryRun,
+	})
+}
+
+// SetAuditSink replaces the session's audit sink, or removes it if sink is
+// nil. It must not be called concurrently with sending a command.
+func (s *V2Session) SetAuditSink(sink AuditSink) {
+	s.auditSink = sink
+}
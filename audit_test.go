@@ -0,0 +1,66 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// recordingAuditSink retains the records it is passed.
+type recordingAuditSink struct {
+	records []AuditRecord
+}
+
+func (s *recordingAuditSink) Audit(_ context.Context, r *AuditRecord) {
+	s.records = append(s.records, *r)
+}
+
+func TestAuditSink(t *testing.T) {
+	sink := &recordingAuditSink{}
+	simBMC, sess := newTestSessionWithOpts(t, &sim.Config{}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+			AuditSink:         sink,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	metadata := RequestMetadata{
+		TraceID: "4bf92f3577b34da6",
+		User:    "alice",
+	}
+	ctx = WithRequestMetadata(ctx, metadata)
+	if _, err := sess.GetChassisStatus(ctx); err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if len(sink.records) != 0 {
+		t.Errorf("Get Chassis Status was audited: %+v", sink.records)
+	}
+	before := time.Now()
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOff); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	if len(sink.records) != 1 {
+		t.Fatalf("audit records = %+v, want 1", sink.records)
+	}
+	got := sink.records[0]
+	if got.Time.Before(before) || got.Time.After(time.Now()) {
+		t.Errorf("record time %v is outside the call", got.Time)
+	}
+	got.Time = time.Time{}
+	want := AuditRecord{
+		Target:         simBMC.Addr(),
+		Command:        "Chassis Control",
+		Operation:      ipmi.OperationChassisControlReq,
+		CompletionCode: ipmi.CompletionCodeNormal,
+		Metadata:       metadata,
+	}
+	if got != want {
+		t.Errorf("audit record = %+v, want %+v", got, want)
+	}
+}
@@ -168,8 +168,9 @@ func calculateSIK(h hash.Hash, rakpMessage1 *ipmi.RAKPMessage1, rakpMessage2 *ip
 
 // calculateRAKPMessage2AuthCode computes the ICV that should be sent by the BMC
 // in RAKP Message 2 based on the RAKP Message 1 sent by the remote console and
-// the RAKP Message 2 sent by the BMC.
-func calculateRAKPMessage2AuthCode(h hash.Hash, rakpMessage1 *ipmi.RAKPMessage1, rakpMessage2 *ipmi.RAKPMessage2) []byte {
+// the RAKP Message 2 sent by the BMC. If padUsername is true, the username is
+// padded to 16 bytes as per QuirkRAKP2UsernamePadded.
+func calculateRAKPMessage2AuthCode(h hash.Hash, rakpMessage1 *ipmi.RAKPMessage1, rakpMessage2 *ipmi.RAKPMessage2, padUsername bool) []byte {
 	buf := [4]byte{}
 
 	// session IDs are in wire byte order, presumably for efficiency, but we'd
@@ -186,9 +187,15 @@ func calculateRAKPMessage2AuthCode(h hash.Hash, rakpMessage1 *ipmi.RAKPMessage1,
 	if !rakpMessage1.PrivilegeLevelLookup {
 		role |= 1 << 4
 	}
-	h.Write([]byte{role})                              // Role_M (entire byte from original wire format)
-	h.Write([]byte{uint8(len(rakpMessage1.Username))}) // ULength_M
-	h.Write([]byte(rakpMessage1.Username))             // UName_M
+	h.Write([]byte{role}) // Role_M (entire byte from original wire format)
+	username := []byte(rakpMessage1.Username)
+	if padUsername && len(username) < 16 {
+		padded := [16]byte{}
+		copy(padded[:], username)
+		username = padded[:]
+	}
+	h.Write([]byte{uint8(len(username))}) // ULength_M
+	h.Write(username)                     // UName_M
 	sum := h.Sum(nil)
 	h.Reset()
 	return sum
@@ -0,0 +1,48 @@
+package bmc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestCalculateRAKPMessage2AuthCodePadUsername(t *testing.T) {
+	rakpMessage1 := &ipmi.RAKPMessage1{
+		ManagedSystemSessionID: 0x04030201,
+		MaxPrivilegeLevel:      ipmi.PrivilegeLevelAdministrator,
+		Username:               "ADMIN",
+	}
+	rakpMessage2 := &ipmi.RAKPMessage2{
+		RemoteConsoleSessionID: 0x08070605,
+	}
+	key := []byte("password")
+
+	// everything except the username is zero apart from the session IDs
+	prefix := []byte{0x05, 0x06, 0x07, 0x08, 0x01, 0x02, 0x03, 0x04}
+	prefix = append(prefix, make([]byte, 16+16+16)...) // R_M, R_C, GUID_C
+	prefix = append(prefix, uint8(ipmi.PrivilegeLevelAdministrator)|1<<4)
+
+	tests := []struct {
+		pad      bool
+		username []byte
+	}{
+		{false, []byte("ADMIN")},
+		{true, append([]byte("ADMIN"), make([]byte, 11)...)},
+	}
+	for _, test := range tests {
+		h := hmac.New(sha1.New, key)
+		h.Write(prefix)
+		h.Write([]byte{uint8(len(test.username))})
+		h.Write(test.username)
+		want := h.Sum(nil)
+
+		got := calculateRAKPMessage2AuthCode(hmac.New(sha1.New, key),
+			rakpMessage1, rakpMessage2, test.pad)
+		if !bytes.Equal(got, want) {
+			t.Errorf("pad = %v: got %x, want %x", test.pad, got, want)
+		}
+	}
+}
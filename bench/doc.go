@@ -0,0 +1,12 @@
+// Package bench contains benchmarks for the library, run against the simulated
+// BMC in internal/pkg/sim over the loopback interface. It has no non-test
+// code; run the suite with:
+//
+//	go test -run '^$' -bench . -benchmem ./bench
+//
+// Round-trip benchmarks include the cost of the simulator, which is built from
+// the same layers as the client, so they approximate twice the client's
+// per-packet cost plus loopback latency. Layer benchmarks measure
+// serialisation and decoding in isolation. Compare runs before and after a
+// change with benchstat.
+package bench
@@ -0,0 +1,256 @@
+package bench
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"testing"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+var serializeOptions = gopacket.SerializeOptions{
+	FixLengths:       true,
+	ComputeChecksums: true,
+}
+
+func newAES128CBC(b *testing.B) *ipmi.AES128CBC {
+	aes, err := ipmi.NewAES128CBC([16]byte{0x1, 0x2, 0x3})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return aes
+}
+
+// serialize returns the bytes of the provided layers, serialised in order.
+func serialize(b *testing.B, ls ...gopacket.SerializableLayer) []byte {
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions, ls...); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkSerialize measures serialising each outbound layer on its own, into
+// a reused buffer, which is how connections build packets.
+func BenchmarkSerialize(b *testing.B) {
+	payload := gopacket.Payload{0x1, 0x2, 0x3, 0x4}
+	table := []struct {
+		name   string
+		layers []gopacket.SerializableLayer
+	}{
+		{
+			"RMCP",
+			[]gopacket.SerializableLayer{
+				&layers.RMCP{
+					Version:  layers.RMCPVersion1,
+					Sequence: 0xff,
+					Class:    layers.RMCPClassIPMI,
+				},
+			},
+		},
+		{
+			"V2Session",
+			[]gopacket.SerializableLayer{
+				&ipmi.V2Session{
+					PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
+				},
+				payload,
+			},
+		},
+		{
+			"V2SessionAuthenticated",
+			[]gopacket.SerializableLayer{
+				&ipmi.V2Session{
+					PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
+					Authenticated:     true,
+					ID:                0x01020304,
+					Sequence:          1,
+					IntegrityAlgorithm: hmac.New(sha1.New,
+						[]byte{0x1, 0x2, 0x3}),
+				},
+				payload,
+			},
+		},
+		{
+			"AES128CBC",
+			[]gopacket.SerializableLayer{
+				newAES128CBC(b),
+				payload,
+			},
+		},
+		{
+			"Message",
+			[]gopacket.SerializableLayer{
+				&ipmi.Message{
+					Operation:     ipmi.OperationGetSensorReadingReq,
+					RemoteAddress: ipmi.SlaveAddressBMC.Address(),
+					LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+					Sequence:      1,
+				},
+				payload,
+			},
+		},
+		{
+			"GetSensorReadingReq",
+			[]gopacket.SerializableLayer{
+				&ipmi.GetSensorReadingReq{
+					Number: 1,
+				},
+			},
+		},
+		{
+			"GetSDRReq",
+			[]gopacket.SerializableLayer{
+				&ipmi.GetSDRReq{
+					RecordID: ipmi.RecordIDFirst,
+					Length:   0xff,
+				},
+			},
+		},
+		{
+			"OpenSessionReq",
+			[]gopacket.SerializableLayer{
+				&ipmi.OpenSessionReq{
+					MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+					SessionID:         1,
+					AuthenticationPayloads: []ipmi.AuthenticationPayload{{
+						Algorithm: ipmi.AuthenticationAlgorithmHMACSHA1,
+					}},
+					IntegrityPayloads: []ipmi.IntegrityPayload{{
+						Algorithm: ipmi.IntegrityAlgorithmHMACSHA196,
+					}},
+					ConfidentialityPayloads: []ipmi.ConfidentialityPayload{{
+						Algorithm: ipmi.ConfidentialityAlgorithmAESCBC128,
+					}},
+				},
+			},
+		},
+	}
+	for _, test := range table {
+		b.Run(test.name, func(b *testing.B) {
+			buf := gopacket.NewSerializeBuffer()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := gopacket.SerializeLayers(buf, serializeOptions,
+					test.layers...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecode measures decoding each inbound layer on its own, into a
+// reused layer, which is how connections parse responses. Layers may decode in
+// place, e.g. AES-CBC-128 decrypts its input, so each iteration includes
+// copying the packet into a scratch buffer.
+func BenchmarkDecode(b *testing.B) {
+	payload := gopacket.Payload{0x1, 0x2, 0x3, 0x4}
+	integrity := hmac.New(sha1.New, []byte{0x1, 0x2, 0x3})
+	aes := newAES128CBC(b)
+	table := []struct {
+		name  string
+		layer gopacket.DecodingLayer
+		data  []byte
+	}{
+		{
+			"RMCP",
+			&layers.RMCP{},
+			[]byte{0x06, 0x00, 0xff, 0x07},
+		},
+		{
+			"SessionSelector",
+			&ipmi.SessionSelector{},
+			[]byte{0x06},
+		},
+		{
+			"V2Session",
+			&ipmi.V2Session{},
+			serialize(b, &ipmi.V2Session{
+				PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
+			}, payload),
+		},
+		{
+			"V2SessionAuthenticated",
+			&ipmi.V2Session{
+				IntegrityAlgorithm: integrity,
+			},
+			serialize(b, &ipmi.V2Session{
+				PayloadDescriptor:  ipmi.PayloadDescriptorIPMI,
+				Authenticated:      true,
+				ID:                 0x01020304,
+				Sequence:           1,
+				IntegrityAlgorithm: integrity,
+			}, payload),
+		},
+		{
+			"AES128CBC",
+			aes,
+			serialize(b, aes, payload),
+		},
+		{
+			"Message",
+			&ipmi.Message{},
+			serialize(b, &ipmi.Message{
+				Operation:      ipmi.OperationGetSensorReadingRsp,
+				RemoteAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+				LocalAddress:   ipmi.SlaveAddressBMC.Address(),
+				Sequence:       1,
+				CompletionCode: ipmi.CompletionCodeNormal,
+			}, payload),
+		},
+		{
+			"GetSensorReadingRsp",
+			&ipmi.GetSensorReadingRsp{},
+			[]byte{0x2d, 0xc0, 0x00},
+		},
+		{
+			"GetSDRRsp",
+			&ipmi.GetSDRRsp{},
+			append([]byte{0x02, 0x00}, sim.FullSensorRecord(1, 1, "Temp 1")...),
+		},
+		{
+			"SDR",
+			&ipmi.SDR{},
+			sim.FullSensorRecord(1, 1, "Temp 1"),
+		},
+		{
+			"FullSensorRecord",
+			&ipmi.FullSensorRecord{},
+			sim.FullSensorRecord(1, 1, "Temp 1")[5:],
+		},
+		{
+			"OpenSessionRsp",
+			&ipmi.OpenSessionRsp{},
+			[]byte{0x00, 0x00, 0x02, 0x00, 0x01, 0x00, 0x00, 0x00,
+				0x04, 0x03, 0x02, 0x01,
+				0x00, 0x00, 0x00, 0x08, 0x01, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x08, 0x01, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x08, 0x01, 0x00, 0x00, 0x00},
+		},
+		{
+			"RAKPMessage2",
+			&ipmi.RAKPMessage2{},
+			append(make([]byte, 40), make([]byte, 20)...),
+		},
+	}
+	for _, test := range table {
+		b.Run(test.name, func(b *testing.B) {
+			data := make([]byte, len(test.data))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(data, test.data)
+				if err := test.layer.DecodeFromBytes(data,
+					gopacket.NilDecodeFeedback); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	username = "admin"
+	password = "hunter2"
+
+	// sensors is the number of Full Sensor Records in the simulated BMC's SDR
+	// Repository, in line with a typical 1U server.
+	sensors = 50
+)
+
+// newSim starts a simulated BMC with sensors Full Sensor Records, each with a
+// reading, and dials it. Both are closed when the benchmark completes.
+func newSim(b *testing.B) *bmc.V2SessionlessTransport {
+	config := &sim.Config{
+		Username: username,
+		Password: password,
+		Readings: map[uint8]uint8{},
+	}
+	for i := 0; i < sensors; i++ {
+		number := uint8(i + 1)
+		config.SDRs = append(config.SDRs, sim.FullSensorRecord(
+			ipmi.RecordID(i+1), number, fmt.Sprintf("Temp %v", number)))
+		config.Readings[number] = 20 + number
+	}
+	s, err := sim.New(config)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		s.Close()
+	})
+
+	machine, err := bmc.DialV2(s.Addr())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		machine.Close()
+	})
+	return machine
+}
+
+func newSession(ctx context.Context, b *testing.B, machine *bmc.V2SessionlessTransport) bmc.Session {
+	sess, err := machine.NewSession(ctx, &bmc.SessionOpts{
+		Username:          username,
+		Password:          []byte(password),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		sess.Close(ctx)
+	})
+	return sess
+}
+
+// BenchmarkSessionEstablishment measures opening and closing a session: Get
+// Channel Authentication Capabilities, Open Session, RAKP 1-4 and Close
+// Session, i.e. 5 round-trips and the key derivation at both ends.
+func BenchmarkSessionEstablishment(b *testing.B) {
+	ctx := context.Background()
+	machine := newSim(b)
+	opts := &bmc.SessionOpts{
+		Username:          username,
+		Password:          []byte(password),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sess, err := machine.NewSession(ctx, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := sess.Close(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetSensorReading measures an authenticated and encrypted Get Sensor
+// Reading round-trip within an established session.
+func BenchmarkGetSensorReading(b *testing.B) {
+	ctx := context.Background()
+	sess := newSession(ctx, b, newSim(b))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sess.GetSensorReading(ctx, uint8(i%sensors+1)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSDRWalk measures retrieving and parsing the entire SDR Repository,
+// which requires a Get SDR command per record, plus a Get SDR Repository Info
+// either side. Throughput is additionally reported in records per second.
+func BenchmarkSDRWalk(b *testing.B) {
+	ctx := context.Background()
+	sess := newSession(ctx, b, newSim(b))
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		repo, err := bmc.RetrieveSDRRepository(ctx, sess)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(repo) != sensors {
+			b.Fatalf("retrieved %v records, want %v", len(repo), sensors)
+		}
+	}
+	b.ReportMetric(float64(b.N*sensors)/time.Since(start).Seconds(), "records/s")
+}
@@ -4,8 +4,10 @@ package bmc
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"net"
 	"time"
 
 	"github.com/kuiwang02/bmc/internal/pkg/transport"
@@ -21,59 +23,192 @@ var (
 	}
 
 	namespace = "bmc"
+
+	// ErrBMCBusy is wrapped by errors caused by the BMC being too busy to
+	// process a command, i.e. a temporary completion code such as Node Busy
+	// (0xc0), including when retries are exhausted.
+	ErrBMCBusy = errors.New("BMC busy")
+
+	// ErrDecode is wrapped by errors returned when a response cannot be
+	// decoded, including when no valid response is received before the
+	// deadline.
+	ErrDecode = errors.New("failed to decode response")
+
+	// ErrChecksum is wrapped by errors returned when a message or FRU data
+	// has an incorrect checksum. It is the same as ipmi.ErrInvalidChecksum.
+	ErrChecksum = ipmi.ErrInvalidChecksum
+)
+
+const (
+	// defaultCommandTimeout is the per-request timeout for connections, unless
+	// overridden by DialOpts or SetTimeout().
+	defaultCommandTimeout = time.Second
 )
 
-// Dial is currently an alias for DialV2. When IPMI v1.5 is implemented, this
-// will query the BMC for IPMI v2.0 capability. If it supports IPMI v2.0, a
-// V2SessionlessTransport will be returned, otherwise a V1SessionlessTransport
-// will be returned. If you know the BMC's capabilities, or need a specific
-// feature (e.g. DCMI), use the DialV*() functions instead, which expose
-// additional information and functionality.
-func Dial(_ context.Context, addr string) (SessionlessTransport, error) {
-	return DialV2(addr)
+// DialOpts configures the timeouts of a new connection. The zero value is
+// valid, and results in the defaults described for each field.
+type DialOpts struct {
+
+	// Timeout bounds DNS resolution and socket setup, in addition to the
+	// context passed to the dial function. This allows one context to be used
+	// for an entire interaction with a BMC, without a slow DNS lookup using up
+	// the time intended for commands. If 0, only the context applies.
+	Timeout time.Duration
+
+	// CommandTimeout is the time allowed for the BMC to respond to each
+	// attempt of a command, on the connection and any sessions created from
+	// it. It can later be changed with SetTimeout(), or overridden for
+	// individual commands with WithCommandTimeout(). Defaults to 1 second,
+	// which is too short for BMCs reached over high-latency links, e.g.
+	// satellite or WAN, where several seconds may be needed.
+	CommandTimeout time.Duration
+
+	// EstablishmentTimeout is the time allowed for the BMC to respond to each
+	// attempt of an RMCP+ session establishment message (Open Session and RAKP
+	// Messages 1 and 3). Some BMCs take noticeably longer to respond to these
+	// than to commands, as they generate random numbers and calculate HMACs.
+	// It can later be changed with SetEstablishmentTimeout(). Defaults to
+	// CommandTimeout.
+	EstablishmentTimeout time.Duration
+
+	// AddressStagger is the delay between probing successive addresses when
+	// a hostname resolves to several, e.g. because both the BMC's dedicated
+	// and shared NICs are in DNS, but only one is reachable. Each address is
+	// sent a Presence Ping, IPv4 addresses first, and the first to respond
+	// is used; the connection's Address() returns which was chosen. Each
+	// address is allowed CommandTimeout to respond, after which, if none
+	// has, the first address is used. Defaults to 250ms. If negative, the
+	// first address is used without probing.
+	AddressStagger time.Duration
+
+	// MaxPacketSize is the largest UDP payload in bytes that the connection
+	// and sessions created from it will send. Larger packets are not sent,
+	// and an error wrapping ErrPacketTooLarge is returned instead, as
+	// fragmented UDP to BMCs is frequently dropped, which would otherwise
+	// surface as an unexplained timeout. No command defined by the spec
+	// approaches this; it guards raw commands and OEM payloads built by the
+	// caller. Defaults to the largest payload that fits the MTU of the local
+	// interface used to reach the BMC, capped at a 1500 byte Ethernet MTU,
+	// i.e. at most 1472 bytes over IPv4 and 1452 over IPv6. Lower it for
+	// paths with a smaller MTU beyond the local network, e.g. VPNs.
+	MaxPacketSize int
+
+	// Clock overrides the system clock for the connection and sessions
+	// created from it. It determines the times in AuditRecords, and how long
+	// retransmission and session establishment retries are considered to have
+	// taken against their maximum elapsed time. Timeouts of individual
+	// attempts and the waits between them still use real timers. It is
+	// intended for tests; nil, the default, uses the system clock.
+	Clock Clock
+
+	// Rand overrides crypto/rand as the source of the remote console random
+	// numbers exchanged during session establishment, so sessions can be
+	// established deterministically in tests. The security of sessions
+	// depends on these numbers being unpredictable, so this must not be set
+	// outside tests. Session IDs and sequence numbers are allocated
+	// sequentially from 1, so are already deterministic.
+	Rand io.Reader
+}
+
+// Dial is currently an alias for DialV2Context with default options. When IPMI
+// v1.5 is implemented, this will query the BMC for IPMI v2.0 capability. If it
+// supports IPMI v2.0, a V2SessionlessTransport will be returned, otherwise a
+// V1SessionlessTransport will be returned. If you know the BMC's capabilities,
+// or need a specific feature (e.g. DCMI), use the DialV*() functions instead,
+// which expose additional information and functionality. The context bounds
+// DNS resolution.
+func Dial(ctx context.Context, addr string) (SessionlessTransport, error) {
+	return DialV2Context(ctx, addr, nil)
 }
 
 // DialV2 establishes a new IPMI v2.0 connection with the supplied BMC. The
 // address is of the form IP[:port] (IPv6 must be enclosed in square brackets).
 // Use this if you know the BMC supports IPMI v2.0 and/or require DCMI
-// functionality. Note v4 is preferred to v6 if a hostname is passed returning
-// both A and AAAA records.
+// functionality. If a hostname is passed returning several A or AAAA records,
+// the first address to respond to a Presence Ping is used, preferring v4 to
+// v6; see DialOpts.AddressStagger. DNS resolution is unbounded; use
+// DialV2Context() to limit it.
 func DialV2(addr string) (*V2SessionlessTransport, error) {
+	return DialV2Context(context.Background(), addr, nil)
+}
+
+// DialV2Context is like DialV2, but respects the context's deadline during DNS
+// resolution and socket setup, and allows configuring timeouts. opts may be
+// nil to use the defaults. The context is not retained after this returns.
+func DialV2Context(ctx context.Context, addr string, opts *DialOpts) (*V2SessionlessTransport, error) {
+	if opts == nil {
+		opts = &DialOpts{}
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
 	v2ConnectionOpenAttempts.Inc()
-	t, err := newTransport(addr)
+	stagger := opts.AddressStagger
+	if stagger == 0 {
+		stagger = defaultAddressStagger
+	}
+	timeout := opts.CommandTimeout
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
+	}
+	t, err := newTransport(ctx, addr, stagger, timeout)
 	if err != nil {
 		v2ConnectionOpenFailures.Inc()
 		return nil, err
 	}
 	v2ConnectionsOpen.Inc()
-	return newV2SessionlessTransport(t), nil
+	s := newV2SessionlessTransport(t)
+	if opts.MaxPacketSize > 0 {
+		s.demux.maxPacketSize = opts.MaxPacketSize
+	} else if raddr, ok := t.Address().(*net.UDPAddr); ok {
+		s.demux.maxPacketSize = transport.MaxPayloadSize(raddr)
+	}
+	s.clock = opts.Clock
+	s.rand = opts.Rand
+	s.backoff = s.newBackOff()
+	if opts.CommandTimeout > 0 {
+		s.SetTimeout(opts.CommandTimeout)
+	}
+	s.SetEstablishmentTimeout(opts.EstablishmentTimeout)
+	return s, nil
 }
 
 func newV2SessionlessTransport(t transport.Transport) *V2SessionlessTransport {
+	d := newDemultiplexer(t)
 	return &V2SessionlessTransport{
-		Transport:     t,
-		V2Sessionless: newV2Sessionless(t, time.Second),
+		Transport:     d,
+		V2Sessionless: newV2Sessionless(d, defaultCommandTimeout),
 	}
 }
 
-func newTransport(addr string) (transport.Transport, error) {
-	// default to port 623
-	if !strings.Contains(addr, ":") || strings.HasSuffix(addr, "]") {
-		addr = addr + ":623"
-	}
-	return transport.New(addr)
+// CompletionCodeError is returned by ValidateResponse() for a non-normal
+// completion code. It matches ErrBMCBusy if the code is temporary.
+type CompletionCodeError struct {
+	Code ipmi.CompletionCode
+}
+
+func (e *CompletionCodeError) Error() string {
+	return fmt.Sprintf("received non-normal completion code: %v", e.Code)
+}
+
+func (e *CompletionCodeError) Is(target error) bool {
+	return target == ErrBMCBusy && e.Code.IsTemporary()
 }
 
 // ValidateResponse is a helper to remove some boilerplate error handling from
-// SendCommand() calls. It ensures a non-nil error and normal completion code.
-// If the error is non-nil, it is returned. If the completion code is
-// non-normal, an error is returned containing the actual value.
+// SendCommand() calls. It ensures a nil error and normal completion code. If
+// the completion code is non-normal, a *CompletionCodeError is returned
+// containing the actual value. This takes precedence over a non-nil error, as
+// BMCs usually omit the response body with non-normal codes, so the error is
+// merely a failure to decode it. Otherwise, a non-nil error is returned as-is.
 func ValidateResponse(c ipmi.CompletionCode, err error) error {
-	if err != nil {
-		return err
-	}
 	if c != ipmi.CompletionCodeNormal {
-		return fmt.Errorf("received non-normal completion code: %v", c)
+		return &CompletionCodeError{
+			Code: c,
+		}
 	}
-	return nil
+	return err
 }
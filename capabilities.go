@@ -0,0 +1,127 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// MachineCapabilities describes which optional features of IPMI and DCMI a BMC
+// supports, so generic tools can adapt their behaviour up-front, e.g. hiding
+// power graphs, rather than discovering what is missing one failed command at
+// a time. Features whose discovery command fails are reported as unsupported.
+type MachineCapabilities struct {
+
+	// IPMIVersion is the IPMI version reported in Get Device ID, e.g. "2.0".
+	IPMIVersion string
+
+	// Chassis, Bridge, IPMBEventGenerator, IPMBEventReceiver, FRUInventory,
+	// SEL, SDRRepository and Sensors are the additional device support flags
+	// of Get Device ID. Bridge refers to an ICMB bridge; it does not indicate
+	// whether Send Message can be used to reach satellite controllers.
+	Chassis            bool
+	Bridge             bool
+	IPMBEventGenerator bool
+	IPMBEventReceiver  bool
+	FRUInventory       bool
+	SEL                bool
+	SDRRepository      bool
+	Sensors            bool
+
+	// PowerInterlock, DiagnosticInterrupt, FrontPanelLockout and
+	// IntrusionSensor are the capability flags of Get Chassis Capabilities.
+	// They are all false if the BMC does not support chassis commands.
+	PowerInterlock      bool
+	DiagnosticInterrupt bool
+	FrontPanelLockout   bool
+	IntrusionSensor     bool
+
+	// Channel is the number of the channel being used, as returned by Get
+	// Channel Authentication Capabilities. It is ipmi.ChannelPresentInterface
+	// if the BMC did not respond with its number.
+	Channel ipmi.Channel
+
+	// IPMIv2 indicates whether the channel being used supports IPMI v2.0
+	// sessions, and hence RMCP+ features such as encryption and SOL.
+	IPMIv2 bool
+
+	// SOL indicates whether the BMC supports Serial over LAN on the channel
+	// being used, as determined by the SOL Enable configuration parameter
+	// being retrievable. SOLEnabled indicates whether SOL is currently
+	// enabled; it can be enabled with ApplyConfig().
+	SOL        bool
+	SOLEnabled bool
+
+	// DCMIVersion is the DCMI specification version the BMC conforms to, e.g.
+	// "1.5", or empty if it does not support DCMI.
+	DCMIVersion string
+
+	// DCMIPowerManagement indicates whether the BMC supports the DCMI power
+	// management commands, e.g. Get Power Reading.
+	DCMIPowerManagement bool
+}
+
+// Capabilities discovers which optional features a BMC supports, using Get
+// Device ID, Get Chassis Capabilities, Get Channel Authentication
+// Capabilities, the SOL Enable configuration parameter and Get DCMI
+// Capabilities Info. Only Get Device ID is required to succeed; other commands
+// are allowed to fail, leaving the corresponding features unsupported, unless
+// the context expires, in which case the context error is returned.
+func Capabilities(ctx context.Context, s Session) (*MachineCapabilities, error) {
+	deviceID, err := s.GetDeviceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	caps := &MachineCapabilities{
+		IPMIVersion: fmt.Sprintf("%v.%v", deviceID.MajorIPMIVersion,
+			deviceID.MinorIPMIVersion),
+		Chassis:            deviceID.SupportsChassisDevice,
+		Bridge:             deviceID.SupportsBridgeDevice,
+		IPMBEventGenerator: deviceID.SupportsIPMBEventGeneratorDevice,
+		IPMBEventReceiver:  deviceID.SupportsIPMBEventReceiverDevice,
+		FRUInventory:       deviceID.SupportsFRUInventoryDevice,
+		SEL:                deviceID.SupportsSELDevice,
+		SDRRepository:      deviceID.SupportsSDRRepositoryDevice,
+		Sensors:            deviceID.SupportsSensorDevice,
+		Channel:            ipmi.ChannelPresentInterface,
+	}
+
+	// the remaining commands are optional; we stop only if the context has
+	// expired
+	if chassis, err := s.GetChassisCapabilities(ctx); err == nil {
+		caps.PowerInterlock = chassis.ProvidesPowerInterlock
+		caps.DiagnosticInterrupt = chassis.ProvidesDiagnosticInterrupt
+		caps.FrontPanelLockout = chassis.ProvidesFrontPanelLockout
+		caps.IntrusionSensor = chassis.ProvidesIntrusionSensor
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	auth, err := s.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		})
+	if err == nil {
+		caps.Channel = auth.Channel
+		caps.IPMIv2 = auth.SupportsV2
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if enable, err := getSOLParameter(ctx, s, caps.Channel,
+		ipmi.SOLConfigurationParameterEnable, 1); err == nil {
+		caps.SOL = true
+		caps.SOLEnabled = enable[0]&1 != 0
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if dcmi, err := getSupportedDCMICapabilities(ctx, s); err == nil {
+		caps.DCMIVersion = fmt.Sprintf("%v.%v", dcmi[0], dcmi[1])
+		// optional platform capabilities
+		caps.DCMIPowerManagement = dcmi[4]&1 != 0
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return caps, nil
+}
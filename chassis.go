@@ -0,0 +1,184 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+var (
+	// ErrDiagnosticInterruptUnsupported is returned by DiagnosticInterrupt()
+	// if the chassis cannot deliver a diagnostic interrupt.
+	ErrDiagnosticInterruptUnsupported = errors.New("the chassis does not " +
+		"support diagnostic interrupts")
+
+	// ErrFrontPanelButtonDisableUnsupported is returned by
+	// SetFrontPanelEnables() if asked to disable a button the chassis does not
+	// allow to be disabled.
+	ErrFrontPanelButtonDisableUnsupported = errors.New("the chassis does " +
+		"not allow disabling a requested front panel button")
+
+	// ErrFrontPanelLockoutNotApplied is returned by LockFrontPanel() if the
+	// BMC accepted the change to the front panel buttons, but Get Chassis
+	// Status does not reflect it.
+	ErrFrontPanelLockoutNotApplied = errors.New("the front panel buttons " +
+		"did not change state as requested")
+)
+
+// SupportsDiagnosticInterrupt returns whether the chassis can deliver a
+// diagnostic interrupt (NMI on x86) to the CPU(s), according to Get Chassis
+// Capabilities.
+func SupportsDiagnosticInterrupt(ctx context.Context, s Session) (bool, error) {
+	caps, err := s.GetChassisCapabilities(ctx)
+	if err != nil {
+		return false, err
+	}
+	return caps.ProvidesDiagnosticInterrupt, nil
+}
+
+// DiagnosticInterrupt pulses a diagnostic interrupt to the CPU(s), usually
+// causing the OS to panic and write a crash dump. Unlike sending
+// ipmi.ChassisControlDiagnosticInterrupt directly, which a BMC may accept even
+// if it cannot deliver the interrupt, this first checks the chassis supports
+// it, returning ErrDiagnosticInterruptUnsupported if not.
+func DiagnosticInterrupt(ctx context.Context, s Session) error {
+	supported, err := SupportsDiagnosticInterrupt(ctx, s)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return ErrDiagnosticInterruptUnsupported
+	}
+	return s.ChassisControl(ctx, ipmi.ChassisControlDiagnosticInterrupt)
+}
+
+// SetBootFlags sets the boot flags system boot option, directing the BIOS to
+// boot from a device on the next boot, or every boot if flags.Persistent is
+// set. flags.Valid is set automatically. Most BMCs clear the flags if the
+// system is not restarted within 60 seconds, so this is normally followed by a
+// power on or power cycle.
+func SetBootFlags(ctx context.Context, s Session, flags *ipmi.BootFlags) error {
+	data := *flags
+	data.Valid = true
+	cmd := &ipmi.SetSystemBootOptionsCmd{
+		Req: ipmi.SetSystemBootOptionsReq{
+			Parameter: ipmi.BootOptionParameterBootFlags,
+			Data:      &data,
+		},
+	}
+	return ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+// GetBootFlags retrieves the boot flags system boot option. If Valid is false
+// in the returned flags, the BIOS will use its configured boot order.
+func GetBootFlags(ctx context.Context, s Session) (*ipmi.BootFlags, error) {
+	cmd := &ipmi.GetSystemBootOptionsCmd{
+		Req: ipmi.GetSystemBootOptionsReq{
+			Parameter: ipmi.BootOptionParameterBootFlags,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+
+	// the response payload belongs to the session, so must be copied
+	flags := &ipmi.BootFlags{}
+	data := append([]byte(nil), cmd.Rsp.Payload...)
+	if err := flags.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// SetFrontPanelEnables enables or disables the chassis' front panel buttons,
+// e.g. to stop a machine being powered off locally. Buttons whose Disable
+// field is false are enabled. As BMCs may silently ignore buttons that cannot
+// be disabled, this first checks each requested button is allowed to be
+// disabled according to Get Chassis Status, returning
+// ErrFrontPanelButtonDisableUnsupported if not. The current state of the
+// buttons is also returned by Get Chassis Status.
+func SetFrontPanelEnables(ctx context.Context, s Session, req *ipmi.SetFrontPanelEnablesReq) error {
+	status, err := s.GetChassisStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if req.DisableStandbyButton && !status.StandbyButtonDisableAllowed ||
+		req.DisableDiagnosticInterruptButton &&
+			!status.DiagnosticInterruptButtonDisableAllowed ||
+		req.DisableResetButton && !status.ResetButtonDisableAllowed ||
+		req.DisablePowerOffButton && !status.PowerOffButtonDisableAllowed {
+		return ErrFrontPanelButtonDisableUnsupported
+	}
+	cmd := &ipmi.SetFrontPanelEnablesCmd{
+		Req: *req,
+	}
+	return ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+// LockFrontPanel disables the chassis' power off and reset buttons if lock is
+// true, or enables them if false, leaving the standby and diagnostic interrupt
+// buttons as they are. It is intended for environments where physical controls
+// must be locked for compliance, so is guarded on both sides: locking returns
+// ErrFrontPanelButtonDisableUnsupported unless Get Chassis Capabilities
+// advertises front panel lockout and Get Chassis Status allows both buttons to
+// be disabled, and as some BMCs accept Set Front Panel Enables without
+// effect, the button state is read back afterwards, returning
+// ErrFrontPanelLockoutNotApplied if it differs. A chassis that supports
+// lockout without advertising it can be corrected with Set Chassis
+// Capabilities.
+func LockFrontPanel(ctx context.Context, s Session, lock bool) error {
+	if lock {
+		caps, err := s.GetChassisCapabilities(ctx)
+		if err != nil {
+			return err
+		}
+		if !caps.ProvidesFrontPanelLockout {
+			return ErrFrontPanelButtonDisableUnsupported
+		}
+	}
+	status, err := s.GetChassisStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if err := SetFrontPanelEnables(ctx, s, &ipmi.SetFrontPanelEnablesReq{
+		DisableStandbyButton:             status.StandbyButtonDisabled,
+		DisableDiagnosticInterruptButton: status.DiagnosticInterruptButtonDisabled,
+		DisableResetButton:               lock,
+		DisablePowerOffButton:            lock,
+	}); err != nil {
+		return err
+	}
+	status, err = s.GetChassisStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.ResetButtonDisabled != lock || status.PowerOffButtonDisabled != lock {
+		return ErrFrontPanelLockoutNotApplied
+	}
+	return nil
+}
+
+// Identify causes the chassis to physically identify itself, typically by
+// blinking a front-panel light, so it can be located in a datacenter. It stops
+// after d, rounded up to the nearest second; a d of 0 stops identifying
+// immediately, and a negative d identifies until stopped, which not all BMCs
+// support. The longest finite interval is 255 seconds.
+func Identify(ctx context.Context, s Session, d time.Duration) error {
+	cmd := &ipmi.ChassisIdentifyCmd{}
+	switch seconds := math.Ceil(d.Seconds()); {
+	case d < 0:
+		cmd.Req.Force = true
+	case seconds > math.MaxUint8:
+		return fmt.Errorf("identify interval must be at most %v seconds, "+
+			"got %v", math.MaxUint8, d)
+	default:
+		cmd.Req.Interval = uint8(seconds)
+	}
+	return ValidateResponse(s.SendCommand(ctx, cmd))
+}
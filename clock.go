@@ -0,0 +1,43 @@
+package bmc
+
+import (
+	"crypto/rand"
+	"io"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Clock provides the current time. It is set via DialOpts, allowing tests to
+// control the timestamps the library records, and how long it considers
+// retransmission to have taken. It is compatible with backoff.Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// now returns the current time according to the connection's clock.
+func (s *v2ConnectionShared) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// random returns the source of the connection's random numbers.
+func (s *v2ConnectionShared) random() io.Reader {
+	if s.rand == nil {
+		return rand.Reader
+	}
+	return s.rand
+}
+
+// newBackOff returns an exponential backoff using the connection's clock to
+// measure elapsed time.
+func (s *v2ConnectionShared) newBackOff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	if s.clock != nil {
+		b.Clock = s.clock
+		b.Reset()
+	}
+	return b
+}
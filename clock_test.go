@@ -0,0 +1,61 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// fixedClock is a Clock that never advances.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestDialClock(t *testing.T) {
+	simBMC, err := sim.New(&sim.Config{
+		Username: "admin",
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simBMC.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	machine, err := DialV2Context(ctx, simBMC.Addr(), &DialOpts{
+		Clock: fixedClock(now),
+		Rand:  bytes.NewReader(make([]byte, 16)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer machine.Close()
+
+	sink := &recordingAuditSink{}
+	sess, err := machine.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter2"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		AuditSink:         sink,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() failed: %v", err)
+	}
+	defer sess.Close(ctx)
+
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOn); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	if len(sink.records) != 1 || !sink.records[0].Time.Equal(now) {
+		t.Errorf("audit records = %+v, want 1 at %v", sink.records, now)
+	}
+}
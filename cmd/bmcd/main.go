@@ -0,0 +1,560 @@
+package main
+
+// bmcd is an HTTP daemon exposing the power state, sensors and SEL of BMCs as
+// JSON, so systems not written in Go can drive them through one process that
+// holds the credentials. Sessions are pooled: requests for the same BMC share
+// one session, which matters as BMCs support very few concurrently.
+//
+//	GET  /bmcs/{addr}/power    {"poweredOn": true}
+//	POST /bmcs/{addr}/power    {"action": "on"}; on/off/cycle/reset/interrupt/softoff
+//	GET  /bmcs/{addr}/sensors  [{"name": "CPU Temp", "number": 11, "value": 45, "unit": "C"}, ...]
+//	GET  /bmcs/{addr}/sel      [{"id": 1, "type": 2, "sensorType": "Processor", ...}, ...]
+//	GET  /metrics              Prometheus metrics, including those of the library.
+//
+// addr is IP[:port] of the BMC. The same credentials are used for every BMC.
+// Requests can be required to carry a bearer token, and BMCs restricted to
+// certain networks, so the daemon cannot be used to reach arbitrary hosts.
+// Errors are returned as {"error": "..."}.
+//
+// Each request is identified by its X-Request-Id header, or a random ID if
+// absent, which is echoed in the response. Power actions are logged with the
+// ID and, if --user-header is set, the user the request was made for, as
+// asserted by an authenticating proxy in front of the daemon.
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "bmcd"
+
+	// sdrCacheTTL is how long a BMC's SDR Repository is cached. It rarely
+	// changes, but takes many commands to retrieve.
+	sdrCacheTTL = time.Minute * 10
+
+	// shutdownTimeout bounds waiting for in-flight requests to complete and
+	// sessions to be closed on exit.
+	shutdownTimeout = time.Second * 10
+)
+
+var (
+	flgListen = kingpin.Flag("listen", "The address to serve HTTP on.").
+			Default("localhost:8080").
+			String()
+	flgTokenFile = kingpin.Flag("token-file", "If set, requests must include this file's contents as a bearer token.").
+			ExistingFile()
+	flgAllowedNetworks = kingpin.Flag("allowed-network", "If set, only BMCs within these CIDR ranges can be accessed. Repeatable.").
+				Strings()
+	flgIdleTimeout = kingpin.Flag("idle-timeout", "How long a session may go unused before it is closed.").
+			Default("30s").
+			Duration()
+	flgUserHeader = kingpin.Flag("user-header", "If set, the request header containing the user a request is made for, e.g. as set by an authenticating proxy. It is recorded, not used for authorisation.").
+			String()
+
+	// the timeout applies to each HTTP request
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Second*10)
+
+	httpRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "The number of API requests served, by endpoint and status code.",
+		},
+		[]string{"endpoint", "code"},
+	)
+	httpRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "The time taken to serve API requests, by endpoint.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"endpoint"},
+	)
+
+	cmdControls = map[string]ipmi.ChassisControl{
+		"off":       ipmi.ChassisControlPowerOff,
+		"on":        ipmi.ChassisControlPowerOn,
+		"cycle":     ipmi.ChassisControlPowerCycle,
+		"reset":     ipmi.ChassisControlHardReset,
+		"interrupt": ipmi.ChassisControlDiagnosticInterrupt,
+		"softoff":   ipmi.ChassisControlSoftPowerOff,
+	}
+)
+
+// authenticator is a hook deciding whether a request may proceed, returning
+// an error describing why not. Authenticators are run in order before every
+// API request; further mechanisms, e.g. checking a client certificate, can be
+// added to the server's list.
+type authenticator func(*http.Request) error
+
+// bearerToken returns an authenticator requiring the Authorization header to
+// contain the token.
+func bearerToken(token string) authenticator {
+	want := []byte("Bearer " + token)
+	return func(r *http.Request) error {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			return errors.New("missing or invalid bearer token")
+		}
+		return nil
+	}
+}
+
+// addrKey is the context key of the BMC address of a request.
+type addrKey struct{}
+
+// server serves the API.
+type server struct {
+	pool           *bmc.Pool
+	authenticators []authenticator
+
+	// networks contains the ranges BMCs must be within. If empty, any BMC can
+	// be accessed.
+	networks []netip.Prefix
+
+	// endpoints maps the final path element to its handler.
+	endpoints map[string]http.Handler
+
+	sdrsMu sync.Mutex
+	sdrs   map[string]*cachedSDRRepository
+}
+
+// cachedSDRRepository is a retrieved SDR Repository.
+type cachedSDRRepository struct {
+	repo      bmc.SDRRepository
+	retrieved time.Time
+}
+
+func main() {
+	kingpin.Parse()
+
+	srv := &server{
+		pool: &bmc.Pool{
+			SessionOpts: func(string) (*bmc.V2SessionOpts, error) {
+				return flags.SessionOpts()
+			},
+			DialOpts: &bmc.DialOpts{
+				Timeout: flags.Timeout,
+			},
+			IdleTimeout: *flgIdleTimeout,
+		},
+		sdrs: map[string]*cachedSDRRepository{},
+	}
+	if _, err := flags.SessionOpts(); err != nil {
+		kingpin.Fatalf("%v", err)
+	}
+	if *flgTokenFile != "" {
+		token, err := os.ReadFile(*flgTokenFile)
+		if err != nil {
+			kingpin.Fatalf("%v", err)
+		}
+		srv.authenticators = append(srv.authenticators,
+			bearerToken(strings.TrimSpace(string(token))))
+	}
+	for _, cidr := range *flgAllowedNetworks {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			kingpin.Fatalf("%v", err)
+		}
+		srv.networks = append(srv.networks, prefix.Masked())
+	}
+	srv.endpoints = map[string]http.Handler{
+		"power":   instrument("power", srv.power),
+		"sensors": instrument("sensors", srv.sensors),
+		"sel":     instrument("sel", srv.sel),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/bmcs/", srv)
+	mux.Handle("/metrics", promhttp.Handler())
+	httpSrv := &http.Server{
+		Addr:              *flgListen,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second * 10,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(),
+			shutdownTimeout)
+		defer cancel()
+		if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shut down cleanly: %v", err)
+		}
+	}()
+
+	log.Printf("listening on %v", *flgListen)
+	if err := httpSrv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	<-shutdown
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.pool.Close(ctx); err != nil {
+		log.Printf("failed to close sessions: %v", err)
+	}
+}
+
+// instrument wraps an endpoint's handler with request metrics.
+func instrument(endpoint string, h http.HandlerFunc) http.Handler {
+	labels := prometheus.Labels{"endpoint": endpoint}
+	return promhttp.InstrumentHandlerDuration(
+		httpRequestDuration.MustCurryWith(labels),
+		promhttp.InstrumentHandlerCounter(
+			httpRequests.MustCurryWith(labels), h))
+}
+
+// ServeHTTP authenticates an API request, checks its BMC may be accessed,
+// then dispatches it to its endpoint.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, authenticate := range s.authenticators {
+		if err := authenticate(r); err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	// /bmcs/{addr}/{endpoint}
+	path := strings.TrimPrefix(r.URL.Path, "/bmcs/")
+	i := strings.LastIndex(path, "/")
+	if i < 1 {
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	addr, endpoint := path[:i], path[i+1:]
+	h, ok := s.endpoints[endpoint]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	if err := s.checkAddr(addr); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	metadata := bmc.RequestMetadata{
+		TraceID: r.Header.Get("X-Request-Id"),
+	}
+	if metadata.TraceID == "" {
+		metadata.TraceID = newRequestID()
+	}
+	if *flgUserHeader != "" {
+		metadata.User = r.Header.Get(*flgUserHeader)
+	}
+	w.Header().Set("X-Request-Id", metadata.TraceID)
+	ctx := bmc.WithRequestMetadata(r.Context(), metadata)
+	h.ServeHTTP(w, r.WithContext(context.WithValue(ctx, addrKey{}, addr)))
+}
+
+// newRequestID returns a random ID for a request that did not carry one.
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		// the request can still be served, just not correlated
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// logAction logs the outcome of a request changing the state of a BMC, and
+// who it was made for.
+func logAction(r *http.Request, action string, err error) {
+	metadata, _ := bmc.RequestMetadataFromContext(r.Context())
+	outcome := "succeeded"
+	if err != nil {
+		outcome = fmt.Sprintf("failed: %v", err)
+	}
+	log.Printf("%v of %v for user %q (request %v) %v", action,
+		r.Context().Value(addrKey{}), metadata.User, metadata.TraceID, outcome)
+}
+
+// checkAddr returns an error if the BMC at addr may not be accessed.
+func (s *server) checkAddr(addr string) error {
+	if len(s.networks) == 0 {
+		return nil
+	}
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%v is not an IP address", host)
+	}
+	for _, network := range s.networks {
+		if network.Contains(ip.Unmap()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v is not within an allowed network", ip)
+}
+
+// do calls f with a pooled session for the BMC of the request, bounded by
+// --timeout.
+func (s *server) do(r *http.Request, f func(context.Context, bmc.Session) error) error {
+	ctx, cancel := context.WithTimeout(r.Context(), flags.Timeout)
+	defer cancel()
+	return s.pool.Do(ctx, r.Context().Value(addrKey{}).(string), f)
+}
+
+func (s *server) power(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		poweredOn := false
+		if err := s.do(r, func(ctx context.Context, sess bmc.Session) error {
+			status, err := sess.GetChassisStatus(ctx)
+			if err != nil {
+				return err
+			}
+			poweredOn = status.PoweredOn
+			return nil
+		}); err != nil {
+			writeBMCError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]bool{
+			"poweredOn": poweredOn,
+		})
+	case http.MethodPost:
+		body := struct {
+			Action string `json:"action"`
+		}{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).
+			Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		ctrl, ok := cmdControls[body.Action]
+		if !ok {
+			writeError(w, http.StatusBadRequest,
+				fmt.Errorf("invalid action %q", body.Action))
+			return
+		}
+		err := s.do(r, func(ctx context.Context, sess bmc.Session) error {
+			return sess.ChassisControl(ctx, ctrl)
+		})
+		logAction(r, fmt.Sprintf("power %v", body.Action), err)
+		if err != nil {
+			writeBMCError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, body)
+	default:
+		writeMethodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+// sensor is a sensor reading in the API.
+type sensor struct {
+	Name   string   `json:"name"`
+	Number uint8    `json:"number"`
+	Value  *float64 `json:"value,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+
+	// Error is set if the sensor could not be read, e.g. because it is not
+	// analog, or the system is off.
+	Error string `json:"error,omitempty"`
+}
+
+func (s *server) sensors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	addr := r.Context().Value(addrKey{}).(string)
+	sensors := []sensor{}
+	if err := s.do(r, func(ctx context.Context, sess bmc.Session) error {
+		repo, err := s.sdrRepository(ctx, addr, sess)
+		if err != nil {
+			return err
+		}
+		recordIDs := make([]ipmi.RecordID, 0, len(repo))
+		for recordID := range repo {
+			recordIDs = append(recordIDs, recordID)
+		}
+		sort.Slice(recordIDs, func(i, j int) bool {
+			return recordIDs[i] < recordIDs[j]
+		})
+		for _, recordID := range recordIDs {
+			fsr := repo[recordID]
+			result := sensor{
+				Name:   fsr.Identity,
+				Number: fsr.Number,
+			}
+			reader, err := bmc.NewSensorReader(fsr)
+			if err != nil {
+				result.Error = err.Error()
+				sensors = append(sensors, result)
+				continue
+			}
+			value, err := reader.Read(ctx, sess)
+			switch {
+			case err == nil:
+				result.Value = &value
+				result.Unit = fsr.BaseUnit.Symbol()
+			case ctx.Err() != nil:
+				// fail the request, and discard the session
+				return err
+			default:
+				result.Error = err.Error()
+			}
+			sensors = append(sensors, result)
+		}
+		return nil
+	}); err != nil {
+		writeBMCError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sensors)
+}
+
+// sdrRepository returns the SDR Repository of the BMC at addr, retrieving it if
+// it is not cached.
+func (s *server) sdrRepository(ctx context.Context, addr string, sess bmc.Session) (bmc.SDRRepository, error) {
+	s.sdrsMu.Lock()
+	cached, ok := s.sdrs[addr]
+	s.sdrsMu.Unlock()
+	if ok && time.Since(cached.retrieved) < sdrCacheTTL {
+		return cached.repo, nil
+	}
+
+	repo, err := bmc.RetrieveSDRRepository(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	s.sdrsMu.Lock()
+	s.sdrs[addr] = &cachedSDRRepository{
+		repo:      repo,
+		retrieved: time.Now(),
+	}
+	s.sdrsMu.Unlock()
+	return repo, nil
+}
+
+// selRecord is a SEL record in the API. Only system event records have the
+// event fields set.
+type selRecord struct {
+	ID           ipmi.RecordID `json:"id"`
+	Type         uint8         `json:"type"`
+	Timestamp    *time.Time    `json:"timestamp,omitempty"`
+	GeneratorID  uint16        `json:"generatorId,omitempty"`
+	SensorType   string        `json:"sensorType,omitempty"`
+	SensorNumber uint8         `json:"sensorNumber,omitempty"`
+	Deassertion  bool          `json:"deassertion,omitempty"`
+	EventType    uint8         `json:"eventType,omitempty"`
+	EventData    string        `json:"eventData,omitempty"`
+	Manufacturer string        `json:"manufacturer,omitempty"`
+	OEMData      string        `json:"oemData,omitempty"`
+}
+
+func (s *server) sel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	records := []*ipmi.SELEventRecord(nil)
+	if err := s.do(r, func(ctx context.Context, sess bmc.Session) error {
+		var err error
+		records, err = bmc.RetrieveSEL(ctx, sess)
+		return err
+	}); err != nil {
+		writeBMCError(w, err)
+		return
+	}
+
+	rsp := make([]selRecord, 0, len(records))
+	for _, record := range records {
+		rec := selRecord{
+			ID:   record.ID,
+			Type: record.Type,
+		}
+		if !record.Timestamp.IsZero() {
+			rec.Timestamp = &record.Timestamp
+		}
+		if record.Type == ipmi.SELRecordTypeSystemEvent {
+			rec.GeneratorID = record.GeneratorID
+			rec.SensorType = record.SensorType.Description()
+			rec.SensorNumber = record.SensorNumber
+			rec.Deassertion = record.Deassertion
+			rec.EventType = record.EventType
+			rec.EventData = hex.EncodeToString(record.EventData[:])
+		} else {
+			if record.Manufacturer != 0 {
+				rec.Manufacturer = record.Manufacturer.String()
+			}
+			rec.OEMData = hex.EncodeToString(record.OEMData)
+		}
+		rsp = append(rsp, rec)
+	}
+	writeJSON(w, http.StatusOK, rsp)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{
+		"error": err.Error(),
+	})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+}
+
+// writeBMCError writes an error that occurred communicating with a BMC. As the
+// daemon is a gateway, these are 5xx errors regardless of their cause.
+func writeBMCError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, err)
+	case errors.Is(err, bmc.ErrPoolClosed):
+		writeError(w, http.StatusServiceUnavailable, err)
+	default:
+		writeError(w, http.StatusBadGateway, err)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+// Boot sets the device a system boots from, and optionally power cycles it so
+// the setting takes effect, e.g. to PXE boot a machine for provisioning. With
+// --wait-for-off, the cycle is performed as a hard power off followed by a
+// power on, so the off state can be confirmed; a single power cycle command
+// may turn the chassis off too briefly to observe.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC to control.").
+			Required().
+			String()
+	argDevice = kingpin.Arg("device", "The device to boot from (pxe/disk/safe/diag/cdrom/bios/none).").
+			Required().
+			Enum("pxe", "disk", "safe", "diag", "cdrom", "bios", "none")
+	flgMode = kingpin.Flag("mode", "The BIOS boot type (uefi/legacy).").
+		Default("uefi").
+		Enum("uefi", "legacy")
+	flgPersistent = kingpin.Flag("persistent", "Apply to all future boots, rather than only the next.").
+			Bool()
+	flgCycle = kingpin.Flag("cycle", "Power cycle the system after setting the boot device, or power it on if off.").
+			Bool()
+	flgWaitForOff = kingpin.Flag("wait-for-off", "When cycling, confirm the system powered off before powering it on.").
+			Bool()
+	flgWaitForOn = kingpin.Flag("wait-for-on", "When cycling, confirm the system powered on.").
+			Bool()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Minute*2)
+
+	argDevices = map[string]ipmi.BootDevice{
+		"pxe":   ipmi.BootDevicePXE,
+		"disk":  ipmi.BootDeviceDisk,
+		"safe":  ipmi.BootDeviceDiskSafeMode,
+		"diag":  ipmi.BootDeviceDiagnosticPartition,
+		"cdrom": ipmi.BootDeviceCDROM,
+		"bios":  ipmi.BootDeviceBIOSSetup,
+		"none":  ipmi.BootDeviceNone,
+	}
+)
+
+func main() {
+	kingpin.Parse()
+
+	if (*flgWaitForOff || *flgWaitForOn) && !*flgCycle {
+		kingpin.Fatalf("--wait-for-off and --wait-for-on require --cycle")
+	}
+
+	sess, closeSess, err := flags.Connect(context.Background(), *argBMCAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeSess()
+
+	ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
+	defer cancel()
+
+	if err := run(ctx, sess); err != nil {
+		closeSess()
+		log.Fatal(err)
+	}
+}
+
+// run sets the boot device, then cycles the system if requested.
+func run(ctx context.Context, sess bmc.Session) error {
+	bootFlags := &ipmi.BootFlags{
+		Persistent: *flgPersistent,
+		EFI:        *flgMode == "uefi",
+		Device:     argDevices[*argDevice],
+	}
+	if err := bmc.SetBootFlags(ctx, sess, bootFlags); err != nil {
+		return err
+	}
+	log.Printf("set boot device to %v", bootFlags.Device.Description())
+
+	if *flgCycle {
+		return cycle(ctx, sess)
+	}
+	return nil
+}
+
+// cycle power cycles the system, or powers it on if it is off, confirming each
+// transition as requested by the flags.
+func cycle(ctx context.Context, sess bmc.Session) error {
+	opts := &bmc.PowerOpts{
+		// the boot device is only honoured for a short time, so there is no
+		// point waiting for the OS to shut down cleanly
+		SkipSoftOff: true,
+	}
+	if *flgWaitForOff {
+		transitions, err := bmc.EnsurePowerState(ctx, sess, bmc.PowerStateOff, opts)
+		logTransitions(transitions)
+		if err != nil {
+			return err
+		}
+		if !*flgWaitForOn {
+			return sess.ChassisControl(ctx, ipmi.ChassisControlPowerOn)
+		}
+	} else {
+		status, err := sess.GetChassisStatus(ctx)
+		if err != nil {
+			return err
+		}
+		// a power cycle is a no-op if the chassis is off
+		control := ipmi.ChassisControlPowerCycle
+		if !status.PoweredOn {
+			control = ipmi.ChassisControlPowerOn
+		}
+		if err := sess.ChassisControl(ctx, control); err != nil {
+			return err
+		}
+		log.Print(control.Description())
+		if !*flgWaitForOn {
+			return nil
+		}
+	}
+	transitions, err := bmc.EnsurePowerState(ctx, sess, bmc.PowerStateOn, opts)
+	logTransitions(transitions)
+	if err != nil {
+		return fmt.Errorf("system did not power on: %w", err)
+	}
+	log.Print("confirmed powered on")
+	return nil
+}
+
+func logTransitions(transitions []bmc.PowerTransition) {
+	for _, transition := range transitions {
+		log.Print(transition)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 
 	"github.com/alecthomas/kingpin"
@@ -22,12 +23,9 @@ var (
 	argCommand = kingpin.Arg("command", "The command to send (on/off/cycle/reset/interrupt/softoff).").
 			Required().
 			String()
-	flgUsername = kingpin.Flag("username", "The username to connect as.").
-			Required().
-			String()
-	flgPassword = kingpin.Flag("password", "The password of the user to connect as.").
-			Required().
-			String()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Second*5)
 
 	cmdControls = map[string]ipmi.ChassisControl{
 		"off":       ipmi.ChassisControlPowerOff,
@@ -49,32 +47,28 @@ func lookupCommand(cmd string) (ipmi.ChassisControl, error) {
 func main() {
 	kingpin.Parse()
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-
-	machine, err := bmc.Dial(ctx, *argBMCAddr)
+	cmd, err := lookupCommand(*argCommand)
 	if err != nil {
-		log.Fatal(err)
+		kingpin.Fatalf("%v", err)
 	}
-	defer machine.Close()
-
-	log.Printf("connected to %v over IPMI v%v", machine.Address(), machine.Version())
 
-	sess, err := machine.NewSession(ctx, &bmc.SessionOpts{
-		Username:          *flgUsername,
-		Password:          []byte(*flgPassword),
-		MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
-	})
+	sess, closeSess, err := flags.Connect(context.Background(), *argBMCAddr)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer sess.Close(ctx)
+	defer closeSess()
 
-	cmd, err := lookupCommand(*argCommand)
-	if err != nil {
-		log.Fatal(err)
+	ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
+	defer cancel()
+
+	if cmd == ipmi.ChassisControlDiagnosticInterrupt {
+		// fail cleanly rather than silently doing nothing
+		err = bmc.DiagnosticInterrupt(ctx, sess)
+	} else {
+		err = sess.ChassisControl(ctx, cmd)
 	}
-	if err := sess.ChassisControl(ctx, cmd); err != nil {
+	if err != nil {
+		closeSess()
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,132 @@
+package main
+
+// Config backs up and restores BMC configuration as JSON. export writes the
+// current configuration of a BMC; diff shows what apply would change to make a
+// BMC match a file; apply makes those changes. Files need only contain the
+// settings to manage, so the same tool can push a partial configuration to a
+// fleet. Passwords are never exported, but can be added to a file to be set.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	flags = clilib.Register(kingpin.CommandLine,
+		ipmi.PrivilegeLevelAdministrator, time.Second*30)
+
+	cmdExport     = kingpin.Command("export", "Print the configuration of the BMC as JSON.")
+	argExportAddr = cmdExport.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+
+	cmdDiff     = kingpin.Command("diff", "Print the changes needed to make the BMC match a file.")
+	argDiffAddr = cmdDiff.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	argDiffFile = cmdDiff.Arg("file", "The desired configuration, as written by export.").
+			Required().
+			ExistingFile()
+
+	cmdApply     = kingpin.Command("apply", "Change the BMC to match a file, printing each change.")
+	argApplyAddr = cmdApply.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	argApplyFile = cmdApply.Arg("file", "The desired configuration, as written by export.").
+			Required().
+			ExistingFile()
+)
+
+func main() {
+	command := kingpin.Parse()
+
+	// read the file before connecting, so mistakes fail fast
+	path := map[string]string{
+		cmdDiff.FullCommand():  *argDiffFile,
+		cmdApply.FullCommand(): *argApplyFile,
+	}[command]
+	config := (*bmc.Config)(nil)
+	if path != "" {
+		var err error
+		if config, err = readConfig(path); err != nil {
+			kingpin.Fatalf("%v", err)
+		}
+	}
+
+	if err := run(command, config); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readConfig(path string) (*bmc.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &bmc.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return config, nil
+}
+
+// run executes the command, returning once the session is closed.
+func run(command string, config *bmc.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	addr := map[string]string{
+		cmdExport.FullCommand(): *argExportAddr,
+		cmdDiff.FullCommand():   *argDiffAddr,
+		cmdApply.FullCommand():  *argApplyAddr,
+	}[command]
+
+	sess, closeSess, err := flags.Connect(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer closeSess()
+
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	switch command {
+	case cmdExport.FullCommand():
+		config, err := bmc.ExportConfig(ctx, sess)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(config)
+	case cmdDiff.FullCommand():
+		changes, err := bmc.DiffConfig(ctx, sess, config)
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		return nil
+	default:
+		changes, err := bmc.ApplyConfig(ctx, sess, config)
+		// print what was changed even if a later change failed
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		return err
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+// DCMI reads the power consumption of a system, and gets, sets, activates and
+// deactivates its power limit, e.g. to validate capping behaviour. With
+// --watch, a reading is printed at each interval until interrupted. With
+// --period, the BMC's rolling statistics over that period are requested
+// (DCMI v1.5 enhanced system power statistics); the period must be one the BMC
+// supports, which are listed if it is rejected.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/dcmi"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Second*10)
+
+	cmdReading     = kingpin.Command("reading", "Print the power consumption of the system.")
+	argReadingAddr = cmdReading.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	flgReadingPeriod = cmdReading.Flag("period", "Request rolling statistics over this period, which must be supported by the BMC.").
+				Duration()
+	flgReadingWatch = cmdReading.Flag("watch", "If non-zero, print a reading at this interval until interrupted.").
+			Duration()
+
+	cmdLimit     = kingpin.Command("limit", "Print the power limit, and whether it is active.")
+	argLimitAddr = cmdLimit.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+
+	cmdSetLimit     = kingpin.Command("set-limit", "Set the power limit. This does not activate it unless --activate is passed.")
+	argSetLimitAddr = cmdSetLimit.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	flgSetLimitWatts = cmdSetLimit.Flag("watts", "The maximum power consumption of the system.").
+				Required().
+				Uint16()
+	flgSetLimitAction = cmdSetLimit.Flag("action", "What to do if the limit cannot be maintained (none/off/log).").
+				Default("log").
+				Enum("none", "off", "log")
+	flgSetLimitCorrectionTime = cmdSetLimit.Flag("correction-time", "The time the limit may be exceeded before the action is taken.").
+					Default("1s").
+					Duration()
+	flgSetLimitSamplingPeriod = cmdSetLimit.Flag("sampling-period", "The period over which power is averaged to check the limit.").
+					Default("1s").
+					Duration()
+	flgSetLimitActivate = cmdSetLimit.Flag("activate", "Activate the limit after setting it.").
+				Bool()
+
+	cmdActivate     = kingpin.Command("activate", "Start enforcing the power limit.")
+	argActivateAddr = cmdActivate.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+
+	cmdDeactivate     = kingpin.Command("deactivate", "Stop enforcing the power limit.")
+	argDeactivateAddr = cmdDeactivate.Arg("addr", "IP[:port] of the BMC.").
+				Required().
+				String()
+
+	argActions = map[string]dcmi.ExceptionAction{
+		"none": dcmi.ExceptionActionNone,
+		"off":  dcmi.ExceptionActionHardPowerOff,
+		"log":  dcmi.ExceptionActionLogEvent,
+	}
+)
+
+func main() {
+	if err := run(kingpin.Parse()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes the command, returning once the session is closed.
+func run(command string) error {
+	// watching stops on interrupt; the session is still closed cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	addr := map[string]string{
+		cmdReading.FullCommand():    *argReadingAddr,
+		cmdLimit.FullCommand():      *argLimitAddr,
+		cmdSetLimit.FullCommand():   *argSetLimitAddr,
+		cmdActivate.FullCommand():   *argActivateAddr,
+		cmdDeactivate.FullCommand(): *argDeactivateAddr,
+	}[command]
+
+	sess, closeSess, err := flags.Connect(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer closeSess()
+
+	commander := dcmi.NewSessionCommander(sess)
+	switch command {
+	case cmdReading.FullCommand():
+		return reading(ctx, commander)
+	case cmdLimit.FullCommand():
+		return limit(ctx, commander)
+	case cmdSetLimit.FullCommand():
+		return setLimit(ctx, commander)
+	case cmdActivate.FullCommand():
+		return activate(ctx, commander, true)
+	default:
+		return activate(ctx, commander, false)
+	}
+}
+
+func reading(ctx context.Context, commander dcmi.SessionCommands) error {
+	req := &dcmi.GetPowerReadingReq{
+		Mode: dcmi.SystemPowerStatisticsModeNormal,
+	}
+	if *flgReadingPeriod > 0 {
+		req.Mode = dcmi.SystemPowerStatisticsModeEnhanced
+		req.Period = *flgReadingPeriod
+	}
+	get := func(ctx context.Context) (*dcmi.GetPowerReadingRsp, error) {
+		ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+		defer cancel()
+		rsp, err := commander.GetPowerReading(ctx, req)
+		if err != nil && req.Mode == dcmi.SystemPowerStatisticsModeEnhanced {
+			return nil, periodError(ctx, commander, err)
+		}
+		return rsp, err
+	}
+
+	if *flgReadingWatch <= 0 {
+		rsp, err := get(ctx)
+		if err != nil {
+			return err
+		}
+		printReading(rsp)
+		return nil
+	}
+
+	// a failed reading is not fatal when watching, but fail fast if the
+	// request is invalid
+	rsp, err := get(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-25v %6v %6v %6v %6v  %v\n", "TIMESTAMP", "INST", "MIN",
+		"AVG", "MAX", "PERIOD")
+	printReadingLine(rsp)
+	ticker := time.NewTicker(*flgReadingWatch)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			rsp, err := get(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("failed to get reading: %v", err)
+				}
+				continue
+			}
+			printReadingLine(rsp)
+		}
+	}
+}
+
+// periodError adds the rolling average periods supported by the BMC to an
+// error getting an enhanced power reading, as the most likely cause is an
+// unsupported period.
+func periodError(ctx context.Context, commander dcmi.SessionCommands, err error) error {
+	attrs, attrsErr := commander.GetDCMICapabilitiesInfoEnhancedSystemPowerStatisticsAttrs(ctx)
+	if attrsErr != nil {
+		return fmt.Errorf("%w (could not retrieve supported periods: %v)",
+			err, attrsErr)
+	}
+	return fmt.Errorf("%w (supported periods: %v)", err,
+		attrs.PowerRollingAvgTimePeriods)
+}
+
+func printReading(r *dcmi.GetPowerReadingRsp) {
+	fmt.Printf("Instantaneous: %v W\n", r.Instantaneous)
+	fmt.Printf("Minimum:       %v W\n", r.Min)
+	fmt.Printf("Average:       %v W\n", r.Avg)
+	fmt.Printf("Maximum:       %v W\n", r.Max)
+	fmt.Printf("Period:        %v\n", r.Period)
+	fmt.Printf("As of:         %v\n", r.Timestamp)
+	fmt.Printf("Active:        %v\n", r.Active)
+}
+
+func printReadingLine(r *dcmi.GetPowerReadingRsp) {
+	fmt.Printf("%-25v %6v %6v %6v %6v  %v\n", r.Timestamp.Format(time.RFC3339),
+		r.Instantaneous, r.Min, r.Avg, r.Max, r.Period)
+}
+
+func limit(ctx context.Context, commander dcmi.SessionCommands) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	rsp, active, err := commander.GetPowerLimit(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Active:          %v\n", active)
+	fmt.Printf("Limit:           %v W\n", rsp.Limit)
+	fmt.Printf("Exception:       %v\n", rsp.ExceptionAction.Description())
+	fmt.Printf("Correction time: %v\n", rsp.CorrectionTime)
+	fmt.Printf("Sampling period: %v\n", rsp.SamplingPeriod)
+	return nil
+}
+
+func setLimit(ctx context.Context, commander dcmi.SessionCommands) error {
+	setCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	req := &dcmi.SetPowerLimitReq{
+		ExceptionAction: argActions[*flgSetLimitAction],
+		Limit:           *flgSetLimitWatts,
+		CorrectionTime:  *flgSetLimitCorrectionTime,
+		SamplingPeriod:  *flgSetLimitSamplingPeriod,
+	}
+	if err := commander.SetPowerLimit(setCtx, req); err != nil {
+		return err
+	}
+	log.Printf("set power limit to %v W", req.Limit)
+	if *flgSetLimitActivate {
+		return activate(ctx, commander, true)
+	}
+	return nil
+}
+
+func activate(ctx context.Context, commander dcmi.SessionCommands, active bool) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	if err := commander.ActivateDeactivatePowerLimit(ctx,
+		&dcmi.ActivateDeactivatePowerLimitReq{
+			Activate: active,
+		}); err != nil {
+		return err
+	}
+	if active {
+		log.Print("activated power limit")
+	} else {
+		log.Print("deactivated power limit")
+	}
+	return nil
+}
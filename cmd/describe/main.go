@@ -13,12 +13,11 @@ import (
 	"time"
 
 	"github.com/kuiwang02/bmc"
-	"github.com/kuiwang02/bmc/internal/pkg/transport"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
 	"github.com/kuiwang02/bmc/pkg/dcmi"
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 
 	"github.com/alecthomas/kingpin"
-	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 )
 
@@ -26,21 +25,18 @@ var (
 	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC to describe.").
 			Required().
 			String()
-	flgUsername = kingpin.Flag("username", "The username to connect as.").
-			Required().
-			String()
-	flgPassword = kingpin.Flag("password", "The password of the user to connect as.").
-			Required().
-			String()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelUser,
+		time.Second*10)
 )
 
 func main() {
 	kingpin.Parse()
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
 	defer cancel()
 
-	machine, err := bmc.Dial(ctx, *argBMCAddr)
+	machine, err := flags.Dial(ctx, *argBMCAddr)
 	if err != nil {
 		log.Print(err)
 		return
@@ -49,7 +45,7 @@ func main() {
 
 	log.Printf("connected to %v over IPMI v%v", machine.Address(), machine.Version())
 
-	if pong, err := presencePing(ctx, machine); err != nil {
+	if pong, err := machine.PresencePing(ctx); err != nil {
 		log.Printf("failed to get presence pong capabilities: %v", err)
 	} else {
 		printPong(pong)
@@ -72,11 +68,7 @@ func main() {
 		printSystemGUID(guid)
 	}
 
-	sess, err := machine.NewSession(ctx, &bmc.SessionOpts{
-		Username:          *flgUsername,
-		Password:          []byte(*flgPassword),
-		MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
-	})
+	sess, err := flags.NewSession(ctx, machine)
 	if err != nil {
 		log.Print(err)
 		return
@@ -87,6 +79,13 @@ func main() {
 		log.Printf("failed to get device id: %v", err)
 	} else {
 		printDeviceID(id)
+		if bmc.IsOpenBMC(id) {
+			if version, err := sess.GetSystemFirmwareVersion(ctx); err != nil {
+				log.Printf("failed to get system firmware version: %v", err)
+			} else {
+				fmt.Printf("\tFirmware String:    %v\n", version)
+			}
+		}
 	}
 
 	if status, err := sess.GetChassisStatus(ctx); err != nil {
@@ -166,39 +165,6 @@ func main() {
 	}
 }
 
-func presencePing(ctx context.Context, t transport.Transport) (*layers.ASFPresencePong, error) {
-	asfRmcp := &layers.RMCP{
-		Version:  layers.RMCPVersion1,
-		Sequence: 0xFF, // do not send an ACK
-		Class:    layers.RMCPClassASF,
-	}
-	asf := &layers.ASF{
-		ASFDataIdentifier: layers.ASFDataIdentifierPresencePing,
-	}
-
-	buf := gopacket.NewSerializeBuffer()
-	opts := gopacket.SerializeOptions{
-		FixLengths:       true,
-		ComputeChecksums: true,
-	}
-	if err := gopacket.SerializeLayers(buf, opts, asfRmcp, asf); err != nil {
-		return nil, err
-	}
-	bytes, err := t.Send(ctx, buf.Bytes())
-	if err != nil {
-		return nil, err
-	}
-	packet := gopacket.NewPacket(bytes, layers.LayerTypeRMCP, gopacket.DecodeOptions{
-		Lazy:   true,
-		NoCopy: true,
-	})
-	pongLayer := packet.Layer(layers.LayerTypeASFPresencePong)
-	if pongLayer == nil {
-		return nil, fmt.Errorf("no presence pong layer in response")
-	}
-	return pongLayer.(*layers.ASFPresencePong), nil
-}
-
 func printPong(p *layers.ASFPresencePong) {
 	fmt.Println("ASF Presence Pong capabilities:")
 	fmt.Printf("\tIPMI:               %v\n", p.IPMI)
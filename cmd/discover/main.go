@@ -0,0 +1,278 @@
+package main
+
+// Discover sweeps a network for BMCs, sending Get Channel Authentication
+// Capabilities to each address in a CIDR range, and prints those that respond
+// with the IPMI versions and authentication types they support. If
+// credentials are provided, a session is established with each, and its
+// device and firmware information is included. Results are written to stdout
+// as CSV or JSON, sorted by address; progress and errors go to stderr.
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+const (
+	// maxHostBits bounds the size of the range, to catch typos like /8
+	// rather than /28.
+	maxHostBits = 16
+)
+
+var (
+	argCIDR = kingpin.Arg("cidr", "The network to sweep, e.g. 10.0.0.0/24.").
+		Required().
+		String()
+	flgPort = kingpin.Flag("port", "The UDP port BMCs listen on.").
+		Default("623").
+		Uint16()
+	flgConcurrency = kingpin.Flag("concurrency", "The number of addresses to probe at once.").
+			Default("64").
+			Int()
+	flgOutput = clilib.Output(kingpin.CommandLine, "csv", "json")
+
+	// if a username is provided, a session is established to retrieve device
+	// info; the timeout applies to each address
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelUser,
+		time.Second*3)
+)
+
+// result describes a BMC that responded.
+type result struct {
+	Address         string   `json:"address"`
+	Versions        []string `json:"versions"`
+	AuthTypes       []string `json:"authTypes"`
+	Manufacturer    string   `json:"manufacturer,omitempty"`
+	Product         *uint16  `json:"product,omitempty"`
+	FirmwareVersion string   `json:"firmwareVersion,omitempty"`
+
+	// Error is set if credentials were provided, but device info could not be
+	// retrieved.
+	Error string `json:"error,omitempty"`
+
+	addr netip.Addr
+}
+
+func main() {
+	kingpin.Parse()
+
+	if flags.Username != "" && flags.Password == "" {
+		kingpin.Fatalf("--username requires --password")
+	}
+	if *flgConcurrency < 1 {
+		kingpin.Fatalf("--concurrency must be at least 1")
+	}
+
+	addrs, err := hosts(*argCIDR)
+	if err != nil {
+		kingpin.Fatalf("%v", err)
+	}
+	log.Printf("probing %v addresses", len(addrs))
+
+	results := sweep(addrs)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].addr.Less(results[j].addr)
+	})
+	log.Printf("found %v BMCs", len(results))
+
+	switch *flgOutput {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(results)
+	default:
+		err = writeCSV(results)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// hosts returns the addresses in a CIDR range, excluding the network and
+// broadcast addresses of IPv4 ranges larger than /31.
+func hosts(cidr string) ([]netip.Addr, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, err
+	}
+	prefix = prefix.Masked()
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits > maxHostBits {
+		return nil, fmt.Errorf("%v contains more than %v addresses", prefix,
+			1<<maxHostBits)
+	}
+
+	addrs := []netip.Addr{}
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		addrs = append(addrs, addr)
+	}
+	if prefix.Addr().Is4() && hostBits > 1 {
+		addrs = addrs[1 : len(addrs)-1]
+	}
+	return addrs, nil
+}
+
+// sweep probes each address, returning results for those that responded, in
+// no particular order.
+func sweep(addrs []netip.Addr) []*result {
+	work := make(chan netip.Addr)
+	go func() {
+		defer close(work)
+		for _, addr := range addrs {
+			work <- addr
+		}
+	}()
+
+	mu := sync.Mutex{}
+	results := []*result{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < *flgConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range work {
+				if r := probe(addr); r != nil {
+					mu.Lock()
+					results = append(results, r)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// probe returns the result for a single address, or nil if it did not
+// respond.
+func probe(addr netip.Addr) *result {
+	ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
+	defer cancel()
+
+	hostPort := net.JoinHostPort(addr.String(), strconv.Itoa(int(*flgPort)))
+	machine, err := bmc.DialV2Context(ctx, hostPort, nil)
+	if err != nil {
+		log.Printf("%v: %v", addr, err)
+		return nil
+	}
+	defer machine.Close()
+
+	caps, err := machine.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		})
+	if err != nil {
+		// the overwhelmingly common case is no BMC at the address
+		return nil
+	}
+
+	r := &result{
+		Address:   addr.String(),
+		Versions:  versions(caps),
+		AuthTypes: authTypes(caps),
+		addr:      addr,
+	}
+	if flags.Username != "" {
+		// the capabilities may have used most of the timeout on retries
+		ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
+		defer cancel()
+		if err := describe(ctx, machine, r); err != nil {
+			r.Error = err.Error()
+		}
+	}
+	return r
+}
+
+// describe establishes a session to populate the device info of a result.
+func describe(ctx context.Context, machine *bmc.V2SessionlessTransport, r *result) error {
+	sess, err := flags.NewSession(ctx, machine)
+	if err != nil {
+		return err
+	}
+	defer sess.Close(ctx)
+
+	id, err := sess.GetDeviceID(ctx)
+	if err != nil {
+		return err
+	}
+	r.Manufacturer = id.Manufacturer.String()
+	r.Product = &id.Product
+	r.FirmwareVersion = bmc.FirmwareVersion(id)
+	return nil
+}
+
+func versions(caps *ipmi.GetChannelAuthenticationCapabilitiesRsp) []string {
+	versions := []string{}
+	// v1.5-only BMCs do not set SupportsV1, as the field was added in v2.0
+	if caps.SupportsV1 || !caps.SupportsV2 {
+		versions = append(versions, "1.5")
+	}
+	if caps.SupportsV2 {
+		versions = append(versions, "2.0")
+	}
+	return versions
+}
+
+func authTypes(caps *ipmi.GetChannelAuthenticationCapabilitiesRsp) []string {
+	types := []string{}
+	for _, t := range []struct {
+		name      string
+		supported bool
+	}{
+		{"none", caps.AuthenticationTypeNone},
+		{"md2", caps.AuthenticationTypeMD2},
+		{"md5", caps.AuthenticationTypeMD5},
+		{"password", caps.AuthenticationTypePassword},
+		{"oem", caps.AuthenticationTypeOEM},
+	} {
+		if t.supported {
+			types = append(types, t.name)
+		}
+	}
+	return types
+}
+
+func writeCSV(results []*result) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"address", "versions", "auth_types",
+		"manufacturer", "product", "firmware_version", "error"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		product := ""
+		if r.Product != nil {
+			product = strconv.Itoa(int(*r.Product))
+		}
+		if err := w.Write([]string{
+			r.Address,
+			strings.Join(r.Versions, " "),
+			strings.Join(r.AuthTypes, " "),
+			r.Manufacturer,
+			product,
+			r.FirmwareVersion,
+			r.Error,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,374 @@
+package main
+
+// event-forwarder watches the SEL of each BMC passed to it, forwarding new
+// records as JSON to a webhook and/or syslog, turning BMCs into a source of a
+// hardware event pipeline. Each BMC has its own session, which is
+// re-established if polling fails. Records are forwarded in order, at least
+// once: a record is retried until every destination accepts it, and only then
+// is it recorded as forwarded. With --state-dir, the newest record forwarded
+// from each BMC is persisted, so a restart resumes where it left off rather
+// than forwarding the whole SEL again, or missing records added while stopped.
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/syslog"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/gopacket"
+)
+
+const (
+	// reconnectInterval is how long to wait before re-establishing a session
+	// after it fails.
+	reconnectInterval = time.Second * 30
+)
+
+var (
+	argAddrs = kingpin.Arg("addr", "IP[:port] of each BMC to watch.").
+			Strings()
+	flgTargetsFile = kingpin.Flag("targets-file", "A file containing further BMCs to watch, one per line.").
+			ExistingFile()
+	flgPollInterval = kingpin.Flag("poll-interval", "How often to check each SEL for new records.").
+			Default("10s").
+			Duration()
+	flgReplay = kingpin.Flag("replay", "Forward records already in the SEL of BMCs without persisted state.").
+			Bool()
+	flgStateDir = kingpin.Flag("state-dir", "If set, persist the newest record forwarded from each BMC in this directory.").
+			ExistingDir()
+	flgWebhook = kingpin.Flag("webhook", "If set, POST each record as JSON to this URL.").
+			URL()
+	flgSyslog = kingpin.Flag("syslog", "Write each record as JSON to the local syslog daemon.").
+			Bool()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelUser,
+		time.Second*10)
+)
+
+// event is a SEL record as forwarded. Only system event records have the event
+// fields set.
+type event struct {
+	BMC          string        `json:"bmc"`
+	Source       string        `json:"source"`
+	ID           ipmi.RecordID `json:"id"`
+	Type         uint8         `json:"type"`
+	Timestamp    *time.Time    `json:"timestamp,omitempty"`
+	GeneratorID  uint16        `json:"generatorId,omitempty"`
+	SensorType   string        `json:"sensorType,omitempty"`
+	SensorNumber uint8         `json:"sensorNumber,omitempty"`
+	Deassertion  bool          `json:"deassertion,omitempty"`
+	EventType    uint8         `json:"eventType,omitempty"`
+	EventData    string        `json:"eventData,omitempty"`
+	Manufacturer string        `json:"manufacturer,omitempty"`
+	OEMData      string        `json:"oemData,omitempty"`
+}
+
+func newEvent(addr string, e bmc.Event) *event {
+	record := e.Record
+	ev := &event{
+		BMC:    addr,
+		Source: e.Source.String(),
+		ID:     record.ID,
+		Type:   record.Type,
+	}
+	if !record.Timestamp.IsZero() {
+		ev.Timestamp = &record.Timestamp
+	}
+	if record.Type == ipmi.SELRecordTypeSystemEvent {
+		ev.GeneratorID = record.GeneratorID
+		ev.SensorType = record.SensorType.Description()
+		ev.SensorNumber = record.SensorNumber
+		ev.Deassertion = record.Deassertion
+		ev.EventType = record.EventType
+		ev.EventData = hex.EncodeToString(record.EventData[:])
+	} else {
+		if record.Manufacturer != 0 {
+			ev.Manufacturer = record.Manufacturer.String()
+		}
+		ev.OEMData = hex.EncodeToString(record.OEMData)
+	}
+	return ev
+}
+
+// destination is somewhere events are forwarded to.
+type destination interface {
+	fmt.Stringer
+
+	// Send forwards an event, returning an error if it was not accepted.
+	Send(context.Context, *event) error
+}
+
+// webhook POSTs events as JSON to a URL.
+type webhook struct {
+	url string
+}
+
+func (w *webhook) String() string {
+	return w.url
+}
+
+func (w *webhook) Send(ctx context.Context, e *event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url,
+		bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %v", rsp.Status)
+	}
+	return nil
+}
+
+// syslogger writes events as JSON to syslog. Assertions are logged as
+// warnings, and everything else as notices.
+type syslogger struct {
+	w *syslog.Writer
+}
+
+func (s *syslogger) String() string {
+	return "syslog"
+}
+
+func (s *syslogger) Send(_ context.Context, e *event) error {
+	msg, err := json.Marshal(e)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	if e.Type == ipmi.SELRecordTypeSystemEvent && !e.Deassertion {
+		return s.w.Warning(string(msg))
+	}
+	return s.w.Notice(string(msg))
+}
+
+// target is a BMC being watched.
+type target struct {
+	addr string
+
+	// last is the newest record forwarded, or nil if none has been.
+	last *ipmi.SELEventRecord
+}
+
+func main() {
+	kingpin.Parse()
+
+	addrs := *argAddrs
+	if *flgTargetsFile != "" {
+		fileAddrs, err := readTargets(*flgTargetsFile)
+		if err != nil {
+			kingpin.Fatalf("%v", err)
+		}
+		addrs = append(addrs, fileAddrs...)
+	}
+	if len(addrs) == 0 {
+		kingpin.Fatalf("no BMCs to watch")
+	}
+
+	destinations := []destination{}
+	if *flgWebhook != nil {
+		destinations = append(destinations, &webhook{
+			url: (*flgWebhook).String(),
+		})
+	}
+	if *flgSyslog {
+		w, err := syslog.New(syslog.LOG_DAEMON, "event-forwarder")
+		if err != nil {
+			kingpin.Fatalf("%v", err)
+		}
+		defer w.Close()
+		destinations = append(destinations, &syslogger{
+			w: w,
+		})
+	}
+	if len(destinations) == 0 {
+		kingpin.Fatalf("at least one of --webhook or --syslog is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	wg := sync.WaitGroup{}
+	for _, addr := range addrs {
+		t := &target{
+			addr: addr,
+		}
+		if err := t.loadState(); err != nil {
+			log.Fatalf("%v: %v", addr, err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.run(ctx, destinations)
+		}()
+	}
+	wg.Wait()
+}
+
+// readTargets returns the non-empty lines of a file.
+func readTargets(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	addrs := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			addrs = append(addrs, line)
+		}
+	}
+	return addrs, scanner.Err()
+}
+
+// run watches the target until the context expires, re-establishing its
+// session after failures.
+func (t *target) run(ctx context.Context, destinations []destination) {
+	for {
+		if err := t.watch(ctx, destinations); err != nil && ctx.Err() == nil {
+			log.Printf("%v: %v; reconnecting in %v", t.addr, err,
+				reconnectInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+// watch forwards events from the target's SEL within a single session,
+// returning when polling fails, or the context expires.
+func (t *target) watch(ctx context.Context, destinations []destination) error {
+	sess, closeSess, err := flags.Connect(ctx, t.addr)
+	if err != nil {
+		return err
+	}
+	defer closeSess()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pollErr := error(nil)
+	events, err := bmc.Events(ctx, sess, &bmc.EventsOpts{
+		PollInterval: *flgPollInterval,
+		Replay:       *flgReplay,
+		After:        t.last,
+		Errors: func(err error) {
+			// the session may be broken, so start again
+			pollErr = err
+			cancel()
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for e := range events {
+		ev := newEvent(t.addr, e)
+		for _, d := range destinations {
+			if err := forward(ctx, d, ev); err != nil {
+				// the context expired; the record will be sent again
+				cancel()
+				for range events {
+					// drain until closed
+				}
+				return err
+			}
+		}
+		t.last = e.Record
+		if err := t.saveState(); err != nil {
+			log.Printf("%v: failed to persist state: %v", t.addr, err)
+		}
+	}
+	// the channel is closed after the last call to Errors
+	return pollErr
+}
+
+// forward sends an event to a destination, retrying until it is accepted, or
+// the context expires.
+func forward(ctx context.Context, d destination, e *event) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxInterval = time.Minute
+	b.MaxElapsedTime = 0
+	return backoff.RetryNotify(func() error {
+		return d.Send(ctx, e)
+	}, backoff.WithContext(b, ctx), func(err error, wait time.Duration) {
+		log.Printf("%v: failed to forward record %v to %v: %v; retrying "+
+			"in %v", e.BMC, e.ID, d, err, wait)
+	})
+}
+
+// statePath returns the path of the file persisting the target's state.
+func (t *target) statePath() string {
+	name := strings.NewReplacer(":", "_", "/", "_", "[", "", "]", "").
+		Replace(t.addr)
+	return filepath.Join(*flgStateDir, name)
+}
+
+// loadState reads the newest record forwarded by a previous run, if any.
+func (t *target) loadState() error {
+	if *flgStateDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(t.statePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	contents, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("invalid state in %v: %w", t.statePath(), err)
+	}
+	record := &ipmi.SELEventRecord{}
+	if err := record.DecodeFromBytes(contents,
+		gopacket.NilDecodeFeedback); err != nil {
+		return fmt.Errorf("invalid state in %v: %w", t.statePath(), err)
+	}
+	t.last = record
+	return nil
+}
+
+// saveState persists the newest record forwarded, replacing the file
+// atomically so a crash cannot leave it truncated.
+func (t *target) saveState() error {
+	if *flgStateDir == "" {
+		return nil
+	}
+	path := t.statePath()
+	tmp := path + ".tmp"
+	data := hex.EncodeToString(t.last.Contents) + "\n"
+	if err := os.WriteFile(tmp, []byte(data), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
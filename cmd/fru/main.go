@@ -0,0 +1,259 @@
+package main
+
+// Fru prints the chassis, board and product information areas of a system's
+// FRU Inventory Devices. Device 0 is always read; other logical devices
+// accessed via the BMC are found via FRU Device Locator SDRs. Alternatively,
+// --raw writes the binary contents of a single device to stdout, in the same
+// format as ipmitool's "fru read".
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC to query.").
+			Required().
+			String()
+	flgDevices = kingpin.Flag("device", "FRU device ID to read, instead of discovering devices. Repeatable.").
+			Uint8List()
+	flgRaw = kingpin.Flag("raw", "Write the binary contents of the device to stdout. Requires at most one --device, defaulting to 0.").
+		Bool()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelUser,
+		time.Second*30)
+	flgOutput = clilib.Output(kingpin.CommandLine, "text", "json")
+)
+
+// device is a FRU Inventory Device to read, and its decoded contents.
+type device struct {
+	ID      uint8    `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Chassis *chassis `json:"chassis,omitempty"`
+	Board   *board   `json:"board,omitempty"`
+	Product *product `json:"product,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type chassis struct {
+	Type         uint8    `json:"type"`
+	PartNumber   string   `json:"partNumber,omitempty"`
+	SerialNumber string   `json:"serialNumber,omitempty"`
+	Custom       []string `json:"custom,omitempty"`
+}
+
+type board struct {
+	Manufactured *time.Time `json:"manufactured,omitempty"`
+	Manufacturer string     `json:"manufacturer,omitempty"`
+	ProductName  string     `json:"productName,omitempty"`
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	PartNumber   string     `json:"partNumber,omitempty"`
+	FRUFileID    string     `json:"fruFileID,omitempty"`
+	Custom       []string   `json:"custom,omitempty"`
+}
+
+type product struct {
+	Manufacturer string   `json:"manufacturer,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	PartNumber   string   `json:"partNumber,omitempty"`
+	Version      string   `json:"version,omitempty"`
+	SerialNumber string   `json:"serialNumber,omitempty"`
+	AssetTag     string   `json:"assetTag,omitempty"`
+	FRUFileID    string   `json:"fruFileID,omitempty"`
+	Custom       []string `json:"custom,omitempty"`
+}
+
+func main() {
+	kingpin.Parse()
+
+	if *flgRaw && len(*flgDevices) > 1 {
+		kingpin.Fatalf("--raw can only be used with a single --device")
+	}
+
+	sess, closeSess, err := flags.Connect(context.Background(), *argBMCAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeSess()
+
+	ctx, cancel := context.WithTimeout(context.Background(), flags.Timeout)
+	defer cancel()
+
+	if *flgRaw {
+		id := uint8(0)
+		if len(*flgDevices) == 1 {
+			id = (*flgDevices)[0]
+		}
+		if err := bmc.SaveFRU(ctx, sess, id, os.Stdout); err != nil {
+			closeSess()
+			log.Fatal(err)
+		}
+		return
+	}
+
+	devices := []*device{}
+	if len(*flgDevices) == 0 {
+		devices = discoverDevices(ctx, sess)
+	} else {
+		for _, id := range *flgDevices {
+			devices = append(devices, &device{ID: id})
+		}
+	}
+	for _, d := range devices {
+		inventory, err := bmc.ReadFRUInventory(ctx, sess, d.ID)
+		if err != nil {
+			d.Error = err.Error()
+			continue
+		}
+		d.set(inventory)
+	}
+
+	if *flgOutput == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(devices); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	for i, d := range devices {
+		if i > 0 {
+			fmt.Println()
+		}
+		d.print()
+	}
+}
+
+// discoverDevices returns device 0, followed by the logical FRU devices behind
+// the BMC described by the SDR Repository. If the repository cannot be read,
+// only device 0 is returned.
+func discoverDevices(ctx context.Context, sess bmc.Session) []*device {
+	devices := []*device{
+		{
+			ID:   0,
+			Name: "Builtin FRU Device",
+		},
+	}
+	records, err := bmc.RetrieveFRUDeviceLocators(ctx, sess)
+	if err != nil {
+		log.Printf("failed to retrieve FRU Device Locator records: %v", err)
+		return devices
+	}
+	for _, record := range records {
+		if !record.Logical || record.DeviceID == 0 {
+			continue
+		}
+		if record.DeviceAccessAddress != ipmi.SlaveAddressBMC ||
+			record.AccessLUN != ipmi.LUNBMC || record.Channel != 0 {
+			log.Printf("skipping %v (FRU device %v), which is behind %v on "+
+				"channel %v", record.Identity, record.DeviceID,
+				record.DeviceAccessAddress, record.Channel)
+			continue
+		}
+		devices = append(devices, &device{
+			ID:   record.DeviceID,
+			Name: record.Identity,
+		})
+	}
+	return devices
+}
+
+// set populates the device's areas from a decoded inventory.
+func (d *device) set(inventory *bmc.FRUInventory) {
+	if a := inventory.Chassis; a != nil {
+		d.Chassis = &chassis{
+			Type:         a.Type,
+			PartNumber:   a.PartNumber,
+			SerialNumber: a.SerialNumber,
+			Custom:       a.Custom,
+		}
+	}
+	if a := inventory.Board; a != nil {
+		d.Board = &board{
+			Manufacturer: a.Manufacturer,
+			ProductName:  a.ProductName,
+			SerialNumber: a.SerialNumber,
+			PartNumber:   a.PartNumber,
+			FRUFileID:    a.FRUFileID,
+			Custom:       a.Custom,
+		}
+		if !a.Manufactured.IsZero() {
+			d.Board.Manufactured = &a.Manufactured
+		}
+	}
+	if a := inventory.Product; a != nil {
+		d.Product = &product{
+			Manufacturer: a.Manufacturer,
+			Name:         a.Name,
+			PartNumber:   a.PartNumber,
+			Version:      a.Version,
+			SerialNumber: a.SerialNumber,
+			AssetTag:     a.AssetTag,
+			FRUFileID:    a.FRUFileID,
+			Custom:       a.Custom,
+		}
+	}
+}
+
+// print writes the device in a similar format to ipmitool's "fru print".
+func (d *device) print() {
+	if d.Name == "" {
+		fmt.Printf("FRU Device ID %v\n", d.ID)
+	} else {
+		fmt.Printf("FRU Device ID %v (%v)\n", d.ID, d.Name)
+	}
+	if d.Error != "" {
+		fmt.Printf("  Error: %v\n", d.Error)
+		return
+	}
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Printf("  %-24v: %v\n", name, value)
+		}
+	}
+	custom := func(area string, values []string) {
+		for _, value := range values {
+			field(area+" Extra", value)
+		}
+	}
+	if c := d.Chassis; c != nil {
+		field("Chassis Type", fmt.Sprintf("%#.2x", c.Type))
+		field("Chassis Part Number", c.PartNumber)
+		field("Chassis Serial", c.SerialNumber)
+		custom("Chassis", c.Custom)
+	}
+	if b := d.Board; b != nil {
+		if b.Manufactured != nil {
+			field("Board Mfg Date", b.Manufactured.Format(time.RFC3339))
+		}
+		field("Board Mfg", b.Manufacturer)
+		field("Board Product", b.ProductName)
+		field("Board Serial", b.SerialNumber)
+		field("Board Part Number", b.PartNumber)
+		field("Board FRU File ID", b.FRUFileID)
+		custom("Board", b.Custom)
+	}
+	if p := d.Product; p != nil {
+		field("Product Manufacturer", p.Manufacturer)
+		field("Product Name", p.Name)
+		field("Product Part Number", p.PartNumber)
+		field("Product Version", p.Version)
+		field("Product Serial", p.SerialNumber)
+		field("Product Asset Tag", p.AssetTag)
+		field("Product FRU File ID", p.FRUFileID)
+		custom("Product", p.Custom)
+	}
+	if d.Chassis == nil && d.Board == nil && d.Product == nil {
+		fmt.Println("  No information areas")
+	}
+}
@@ -0,0 +1,443 @@
+package main
+
+// Shell establishes a single session with a BMC, then reads commands from
+// stdin, e.g. "power status", "sensor read CPU Temp", "sel list" or
+// "raw 0x06 0x01". This avoids the RMCP+ handshake of each invocation of the
+// other tools, which can take seconds against slow BMCs. The session is kept
+// alive while idle. Type "help" for the supported commands; EOF or an
+// interrupt closes the session.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/google/gopacket"
+)
+
+const (
+	help = `Commands:
+  power status                          Print whether the system is powered on.
+  power on|off|cycle|reset|interrupt|softoff
+                                        Send a chassis control command.
+  sensor list                           Print the reading of every sensor.
+  sensor read <name|number>             Print the reading of a single sensor.
+  sel list                              Print the System Event Log.
+  raw <netfn> <cmd> [data...]           Send a command, printing the response
+                                        data. Values are bytes, e.g. 0x06 6.
+  help                                  Print this message.
+  exit                                  Close the session and exit.
+`
+)
+
+var (
+	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	flgKeepalive = kingpin.Flag("keepalive", "The interval at which to send a keepalive while idle, so the BMC does not close the session.").
+			Default("30s").
+			Duration()
+
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Second*10)
+
+	cmdControls = map[string]ipmi.ChassisControl{
+		"off":       ipmi.ChassisControlPowerOff,
+		"on":        ipmi.ChassisControlPowerOn,
+		"cycle":     ipmi.ChassisControlPowerCycle,
+		"reset":     ipmi.ChassisControlHardReset,
+		"interrupt": ipmi.ChassisControlDiagnosticInterrupt,
+		"softoff":   ipmi.ChassisControlSoftPowerOff,
+	}
+
+	errExit = errors.New("exit")
+)
+
+// shell holds the state of an interactive session.
+type shell struct {
+	sess bmc.Session
+
+	// repo is the SDR Repository, retrieved on first use.
+	repo bmc.SDRRepository
+
+	// deviceID is the BMC's Get Device ID response, retrieved on first use.
+	deviceID *ipmi.GetDeviceIDRsp
+}
+
+func main() {
+	kingpin.Parse()
+
+	if *flgKeepalive <= 0 {
+		kingpin.Fatalf("--keepalive must be positive")
+	}
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes commands until EOF or interrupt, returning once the session is
+// closed.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	sess, closeSess, err := flags.Connect(ctx, *argBMCAddr)
+	if err != nil {
+		return err
+	}
+	defer closeSess()
+
+	// reading stdin cannot be cancelled, so is done in the background, with
+	// the goroutine abandoned on interrupt
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	sh := &shell{
+		sess: sess,
+	}
+	keepalive := time.NewTicker(*flgKeepalive)
+	defer keepalive.Stop()
+	prompt()
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-keepalive.C:
+			if err := sh.keepalive(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("keepalive failed: %v", err)
+			}
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			err := sh.execute(ctx, strings.Fields(line))
+			if err == errExit {
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			}
+			keepalive.Reset(*flgKeepalive)
+			prompt()
+		}
+	}
+}
+
+// prompt prints the prompt if stdin is a terminal, so piped commands produce
+// clean output.
+func prompt() {
+	if info, err := os.Stdin.Stat(); err == nil &&
+		info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Print("bmc> ")
+	}
+}
+
+// keepalive sends Get Channel Authentication Capabilities, which is
+// conventionally used to keep a session open.
+func (sh *shell) keepalive(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	_, err := sh.sess.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		})
+	return err
+}
+
+// execute runs a single command, returning errExit if the shell should exit.
+func (sh *shell) execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	switch args[0] {
+	case "help", "?":
+		fmt.Print(help)
+		return nil
+	case "exit", "quit":
+		return errExit
+	case "power":
+		return sh.power(ctx, args[1:])
+	case "sensor":
+		return sh.sensor(ctx, args[1:])
+	case "sel":
+		if len(args) != 2 || args[1] != "list" {
+			return fmt.Errorf("usage: sel list")
+		}
+		return sh.selList(ctx)
+	case "raw":
+		return sh.raw(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q; type help for a list", args[0])
+	}
+}
+
+func (sh *shell) power(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: power status|on|off|cycle|reset|interrupt|softoff")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	if args[0] == "status" {
+		status, err := sh.sess.GetChassisStatus(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Power: %v\n", bmc.PowerState(status.PoweredOn))
+		return nil
+	}
+	ctrl, ok := cmdControls[args[0]]
+	if !ok {
+		return fmt.Errorf("invalid power command: %v", args[0])
+	}
+	if err := sh.sess.ChassisControl(ctx, ctrl); err != nil {
+		return err
+	}
+	fmt.Printf("Sent %v\n", ctrl.Description())
+	return nil
+}
+
+func (sh *shell) sensor(ctx context.Context, args []string) error {
+	if len(args) == 0 || (args[0] != "list" && args[0] != "read") ||
+		(args[0] == "list") != (len(args) == 1) {
+		return fmt.Errorf("usage: sensor list|read <name|number>")
+	}
+	if err := sh.retrieveSDRRepository(ctx); err != nil {
+		return err
+	}
+
+	recordIDs := make([]ipmi.RecordID, 0, len(sh.repo))
+	for recordID := range sh.repo {
+		recordIDs = append(recordIDs, recordID)
+	}
+	sort.Slice(recordIDs, func(i, j int) bool {
+		return recordIDs[i] < recordIDs[j]
+	})
+	if args[0] == "list" {
+		for _, recordID := range recordIDs {
+			sh.printSensor(ctx, sh.repo[recordID])
+		}
+		return nil
+	}
+
+	// names can contain spaces, and take precedence over numbers
+	name := strings.Join(args[1:], " ")
+	for _, recordID := range recordIDs {
+		if fsr := sh.repo[recordID]; strings.EqualFold(fsr.Identity, name) {
+			sh.printSensor(ctx, fsr)
+			return nil
+		}
+	}
+	if number, err := strconv.ParseUint(name, 0, 8); err == nil {
+		for _, recordID := range recordIDs {
+			if fsr := sh.repo[recordID]; fsr.Number == uint8(number) {
+				sh.printSensor(ctx, fsr)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no sensor named or numbered %q", name)
+}
+
+// retrieveSDRRepository populates the SDR Repository if it has not already
+// been retrieved. It is cached for the life of the shell, as it rarely
+// changes, and takes many commands to retrieve.
+func (sh *shell) retrieveSDRRepository(ctx context.Context) error {
+	if sh.repo != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	repo, err := bmc.RetrieveSDRRepository(ctx, sh.sess)
+	if err != nil {
+		return err
+	}
+	sh.repo = repo
+	return nil
+}
+
+// manufacturer returns the manufacturer of the BMC, for interpreting
+// completion codes. It returns the reserved enterprise number if it cannot be
+// retrieved, as the response is only used to improve error messages.
+func (sh *shell) manufacturer(ctx context.Context) iana.Enterprise {
+	if sh.deviceID == nil {
+		deviceID, err := sh.sess.GetDeviceID(ctx)
+		if err != nil {
+			return iana.EnterpriseReserved
+		}
+		sh.deviceID = deviceID
+	}
+	return sh.deviceID.Manufacturer
+}
+
+func (sh *shell) printSensor(ctx context.Context, fsr *ipmi.FullSensorRecord) {
+	reader, err := bmc.NewSensorReader(fsr)
+	if err != nil {
+		// e.g. chassis intrusion
+		fmt.Printf("%-19v not analog\n", fsr.Identity)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	reading, err := reader.Read(ctx, sh.sess)
+	switch err {
+	case nil:
+		fmt.Printf("%-19v %v%v\n", fsr.Identity, reading, fsr.BaseUnit.Symbol())
+	case bmc.ErrSensorScanningDisabled:
+		// suggests system is off
+		fmt.Printf("%-19v disabled\n", fsr.Identity)
+	default:
+		// suggests slot empty (fan, memory module), or ctx expired
+		fmt.Printf("%-19v no reading/missing (%v)\n", fsr.Identity, err)
+	}
+}
+
+func (sh *shell) selList(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	records, err := bmc.RetrieveSEL(ctx, sh.sess)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		printSELRecord(record)
+	}
+	return nil
+}
+
+func printSELRecord(r *ipmi.SELEventRecord) {
+	timestamp := "unspecified"
+	if !r.Timestamp.IsZero() {
+		timestamp = r.Timestamp.Format(time.RFC3339)
+	}
+	if r.Type != ipmi.SELRecordTypeSystemEvent {
+		manufacturer := ""
+		if r.Manufacturer != 0 {
+			manufacturer = fmt.Sprintf(" from %v", r.Manufacturer)
+		}
+		fmt.Printf("%6v  %-25v OEM record type %#02x%v: % x\n", r.ID,
+			timestamp, r.Type, manufacturer, r.OEMData)
+		return
+	}
+	direction := "asserted"
+	if r.Deassertion {
+		direction = "deasserted"
+	}
+	fmt.Printf("%6v  %-25v %v #%#02x %v, event type %#02x, data % x\n",
+		r.ID, timestamp, r.SensorType, r.SensorNumber, direction,
+		r.EventType, r.EventData)
+}
+
+func (sh *shell) raw(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: raw <netfn> <cmd> [data...]")
+	}
+	bytes := make([]byte, len(args))
+	for i, arg := range args {
+		b, err := strconv.ParseUint(arg, 0, 8)
+		if err != nil {
+			return fmt.Errorf("invalid byte %q", arg)
+		}
+		bytes[i] = uint8(b)
+	}
+
+	cmd := &rawCmd{
+		operation: ipmi.Operation{
+			Function: ipmi.NetworkFunction(bytes[0]),
+			Command:  ipmi.CommandNumber(bytes[1]),
+		},
+	}
+	if !cmd.operation.Function.IsRequest() {
+		return fmt.Errorf("%v is not a request network function",
+			cmd.operation.Function)
+	}
+	data := bytes[2:]
+
+	// the message layer adds the body code or enterprise number, so remove
+	// them from the data
+	switch cmd.operation.Function {
+	case ipmi.NetworkFunctionGroupReq:
+		if len(data) < 1 {
+			return fmt.Errorf("group requests require a body code")
+		}
+		cmd.operation.Body = ipmi.BodyCode(data[0])
+		data = data[1:]
+	case ipmi.NetworkFunctionOEMReq:
+		if len(data) < 3 {
+			return fmt.Errorf("OEM requests require an enterprise number")
+		}
+		cmd.operation.Enterprise = iana.Enterprise(uint32(data[0]) |
+			uint32(data[1])<<8 | uint32(data[2])<<16)
+		data = data[3:]
+	}
+	cmd.data = data
+
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	m, err := sh.sess.SendCommandRaw(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if m.CompletionCode != ipmi.CompletionCodeNormal {
+		return fmt.Errorf("completion code %v", m.CompletionCode.StringFor(
+			&cmd.operation, sh.manufacturer(ctx)))
+	}
+	fmt.Printf("% x\n", m.LayerPayload())
+	return nil
+}
+
+// rawCmd is a command built from bytes entered by the user. It has no response
+// layer; the response data is taken from the message.
+type rawCmd struct {
+	operation ipmi.Operation
+	data      gopacket.Payload
+}
+
+func (*rawCmd) Name() string {
+	return "Raw"
+}
+
+func (c *rawCmd) Operation() *ipmi.Operation {
+	return &c.operation
+}
+
+func (c *rawCmd) Request() gopacket.SerializableLayer {
+	return &c.data
+}
+
+func (*rawCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
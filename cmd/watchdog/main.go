@@ -0,0 +1,252 @@
+package main
+
+// Watchdog shows, arms, disarms and resets (pets) the BMC watchdog timer. With
+// --pet-interval, arm and reset keep petting the timer until interrupted, e.g.
+// to hold off a timer armed by the OS while it is stopped for maintenance.
+// Stopping petting does not disarm the timer: it expires unless disarmed or
+// petted by something else.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/internal/pkg/clilib"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	flags = clilib.Register(kingpin.CommandLine, ipmi.PrivilegeLevelOperator,
+		time.Second*10)
+
+	cmdShow     = kingpin.Command("show", "Print the configuration and countdown of the timer.")
+	argShowAddr = cmdShow.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+
+	cmdArm     = kingpin.Command("arm", "Configure and start the timer.")
+	argArmAddr = cmdArm.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	flgArmCountdown = cmdArm.Flag("countdown", "The time without a reset before the timer expires, at most 1h49m.").
+			Default("5m").
+			Duration()
+	flgArmAction = cmdArm.Flag("action", "What to do when the timer expires (reset/off/cycle/none).").
+			Default("reset").
+			Enum("reset", "off", "cycle", "none")
+	flgArmUse = cmdArm.Flag("use", "The timer use to set, logged on expiration (frb2/post/osload/smsos/oem).").
+			Default("smsos").
+			Enum("frb2", "post", "osload", "smsos", "oem")
+	flgArmDontLog = cmdArm.Flag("dont-log", "Do not log an event to the SEL on expiration.").
+			Bool()
+	flgArmPetInterval = cmdArm.Flag("pet-interval", "If non-zero, keep resetting the timer at this interval until interrupted.").
+				Duration()
+
+	cmdDisarm     = kingpin.Command("disarm", "Stop the timer, and set its timeout action to none.")
+	argDisarmAddr = cmdDisarm.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+
+	cmdReset     = kingpin.Command("reset", "Restart the countdown of a configured timer, starting it if stopped.")
+	argResetAddr = cmdReset.Arg("addr", "IP[:port] of the BMC.").
+			Required().
+			String()
+	flgResetPetInterval = cmdReset.Flag("pet-interval", "If non-zero, keep resetting the timer at this interval until interrupted.").
+				Duration()
+
+	argActions = map[string]ipmi.WatchdogTimeoutAction{
+		"reset": ipmi.WatchdogTimeoutActionHardReset,
+		"off":   ipmi.WatchdogTimeoutActionPowerDown,
+		"cycle": ipmi.WatchdogTimeoutActionPowerCycle,
+		"none":  ipmi.WatchdogTimeoutActionNone,
+	}
+	argUses = map[string]ipmi.WatchdogTimerUse{
+		"frb2":   ipmi.WatchdogTimerUseBIOSFRB2,
+		"post":   ipmi.WatchdogTimerUseBIOSPOST,
+		"osload": ipmi.WatchdogTimerUseOSLoad,
+		"smsos":  ipmi.WatchdogTimerUseSMSOS,
+		"oem":    ipmi.WatchdogTimerUseOEM,
+	}
+)
+
+func main() {
+	command := kingpin.Parse()
+
+	if command == cmdArm.FullCommand() {
+		if *flgArmCountdown <= 0 || *flgArmCountdown > countdown(0xffff) {
+			kingpin.Fatalf("--countdown must be between 100ms and %v",
+				countdown(0xffff))
+		}
+		if *flgArmPetInterval >= *flgArmCountdown {
+			kingpin.Fatalf("--pet-interval must be less than --countdown")
+		}
+	}
+
+	if err := run(command); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes the command, returning once the session is closed.
+func run(command string) error {
+	// petting stops on interrupt; the session is still closed cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+
+	addr := map[string]string{
+		cmdShow.FullCommand():   *argShowAddr,
+		cmdArm.FullCommand():    *argArmAddr,
+		cmdDisarm.FullCommand(): *argDisarmAddr,
+		cmdReset.FullCommand():  *argResetAddr,
+	}[command]
+
+	sess, closeSess, err := flags.Connect(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer closeSess()
+
+	switch command {
+	case cmdShow.FullCommand():
+		return show(ctx, sess)
+	case cmdArm.FullCommand():
+		return arm(ctx, sess)
+	case cmdDisarm.FullCommand():
+		return disarm(ctx, sess)
+	default:
+		return reset(ctx, sess, *flgResetPetInterval)
+	}
+}
+
+func show(ctx context.Context, sess bmc.Session) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	timer, err := bmc.GetWatchdogTimer(ctx, sess)
+	if err != nil {
+		return err
+	}
+	state := "Stopped"
+	if timer.Running {
+		state = "Running"
+	}
+	fmt.Printf("State:                 %v\n", state)
+	fmt.Printf("Timer use:             %v\n", timer.TimerUse.Description())
+	fmt.Printf("Log on expiration:     %v\n", !timer.DontLog)
+	fmt.Printf("Timeout action:        %v\n", timer.TimeoutAction.Description())
+	fmt.Printf("Pre-timeout interrupt: %v\n", timer.PreTimeoutInterrupt.Description())
+	fmt.Printf("Pre-timeout interval:  %v\n", time.Duration(timer.PreTimeoutInterval)*time.Second)
+	fmt.Printf("Initial countdown:     %v\n", countdown(timer.InitialCountdown))
+	fmt.Printf("Present countdown:     %v\n", countdown(timer.PresentCountdown))
+	fmt.Printf("Expiration flags:      %#02x\n", timer.ExpirationFlags)
+	return nil
+}
+
+func arm(ctx context.Context, sess bmc.Session) error {
+	setCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+	req := &ipmi.SetWatchdogTimerReq{
+		DontLog:          *flgArmDontLog,
+		TimerUse:         argUses[*flgArmUse],
+		TimeoutAction:    argActions[*flgArmAction],
+		InitialCountdown: bmc.WatchdogCountdown(*flgArmCountdown),
+	}
+	if err := bmc.SetWatchdogTimer(setCtx, sess, req); err != nil {
+		return err
+	}
+	if err := bmc.ResetWatchdogTimer(setCtx, sess); err != nil {
+		return err
+	}
+	log.Printf("armed timer; %v in %v without a reset",
+		req.TimeoutAction.Description(), countdown(req.InitialCountdown))
+	return pet(ctx, sess, *flgArmPetInterval)
+}
+
+func disarm(ctx context.Context, sess bmc.Session) error {
+	ctx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	// preserve the rest of the configuration, so a reset by the OS restarts
+	// the timer as it expects, but harmlessly
+	timer, err := bmc.GetWatchdogTimer(ctx, sess)
+	if err != nil {
+		return err
+	}
+	use := timer.TimerUse
+	if use == 0 {
+		// never configured; 0 is reserved
+		use = ipmi.WatchdogTimerUseSMSOS
+	}
+	if err := bmc.SetWatchdogTimer(ctx, sess, &ipmi.SetWatchdogTimerReq{
+		DontLog:            timer.DontLog,
+		TimerUse:           use,
+		PreTimeoutInterval: timer.PreTimeoutInterval,
+		TimeoutAction:      ipmi.WatchdogTimeoutActionNone,
+		InitialCountdown:   timer.InitialCountdown,
+	}); err != nil {
+		return err
+	}
+	log.Print("disarmed timer")
+	return nil
+}
+
+func reset(ctx context.Context, sess bmc.Session, interval time.Duration) error {
+	resetCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
+	defer cancel()
+
+	if interval > 0 {
+		timer, err := bmc.GetWatchdogTimer(resetCtx, sess)
+		if err != nil {
+			return err
+		}
+		if initial := countdown(timer.InitialCountdown); interval >= initial {
+			return fmt.Errorf("--pet-interval must be less than the "+
+				"timer's initial countdown of %v", initial)
+		}
+	}
+	if err := bmc.ResetWatchdogTimer(resetCtx, sess); err != nil {
+		return err
+	}
+	log.Print("reset timer")
+	return pet(ctx, sess, interval)
+}
+
+// pet resets the timer every interval until the context is cancelled. It
+// returns immediately if interval is not positive. Failed resets are logged,
+// as the next may succeed before the timer expires.
+func pet(ctx context.Context, sess bmc.Session, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+	log.Printf("resetting timer every %v; interrupt to stop", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Print("stopped resetting timer; it will expire unless " +
+				"disarmed or reset")
+			return nil
+		case <-ticker.C:
+			resetCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
+			err := bmc.ResetWatchdogTimer(resetCtx, sess)
+			cancel()
+			if err != nil && ctx.Err() == nil {
+				log.Printf("failed to reset timer: %v", err)
+			}
+		}
+	}
+}
+
+// countdown converts a watchdog countdown to a duration.
+func countdown(c uint16) time.Duration {
+	return time.Duration(c) * ipmi.WatchdogCountdownUnit
+}
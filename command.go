@@ -0,0 +1,115 @@
+package bmc
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// Command defines an IPMI command in terms of its request and response layers,
+// removing the need to write an ipmi.Command implementation and a wrapper
+// function for each new command. It is primarily intended for OEM commands
+// defined outside this library, e.g.
+//
+//	var getFanMode = bmc.Command[*gopacket.Payload, *FanModeRsp]{
+//		Name:      "Get Fan Mode",
+//		Operation: ipmi.Operation{Function: ipmi.NetworkFunctionOEMReq, ...},
+//	}
+//
+//	rsp, err := getFanMode.Send(ctx, sess, nil)
+//
+// Req is the request layer type; a nil pointer sends a request with no data.
+// Rsp is the response layer type, usually a pointer to a struct. A Command is
+// immutable once defined, so can be used concurrently.
+type Command[Req gopacket.SerializableLayer, Rsp gopacket.DecodingLayer] struct {
+
+	// Name is the human-readable name of the command, e.g. "Get Fan Mode",
+	// used as the command label in metrics.
+	Name string
+
+	// Operation identifies the command. It must contain the request network
+	// function.
+	Operation ipmi.Operation
+
+	// NewResponse returns an empty response layer for each call to Send().
+	// This is optional if Rsp is a pointer type, in which case a new value of
+	// the type it points to is allocated.
+	NewResponse func() Rsp
+}
+
+// Send sends the command with the provided request, returning the decoded
+// response. An error is returned if the command could not be sent, the
+// completion code was non-normal, or the response failed to decode; in
+// particular, it is not possible to distinguish completion codes. Use
+// SendCommand() with the ipmi.Command returned by Cmd() if these need
+// handling.
+func (c *Command[Req, Rsp]) Send(ctx context.Context, conn Connection, req Req) (Rsp, error) {
+	cmd := c.Cmd(req)
+	if err := ValidateResponse(conn.SendCommand(ctx, cmd)); err != nil {
+		var zero Rsp
+		return zero, err
+	}
+	return cmd.rsp, nil
+}
+
+// Cmd returns an ipmi.Command for the provided request, for use with
+// SendCommand() or SendCommandRaw(). Its response layer is freshly allocated.
+func (c *Command[Req, Rsp]) Cmd(req Req) *GenericCmd[Req, Rsp] {
+	return &GenericCmd[Req, Rsp]{
+		def: c,
+		req: req,
+		rsp: c.newResponse(),
+	}
+}
+
+func (c *Command[Req, Rsp]) newResponse() Rsp {
+	if c.NewResponse != nil {
+		return c.NewResponse()
+	}
+	var rsp Rsp
+	if t := reflect.TypeOf(&rsp).Elem(); t.Kind() == reflect.Ptr {
+		rsp = reflect.New(t.Elem()).Interface().(Rsp)
+	}
+	return rsp
+}
+
+// GenericCmd is the ipmi.Command implementation for a Command and a particular
+// request.
+type GenericCmd[Req gopacket.SerializableLayer, Rsp gopacket.DecodingLayer] struct {
+	def *Command[Req, Rsp]
+	req Req
+	rsp Rsp
+}
+
+// Name returns the name of the command definition.
+func (c *GenericCmd[Req, Rsp]) Name() string {
+	return c.def.Name
+}
+
+// Operation returns the operation of the command definition.
+func (c *GenericCmd[Req, Rsp]) Operation() *ipmi.Operation {
+	return &c.def.Operation
+}
+
+// Request returns the request, or nil if it is a nil pointer.
+func (c *GenericCmd[Req, Rsp]) Request() gopacket.SerializableLayer {
+	if v := reflect.ValueOf(c.req); !v.IsValid() ||
+		v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return c.req
+}
+
+// Response returns the response layer, which is populated once the command has
+// been sent successfully.
+func (c *GenericCmd[Req, Rsp]) Response() gopacket.DecodingLayer {
+	return c.rsp
+}
+
+// Rsp returns the response layer, for use after SendCommand().
+func (c *GenericCmd[Req, Rsp]) Rsp() Rsp {
+	return c.rsp
+}
@@ -0,0 +1,124 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// replayConnection responds to every command with a fixed completion code and
+// response data.
+type replayConnection struct {
+	code ipmi.CompletionCode
+	data []byte
+
+	// request is the serialised request data of the last command sent.
+	request []byte
+}
+
+func (c *replayConnection) SendCommand(_ context.Context, cmd ipmi.Command) (ipmi.CompletionCode, error) {
+	c.request = nil
+	if cmd.Request() != nil {
+		buf := gopacket.NewSerializeBuffer()
+		if err := cmd.Request().SerializeTo(buf, serializeOptions); err != nil {
+			return 0, err
+		}
+		c.request = buf.Bytes()
+	}
+	if cmd.Response() != nil {
+		if err := cmd.Response().DecodeFromBytes(c.data,
+			gopacket.NilDecodeFeedback); err != nil {
+			return c.code, err
+		}
+	}
+	return c.code, nil
+}
+
+func (c *replayConnection) SendCommandRaw(ctx context.Context, cmd ipmi.Command) (*ipmi.Message, error) {
+	code, err := c.SendCommand(ctx, cmd)
+	return &ipmi.Message{CompletionCode: code}, err
+}
+
+func (*replayConnection) Version() string {
+	return "2.0"
+}
+
+func TestCommandSend(t *testing.T) {
+	getPOHCounter := Command[*gopacket.Payload, *ipmi.GetPOHCounterRsp]{
+		Name:      "Get POH Counter",
+		Operation: ipmi.OperationGetPOHCounterReq,
+	}
+	conn := &replayConnection{
+		data: []byte{60, 0x10, 0x00, 0x00, 0x00},
+	}
+	rsp, err := getPOHCounter.Send(context.Background(), conn, nil)
+	if err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if rsp.MinutesPerCount != 60 || rsp.Counter != 16 {
+		t.Errorf("Send() = %+v, want 60 minutes per count and counter 16", rsp)
+	}
+	if len(conn.request) != 0 {
+		t.Errorf("Send() with nil request sent %v, want no data", conn.request)
+	}
+
+	// each call must have its own response layer
+	conn.data = []byte{60, 0x20, 0x00, 0x00, 0x00}
+	second, err := getPOHCounter.Send(context.Background(), conn, nil)
+	if err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if rsp.Counter != 16 || second.Counter != 32 {
+		t.Errorf("Send() counters = %v, %v, want 16, 32", rsp.Counter,
+			second.Counter)
+	}
+
+	conn.code = ipmi.CompletionCodeUnrecognisedCommand
+	_, err = getPOHCounter.Send(context.Background(), conn, nil)
+	codeErr := (*CompletionCodeError)(nil)
+	if !errors.As(err, &codeErr) || codeErr.Code != conn.code {
+		t.Errorf("Send() with non-normal completion code = %v, want "+
+			"CompletionCodeError with %v", err, conn.code)
+	}
+	if errors.Is(err, ErrBMCBusy) {
+		t.Errorf("%v matches ErrBMCBusy", err)
+	}
+
+	conn.code = ipmi.CompletionCodeNodeBusy
+	if _, err := getPOHCounter.Send(context.Background(), conn, nil); !errors.Is(err, ErrBMCBusy) {
+		t.Errorf("Send() with %v = %v, want ErrBMCBusy", conn.code, err)
+	}
+}
+
+func TestCommandRequest(t *testing.T) {
+	getSessionInfo := Command[*ipmi.GetSessionInfoReq, *ipmi.GetSessionInfoRsp]{
+		Name:      "Get Session Info",
+		Operation: ipmi.OperationGetSessionInfoReq,
+		NewResponse: func() *ipmi.GetSessionInfoRsp {
+			return &ipmi.GetSessionInfoRsp{}
+		},
+	}
+	cmd := getSessionInfo.Cmd(&ipmi.GetSessionInfoReq{
+		Index: ipmi.SessionIndexCurrent,
+	})
+	if cmd.Name() != "Get Session Info" {
+		t.Errorf("Name() = %v, want Get Session Info", cmd.Name())
+	}
+	if *cmd.Operation() != ipmi.OperationGetSessionInfoReq {
+		t.Errorf("Operation() = %v, want %v", cmd.Operation(),
+			ipmi.OperationGetSessionInfoReq)
+	}
+	if cmd.Request() == nil {
+		t.Error("Request() = nil, want request layer")
+	}
+	if cmd.Rsp() == nil {
+		t.Error("Rsp() = nil, want response layer")
+	}
+	if getSessionInfo.Cmd(nil).Request() != nil {
+		t.Error("Request() with nil request is non-nil")
+	}
+}
@@ -0,0 +1,196 @@
+// Code generated by aliasgen. DO NOT EDIT.
+
+// Package bmc forwards to github.com/kuiwang02/bmc,
+// so code written against github.com/gebn/bmc compiles unchanged.
+package bmc
+
+import fork "github.com/kuiwang02/bmc"
+
+type (
+	ARPConfig                      = fork.ARPConfig
+	AdditionalKeyMaterialGenerator = fork.AdditionalKeyMaterialGenerator
+	AuditRecord                    = fork.AuditRecord
+	AuditSink                      = fork.AuditSink
+	BootConfig                     = fork.BootConfig
+	ChassisIntrusion               = fork.ChassisIntrusion
+	Clock                          = fork.Clock
+	CompletionCodeError            = fork.CompletionCodeError
+	Config                         = fork.Config
+	ConfigChange                   = fork.ConfigChange
+	Connection                     = fork.Connection
+	DetailedSensorReader           = fork.DetailedSensorReader
+	DialOpts                       = fork.DialOpts
+	EstablishmentError             = fork.EstablishmentError
+	EstablishmentStep              = fork.EstablishmentStep
+	Event                          = fork.Event
+	EventSource                    = fork.EventSource
+	EventsOpts                     = fork.EventsOpts
+	FRUInventory                   = fork.FRUInventory
+	FirmwareComponent              = fork.FirmwareComponent
+	FirmwareInventory              = fork.FirmwareInventory
+	HealthReason                   = fork.HealthReason
+	HealthReport                   = fork.HealthReport
+	HealthStatus                   = fork.HealthStatus
+	IdentitySource                 = fork.IdentitySource
+	IntrusionOpts                  = fork.IntrusionOpts
+	IntrusionSensor                = fork.IntrusionSensor
+	LANConfig                      = fork.LANConfig
+	LCD                            = fork.LCD
+	MachineCapabilities            = fork.MachineCapabilities
+	MachineIdentity                = fork.MachineIdentity
+	MachineInventory               = fork.MachineInventory
+	ManagementNIC                  = fork.ManagementNIC
+	NICMode                        = fork.NICMode
+	OEM                            = fork.OEM
+	OEMPayloadHandler              = fork.OEMPayloadHandler
+	PEFConfig                      = fork.PEFConfig
+	PasswordCompatibility          = fork.PasswordCompatibility
+	Pool                           = fork.Pool
+	PowerOpts                      = fork.PowerOpts
+	PowerState                     = fork.PowerState
+	PowerTransition                = fork.PowerTransition
+	Profile                        = fork.Profile
+	Quirks                         = fork.Quirks
+	RequestMetadata                = fork.RequestMetadata
+	ResumeOpts                     = fork.ResumeOpts
+	SDRProblem                     = fork.SDRProblem
+	SDRRepository                  = fork.SDRRepository
+	SOLConfig                      = fork.SOLConfig
+	SensorFilter                   = fork.SensorFilter
+	SensorReader                   = fork.SensorReader
+	SensorReading                  = fork.SensorReading
+	Session                        = fork.Session
+	SessionCommands                = fork.SessionCommands
+	SessionOpts                    = fork.SessionOpts
+	Sessionless                    = fork.Sessionless
+	SessionlessCommands            = fork.SessionlessCommands
+	SessionlessTransport           = fork.SessionlessTransport
+	TimeoutError                   = fork.TimeoutError
+	TimeoutStage                   = fork.TimeoutStage
+	Timeouts                       = fork.Timeouts
+	UnsolicitedPacket              = fork.UnsolicitedPacket
+	UserConfig                     = fork.UserConfig
+	V2Session                      = fork.V2Session
+	V2SessionOpts                  = fork.V2SessionOpts
+	V2SessionState                 = fork.V2SessionState
+	V2Sessionless                  = fork.V2Sessionless
+	V2SessionlessTransport         = fork.V2SessionlessTransport
+	VirtualMedia                   = fork.VirtualMedia
+)
+
+const (
+	EstablishmentStepCapabilities   = fork.EstablishmentStepCapabilities
+	EstablishmentStepComplete       = fork.EstablishmentStepComplete
+	EstablishmentStepOpenSession    = fork.EstablishmentStepOpenSession
+	EstablishmentStepRAKP1          = fork.EstablishmentStepRAKP1
+	EstablishmentStepRAKP3          = fork.EstablishmentStepRAKP3
+	EventSourceSEL                  = fork.EventSourceSEL
+	HealthStatusCritical            = fork.HealthStatusCritical
+	HealthStatusOK                  = fork.HealthStatusOK
+	HealthStatusWarning             = fork.HealthStatusWarning
+	IdentitySourceBoardSerial       = fork.IdentitySourceBoardSerial
+	IdentitySourceGUID              = fork.IdentitySourceGUID
+	IdentitySourceMACAddress        = fork.IdentitySourceMACAddress
+	NICModeDedicated                = fork.NICModeDedicated
+	NICModeFailover                 = fork.NICModeFailover
+	NICModeShared                   = fork.NICModeShared
+	PasswordCompatibilityAuto       = fork.PasswordCompatibilityAuto
+	PasswordCompatibilityExact      = fork.PasswordCompatibilityExact
+	PasswordCompatibilityTruncate16 = fork.PasswordCompatibilityTruncate16
+	PasswordCompatibilityTruncate20 = fork.PasswordCompatibilityTruncate20
+	PowerStateOff                   = fork.PowerStateOff
+	PowerStateOn                    = fork.PowerStateOn
+	QuirkIgnoreRAKP4ICV             = fork.QuirkIgnoreRAKP4ICV
+	QuirkRAKP2UsernamePadded        = fork.QuirkRAKP2UsernamePadded
+	TimeoutStageAwaitResponse       = fork.TimeoutStageAwaitResponse
+	TimeoutStageDecode              = fork.TimeoutStageDecode
+	TimeoutStageSend                = fork.TimeoutStageSend
+)
+
+var (
+	ApplyConfig                           = fork.ApplyConfig
+	Capabilities                          = fork.Capabilities
+	ClearLANStatistics                    = fork.ClearLANStatistics
+	ContextTimeouts                       = fork.ContextTimeouts
+	DiagnosticInterrupt                   = fork.DiagnosticInterrupt
+	Dial                                  = fork.Dial
+	DialV2                                = fork.DialV2
+	DialV2Context                         = fork.DialV2Context
+	DiffConfig                            = fork.DiffConfig
+	EnsurePowerState                      = fork.EnsurePowerState
+	ErrAuditSinkRequired                  = fork.ErrAuditSinkRequired
+	ErrBMCBusy                            = fork.ErrBMCBusy
+	ErrChecksum                           = fork.ErrChecksum
+	ErrDecode                             = fork.ErrDecode
+	ErrDiagnosticInterruptUnsupported     = fork.ErrDiagnosticInterruptUnsupported
+	ErrFrontPanelButtonDisableUnsupported = fork.ErrFrontPanelButtonDisableUnsupported
+	ErrFrontPanelLockoutNotApplied        = fork.ErrFrontPanelLockoutNotApplied
+	ErrIncorrectPassword                  = fork.ErrIncorrectPassword
+	ErrInsufficientPrivilege              = fork.ErrInsufficientPrivilege
+	ErrInvalidResumption                  = fork.ErrInvalidResumption
+	ErrNoMachineIdentity                  = fork.ErrNoMachineIdentity
+	ErrNotOEMPayload                      = fork.ErrNotOEMPayload
+	ErrOEMUnsupported                     = fork.ErrOEMUnsupported
+	ErrPacketTooLarge                     = fork.ErrPacketTooLarge
+	ErrPoolClosed                         = fork.ErrPoolClosed
+	ErrPowerStateNotReached               = fork.ErrPowerStateNotReached
+	ErrReadOnlySession                    = fork.ErrReadOnlySession
+	ErrSensorReadingUnavailable           = fork.ErrSensorReadingUnavailable
+	ErrSensorScanningDisabled             = fork.ErrSensorScanningDisabled
+	ErrSequenceNumbersExhausted           = fork.ErrSequenceNumbersExhausted
+	ErrSessionClosed                      = fork.ErrSessionClosed
+	ErrTimeout                            = fork.ErrTimeout
+	ErrTransportClosed                    = fork.ErrTransportClosed
+	ErrUnhandledPayload                   = fork.ErrUnhandledPayload
+	ErrWatchdogNotInitialised             = fork.ErrWatchdogNotInitialised
+	Events                                = fork.Events
+	ExportConfig                          = fork.ExportConfig
+	FirmwareVersion                       = fork.FirmwareVersion
+	GetARPConfig                          = fork.GetARPConfig
+	GetBootFlags                          = fork.GetBootFlags
+	GetChassisIntrusion                   = fork.GetChassisIntrusion
+	GetLANStatistics                      = fork.GetLANStatistics
+	GetMachineIdentity                    = fork.GetMachineIdentity
+	GetSystemInfoString                   = fork.GetSystemInfoString
+	GetWatchdogTimer                      = fork.GetWatchdogTimer
+	Health                                = fork.Health
+	Identify                              = fork.Identify
+	Inventory                             = fork.Inventory
+	IsOpenBMC                             = fork.IsOpenBMC
+	LoadFRUInventory                      = fork.LoadFRUInventory
+	LoadProfile                           = fork.LoadProfile
+	LoadSDRRepository                     = fork.LoadSDRRepository
+	LockFrontPanel                        = fork.LockFrontPanel
+	LookupOEM                             = fork.LookupOEM
+	NewMachineIdentity                    = fork.NewMachineIdentity
+	NewSensorReader                       = fork.NewSensorReader
+	OEMFor                                = fork.OEMFor
+	ReadFRUInventory                      = fork.ReadFRUInventory
+	RegisterOEM                           = fork.RegisterOEM
+	RequestMetadataFromContext            = fork.RequestMetadataFromContext
+	ResetWatchdogTimer                    = fork.ResetWatchdogTimer
+	RetrieveFRUDeviceLocators             = fork.RetrieveFRUDeviceLocators
+	RetrieveFilteredSDRRepository         = fork.RetrieveFilteredSDRRepository
+	RetrieveSDRRepository                 = fork.RetrieveSDRRepository
+	RetrieveSEL                           = fork.RetrieveSEL
+	SaveFRU                               = fork.SaveFRU
+	SaveProfile                           = fork.SaveProfile
+	SaveSDRRepository                     = fork.SaveSDRRepository
+	SetARPConfig                          = fork.SetARPConfig
+	SetBootFlags                          = fork.SetBootFlags
+	SetFrontPanelEnables                  = fork.SetFrontPanelEnables
+	SetSystemInfoString                   = fork.SetSystemInfoString
+	SetWatchdogTimer                      = fork.SetWatchdogTimer
+	SupportsDiagnosticInterrupt           = fork.SupportsDiagnosticInterrupt
+	SuspendARPs                           = fork.SuspendARPs
+	ValidateResponse                      = fork.ValidateResponse
+	VerifySDRDump                         = fork.VerifySDRDump
+	VerifySDRRepository                   = fork.VerifySDRRepository
+	WaitFor                               = fork.WaitFor
+	WatchdogCountdown                     = fork.WatchdogCountdown
+	WithCommandTimeout                    = fork.WithCommandTimeout
+	WithRequestMetadata                   = fork.WithRequestMetadata
+	WithTimeouts                          = fork.WithTimeouts
+	WithoutAuthentication                 = fork.WithoutAuthentication
+	WithoutEncryption                     = fork.WithoutEncryption
+)
@@ -0,0 +1,33 @@
+package bmc_test
+
+import (
+	"context"
+	"testing"
+
+	fork "github.com/kuiwang02/bmc"
+	forkipmi "github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/gebn/bmc"
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// TestInterchangeable checks values can be passed between code using the
+// upstream and fork import paths, which requires the types to be identical.
+func TestInterchangeable(t *testing.T) {
+	var upstream bmc.SessionlessTransport
+	var f fork.SessionlessTransport = upstream
+	upstream = f
+
+	cmd := &ipmi.GetDeviceIDCmd{}
+	var _ forkipmi.Command = cmd
+	if cmd.Operation() != &forkipmi.OperationGetDeviceIDReq {
+		t.Error("operation not shared between import paths")
+	}
+
+	// functions are forwarded
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := bmc.Dial(ctx, "bmc.example.com"); err == nil {
+		t.Error("Dial() with cancelled context succeeded")
+	}
+}
@@ -0,0 +1,30 @@
+// Code generated by aliasgen. DO NOT EDIT.
+
+// Package iana forwards to github.com/kuiwang02/bmc/pkg/iana,
+// so code written against github.com/gebn/bmc/pkg/iana compiles unchanged.
+package iana
+
+import fork "github.com/kuiwang02/bmc/pkg/iana"
+
+type (
+	Enterprise = fork.Enterprise
+)
+
+const (
+	EnterpriseAten       = fork.EnterpriseAten
+	EnterpriseDell       = fork.EnterpriseDell
+	EnterpriseGigaByte   = fork.EnterpriseGigaByte
+	EnterpriseHP         = fork.EnterpriseHP
+	EnterpriseHPE        = fork.EnterpriseHPE
+	EnterpriseIBM        = fork.EnterpriseIBM
+	EnterpriseIntel      = fork.EnterpriseIntel
+	EnterpriseLenovo     = fork.EnterpriseLenovo
+	EnterpriseOpenBMC    = fork.EnterpriseOpenBMC
+	EnterpriseQuanta     = fork.EnterpriseQuanta
+	EnterpriseReserved   = fork.EnterpriseReserved
+	EnterpriseSuperMicro = fork.EnterpriseSuperMicro
+)
+
+var (
+	RegisterEnterprise = fork.RegisterEnterprise
+)
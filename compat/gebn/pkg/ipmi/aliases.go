@@ -0,0 +1,1010 @@
+// Code generated by aliasgen. DO NOT EDIT.
+
+// Package ipmi forwards to github.com/kuiwang02/bmc/pkg/ipmi,
+// so code written against github.com/gebn/bmc/pkg/ipmi compiles unchanged.
+package ipmi
+
+import fork "github.com/kuiwang02/bmc/pkg/ipmi"
+
+type (
+	AES128CBC                               = fork.AES128CBC
+	AddSDRCmd                               = fork.AddSDRCmd
+	AddSDRReq                               = fork.AddSDRReq
+	AddSDRRsp                               = fork.AddSDRRsp
+	Address                                 = fork.Address
+	AddressedCommand                        = fork.AddressedCommand
+	AnalogDataFormat                        = fork.AnalogDataFormat
+	AnalogDataFormatParser                  = fork.AnalogDataFormatParser
+	AnalogDataFormatParserFunc              = fork.AnalogDataFormatParserFunc
+	AuthenticationAlgorithm                 = fork.AuthenticationAlgorithm
+	AuthenticationPayload                   = fork.AuthenticationPayload
+	AuthenticationType                      = fork.AuthenticationType
+	BodyCode                                = fork.BodyCode
+	BootDevice                              = fork.BootDevice
+	BootFlags                               = fork.BootFlags
+	BootOptionParameter                     = fork.BootOptionParameter
+	Channel                                 = fork.Channel
+	ChassisControl                          = fork.ChassisControl
+	ChassisControlCmd                       = fork.ChassisControlCmd
+	ChassisControlReq                       = fork.ChassisControlReq
+	ChassisIdentifyCmd                      = fork.ChassisIdentifyCmd
+	ChassisIdentifyReq                      = fork.ChassisIdentifyReq
+	ChassisIdentifyState                    = fork.ChassisIdentifyState
+	CipherSuite                             = fork.CipherSuite
+	ClearSDRRepositoryCmd                   = fork.ClearSDRRepositoryCmd
+	ClearSDRRepositoryReq                   = fork.ClearSDRRepositoryReq
+	ClearSDRRepositoryRsp                   = fork.ClearSDRRepositoryRsp
+	ClearSELAction                          = fork.ClearSELAction
+	ClearSELCmd                             = fork.ClearSELCmd
+	ClearSELReq                             = fork.ClearSELReq
+	ClearSELRsp                             = fork.ClearSELRsp
+	CloseSessionCmd                         = fork.CloseSessionCmd
+	CloseSessionReq                         = fork.CloseSessionReq
+	Command                                 = fork.Command
+	CommandNumber                           = fork.CommandNumber
+	CompletionCode                          = fork.CompletionCode
+	ConfidentialityAlgorithm                = fork.ConfidentialityAlgorithm
+	ConfidentialityPayload                  = fork.ConfidentialityPayload
+	ConversionFactors                       = fork.ConversionFactors
+	DeleteSDRCmd                            = fork.DeleteSDRCmd
+	DeleteSDRReq                            = fork.DeleteSDRReq
+	DeleteSDRRsp                            = fork.DeleteSDRRsp
+	EffectCommand                           = fork.EffectCommand
+	EntityID                                = fork.EntityID
+	EntityInstance                          = fork.EntityInstance
+	ErasureProgress                         = fork.ErasureProgress
+	FRUBoardInfoArea                        = fork.FRUBoardInfoArea
+	FRUChassisInfoArea                      = fork.FRUChassisInfoArea
+	FRUCommonHeader                         = fork.FRUCommonHeader
+	FRUDeviceLocatorRecord                  = fork.FRUDeviceLocatorRecord
+	FRUProductInfoArea                      = fork.FRUProductInfoArea
+	FullSensorRecord                        = fork.FullSensorRecord
+	GetChannelAuthenticationCapabilitiesCmd = fork.GetChannelAuthenticationCapabilitiesCmd
+	GetChannelAuthenticationCapabilitiesReq = fork.GetChannelAuthenticationCapabilitiesReq
+	GetChannelAuthenticationCapabilitiesRsp = fork.GetChannelAuthenticationCapabilitiesRsp
+	GetChannelOEMPayloadInfoCmd             = fork.GetChannelOEMPayloadInfoCmd
+	GetChannelOEMPayloadInfoReq             = fork.GetChannelOEMPayloadInfoReq
+	GetChannelOEMPayloadInfoRsp             = fork.GetChannelOEMPayloadInfoRsp
+	GetChassisCapabilitiesCmd               = fork.GetChassisCapabilitiesCmd
+	GetChassisCapabilitiesRsp               = fork.GetChassisCapabilitiesRsp
+	GetChassisStatusCmd                     = fork.GetChassisStatusCmd
+	GetChassisStatusRsp                     = fork.GetChassisStatusRsp
+	GetDeviceIDCmd                          = fork.GetDeviceIDCmd
+	GetDeviceIDRsp                          = fork.GetDeviceIDRsp
+	GetFRUInventoryAreaInfoCmd              = fork.GetFRUInventoryAreaInfoCmd
+	GetFRUInventoryAreaInfoReq              = fork.GetFRUInventoryAreaInfoReq
+	GetFRUInventoryAreaInfoRsp              = fork.GetFRUInventoryAreaInfoRsp
+	GetIPUDPRMCPStatisticsCmd               = fork.GetIPUDPRMCPStatisticsCmd
+	GetIPUDPRMCPStatisticsReq               = fork.GetIPUDPRMCPStatisticsReq
+	GetIPUDPRMCPStatisticsRsp               = fork.GetIPUDPRMCPStatisticsRsp
+	GetLANConfigurationParametersCmd        = fork.GetLANConfigurationParametersCmd
+	GetLANConfigurationParametersReq        = fork.GetLANConfigurationParametersReq
+	GetLANConfigurationParametersRsp        = fork.GetLANConfigurationParametersRsp
+	GetPEFConfigurationParametersCmd        = fork.GetPEFConfigurationParametersCmd
+	GetPEFConfigurationParametersReq        = fork.GetPEFConfigurationParametersReq
+	GetPEFConfigurationParametersRsp        = fork.GetPEFConfigurationParametersRsp
+	GetPOHCounterCmd                        = fork.GetPOHCounterCmd
+	GetPOHCounterRsp                        = fork.GetPOHCounterRsp
+	GetSDRCmd                               = fork.GetSDRCmd
+	GetSDRRepositoryAllocationInfoCmd       = fork.GetSDRRepositoryAllocationInfoCmd
+	GetSDRRepositoryAllocationInfoRsp       = fork.GetSDRRepositoryAllocationInfoRsp
+	GetSDRRepositoryInfoCmd                 = fork.GetSDRRepositoryInfoCmd
+	GetSDRRepositoryInfoRsp                 = fork.GetSDRRepositoryInfoRsp
+	GetSDRReq                               = fork.GetSDRReq
+	GetSDRRsp                               = fork.GetSDRRsp
+	GetSELEntryCmd                          = fork.GetSELEntryCmd
+	GetSELEntryReq                          = fork.GetSELEntryReq
+	GetSELEntryRsp                          = fork.GetSELEntryRsp
+	GetSELInfoCmd                           = fork.GetSELInfoCmd
+	GetSELInfoRsp                           = fork.GetSELInfoRsp
+	GetSELTimeCmd                           = fork.GetSELTimeCmd
+	GetSELTimeRsp                           = fork.GetSELTimeRsp
+	GetSELTimeUTCOffsetCmd                  = fork.GetSELTimeUTCOffsetCmd
+	GetSELTimeUTCOffsetRsp                  = fork.GetSELTimeUTCOffsetRsp
+	GetSOLConfigurationParametersCmd        = fork.GetSOLConfigurationParametersCmd
+	GetSOLConfigurationParametersReq        = fork.GetSOLConfigurationParametersReq
+	GetSOLConfigurationParametersRsp        = fork.GetSOLConfigurationParametersRsp
+	GetSensorReadingCmd                     = fork.GetSensorReadingCmd
+	GetSensorReadingFactorsCmd              = fork.GetSensorReadingFactorsCmd
+	GetSensorReadingFactorsReq              = fork.GetSensorReadingFactorsReq
+	GetSensorReadingFactorsRsp              = fork.GetSensorReadingFactorsRsp
+	GetSensorReadingReq                     = fork.GetSensorReadingReq
+	GetSensorReadingRsp                     = fork.GetSensorReadingRsp
+	GetSessionInfoCmd                       = fork.GetSessionInfoCmd
+	GetSessionInfoReq                       = fork.GetSessionInfoReq
+	GetSessionInfoRsp                       = fork.GetSessionInfoRsp
+	GetSystemBootOptionsCmd                 = fork.GetSystemBootOptionsCmd
+	GetSystemBootOptionsReq                 = fork.GetSystemBootOptionsReq
+	GetSystemBootOptionsRsp                 = fork.GetSystemBootOptionsRsp
+	GetSystemGUIDCmd                        = fork.GetSystemGUIDCmd
+	GetSystemGUIDRsp                        = fork.GetSystemGUIDRsp
+	GetSystemInfoParametersCmd              = fork.GetSystemInfoParametersCmd
+	GetSystemInfoParametersReq              = fork.GetSystemInfoParametersReq
+	GetSystemInfoParametersRsp              = fork.GetSystemInfoParametersRsp
+	GetUserAccessCmd                        = fork.GetUserAccessCmd
+	GetUserAccessReq                        = fork.GetUserAccessReq
+	GetUserAccessRsp                        = fork.GetUserAccessRsp
+	GetUserNameCmd                          = fork.GetUserNameCmd
+	GetUserNameReq                          = fork.GetUserNameReq
+	GetUserNameRsp                          = fork.GetUserNameRsp
+	GetWatchdogTimerCmd                     = fork.GetWatchdogTimerCmd
+	GetWatchdogTimerRsp                     = fork.GetWatchdogTimerRsp
+	IPAddressSource                         = fork.IPAddressSource
+	IntegrityAlgorithm                      = fork.IntegrityAlgorithm
+	IntegrityPayload                        = fork.IntegrityPayload
+	LANConfigurationParameter               = fork.LANConfigurationParameter
+	LUN                                     = fork.LUN
+	LUNCommand                              = fork.LUNCommand
+	LastPowerEvent                          = fork.LastPowerEvent
+	Linearisation                           = fork.Linearisation
+	Lineariser                              = fork.Lineariser
+	LineariserFunc                          = fork.LineariserFunc
+	Message                                 = fork.Message
+	ModifierRelation                        = fork.ModifierRelation
+	NetworkFunction                         = fork.NetworkFunction
+	OpenSessionPayload                      = fork.OpenSessionPayload
+	OpenSessionReq                          = fork.OpenSessionReq
+	OpenSessionRsp                          = fork.OpenSessionRsp
+	Operation                               = fork.Operation
+	OperationEffect                         = fork.OperationEffect
+	OutputType                              = fork.OutputType
+	PEFAction                               = fork.PEFAction
+	PEFConfigurationParameter               = fork.PEFConfigurationParameter
+	PEFControl                              = fork.PEFControl
+	PartialAddSDRCmd                        = fork.PartialAddSDRCmd
+	PartialAddSDRReq                        = fork.PartialAddSDRReq
+	PartialAddSDRRsp                        = fork.PartialAddSDRRsp
+	Payload                                 = fork.Payload
+	PayloadDescriptor                       = fork.PayloadDescriptor
+	PayloadType                             = fork.PayloadType
+	PowerRestorePolicy                      = fork.PowerRestorePolicy
+	PrivilegeLevel                          = fork.PrivilegeLevel
+	RAKPMessage1                            = fork.RAKPMessage1
+	RAKPMessage1Payload                     = fork.RAKPMessage1Payload
+	RAKPMessage2                            = fork.RAKPMessage2
+	RAKPMessage3                            = fork.RAKPMessage3
+	RAKPMessage3Payload                     = fork.RAKPMessage3Payload
+	RAKPMessage4                            = fork.RAKPMessage4
+	RateUnit                                = fork.RateUnit
+	ReadFRUDataCmd                          = fork.ReadFRUDataCmd
+	ReadFRUDataReq                          = fork.ReadFRUDataReq
+	ReadFRUDataRsp                          = fork.ReadFRUDataRsp
+	RearmSensorEventsCmd                    = fork.RearmSensorEventsCmd
+	RearmSensorEventsReq                    = fork.RearmSensorEventsReq
+	RecordID                                = fork.RecordID
+	RecordType                              = fork.RecordType
+	ReservationID                           = fork.ReservationID
+	ReserveSDRRepositoryCmd                 = fork.ReserveSDRRepositoryCmd
+	ReserveSDRRepositoryRsp                 = fork.ReserveSDRRepositoryRsp
+	ReserveSELCmd                           = fork.ReserveSELCmd
+	ReserveSELRsp                           = fork.ReserveSELRsp
+	ResetWatchdogTimerCmd                   = fork.ResetWatchdogTimerCmd
+	ResponseLength                          = fork.ResponseLength
+	ResponseLengthError                     = fork.ResponseLengthError
+	RetryPolicy                             = fork.RetryPolicy
+	RunInitializationAgentCmd               = fork.RunInitializationAgentCmd
+	RunInitializationAgentReq               = fork.RunInitializationAgentReq
+	RunInitializationAgentRsp               = fork.RunInitializationAgentRsp
+	SDR                                     = fork.SDR
+	SELEventRecord                          = fork.SELEventRecord
+	SOLBitRate                              = fork.SOLBitRate
+	SOLConfigurationParameter               = fork.SOLConfigurationParameter
+	SensorDirection                         = fork.SensorDirection
+	SensorRecordKey                         = fork.SensorRecordKey
+	SensorType                              = fork.SensorType
+	SensorUnit                              = fork.SensorUnit
+	SessionHandle                           = fork.SessionHandle
+	SessionIndex                            = fork.SessionIndex
+	SessionSelector                         = fork.SessionSelector
+	SetChassisCapabilitiesCmd               = fork.SetChassisCapabilitiesCmd
+	SetChassisCapabilitiesReq               = fork.SetChassisCapabilitiesReq
+	SetFrontPanelEnablesCmd                 = fork.SetFrontPanelEnablesCmd
+	SetFrontPanelEnablesReq                 = fork.SetFrontPanelEnablesReq
+	SetLANConfigurationParametersCmd        = fork.SetLANConfigurationParametersCmd
+	SetLANConfigurationParametersReq        = fork.SetLANConfigurationParametersReq
+	SetPEFConfigurationParametersCmd        = fork.SetPEFConfigurationParametersCmd
+	SetPEFConfigurationParametersReq        = fork.SetPEFConfigurationParametersReq
+	SetPowerRestorePolicyCmd                = fork.SetPowerRestorePolicyCmd
+	SetPowerRestorePolicyReq                = fork.SetPowerRestorePolicyReq
+	SetPowerRestorePolicyRsp                = fork.SetPowerRestorePolicyRsp
+	SetSELTimeCmd                           = fork.SetSELTimeCmd
+	SetSELTimeReq                           = fork.SetSELTimeReq
+	SetSOLConfigurationParametersCmd        = fork.SetSOLConfigurationParametersCmd
+	SetSOLConfigurationParametersReq        = fork.SetSOLConfigurationParametersReq
+	SetSystemBootOptionsCmd                 = fork.SetSystemBootOptionsCmd
+	SetSystemBootOptionsReq                 = fork.SetSystemBootOptionsReq
+	SetSystemInfoParametersCmd              = fork.SetSystemInfoParametersCmd
+	SetSystemInfoParametersReq              = fork.SetSystemInfoParametersReq
+	SetUserAccessCmd                        = fork.SetUserAccessCmd
+	SetUserAccessReq                        = fork.SetUserAccessReq
+	SetUserNameCmd                          = fork.SetUserNameCmd
+	SetUserNameReq                          = fork.SetUserNameReq
+	SetUserPasswordCmd                      = fork.SetUserPasswordCmd
+	SetUserPasswordReq                      = fork.SetUserPasswordReq
+	SetWatchdogTimerCmd                     = fork.SetWatchdogTimerCmd
+	SetWatchdogTimerReq                     = fork.SetWatchdogTimerReq
+	SlaveAddress                            = fork.SlaveAddress
+	SoftwareID                              = fork.SoftwareID
+	StatusCode                              = fork.StatusCode
+	StringDecoder                           = fork.StringDecoder
+	StringDecoderFunc                       = fork.StringDecoderFunc
+	StringEncoding                          = fork.StringEncoding
+	SuspendBMCARPsCmd                       = fork.SuspendBMCARPsCmd
+	SuspendBMCARPsReq                       = fork.SuspendBMCARPsReq
+	SuspendBMCARPsRsp                       = fork.SuspendBMCARPsRsp
+	SystemInfoParameter                     = fork.SystemInfoParameter
+	ThresholdStatus                         = fork.ThresholdStatus
+	Timestamp                               = fork.Timestamp
+	Unit                                    = fork.Unit
+	UserPasswordOperation                   = fork.UserPasswordOperation
+	UserStatus                              = fork.UserStatus
+	V1Session                               = fork.V1Session
+	V2Parser                                = fork.V2Parser
+	V2Session                               = fork.V2Session
+	WatchdogPreTimeoutInterrupt             = fork.WatchdogPreTimeoutInterrupt
+	WatchdogTimeoutAction                   = fork.WatchdogTimeoutAction
+	WatchdogTimerUse                        = fork.WatchdogTimerUse
+)
+
+const (
+	AnalogDataFormatNotAnalog                           = fork.AnalogDataFormatNotAnalog
+	AnalogDataFormatOnesComplement                      = fork.AnalogDataFormatOnesComplement
+	AnalogDataFormatTwosComplement                      = fork.AnalogDataFormatTwosComplement
+	AnalogDataFormatUnsigned                            = fork.AnalogDataFormatUnsigned
+	AuthenticationAlgorithmHMACMD5                      = fork.AuthenticationAlgorithmHMACMD5
+	AuthenticationAlgorithmHMACSHA1                     = fork.AuthenticationAlgorithmHMACSHA1
+	AuthenticationAlgorithmHMACSHA256                   = fork.AuthenticationAlgorithmHMACSHA256
+	AuthenticationAlgorithmNone                         = fork.AuthenticationAlgorithmNone
+	AuthenticationTypeMD2                               = fork.AuthenticationTypeMD2
+	AuthenticationTypeMD5                               = fork.AuthenticationTypeMD5
+	AuthenticationTypeNone                              = fork.AuthenticationTypeNone
+	AuthenticationTypeOEM                               = fork.AuthenticationTypeOEM
+	AuthenticationTypePassword                          = fork.AuthenticationTypePassword
+	AuthenticationTypeRMCPPlus                          = fork.AuthenticationTypeRMCPPlus
+	BodyCodeDCMI                                        = fork.BodyCodeDCMI
+	BodyCodeDMTF                                        = fork.BodyCodeDMTF
+	BodyCodePICMG                                       = fork.BodyCodePICMG
+	BodyCodeSSI                                         = fork.BodyCodeSSI
+	BodyCodeVSO                                         = fork.BodyCodeVSO
+	BootDeviceBIOSSetup                                 = fork.BootDeviceBIOSSetup
+	BootDeviceCDROM                                     = fork.BootDeviceCDROM
+	BootDeviceDiagnosticPartition                       = fork.BootDeviceDiagnosticPartition
+	BootDeviceDisk                                      = fork.BootDeviceDisk
+	BootDeviceDiskSafeMode                              = fork.BootDeviceDiskSafeMode
+	BootDeviceFloppy                                    = fork.BootDeviceFloppy
+	BootDeviceNone                                      = fork.BootDeviceNone
+	BootDevicePXE                                       = fork.BootDevicePXE
+	BootDeviceRemoteCDROM                               = fork.BootDeviceRemoteCDROM
+	BootDeviceRemoteDisk                                = fork.BootDeviceRemoteDisk
+	BootDeviceRemoteFloppy                              = fork.BootDeviceRemoteFloppy
+	BootDeviceRemoteMedia                               = fork.BootDeviceRemoteMedia
+	BootOptionParameterBMCBootFlagValidBitClearing      = fork.BootOptionParameterBMCBootFlagValidBitClearing
+	BootOptionParameterBootFlags                        = fork.BootOptionParameterBootFlags
+	BootOptionParameterBootInfoAcknowledge              = fork.BootOptionParameterBootInfoAcknowledge
+	BootOptionParameterBootInitiatorInfo                = fork.BootOptionParameterBootInitiatorInfo
+	BootOptionParameterBootInitiatorMailbox             = fork.BootOptionParameterBootInitiatorMailbox
+	BootOptionParameterServicePartitionScan             = fork.BootOptionParameterServicePartitionScan
+	BootOptionParameterServicePartitionSelector         = fork.BootOptionParameterServicePartitionSelector
+	BootOptionParameterSetInProgress                    = fork.BootOptionParameterSetInProgress
+	ChannelPresentInterface                             = fork.ChannelPresentInterface
+	ChannelPrimaryIPMB                                  = fork.ChannelPrimaryIPMB
+	ChannelSystemInterface                              = fork.ChannelSystemInterface
+	ChassisControlDiagnosticInterrupt                   = fork.ChassisControlDiagnosticInterrupt
+	ChassisControlHardReset                             = fork.ChassisControlHardReset
+	ChassisControlPowerCycle                            = fork.ChassisControlPowerCycle
+	ChassisControlPowerOff                              = fork.ChassisControlPowerOff
+	ChassisControlPowerOn                               = fork.ChassisControlPowerOn
+	ChassisControlSoftPowerOff                          = fork.ChassisControlSoftPowerOff
+	ChassisIdentifyStateIndefinite                      = fork.ChassisIdentifyStateIndefinite
+	ChassisIdentifyStateOff                             = fork.ChassisIdentifyStateOff
+	ChassisIdentifyStateTemporary                       = fork.ChassisIdentifyStateTemporary
+	ChassisIdentifyStateUnknown                         = fork.ChassisIdentifyStateUnknown
+	ClearSELActionGetErasureStatus                      = fork.ClearSELActionGetErasureStatus
+	ClearSELActionInitiateErase                         = fork.ClearSELActionInitiateErase
+	CompletionCodeCannotReturnRequestedDataBytes        = fork.CompletionCodeCannotReturnRequestedDataBytes
+	CompletionCodeDestinationUnavailable                = fork.CompletionCodeDestinationUnavailable
+	CompletionCodeDuplicateRequest                      = fork.CompletionCodeDuplicateRequest
+	CompletionCodeFirmwareUpdateMode                    = fork.CompletionCodeFirmwareUpdateMode
+	CompletionCodeIllegalForSensorOrRecordType          = fork.CompletionCodeIllegalForSensorOrRecordType
+	CompletionCodeIllegalInPresentState                 = fork.CompletionCodeIllegalInPresentState
+	CompletionCodeInitializationInProgress              = fork.CompletionCodeInitializationInProgress
+	CompletionCodeInsufficientPrivileges                = fork.CompletionCodeInsufficientPrivileges
+	CompletionCodeInvalidCommandForLUN                  = fork.CompletionCodeInvalidCommandForLUN
+	CompletionCodeInvalidDataField                      = fork.CompletionCodeInvalidDataField
+	CompletionCodeInvalidSessionID                      = fork.CompletionCodeInvalidSessionID
+	CompletionCodeNodeBusy                              = fork.CompletionCodeNodeBusy
+	CompletionCodeNormal                                = fork.CompletionCodeNormal
+	CompletionCodeNotPresent                            = fork.CompletionCodeNotPresent
+	CompletionCodeOutOfSpace                            = fork.CompletionCodeOutOfSpace
+	CompletionCodeParameterOutOfRange                   = fork.CompletionCodeParameterOutOfRange
+	CompletionCodePasswordMismatch                      = fork.CompletionCodePasswordMismatch
+	CompletionCodePasswordSizeMismatch                  = fork.CompletionCodePasswordSizeMismatch
+	CompletionCodeRequestDataFieldLengthLimitExceeded   = fork.CompletionCodeRequestDataFieldLengthLimitExceeded
+	CompletionCodeRequestDataLengthInvalid              = fork.CompletionCodeRequestDataLengthInvalid
+	CompletionCodeRequestTruncated                      = fork.CompletionCodeRequestTruncated
+	CompletionCodeReservationCancelled                  = fork.CompletionCodeReservationCancelled
+	CompletionCodeResponseUnavailable                   = fork.CompletionCodeResponseUnavailable
+	CompletionCodeSDRRepositoryInUpdateMode             = fork.CompletionCodeSDRRepositoryInUpdateMode
+	CompletionCodeSubFunctionUnavailable                = fork.CompletionCodeSubFunctionUnavailable
+	CompletionCodeTimeout                               = fork.CompletionCodeTimeout
+	CompletionCodeUnrecognisedCommand                   = fork.CompletionCodeUnrecognisedCommand
+	CompletionCodeUnspecified                           = fork.CompletionCodeUnspecified
+	CompletionCodeWatchdogNotInitialised                = fork.CompletionCodeWatchdogNotInitialised
+	ConfidentialityAlgorithmAESCBC128                   = fork.ConfidentialityAlgorithmAESCBC128
+	ConfidentialityAlgorithmNone                        = fork.ConfidentialityAlgorithmNone
+	ConfidentialityAlgorithmXRC4128                     = fork.ConfidentialityAlgorithmXRC4128
+	ConfidentialityAlgorithmXRC440                      = fork.ConfidentialityAlgorithmXRC440
+	EntityIDAddInCard                                   = fork.EntityIDAddInCard
+	EntityIDAirInlet                                    = fork.EntityIDAirInlet
+	EntityIDBackPanelBoard                              = fork.EntityIDBackPanelBoard
+	EntityIDBattery                                     = fork.EntityIDBattery
+	EntityIDCableInterconnect                           = fork.EntityIDCableInterconnect
+	EntityIDChassisBackPanelBoard                       = fork.EntityIDChassisBackPanelBoard
+	EntityIDChassisPeripheralBay                        = fork.EntityIDChassisPeripheralBay
+	EntityIDConnectivitySwitch                          = fork.EntityIDConnectivitySwitch
+	EntityIDCoolingDevice                               = fork.EntityIDCoolingDevice
+	EntityIDCoolingUnit                                 = fork.EntityIDCoolingUnit
+	EntityIDDCMIAirInlet                                = fork.EntityIDDCMIAirInlet
+	EntityIDDCMIProcessor                               = fork.EntityIDDCMIProcessor
+	EntityIDDCMISystemBoard                             = fork.EntityIDDCMISystemBoard
+	EntityIDDeviceBay                                   = fork.EntityIDDeviceBay
+	EntityIDDisk                                        = fork.EntityIDDisk
+	EntityIDDiskDriveBay                                = fork.EntityIDDiskDriveBay
+	EntityIDDriveBackplane                              = fork.EntityIDDriveBackplane
+	EntityIDExternalEnvironment                         = fork.EntityIDExternalEnvironment
+	EntityIDFrontPanelBoard                             = fork.EntityIDFrontPanelBoard
+	EntityIDGroup                                       = fork.EntityIDGroup
+	EntityIDIOModule                                    = fork.EntityIDIOModule
+	EntityIDIPMIChannel                                 = fork.EntityIDIPMIChannel
+	EntityIDManagementControllerFirmware                = fork.EntityIDManagementControllerFirmware
+	EntityIDMemoryDevice                                = fork.EntityIDMemoryDevice
+	EntityIDMemoryModule                                = fork.EntityIDMemoryModule
+	EntityIDOperatingSystem                             = fork.EntityIDOperatingSystem
+	EntityIDOther                                       = fork.EntityIDOther
+	EntityIDOtherChassisBoard                           = fork.EntityIDOtherChassisBoard
+	EntityIDOtherSystemBoard                            = fork.EntityIDOtherSystemBoard
+	EntityIDPCIBus                                      = fork.EntityIDPCIBus
+	EntityIDPCIExpressBus                               = fork.EntityIDPCIExpressBus
+	EntityIDPeripheralBay                               = fork.EntityIDPeripheralBay
+	EntityIDPowerManagementBoard                        = fork.EntityIDPowerManagementBoard
+	EntityIDPowerModule                                 = fork.EntityIDPowerModule
+	EntityIDPowerSupply                                 = fork.EntityIDPowerSupply
+	EntityIDPowerSystemBoard                            = fork.EntityIDPowerSystemBoard
+	EntityIDPowerUnit                                   = fork.EntityIDPowerUnit
+	EntityIDProcessingBlade                             = fork.EntityIDProcessingBlade
+	EntityIDProcessor                                   = fork.EntityIDProcessor
+	EntityIDProcessorBoard                              = fork.EntityIDProcessorBoard
+	EntityIDProcessorFrontSideBus                       = fork.EntityIDProcessorFrontSideBus
+	EntityIDProcessorIOModule                           = fork.EntityIDProcessorIOModule
+	EntityIDProcessorMemoryModule                       = fork.EntityIDProcessorMemoryModule
+	EntityIDProcessorModule                             = fork.EntityIDProcessorModule
+	EntityIDRealTimeClock                               = fork.EntityIDRealTimeClock
+	EntityIDRemoteManagementCommunicationDevice         = fork.EntityIDRemoteManagementCommunicationDevice
+	EntityIDSATASASBus                                  = fork.EntityIDSATASASBus
+	EntityIDSCSIBus                                     = fork.EntityIDSCSIBus
+	EntityIDSubChassis                                  = fork.EntityIDSubChassis
+	EntityIDSystemBoard                                 = fork.EntityIDSystemBoard
+	EntityIDSystemBus                                   = fork.EntityIDSystemBus
+	EntityIDSystemChassis                               = fork.EntityIDSystemChassis
+	EntityIDSystemFirmware                              = fork.EntityIDSystemFirmware
+	EntityIDSystemInternalExpansionBoard                = fork.EntityIDSystemInternalExpansionBoard
+	EntityIDSystemManagementModule                      = fork.EntityIDSystemManagementModule
+	EntityIDSystemManagementSoftware                    = fork.EntityIDSystemManagementSoftware
+	EntityIDUnknown                                     = fork.EntityIDUnknown
+	EntityIDUnspecified                                 = fork.EntityIDUnspecified
+	ErasureProgressCompleted                            = fork.ErasureProgressCompleted
+	ErasureProgressInProgress                           = fork.ErasureProgressInProgress
+	FRULanguageCodeEnglish                              = fork.FRULanguageCodeEnglish
+	IPAddressSourceBIOS                                 = fork.IPAddressSourceBIOS
+	IPAddressSourceDHCP                                 = fork.IPAddressSourceDHCP
+	IPAddressSourceOther                                = fork.IPAddressSourceOther
+	IPAddressSourceStatic                               = fork.IPAddressSourceStatic
+	IPAddressSourceUnspecified                          = fork.IPAddressSourceUnspecified
+	IntegrityAlgorithmHMACMD5128                        = fork.IntegrityAlgorithmHMACMD5128
+	IntegrityAlgorithmHMACSHA196                        = fork.IntegrityAlgorithmHMACSHA196
+	IntegrityAlgorithmHMACSHA256128                     = fork.IntegrityAlgorithmHMACSHA256128
+	IntegrityAlgorithmMD5128                            = fork.IntegrityAlgorithmMD5128
+	IntegrityAlgorithmNone                              = fork.IntegrityAlgorithmNone
+	LANConfigurationParameterAuthenticationTypeEnables  = fork.LANConfigurationParameterAuthenticationTypeEnables
+	LANConfigurationParameterAuthenticationTypeSupport  = fork.LANConfigurationParameterAuthenticationTypeSupport
+	LANConfigurationParameterBMCGeneratedARPControl     = fork.LANConfigurationParameterBMCGeneratedARPControl
+	LANConfigurationParameterBackupGatewayAddress       = fork.LANConfigurationParameterBackupGatewayAddress
+	LANConfigurationParameterBackupGatewayMACAddress    = fork.LANConfigurationParameterBackupGatewayMACAddress
+	LANConfigurationParameterCipherSuiteEntries         = fork.LANConfigurationParameterCipherSuiteEntries
+	LANConfigurationParameterCipherSuiteEntrySupport    = fork.LANConfigurationParameterCipherSuiteEntrySupport
+	LANConfigurationParameterCipherSuitePrivilegeLevels = fork.LANConfigurationParameterCipherSuitePrivilegeLevels
+	LANConfigurationParameterCommunityString            = fork.LANConfigurationParameterCommunityString
+	LANConfigurationParameterDefaultGatewayAddress      = fork.LANConfigurationParameterDefaultGatewayAddress
+	LANConfigurationParameterDefaultGatewayMACAddress   = fork.LANConfigurationParameterDefaultGatewayMACAddress
+	LANConfigurationParameterDestinationAddressVLANTags = fork.LANConfigurationParameterDestinationAddressVLANTags
+	LANConfigurationParameterDestinationAddresses       = fork.LANConfigurationParameterDestinationAddresses
+	LANConfigurationParameterDestinationType            = fork.LANConfigurationParameterDestinationType
+	LANConfigurationParameterDestinations               = fork.LANConfigurationParameterDestinations
+	LANConfigurationParameterGratuitousARPInterval      = fork.LANConfigurationParameterGratuitousARPInterval
+	LANConfigurationParameterIPAddress                  = fork.LANConfigurationParameterIPAddress
+	LANConfigurationParameterIPAddressSource            = fork.LANConfigurationParameterIPAddressSource
+	LANConfigurationParameterIPv4HeaderParameters       = fork.LANConfigurationParameterIPv4HeaderParameters
+	LANConfigurationParameterMACAddress                 = fork.LANConfigurationParameterMACAddress
+	LANConfigurationParameterPrimaryRMCPPort            = fork.LANConfigurationParameterPrimaryRMCPPort
+	LANConfigurationParameterSecondaryRMCPPort          = fork.LANConfigurationParameterSecondaryRMCPPort
+	LANConfigurationParameterSetInProgress              = fork.LANConfigurationParameterSetInProgress
+	LANConfigurationParameterSubnetMask                 = fork.LANConfigurationParameterSubnetMask
+	LANConfigurationParameterVLANID                     = fork.LANConfigurationParameterVLANID
+	LANConfigurationParameterVLANPriority               = fork.LANConfigurationParameterVLANPriority
+	LUNBMC                                              = fork.LUNBMC
+	LUNSMS                                              = fork.LUNSMS
+	LastPowerEventACFailed                              = fork.LastPowerEventACFailed
+	LastPowerEventCommand                               = fork.LastPowerEventCommand
+	LastPowerEventFault                                 = fork.LastPowerEventFault
+	LastPowerEventInterlock                             = fork.LastPowerEventInterlock
+	LastPowerEventOverload                              = fork.LastPowerEventOverload
+	LinearisationCube                                   = fork.LinearisationCube
+	LinearisationCubeRt                                 = fork.LinearisationCubeRt
+	LinearisationE                                      = fork.LinearisationE
+	LinearisationExp10                                  = fork.LinearisationExp10
+	LinearisationExp2                                   = fork.LinearisationExp2
+	LinearisationInverse                                = fork.LinearisationInverse
+	LinearisationLinear                                 = fork.LinearisationLinear
+	LinearisationLn                                     = fork.LinearisationLn
+	LinearisationLog10                                  = fork.LinearisationLog10
+	LinearisationLog2                                   = fork.LinearisationLog2
+	LinearisationNonLinear                              = fork.LinearisationNonLinear
+	LinearisationSqr                                    = fork.LinearisationSqr
+	LinearisationSqrt                                   = fork.LinearisationSqrt
+	ModifierRelationDivide                              = fork.ModifierRelationDivide
+	ModifierRelationMultiply                            = fork.ModifierRelationMultiply
+	ModifierRelationNone                                = fork.ModifierRelationNone
+	NetworkFunctionAppReq                               = fork.NetworkFunctionAppReq
+	NetworkFunctionAppRsp                               = fork.NetworkFunctionAppRsp
+	NetworkFunctionBridgeReq                            = fork.NetworkFunctionBridgeReq
+	NetworkFunctionBridgeRsp                            = fork.NetworkFunctionBridgeRsp
+	NetworkFunctionChassisReq                           = fork.NetworkFunctionChassisReq
+	NetworkFunctionChassisRsp                           = fork.NetworkFunctionChassisRsp
+	NetworkFunctionFirmwareReq                          = fork.NetworkFunctionFirmwareReq
+	NetworkFunctionFirmwareRsp                          = fork.NetworkFunctionFirmwareRsp
+	NetworkFunctionGroupReq                             = fork.NetworkFunctionGroupReq
+	NetworkFunctionGroupRsp                             = fork.NetworkFunctionGroupRsp
+	NetworkFunctionOEMReq                               = fork.NetworkFunctionOEMReq
+	NetworkFunctionOEMRsp                               = fork.NetworkFunctionOEMRsp
+	NetworkFunctionSensorReq                            = fork.NetworkFunctionSensorReq
+	NetworkFunctionSensorRsp                            = fork.NetworkFunctionSensorRsp
+	NetworkFunctionStorageReq                           = fork.NetworkFunctionStorageReq
+	NetworkFunctionStorageRsp                           = fork.NetworkFunctionStorageRsp
+	NetworkFunctionTransportReq                         = fork.NetworkFunctionTransportReq
+	NetworkFunctionTransportRsp                         = fork.NetworkFunctionTransportRsp
+	OperationEffectDestructive                          = fork.OperationEffectDestructive
+	OperationEffectNone                                 = fork.OperationEffectNone
+	OperationEffectStateChanging                        = fork.OperationEffectStateChanging
+	OutputTypeACPIDevicePowerState                      = fork.OutputTypeACPIDevicePowerState
+	OutputTypeAvailabilityState                         = fork.OutputTypeAvailabilityState
+	OutputTypeDMIUsageState                             = fork.OutputTypeDMIUsageState
+	OutputTypeDeviceEnabled                             = fork.OutputTypeDeviceEnabled
+	OutputTypeDevicePresence                            = fork.OutputTypeDevicePresence
+	OutputTypeDigitalState                              = fork.OutputTypeDigitalState
+	OutputTypeLimitExceeded                             = fork.OutputTypeLimitExceeded
+	OutputTypePerformance                               = fork.OutputTypePerformance
+	OutputTypePredictiveFailure                         = fork.OutputTypePredictiveFailure
+	OutputTypeRedundancy                                = fork.OutputTypeRedundancy
+	OutputTypeSensorSpecific                            = fork.OutputTypeSensorSpecific
+	OutputTypeSeverity                                  = fork.OutputTypeSeverity
+	OutputTypeThreshold                                 = fork.OutputTypeThreshold
+	PEFActionAlert                                      = fork.PEFActionAlert
+	PEFActionDiagnosticInterrupt                        = fork.PEFActionDiagnosticInterrupt
+	PEFActionOEM                                        = fork.PEFActionOEM
+	PEFActionPowerCycle                                 = fork.PEFActionPowerCycle
+	PEFActionPowerDown                                  = fork.PEFActionPowerDown
+	PEFActionReset                                      = fork.PEFActionReset
+	PEFConfigurationParameterActionGlobalControl        = fork.PEFConfigurationParameterActionGlobalControl
+	PEFConfigurationParameterAlertPolicies              = fork.PEFConfigurationParameterAlertPolicies
+	PEFConfigurationParameterAlertPolicyTable           = fork.PEFConfigurationParameterAlertPolicyTable
+	PEFConfigurationParameterAlertStartupDelay          = fork.PEFConfigurationParameterAlertStartupDelay
+	PEFConfigurationParameterAlertStringKeys            = fork.PEFConfigurationParameterAlertStringKeys
+	PEFConfigurationParameterAlertStrings               = fork.PEFConfigurationParameterAlertStrings
+	PEFConfigurationParameterAlertStringsTable          = fork.PEFConfigurationParameterAlertStringsTable
+	PEFConfigurationParameterControl                    = fork.PEFConfigurationParameterControl
+	PEFConfigurationParameterEventFilterTable           = fork.PEFConfigurationParameterEventFilterTable
+	PEFConfigurationParameterEventFilterTableData1      = fork.PEFConfigurationParameterEventFilterTableData1
+	PEFConfigurationParameterEventFilters               = fork.PEFConfigurationParameterEventFilters
+	PEFConfigurationParameterSetInProgress              = fork.PEFConfigurationParameterSetInProgress
+	PEFConfigurationParameterStartupDelay               = fork.PEFConfigurationParameterStartupDelay
+	PEFConfigurationParameterSystemGUID                 = fork.PEFConfigurationParameterSystemGUID
+	PEFControlAlertStartupDelay                         = fork.PEFControlAlertStartupDelay
+	PEFControlEnable                                    = fork.PEFControlEnable
+	PEFControlEventMessages                             = fork.PEFControlEventMessages
+	PEFControlStartupDelay                              = fork.PEFControlStartupDelay
+	PayloadTypeIPMI                                     = fork.PayloadTypeIPMI
+	PayloadTypeOEM                                      = fork.PayloadTypeOEM
+	PayloadTypeOEM0                                     = fork.PayloadTypeOEM0
+	PayloadTypeOEM1                                     = fork.PayloadTypeOEM1
+	PayloadTypeOEM2                                     = fork.PayloadTypeOEM2
+	PayloadTypeOEM3                                     = fork.PayloadTypeOEM3
+	PayloadTypeOEM4                                     = fork.PayloadTypeOEM4
+	PayloadTypeOEM5                                     = fork.PayloadTypeOEM5
+	PayloadTypeOEM6                                     = fork.PayloadTypeOEM6
+	PayloadTypeOEM7                                     = fork.PayloadTypeOEM7
+	PayloadTypeOpenSessionReq                           = fork.PayloadTypeOpenSessionReq
+	PayloadTypeOpenSessionRsp                           = fork.PayloadTypeOpenSessionRsp
+	PayloadTypeRAKPMessage1                             = fork.PayloadTypeRAKPMessage1
+	PayloadTypeRAKPMessage2                             = fork.PayloadTypeRAKPMessage2
+	PayloadTypeRAKPMessage3                             = fork.PayloadTypeRAKPMessage3
+	PayloadTypeRAKPMessage4                             = fork.PayloadTypeRAKPMessage4
+	PayloadTypeSOL                                      = fork.PayloadTypeSOL
+	PowerRestorePolicyPowerOn                           = fork.PowerRestorePolicyPowerOn
+	PowerRestorePolicyPriorState                        = fork.PowerRestorePolicyPriorState
+	PowerRestorePolicyRemainOff                         = fork.PowerRestorePolicyRemainOff
+	PowerRestorePolicyUnknown                           = fork.PowerRestorePolicyUnknown
+	PrivilegeLevelAdministrator                         = fork.PrivilegeLevelAdministrator
+	PrivilegeLevelCallback                              = fork.PrivilegeLevelCallback
+	PrivilegeLevelHighest                               = fork.PrivilegeLevelHighest
+	PrivilegeLevelNoAccess                              = fork.PrivilegeLevelNoAccess
+	PrivilegeLevelOEM                                   = fork.PrivilegeLevelOEM
+	PrivilegeLevelOperator                              = fork.PrivilegeLevelOperator
+	PrivilegeLevelUser                                  = fork.PrivilegeLevelUser
+	RateUnitNone                                        = fork.RateUnitNone
+	RateUnitPerDay                                      = fork.RateUnitPerDay
+	RateUnitPerHour                                     = fork.RateUnitPerHour
+	RateUnitPerMicrosecond                              = fork.RateUnitPerMicrosecond
+	RateUnitPerMillisecond                              = fork.RateUnitPerMillisecond
+	RateUnitPerMinute                                   = fork.RateUnitPerMinute
+	RateUnitPerSecond                                   = fork.RateUnitPerSecond
+	RecordIDFirst                                       = fork.RecordIDFirst
+	RecordIDLast                                        = fork.RecordIDLast
+	RecordTypeBMCMessageChannelInfo                     = fork.RecordTypeBMCMessageChannelInfo
+	RecordTypeCompactSensor                             = fork.RecordTypeCompactSensor
+	RecordTypeDeviceRelativeEntityAssociation           = fork.RecordTypeDeviceRelativeEntityAssociation
+	RecordTypeEntityAssociation                         = fork.RecordTypeEntityAssociation
+	RecordTypeEventOnly                                 = fork.RecordTypeEventOnly
+	RecordTypeFRUDeviceLocator                          = fork.RecordTypeFRUDeviceLocator
+	RecordTypeFullSensor                                = fork.RecordTypeFullSensor
+	RecordTypeGenericDeviceLocator                      = fork.RecordTypeGenericDeviceLocator
+	RecordTypeManagementControllerConfirmation          = fork.RecordTypeManagementControllerConfirmation
+	RecordTypeManagementControllerDeviceLocator         = fork.RecordTypeManagementControllerDeviceLocator
+	SELRecordTypeSystemEvent                            = fork.SELRecordTypeSystemEvent
+	SOLBitRate115200                                    = fork.SOLBitRate115200
+	SOLBitRate19200                                     = fork.SOLBitRate19200
+	SOLBitRate38400                                     = fork.SOLBitRate38400
+	SOLBitRate57600                                     = fork.SOLBitRate57600
+	SOLBitRate9600                                      = fork.SOLBitRate9600
+	SOLBitRateSerial                                    = fork.SOLBitRateSerial
+	SOLConfigurationParameterAuthentication             = fork.SOLConfigurationParameterAuthentication
+	SOLConfigurationParameterCharacterAccumulate        = fork.SOLConfigurationParameterCharacterAccumulate
+	SOLConfigurationParameterEnable                     = fork.SOLConfigurationParameterEnable
+	SOLConfigurationParameterNonVolatileBitRate         = fork.SOLConfigurationParameterNonVolatileBitRate
+	SOLConfigurationParameterPayloadChannel             = fork.SOLConfigurationParameterPayloadChannel
+	SOLConfigurationParameterPayloadPort                = fork.SOLConfigurationParameterPayloadPort
+	SOLConfigurationParameterRetry                      = fork.SOLConfigurationParameterRetry
+	SOLConfigurationParameterSetInProgress              = fork.SOLConfigurationParameterSetInProgress
+	SOLConfigurationParameterVolatileBitRate            = fork.SOLConfigurationParameterVolatileBitRate
+	SensorDirectionInput                                = fork.SensorDirectionInput
+	SensorDirectionOutput                               = fork.SensorDirectionOutput
+	SensorDirectionUnspecified                          = fork.SensorDirectionUnspecified
+	SensorTypeAddInCard                                 = fork.SensorTypeAddInCard
+	SensorTypeBaseOSBootInstallationStatus              = fork.SensorTypeBaseOSBootInstallationStatus
+	SensorTypeBattery                                   = fork.SensorTypeBattery
+	SensorTypeBootError                                 = fork.SensorTypeBootError
+	SensorTypeButtonSwitch                              = fork.SensorTypeButtonSwitch
+	SensorTypeCableInterconnect                         = fork.SensorTypeCableInterconnect
+	SensorTypeChassis                                   = fork.SensorTypeChassis
+	SensorTypeChipSet                                   = fork.SensorTypeChipSet
+	SensorTypeCoolingDevice                             = fork.SensorTypeCoolingDevice
+	SensorTypeCriticalInterrupt                         = fork.SensorTypeCriticalInterrupt
+	SensorTypeCurrent                                   = fork.SensorTypeCurrent
+	SensorTypeDriveBay                                  = fork.SensorTypeDriveBay
+	SensorTypeEntityPresence                            = fork.SensorTypeEntityPresence
+	SensorTypeEventLoggingDisabled                      = fork.SensorTypeEventLoggingDisabled
+	SensorTypeFRUState                                  = fork.SensorTypeFRUState
+	SensorTypeFan                                       = fork.SensorTypeFan
+	SensorTypeLAN                                       = fork.SensorTypeLAN
+	SensorTypeManagementSubsystemHealth                 = fork.SensorTypeManagementSubsystemHealth
+	SensorTypeMemory                                    = fork.SensorTypeMemory
+	SensorTypeMicrocontrollerCoprocessor                = fork.SensorTypeMicrocontrollerCoprocessor
+	SensorTypeModuleBoard                               = fork.SensorTypeModuleBoard
+	SensorTypeMonitorASIC                               = fork.SensorTypeMonitorASIC
+	SensorTypeOSStopShutdown                            = fork.SensorTypeOSStopShutdown
+	SensorTypeOtherFRU                                  = fork.SensorTypeOtherFRU
+	SensorTypeOtherUnitsBasedSensor                     = fork.SensorTypeOtherUnitsBasedSensor
+	SensorTypePOSTMemoryResize                          = fork.SensorTypePOSTMemoryResize
+	SensorTypePhysicalSecurity                          = fork.SensorTypePhysicalSecurity
+	SensorTypePlatformAlert                             = fork.SensorTypePlatformAlert
+	SensorTypePlatformSecurity                          = fork.SensorTypePlatformSecurity
+	SensorTypePowerSupply                               = fork.SensorTypePowerSupply
+	SensorTypePowerUnit                                 = fork.SensorTypePowerUnit
+	SensorTypeProcessor                                 = fork.SensorTypeProcessor
+	SensorTypeSessionAudit                              = fork.SensorTypeSessionAudit
+	SensorTypeSlotConnector                             = fork.SensorTypeSlotConnector
+	SensorTypeSystemACPIPowerState                      = fork.SensorTypeSystemACPIPowerState
+	SensorTypeSystemBootRestartInitiated                = fork.SensorTypeSystemBootRestartInitiated
+	SensorTypeSystemEvent                               = fork.SensorTypeSystemEvent
+	SensorTypeSystemFirmwareProgress                    = fork.SensorTypeSystemFirmwareProgress
+	SensorTypeTemperature                               = fork.SensorTypeTemperature
+	SensorTypeTerminator                                = fork.SensorTypeTerminator
+	SensorTypeVersionChange                             = fork.SensorTypeVersionChange
+	SensorTypeVoltage                                   = fork.SensorTypeVoltage
+	SensorTypeWatchdog1                                 = fork.SensorTypeWatchdog1
+	SensorTypeWatchdog2                                 = fork.SensorTypeWatchdog2
+	SensorUnitAmps                                      = fork.SensorUnitAmps
+	SensorUnitBecquerel                                 = fork.SensorUnitBecquerel
+	SensorUnitBits                                      = fork.SensorUnitBits
+	SensorUnitBytes                                     = fork.SensorUnitBytes
+	SensorUnitCandela                                   = fork.SensorUnitCandela
+	SensorUnitCelsius                                   = fork.SensorUnitCelsius
+	SensorUnitCentimeters                               = fork.SensorUnitCentimeters
+	SensorUnitCharacters                                = fork.SensorUnitCharacters
+	SensorUnitCollisions                                = fork.SensorUnitCollisions
+	SensorUnitColorTempKelvin                           = fork.SensorUnitColorTempKelvin
+	SensorUnitCorrectableErrors                         = fork.SensorUnitCorrectableErrors
+	SensorUnitCoulombs                                  = fork.SensorUnitCoulombs
+	SensorUnitCubicCentimeters                          = fork.SensorUnitCubicCentimeters
+	SensorUnitCubicFeet                                 = fork.SensorUnitCubicFeet
+	SensorUnitCubicFeetPerMinute                        = fork.SensorUnitCubicFeetPerMinute
+	SensorUnitCubicInches                               = fork.SensorUnitCubicInches
+	SensorUnitCubicMeters                               = fork.SensorUnitCubicMeters
+	SensorUnitCycles                                    = fork.SensorUnitCycles
+	SensorUnitDays                                      = fork.SensorUnitDays
+	SensorUnitDecibels                                  = fork.SensorUnitDecibels
+	SensorUnitDecibelsAFilter                           = fork.SensorUnitDecibelsAFilter
+	SensorUnitDecibelsCFilter                           = fork.SensorUnitDecibelsCFilter
+	SensorUnitDwords                                    = fork.SensorUnitDwords
+	SensorUnitErrors                                    = fork.SensorUnitErrors
+	SensorUnitFahrenheit                                = fork.SensorUnitFahrenheit
+	SensorUnitFarad                                     = fork.SensorUnitFarad
+	SensorUnitFatal                                     = fork.SensorUnitFatal
+	SensorUnitFeet                                      = fork.SensorUnitFeet
+	SensorUnitFeetPounds                                = fork.SensorUnitFeetPounds
+	SensorUnitFluidOunces                               = fork.SensorUnitFluidOunces
+	SensorUnitGauss                                     = fork.SensorUnitGauss
+	SensorUnitGigabits                                  = fork.SensorUnitGigabits
+	SensorUnitGigabytes                                 = fork.SensorUnitGigabytes
+	SensorUnitGilberts                                  = fork.SensorUnitGilberts
+	SensorUnitGrams                                     = fork.SensorUnitGrams
+	SensorUnitGravities                                 = fork.SensorUnitGravities
+	SensorUnitGray                                      = fork.SensorUnitGray
+	SensorUnitHenry                                     = fork.SensorUnitHenry
+	SensorUnitHertz                                     = fork.SensorUnitHertz
+	SensorUnitHits                                      = fork.SensorUnitHits
+	SensorUnitHours                                     = fork.SensorUnitHours
+	SensorUnitInches                                    = fork.SensorUnitInches
+	SensorUnitJoules                                    = fork.SensorUnitJoules
+	SensorUnitKelvin                                    = fork.SensorUnitKelvin
+	SensorUnitKilobits                                  = fork.SensorUnitKilobits
+	SensorUnitKilobytes                                 = fork.SensorUnitKilobytes
+	SensorUnitKilopascals                               = fork.SensorUnitKilopascals
+	SensorUnitLiters                                    = fork.SensorUnitLiters
+	SensorUnitLumen                                     = fork.SensorUnitLumen
+	SensorUnitLux                                       = fork.SensorUnitLux
+	SensorUnitMegabits                                  = fork.SensorUnitMegabits
+	SensorUnitMegabytes                                 = fork.SensorUnitMegabytes
+	SensorUnitMemoryLines                               = fork.SensorUnitMemoryLines
+	SensorUnitMessages                                  = fork.SensorUnitMessages
+	SensorUnitMeters                                    = fork.SensorUnitMeters
+	SensorUnitMicrofarad                                = fork.SensorUnitMicrofarad
+	SensorUnitMicroseconds                              = fork.SensorUnitMicroseconds
+	SensorUnitMillihenry                                = fork.SensorUnitMillihenry
+	SensorUnitMillimeters                               = fork.SensorUnitMillimeters
+	SensorUnitMilliseconds                              = fork.SensorUnitMilliseconds
+	SensorUnitMils                                      = fork.SensorUnitMils
+	SensorUnitMinutes                                   = fork.SensorUnitMinutes
+	SensorUnitMisses                                    = fork.SensorUnitMisses
+	SensorUnitMoles                                     = fork.SensorUnitMoles
+	SensorUnitNewtons                                   = fork.SensorUnitNewtons
+	SensorUnitNits                                      = fork.SensorUnitNits
+	SensorUnitOhms                                      = fork.SensorUnitOhms
+	SensorUnitOunceInches                               = fork.SensorUnitOunceInches
+	SensorUnitOunces                                    = fork.SensorUnitOunces
+	SensorUnitOverflows                                 = fork.SensorUnitOverflows
+	SensorUnitPackets                                   = fork.SensorUnitPackets
+	SensorUnitPartsPerMillion                           = fork.SensorUnitPartsPerMillion
+	SensorUnitPounds                                    = fork.SensorUnitPounds
+	SensorUnitPoundsPerSquareInch                       = fork.SensorUnitPoundsPerSquareInch
+	SensorUnitQwords                                    = fork.SensorUnitQwords
+	SensorUnitRadians                                   = fork.SensorUnitRadians
+	SensorUnitResets                                    = fork.SensorUnitResets
+	SensorUnitRetries                                   = fork.SensorUnitRetries
+	SensorUnitRevolutions                               = fork.SensorUnitRevolutions
+	SensorUnitRotationsPerMinute                        = fork.SensorUnitRotationsPerMinute
+	SensorUnitSeconds                                   = fork.SensorUnitSeconds
+	SensorUnitSiemens                                   = fork.SensorUnitSiemens
+	SensorUnitSieverts                                  = fork.SensorUnitSieverts
+	SensorUnitSteradians                                = fork.SensorUnitSteradians
+	SensorUnitUncorrectableErrors                       = fork.SensorUnitUncorrectableErrors
+	SensorUnitUnderruns                                 = fork.SensorUnitUnderruns
+	SensorUnitVoltamperes                               = fork.SensorUnitVoltamperes
+	SensorUnitVolts                                     = fork.SensorUnitVolts
+	SensorUnitWatts                                     = fork.SensorUnitWatts
+	SensorUnitWeeks                                     = fork.SensorUnitWeeks
+	SensorUnitWords                                     = fork.SensorUnitWords
+	SessionIndexCurrent                                 = fork.SessionIndexCurrent
+	SessionIndexHandle                                  = fork.SessionIndexHandle
+	SessionIndexID                                      = fork.SessionIndexID
+	SlaveAddressBMC                                     = fork.SlaveAddressBMC
+	SoftwareIDRemoteConsole1                            = fork.SoftwareIDRemoteConsole1
+	SoftwareIDRemoteConsole2                            = fork.SoftwareIDRemoteConsole2
+	SoftwareIDRemoteConsole3                            = fork.SoftwareIDRemoteConsole3
+	SoftwareIDRemoteConsole4                            = fork.SoftwareIDRemoteConsole4
+	SoftwareIDRemoteConsole5                            = fork.SoftwareIDRemoteConsole5
+	SoftwareIDRemoteConsole6                            = fork.SoftwareIDRemoteConsole6
+	SoftwareIDRemoteConsole7                            = fork.SoftwareIDRemoteConsole7
+	SoftwareIDTerminalModeRemoteConsole                 = fork.SoftwareIDTerminalModeRemoteConsole
+	StatusCodeInsufficientResources                     = fork.StatusCodeInsufficientResources
+	StatusCodeInvalidIntegrityCheckValue                = fork.StatusCodeInvalidIntegrityCheckValue
+	StatusCodeInvalidSessionID                          = fork.StatusCodeInvalidSessionID
+	StatusCodeNoCipherSuiteMatch                        = fork.StatusCodeNoCipherSuiteMatch
+	StatusCodeOK                                        = fork.StatusCodeOK
+	StatusCodeUnauthorisedName                          = fork.StatusCodeUnauthorisedName
+	StringEncoding8BitAsciiLatin1                       = fork.StringEncoding8BitAsciiLatin1
+	StringEncodingBCDPlus                               = fork.StringEncodingBCDPlus
+	StringEncodingPacked6BitAscii                       = fork.StringEncodingPacked6BitAscii
+	StringEncodingUnicode                               = fork.StringEncodingUnicode
+	SystemInfoParameterOperatingSystemName              = fork.SystemInfoParameterOperatingSystemName
+	SystemInfoParameterPrimaryOperatingSystemName       = fork.SystemInfoParameterPrimaryOperatingSystemName
+	SystemInfoParameterSetInProgress                    = fork.SystemInfoParameterSetInProgress
+	SystemInfoParameterSystemFirmwareVersion            = fork.SystemInfoParameterSystemFirmwareVersion
+	SystemInfoParameterSystemName                       = fork.SystemInfoParameterSystemName
+	ThresholdStatusLowerCritical                        = fork.ThresholdStatusLowerCritical
+	ThresholdStatusLowerNonCritical                     = fork.ThresholdStatusLowerNonCritical
+	ThresholdStatusLowerNonRecoverable                  = fork.ThresholdStatusLowerNonRecoverable
+	ThresholdStatusUpperCritical                        = fork.ThresholdStatusUpperCritical
+	ThresholdStatusUpperNonCritical                     = fork.ThresholdStatusUpperNonCritical
+	ThresholdStatusUpperNonRecoverable                  = fork.ThresholdStatusUpperNonRecoverable
+	TimestampPostInitMax                                = fork.TimestampPostInitMax
+	TimestampUnspecified                                = fork.TimestampUnspecified
+	UserPasswordOperationDisableUser                    = fork.UserPasswordOperationDisableUser
+	UserPasswordOperationEnableUser                     = fork.UserPasswordOperationEnableUser
+	UserPasswordOperationSetPassword                    = fork.UserPasswordOperationSetPassword
+	UserPasswordOperationTestPassword                   = fork.UserPasswordOperationTestPassword
+	UserStatusDisabled                                  = fork.UserStatusDisabled
+	UserStatusEnabled                                   = fork.UserStatusEnabled
+	UserStatusUnspecified                               = fork.UserStatusUnspecified
+	WatchdogCountdownUnit                               = fork.WatchdogCountdownUnit
+	WatchdogPreTimeoutInterruptMessaging                = fork.WatchdogPreTimeoutInterruptMessaging
+	WatchdogPreTimeoutInterruptNMI                      = fork.WatchdogPreTimeoutInterruptNMI
+	WatchdogPreTimeoutInterruptNone                     = fork.WatchdogPreTimeoutInterruptNone
+	WatchdogPreTimeoutInterruptSMI                      = fork.WatchdogPreTimeoutInterruptSMI
+	WatchdogTimeoutActionHardReset                      = fork.WatchdogTimeoutActionHardReset
+	WatchdogTimeoutActionNone                           = fork.WatchdogTimeoutActionNone
+	WatchdogTimeoutActionPowerCycle                     = fork.WatchdogTimeoutActionPowerCycle
+	WatchdogTimeoutActionPowerDown                      = fork.WatchdogTimeoutActionPowerDown
+	WatchdogTimerUseBIOSFRB2                            = fork.WatchdogTimerUseBIOSFRB2
+	WatchdogTimerUseBIOSPOST                            = fork.WatchdogTimerUseBIOSPOST
+	WatchdogTimerUseOEM                                 = fork.WatchdogTimerUseOEM
+	WatchdogTimerUseOSLoad                              = fork.WatchdogTimerUseOSLoad
+	WatchdogTimerUseSMSOS                               = fork.WatchdogTimerUseSMSOS
+)
+
+var (
+	CipherSuiteIDs                                   = fork.CipherSuiteIDs
+	CommandWithAddress                               = fork.CommandWithAddress
+	CommandWithLUN                                   = fork.CommandWithLUN
+	ErrInvalidChecksum                               = fork.ErrInvalidChecksum
+	ErrInvalidSignature                              = fork.ErrInvalidSignature
+	ErrNotLinearised                                 = fork.ErrNotLinearised
+	ErrProbablyInsufficientPrivilege                 = fork.ErrProbablyInsufficientPrivilege
+	ErrProbablyUnsupported                           = fork.ErrProbablyUnsupported
+	FRUAreaLength                                    = fork.FRUAreaLength
+	LayerTypeAddSDRReq                               = fork.LayerTypeAddSDRReq
+	LayerTypeAddSDRRsp                               = fork.LayerTypeAddSDRRsp
+	LayerTypeBootFlags                               = fork.LayerTypeBootFlags
+	LayerTypeChassisControlReq                       = fork.LayerTypeChassisControlReq
+	LayerTypeChassisIdentifyReq                      = fork.LayerTypeChassisIdentifyReq
+	LayerTypeClearSDRRepositoryReq                   = fork.LayerTypeClearSDRRepositoryReq
+	LayerTypeClearSDRRepositoryRsp                   = fork.LayerTypeClearSDRRepositoryRsp
+	LayerTypeClearSELReq                             = fork.LayerTypeClearSELReq
+	LayerTypeClearSELRsp                             = fork.LayerTypeClearSELRsp
+	LayerTypeCloseSessionReq                         = fork.LayerTypeCloseSessionReq
+	LayerTypeDeleteSDRReq                            = fork.LayerTypeDeleteSDRReq
+	LayerTypeDeleteSDRRsp                            = fork.LayerTypeDeleteSDRRsp
+	LayerTypeFRUBoardInfoArea                        = fork.LayerTypeFRUBoardInfoArea
+	LayerTypeFRUChassisInfoArea                      = fork.LayerTypeFRUChassisInfoArea
+	LayerTypeFRUCommonHeader                         = fork.LayerTypeFRUCommonHeader
+	LayerTypeFRUDeviceLocatorRecord                  = fork.LayerTypeFRUDeviceLocatorRecord
+	LayerTypeFRUProductInfoArea                      = fork.LayerTypeFRUProductInfoArea
+	LayerTypeFullSensorRecord                        = fork.LayerTypeFullSensorRecord
+	LayerTypeGetChannelAuthenticationCapabilitiesReq = fork.LayerTypeGetChannelAuthenticationCapabilitiesReq
+	LayerTypeGetChannelAuthenticationCapabilitiesRsp = fork.LayerTypeGetChannelAuthenticationCapabilitiesRsp
+	LayerTypeGetChannelOEMPayloadInfoReq             = fork.LayerTypeGetChannelOEMPayloadInfoReq
+	LayerTypeGetChannelOEMPayloadInfoRsp             = fork.LayerTypeGetChannelOEMPayloadInfoRsp
+	LayerTypeGetChassisCapabilitiesRsp               = fork.LayerTypeGetChassisCapabilitiesRsp
+	LayerTypeGetChassisStatusRsp                     = fork.LayerTypeGetChassisStatusRsp
+	LayerTypeGetDeviceIDRsp                          = fork.LayerTypeGetDeviceIDRsp
+	LayerTypeGetFRUInventoryAreaInfoReq              = fork.LayerTypeGetFRUInventoryAreaInfoReq
+	LayerTypeGetFRUInventoryAreaInfoRsp              = fork.LayerTypeGetFRUInventoryAreaInfoRsp
+	LayerTypeGetIPUDPRMCPStatisticsReq               = fork.LayerTypeGetIPUDPRMCPStatisticsReq
+	LayerTypeGetIPUDPRMCPStatisticsRsp               = fork.LayerTypeGetIPUDPRMCPStatisticsRsp
+	LayerTypeGetLANConfigurationParametersReq        = fork.LayerTypeGetLANConfigurationParametersReq
+	LayerTypeGetLANConfigurationParametersRsp        = fork.LayerTypeGetLANConfigurationParametersRsp
+	LayerTypeGetPEFConfigurationParametersReq        = fork.LayerTypeGetPEFConfigurationParametersReq
+	LayerTypeGetPEFConfigurationParametersRsp        = fork.LayerTypeGetPEFConfigurationParametersRsp
+	LayerTypeGetPOHCounterRsp                        = fork.LayerTypeGetPOHCounterRsp
+	LayerTypeGetSDRRepositoryAllocationInfoRsp       = fork.LayerTypeGetSDRRepositoryAllocationInfoRsp
+	LayerTypeGetSDRRepositoryInfoRsp                 = fork.LayerTypeGetSDRRepositoryInfoRsp
+	LayerTypeGetSDRReq                               = fork.LayerTypeGetSDRReq
+	LayerTypeGetSDRRsp                               = fork.LayerTypeGetSDRRsp
+	LayerTypeGetSELEntryReq                          = fork.LayerTypeGetSELEntryReq
+	LayerTypeGetSELEntryRsp                          = fork.LayerTypeGetSELEntryRsp
+	LayerTypeGetSELInfoRsp                           = fork.LayerTypeGetSELInfoRsp
+	LayerTypeGetSELTimeRsp                           = fork.LayerTypeGetSELTimeRsp
+	LayerTypeGetSELTimeUTCOffsetRsp                  = fork.LayerTypeGetSELTimeUTCOffsetRsp
+	LayerTypeGetSOLConfigurationParametersReq        = fork.LayerTypeGetSOLConfigurationParametersReq
+	LayerTypeGetSOLConfigurationParametersRsp        = fork.LayerTypeGetSOLConfigurationParametersRsp
+	LayerTypeGetSensorReadingFactorsReq              = fork.LayerTypeGetSensorReadingFactorsReq
+	LayerTypeGetSensorReadingFactorsRsp              = fork.LayerTypeGetSensorReadingFactorsRsp
+	LayerTypeGetSensorReadingReq                     = fork.LayerTypeGetSensorReadingReq
+	LayerTypeGetSensorReadingRsp                     = fork.LayerTypeGetSensorReadingRsp
+	LayerTypeGetSessionInfoReq                       = fork.LayerTypeGetSessionInfoReq
+	LayerTypeGetSessionInfoRsp                       = fork.LayerTypeGetSessionInfoRsp
+	LayerTypeGetSystemBootOptionsReq                 = fork.LayerTypeGetSystemBootOptionsReq
+	LayerTypeGetSystemBootOptionsRsp                 = fork.LayerTypeGetSystemBootOptionsRsp
+	LayerTypeGetSystemGUIDRsp                        = fork.LayerTypeGetSystemGUIDRsp
+	LayerTypeGetSystemInfoParametersReq              = fork.LayerTypeGetSystemInfoParametersReq
+	LayerTypeGetSystemInfoParametersRsp              = fork.LayerTypeGetSystemInfoParametersRsp
+	LayerTypeGetUserAccessReq                        = fork.LayerTypeGetUserAccessReq
+	LayerTypeGetUserAccessRsp                        = fork.LayerTypeGetUserAccessRsp
+	LayerTypeGetUserNameReq                          = fork.LayerTypeGetUserNameReq
+	LayerTypeGetUserNameRsp                          = fork.LayerTypeGetUserNameRsp
+	LayerTypeGetWatchdogTimerRsp                     = fork.LayerTypeGetWatchdogTimerRsp
+	LayerTypeMessage                                 = fork.LayerTypeMessage
+	LayerTypeOpenSessionReq                          = fork.LayerTypeOpenSessionReq
+	LayerTypeOpenSessionRsp                          = fork.LayerTypeOpenSessionRsp
+	LayerTypePartialAddSDRReq                        = fork.LayerTypePartialAddSDRReq
+	LayerTypePartialAddSDRRsp                        = fork.LayerTypePartialAddSDRRsp
+	LayerTypeRAKPMessage1                            = fork.LayerTypeRAKPMessage1
+	LayerTypeRAKPMessage2                            = fork.LayerTypeRAKPMessage2
+	LayerTypeRAKPMessage3                            = fork.LayerTypeRAKPMessage3
+	LayerTypeRAKPMessage4                            = fork.LayerTypeRAKPMessage4
+	LayerTypeReadFRUDataReq                          = fork.LayerTypeReadFRUDataReq
+	LayerTypeReadFRUDataRsp                          = fork.LayerTypeReadFRUDataRsp
+	LayerTypeRearmSensorEventsReq                    = fork.LayerTypeRearmSensorEventsReq
+	LayerTypeReserveSDRRepositoryRsp                 = fork.LayerTypeReserveSDRRepositoryRsp
+	LayerTypeReserveSELRsp                           = fork.LayerTypeReserveSELRsp
+	LayerTypeRunInitializationAgentReq               = fork.LayerTypeRunInitializationAgentReq
+	LayerTypeRunInitializationAgentRsp               = fork.LayerTypeRunInitializationAgentRsp
+	LayerTypeSDR                                     = fork.LayerTypeSDR
+	LayerTypeSELEventRecord                          = fork.LayerTypeSELEventRecord
+	LayerTypeSessionSelector                         = fork.LayerTypeSessionSelector
+	LayerTypeSetChassisCapabilitiesReq               = fork.LayerTypeSetChassisCapabilitiesReq
+	LayerTypeSetFrontPanelEnablesReq                 = fork.LayerTypeSetFrontPanelEnablesReq
+	LayerTypeSetLANConfigurationParametersReq        = fork.LayerTypeSetLANConfigurationParametersReq
+	LayerTypeSetPEFConfigurationParametersReq        = fork.LayerTypeSetPEFConfigurationParametersReq
+	LayerTypeSetPowerRestorePolicyReq                = fork.LayerTypeSetPowerRestorePolicyReq
+	LayerTypeSetPowerRestorePolicyRsp                = fork.LayerTypeSetPowerRestorePolicyRsp
+	LayerTypeSetSELTimeReq                           = fork.LayerTypeSetSELTimeReq
+	LayerTypeSetSOLConfigurationParametersReq        = fork.LayerTypeSetSOLConfigurationParametersReq
+	LayerTypeSetSystemBootOptionsReq                 = fork.LayerTypeSetSystemBootOptionsReq
+	LayerTypeSetSystemInfoParametersReq              = fork.LayerTypeSetSystemInfoParametersReq
+	LayerTypeSetUserAccessReq                        = fork.LayerTypeSetUserAccessReq
+	LayerTypeSetUserNameReq                          = fork.LayerTypeSetUserNameReq
+	LayerTypeSetUserPasswordReq                      = fork.LayerTypeSetUserPasswordReq
+	LayerTypeSetWatchdogTimerReq                     = fork.LayerTypeSetWatchdogTimerReq
+	LayerTypeSuspendBMCARPsReq                       = fork.LayerTypeSuspendBMCARPsReq
+	LayerTypeSuspendBMCARPsRsp                       = fork.LayerTypeSuspendBMCARPsRsp
+	LayerTypeV1Session                               = fork.LayerTypeV1Session
+	LayerTypeV2Session                               = fork.LayerTypeV2Session
+	LookupCipherSuite                                = fork.LookupCipherSuite
+	NewAES128CBC                                     = fork.NewAES128CBC
+	NewTimestamp                                     = fork.NewTimestamp
+	NewV2DecodingLayerFunc                           = fork.NewV2DecodingLayerFunc
+	NewV2Parser                                      = fork.NewV2Parser
+	OperationAddSDRReq                               = fork.OperationAddSDRReq
+	OperationAddSDRRsp                               = fork.OperationAddSDRRsp
+	OperationChassisControlReq                       = fork.OperationChassisControlReq
+	OperationChassisIdentifyReq                      = fork.OperationChassisIdentifyReq
+	OperationChassisIdentifyRsp                      = fork.OperationChassisIdentifyRsp
+	OperationClearSDRRepositoryReq                   = fork.OperationClearSDRRepositoryReq
+	OperationClearSDRRepositoryRsp                   = fork.OperationClearSDRRepositoryRsp
+	OperationClearSELReq                             = fork.OperationClearSELReq
+	OperationClearSELRsp                             = fork.OperationClearSELRsp
+	OperationCloseSessionReq                         = fork.OperationCloseSessionReq
+	OperationDeleteSDRReq                            = fork.OperationDeleteSDRReq
+	OperationDeleteSDRRsp                            = fork.OperationDeleteSDRRsp
+	OperationGetChannelAuthenticationCapabilitiesReq = fork.OperationGetChannelAuthenticationCapabilitiesReq
+	OperationGetChannelAuthenticationCapabilitiesRsp = fork.OperationGetChannelAuthenticationCapabilitiesRsp
+	OperationGetChannelOEMPayloadInfoReq             = fork.OperationGetChannelOEMPayloadInfoReq
+	OperationGetChannelOEMPayloadInfoRsp             = fork.OperationGetChannelOEMPayloadInfoRsp
+	OperationGetChassisCapabilitiesReq               = fork.OperationGetChassisCapabilitiesReq
+	OperationGetChassisCapabilitiesRsp               = fork.OperationGetChassisCapabilitiesRsp
+	OperationGetChassisStatusReq                     = fork.OperationGetChassisStatusReq
+	OperationGetChassisStatusRsp                     = fork.OperationGetChassisStatusRsp
+	OperationGetDeviceIDReq                          = fork.OperationGetDeviceIDReq
+	OperationGetDeviceIDRsp                          = fork.OperationGetDeviceIDRsp
+	OperationGetFRUInventoryAreaInfoReq              = fork.OperationGetFRUInventoryAreaInfoReq
+	OperationGetFRUInventoryAreaInfoRsp              = fork.OperationGetFRUInventoryAreaInfoRsp
+	OperationGetIPUDPRMCPStatisticsReq               = fork.OperationGetIPUDPRMCPStatisticsReq
+	OperationGetIPUDPRMCPStatisticsRsp               = fork.OperationGetIPUDPRMCPStatisticsRsp
+	OperationGetLANConfigurationParametersReq        = fork.OperationGetLANConfigurationParametersReq
+	OperationGetLANConfigurationParametersRsp        = fork.OperationGetLANConfigurationParametersRsp
+	OperationGetPEFConfigurationParametersReq        = fork.OperationGetPEFConfigurationParametersReq
+	OperationGetPEFConfigurationParametersRsp        = fork.OperationGetPEFConfigurationParametersRsp
+	OperationGetPOHCounterReq                        = fork.OperationGetPOHCounterReq
+	OperationGetPOHCounterRsp                        = fork.OperationGetPOHCounterRsp
+	OperationGetSDRRepositoryAllocationInfoReq       = fork.OperationGetSDRRepositoryAllocationInfoReq
+	OperationGetSDRRepositoryAllocationInfoRsp       = fork.OperationGetSDRRepositoryAllocationInfoRsp
+	OperationGetSDRRepositoryInfoReq                 = fork.OperationGetSDRRepositoryInfoReq
+	OperationGetSDRRepositoryInfoRsp                 = fork.OperationGetSDRRepositoryInfoRsp
+	OperationGetSDRReq                               = fork.OperationGetSDRReq
+	OperationGetSDRRsp                               = fork.OperationGetSDRRsp
+	OperationGetSELEntryReq                          = fork.OperationGetSELEntryReq
+	OperationGetSELEntryRsp                          = fork.OperationGetSELEntryRsp
+	OperationGetSELInfoReq                           = fork.OperationGetSELInfoReq
+	OperationGetSELInfoRsp                           = fork.OperationGetSELInfoRsp
+	OperationGetSELTimeReq                           = fork.OperationGetSELTimeReq
+	OperationGetSELTimeRsp                           = fork.OperationGetSELTimeRsp
+	OperationGetSELTimeUTCOffsetReq                  = fork.OperationGetSELTimeUTCOffsetReq
+	OperationGetSELTimeUTCOffsetRsp                  = fork.OperationGetSELTimeUTCOffsetRsp
+	OperationGetSOLConfigurationParametersReq        = fork.OperationGetSOLConfigurationParametersReq
+	OperationGetSOLConfigurationParametersRsp        = fork.OperationGetSOLConfigurationParametersRsp
+	OperationGetSensorReadingFactorsReq              = fork.OperationGetSensorReadingFactorsReq
+	OperationGetSensorReadingFactorsRsp              = fork.OperationGetSensorReadingFactorsRsp
+	OperationGetSensorReadingReq                     = fork.OperationGetSensorReadingReq
+	OperationGetSensorReadingRsp                     = fork.OperationGetSensorReadingRsp
+	OperationGetSessionInfoReq                       = fork.OperationGetSessionInfoReq
+	OperationGetSessionInfoRsp                       = fork.OperationGetSessionInfoRsp
+	OperationGetSystemBootOptionsReq                 = fork.OperationGetSystemBootOptionsReq
+	OperationGetSystemBootOptionsRsp                 = fork.OperationGetSystemBootOptionsRsp
+	OperationGetSystemGUIDReq                        = fork.OperationGetSystemGUIDReq
+	OperationGetSystemGUIDRsp                        = fork.OperationGetSystemGUIDRsp
+	OperationGetSystemInfoParametersReq              = fork.OperationGetSystemInfoParametersReq
+	OperationGetSystemInfoParametersRsp              = fork.OperationGetSystemInfoParametersRsp
+	OperationGetUserAccessReq                        = fork.OperationGetUserAccessReq
+	OperationGetUserAccessRsp                        = fork.OperationGetUserAccessRsp
+	OperationGetUserNameReq                          = fork.OperationGetUserNameReq
+	OperationGetUserNameRsp                          = fork.OperationGetUserNameRsp
+	OperationGetWatchdogTimerReq                     = fork.OperationGetWatchdogTimerReq
+	OperationGetWatchdogTimerRsp                     = fork.OperationGetWatchdogTimerRsp
+	OperationPartialAddSDRReq                        = fork.OperationPartialAddSDRReq
+	OperationPartialAddSDRRsp                        = fork.OperationPartialAddSDRRsp
+	OperationReadFRUDataReq                          = fork.OperationReadFRUDataReq
+	OperationReadFRUDataRsp                          = fork.OperationReadFRUDataRsp
+	OperationRearmSensorEventsReq                    = fork.OperationRearmSensorEventsReq
+	OperationRearmSensorEventsRsp                    = fork.OperationRearmSensorEventsRsp
+	OperationReserveSDRRepositoryReq                 = fork.OperationReserveSDRRepositoryReq
+	OperationReserveSDRRepositoryRsp                 = fork.OperationReserveSDRRepositoryRsp
+	OperationReserveSELReq                           = fork.OperationReserveSELReq
+	OperationReserveSELRsp                           = fork.OperationReserveSELRsp
+	OperationResetWatchdogTimerReq                   = fork.OperationResetWatchdogTimerReq
+	OperationResetWatchdogTimerRsp                   = fork.OperationResetWatchdogTimerRsp
+	OperationRunInitializationAgentReq               = fork.OperationRunInitializationAgentReq
+	OperationRunInitializationAgentRsp               = fork.OperationRunInitializationAgentRsp
+	OperationSetChassisCapabilitiesReq               = fork.OperationSetChassisCapabilitiesReq
+	OperationSetChassisCapabilitiesRsp               = fork.OperationSetChassisCapabilitiesRsp
+	OperationSetFrontPanelEnablesReq                 = fork.OperationSetFrontPanelEnablesReq
+	OperationSetFrontPanelEnablesRsp                 = fork.OperationSetFrontPanelEnablesRsp
+	OperationSetLANConfigurationParametersReq        = fork.OperationSetLANConfigurationParametersReq
+	OperationSetLANConfigurationParametersRsp        = fork.OperationSetLANConfigurationParametersRsp
+	OperationSetPEFConfigurationParametersReq        = fork.OperationSetPEFConfigurationParametersReq
+	OperationSetPEFConfigurationParametersRsp        = fork.OperationSetPEFConfigurationParametersRsp
+	OperationSetPowerRestorePolicyReq                = fork.OperationSetPowerRestorePolicyReq
+	OperationSetPowerRestorePolicyRsp                = fork.OperationSetPowerRestorePolicyRsp
+	OperationSetSELTimeReq                           = fork.OperationSetSELTimeReq
+	OperationSetSELTimeRsp                           = fork.OperationSetSELTimeRsp
+	OperationSetSOLConfigurationParametersReq        = fork.OperationSetSOLConfigurationParametersReq
+	OperationSetSOLConfigurationParametersRsp        = fork.OperationSetSOLConfigurationParametersRsp
+	OperationSetSystemBootOptionsReq                 = fork.OperationSetSystemBootOptionsReq
+	OperationSetSystemBootOptionsRsp                 = fork.OperationSetSystemBootOptionsRsp
+	OperationSetSystemInfoParametersReq              = fork.OperationSetSystemInfoParametersReq
+	OperationSetSystemInfoParametersRsp              = fork.OperationSetSystemInfoParametersRsp
+	OperationSetUserAccessReq                        = fork.OperationSetUserAccessReq
+	OperationSetUserAccessRsp                        = fork.OperationSetUserAccessRsp
+	OperationSetUserNameReq                          = fork.OperationSetUserNameReq
+	OperationSetUserNameRsp                          = fork.OperationSetUserNameRsp
+	OperationSetUserPasswordReq                      = fork.OperationSetUserPasswordReq
+	OperationSetUserPasswordRsp                      = fork.OperationSetUserPasswordRsp
+	OperationSetWatchdogTimerReq                     = fork.OperationSetWatchdogTimerReq
+	OperationSetWatchdogTimerRsp                     = fork.OperationSetWatchdogTimerRsp
+	OperationSuspendBMCARPsReq                       = fork.OperationSuspendBMCARPsReq
+	OperationSuspendBMCARPsRsp                       = fork.OperationSuspendBMCARPsRsp
+	PayloadDescriptorIPMI                            = fork.PayloadDescriptorIPMI
+	PayloadDescriptorOpenSessionReq                  = fork.PayloadDescriptorOpenSessionReq
+	PayloadDescriptorOpenSessionRsp                  = fork.PayloadDescriptorOpenSessionRsp
+	PayloadDescriptorRAKPMessage1                    = fork.PayloadDescriptorRAKPMessage1
+	PayloadDescriptorRAKPMessage2                    = fork.PayloadDescriptorRAKPMessage2
+	PayloadDescriptorRAKPMessage3                    = fork.PayloadDescriptorRAKPMessage3
+	PayloadDescriptorRAKPMessage4                    = fork.PayloadDescriptorRAKPMessage4
+	RegisterCompletionCode                           = fork.RegisterCompletionCode
+	RegisterOEMCompletionCode                        = fork.RegisterOEMCompletionCode
+	RegisterOEMPayloadDescriptor                     = fork.RegisterOEMPayloadDescriptor
+	RegisterOperationEffect                          = fork.RegisterOperationEffect
+	RegisterPrivilegeLevel                           = fork.RegisterPrivilegeLevel
+	RegisterResponseLength                           = fork.RegisterResponseLength
+	RegisterRetryPolicy                              = fork.RegisterRetryPolicy
+	UserName                                         = fork.UserName
+)
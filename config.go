@@ -0,0 +1,812 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// Config is the desired configuration of a BMC, for use with ApplyConfig().
+// Settings left as their zero value are unmanaged: they are neither read nor
+// changed, so a Config need only mention what the caller cares about. It can
+// be serialised as JSON, e.g. to be kept in version control. ExportConfig()
+// returns the current configuration of a BMC.
+type Config struct {
+
+	// PowerRestorePolicy is what the chassis should do when mains power
+	// returns. PowerRestorePolicyUnknown is not allowed.
+	PowerRestorePolicy *ipmi.PowerRestorePolicy `json:"powerRestorePolicy,omitempty"`
+
+	// Users configures user IDs. Users not listed are left unchanged.
+	Users []*UserConfig `json:"users,omitempty"`
+
+	// PEF configures Platform Event Filtering.
+	PEF *PEFConfig `json:"pef,omitempty"`
+
+	// Boot configures the persistent boot device override.
+	Boot *BootConfig `json:"boot,omitempty"`
+
+	// SOL configures Serial over LAN on a channel.
+	SOL *SOLConfig `json:"sol,omitempty"`
+
+	// LAN configures a LAN channel. This is applied last, as changing the
+	// address of the channel in use will likely break the session.
+	LAN *LANConfig `json:"lan,omitempty"`
+}
+
+// UserConfig is the desired configuration of a user ID.
+type UserConfig struct {
+
+	// ID identifies the user, from 1. User 1 is the anonymous user on most
+	// BMCs, whose name cannot be changed.
+	ID uint8 `json:"id"`
+
+	// Name is the user's name, at most 16 bytes.
+	Name string `json:"name,omitempty"`
+
+	// Password is the user's password, at most 16 bytes. Passwords cannot be
+	// read, so it is tested instead, and only set if the BMC reports it
+	// differs.
+	Password string `json:"password,omitempty"`
+
+	// Enabled is whether the user may log in.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PrivilegeLimit is the user's maximum privilege level on Channel.
+	// ipmi.PrivilegeLevelNoAccess denies the user access to the channel.
+	PrivilegeLimit ipmi.PrivilegeLevel `json:"privilegeLimit,omitempty"`
+
+	// Channel is the channel PrivilegeLimit applies to, normally the LAN
+	// channel. It is ignored if PrivilegeLimit is unset.
+	Channel ipmi.Channel `json:"channel,omitempty"`
+}
+
+// PEFConfig is the desired Platform Event Filtering configuration. Event
+// filter and alert policy tables are not managed.
+type PEFConfig struct {
+
+	// Control is the PEF Control parameter, including whether PEF is
+	// enabled.
+	Control *ipmi.PEFControl `json:"control,omitempty"`
+
+	// Actions are the actions event filters are allowed to take.
+	Actions *ipmi.PEFAction `json:"actions,omitempty"`
+}
+
+// BootConfig is the desired persistent boot device override. One-time
+// overrides are not managed, as they are cleared by the next boot.
+type BootConfig struct {
+
+	// Device is the device to boot from. ipmi.BootDeviceNone means the BIOS
+	// uses its configured boot order.
+	Device ipmi.BootDevice `json:"device"`
+
+	// EFI indicates the BIOS should boot in UEFI mode.
+	EFI bool `json:"efi,omitempty"`
+}
+
+func (c BootConfig) String() string {
+	if c.EFI {
+		return fmt.Sprintf("%v (EFI)", c.Device)
+	}
+	return c.Device.String()
+}
+
+// SOLConfig is the desired Serial over LAN configuration of a channel.
+type SOLConfig struct {
+
+	// Channel is the channel to configure, normally the LAN channel.
+	Channel ipmi.Channel `json:"channel"`
+
+	// Enabled is whether SOL payloads may be activated.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// BitRate is the non-volatile bit rate, used when a session is
+	// activated.
+	BitRate *ipmi.SOLBitRate `json:"bitRate,omitempty"`
+}
+
+// LANConfig is the desired configuration of a LAN channel. Only IPv4 is
+// supported.
+type LANConfig struct {
+
+	// Channel is the LAN channel to configure, normally 1.
+	Channel ipmi.Channel `json:"channel"`
+
+	// IPAddressSource is how the channel obtains its address. Setting an
+	// address normally requires this to be ipmi.IPAddressSourceStatic.
+	IPAddressSource ipmi.IPAddressSource `json:"ipAddressSource,omitempty"`
+
+	IPAddress      net.IP `json:"ipAddress,omitempty"`
+	SubnetMask     net.IP `json:"subnetMask,omitempty"`
+	DefaultGateway net.IP `json:"defaultGateway,omitempty"`
+}
+
+// ConfigChange is a setting of a BMC that differs from a Config.
+type ConfigChange struct {
+
+	// Setting identifies what is changed, e.g. "user 3 name".
+	Setting string `json:"setting"`
+
+	// From and To are the current and desired values. Passwords are never
+	// included.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	apply func(context.Context, Session) error
+}
+
+func (c *ConfigChange) String() string {
+	return fmt.Sprintf("%v: %v -> %v", c.Setting, c.From, c.To)
+}
+
+// redacted is the From and To of password changes.
+const redacted = "(redacted)"
+
+// DiffConfig reads the settings managed by c from the BMC, and returns those
+// that differ, in the order ApplyConfig() would change them. Nothing is
+// changed, so this can be used to preview what ApplyConfig() would do.
+func DiffConfig(ctx context.Context, s Session, c *Config) ([]*ConfigChange, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	d := &configDiff{}
+	if c.PowerRestorePolicy != nil {
+		if err := d.powerRestorePolicy(ctx, s, *c.PowerRestorePolicy); err != nil {
+			return nil, err
+		}
+	}
+	for _, u := range c.Users {
+		if err := d.user(ctx, s, u); err != nil {
+			return nil, fmt.Errorf("user %v: %w", u.ID, err)
+		}
+	}
+	if c.PEF != nil {
+		if err := d.pef(ctx, s, c.PEF); err != nil {
+			return nil, fmt.Errorf("PEF: %w", err)
+		}
+	}
+	if c.Boot != nil {
+		if err := d.boot(ctx, s, *c.Boot); err != nil {
+			return nil, fmt.Errorf("boot: %w", err)
+		}
+	}
+	if c.SOL != nil {
+		if err := d.sol(ctx, s, c.SOL); err != nil {
+			return nil, fmt.Errorf("SOL: %w", err)
+		}
+	}
+	if c.LAN != nil {
+		if err := d.lan(ctx, s, c.LAN); err != nil {
+			return nil, fmt.Errorf("LAN: %w", err)
+		}
+	}
+	return d.changes, nil
+}
+
+// ApplyConfig changes the settings of the BMC that differ from c, and returns
+// what it changed. Only the Set commands necessary are sent, so applying the
+// same Config repeatedly is safe, and changes nothing once the BMC matches it.
+// Applying a Config returned by ExportConfig() restores it. If an error is
+// returned, the changes returned are those made before it occurred. Changing
+// users and LAN settings requires an administrator session.
+func ApplyConfig(ctx context.Context, s Session, c *Config) ([]*ConfigChange, error) {
+	changes, err := DiffConfig(ctx, s, c)
+	if err != nil {
+		return nil, err
+	}
+	for i, change := range changes {
+		if err := change.apply(ctx, s); err != nil {
+			return changes[:i], fmt.Errorf("%v: %w", change.Setting, err)
+		}
+	}
+	return changes, nil
+}
+
+func (c *Config) validate() error {
+	if c.PowerRestorePolicy != nil &&
+		*c.PowerRestorePolicy > ipmi.PowerRestorePolicyPowerOn {
+		return fmt.Errorf("invalid power restore policy %v",
+			*c.PowerRestorePolicy)
+	}
+	seen := map[uint8]bool{}
+	for _, u := range c.Users {
+		switch {
+		case u.ID == 0 || u.ID > 0x3f:
+			return fmt.Errorf("invalid user ID %v", u.ID)
+		case seen[u.ID]:
+			return fmt.Errorf("user %v configured more than once", u.ID)
+		case len(u.Name) > 16:
+			return fmt.Errorf("user %v: name longer than 16 bytes", u.ID)
+		case len(u.Password) > 16:
+			return fmt.Errorf("user %v: password longer than 16 bytes", u.ID)
+		case u.PrivilegeLimit != 0 && !u.Channel.Valid():
+			return fmt.Errorf("user %v: invalid channel %v", u.ID, u.Channel)
+		}
+		seen[u.ID] = true
+	}
+	if c.SOL != nil && !c.SOL.Channel.Valid() {
+		return fmt.Errorf("SOL: invalid channel %v", c.SOL.Channel)
+	}
+	if c.LAN != nil {
+		if !c.LAN.Channel.Valid() {
+			return fmt.Errorf("LAN: invalid channel %v", c.LAN.Channel)
+		}
+		for _, ip := range []net.IP{c.LAN.IPAddress, c.LAN.SubnetMask,
+			c.LAN.DefaultGateway} {
+			if ip != nil && ip.To4() == nil {
+				return fmt.Errorf("LAN: %v is not an IPv4 address", ip)
+			}
+		}
+	}
+	return nil
+}
+
+// configDiff accumulates the changes needed to apply a Config.
+type configDiff struct {
+	changes []*ConfigChange
+}
+
+func (d *configDiff) add(setting string, from, to interface{}, apply func(context.Context, Session) error) {
+	d.changes = append(d.changes, &ConfigChange{
+		Setting: setting,
+		From:    fmt.Sprint(from),
+		To:      fmt.Sprint(to),
+		apply:   apply,
+	})
+}
+
+func (d *configDiff) powerRestorePolicy(ctx context.Context, s Session, want ipmi.PowerRestorePolicy) error {
+	status, err := s.GetChassisStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.PowerRestorePolicy == want {
+		return nil
+	}
+	d.add("power restore policy", status.PowerRestorePolicy, want,
+		func(ctx context.Context, s Session) error {
+			cmd := &ipmi.SetPowerRestorePolicyCmd{
+				Req: ipmi.SetPowerRestorePolicyReq{
+					Policy: want,
+				},
+			}
+			if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+				return err
+			}
+			// BMCs ignore policies the chassis does not support
+			supported := map[ipmi.PowerRestorePolicy]bool{
+				ipmi.PowerRestorePolicyRemainOff:  cmd.Rsp.SupportsRemainOff,
+				ipmi.PowerRestorePolicyPriorState: cmd.Rsp.SupportsPriorState,
+				ipmi.PowerRestorePolicyPowerOn:    cmd.Rsp.SupportsPowerOn,
+			}
+			if !supported[want] {
+				return fmt.Errorf("the chassis does not support %v", want)
+			}
+			return nil
+		})
+	return nil
+}
+
+func (d *configDiff) user(ctx context.Context, s Session, u *UserConfig) error {
+	prefix := fmt.Sprintf("user %v", u.ID)
+	if u.Name != "" {
+		cmd := &ipmi.GetUserNameCmd{
+			Req: ipmi.GetUserNameReq{
+				UserID: u.ID,
+			},
+		}
+		if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+			return err
+		}
+		if current := ipmi.UserName(cmd.Rsp.Name); current != u.Name {
+			req := ipmi.SetUserNameReq{
+				UserID: u.ID,
+			}
+			copy(req.Name[:], u.Name)
+			d.add(prefix+" name", fmt.Sprintf("%q", current),
+				fmt.Sprintf("%q", u.Name), sendConfigCmd(
+					&ipmi.SetUserNameCmd{
+						Req: req,
+					}))
+		}
+	}
+	if u.Password != "" {
+		req := ipmi.SetUserPasswordReq{
+			UserID:    u.ID,
+			Operation: ipmi.UserPasswordOperationTestPassword,
+		}
+		copy(req.Password[:], u.Password)
+		code, err := s.SendCommand(ctx, &ipmi.SetUserPasswordCmd{
+			Req: req,
+		})
+		if err != nil {
+			return err
+		}
+		switch code {
+		case ipmi.CompletionCodeNormal:
+		case ipmi.CompletionCodePasswordMismatch,
+			ipmi.CompletionCodePasswordSizeMismatch:
+			req.Operation = ipmi.UserPasswordOperationSetPassword
+			d.add(prefix+" password", redacted, redacted, sendConfigCmd(
+				&ipmi.SetUserPasswordCmd{
+					Req: req,
+				}))
+		default:
+			return ValidateResponse(code, nil)
+		}
+	}
+	if u.Enabled == nil && u.PrivilegeLimit == 0 {
+		return nil
+	}
+	channel := ipmi.ChannelPresentInterface
+	if u.PrivilegeLimit != 0 {
+		channel = u.Channel
+	}
+	access, err := getUserAccess(ctx, s, channel, u.ID)
+	if err != nil {
+		return err
+	}
+	if u.Enabled != nil {
+		want, op := ipmi.UserStatusDisabled, ipmi.UserPasswordOperationDisableUser
+		if *u.Enabled {
+			want, op = ipmi.UserStatusEnabled, ipmi.UserPasswordOperationEnableUser
+		}
+		// an unspecified status could be either, so is always set
+		if access.Status != want {
+			d.add(prefix+" status", access.Status, want, sendConfigCmd(
+				&ipmi.SetUserPasswordCmd{
+					Req: ipmi.SetUserPasswordReq{
+						UserID:    u.ID,
+						Operation: op,
+					},
+				}))
+		}
+	}
+	if u.PrivilegeLimit != 0 && access.PrivilegeLimit != u.PrivilegeLimit {
+		d.add(fmt.Sprintf("%v channel %v privilege limit", prefix,
+			uint8(channel)), access.PrivilegeLimit, u.PrivilegeLimit,
+			sendConfigCmd(&ipmi.SetUserAccessCmd{
+				Req: ipmi.SetUserAccessReq{
+					Channel:        channel,
+					UserID:         u.ID,
+					PrivilegeLimit: u.PrivilegeLimit,
+				},
+			}))
+	}
+	return nil
+}
+
+func (d *configDiff) pef(ctx context.Context, s Session, c *PEFConfig) error {
+	if c.Control != nil {
+		data, err := getPEFParameter(ctx, s,
+			ipmi.PEFConfigurationParameterControl, 1)
+		if err != nil {
+			return err
+		}
+		if current := ipmi.PEFControl(data[0]); current != *c.Control {
+			d.add("PEF control", current, *c.Control, setPEFParameter(
+				ipmi.PEFConfigurationParameterControl, uint8(*c.Control)))
+		}
+	}
+	if c.Actions != nil {
+		data, err := getPEFParameter(ctx, s,
+			ipmi.PEFConfigurationParameterActionGlobalControl, 1)
+		if err != nil {
+			return err
+		}
+		if current := ipmi.PEFAction(data[0]); current != *c.Actions {
+			d.add("PEF actions", current, *c.Actions, setPEFParameter(
+				ipmi.PEFConfigurationParameterActionGlobalControl,
+				uint8(*c.Actions)))
+		}
+	}
+	return nil
+}
+
+func (d *configDiff) boot(ctx context.Context, s Session, want BootConfig) error {
+	current, err := getBootConfig(ctx, s)
+	if err != nil {
+		return err
+	}
+	if current == want {
+		return nil
+	}
+	d.add("boot device override", current, want,
+		func(ctx context.Context, s Session) error {
+			return SetBootFlags(ctx, s, &ipmi.BootFlags{
+				Persistent: true,
+				EFI:        want.EFI,
+				Device:     want.Device,
+			})
+		})
+	return nil
+}
+
+func (d *configDiff) sol(ctx context.Context, s Session, c *SOLConfig) error {
+	if c.Enabled != nil {
+		data, err := getSOLParameter(ctx, s, c.Channel,
+			ipmi.SOLConfigurationParameterEnable, 1)
+		if err != nil {
+			return err
+		}
+		if current := data[0]&1 != 0; current != *c.Enabled {
+			value := uint8(0)
+			if *c.Enabled {
+				value = 1
+			}
+			d.add("SOL enabled", current, *c.Enabled,
+				setSOLParameter(c.Channel,
+					ipmi.SOLConfigurationParameterEnable, value))
+		}
+	}
+	if c.BitRate != nil {
+		data, err := getSOLParameter(ctx, s, c.Channel,
+			ipmi.SOLConfigurationParameterNonVolatileBitRate, 1)
+		if err != nil {
+			return err
+		}
+		if current := ipmi.SOLBitRate(data[0] & 0xf); current != *c.BitRate {
+			d.add("SOL bit rate", current, *c.BitRate,
+				setSOLParameter(c.Channel,
+					ipmi.SOLConfigurationParameterNonVolatileBitRate,
+					uint8(*c.BitRate)))
+		}
+	}
+	return nil
+}
+
+func (d *configDiff) lan(ctx context.Context, s Session, c *LANConfig) error {
+	if c.IPAddressSource != ipmi.IPAddressSourceUnspecified {
+		data, err := getLANParameter(ctx, s, c.Channel,
+			ipmi.LANConfigurationParameterIPAddressSource, 1)
+		if err != nil {
+			return err
+		}
+		current := ipmi.IPAddressSource(data[0] & 0xf)
+		if current != c.IPAddressSource {
+			d.add("LAN IP address source", current, c.IPAddressSource,
+				setLANParameter(c.Channel,
+					ipmi.LANConfigurationParameterIPAddressSource,
+					uint8(c.IPAddressSource)))
+		}
+	}
+	addresses := []struct {
+		setting   string
+		parameter ipmi.LANConfigurationParameter
+		want      net.IP
+	}{
+		{"LAN IP address", ipmi.LANConfigurationParameterIPAddress,
+			c.IPAddress},
+		{"LAN subnet mask", ipmi.LANConfigurationParameterSubnetMask,
+			c.SubnetMask},
+		{"LAN default gateway", ipmi.LANConfigurationParameterDefaultGatewayAddress,
+			c.DefaultGateway},
+	}
+	for _, address := range addresses {
+		if address.want == nil {
+			continue
+		}
+		data, err := getLANParameter(ctx, s, c.Channel, address.parameter,
+			net.IPv4len)
+		if err != nil {
+			return err
+		}
+		current := net.IP(data[:net.IPv4len])
+		if want := address.want.To4(); !current.Equal(want) {
+			d.add(address.setting, current, want, setLANParameter(c.Channel,
+				address.parameter, want...))
+		}
+	}
+	return nil
+}
+
+// ExportConfig reads the configurable settings of the BMC into a Config, which
+// ApplyConfig() can later restore, e.g. after a BMC is reset to factory
+// defaults or replaced. The LAN and SOL settings, and user privilege limits,
+// are those of the channel in use. Passwords cannot be read, so are omitted,
+// as are addresses obtained via DHCP, and names of users whose names are
+// fixed. Settings the BMC does not support are also omitted. The session
+// requires administrator privileges to read user and LAN settings on most
+// BMCs.
+func ExportConfig(ctx context.Context, s Session) (*Config, error) {
+	caps, err := s.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		})
+	if err != nil {
+		return nil, err
+	}
+	channel := caps.Channel
+
+	c := &Config{}
+	// each setting is optional; only failures of the session are fatal
+	omit := func(err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if isSessionError(err) {
+			return err
+		}
+		return nil
+	}
+
+	if status, err := s.GetChassisStatus(ctx); err == nil {
+		if status.PowerRestorePolicy != ipmi.PowerRestorePolicyUnknown {
+			policy := status.PowerRestorePolicy
+			c.PowerRestorePolicy = &policy
+		}
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+
+	if users, err := exportUsers(ctx, s, channel); err == nil {
+		c.Users = users
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+
+	pef := &PEFConfig{}
+	if data, err := getPEFParameter(ctx, s,
+		ipmi.PEFConfigurationParameterControl, 1); err == nil {
+		control := ipmi.PEFControl(data[0])
+		pef.Control = &control
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+	if data, err := getPEFParameter(ctx, s,
+		ipmi.PEFConfigurationParameterActionGlobalControl, 1); err == nil {
+		actions := ipmi.PEFAction(data[0])
+		pef.Actions = &actions
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+	if pef.Control != nil || pef.Actions != nil {
+		c.PEF = pef
+	}
+
+	if boot, err := getBootConfig(ctx, s); err == nil {
+		c.Boot = &boot
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+
+	sol := &SOLConfig{
+		Channel: channel,
+	}
+	if data, err := getSOLParameter(ctx, s, channel,
+		ipmi.SOLConfigurationParameterEnable, 1); err == nil {
+		enabled := data[0]&1 != 0
+		sol.Enabled = &enabled
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+	if data, err := getSOLParameter(ctx, s, channel,
+		ipmi.SOLConfigurationParameterNonVolatileBitRate, 1); err == nil {
+		bitRate := ipmi.SOLBitRate(data[0] & 0xf)
+		sol.BitRate = &bitRate
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+	if sol.Enabled != nil || sol.BitRate != nil {
+		c.SOL = sol
+	}
+
+	if lan, err := exportLAN(ctx, s, channel); err == nil {
+		c.LAN = lan
+	} else if err := omit(err); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// exportUsers reads the name, status and privilege limit on a channel of every
+// user ID the BMC supports.
+func exportUsers(ctx context.Context, s Session, channel ipmi.Channel) ([]*UserConfig, error) {
+	first, err := getUserAccess(ctx, s, channel, 1)
+	if err != nil {
+		return nil, err
+	}
+	users := []*UserConfig{}
+	for id := uint8(1); id <= first.MaxUsers; id++ {
+		access, err := getUserAccess(ctx, s, channel, id)
+		if err != nil {
+			return nil, fmt.Errorf("user %v: %w", id, err)
+		}
+		u := &UserConfig{
+			ID:             id,
+			PrivilegeLimit: access.PrivilegeLimit,
+			Channel:        channel,
+		}
+		switch access.Status {
+		case ipmi.UserStatusEnabled, ipmi.UserStatusDisabled:
+			enabled := access.Status == ipmi.UserStatusEnabled
+			u.Enabled = &enabled
+		}
+		if id > first.FixedNames {
+			cmd := &ipmi.GetUserNameCmd{
+				Req: ipmi.GetUserNameReq{
+					UserID: id,
+				},
+			}
+			if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+				return nil, fmt.Errorf("user %v: %w", id, err)
+			}
+			u.Name = ipmi.UserName(cmd.Rsp.Name)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// exportLAN reads the address configuration of a LAN channel. Addresses are
+// only included if statically assigned.
+func exportLAN(ctx context.Context, s Session, channel ipmi.Channel) (*LANConfig, error) {
+	data, err := getLANParameter(ctx, s, channel,
+		ipmi.LANConfigurationParameterIPAddressSource, 1)
+	if err != nil {
+		return nil, err
+	}
+	lan := &LANConfig{
+		Channel:         channel,
+		IPAddressSource: ipmi.IPAddressSource(data[0] & 0xf),
+	}
+	if lan.IPAddressSource != ipmi.IPAddressSourceStatic {
+		return lan, nil
+	}
+	addresses := []struct {
+		parameter ipmi.LANConfigurationParameter
+		ip        *net.IP
+	}{
+		{ipmi.LANConfigurationParameterIPAddress, &lan.IPAddress},
+		{ipmi.LANConfigurationParameterSubnetMask, &lan.SubnetMask},
+		{ipmi.LANConfigurationParameterDefaultGatewayAddress,
+			&lan.DefaultGateway},
+	}
+	for _, address := range addresses {
+		data, err := getLANParameter(ctx, s, channel, address.parameter,
+			net.IPv4len)
+		if err != nil {
+			return nil, err
+		}
+		*address.ip = net.IP(data[:net.IPv4len])
+	}
+	return lan, nil
+}
+
+// getUserAccess sends a Get User Access command. The response is a copy, owned
+// by the caller.
+func getUserAccess(ctx context.Context, s Session, channel ipmi.Channel, id uint8) (*ipmi.GetUserAccessRsp, error) {
+	cmd := &ipmi.GetUserAccessCmd{
+		Req: ipmi.GetUserAccessReq{
+			Channel: channel,
+			UserID:  id,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	rsp := cmd.Rsp
+	rsp.BaseLayer = layers.BaseLayer{}
+	return &rsp, nil
+}
+
+// getBootConfig returns the persistent boot device override. Flags that are
+// not valid or not persistent are equivalent to no override.
+func getBootConfig(ctx context.Context, s Session) (BootConfig, error) {
+	flags, err := GetBootFlags(ctx, s)
+	if err != nil {
+		return BootConfig{}, err
+	}
+	if !flags.Valid || !flags.Persistent {
+		return BootConfig{
+			Device: ipmi.BootDeviceNone,
+		}, nil
+	}
+	return BootConfig{
+		Device: flags.Device,
+		EFI:    flags.EFI,
+	}, nil
+}
+
+// sendConfigCmd returns a ConfigChange apply function that sends a command
+// with no response data.
+func sendConfigCmd(cmd ipmi.Command) func(context.Context, Session) error {
+	return func(ctx context.Context, s Session) error {
+		return ValidateResponse(s.SendCommand(ctx, cmd))
+	}
+}
+
+// errShortParameter is returned when a configuration parameter has less data
+// than its format requires.
+var errShortParameter = errors.New("parameter data truncated")
+
+// getLANParameter retrieves a LAN configuration parameter, which must be at
+// least length bytes long. The data is a copy, owned by the caller.
+func getLANParameter(ctx context.Context, s Session, channel ipmi.Channel, parameter ipmi.LANConfigurationParameter, length int) ([]byte, error) {
+	cmd := &ipmi.GetLANConfigurationParametersCmd{
+		Req: ipmi.GetLANConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: parameter,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, fmt.Errorf("%v: %w", parameter, err)
+	}
+	if len(cmd.Rsp.Payload) < length {
+		return nil, fmt.Errorf("%v: %w", parameter, errShortParameter)
+	}
+	return append([]byte(nil), cmd.Rsp.Payload...), nil
+}
+
+func setLANParameter(channel ipmi.Channel, parameter ipmi.LANConfigurationParameter, data ...byte) func(context.Context, Session) error {
+	return sendConfigCmd(&ipmi.SetLANConfigurationParametersCmd{
+		Req: ipmi.SetLANConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: parameter,
+			Data:      gopacket.Payload(data),
+		},
+	})
+}
+
+// getSOLParameter retrieves a SOL configuration parameter, which must be at
+// least length bytes long. The data is a copy, owned by the caller.
+func getSOLParameter(ctx context.Context, s Session, channel ipmi.Channel, parameter ipmi.SOLConfigurationParameter, length int) ([]byte, error) {
+	cmd := &ipmi.GetSOLConfigurationParametersCmd{
+		Req: ipmi.GetSOLConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: parameter,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, fmt.Errorf("%v: %w", parameter, err)
+	}
+	if len(cmd.Rsp.Payload) < length {
+		return nil, fmt.Errorf("%v: %w", parameter, errShortParameter)
+	}
+	return append([]byte(nil), cmd.Rsp.Payload...), nil
+}
+
+// getPEFParameter retrieves a PEF configuration parameter, which must be at
+// least length bytes long. The data is a copy, owned by the caller.
+func getPEFParameter(ctx context.Context, s Session, parameter ipmi.PEFConfigurationParameter, length int) ([]byte, error) {
+	cmd := &ipmi.GetPEFConfigurationParametersCmd{
+		Req: ipmi.GetPEFConfigurationParametersReq{
+			Parameter: parameter,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, fmt.Errorf("%v: %w", parameter, err)
+	}
+	if len(cmd.Rsp.Payload) < length {
+		return nil, fmt.Errorf("%v: %w", parameter, errShortParameter)
+	}
+	return append([]byte(nil), cmd.Rsp.Payload...), nil
+}
+
+func setPEFParameter(parameter ipmi.PEFConfigurationParameter, data ...byte) func(context.Context, Session) error {
+	return sendConfigCmd(&ipmi.SetPEFConfigurationParametersCmd{
+		Req: ipmi.SetPEFConfigurationParametersReq{
+			Parameter: parameter,
+			Data:      gopacket.Payload(data),
+		},
+	})
+}
+
+func setSOLParameter(channel ipmi.Channel, parameter ipmi.SOLConfigurationParameter, data ...byte) func(context.Context, Session) error {
+	return sendConfigCmd(&ipmi.SetSOLConfigurationParametersCmd{
+		Req: ipmi.SetSOLConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: parameter,
+			Data:      gopacket.Payload(data),
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package bmc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestConfigValidate(t *testing.T) {
+	invalidPolicy := ipmi.PowerRestorePolicyUnknown
+	tests := []struct {
+		name   string
+		config *Config
+		valid  bool
+	}{
+		{
+			"empty",
+			&Config{},
+			true,
+		},
+		{
+			"unknown power restore policy",
+			&Config{
+				PowerRestorePolicy: &invalidPolicy,
+			},
+			false,
+		},
+		{
+			"user 0",
+			&Config{
+				Users: []*UserConfig{{}},
+			},
+			false,
+		},
+		{
+			"duplicate user",
+			&Config{
+				Users: []*UserConfig{{ID: 2}, {ID: 2}},
+			},
+			false,
+		},
+		{
+			"long password",
+			&Config{
+				Users: []*UserConfig{
+					{
+						ID:       2,
+						Password: "01234567890123456",
+					},
+				},
+			},
+			false,
+		},
+		{
+			"IPv6 address",
+			&Config{
+				LAN: &LANConfig{
+					Channel:   1,
+					IPAddress: net.ParseIP("2001:db8::1"),
+				},
+			},
+			false,
+		},
+		{
+			"IPv4 address",
+			&Config{
+				LAN: &LANConfig{
+					Channel:   1,
+					IPAddress: net.ParseIP("192.0.2.1"),
+				},
+			},
+			true,
+		},
+	}
+	for _, test := range tests {
+		err := test.config.validate()
+		if test.valid && err != nil {
+			t.Errorf("%v: validate() = %v, want nil", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%v: validate() succeeded, want error", test.name)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package bmc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 
+	"github.com/google/gopacket"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -124,8 +127,175 @@ var (
 		},
 		[]string{"code"}, // completion code, printed as text, falling back to hex
 	)
+
+	// only incremented when invalid checksums are being ignored; otherwise
+	// the decode fails and the packet is treated as if it were never received,
+	// so manifests as a retry
+	messageChecksumErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "message",
+			Name:      "checksum_errors_total",
+			Help: "The number of IPMI messages received with an invalid " +
+				"checksum that were accepted regardless.",
+		},
+		[]string{"checksum"}, // "1" or "2"
+	)
+	messageChecksum1Errors = messageChecksumErrors.WithLabelValues("1")
+	messageChecksum2Errors = messageChecksumErrors.WithLabelValues("2")
+
+	// responses shorter than the operation's declared minimum are rejected
+	// before decoding; these are only counted if the completion code is
+	// normal, as BMCs may legitimately truncate other responses. Responses
+	// longer than the declared maximum are decoded regardless.
+	responseLengthErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "response",
+			Name:      "length_errors_total",
+			Help: "The number of responses with a normal completion code " +
+				"that were shorter than the minimum or longer than the " +
+				"maximum length of their command.",
+		},
+		[]string{"command", "reason"}, // reason is "truncated" or "oversized"
+	)
 )
 
+// connectionStrayPackets counts responses discarded by the demultiplexer, as
+// nothing was waiting for them.
+var (
+	connectionStrayPackets = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connection",
+		Name:      "stray_packets_total",
+		Help: "The number of packets received for a different session to " +
+			"the one awaiting a response, which were discarded.",
+	})
+)
+
+// sessionPacketsDropped is partitioned by the check the packet failed.
+var (
+	sessionPacketsDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "session",
+			Name:      "packets_dropped_total",
+			Help: "The number of packets received within a session that " +
+				"failed verification and were discarded.",
+		},
+		[]string{"reason"},
+	)
+	sessionPacketsDroppedIntegrity       = sessionPacketsDropped.WithLabelValues("integrity")
+	sessionPacketsDroppedUnauthenticated = sessionPacketsDropped.WithLabelValues("unauthenticated")
+	sessionPacketsDroppedSessionID       = sessionPacketsDropped.WithLabelValues("session_id")
+	sessionPacketsDroppedSequence        = sessionPacketsDropped.WithLabelValues("sequence")
+)
+
+// commandLUN returns the responder LUN a command should be sent to. This is
+// LUNBMC unless the command implements ipmi.LUNCommand.
+func commandLUN(c ipmi.Command) ipmi.LUN {
+	if lc, ok := c.(ipmi.LUNCommand); ok {
+		return lc.LUN()
+	}
+	return ipmi.LUNBMC
+}
+
+// commandResponderAddress returns the address a command should be sent to. This
+// is the provided default unless the command implements ipmi.AddressedCommand.
+func commandResponderAddress(c ipmi.Command, def ipmi.Address) ipmi.Address {
+	if ac, ok := c.(ipmi.AddressedCommand); ok {
+		return ac.ResponderAddress()
+	}
+	return def
+}
+
+// commandEffect returns the impact of sending a command on the managed system.
+// This is the effect of its operation unless it implements
+// ipmi.EffectCommand.
+func commandEffect(c ipmi.Command) ipmi.OperationEffect {
+	if ec, ok := c.(ipmi.EffectCommand); ok {
+		return ec.Effect()
+	}
+	return c.Operation().Effect()
+}
+
+// checkPrivilegeLevel returns an error wrapping ErrInsufficientPrivilege if the
+// command's operation is known to require a higher privilege level than the
+// session's. Commands with an unknown requirement are allowed, as are all
+// commands if the session's level is Highest (i.e. unknown) or OEM, which has
+// no defined ordering relative to the others.
+func checkPrivilegeLevel(c ipmi.Command, session ipmi.PrivilegeLevel) error {
+	if session == ipmi.PrivilegeLevelHighest || session == ipmi.PrivilegeLevelOEM {
+		return nil
+	}
+	required, ok := c.Operation().PrivilegeLevel()
+	if !ok || session >= required {
+		return nil
+	}
+	return fmt.Errorf("%w: %v requires %v, but the session has %v",
+		ErrInsufficientPrivilege, c.Name(), required, session)
+}
+
+// isPermanentDecodeError returns whether an error decoding a response indicates
+// the BMC will never respond successfully to the command, so it should not be
+// retried.
+func isPermanentDecodeError(err error) bool {
+	return errors.Is(err, ipmi.ErrProbablyInsufficientPrivilege) ||
+		errors.Is(err, ipmi.ErrProbablyUnsupported)
+}
+
+// decodeResponse decodes the data of a response to c into its response layer,
+// if it has one. The data is first checked against the response length
+// declared for the command's operation, so a truncated response never reaches
+// the decoder. Length errors are counted if code is normal.
+func decodeResponse(c ipmi.Command, code ipmi.CompletionCode, data []byte) error {
+	if c.Response() == nil {
+		return nil
+	}
+	if length, ok := c.Operation().ResponseLength(); ok {
+		if err := length.Validate(data); err != nil {
+			if code == ipmi.CompletionCodeNormal {
+				responseLengthErrors.WithLabelValues(c.Name(), "truncated").Inc()
+			}
+			return responseDecodeError(c, err)
+		}
+		if length.Exceeded(data) && code == ipmi.CompletionCodeNormal {
+			responseLengthErrors.WithLabelValues(c.Name(), "oversized").Inc()
+		}
+	}
+	if err := c.Response().DecodeFromBytes(data,
+		gopacket.NilDecodeFeedback); err != nil {
+		return responseDecodeError(c, err)
+	}
+	return nil
+}
+
+// responseDecodeError wraps an error decoding the response layer of a command
+// in ErrDecode.
+func responseDecodeError(c ipmi.Command, err error) error {
+	return fmt.Errorf("%w: %v: %w", ErrDecode, c.Name(), err)
+}
+
+// cloneMessage returns a copy of a decoded message that does not alias the
+// buffers of the connection it was received on.
+func cloneMessage(m *ipmi.Message) *ipmi.Message {
+	clone := *m
+	clone.Contents = append([]byte(nil), m.Contents...)
+	clone.Payload = append([]byte(nil), m.Payload...)
+	return &clone
+}
+
+// observeChecksums increments the checksum error counters if the decoded
+// message was accepted despite having invalid checksums.
+func observeChecksums(m *ipmi.Message) {
+	if m.InvalidChecksum1 {
+		messageChecksum1Errors.Inc()
+	}
+	if m.InvalidChecksum2 {
+		messageChecksum2Errors.Inc()
+	}
+}
+
 // Connection is an IPMI v1.5 or v2.0 session-less, single-session or
 // multi-session connection. The IPMI version and nature of the connection is
 // fixed upon creation - if sending two messages, it will never be the case that
@@ -162,6 +332,17 @@ type Connection interface {
 	// higher-level API, e.g. GetSystemGUID(), which wraps this.
 	SendCommand(ctx context.Context, cmd ipmi.Command) (ipmi.CompletionCode, error)
 
+	// SendCommandRaw is a lower-level variant of SendCommand, returning the
+	// decoded message layer of the response rather than only its completion
+	// code, e.g. to inspect the sequence number, addresses or undecoded data
+	// of an off-spec command. Its payload (LayerPayload()) is the response
+	// data following the completion code and any body code or enterprise
+	// number. The returned message is a copy, so remains valid after further
+	// commands are sent. The same retry and error semantics as SendCommand
+	// apply; if the response layer fails to decode, the message is returned
+	// along with the error.
+	SendCommandRaw(ctx context.Context, cmd ipmi.Command) (*ipmi.Message, error)
+
 	// Version returns the underlying IPMI version of the connection, either
 	// "1.5" or "2.0". Note that even session-less connections use a session
 	// wrapper, which has either the v1.5 or v2.0 format. This is provided for
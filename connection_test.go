@@ -0,0 +1,35 @@
+package bmc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		code      ipmi.CompletionCode
+		data      []byte
+		truncated bool
+	}{
+		{ipmi.CompletionCodeNormal, make([]byte, 16), false},
+		{ipmi.CompletionCodeNormal, make([]byte, 17), false},
+		{ipmi.CompletionCodeNormal, make([]byte, 4), true},
+		{ipmi.CompletionCodeNotPresent, []byte{}, true},
+	}
+	for _, test := range tests {
+		cmd := &ipmi.GetSystemGUIDCmd{}
+		err := decodeResponse(cmd, test.code, test.data)
+		lengthErr := (*ipmi.ResponseLengthError)(nil)
+		truncated := errors.As(err, &lengthErr)
+		if truncated != test.truncated {
+			t.Errorf("decodeResponse(%v, %v bytes) = %v, want truncated %v",
+				test.code, len(test.data), err, test.truncated)
+		}
+		if truncated && !errors.Is(err, ErrDecode) {
+			t.Errorf("decodeResponse(%v, %v bytes) = %v, want ErrDecode",
+				test.code, len(test.data), err)
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package bmc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/transport"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	connectionUnsolicitedPackets = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connection",
+		Name:      "unsolicited_packets_total",
+		Help: "The number of packets received that were not responses to " +
+			"a request, e.g. SOL data.",
+	})
+	connectionUnsolicitedDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connection",
+		Name:      "unsolicited_dropped_total",
+		Help: "The number of unsolicited packets discarded because the " +
+			"channel they are delivered on was full.",
+	})
+	connectionOversizePackets = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connection",
+		Name:      "oversize_packets_total",
+		Help: "The number of packets not sent because they exceeded the " +
+			"connection's maximum packet size.",
+	})
+)
+
+var (
+	// ErrTransportClosed is returned when waiting for a packet on a
+	// connection whose transport has been closed. Recv() returns it once the
+	// transport is closed, so it indicates a clean shutdown of a stream.
+	ErrTransportClosed = errors.New("transport closed")
+
+	// ErrPacketTooLarge is wrapped by errors returned when a packet would
+	// exceed the connection's maximum packet size; see
+	// DialOpts.MaxPacketSize. Nothing is sent, and the command is not
+	// retried.
+	ErrPacketTooLarge = errors.New("packet exceeds maximum size")
+)
+
+const (
+	// unsolicitedBufferSize is the number of unsolicited packets buffered
+	// before further packets are dropped.
+	unsolicitedBufferSize = 32
+
+	// defaultMaxPacketSize is the largest UDP payload that fits in a 1500
+	// byte Ethernet frame without fragmentation, after the 20-byte IPv4 and
+	// 8-byte UDP headers. Dialled connections replace it with a limit derived
+	// from the interface used to reach the BMC.
+	defaultMaxPacketSize = 1472
+)
+
+// UnsolicitedPacket is an RMCP+ packet received from the BMC that was not a
+// response to a request, e.g. SOL data or an OEM payload.
+type UnsolicitedPacket struct {
+
+	// SessionID is the ID of the session the packet was sent within, from our
+	// perspective, i.e. the value of V2Session.LocalID.
+	SessionID uint32
+
+	// PayloadType is the type of the payload within the session wrapper.
+	PayloadType ipmi.PayloadType
+
+	// Data is the UDP payload, starting with the RMCP header. It is owned by
+	// the receiver.
+	Data []byte
+}
+
+// receipt is the outcome of waiting for a packet, passed from the receive
+// goroutine to the waiter.
+type receipt struct {
+	data []byte
+	err  error
+}
+
+// demultiplexer owns the receive side of a transport. A single goroutine reads
+// every inbound packet, routing responses to whoever is waiting for the session
+// ID they are addressed to, and unsolicited packets to a channel. It
+// implements transport.Transport so the raw socket can still be used for
+// non-IPMI traffic, e.g. ASF presence pings; those callers receive packets not
+// claimed by a session.
+type demultiplexer struct {
+	transport transport.Transport
+
+	// maxPacketSize is the largest packet that will be written. It is set
+	// before the demultiplexer is used, so is not protected by mu.
+	maxPacketSize int
+
+	// mu protects waiters, raw and streams.
+	mu sync.Mutex
+
+	// waiters maps session ID (0 for session-less) to the channel to deliver
+	// the response to. Each channel has a buffer of 1; if it is full, the
+	// packet is a stray.
+	waiters map[uint32]chan receipt
+
+	// raw is the channel for a Send() or Receive() in progress, or nil if
+	// there is none.
+	raw chan receipt
+
+	// streams maps session ID to a channel for unsolicited packets within that
+	// session, taking precedence over the unsolicited channel. Streams are
+	// created when a session first calls Recv(). A nil channel means the
+	// session has been closed, so its packets are dropped.
+	streams map[uint32]chan UnsolicitedPacket
+
+	// unsolicited receives unsolicited packets without a stream.
+	unsolicited chan UnsolicitedPacket
+
+	// streamsClosed is set once the receive goroutine has closed all streams;
+	// streams created after this are closed immediately.
+	streamsClosed bool
+
+	// closing is closed by Close() before closing the transport, so the
+	// receive goroutine can distinguish shutdown from a read error.
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	// done is closed when the receive goroutine exits.
+	done chan struct{}
+}
+
+// newDemultiplexer wraps the provided transport, and starts a goroutine to
+// receive from it. The goroutine exits when Close() is called.
+func newDemultiplexer(t transport.Transport) *demultiplexer {
+	d := &demultiplexer{
+		transport:     t,
+		maxPacketSize: defaultMaxPacketSize,
+		waiters:       map[uint32]chan receipt{},
+		streams:       map[uint32]chan UnsolicitedPacket{},
+		unsolicited:   make(chan UnsolicitedPacket, unsolicitedBufferSize),
+		closing:       make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go d.receive()
+	return d
+}
+
+// receive reads packets until the transport is closed.
+func (d *demultiplexer) receive() {
+	defer close(d.done)
+	defer d.closeStreams()
+	for {
+		// no deadline - we block until a packet arrives or Close() is called
+		packet, err := d.transport.Receive(context.Background())
+		if err != nil {
+			select {
+			case <-d.closing:
+				return
+			default:
+			}
+			// e.g. ICMP port unreachable; whoever is waiting should find out
+			d.fail(err)
+			continue
+		}
+		d.route(packet)
+	}
+}
+
+// route delivers a packet to the appropriate waiter, or the unsolicited
+// channel. The packet is copied, as the transport will reuse its buffer.
+func (d *demultiplexer) route(packet []byte) {
+	id, payloadType, ok := peekV2Session(packet)
+	if ok && !isResponsePayloadType(payloadType) {
+		d.deliverUnsolicited(UnsolicitedPacket{
+			SessionID:   id,
+			PayloadType: payloadType,
+			Data:        append([]byte(nil), packet...),
+		})
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := d.raw
+	if ok {
+		if waiter, found := d.waiters[id]; found {
+			c = waiter
+		}
+	}
+	if c == nil {
+		// most likely a late response to an attempt that timed out
+		connectionStrayPackets.Inc()
+		return
+	}
+	select {
+	case c <- receipt{data: append([]byte(nil), packet...)}:
+	default:
+		connectionStrayPackets.Inc()
+	}
+}
+
+// deliverUnsolicited sends an unsolicited packet to the stream for its
+// session, or the unsolicited channel if there is no stream. It never blocks.
+func (d *demultiplexer) deliverUnsolicited(p UnsolicitedPacket) {
+	connectionUnsolicitedPackets.Inc()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, found := d.streams[p.SessionID]
+	switch {
+	case !found:
+		c = d.unsolicited
+	case c == nil:
+		connectionUnsolicitedDropped.Inc()
+		return
+	}
+	select {
+	case c <- p:
+	default:
+		connectionUnsolicitedDropped.Inc()
+	}
+}
+
+// stream returns the channel for unsolicited packets within a session,
+// creating it if necessary. After the receive goroutine has exited, this
+// returns a closed channel.
+func (d *demultiplexer) stream(sessionID uint32) <-chan UnsolicitedPacket {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, found := d.streams[sessionID]
+	if found && c != nil {
+		return c
+	}
+	c = make(chan UnsolicitedPacket, unsolicitedBufferSize)
+	if found || d.streamsClosed {
+		close(c)
+	} else {
+		d.streams[sessionID] = c
+	}
+	return c
+}
+
+// closeStream closes the stream for a session, if it has one, and drops
+// further unsolicited packets within the session.
+func (d *demultiplexer) closeStream(sessionID uint32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c := d.streams[sessionID]; c != nil {
+		close(c)
+	}
+	if !d.streamsClosed {
+		d.streams[sessionID] = nil
+	}
+}
+
+// closeStreams closes every stream and the unsolicited channel, so their
+// receivers see the end of the stream.
+func (d *demultiplexer) closeStreams() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for sessionID, c := range d.streams {
+		delete(d.streams, sessionID)
+		if c != nil {
+			close(c)
+		}
+	}
+	close(d.unsolicited)
+	d.streamsClosed = true
+}
+
+// recv blocks until a packet is received on the provided stream, or the
+// context expires. It returns ErrTransportClosed if the stream is closed.
+func (d *demultiplexer) recv(ctx context.Context, c <-chan UnsolicitedPacket) (UnsolicitedPacket, error) {
+	select {
+	case p, ok := <-c:
+		if !ok {
+			return UnsolicitedPacket{}, ErrTransportClosed
+		}
+		return p, nil
+	case <-ctx.Done():
+		return UnsolicitedPacket{}, ctx.Err()
+	}
+}
+
+// fail delivers an error to everyone waiting for a packet.
+func (d *demultiplexer) fail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, c := range d.waiters {
+		select {
+		case c <- receipt{err: err}:
+		default:
+		}
+	}
+	if d.raw != nil {
+		select {
+		case d.raw <- receipt{err: err}:
+		default:
+		}
+	}
+}
+
+// pendingResponse is a request that has been sent, whose response is awaited.
+// The session ID remains registered with the demultiplexer until close() is
+// called, so packets received while the previous one is examined are not lost.
+type pendingResponse struct {
+	d         *demultiplexer
+	sessionID uint32
+	c         chan receipt
+	start     time.Time
+}
+
+// send sends a packet, then registers to receive packets addressed to the
+// provided session ID (0 for session-less). Only one request may be pending for
+// a given session ID at a time. close() must be called on the returned value
+// once the response has been received.
+func (d *demultiplexer) send(ctx context.Context, sessionID uint32, b []byte) (*pendingResponse, error) {
+	p := &pendingResponse{
+		d:         d,
+		sessionID: sessionID,
+		c:         make(chan receipt, 1),
+		start:     time.Now(),
+	}
+	d.mu.Lock()
+	d.waiters[sessionID] = p.c
+	d.mu.Unlock()
+
+	if d.isClosed() {
+		p.close()
+		return nil, ErrTransportClosed
+	}
+	if err := d.checkSize(b); err != nil {
+		p.close()
+		return nil, err
+	}
+	if err := d.transport.Write(ctx, b); err != nil {
+		p.close()
+		return nil, stageTimeoutError(TimeoutStageSend, p.start, err)
+	}
+	return p, nil
+}
+
+// next blocks until a packet addressed to the session is received, the context
+// expires or the demultiplexer is closed. If the packet turns out not to be the
+// response to the request, e.g. it is a late response to an earlier one, next
+// can be called again to continue waiting without sending the request again.
+func (p *pendingResponse) next(ctx context.Context) ([]byte, error) {
+	response, err := p.d.wait(ctx, p.c)
+	return response, stageTimeoutError(TimeoutStageAwaitResponse, p.start, err)
+}
+
+// close stops packets addressed to the session being delivered to the request.
+func (p *pendingResponse) close() {
+	p.d.mu.Lock()
+	delete(p.d.waiters, p.sessionID)
+	p.d.mu.Unlock()
+}
+
+// exchange sends a packet, then waits for the first packet addressed to the
+// provided session ID (0 for session-less), which it returns.
+func (d *demultiplexer) exchange(ctx context.Context, sessionID uint32, b []byte) ([]byte, error) {
+	p, err := d.send(ctx, sessionID, b)
+	if err != nil {
+		return nil, err
+	}
+	defer p.close()
+	return p.next(ctx)
+}
+
+// checkSize returns an error wrapping ErrPacketTooLarge if a packet is larger
+// than the maximum packet size. IP fragments are frequently dropped by
+// firewalls in front of BMCs, so sending the packet would most likely result
+// in a timeout.
+func (d *demultiplexer) checkSize(b []byte) error {
+	if len(b) <= d.maxPacketSize {
+		return nil
+	}
+	connectionOversizePackets.Inc()
+	return fmt.Errorf("%w: %v bytes, maximum is %v", ErrPacketTooLarge,
+		len(b), d.maxPacketSize)
+}
+
+// wait blocks until a receipt is delivered on the channel, the context expires
+// or the demultiplexer is closed.
+func (d *demultiplexer) wait(ctx context.Context, c <-chan receipt) ([]byte, error) {
+	select {
+	case r := <-c:
+		return r.data, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-d.done:
+		return nil, ErrTransportClosed
+	}
+}
+
+// Unsolicited returns the channel on which unsolicited packets are delivered.
+// It is closed when the demultiplexer is closed.
+func (d *demultiplexer) Unsolicited() <-chan UnsolicitedPacket {
+	return d.unsolicited
+}
+
+func (d *demultiplexer) Address() net.Addr {
+	return d.transport.Address()
+}
+
+// Send sends the supplied data, then waits for the next packet not addressed
+// to a session with a request in progress. Calls to Send and Receive must be
+// serialised with each other, but not with the rest of the connection.
+func (d *demultiplexer) Send(ctx context.Context, b []byte) ([]byte, error) {
+	c := d.registerRaw()
+	defer d.unregisterRaw()
+	if d.isClosed() {
+		return nil, ErrTransportClosed
+	}
+	if err := d.checkSize(b); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if err := d.transport.Write(ctx, b); err != nil {
+		return nil, stageTimeoutError(TimeoutStageSend, start, err)
+	}
+	response, err := d.wait(ctx, c)
+	return response, stageTimeoutError(TimeoutStageAwaitResponse, start, err)
+}
+
+func (d *demultiplexer) Write(ctx context.Context, b []byte) error {
+	if err := d.checkSize(b); err != nil {
+		return err
+	}
+	return d.transport.Write(ctx, b)
+}
+
+// Receive waits for the next packet not addressed to a session with a request
+// in progress.
+func (d *demultiplexer) Receive(ctx context.Context) ([]byte, error) {
+	c := d.registerRaw()
+	defer d.unregisterRaw()
+	return d.wait(ctx, c)
+}
+
+func (d *demultiplexer) registerRaw() <-chan receipt {
+	c := make(chan receipt, 1)
+	d.mu.Lock()
+	d.raw = c
+	d.mu.Unlock()
+	return c
+}
+
+func (d *demultiplexer) unregisterRaw() {
+	d.mu.Lock()
+	d.raw = nil
+	d.mu.Unlock()
+}
+
+// isClosed returns whether Close() has been called.
+func (d *demultiplexer) isClosed() bool {
+	select {
+	case <-d.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the underlying transport, then waits for the receive goroutine
+// to exit.
+func (d *demultiplexer) Close() error {
+	err := ErrTransportClosed
+	d.closeOnce.Do(func() {
+		close(d.closing)
+		err = d.transport.Close()
+		<-d.done
+	})
+	return err
+}
+
+// isResponsePayloadType returns whether a payload type is only ever sent by the
+// BMC in response to a request from us.
+func isResponsePayloadType(t ipmi.PayloadType) bool {
+	switch t {
+	case ipmi.PayloadTypeIPMI, ipmi.PayloadTypeOpenSessionRsp,
+		ipmi.PayloadTypeRAKPMessage2, ipmi.PayloadTypeRAKPMessage4:
+		return true
+	default:
+		return false
+	}
+}
+
+// peekV2Session returns the session ID and payload type of an RMCP+ packet
+// without decoding it, and whether the packet was long enough and of the right
+// format to contain them.
+func peekV2Session(packet []byte) (uint32, ipmi.PayloadType, bool) {
+	// RMCP header (4), auth type (1), payload type (1), [OEM IANA (4), OEM
+	// payload ID (2)], session ID (4)
+	offset := 6
+	if len(packet) < offset ||
+		ipmi.AuthenticationType(packet[4]) != ipmi.AuthenticationTypeRMCPPlus {
+		return 0, 0, false
+	}
+	payloadType := ipmi.PayloadType(packet[5] & 0x3f)
+	if payloadType == ipmi.PayloadTypeOEM {
+		offset += 6
+	}
+	if len(packet) < offset+4 {
+		return 0, 0, false
+	}
+	return binary.LittleEndian.Uint32(packet[offset : offset+4]), payloadType, true
+}
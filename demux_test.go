@@ -0,0 +1,233 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPeekV2Session(t *testing.T) {
+	tests := []struct {
+		packet      []byte
+		id          uint32
+		payloadType ipmi.PayloadType
+		ok          bool
+	}{
+		{nil, 0, 0, false},
+		{
+			// v1.5 session wrapper
+			[]byte{0x06, 0x00, 0xff, 0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			0, 0, false,
+		},
+		{
+			[]byte{0x06, 0x00, 0xff, 0x07, 0x06, 0xc1, 0x04, 0x03, 0x02, 0x01},
+			0x01020304, ipmi.PayloadTypeSOL, true,
+		},
+		{
+			// truncated
+			[]byte{0x06, 0x00, 0xff, 0x07, 0x06, 0xc0, 0x04, 0x03, 0x02},
+			0, 0, false,
+		},
+		{
+			// OEM payload
+			[]byte{0x06, 0x00, 0xff, 0x07, 0x06, 0x02, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x04, 0x03, 0x02, 0x01},
+			0x01020304, ipmi.PayloadTypeOEM, true,
+		},
+	}
+	for i, test := range tests {
+		id, payloadType, ok := peekV2Session(test.packet)
+		if id != test.id || payloadType != test.payloadType || ok != test.ok {
+			t.Errorf("%v: peekV2Session() = (%v, %v, %v), want (%v, %v, %v)",
+				i, id, payloadType, ok, test.id, test.payloadType, test.ok)
+		}
+	}
+}
+
+// fakeTransport delivers packets written to its inbound channel to Receive().
+type fakeTransport struct {
+	inbound chan []byte
+	written chan []byte
+	closed  chan struct{}
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{
+		inbound: make(chan []byte, 8),
+		written: make(chan []byte, 8),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (t *fakeTransport) Address() net.Addr {
+	return &net.UDPAddr{}
+}
+
+func (t *fakeTransport) Send(ctx context.Context, b []byte) ([]byte, error) {
+	if err := t.Write(ctx, b); err != nil {
+		return nil, err
+	}
+	return t.Receive(ctx)
+}
+
+func (t *fakeTransport) Write(_ context.Context, b []byte) error {
+	t.written <- b
+	return nil
+}
+
+func (t *fakeTransport) Receive(ctx context.Context) ([]byte, error) {
+	select {
+	case b := <-t.inbound:
+		return b, nil
+	case <-t.closed:
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *fakeTransport) Close() error {
+	close(t.closed)
+	return nil
+}
+
+func v2SessionPacket(payloadType ipmi.PayloadType, id uint32) []byte {
+	return []byte{0x06, 0x00, 0xff, 0x07, 0x06, byte(payloadType),
+		byte(id), byte(id >> 8), byte(id >> 16), byte(id >> 24)}
+}
+
+func TestDemultiplexer(t *testing.T) {
+	ft := newFakeTransport()
+	d := newDemultiplexer(ft)
+
+	go func() {
+		<-ft.written
+		ft.inbound <- v2SessionPacket(ipmi.PayloadTypeIPMI, 2) // stray
+		ft.inbound <- v2SessionPacket(ipmi.PayloadTypeSOL, 1)  // unsolicited
+		ft.inbound <- v2SessionPacket(ipmi.PayloadTypeIPMI, 1) // response
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	response, err := d.exchange(ctx, 1, []byte{0x01})
+	if err != nil {
+		t.Fatalf("exchange() failed: %v", err)
+	}
+	want := v2SessionPacket(ipmi.PayloadTypeIPMI, 1)
+	if diff := cmp.Diff(want, response); diff != "" {
+		t.Errorf("exchange() = %v, want %v", response, want)
+	}
+
+	select {
+	case p := <-d.Unsolicited():
+		want := UnsolicitedPacket{
+			SessionID:   1,
+			PayloadType: ipmi.PayloadTypeSOL,
+			Data:        v2SessionPacket(ipmi.PayloadTypeSOL, 1),
+		}
+		if diff := cmp.Diff(want, p); diff != "" {
+			t.Errorf("unexpected unsolicited packet (-want +got):\n%v", diff)
+		}
+	case <-ctx.Done():
+		t.Fatal("unsolicited packet not delivered")
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if _, ok := <-d.Unsolicited(); ok {
+		t.Error("unsolicited channel not closed")
+	}
+	if _, err := d.exchange(ctx, 1, []byte{0x01}); err != ErrTransportClosed {
+		t.Errorf("exchange() after Close() = %v, want %v", err,
+			ErrTransportClosed)
+	}
+}
+
+func TestDemultiplexerExchangeTimeout(t *testing.T) {
+	ft := newFakeTransport()
+	d := newDemultiplexer(ft)
+	defer d.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err := d.exchange(ctx, 1, []byte{0x01})
+	timeoutErr := (*TimeoutError)(nil)
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("exchange() = %v, want TimeoutError", err)
+	}
+	if timeoutErr.Stage != TimeoutStageAwaitResponse {
+		t.Errorf("Stage = %v, want %v", timeoutErr.Stage,
+			TimeoutStageAwaitResponse)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("%v does not wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestDemultiplexerMaxPacketSize(t *testing.T) {
+	ft := newFakeTransport()
+	d := newDemultiplexer(ft)
+	d.maxPacketSize = 2
+	defer d.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := d.exchange(ctx, 1, []byte{0x01, 0x02, 0x03}); !errors.Is(err, ErrPacketTooLarge) {
+		t.Errorf("exchange() = %v, want %v", err, ErrPacketTooLarge)
+	}
+	if err := d.Write(ctx, []byte{0x01, 0x02, 0x03}); !errors.Is(err, ErrPacketTooLarge) {
+		t.Errorf("Write() = %v, want %v", err, ErrPacketTooLarge)
+	}
+	if err := d.Write(ctx, []byte{0x01, 0x02}); err != nil {
+		t.Fatalf("Write() of maximum size failed: %v", err)
+	}
+	if written := <-ft.written; len(written) != 2 {
+		t.Errorf("wrote %v, want only the packet within the limit", written)
+	}
+}
+
+func TestDemultiplexerStream(t *testing.T) {
+	ft := newFakeTransport()
+	d := newDemultiplexer(ft)
+	defer d.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream := d.stream(1)
+	ft.inbound <- v2SessionPacket(ipmi.PayloadTypeSOL, 2)
+	ft.inbound <- v2SessionPacket(ipmi.PayloadTypeSOL, 1)
+
+	p, err := d.recv(ctx, stream)
+	if err != nil {
+		t.Fatalf("recv() failed: %v", err)
+	}
+	if p.SessionID != 1 {
+		t.Errorf("stream received packet for session %v, want 1", p.SessionID)
+	}
+	p, err = d.recv(ctx, d.Unsolicited())
+	if err != nil {
+		t.Fatalf("recv() failed: %v", err)
+	}
+	if p.SessionID != 2 {
+		t.Errorf("unsolicited channel received packet for session %v, want 2",
+			p.SessionID)
+	}
+
+	d.closeStream(1)
+	if _, err := d.recv(ctx, stream); err != ErrTransportClosed {
+		t.Errorf("recv() on closed stream = %v, want %v", err,
+			ErrTransportClosed)
+	}
+	if _, err := d.recv(ctx, d.stream(1)); err != ErrTransportClosed {
+		t.Errorf("recv() on stream of closed session = %v, want %v", err,
+			ErrTransportClosed)
+	}
+}
@@ -0,0 +1,103 @@
+package bmc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/transport"
+)
+
+const (
+	// defaultAddressStagger is the delay between probing successive addresses
+	// of a hostname, unless overridden by DialOpts. This is the connection
+	// attempt delay recommended by RFC 8305.
+	defaultAddressStagger = time.Millisecond * 250
+)
+
+// newTransport resolves addr, and connects to the first of its addresses to
+// respond to a Presence Ping, sending one to each in turn, stagger apart. Each
+// address is allowed timeout to respond. If none do, or stagger is negative,
+// the first address is used.
+func newTransport(ctx context.Context, addr string, stagger, timeout time.Duration) (transport.Transport, error) {
+	// default to port 623
+	if !strings.Contains(addr, ":") || strings.HasSuffix(addr, "]") {
+		addr = addr + ":623"
+	}
+	raddrs, err := transport.Resolve(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(raddrs) == 1 || stagger < 0 {
+		return transport.Dial(raddrs[0], addr)
+	}
+
+	transports := make([]transport.Transport, 0, len(raddrs))
+	for _, raddr := range raddrs {
+		t, err := transport.Dial(raddr, addr)
+		if err != nil {
+			for _, t := range transports {
+				t.Close()
+			}
+			return nil, err
+		}
+		transports = append(transports, t)
+	}
+	chosen := raceTransports(ctx, transports, stagger, timeout)
+	for i, t := range transports {
+		if i != chosen {
+			t.Close()
+		}
+	}
+	return transports[chosen], nil
+}
+
+// raceTransports sends a Presence Ping over each transport, stagger apart,
+// returning the index of the first to receive a response, or 0 if none do
+// within timeout of their ping being sent. Pings still awaiting a response
+// when this returns are abandoned; the caller should close the transports not
+// chosen to unblock them.
+func raceTransports(ctx context.Context, transports []transport.Transport, stagger, timeout time.Duration) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responded := make(chan int, len(transports))
+	wg := sync.WaitGroup{}
+	for i, t := range transports {
+		wg.Add(1)
+		go func(i int, t transport.Transport) {
+			defer wg.Done()
+			timer := time.NewTimer(stagger * time.Duration(i))
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if _, err := presencePing(pingCtx, t); err == nil {
+				responded <- i
+			}
+		}(i, t)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case chosen := <-responded:
+		cancel()
+		return chosen
+	case <-done:
+		select {
+		case chosen := <-responded:
+			return chosen
+		default:
+			return 0
+		}
+	}
+}
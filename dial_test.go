@@ -0,0 +1,101 @@
+package bmc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/transport"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// listenPong returns a socket that replies to every packet with a Presence
+// Pong if respond is true, otherwise ignoring them.
+func listenPong(t *testing.T, respond bool) *net.UDPConn {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff,
+			Class:    layers.RMCPClassASF,
+		},
+		&layers.ASF{
+			ASFDataIdentifier: layers.ASFDataIdentifierPresencePong,
+		},
+		&layers.ASFPresencePong{
+			Enterprise: 4542,
+			IPMI:       true,
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		packet := make([]byte, 64)
+		for {
+			_, addr, err := conn.ReadFromUDP(packet)
+			if err != nil {
+				return
+			}
+			if respond {
+				_, _ = conn.WriteToUDP(buf.Bytes(), addr)
+			}
+		}
+	}()
+	return conn
+}
+
+func TestRaceTransports(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond []bool
+		want    int
+	}{
+		{
+			name:    "first responds",
+			respond: []bool{true, true},
+			want:    0,
+		},
+		{
+			name:    "second responds",
+			respond: []bool{false, true, false},
+			want:    1,
+		},
+		{
+			name:    "none respond",
+			respond: []bool{false, false},
+			want:    0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transports := []transport.Transport{}
+			for _, respond := range test.respond {
+				conn := listenPong(t, respond)
+				raddr := conn.LocalAddr().(*net.UDPAddr)
+				tr, err := transport.Dial(raddr, raddr.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer tr.Close()
+				transports = append(transports, tr)
+			}
+
+			got := raceTransports(context.Background(), transports,
+				time.Millisecond*10, time.Millisecond*100)
+			if got != test.want {
+				t.Errorf("raceTransports() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// defaultEventsPollInterval is how often the SEL is checked for new
+	// records if EventsOpts.PollInterval is unset.
+	defaultEventsPollInterval = time.Second * 10
+)
+
+// EventSource identifies the mechanism by which an Event was received.
+type EventSource uint8
+
+const (
+	// EventSourceSEL indicates the event was read from the System Event Log
+	// by polling. This is currently the only source; PET alerts and SOL
+	// notifications will be added as further sources, delivered through the
+	// same channel.
+	EventSourceSEL EventSource = iota + 1
+)
+
+func (s EventSource) String() string {
+	switch s {
+	case EventSourceSEL:
+		return "SEL"
+	default:
+		return fmt.Sprintf("EventSource(%v)", uint8(s))
+	}
+}
+
+// Event is a platform event, normalised across delivery mechanisms, so
+// consumers can use a single handler regardless of how it was received.
+type Event struct {
+
+	// Source is how the event was received.
+	Source EventSource
+
+	// Record is the event itself. For events from the SEL, its ID is the SEL
+	// record ID. The record is not reused, so may be retained.
+	Record *ipmi.SELEventRecord
+}
+
+// EventsOpts contains optional parameters for Events().
+type EventsOpts struct {
+
+	// PollInterval is how often to check the SEL for new records. Each check
+	// costs a single command if there are none. Defaults to 10s.
+	PollInterval time.Duration
+
+	// Replay causes records already in the SEL to be emitted first. By
+	// default, only records added after Events() is called are emitted.
+	Replay bool
+
+	// After, if non-nil, is the newest record already processed, e.g. by a
+	// previous run of the program, which can persist Record.Contents and
+	// decode it on restart. Only records following it are emitted. If it is no
+	// longer in the SEL, e.g. because the SEL was cleared, every record is
+	// emitted. This takes precedence over Replay.
+	After *ipmi.SELEventRecord
+
+	// Errors, if non-nil, is called with each error encountered while
+	// polling. Polling continues at the next interval regardless. It is called
+	// from the goroutine sending events, so should not block.
+	Errors func(error)
+}
+
+// Events returns a channel of events from the BMC, which is closed once the
+// context expires. Events are currently sourced by polling the SEL. The SEL
+// is assumed to only be appended to; if the newest record seen is deleted or
+// the SEL is cleared, every record present at the next poll is emitted, so
+// duplicates are possible. An error is returned if the SEL cannot be read
+// initially; later errors are passed to opts.Errors. opts may be nil. The
+// session must not be closed before the context expires.
+func Events(ctx context.Context, s Session, opts *EventsOpts) (<-chan Event, error) {
+	if opts == nil {
+		opts = &EventsOpts{}
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultEventsPollInterval
+	}
+
+	p := &selPoller{
+		conn: s,
+		last: opts.After,
+	}
+	if opts.After == nil && !opts.Replay {
+		if err := p.seekLast(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		emit := func(r *ipmi.SELEventRecord) bool {
+			select {
+			case events <- Event{
+				Source: EventSourceSEL,
+				Record: r,
+			}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		// WaitFor only returns once the context expires, as poll never
+		// indicates completion, and errors are handled internally
+		_ = WaitFor(ctx, interval, func(ctx context.Context) (bool, error) {
+			if err := p.poll(ctx, emit); err != nil && opts.Errors != nil &&
+				ctx.Err() == nil {
+				opts.Errors(err)
+			}
+			return false, nil
+		})
+	}()
+	return events, nil
+}
+
+// selPoller tracks the newest record read from the SEL, in order to retrieve
+// only records added after it.
+type selPoller struct {
+	conn Connection
+	cmd  ipmi.GetSELEntryCmd
+
+	// last is the newest record read. It is nil if no records have been read,
+	// or the record was found to no longer exist, in which case the SEL is
+	// read from the beginning.
+	last *ipmi.SELEventRecord
+}
+
+// read retrieves the record with the provided ID, returning nil if it does not
+// exist.
+func (p *selPoller) read(ctx context.Context, id ipmi.RecordID) (*ipmi.SELEventRecord, error) {
+	p.cmd.Req = ipmi.GetSELEntryReq{
+		RecordID: id,
+		Length:   0xff,
+	}
+	code, err := p.conn.SendCommand(ctx, &p.cmd)
+	if err == nil && code == ipmi.CompletionCodeNotPresent {
+		return nil, nil
+	}
+	if err := ValidateResponse(code, err); err != nil {
+		return nil, err
+	}
+
+	// the response layer is reused, so we must copy the payload
+	record := &ipmi.SELEventRecord{}
+	data := append([]byte(nil), p.cmd.Rsp.Payload...)
+	if err := record.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// seekLast sets the newest record without emitting it.
+func (p *selPoller) seekLast(ctx context.Context) error {
+	last, err := p.read(ctx, ipmi.RecordIDLast)
+	if err != nil {
+		return err
+	}
+	p.last = last
+	return nil
+}
+
+// poll emits all records newer than the last one read, stopping early if emit
+// returns false.
+func (p *selPoller) poll(ctx context.Context, emit func(*ipmi.SELEventRecord) bool) error {
+	next := ipmi.RecordIDFirst
+	if p.last != nil {
+		// record IDs may be reused after the SEL is cleared, so check the
+		// record is the one we saw
+		current, err := p.read(ctx, p.last.ID)
+		if err != nil {
+			return err
+		}
+		if current != nil && bytes.Equal(current.Contents, p.last.Contents) {
+			if p.cmd.Rsp.Next == ipmi.RecordIDLast {
+				return nil
+			}
+			next = p.cmd.Rsp.Next
+		} else {
+			p.last = nil
+		}
+	}
+
+	for {
+		record, err := p.read(ctx, next)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			// the SEL is empty, or the record was deleted; try again next
+			// time
+			return nil
+		}
+		if !emit(record) {
+			return nil
+		}
+		p.last = record
+		if p.cmd.Rsp.Next == ipmi.RecordIDLast {
+			return nil
+		}
+		next = p.cmd.Rsp.Next
+	}
+}
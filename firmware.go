@@ -24,21 +24,42 @@ func FirmwareVersion(r *ipmi.GetDeviceIDRsp) string {
 			r.MajorFirmwareRevision, r.MinorFirmwareRevision,
 			binary.LittleEndian.Uint16(r.AuxiliaryFirmwareRevision[2:]))
 	case iana.EnterpriseDell:
-		// 0 is always 0x00, unused
-		return fmt.Sprintf("%d.%d.%d.%db%02d",
+		// 0 is always 0x00, unused; 1 is the build number, which iDRAC 9
+		// leaves as 0x00 and does not display
+		version := fmt.Sprintf("%d.%d.%d.%d",
 			r.MajorFirmwareRevision, r.MinorFirmwareRevision,
 			r.AuxiliaryFirmwareRevision[2],
-			r.AuxiliaryFirmwareRevision[3],
-			r.AuxiliaryFirmwareRevision[1])
+			r.AuxiliaryFirmwareRevision[3])
+		if build := r.AuxiliaryFirmwareRevision[1]; build != 0 {
+			version += fmt.Sprintf("b%02d", build)
+		}
+		return version
 	case iana.EnterpriseQuanta:
 		// 1, 2, 3 are always 0x00, unused
 		return fmt.Sprintf("%d.%d.%02d",
 			r.MajorFirmwareRevision, r.MinorFirmwareRevision,
 			r.AuxiliaryFirmwareRevision[0])
 	case iana.EnterpriseSuperMicro:
-		// does not use the aux revision bytes, but formats to two digits
-		return fmt.Sprintf("%02d.%02d",
+		// formats to two digits; older firmware does not use the aux revision
+		// bytes, while X12 onwards puts the build number in 0, which the web
+		// interface shows as a third component
+		version := fmt.Sprintf("%02d.%02d",
 			r.MajorFirmwareRevision, r.MinorFirmwareRevision)
+		if build := r.AuxiliaryFirmwareRevision[0]; build != 0 {
+			version += fmt.Sprintf(".%02d", build)
+		}
+		return version
+	case iana.EnterpriseOpenBMC:
+		// phosphor-host-ipmid parses the digits of VERSION_ID in os-release as
+		// hex so they survive BCD decoding. 0 and 1 are the number of commits
+		// on top of the tag in the same way, big-endian, as in git describe
+		// output; 2 and 3 are a flag for any further suffix, which we omit
+		version := fmt.Sprintf("%d.%d",
+			r.MajorFirmwareRevision, r.MinorFirmwareRevision)
+		if commits := binary.BigEndian.Uint16(r.AuxiliaryFirmwareRevision[:2]); commits != 0 {
+			version += fmt.Sprintf("-%x", commits)
+		}
+		return version
 	default:
 		return fmt.Sprintf("%d.%d",
 			r.MajorFirmwareRevision, r.MinorFirmwareRevision)
@@ -0,0 +1,31 @@
+package bmc
+
+import (
+	"context"
+)
+
+// FirmwareComponent is a separately versioned piece of firmware in a machine,
+// e.g. the UEFI firmware, or the firmware of a RAID controller.
+type FirmwareComponent struct {
+
+	// Name identifies the component, in the manufacturer's terms, e.g.
+	// "UEFI".
+	Name string
+
+	// Version is the component's firmware version, in the format shown by
+	// the manufacturer's tools.
+	Version string
+}
+
+// FirmwareInventory is implemented by OEM extensions that can list the
+// versions of all firmware components in a machine, as included in a
+// manufacturer's firmware bundle. The specification only provides for the
+// BMC's own version, and the free-form System Firmware Version parameter,
+// which few BMCs support. Implementations are registered with RegisterOEM(),
+// and used by Inventory().
+type FirmwareInventory interface {
+
+	// FirmwareInventory returns the firmware components of the machine, in
+	// the order the manufacturer lists them.
+	FirmwareInventory(ctx context.Context, s Session) ([]FirmwareComponent, error)
+}
@@ -3,8 +3,8 @@ package bmc
 import (
 	"testing"
 
-	"github.com/gebn/bmc/pkg/iana"
-	"github.com/gebn/bmc/pkg/ipmi"
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
 )
 
 func TestFirmwareVersion(t *testing.T) {
@@ -84,6 +84,52 @@ func TestFirmwareVersion(t *testing.T) {
 			},
 			"03.72",
 		},
+		{
+			&ipmi.GetDeviceIDRsp{
+				ID:                        32,
+				Revision:                  1,
+				MajorFirmwareRevision:     1,
+				MinorFirmwareRevision:     1,
+				Manufacturer:              iana.EnterpriseSuperMicro,
+				Product:                   6929,
+				AuxiliaryFirmwareRevision: [4]byte{0x0a, 0x00, 0x00, 0x00},
+			},
+			"01.01.10",
+		},
+		{
+			&ipmi.GetDeviceIDRsp{
+				ID:                        32,
+				Revision:                  1,
+				MajorFirmwareRevision:     3,
+				MinorFirmwareRevision:     88,
+				Manufacturer:              iana.EnterpriseDell,
+				Product:                   256,
+				AuxiliaryFirmwareRevision: [4]byte{0x00, 0x00, 0x58, 0x58},
+			},
+			"3.88.88.88",
+		},
+		{
+			&ipmi.GetDeviceIDRsp{
+				ID:                        32,
+				Revision:                  1,
+				MajorFirmwareRevision:     2,
+				MinorFirmwareRevision:     12,
+				Manufacturer:              iana.EnterpriseOpenBMC,
+				AuxiliaryFirmwareRevision: [4]byte{0x01, 0x23, 0x00, 0x01},
+			},
+			"2.12-123",
+		},
+		{
+			&ipmi.GetDeviceIDRsp{
+				ID:                        32,
+				Revision:                  1,
+				MajorFirmwareRevision:     2,
+				MinorFirmwareRevision:     12,
+				Manufacturer:              iana.EnterpriseOpenBMC,
+				AuxiliaryFirmwareRevision: [4]byte{},
+			},
+			"2.12",
+		},
 	}
 	for _, test := range tests {
 		if got := FirmwareVersion(test.in); got != test.want {
@@ -0,0 +1,189 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// fruReadChunkSize is the number of bytes we initially request in each
+	// Read FRU Data command. Responses must fit in a 32-byte message on IPMB,
+	// but BMCs talking over LAN can usually return more; we are conservative,
+	// as FRU data is small, and halve this if the BMC objects.
+	fruReadChunkSize = 32
+
+	// fruMinReadChunkSize is the size below which we stop halving the number
+	// of bytes requested, and give up.
+	fruMinReadChunkSize = 4
+)
+
+// FRUInventory contains the decoded information areas of a FRU Inventory
+// Device. Areas not present on the device are nil. The internal use and
+// multi-record areas are not retrieved.
+type FRUInventory struct {
+	Chassis *ipmi.FRUChassisInfoArea
+	Board   *ipmi.FRUBoardInfoArea
+	Product *ipmi.FRUProductInfoArea
+}
+
+// ReadFRUInventory retrieves and decodes the chassis, board and product
+// information areas of a FRU Inventory Device. Device 0 contains the FRU
+// information of the BMC itself, and is usually the only one of interest. An
+// error is returned if the device does not exist, or any present area cannot
+// be decoded.
+func ReadFRUInventory(ctx context.Context, s Session, deviceID uint8) (*FRUInventory, error) {
+	r, err := newFRUReader(ctx, s, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFRUInventory(func(offset, n int) ([]byte, error) {
+		return r.read(ctx, offset, n)
+	})
+}
+
+// fruReadFunc returns n bytes of a FRU Inventory Device starting at offset.
+// The returned slice must not alias any packet.
+type fruReadFunc func(offset, n int) ([]byte, error)
+
+// decodeFRUInventory reads the common header of a FRU Inventory Device, then
+// decodes the chassis, board and product information areas it points to.
+func decodeFRUInventory(read fruReadFunc) (*FRUInventory, error) {
+	data, err := read(0, 8)
+	if err != nil {
+		return nil, err
+	}
+	header := &ipmi.FRUCommonHeader{}
+	if err := header.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+
+	inventory := &FRUInventory{}
+	areas := []struct {
+		offset uint16
+		layer  gopacket.DecodingLayer
+	}{
+		{header.ChassisInfoOffset, &ipmi.FRUChassisInfoArea{}},
+		{header.BoardInfoOffset, &ipmi.FRUBoardInfoArea{}},
+		{header.ProductInfoOffset, &ipmi.FRUProductInfoArea{}},
+	}
+	for _, area := range areas {
+		if area.offset == 0 {
+			continue
+		}
+		data, err := readFRUArea(read, area.offset)
+		if err != nil {
+			return nil, err
+		}
+		if err := area.layer.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+		switch layer := area.layer.(type) {
+		case *ipmi.FRUChassisInfoArea:
+			inventory.Chassis = layer
+		case *ipmi.FRUBoardInfoArea:
+			inventory.Board = layer
+		case *ipmi.FRUProductInfoArea:
+			inventory.Product = layer
+		}
+	}
+	return inventory, nil
+}
+
+// readFRUArea reads a chassis, board or product information area starting at
+// offset, using its length field to determine how much to read.
+func readFRUArea(read fruReadFunc, offset uint16) ([]byte, error) {
+	// all areas are at least 8 bytes
+	data, err := read(int(offset), 8)
+	if err != nil {
+		return nil, err
+	}
+	length, err := ipmi.FRUAreaLength(data)
+	if err != nil {
+		return nil, err
+	}
+	if length <= len(data) {
+		return data[:length], nil
+	}
+	rest, err := read(int(offset)+len(data), length-len(data))
+	if err != nil {
+		return nil, err
+	}
+	return append(data, rest...), nil
+}
+
+// fruReader reads byte ranges from a FRU Inventory Device, hiding word access
+// and the BMC's maximum response size.
+type fruReader struct {
+	session    Session
+	cmd        ipmi.ReadFRUDataCmd
+	size       int
+	wordAccess bool
+	chunkSize  int
+}
+
+func newFRUReader(ctx context.Context, s Session, deviceID uint8) (*fruReader, error) {
+	infoCmd := &ipmi.GetFRUInventoryAreaInfoCmd{
+		Req: ipmi.GetFRUInventoryAreaInfoReq{
+			DeviceID: deviceID,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, infoCmd)); err != nil {
+		return nil, err
+	}
+	r := &fruReader{
+		session:    s,
+		size:       int(infoCmd.Rsp.Size),
+		wordAccess: infoCmd.Rsp.WordAccess,
+		chunkSize:  fruReadChunkSize,
+	}
+	r.cmd.Req.DeviceID = deviceID
+	return r, nil
+}
+
+// read returns n bytes from the device starting at offset. The returned slice
+// does not alias any packet.
+func (r *fruReader) read(ctx context.Context, offset, n int) ([]byte, error) {
+	if offset+n > r.size {
+		return nil, fmt.Errorf("cannot read %v bytes at offset %v from FRU "+
+			"device of %v bytes", n, offset, r.size)
+	}
+	data := make([]byte, 0, n)
+	for len(data) < n {
+		count := n - len(data)
+		if count > r.chunkSize {
+			count = r.chunkSize
+		}
+		r.cmd.Req.Offset = uint16(offset + len(data))
+		r.cmd.Req.Count = uint8(count)
+		if r.wordAccess {
+			// FRU offsets and lengths are multiples of 8 bytes, so these
+			// are always even
+			r.cmd.Req.Offset /= 2
+			r.cmd.Req.Count = uint8((count + 1) / 2)
+		}
+		code, err := r.session.SendCommand(ctx, &r.cmd)
+		// the response is likely truncated, so err may be a decode error
+		if code == ipmi.CompletionCodeCannotReturnRequestedDataBytes &&
+			r.chunkSize/2 >= fruMinReadChunkSize {
+			r.chunkSize /= 2
+			continue
+		}
+		if err := ValidateResponse(code, err); err != nil {
+			return nil, err
+		}
+		read := r.cmd.Rsp.Data
+		if len(read) == 0 {
+			return nil, fmt.Errorf("FRU device returned no data at offset %v",
+				r.cmd.Req.Offset)
+		}
+		if len(read) > n-len(data) {
+			read = read[:n-len(data)]
+		}
+		data = append(data, read...)
+	}
+	return data, nil
+}
@@ -0,0 +1,5 @@
+package bmc
+
+// The compat/gebn module re-exports this module's public packages under the
+// upstream import paths.
+//go:generate go run ./internal/cmd/aliasgen
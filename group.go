@@ -0,0 +1,116 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultGroupParallelism is the number of hosts a Group operates on
+	// concurrently if its Parallelism is unset.
+	defaultGroupParallelism = 16
+)
+
+// Group runs a function against many BMCs concurrently, e.g. to collect the
+// firmware version of a fleet. It bounds parallelism, applies a timeout to each
+// host, and isolates panics, so one misbehaving BMC cannot affect the others.
+// The zero value is usable. T is the type of result the function returns for
+// each host.
+type Group[T any] struct {
+
+	// Parallelism is the maximum number of hosts to operate on concurrently.
+	// Defaults to 16.
+	Parallelism int
+
+	// Timeout is the maximum time to spend on each host. The context passed to
+	// the function expires after this. If 0, only the context passed to Run()
+	// applies.
+	Timeout time.Duration
+}
+
+// GroupResult is the outcome of running a Group's function against a single
+// host.
+type GroupResult[T any] struct {
+
+	// Addr is the host, as passed to Run().
+	Addr string
+
+	// Value is the value returned by the function. It is only meaningful if
+	// Err is nil.
+	Value T
+
+	// Err is the error returned by the function, or an error describing the
+	// panic it raised. If the function was not called because the context
+	// passed to Run() expired, this is the context error.
+	Err error
+
+	// Elapsed is how long the function took to return.
+	Elapsed time.Duration
+}
+
+// Run calls f for each address, returning one result per address in the same
+// order. The returned error joins the errors of all failed hosts, each
+// prefixed with its address; it is nil if every host succeeded, and is
+// compatible with errors.Is() and errors.As(). Run returns once all started
+// calls have returned; f must respect its context for the per-host timeout to
+// be effective.
+func (g *Group[T]) Run(ctx context.Context, addrs []string, f func(ctx context.Context, addr string) (T, error)) ([]GroupResult[T], error) {
+	parallelism := g.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultGroupParallelism
+	}
+
+	results := make([]GroupResult[T], len(addrs))
+	sem := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	for i, addr := range addrs {
+		results[i].Addr = addr
+		// select chooses randomly if both are ready, so check first
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(result *GroupResult[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			g.runOne(ctx, result, f)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	errs := []error{}
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", result.Addr, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// runOne calls f for a single host, populating result.
+func (g *Group[T]) runOne(ctx context.Context, result *GroupResult[T], f func(context.Context, string) (T, error)) {
+	if g.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	defer func() {
+		result.Elapsed = time.Since(start)
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	result.Value, result.Err = f(ctx, result.Addr)
+}
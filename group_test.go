@@ -0,0 +1,115 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupRun(t *testing.T) {
+	errHost := errors.New("host failed")
+	var running, maxRunning int32
+	g := &Group[string]{
+		Parallelism: 2,
+		Timeout:     time.Millisecond * 20,
+	}
+	addrs := []string{"ok-1", "fail", "panic", "hang", "ok-2"}
+	results, err := g.Run(context.Background(), addrs,
+		func(ctx context.Context, addr string) (string, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond) // give other calls a chance to overlap
+
+			switch addr {
+			case "fail":
+				return "", errHost
+			case "panic":
+				panic("oops")
+			case "hang":
+				<-ctx.Done()
+				return "", ctx.Err()
+			default:
+				return strings.ToUpper(addr), nil
+			}
+		})
+
+	if max := atomic.LoadInt32(&maxRunning); max > 2 {
+		t.Errorf("%v calls ran concurrently, want at most 2", max)
+	}
+	if len(results) != len(addrs) {
+		t.Fatalf("got %v results, want %v", len(results), len(addrs))
+	}
+	for i, addr := range addrs {
+		if results[i].Addr != addr {
+			t.Errorf("result %v is for %v, want %v", i, results[i].Addr, addr)
+		}
+	}
+	if results[0].Err != nil || results[0].Value != "OK-1" ||
+		results[4].Err != nil || results[4].Value != "OK-2" {
+		t.Errorf("successful results = %+v, %+v", results[0], results[4])
+	}
+	if !errors.Is(results[1].Err, errHost) {
+		t.Errorf("fail result error = %v, want %v", results[1].Err, errHost)
+	}
+	if results[2].Err == nil || !strings.HasPrefix(results[2].Err.Error(), "panic: oops") {
+		t.Errorf("panic result error = %v, want panic", results[2].Err)
+	}
+	if !errors.Is(results[3].Err, context.DeadlineExceeded) {
+		t.Errorf("hang result error = %v, want %v", results[3].Err,
+			context.DeadlineExceeded)
+	}
+
+	if !errors.Is(err, errHost) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() = %v, want joined host errors", err)
+	}
+	if !strings.Contains(err.Error(), "fail: host failed") {
+		t.Errorf("Run() = %v, want errors prefixed with address", err)
+	}
+}
+
+func TestGroupRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := &Group[struct{}]{}
+	called := false
+	results, err := g.Run(ctx, []string{"a", "b"},
+		func(context.Context, string) (struct{}, error) {
+			called = true
+			return struct{}{}, nil
+		})
+	if called {
+		t.Errorf("function called after context cancelled")
+	}
+	for _, result := range results {
+		if result.Err != context.Canceled {
+			t.Errorf("result for %v = %v, want %v", result.Addr, result.Err,
+				context.Canceled)
+		}
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestGroupRunSuccess(t *testing.T) {
+	g := &Group[int]{}
+	results, err := g.Run(context.Background(), []string{"a", "bb"},
+		func(_ context.Context, addr string) (int, error) {
+			return len(addr), nil
+		})
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if results[0].Value != 1 || results[1].Value != 2 {
+		t.Errorf("Run() = %+v, want values 1 and 2", results)
+	}
+}
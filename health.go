@@ -0,0 +1,223 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// healthSELRecords is the number of most recent SEL records examined by
+	// Health(). Recency is by position rather than timestamp, as BMC clocks
+	// are frequently wrong, or unset until the OS boots.
+	healthSELRecords = 256
+)
+
+// HealthStatus is the overall health of a machine, as determined by Health().
+// Values are ordered by severity.
+type HealthStatus uint8
+
+const (
+	HealthStatusOK HealthStatus = iota
+	HealthStatusWarning
+	HealthStatusCritical
+)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthStatusOK:
+		return "OK"
+	case HealthStatusWarning:
+		return "Warning"
+	case HealthStatusCritical:
+		return "Critical"
+	default:
+		return "Unknown"
+	}
+}
+
+// HealthReason is a finding contributing to a HealthReport.
+type HealthReason struct {
+
+	// Status is the severity of the finding; it is never HealthStatusOK.
+	Status HealthStatus
+
+	// Description explains the finding, e.g. which sensor and threshold.
+	Description string
+}
+
+func (r HealthReason) String() string {
+	return fmt.Sprintf("%v: %v", r.Status, r.Description)
+}
+
+// HealthReport summarises the health of a machine.
+type HealthReport struct {
+
+	// Status is the most severe status of any reason, or HealthStatusOK if
+	// there are none.
+	Status HealthStatus
+
+	// Reasons contains the findings behind Status: threshold sensors in SDR
+	// Repository record ID order, followed by SEL records, oldest first.
+	Reasons []HealthReason
+}
+
+func (r *HealthReport) add(status HealthStatus, format string, a ...interface{}) {
+	r.Reasons = append(r.Reasons, HealthReason{
+		Status:      status,
+		Description: fmt.Sprintf(format, a...),
+	})
+	if status > r.Status {
+		r.Status = status
+	}
+}
+
+// Health evaluates the machine behind a session, so fleet health checks can be
+// done in a single call. Every threshold sensor in the SDR Repository is read,
+// with those at or beyond a non-critical threshold contributing a warning, and
+// those at or beyond a critical or non-recoverable threshold contributing a
+// critical reason. The BMC's own comparison against each threshold is used, so
+// no conversion is required. Sensors that are not present, disabled or whose
+// reading is unavailable are skipped. Recent SEL records are then scanned for
+// threshold events that were asserted and never deasserted, which are
+// evaluated the same way; this catches sensors that cannot currently be read,
+// e.g. because the system is off. Discrete events are ignored, as their
+// severity depends on the sensor type and offset, and many are informational.
+// An error is returned if the SDR Repository or SEL cannot be retrieved.
+func Health(ctx context.Context, s Session) (*HealthReport, error) {
+	repo, err := RetrieveSDRRepository(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	recordIDs := make([]ipmi.RecordID, 0, len(repo))
+	for recordID, fsr := range repo {
+		if fsr.OutputType == ipmi.OutputTypeThreshold {
+			recordIDs = append(recordIDs, recordID)
+		}
+	}
+	sort.Slice(recordIDs, func(i, j int) bool {
+		return recordIDs[i] < recordIDs[j]
+	})
+
+	report := &HealthReport{}
+	for _, recordID := range recordIDs {
+		fsr := repo[recordID]
+		status, ok, err := thresholdStatus(ctx, s, fsr)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || status == 0 {
+			continue
+		}
+		report.add(thresholdHealth(status), "sensor %v is at or beyond %v",
+			fsr.Identity, status)
+	}
+
+	records, err := RetrieveSEL(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > healthSELRecords {
+		records = records[len(records)-healthSELRecords:]
+	}
+	for _, record := range unrecoveredThresholdEvents(records) {
+		status := ipmi.ThresholdStatus(1 << ((record.EventData[0] & 0xf) >> 1))
+		report.add(thresholdHealth(status), "SEL record %v: sensor %v "+
+			"crossed %v without recovering", record.ID,
+			selSensorName(repo, record), status)
+	}
+	return report, nil
+}
+
+// thresholdStatus reads a threshold sensor, returning the thresholds it is at
+// or beyond, and whether the status is valid. An error is only returned if the
+// session may no longer be usable; a sensor that cannot be read is not valid.
+func thresholdStatus(ctx context.Context, s Session, fsr *ipmi.FullSensorRecord) (ipmi.ThresholdStatus, bool, error) {
+	cmd := &ipmi.GetSensorReadingCmd{
+		Req: ipmi.GetSensorReadingReq{
+			Number: fsr.Number,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx,
+		ipmi.CommandWithLUN(cmd, fsr.OwnerLUN))); err != nil {
+		if isSessionError(err) {
+			return 0, false, err
+		}
+		// some BMCs return an empty response when the component is not
+		// present
+		return 0, false, nil
+	}
+	if cmd.Rsp.ReadingUnavailable || !cmd.Rsp.ScanningEnabled {
+		return 0, false, nil
+	}
+	return cmd.Rsp.ThresholdStatus, true, nil
+}
+
+// thresholdHealth returns the severity of a sensor at or beyond thresholds.
+func thresholdHealth(s ipmi.ThresholdStatus) HealthStatus {
+	switch {
+	case s.Critical():
+		return HealthStatusCritical
+	case s != 0:
+		return HealthStatusWarning
+	default:
+		return HealthStatusOK
+	}
+}
+
+// selThresholdEvent identifies a threshold of a sensor in the SEL. The going
+// low and going high offsets of a threshold are considered the same.
+type selThresholdEvent struct {
+	generatorID  uint16
+	sensorNumber uint8
+	threshold    uint8
+}
+
+// unrecoveredThresholdEvents returns the threshold event assertions in records
+// without a subsequent deassertion, oldest first.
+func unrecoveredThresholdEvents(records []*ipmi.SELEventRecord) []*ipmi.SELEventRecord {
+	asserted := map[selThresholdEvent]*ipmi.SELEventRecord{}
+	for _, record := range records {
+		offset := record.EventData[0] & 0xf
+		if record.Type != ipmi.SELRecordTypeSystemEvent ||
+			record.EventType != uint8(ipmi.OutputTypeThreshold) ||
+			offset > 0xb {
+			continue
+		}
+		event := selThresholdEvent{
+			generatorID:  record.GeneratorID,
+			sensorNumber: record.SensorNumber,
+			threshold:    offset >> 1,
+		}
+		if record.Deassertion {
+			delete(asserted, event)
+		} else {
+			asserted[event] = record
+		}
+	}
+	remaining := map[*ipmi.SELEventRecord]bool{}
+	for _, record := range asserted {
+		remaining[record] = true
+	}
+	unrecovered := []*ipmi.SELEventRecord{}
+	for _, record := range records {
+		if remaining[record] {
+			unrecovered = append(unrecovered, record)
+		}
+	}
+	return unrecovered
+}
+
+// selSensorName returns the name of the sensor that generated a SEL record, or
+// its number if it is not in the SDR Repository.
+func selSensorName(repo SDRRepository, r *ipmi.SELEventRecord) string {
+	for _, fsr := range repo {
+		if fsr.OwnerAddress == ipmi.Address(r.GeneratorID) &&
+			fsr.Number == r.SensorNumber {
+			return fsr.Identity
+		}
+	}
+	return fmt.Sprintf("%#x", r.SensorNumber)
+}
@@ -0,0 +1,177 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrNoMachineIdentity is returned by NewMachineIdentity() if none of the
+	// sources of identity are usable, e.g. a BMC with a placeholder GUID, no
+	// FRU data, and no MAC address.
+	ErrNoMachineIdentity = errors.New("no usable machine identity")
+
+	// placeholderGUIDs contains GUIDs that are shared by many machines, in
+	// the byte order returned by Get System GUID. Values with all bytes equal
+	// are also rejected.
+	placeholderGUIDs = [][16]byte{
+		// 03000200-0400-0500-0006-000700080009, set by AMI BIOSes that have
+		// not been customised, in both byte orders
+		{0x00, 0x02, 0x00, 0x03, 0x00, 0x04, 0x00, 0x05,
+			0x00, 0x06, 0x00, 0x07, 0x00, 0x08, 0x00, 0x09},
+		{0x09, 0x00, 0x08, 0x00, 0x07, 0x00, 0x06, 0x00,
+			0x05, 0x00, 0x04, 0x00, 0x03, 0x00, 0x02, 0x00},
+	}
+
+	// placeholderSerials contains upper case serial numbers left in FRU data
+	// by manufacturers that do not set one. Values consisting of a single
+	// repeated character are also rejected.
+	placeholderSerials = map[string]bool{
+		"TO BE FILLED BY O.E.M.": true,
+		"DEFAULT STRING":         true,
+		"SYSTEM SERIAL NUMBER":   true,
+		"SERIAL NUMBER":          true,
+		"0123456789":             true,
+		"123456789":              true,
+		"NONE":                   true,
+		"N/A":                    true,
+		"NA":                     true,
+		"UNKNOWN":                true,
+	}
+)
+
+// IdentitySource is the source of a machine's canonical identity.
+type IdentitySource uint8
+
+const (
+	// IdentitySourceGUID indicates the identity is the system GUID.
+	IdentitySourceGUID IdentitySource = iota
+
+	// IdentitySourceBoardSerial indicates the identity is the board serial
+	// number in the FRU data of the BMC.
+	IdentitySourceBoardSerial
+
+	// IdentitySourceMACAddress indicates the identity is the MAC address of
+	// the BMC's LAN channel.
+	IdentitySourceMACAddress
+)
+
+func (s IdentitySource) String() string {
+	switch s {
+	case IdentitySourceGUID:
+		return "guid"
+	case IdentitySourceBoardSerial:
+		return "serial"
+	case IdentitySourceMACAddress:
+		return "mac"
+	default:
+		return fmt.Sprintf("Unknown(%v)", uint8(s))
+	}
+}
+
+// MachineIdentity is a stable, canonical identifier for a machine, derived
+// from the system GUID, board serial number and BMC MAC address. Each is
+// unreliable on some hardware: GUIDs are sometimes left as placeholders shared
+// by every machine with the same BIOS, serial numbers are often unset, and the
+// MAC address changes if the BMC's NIC is replaced. The first usable value in
+// that order of precedence is used.
+type MachineIdentity struct {
+
+	// ID is the canonical identifier, consisting of the source and its
+	// normalised value, e.g. "guid:44454c4c-4200-1035-8056-b4c04f4d4b32".
+	ID string
+
+	// Source is the source of ID.
+	Source IdentitySource
+
+	// GUID is the normalised system GUID: lower case hexadecimal, formatted
+	// from the bytes in the order returned by the BMC. As this varies between
+	// manufacturers, it may not match the UUID reported by the OS. It is
+	// empty if the GUID is a placeholder.
+	GUID string
+
+	// BoardSerial is the normalised board serial number: trimmed and upper
+	// case. It is empty if there is no FRU board area, or the serial number
+	// is a placeholder.
+	BoardSerial string
+
+	// MACAddress is the normalised MAC address of the BMC: lower case and
+	// colon-separated. It is empty if unknown or not a unicast address.
+	MACAddress string
+}
+
+// String returns the ID.
+func (i *MachineIdentity) String() string {
+	return i.ID
+}
+
+// NewMachineIdentity derives the identity of a machine from its inventory,
+// returning ErrNoMachineIdentity if no source is usable.
+func NewMachineIdentity(inv *MachineInventory) (*MachineIdentity, error) {
+	identity := &MachineIdentity{
+		GUID: normaliseGUID(inv.GUID),
+	}
+	if inv.FRU != nil && inv.FRU.Board != nil {
+		identity.BoardSerial = normaliseSerial(inv.FRU.Board.SerialNumber)
+	}
+	if len(inv.MACAddress) == 6 && inv.MACAddress[0]&1 == 0 &&
+		!bytes.Equal(inv.MACAddress, make([]byte, 6)) {
+		identity.MACAddress = inv.MACAddress.String()
+	}
+
+	switch {
+	case identity.GUID != "":
+		identity.Source = IdentitySourceGUID
+		identity.ID = identity.Source.String() + ":" + identity.GUID
+	case identity.BoardSerial != "":
+		identity.Source = IdentitySourceBoardSerial
+		identity.ID = identity.Source.String() + ":" + identity.BoardSerial
+	case identity.MACAddress != "":
+		identity.Source = IdentitySourceMACAddress
+		identity.ID = identity.Source.String() + ":" + identity.MACAddress
+	default:
+		return nil, ErrNoMachineIdentity
+	}
+	return identity, nil
+}
+
+// GetMachineIdentity retrieves the inventory of a machine with Inventory(),
+// and derives its identity.
+func GetMachineIdentity(ctx context.Context, s Session) (*MachineIdentity, error) {
+	inv, err := Inventory(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return NewMachineIdentity(inv)
+}
+
+// normaliseGUID formats a GUID in canonical form, or returns an empty string
+// if it is a placeholder.
+func normaliseGUID(guid [16]byte) string {
+	if bytes.Count(guid[:], guid[:1]) == len(guid) {
+		return ""
+	}
+	for _, placeholder := range placeholderGUIDs {
+		if guid == placeholder {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", guid[0:4], guid[4:6], guid[6:8],
+		guid[8:10], guid[10:16])
+}
+
+// normaliseSerial trims and upper cases a serial number, or returns an empty
+// string if it is a placeholder.
+func normaliseSerial(serial string) string {
+	serial = strings.ToUpper(strings.TrimSpace(serial))
+	first, _ := utf8.DecodeRuneInString(serial)
+	if serial == "" || placeholderSerials[serial] ||
+		strings.Trim(serial, string(first)) == "" {
+		return ""
+	}
+	return serial
+}
@@ -0,0 +1,92 @@
+package bmc
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestNewMachineIdentity(t *testing.T) {
+	guid := [16]byte{0x44, 0x45, 0x4c, 0x4c, 0x42, 0x00, 0x10, 0x35,
+		0x80, 0x56, 0xb4, 0xc0, 0x4f, 0x4d, 0x4b, 0x32}
+	amiGUID := [16]byte{0x00, 0x02, 0x00, 0x03, 0x00, 0x04, 0x00, 0x05,
+		0x00, 0x06, 0x00, 0x07, 0x00, 0x08, 0x00, 0x09}
+	fru := func(serial string) *FRUInventory {
+		return &FRUInventory{
+			Board: &ipmi.FRUBoardInfoArea{
+				SerialNumber: serial,
+			},
+		}
+	}
+	mac := net.HardwareAddr{0x0C, 0xC4, 0x7A, 0x01, 0x02, 0x03}
+
+	tests := []struct {
+		name string
+		inv  *MachineInventory
+		want string // empty if error expected
+	}{
+		{
+			"guid preferred",
+			&MachineInventory{GUID: guid, FRU: fru("BSN0001"), MACAddress: mac},
+			"guid:44454c4c-4200-1035-8056-b4c04f4d4b32",
+		},
+		{
+			"zero guid",
+			&MachineInventory{FRU: fru(" bsn0001 "), MACAddress: mac},
+			"serial:BSN0001",
+		},
+		{
+			"placeholder guid",
+			&MachineInventory{GUID: amiGUID, FRU: fru("BSN0001")},
+			"serial:BSN0001",
+		},
+		{
+			"placeholder serial",
+			&MachineInventory{FRU: fru("To be filled by O.E.M."), MACAddress: mac},
+			"mac:0c:c4:7a:01:02:03",
+		},
+		{
+			"repeated serial",
+			&MachineInventory{FRU: fru("0000000"), MACAddress: mac},
+			"mac:0c:c4:7a:01:02:03",
+		},
+		{
+			"no board area",
+			&MachineInventory{FRU: &FRUInventory{}, MACAddress: mac},
+			"mac:0c:c4:7a:01:02:03",
+		},
+		{
+			"multicast mac",
+			&MachineInventory{
+				MACAddress: net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01},
+			},
+			"",
+		},
+		{
+			"nothing",
+			&MachineInventory{MACAddress: make(net.HardwareAddr, 6)},
+			"",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewMachineIdentity(test.inv)
+			if test.want == "" {
+				if !errors.Is(err, ErrNoMachineIdentity) {
+					t.Errorf("NewMachineIdentity() = %v, %v, want %v", got,
+						err, ErrNoMachineIdentity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMachineIdentity() failed: %v", err)
+			}
+			if got.ID != test.want {
+				t.Errorf("NewMachineIdentity().ID = %q, want %q", got.ID,
+					test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// modulePath is the import path of this module.
+	modulePath = "github.com/kuiwang02/bmc"
+
+	// upstreamPath is the import path of the module being emulated.
+	upstreamPath = "github.com/gebn/bmc"
+
+	// fileName is the name of the generated file in each package.
+	fileName = "aliases.go"
+)
+
+// packages are the directories of the public packages upstream, relative to
+// the module root.
+var packages = []string{
+	".",
+	"pkg/dcmi",
+	"pkg/iana",
+	"pkg/ipmi",
+	"pkg/layerexts",
+}
+
+// File is a generated source file.
+type File struct {
+
+	// Name is the path of the file relative to the compatibility module.
+	Name   string
+	Source []byte
+}
+
+// exports contains the exported package-level identifiers of a package.
+type exports struct {
+	name                  string
+	types, consts, values []string
+}
+
+// Generate returns the alias file for each package, given the root directory
+// of this module.
+func Generate(root string) ([]File, error) {
+	files := []File{}
+	for _, dir := range packages {
+		e, err := parseExports(path.Join(root, dir))
+		if err != nil {
+			return nil, err
+		}
+		src, err := e.source(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", dir, err)
+		}
+		files = append(files, File{path.Join(dir, fileName), src})
+	}
+	return files, nil
+}
+
+// parseExports finds the exported identifiers of the package in dir,
+// excluding tests.
+func parseExports(dir string) (*exports, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%v: expected 1 package, found %v", dir,
+			len(pkgs))
+	}
+
+	e := &exports{}
+	for name, pkg := range pkgs {
+		e.name = name
+		for _, file := range pkg.Files {
+			e.add(file)
+		}
+	}
+	sort.Strings(e.types)
+	sort.Strings(e.consts)
+	sort.Strings(e.values)
+	return e, nil
+}
+
+func (e *exports) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil && decl.Name.IsExported() &&
+				decl.Type.TypeParams == nil {
+				e.values = append(e.values, decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() && spec.TypeParams == nil {
+						e.types = append(e.types, spec.Name.Name)
+					}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if !name.IsExported() {
+							continue
+						}
+						if decl.Tok == token.CONST {
+							e.consts = append(e.consts, name.Name)
+						} else {
+							e.values = append(e.values, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// source returns the alias file for the package in dir.
+func (e *exports) source(dir string) ([]byte, error) {
+	importPath := modulePath
+	if dir != "." {
+		importPath += "/" + dir
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("// Code generated by aliasgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "// Package %v forwards to %v,\n// so code written against "+
+		"%v compiles unchanged.\n", e.name, importPath,
+		path.Join(upstreamPath, dir))
+	fmt.Fprintf(b, "package %v\n\nimport fork %q\n\n", e.name, importPath)
+	section := func(keyword string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		fmt.Fprintf(b, "%v (\n", keyword)
+		for _, name := range names {
+			fmt.Fprintf(b, "%v = fork.%v\n", name, name)
+		}
+		b.WriteString(")\n\n")
+	}
+	section("type", e.types)
+	section("const", e.consts)
+	section("var", e.values)
+
+	return format.Source([]byte(b.String()))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails if the checked-in compatibility module does not
+// match what the generator currently produces, i.e. an exported identifier
+// has been added or removed without running go generate.
+func TestGeneratedUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+	files, err := Generate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		path := filepath.Join(root, "compat", "gebn", f.Name)
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("%v: %v", f.Name, err)
+			continue
+		}
+		if !bytes.Equal(existing, f.Source) {
+			t.Errorf("%v is out of date; run go generate", f.Name)
+		}
+	}
+}
+
+func TestParseExports(t *testing.T) {
+	e, err := parseExports(filepath.Join("..", "..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.name != "bmc" {
+		t.Errorf("package name = %v, want bmc", e.name)
+	}
+	found := map[string]bool{}
+	for _, names := range [][]string{e.types, e.consts, e.values} {
+		for _, name := range names {
+			found[name] = true
+		}
+	}
+	for _, name := range []string{"Session", "Dial", "ErrTransportClosed"} {
+		if !found[name] {
+			t.Errorf("%v not exported", name)
+		}
+	}
+	// generics cannot be aliased
+	for _, name := range []string{"Group", "Command", "newTransport"} {
+		if found[name] {
+			t.Errorf("%v unexpectedly exported", name)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+// Aliasgen generates the compat/gebn module, which re-exports this module's
+// packages under the github.com/gebn/bmc import path, so code written against
+// upstream compiles against this fork unchanged. Types become aliases, so
+// values can be passed freely between the two import paths; functions and
+// variables are forwarded. Generic types and functions are omitted, as they
+// cannot be aliased, and do not exist upstream. It is run via go:generate in
+// the root package.
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	flgRoot = kingpin.Flag("root", "Root directory of this module.").
+		Default(".").
+		String()
+	flgOut = kingpin.Flag("out", "Directory of the compatibility module, "+
+		"relative to the root.").
+		Default(filepath.Join("compat", "gebn")).
+		String()
+)
+
+func main() {
+	kingpin.Parse()
+
+	files, err := Generate(*flgRoot)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range files {
+		path := filepath.Join(*flgRoot, *flgOut, f.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, f.Source, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
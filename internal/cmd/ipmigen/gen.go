@@ -0,0 +1,437 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// header marks files as generated, so they are ignored by linters and not
+// edited by hand.
+const header = "// Code generated by ipmigen from %v. DO NOT EDIT.\n\n"
+
+// modulePath is the import path prefix of this module, whose packages are
+// grouped separately from other imports.
+const modulePath = "github.com/kuiwang02/bmc"
+
+// generator accumulates the source of a single file.
+type generator struct {
+	b       strings.Builder
+	imports map[string]bool
+}
+
+func newGenerator() *generator {
+	return &generator{
+		imports: map[string]bool{},
+	}
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.b, format, args...)
+}
+
+// source assembles the file, adding the header, package clause and imports,
+// and formats it.
+func (g *generator) source(specName, pkg string) ([]byte, error) {
+	// standard library, this module, then everything else
+	groups := make([][]string, 3)
+	for path := range g.imports {
+		group := 2
+		switch {
+		case !strings.Contains(strings.SplitN(path, "/", 2)[0], "."):
+			group = 0
+		case strings.HasPrefix(path, modulePath+"/"):
+			group = 1
+		}
+		groups[group] = append(groups[group], path)
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, header, specName)
+	fmt.Fprintf(b, "package %v\n\nimport (\n", pkg)
+	first := true
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		sort.Strings(group)
+		for _, path := range group {
+			fmt.Fprintf(b, "\t%q\n", path)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.WriteString(g.b.String())
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, b)
+	}
+	return src, nil
+}
+
+// File is a generated source file.
+type File struct {
+	Name   string
+	Source []byte
+}
+
+// Generate returns the layer and test files for every command in the spec.
+// specName is recorded in the generated file headers.
+func Generate(spec *Spec, specName string) ([]File, error) {
+	files := []File{}
+	for _, c := range spec.Commands {
+		g := newGenerator()
+		g.command(c)
+		src, err := g.source(specName, spec.Package)
+		if err != nil {
+			return nil, fmt.Errorf("command %v: %w", c.Name, err)
+		}
+		files = append(files, File{c.File + ".go", src})
+
+		if !c.hasTests() {
+			continue
+		}
+		g = newGenerator()
+		g.tests(c)
+		src, err = g.source(specName, spec.Package)
+		if err != nil {
+			return nil, fmt.Errorf("command %v tests: %w", c.Name, err)
+		}
+		files = append(files, File{c.File + "_test.go", src})
+	}
+	return files, nil
+}
+
+func (c *Command) hasTests() bool {
+	return c.Request != nil || c.Response != nil
+}
+
+// command emits the operations, layer types, layers and command struct.
+func (g *generator) command(c *Command) {
+	g.imports["github.com/google/gopacket"] = true
+
+	g.printf("var (\n")
+	for _, suffix := range []string{"Req", "Rsp"} {
+		g.printf("Operation%v%v = Operation{\nFunction: NetworkFunction%v%v,\n"+
+			"Command: %v,\n}\n", c.Name, suffix, c.Function, suffix, c.Command)
+	}
+	if c.Request != nil {
+		g.printf("LayerType%vReq = gopacket.RegisterLayerType(\n%v,\n"+
+			"gopacket.LayerTypeMetadata{\nName: \"%v Request\",\n},\n)\n",
+			c.Name, c.Request.LayerType, c.Display)
+	}
+	if c.Response != nil {
+		g.imports["github.com/kuiwang02/bmc/pkg/layerexts"] = true
+		g.printf("LayerType%vRsp = gopacket.RegisterLayerType(\n%v,\n"+
+			"gopacket.LayerTypeMetadata{\nName: \"%v Response\",\n"+
+			"Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {\n"+
+			"return &%vRsp{}\n}),\n},\n)\n",
+			c.Name, c.Response.LayerType, c.Display, c.Name)
+	}
+	g.printf(")\n\n")
+
+	if c.Response != nil {
+		g.printf("func init() {\noperationLayerTypes[Operation%vRsp] = "+
+			"LayerType%vRsp\n}\n\n", c.Name, c.Name)
+	}
+
+	if c.Request != nil {
+		g.request(c)
+	}
+	if c.Response != nil {
+		g.response(c)
+	}
+	g.cmd(c)
+}
+
+// doc emits a comment, wrapped at 80 columns including the indent.
+func (g *generator) doc(text string, indent int) {
+	if text == "" {
+		return
+	}
+	prefix := strings.Repeat("\t", indent) + "//"
+	line := prefix
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word)+indent*3 > 80 && line != prefix {
+			g.printf("%v\n", line)
+			line = prefix
+		}
+		line += " " + word
+	}
+	g.printf("%v\n", line)
+}
+
+// layerDoc returns the comment for a layer struct.
+func layerDoc(c *Command, suffix string) string {
+	kind := "the response to a"
+	if suffix == "Req" {
+		kind = "a"
+	}
+	doc := fmt.Sprintf("%v%v represents %v %v command.", c.Name, suffix,
+		kind, c.Display)
+	if c.Doc != "" {
+		doc += " " + c.Doc
+	}
+	return doc
+}
+
+func (g *generator) structDef(c *Command, suffix string, l *Layer) {
+	g.imports["github.com/google/gopacket/layers"] = true
+	g.doc(layerDoc(c, suffix), 0)
+	g.printf("type %v%v struct {\nlayers.BaseLayer\n", c.Name, suffix)
+	for _, f := range l.Fields {
+		g.printf("\n")
+		g.doc(f.Doc, 1)
+		g.printf("%v %v\n", f.Name, f.Type)
+	}
+	g.printf("}\n\n")
+	g.printf("func (*%v%v) LayerType() gopacket.LayerType {\n"+
+		"return LayerType%v%v\n}\n\n", c.Name, suffix, c.Name, suffix)
+}
+
+func (g *generator) request(c *Command) {
+	l := c.Request
+	g.structDef(c, "Req", l)
+	g.printf("func (r *%vReq) SerializeTo(b gopacket.SerializeBuffer, "+
+		"_ gopacket.SerializeOptions) error {\n", c.Name)
+	g.printf("bytes, err := b.PrependBytes(%v)\nif err != nil {\nreturn err\n}\n",
+		l.Length())
+
+	assigned := make([]bool, l.Length())
+	for _, f := range l.Fields {
+		op := "|="
+		if !assigned[f.Offset] {
+			op = "="
+		}
+		for i := f.Offset; i < f.Offset+f.Size(); i++ {
+			assigned[i] = true
+		}
+		switch f.Wire {
+		case "uint8":
+			g.printf("bytes[%v] %v %v\n", f.Offset, op, convert("uint8", f.Type, "r."+f.Name))
+		case "uint16", "uint32":
+			g.imports["encoding/binary"] = true
+			g.printf("binary.LittleEndian.Put%v(bytes[%v:%v], %v)\n",
+				exported(f.Wire), f.Offset, f.Offset+f.Size(),
+				convert(f.Wire, f.Type, "r."+f.Name))
+		case "bool":
+			if op == "=" {
+				g.printf("bytes[%v] = 0\n", f.Offset)
+			}
+			g.printf("if r.%v {\nbytes[%v] |= %v\n}\n", f.Name, f.Offset,
+				strings.Trim(bitMask(f.Bit), "()"))
+		case "bits":
+			value := fmt.Sprintf("%v&%#x", convert("uint8", f.Type, "r."+f.Name),
+				1<<f.Width-1)
+			if f.Bit != 0 {
+				value = fmt.Sprintf("(%v) << %v", value, f.Bit)
+			}
+			g.printf("bytes[%v] %v %v\n", f.Offset, op, value)
+		case "bytes":
+			g.printf("copy(bytes[%v:%v], r.%v[:])\n", f.Offset,
+				f.Offset+f.Size(), f.Name)
+		}
+	}
+	for i, ok := range assigned {
+		if !ok {
+			g.printf("bytes[%v] = 0 // reserved\n", i)
+		}
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func (g *generator) response(c *Command) {
+	l := c.Response
+	g.imports["fmt"] = true
+	g.structDef(c, "Rsp", l)
+	g.printf("func (r *%vRsp) CanDecode() gopacket.LayerClass {\n"+
+		"return r.LayerType()\n}\n\n", c.Name)
+	g.printf("func (*%vRsp) NextLayerType() gopacket.LayerType {\n"+
+		"return gopacket.LayerTypePayload\n}\n\n", c.Name)
+
+	length := l.Length()
+	g.printf("func (r *%vRsp) DecodeFromBytes(data []byte, "+
+		"df gopacket.DecodeFeedback) error {\n", c.Name)
+	g.printf("if len(data) < %v {\ndf.SetTruncated()\n"+
+		"return fmt.Errorf(\"%v response must be %v bytes, got %%v\", len(data))\n}\n\n",
+		length, c.Display, length)
+	g.printf("r.BaseLayer.Contents = data[:%v]\nr.BaseLayer.Payload = data[%v:]\n",
+		length, length)
+	for _, f := range l.Fields {
+		switch f.Wire {
+		case "uint8":
+			g.printf("r.%v = %v\n", f.Name,
+				convert(f.Type, "uint8", fmt.Sprintf("data[%v]", f.Offset)))
+		case "uint16", "uint32":
+			g.imports["encoding/binary"] = true
+			g.printf("r.%v = %v\n", f.Name, convert(f.Type, f.Wire,
+				fmt.Sprintf("binary.LittleEndian.%v(data[%v:%v])",
+					exported(f.Wire), f.Offset, f.Offset+f.Size())))
+		case "bool":
+			g.printf("r.%v = data[%v]&%v != 0\n", f.Name, f.Offset,
+				bitMask(f.Bit))
+		case "bits":
+			value := fmt.Sprintf("data[%v]", f.Offset)
+			if f.Bit != 0 {
+				value = fmt.Sprintf("(%v >> %v)", value, f.Bit)
+			}
+			value = fmt.Sprintf("%v & %#x", value, 1<<f.Width-1)
+			if f.Type != "uint8" && f.Type != "byte" {
+				value = fmt.Sprintf("%v(%v)", f.Type, value)
+			}
+			g.printf("r.%v = %v\n", f.Name, value)
+		case "bytes":
+			g.printf("copy(r.%v[:], data[%v:%v])\n", f.Name, f.Offset,
+				f.Offset+f.Size())
+		}
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func (g *generator) cmd(c *Command) {
+	g.printf("type %vCmd struct {\n", c.Name)
+	if c.Request != nil {
+		g.printf("Req %vReq\n", c.Name)
+	}
+	if c.Response != nil {
+		g.printf("Rsp %vRsp\n", c.Name)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// Name returns %q.\nfunc (*%vCmd) Name() string {\n"+
+		"return %q\n}\n\n", c.Display, c.Name, c.Display)
+	g.printf("// Operation returns &Operation%vReq.\n"+
+		"func (*%vCmd) Operation() *Operation {\nreturn &Operation%vReq\n}\n\n",
+		c.Name, c.Name, c.Name)
+	if c.Request != nil {
+		g.printf("func (c *%vCmd) Request() gopacket.SerializableLayer {\n"+
+			"return &c.Req\n}\n\n", c.Name)
+	} else {
+		g.printf("func (*%vCmd) Request() gopacket.SerializableLayer {\n"+
+			"return nil\n}\n\n", c.Name)
+	}
+	if c.Response != nil {
+		g.printf("func (c *%vCmd) Response() gopacket.DecodingLayer {\n"+
+			"return &c.Rsp\n}\n", c.Name)
+	} else {
+		g.printf("func (*%vCmd) Response() gopacket.DecodingLayer {\n"+
+			"return nil\n}\n", c.Name)
+	}
+}
+
+// tests emits a serialisation test for the request, and a decoding test for
+// the response. The decoding test always checks truncated data is rejected.
+func (g *generator) tests(c *Command) {
+	g.imports["testing"] = true
+	g.imports["github.com/google/gopacket"] = true
+	if l := c.Request; l != nil {
+		g.imports["bytes"] = true
+		g.printf("func Test%vReqSerializeTo(t *testing.T) {\n", c.Name)
+		g.printf("tests := []struct {\nlayer *%vReq\nwant []byte\n}{\n", c.Name)
+		g.printf("{\n&%vReq{},\n%v,\n},\n", c.Name,
+			byteSlice(make([]byte, l.Length())))
+		for _, e := range l.Tests {
+			data, _ := e.bytes()
+			g.printf("{\n&%vReq{\n", c.Name)
+			g.fieldValues(l, e)
+			g.printf("},\n%v,\n},\n", byteSlice(data[:l.Length()]))
+		}
+		g.printf("}\n")
+		g.printf(`for _, test := range tests {
+			sb := gopacket.NewSerializeBuffer()
+			if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+				t.Errorf("serialize %%+v failed with %%v", test.layer, err)
+				continue
+			}
+			if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+				t.Errorf("serialize %%+v = %%v, want %%v", test.layer, got, test.want)
+			}
+		}
+	}
+
+	`)
+	}
+	if l := c.Response; l != nil {
+		g.imports["github.com/google/go-cmp/cmp"] = true
+		if len(l.Tests) > 0 {
+			g.imports["github.com/google/gopacket/layers"] = true
+		}
+		g.printf("func Test%vRspDecodeFromBytes(t *testing.T) {\n", c.Name)
+		g.printf("tests := []struct {\nin []byte\nwant *%vRsp\n}{\n", c.Name)
+		g.printf("{\n// too short\n%v,\nnil,\n},\n",
+			byteSlice(make([]byte, l.Length()-1)))
+		for _, e := range l.Tests {
+			data, _ := e.bytes()
+			g.printf("{\n%v,\n&%vRsp{\n", byteSlice(data), c.Name)
+			g.printf("BaseLayer: layers.BaseLayer{\nContents: %v,\nPayload: %v,\n},\n",
+				byteSlice(data[:l.Length()]), byteSlice(data[l.Length():]))
+			g.fieldValues(l, e)
+			g.printf("},\n},\n")
+		}
+		g.printf("}\n")
+		g.printf(`for _, test := range tests {
+			rsp := &%vRsp{}
+			err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+			switch {
+			case err == nil && test.want == nil:
+				t.Errorf("expected error decoding %%v, got none", test.in)
+			case err != nil && test.want != nil:
+				t.Errorf("unexpected error decoding %%v: %%v", test.in, err)
+			case err == nil && test.want != nil:
+				if diff := cmp.Diff(test.want, rsp); diff != "" {
+					t.Errorf("decode %%v = %%v, want %%v: %%v", test.in, rsp, test.want, diff)
+				}
+			}
+		}
+	}
+	`, c.Name)
+	}
+}
+
+// fieldValues emits the fields of a struct literal for an example, in
+// declaration order.
+func (g *generator) fieldValues(l *Layer, e *Example) {
+	for _, f := range l.Fields {
+		if value, ok := e.Want[f.Name]; ok {
+			g.printf("%v: %v,\n", f.Name, value)
+		}
+	}
+}
+
+// convert returns expr, of type from, converted to type to, omitting the
+// conversion if they are the same.
+func convert(to, from, expr string) string {
+	if to == from || to == "uint8" && from == "byte" ||
+		to == "byte" && from == "uint8" {
+		return expr
+	}
+	return fmt.Sprintf("%v(%v)", to, expr)
+}
+
+// exported returns the name of the binary package function for a wire type,
+// e.g. Uint16.
+func exported(wire string) string {
+	return strings.ToUpper(wire[:1]) + wire[1:]
+}
+
+// bitMask returns an expression for a byte with only the provided bit set.
+func bitMask(bit int) string {
+	if bit == 0 {
+		return "1"
+	}
+	return fmt.Sprintf("(1 << %v)", bit)
+}
+
+// byteSlice returns a []byte literal containing b.
+func byteSlice(b []byte) string {
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("0x%02x", c)
+	}
+	return "[]byte{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"GetSELInfo", "get_sel_info"},
+		{"GetPOHCounter", "get_poh_counter"},
+		{"SetSELTime", "set_sel_time"},
+		{"ReadFRUData", "read_fru_data"},
+		{"GetDeviceID", "get_device_id"},
+	}
+	for _, test := range tests {
+		if got := snakeCase(test.in); got != test.want {
+			t.Errorf("snakeCase(%v) = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+// TestGeneratedUpToDate fails if the checked-in files generated from the ipmi
+// package's command table do not match what the generator currently produces,
+// i.e. go generate needs to be run.
+func TestGeneratedUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "pkg", "ipmi")
+	spec, err := ReadSpec(filepath.Join(dir, "commands.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := Generate(spec, "commands.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, f.Name))
+		if err != nil {
+			t.Errorf("%v: %v", f.Name, err)
+			continue
+		}
+		if !bytes.Equal(existing, f.Source) {
+			t.Errorf("%v is out of date; run go generate ./pkg/ipmi", f.Name)
+		}
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *Spec
+	}{
+		{
+			"duplicate layer type",
+			&Spec{
+				Package: "ipmi",
+				Commands: []*Command{
+					{
+						Name: "A", Display: "A", Function: "App",
+						Command:  "0x01",
+						Response: &Layer{LayerType: 1},
+					},
+					{
+						Name: "B", Display: "B", Function: "App",
+						Command:  "0x02",
+						Response: &Layer{LayerType: 1},
+					},
+				},
+			},
+		},
+		{
+			"named type without wire encoding",
+			&Spec{
+				Package: "ipmi",
+				Commands: []*Command{
+					{
+						Name: "A", Display: "A", Function: "App",
+						Command: "0x01",
+						Response: &Layer{
+							LayerType: 1,
+							Fields: []*Field{
+								{Name: "Channel", Type: "Channel"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"bits overflowing byte",
+			&Spec{
+				Package: "ipmi",
+				Commands: []*Command{
+					{
+						Name: "A", Display: "A", Function: "App",
+						Command: "0x01",
+						Request: &Layer{
+							LayerType: 1,
+							Fields: []*Field{
+								{Name: "X", Type: "uint8", Wire: "bits", Bit: 6, Width: 3},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		if err := test.spec.validate(); err == nil {
+			t.Errorf("%v: validate() returned nil error", test.name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+// Ipmigen generates IPMI command layers, command structs and their tests from
+// a declarative table, so adding a simple command does not require writing
+// hundreds of lines of boilerplate by hand. It is run via go:generate in
+// pkg/ipmi. Commands whose wire format cannot be described as fields at fixed
+// offsets, e.g. those with variable-length data, are still written by hand;
+// generated layers can be given further methods in separate files.
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	argSpec = kingpin.Arg("spec", "Path of the JSON command table.").
+		Required().
+		String()
+	flgOut = kingpin.Flag("out", "Directory to write generated files to. "+
+		"Defaults to the directory containing the spec.").
+		String()
+	flgCheck = kingpin.Flag("check", "Exit non-zero if any generated file "+
+		"is missing or out of date, rather than writing it.").
+		Bool()
+)
+
+func main() {
+	kingpin.Parse()
+
+	spec, err := ReadSpec(*argSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := Generate(spec, filepath.Base(*argSpec))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := *flgOut
+	if out == "" {
+		out = filepath.Dir(*argSpec)
+	}
+	stale := false
+	for _, f := range files {
+		path := filepath.Join(out, f.Name)
+		if *flgCheck {
+			if existing, err := os.ReadFile(path); err != nil ||
+				!bytes.Equal(existing, f.Source) {
+				log.Printf("%v is out of date", path)
+				stale = true
+			}
+			continue
+		}
+		if err := os.WriteFile(path, f.Source, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if stale {
+		os.Exit(1)
+	}
+}
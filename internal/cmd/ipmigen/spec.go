@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Spec is the root of a command table file.
+type Spec struct {
+
+	// Package is the name of the package to generate code in.
+	Package string `json:"package"`
+
+	Commands []*Command `json:"commands"`
+}
+
+// Command describes a single IPMI command, with optional request and response
+// bodies. A body that is omitted is empty.
+type Command struct {
+
+	// Name is the Go identifier prefix, e.g. GetSELInfo.
+	Name string `json:"name"`
+
+	// Display is the human-readable name, e.g. "Get SEL Info".
+	Display string `json:"display"`
+
+	// Function is the network function without the Req/Rsp suffix, e.g.
+	// Storage.
+	Function string `json:"function"`
+
+	// Command is the command number, e.g. "0x40".
+	Command string `json:"command"`
+
+	// Doc is the doc comment of the response layer, or request layer if there
+	// is no response, without the leading type name.
+	Doc string `json:"doc"`
+
+	Request  *Layer `json:"request"`
+	Response *Layer `json:"response"`
+
+	// File is the generated file name, without extension. Defaults to the
+	// snake case of Name.
+	File string `json:"file"`
+}
+
+// Layer describes a request or response body.
+type Layer struct {
+
+	// LayerType is the gopacket layer type number to register. These must be
+	// unique within the package, and never reused.
+	LayerType int `json:"layerType"`
+
+	Fields []*Field `json:"fields"`
+
+	// Tests are examples of the wire format and the fields it corresponds to.
+	// The generated tests check requests serialise to, and responses decode
+	// from, the data.
+	Tests []*Example `json:"tests"`
+}
+
+// Field is a value at a fixed offset in a layer.
+type Field struct {
+	Name string `json:"name"`
+
+	// Type is the Go type of the field, e.g. uint16, bool, [16]byte or
+	// Channel.
+	Type string `json:"type"`
+
+	// Wire is how the field is encoded: uint8, uint16 or uint32 (all
+	// little-endian), bool, bits or bytes. It defaults to Type for the
+	// built-in types, and must be specified for named types.
+	Wire string `json:"wire"`
+
+	// Offset is the index of the field's first byte in the layer, excluding
+	// the completion code.
+	Offset int `json:"offset"`
+
+	// Bit is the least significant bit of a bool or bits field.
+	Bit int `json:"bit"`
+
+	// Width is the number of bits in a bits field.
+	Width int `json:"width"`
+
+	// Doc is the field's comment, if any.
+	Doc string `json:"doc"`
+}
+
+// Example is a test case for a layer.
+type Example struct {
+
+	// Data is the hex-encoded body, with optional spaces between bytes.
+	Data string `json:"data"`
+
+	// Want maps field names to Go expressions of their expected values.
+	// Omitted fields are expected to be their zero value.
+	Want map[string]string `json:"want"`
+}
+
+var (
+	identifier = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	byteArray  = regexp.MustCompile(`^\[(\d+)\]byte$`)
+)
+
+// ReadSpec parses and validates a command table.
+func ReadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return spec, nil
+}
+
+func (s *Spec) validate() error {
+	if s.Package == "" {
+		return fmt.Errorf("missing package")
+	}
+	layerTypes := map[int]string{}
+	for _, c := range s.Commands {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("command %v: %w", c.Name, err)
+		}
+		for _, l := range []*Layer{c.Request, c.Response} {
+			if l == nil {
+				continue
+			}
+			if other, ok := layerTypes[l.LayerType]; ok {
+				return fmt.Errorf("command %v: layer type %v already used by %v",
+					c.Name, l.LayerType, other)
+			}
+			layerTypes[l.LayerType] = c.Name
+		}
+	}
+	return nil
+}
+
+func (c *Command) validate() error {
+	if !identifier.MatchString(c.Name) {
+		return fmt.Errorf("name must be an exported identifier")
+	}
+	if c.Display == "" || c.Function == "" {
+		return fmt.Errorf("missing display name or network function")
+	}
+	if _, err := strconv.ParseUint(c.Command, 0, 8); err != nil {
+		return fmt.Errorf("invalid command number %q", c.Command)
+	}
+	if c.File == "" {
+		c.File = snakeCase(c.Name)
+	}
+	for _, l := range []*Layer{c.Request, c.Response} {
+		if l == nil {
+			continue
+		}
+		if l.LayerType == 0 {
+			return fmt.Errorf("missing layer type")
+		}
+		if err := l.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Layer) validate() error {
+	names := map[string]bool{}
+	for _, f := range l.Fields {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("field %v: %w", f.Name, err)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("duplicate field %v", f.Name)
+		}
+		names[f.Name] = true
+	}
+	for i, e := range l.Tests {
+		data, err := e.bytes()
+		if err != nil {
+			return fmt.Errorf("test %v: %w", i, err)
+		}
+		if len(data) < l.Length() {
+			return fmt.Errorf("test %v: data is %v bytes, layer is %v", i,
+				len(data), l.Length())
+		}
+		for name := range e.Want {
+			if !names[name] {
+				return fmt.Errorf("test %v: unknown field %v", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Field) validate() error {
+	if !identifier.MatchString(f.Name) {
+		return fmt.Errorf("name must be an exported identifier")
+	}
+	if f.Wire == "" {
+		switch {
+		case f.Type == "uint8" || f.Type == "byte":
+			f.Wire = "uint8"
+		case f.Type == "uint16" || f.Type == "uint32" || f.Type == "bool":
+			f.Wire = f.Type
+		case byteArray.MatchString(f.Type):
+			f.Wire = "bytes"
+		default:
+			return fmt.Errorf("wire encoding required for type %v", f.Type)
+		}
+	}
+	switch f.Wire {
+	case "uint8", "uint16", "uint32":
+	case "bool":
+		f.Width = 1
+	case "bits":
+		if f.Width < 1 || f.Bit+f.Width > 8 {
+			return fmt.Errorf("bits must fit within a byte")
+		}
+	case "bytes":
+		if !byteArray.MatchString(f.Type) {
+			return fmt.Errorf("bytes fields must be of type [N]byte")
+		}
+	default:
+		return fmt.Errorf("unknown wire encoding %v", f.Wire)
+	}
+	if f.Bit < 0 || f.Bit > 7 {
+		return fmt.Errorf("bit must be between 0 and 7")
+	}
+	return nil
+}
+
+// Size returns the number of bytes the field spans.
+func (f *Field) Size() int {
+	switch f.Wire {
+	case "uint16":
+		return 2
+	case "uint32":
+		return 4
+	case "bytes":
+		n, _ := strconv.Atoi(byteArray.FindStringSubmatch(f.Type)[1])
+		return n
+	default:
+		return 1
+	}
+}
+
+// Length returns the number of bytes in the layer.
+func (l *Layer) Length() int {
+	length := 0
+	for _, f := range l.Fields {
+		if end := f.Offset + f.Size(); end > length {
+			length = end
+		}
+	}
+	return length
+}
+
+func (e *Example) bytes() ([]byte, error) {
+	data := []byte{}
+	for _, s := range strings.Fields(e.Data) {
+		for len(s) > 0 {
+			if len(s) < 2 {
+				return nil, fmt.Errorf("odd number of hex digits")
+			}
+			b, err := strconv.ParseUint(s[:2], 16, 8)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, byte(b))
+			s = s[2:]
+		}
+	}
+	return data, nil
+}
+
+// snakeCase converts an identifier to a file name, treating runs of capitals
+// as acronyms, e.g. GetSELInfo becomes get_sel_info.
+func snakeCase(name string) string {
+	b := strings.Builder{}
+	for i, r := range name {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevUpper := name[i-1] >= 'A' && name[i-1] <= 'Z'
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+			if !prevUpper || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteString(strings.ToLower(string(r)))
+	}
+	return b.String()
+}
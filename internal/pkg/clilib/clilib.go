@@ -0,0 +1,173 @@
+// Package clilib contains the flags and connection handling shared by the
+// command-line tools in cmd/, so they behave consistently. A tool registers
+// the flags with Register(), then calls Connect() with the address of the BMC
+// once kingpin has parsed the command line.
+package clilib
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var (
+	privilegeLevels = map[string]ipmi.PrivilegeLevel{
+		"user":          ipmi.PrivilegeLevelUser,
+		"operator":      ipmi.PrivilegeLevelOperator,
+		"administrator": ipmi.PrivilegeLevelAdministrator,
+	}
+)
+
+// Flags contains the values of the shared flags once parsed.
+type Flags struct {
+	Username string
+	Password string
+
+	// Timeout is the maximum time to allow for connecting and for each
+	// operation. What constitutes an operation depends on the tool.
+	Timeout time.Duration
+
+	// CipherSuite is the ID of the cipher suite to propose, or "auto" to
+	// propose all secure algorithms and let the BMC choose.
+	CipherSuite string
+
+	// Privilege is the maximum privilege level of the session.
+	Privilege string
+
+	// Insecure allows cipher suites without integrity or confidentiality, and
+	// those using MD5.
+	Insecure bool
+}
+
+// Register adds the shared connection flags to app. privilege is the lowest
+// privilege level needed by every command the tool can send, which is used as
+// the default for --privilege. timeout is the default for --timeout.
+func Register(app *kingpin.Application, privilege ipmi.PrivilegeLevel, timeout time.Duration) *Flags {
+	suites := []string{"auto"}
+	for _, id := range ipmi.CipherSuiteIDs() {
+		suites = append(suites, strconv.Itoa(int(id)))
+	}
+	privileges := []string{"user", "operator", "administrator"}
+	defaultPrivilege := ""
+	for name, level := range privilegeLevels {
+		if level == privilege {
+			defaultPrivilege = name
+		}
+	}
+
+	f := &Flags{}
+	app.Flag("username", "The username to connect as. Empty for the null user.").
+		StringVar(&f.Username)
+	app.Flag("password", "The password of the user to connect as.").
+		StringVar(&f.Password)
+	app.Flag("timeout", "The maximum time to allow for connecting, and each operation.").
+		Default(timeout.String()).
+		DurationVar(&f.Timeout)
+	app.Flag("cipher-suite", "The RMCP+ cipher suite ID to use, or auto.").
+		Default("auto").
+		EnumVar(&f.CipherSuite, suites...)
+	app.Flag("privilege", "The maximum privilege level of the session (user/operator/administrator).").
+		Default(defaultPrivilege).
+		EnumVar(&f.Privilege, privileges...)
+	app.Flag("insecure", "Allow cipher suites without integrity or confidentiality, or using MD5.").
+		BoolVar(&f.Insecure)
+	return f
+}
+
+// Output adds an --output flag to app, allowing the user to choose between
+// formats. The first format is the default.
+func Output(app *kingpin.Application, formats ...string) *string {
+	return app.Flag("output", fmt.Sprintf("The output format (%v).",
+		strings.Join(formats, "/"))).
+		Default(formats[0]).
+		Enum(formats...)
+}
+
+// Dial connects to the BMC at addr, which is of the form IP[:port].
+func (f *Flags) Dial(ctx context.Context, addr string) (*bmc.V2SessionlessTransport, error) {
+	return bmc.DialV2Context(ctx, addr, &bmc.DialOpts{
+		Timeout: f.Timeout,
+	})
+}
+
+// SessionOpts returns the session options corresponding to the flags. It
+// fails if the cipher suite is not secure, and --insecure was not passed.
+func (f *Flags) SessionOpts() (*bmc.V2SessionOpts, error) {
+	opts := &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			Username:          f.Username,
+			Password:          []byte(f.Password),
+			MaxPrivilegeLevel: privilegeLevels[f.Privilege],
+		},
+		AllowLegacyAlgorithms: f.Insecure,
+	}
+	if f.CipherSuite == "auto" {
+		return opts, nil
+	}
+	id, err := strconv.ParseUint(f.CipherSuite, 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	suite, ok := ipmi.LookupCipherSuite(uint8(id))
+	if !ok {
+		return nil, fmt.Errorf("unsupported cipher suite %v", id)
+	}
+	if !suite.IsSecure() && !f.Insecure {
+		return nil, fmt.Errorf("cipher suite %v lacks integrity or "+
+			"confidentiality, or uses MD5; pass --insecure to use it", id)
+	}
+	opts.AuthenticationAlgorithms = []ipmi.AuthenticationAlgorithm{
+		suite.Authentication,
+	}
+	opts.IntegrityAlgorithms = []ipmi.IntegrityAlgorithm{suite.Integrity}
+	opts.ConfidentialityAlgorithms = []ipmi.ConfidentialityAlgorithm{
+		suite.Confidentiality,
+	}
+	return opts, nil
+}
+
+// NewSession establishes a session with the BMC as configured by the flags.
+func (f *Flags) NewSession(ctx context.Context, machine *bmc.V2SessionlessTransport) (*bmc.V2Session, error) {
+	opts, err := f.SessionOpts()
+	if err != nil {
+		return nil, err
+	}
+	return machine.NewV2Session(ctx, opts)
+}
+
+// Connect dials the BMC at addr and establishes a session, bounded by the
+// context and --timeout. The returned function closes the session, then the
+// connection, and must be called once the session is no longer needed. It
+// has its own timeout, so can be called after the context is cancelled.
+func (f *Flags) Connect(ctx context.Context, addr string) (*bmc.V2Session, func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+
+	machine, err := f.Dial(ctx, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("connected to %v over IPMI v%v", machine.Address(), machine.Version())
+
+	sess, err := f.NewSession(ctx, machine)
+	if err != nil {
+		machine.Close()
+		return nil, nil, err
+	}
+	return sess, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), f.Timeout)
+		defer cancel()
+		if err := sess.Close(ctx); err != nil {
+			log.Printf("failed to close session: %v", err)
+		}
+		machine.Close()
+	}, nil
+}
@@ -0,0 +1,112 @@
+package clilib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+)
+
+func parse(t *testing.T, args ...string) *Flags {
+	t.Helper()
+	app := kingpin.New("test", "")
+	flags := Register(app, ipmi.PrivilegeLevelOperator, time.Second*5)
+	if _, err := app.Parse(args); err != nil {
+		t.Fatalf("Parse(%v) = %v", args, err)
+	}
+	return flags
+}
+
+func TestRegisterDefaults(t *testing.T) {
+	flags := parse(t)
+	if flags.Timeout != time.Second*5 {
+		t.Errorf("timeout = %v, want %v", flags.Timeout, time.Second*5)
+	}
+	if flags.CipherSuite != "auto" {
+		t.Errorf("cipher suite = %v, want auto", flags.CipherSuite)
+	}
+	opts, err := flags.SessionOpts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.MaxPrivilegeLevel != ipmi.PrivilegeLevelOperator {
+		t.Errorf("max privilege level = %v, want %v",
+			opts.MaxPrivilegeLevel, ipmi.PrivilegeLevelOperator)
+	}
+	if opts.AllowLegacyAlgorithms {
+		t.Errorf("legacy algorithms allowed without --insecure")
+	}
+	if len(opts.AuthenticationAlgorithms) != 0 {
+		t.Errorf("auto proposed authentication algorithms %v, want defaults",
+			opts.AuthenticationAlgorithms)
+	}
+}
+
+func TestSessionOpts(t *testing.T) {
+	tests := []struct {
+		args []string
+		want ipmi.AuthenticationAlgorithm
+		err  bool
+	}{
+		{
+			args: []string{"--cipher-suite=17"},
+			want: ipmi.AuthenticationAlgorithmHMACSHA256,
+		},
+		{
+			args: []string{"--cipher-suite=3"},
+			want: ipmi.AuthenticationAlgorithmHMACSHA1,
+		},
+		{
+			args: []string{"--cipher-suite=1"},
+			err:  true,
+		},
+		{
+			args: []string{"--cipher-suite=1", "--insecure"},
+			want: ipmi.AuthenticationAlgorithmHMACSHA1,
+		},
+		{
+			args: []string{"--cipher-suite=8"},
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		opts, err := parse(t, test.args...).SessionOpts()
+		switch {
+		case test.err && err == nil:
+			t.Errorf("%v: SessionOpts() succeeded, want error", test.args)
+		case !test.err && err != nil:
+			t.Errorf("%v: SessionOpts() = %v, want success", test.args, err)
+		case !test.err && (len(opts.AuthenticationAlgorithms) != 1 ||
+			opts.AuthenticationAlgorithms[0] != test.want):
+			t.Errorf("%v: authentication algorithms = %v, want [%v]",
+				test.args, opts.AuthenticationAlgorithms, test.want)
+		}
+	}
+}
+
+func TestPrivilege(t *testing.T) {
+	opts, err := parse(t, "--privilege=administrator").SessionOpts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.MaxPrivilegeLevel != ipmi.PrivilegeLevelAdministrator {
+		t.Errorf("max privilege level = %v, want %v",
+			opts.MaxPrivilegeLevel, ipmi.PrivilegeLevelAdministrator)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	app := kingpin.New("test", "")
+	output := Output(app, "text", "json")
+	if _, err := app.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *output != "text" {
+		t.Errorf("output = %v, want text", *output)
+	}
+	if _, err := app.Parse([]string{"--output=yaml"}); err == nil {
+		t.Errorf("unsupported output format accepted")
+	}
+}
@@ -0,0 +1,88 @@
+// Package sequencer allocates and matches the requester sequence numbers
+// (rqSeq) of IPMI messages, specified in section 5.8 of IPMI v2.0. These are
+// distinct from session sequence numbers: they identify a request at the
+// message level, so a response can be paired with it regardless of the
+// medium, and a responder can recognise a retried request. Each requester
+// using an independent address or channel, e.g. a session or a bridge
+// forwarding requests onto IPMB, needs its own Sequencer.
+package sequencer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// Max is the largest sequence number, as they are 6 bits on the wire.
+	Max = 0x3f
+)
+
+var (
+	// ErrMismatch is wrapped by errors returned from Match() when a response
+	// does not correspond to the request, e.g. because it is a late response
+	// to an earlier request.
+	ErrMismatch = errors.New("response does not match request")
+)
+
+// Sequencer allocates sequence numbers for requests. The zero value is ready
+// to use, and first allocates 1. It is safe for concurrent use.
+type Sequencer struct {
+	mu   sync.Mutex
+	last uint8
+}
+
+// Next returns the sequence number to use for a new request, wrapping to 0
+// after Max. Retries of a request should reuse its sequence number, so the
+// responder can identify them as such.
+func (s *Sequencer) Next() uint8 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = (s.last + 1) & Max
+	return s.last
+}
+
+// Match returns an error wrapping ErrMismatch if rsp is not a response to req:
+// the network function must be the response counterpart of the request's,
+// and the command, body code, enterprise number and sequence number must be
+// the same. Addresses and LUNs are not compared, as they are not needed to
+// pair messages on a point-to-point session.
+func Match(req, rsp *ipmi.Message) error {
+	switch {
+	case rsp.Function != req.Function+1:
+		return fmt.Errorf("%w: got network function %v, expected %v",
+			ErrMismatch, rsp.Function, req.Function+1)
+	case rsp.Command != req.Command ||
+		rsp.Body != req.Body ||
+		rsp.Enterprise != req.Enterprise:
+		return fmt.Errorf("%w: got command %v, expected %v", ErrMismatch,
+			&rsp.Operation, &req.Operation)
+	case rsp.Sequence != req.Sequence:
+		return fmt.Errorf("%w: got sequence number %v, expected %v",
+			ErrMismatch, rsp.Sequence, req.Sequence)
+	}
+	return nil
+}
+
+// Response returns the message header of a response to req with the provided
+// completion code: the network function is converted to a response, the
+// addresses and LUNs swapped, and the sequence number echoed, so Match()
+// accepts it.
+func Response(req *ipmi.Message, code ipmi.CompletionCode) ipmi.Message {
+	return ipmi.Message{
+		Operation: ipmi.Operation{
+			Function:   req.Function + 1,
+			Body:       req.Body,
+			Enterprise: req.Enterprise,
+			Command:    req.Command,
+		},
+		RemoteAddress:  req.LocalAddress,
+		RemoteLUN:      req.LocalLUN,
+		LocalAddress:   req.RemoteAddress,
+		LocalLUN:       req.RemoteLUN,
+		Sequence:       req.Sequence,
+		CompletionCode: code,
+	}
+}
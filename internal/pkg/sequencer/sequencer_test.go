@@ -0,0 +1,146 @@
+package sequencer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestSequencerNext(t *testing.T) {
+	s := Sequencer{}
+	for i := 1; i <= Max; i++ {
+		if got := s.Next(); got != uint8(i) {
+			t.Fatalf("Next() = %v, want %v", got, i)
+		}
+	}
+	if got := s.Next(); got != 0 {
+		t.Errorf("Next() after Max = %v, want 0", got)
+	}
+	if got := s.Next(); got != 1 {
+		t.Errorf("Next() after wrapping = %v, want 1", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	req := &ipmi.Message{
+		Operation:     ipmi.OperationGetDeviceIDReq,
+		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
+		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+		Sequence:      5,
+	}
+	oemReq := &ipmi.Message{
+		Operation: ipmi.Operation{
+			Function:   ipmi.NetworkFunctionOEMReq,
+			Enterprise: iana.EnterpriseDell,
+			Command:    0x01,
+		},
+		Sequence: 5,
+	}
+	tests := []struct {
+		name string
+		req  *ipmi.Message
+		rsp  ipmi.Message
+		want bool
+	}{
+		{
+			name: "response",
+			req:  req,
+			rsp:  Response(req, ipmi.CompletionCodeNormal),
+			want: true,
+		},
+		{
+			name: "non-normal code",
+			req:  req,
+			rsp:  Response(req, ipmi.CompletionCodeNodeBusy),
+			want: true,
+		},
+		{
+			name: "addresses ignored",
+			req:  req,
+			rsp: ipmi.Message{
+				Operation: ipmi.OperationGetDeviceIDRsp,
+				Sequence:  5,
+			},
+			want: true,
+		},
+		{
+			name: "request",
+			req:  req,
+			rsp:  *req,
+		},
+		{
+			name: "other command",
+			req:  req,
+			rsp: ipmi.Message{
+				Operation: ipmi.OperationGetSystemGUIDRsp,
+				Sequence:  5,
+			},
+		},
+		{
+			name: "other sequence",
+			req:  req,
+			rsp: ipmi.Message{
+				Operation: ipmi.OperationGetDeviceIDRsp,
+				Sequence:  4,
+			},
+		},
+		{
+			name: "OEM response",
+			req:  oemReq,
+			rsp:  Response(oemReq, ipmi.CompletionCodeNormal),
+			want: true,
+		},
+		{
+			name: "other enterprise",
+			req:  oemReq,
+			rsp: ipmi.Message{
+				Operation: ipmi.Operation{
+					Function:   ipmi.NetworkFunctionOEMRsp,
+					Enterprise: iana.EnterpriseIntel,
+					Command:    0x01,
+				},
+				Sequence: 5,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Match(test.req, &test.rsp)
+			if test.want && err != nil {
+				t.Errorf("Match() = %v, want nil", err)
+			}
+			if !test.want && !errors.Is(err, ErrMismatch) {
+				t.Errorf("Match() = %v, want ErrMismatch", err)
+			}
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	req := &ipmi.Message{
+		Operation:     ipmi.OperationGetDeviceIDReq,
+		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
+		RemoteLUN:     ipmi.LUNBMC,
+		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+		LocalLUN:      2,
+		Sequence:      0x3f,
+	}
+	rsp := Response(req, ipmi.CompletionCodeTimeout)
+	if rsp.Operation != ipmi.OperationGetDeviceIDRsp {
+		t.Errorf("Operation = %v, want %v", rsp.Operation,
+			ipmi.OperationGetDeviceIDRsp)
+	}
+	if rsp.RemoteAddress != req.LocalAddress || rsp.RemoteLUN != req.LocalLUN ||
+		rsp.LocalAddress != req.RemoteAddress || rsp.LocalLUN != req.RemoteLUN {
+		t.Errorf("addresses not swapped: got %+v", rsp)
+	}
+	if rsp.Sequence != req.Sequence {
+		t.Errorf("Sequence = %v, want %v", rsp.Sequence, req.Sequence)
+	}
+	if rsp.CompletionCode != ipmi.CompletionCodeTimeout {
+		t.Errorf("CompletionCode = %v, want %v", rsp.CompletionCode,
+			ipmi.CompletionCodeTimeout)
+	}
+}
@@ -0,0 +1,525 @@
+package sim
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// command executes an IPMI command, returning the completion code and response
+// data. s is nil for session-less commands.
+func (b *BMC) command(s *session, m *ipmi.Message) (ipmi.CompletionCode, []byte) {
+	req := m.LayerPayload()
+	switch m.Operation {
+	case ipmi.OperationGetChannelAuthenticationCapabilitiesReq:
+		// channel 1, extended capabilities, non-null usernames, IPMI v2.0
+		return ipmi.CompletionCodeNormal, []byte{0x01, 0x80, 0x04, 0x02, 0x00,
+			0x00, 0x00, 0x00}
+	case ipmi.OperationGetSystemGUIDReq:
+		return ipmi.CompletionCodeNormal, b.config.GUID[:]
+	}
+
+	if s == nil {
+		// everything else requires a session
+		return ipmi.CompletionCodeInsufficientPrivileges, nil
+	}
+	if m.Function == ipmi.NetworkFunctionGroupReq && m.Body == ipmi.BodyCodeDCMI {
+		return b.dcmiCommand(m.Command, req)
+	}
+	switch m.Operation {
+	case ipmi.OperationCloseSessionReq:
+		if len(req) < 4 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if id := binary.LittleEndian.Uint32(req[0:4]); id != s.id {
+			return ipmi.CompletionCodeInvalidSessionID, nil
+		}
+		delete(b.sessions, s.id)
+		atomic.AddInt32(&b.sessionsClosed, 1)
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetSessionInfoReq:
+		return b.getSessionInfo(s, req)
+	case ipmi.OperationGetSensorReadingReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		reading, ok := b.config.Readings[req[0]]
+		if !ok {
+			return ipmi.CompletionCodeNotPresent, nil
+		}
+		// event messages and scanning enabled
+		return ipmi.CompletionCodeNormal, []byte{reading, 0xc0,
+			b.states[req[0]]}
+	case ipmi.OperationGetSensorReadingFactorsReq:
+		if len(req) < 2 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		factors, ok := b.config.ReadingFactors[req[0]]
+		if !ok {
+			return ipmi.CompletionCodeNotPresent, nil
+		}
+		return ipmi.CompletionCodeNormal, factors(req[1])
+	case ipmi.OperationRearmSensorEventsReq:
+		if len(req) < 2 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		delete(b.states, req[0])
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetSDRRepositoryInfoReq:
+		rsp := make([]byte, 14)
+		rsp[0] = 0x51
+		binary.LittleEndian.PutUint16(rsp[1:3], uint16(len(b.config.SDRs)))
+		binary.LittleEndian.PutUint16(rsp[3:5], 0xffff)
+		// last addition and erase timestamps are left as 0
+		rsp[13] = 1 << 1 // reserve supported
+		return ipmi.CompletionCodeNormal, rsp
+	case ipmi.OperationReserveSDRRepositoryReq:
+		return ipmi.CompletionCodeNormal, []byte{0x01, 0x00}
+	case ipmi.OperationGetSDRReq:
+		return getRecord(b.config.SDRs, req)
+	case ipmi.OperationGetSELEntryReq:
+		b.selMu.Lock()
+		defer b.selMu.Unlock()
+		return getRecord(b.sel, req)
+	case ipmi.OperationReserveSELReq:
+		return ipmi.CompletionCodeNormal, []byte{0x01, 0x00}
+	case ipmi.OperationClearSELReq:
+		// reservation ID (2), "CLR", action
+		if len(req) < 6 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if string(req[2:5]) != "CLR" {
+			return ipmi.CompletionCodeInvalidDataField, nil
+		}
+		b.selMu.Lock()
+		defer b.selMu.Unlock()
+		switch ipmi.ClearSELAction(req[5]) {
+		case ipmi.ClearSELActionInitiateErase:
+			b.sel = nil
+		case ipmi.ClearSELActionGetErasureStatus:
+		default:
+			return ipmi.CompletionCodeInvalidDataField, nil
+		}
+		// erasure is instantaneous
+		return ipmi.CompletionCodeNormal,
+			[]byte{uint8(ipmi.ErasureProgressCompleted)}
+	case ipmi.OperationGetChassisCapabilitiesReq:
+		return ipmi.CompletionCodeNormal,
+			append([]byte(nil), b.chassisCapabilities...)
+	case ipmi.OperationSetChassisCapabilitiesReq:
+		// flags and four device addresses, then an optional bridge device
+		// address
+		if len(req) < 5 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		// only the front panel lockout and intrusion sensor flags can be set
+		b.chassisCapabilities[0] = b.chassisCapabilities[0]&^0x3 | req[0]&0x3
+		copy(b.chassisCapabilities[1:], req[1:])
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetChassisStatusReq:
+		// no faults
+		rsp := []byte{uint8(b.powerRestorePolicy) << 5, 0x00, 0x00}
+		if b.poweredOn {
+			rsp[0] |= 1
+		}
+		if b.config.Intrusion {
+			rsp[2] |= 1
+		}
+		if b.config.FrontPanelDisableAllowed != 0 {
+			rsp = append(rsp, b.config.FrontPanelDisableAllowed<<4|
+				b.frontPanelDisabled)
+		}
+		return ipmi.CompletionCodeNormal, rsp
+	case ipmi.OperationSetFrontPanelEnablesReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		disable := req[0] & 0xf
+		if disable&^b.config.FrontPanelDisableAllowed != 0 {
+			return ipmi.CompletionCodeInvalidDataField, nil
+		}
+		if !b.config.IgnoreFrontPanelEnables {
+			b.frontPanelDisabled = disable
+		}
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationChassisControlReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		return b.chassisControl(ipmi.ChassisControl(req[0] & 0xf))
+	case ipmi.OperationChassisIdentifyReq:
+		// there is nothing to blink
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationSetSystemBootOptionsReq:
+		// only boot flags are supported
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if ipmi.BootOptionParameter(req[0]&0x7f) != ipmi.BootOptionParameterBootFlags {
+			return ipmi.CompletionCodeNotPresent, nil
+		}
+		if len(req) < 1+len(b.bootFlags) {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		copy(b.bootFlags[:], req[1:])
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetSystemBootOptionsReq:
+		if len(req) < 3 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if ipmi.BootOptionParameter(req[0]&0x7f) != ipmi.BootOptionParameterBootFlags {
+			return ipmi.CompletionCodeNotPresent, nil
+		}
+		return ipmi.CompletionCodeNormal, append([]byte{0x01,
+			uint8(ipmi.BootOptionParameterBootFlags)}, b.bootFlags[:]...)
+	case ipmi.OperationSetWatchdogTimerReq:
+		if len(req) < 6 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if req[0]&(1<<6) == 0 {
+			b.watchdogRunning = false
+		}
+		b.watchdog = append([]byte(nil), req[:6]...)
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationResetWatchdogTimerReq:
+		if b.watchdog == nil {
+			return ipmi.CompletionCodeWatchdogNotInitialised, nil
+		}
+		b.watchdogRunning = true
+		b.watchdogReset = time.Now()
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetWatchdogTimerReq:
+		return ipmi.CompletionCodeNormal, b.getWatchdogTimer()
+	case ipmi.OperationGetDeviceIDReq:
+		return ipmi.CompletionCodeNormal, b.getDeviceID()
+	case ipmi.OperationGetSystemInfoParametersReq,
+		ipmi.OperationSetSystemInfoParametersReq:
+		return b.systemInfoParameter(
+			m.Operation == ipmi.OperationSetSystemInfoParametersReq, req)
+	case ipmi.OperationGetFRUInventoryAreaInfoReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if req[0] != 0 || b.config.FRU == nil {
+			return ipmi.CompletionCodeNotPresent, nil
+		}
+		rsp := make([]byte, 3) // byte access
+		binary.LittleEndian.PutUint16(rsp[0:2], uint16(len(b.config.FRU)))
+		return ipmi.CompletionCodeNormal, rsp
+	case ipmi.OperationReadFRUDataReq:
+		return b.readFRUData(req)
+	case ipmi.OperationGetLANConfigurationParametersReq,
+		ipmi.OperationSetLANConfigurationParametersReq:
+		return channelParameter(b.lanParameters, writableLANParameters,
+			m.Operation == ipmi.OperationSetLANConfigurationParametersReq, req)
+	case ipmi.OperationSuspendBMCARPsReq:
+		if len(req) < 2 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if channel := ipmi.Channel(req[0] & 0xf); channel != 1 &&
+			channel != ipmi.ChannelPresentInterface {
+			return ipmi.CompletionCodeParameterOutOfRange, nil
+		}
+		b.arpsSuspended = req[1] & 0x3
+		enabled := b.lanParameters[uint8(ipmi.LANConfigurationParameterBMCGeneratedARPControl)][0]
+		return ipmi.CompletionCodeNormal, []byte{enabled &^ b.arpsSuspended}
+	case ipmi.OperationGetIPUDPRMCPStatisticsReq:
+		if len(req) < 2 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if channel := ipmi.Channel(req[0] & 0xf); channel != 1 &&
+			channel != ipmi.ChannelPresentInterface {
+			return ipmi.CompletionCodeParameterOutOfRange, nil
+		}
+		data := make([]byte, 18)
+		for i, counter := range []uint16{b.udpPackets, 0, 0, 0,
+			b.transmittedPackets, b.udpPackets, b.rmcpPackets, 0, 0} {
+			binary.LittleEndian.PutUint16(data[i*2:], counter)
+		}
+		if req[1]&1 != 0 {
+			b.udpPackets, b.rmcpPackets, b.transmittedPackets = 0, 0, 0
+		}
+		return ipmi.CompletionCodeNormal, data
+	case ipmi.OperationGetSOLConfigurationParametersReq,
+		ipmi.OperationSetSOLConfigurationParametersReq:
+		return channelParameter(b.solParameters, writableSOLParameters,
+			m.Operation == ipmi.OperationSetSOLConfigurationParametersReq, req)
+	case ipmi.OperationGetPEFConfigurationParametersReq:
+		// parameter, set selector, block selector
+		if len(req) < 3 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		return getParameter(b.pefParameters, req[0]&0x7f)
+	case ipmi.OperationSetPEFConfigurationParametersReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		return setParameter(b.pefParameters, writablePEFParameters,
+			req[0]&0x7f, req[1:])
+	case ipmi.OperationSetPowerRestorePolicyReq:
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if policy := ipmi.PowerRestorePolicy(req[0] & 0x7); policy <
+			ipmi.PowerRestorePolicyUnknown {
+			b.powerRestorePolicy = policy
+		}
+		// all policies are supported
+		return ipmi.CompletionCodeNormal, []byte{0x07}
+	case ipmi.OperationGetUserNameReq, ipmi.OperationSetUserNameReq,
+		ipmi.OperationGetUserAccessReq, ipmi.OperationSetUserAccessReq,
+		ipmi.OperationSetUserPasswordReq:
+		return b.userCommand(m.Operation, req)
+	default:
+		return ipmi.CompletionCodeUnrecognisedCommand, nil
+	}
+}
+
+// getRecord returns a record from the SDR Repository or SEL, preceded by the
+// ID of the next record. Both use the same request format.
+func getRecord(records [][]byte, req []byte) (ipmi.CompletionCode, []byte) {
+	// reservation ID (2), record ID (2), offset, bytes to read
+	if len(req) < 6 {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	id := ipmi.RecordID(binary.LittleEndian.Uint16(req[2:4]))
+	offset, length := int(req[4]), int(req[5])
+
+	for i, record := range records {
+		recordID := ipmi.RecordID(binary.LittleEndian.Uint16(record[0:2]))
+		if id != ipmi.RecordIDFirst && id != recordID &&
+			!(id == ipmi.RecordIDLast && i == len(records)-1) {
+			continue
+		}
+		next := ipmi.RecordIDLast
+		if i+1 < len(records) {
+			next = ipmi.RecordID(binary.LittleEndian.Uint16(
+				records[i+1][0:2]))
+		}
+		if offset > len(record) {
+			return ipmi.CompletionCodeUnspecified, nil
+		}
+		end := len(record)
+		if length != 0xff && offset+length < end {
+			end = offset + length
+		}
+		rsp := make([]byte, 2, 2+end-offset)
+		binary.LittleEndian.PutUint16(rsp, uint16(next))
+		return ipmi.CompletionCodeNormal, append(rsp, record[offset:end]...)
+	}
+	return ipmi.CompletionCodeNotPresent, nil
+}
+
+// chassisControl changes the power state of the chassis. Resets and
+// interrupts are accepted, but have no effect.
+func (b *BMC) chassisControl(c ipmi.ChassisControl) (ipmi.CompletionCode, []byte) {
+	b.chassisControlsMu.Lock()
+	b.chassisControls = append(b.chassisControls, c)
+	b.chassisControlsMu.Unlock()
+
+	switch c {
+	case ipmi.ChassisControlPowerOff:
+		b.poweredOn = false
+	case ipmi.ChassisControlPowerOn:
+		if b.ignoredPowerOns > 0 {
+			b.ignoredPowerOns--
+			break
+		}
+		b.poweredOn = true
+	case ipmi.ChassisControlSoftPowerOff:
+		if !b.config.IgnoreSoftPowerOff {
+			b.poweredOn = false
+		}
+	case ipmi.ChassisControlPowerCycle, ipmi.ChassisControlHardReset,
+		ipmi.ChassisControlDiagnosticInterrupt:
+	default:
+		return ipmi.CompletionCodeUnspecified, nil
+	}
+	return ipmi.CompletionCodeNormal, nil
+}
+
+// getDeviceID returns a Get Device ID response for a BMC running firmware
+// 1.2 that conforms to IPMI v2.0.
+func (b *BMC) getDeviceID() []byte {
+	// device ID, device revision, firmware revision (2), IPMI version,
+	// additional device support, manufacturer ID (3), product ID (2)
+	rsp := []byte{0x20, 0x01, 0x01, 0x02, 0x02, 0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00}
+	if b.config.FRU != nil {
+		rsp[5] |= 1 << 3
+	}
+	rsp[6] = uint8(b.config.Manufacturer)
+	rsp[7] = uint8(b.config.Manufacturer >> 8)
+	rsp[8] = uint8(b.config.Manufacturer >> 16)
+	return rsp
+}
+
+// readFRUData returns a range of FRU device 0.
+func (b *BMC) readFRUData(req []byte) (ipmi.CompletionCode, []byte) {
+	// device ID, offset (2), count
+	if len(req) < 4 {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	if req[0] != 0 || b.config.FRU == nil {
+		return ipmi.CompletionCodeNotPresent, nil
+	}
+	offset, count := int(binary.LittleEndian.Uint16(req[1:3])), int(req[3])
+	if count > 16 {
+		return ipmi.CompletionCodeCannotReturnRequestedDataBytes, nil
+	}
+	if offset > len(b.config.FRU) {
+		return ipmi.CompletionCodeUnspecified, nil
+	}
+	end := offset + count
+	if end > len(b.config.FRU) {
+		end = len(b.config.FRU)
+	}
+	return ipmi.CompletionCodeNormal, append([]byte{uint8(end - offset)},
+		b.config.FRU[offset:end]...)
+}
+
+// FullSensorRecord builds a minimal Full Sensor Record for a linear
+// temperature sensor with the provided record ID, sensor number and name (at
+// most 16 characters), owned by the BMC. Readings convert to degrees C
+// one-to-one.
+func FullSensorRecord(id ipmi.RecordID, number uint8, name string) []byte {
+	if len(name) > 16 {
+		name = name[:16]
+	}
+	// header (5), fixed fields (43), ID string
+	record := make([]byte, 48+len(name))
+	binary.LittleEndian.PutUint16(record[0:2], uint16(id))
+	record[2] = 0x51 // SDR version 1.5
+	record[3] = uint8(ipmi.RecordTypeFullSensor)
+	record[4] = uint8(len(record) - 5)
+
+	body := record[5:]
+	body[0] = uint8(ipmi.SlaveAddressBMC.Address())
+	body[2] = number
+	body[7] = uint8(ipmi.SensorTypeTemperature)
+	body[8] = 0x01                           // threshold
+	body[16] = uint8(ipmi.SensorUnitCelsius) // base unit
+	body[19] = 1                             // M
+	body[42] = uint8(ipmi.StringEncoding8BitAsciiLatin1)<<6 | uint8(len(name))
+	copy(body[43:], name)
+	return record
+}
+
+// FRUDeviceLocatorRecord builds an SDR describing a logical FRU device with
+// the provided ID, accessed via the BMC.
+func FRUDeviceLocatorRecord(id ipmi.RecordID, deviceID uint8, name string) []byte {
+	if len(name) > 16 {
+		name = name[:16]
+	}
+	// header (5), fixed fields (11), ID string
+	record := make([]byte, 16+len(name))
+	binary.LittleEndian.PutUint16(record[0:2], uint16(id))
+	record[2] = 0x51 // SDR version 1.5
+	record[3] = uint8(ipmi.RecordTypeFRUDeviceLocator)
+	record[4] = uint8(len(record) - 5)
+
+	body := record[5:]
+	body[0] = uint8(ipmi.SlaveAddressBMC.Address())
+	body[1] = deviceID
+	body[2] = 1 << 7 // logical
+	body[5] = 0x10   // IPMI FRU Inventory
+	body[10] = uint8(ipmi.StringEncoding8BitAsciiLatin1)<<6 | uint8(len(name))
+	copy(body[11:], name)
+	return record
+}
+
+// SystemEventRecord builds a SEL system event record with the provided record
+// ID, generated by the BMC for an assertion of a sensor-specific state
+// offset.
+func SystemEventRecord(id ipmi.RecordID, sensorType ipmi.SensorType, number uint8, offset uint8) []byte {
+	record := make([]byte, 16)
+	binary.LittleEndian.PutUint16(record[0:2], uint16(id))
+	record[2] = ipmi.SELRecordTypeSystemEvent
+	binary.LittleEndian.PutUint32(record[3:7], 0xffffffff) // unspecified
+	record[7] = uint8(ipmi.SlaveAddressBMC.Address())
+	record[9] = 0x04 // IPMI v2.0
+	record[10] = uint8(sensorType)
+	record[11] = number
+	record[12] = uint8(ipmi.OutputTypeSensorSpecific)
+	record[13] = offset & 0xf
+	record[14] = 0xff
+	record[15] = 0xff
+	return record
+}
+
+// DiscreteSensorRecord builds a Full Sensor Record with the provided record
+// ID, describing a sensor-specific discrete sensor of the provided type owned
+// by the BMC, e.g. a chassis intrusion sensor. Its events are not re-armed
+// automatically.
+func DiscreteSensorRecord(id ipmi.RecordID, number uint8, sensorType ipmi.SensorType, name string) []byte {
+	record := FullSensorRecord(id, number, name)
+	body := record[5:]
+	body[7] = uint8(sensorType)
+	body[8] = uint8(ipmi.OutputTypeSensorSpecific)
+	body[16] = 0 // unspecified unit
+	return record
+}
+
+// ThresholdEventRecord builds a SEL system event record with the provided
+// record ID, generated by the BMC for a threshold sensor crossing the threshold
+// of the event offset, e.g. 0x9 for upper critical going high.
+func ThresholdEventRecord(id ipmi.RecordID, number uint8, offset uint8, deassertion bool) []byte {
+	record := SystemEventRecord(id, ipmi.SensorTypeTemperature, number, offset)
+	record[12] = 0x01 // threshold
+	if deassertion {
+		record[12] |= 1 << 7
+	}
+	return record
+}
+
+// getWatchdogTimer returns the Get Watchdog Timer response data for the
+// current watchdog state.
+// getSessionInfo handles a Get Session Info request. Only the current session
+// can be requested.
+func (b *BMC) getSessionInfo(s *session, req []byte) (ipmi.CompletionCode, []byte) {
+	if len(req) < 1 {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	if ipmi.SessionIndex(req[0]) != ipmi.SessionIndexCurrent {
+		return ipmi.CompletionCodeInvalidDataField, nil
+	}
+	active := uint8(0)
+	for _, other := range b.sessions {
+		if other.established {
+			active++
+		}
+	}
+	// handle (session IDs are allocated from 1, so the low byte suffices for
+	// tests), max sessions (not enforced), active sessions, user ID, privilege
+	// level, IPMI v2.0 on channel 1
+	return ipmi.CompletionCodeNormal, []byte{uint8(s.id), 0x3f, active, 0x02,
+		s.role & 0x0f, 0x11}
+}
+
+func (b *BMC) getWatchdogTimer() []byte {
+	rsp := make([]byte, 8)
+	if b.watchdog == nil {
+		return rsp
+	}
+	rsp[0] = b.watchdog[0] &^ (1 << 6) // don't stop is not retained
+	if b.watchdogRunning {
+		rsp[0] |= 1 << 6
+	}
+	copy(rsp[1:3], b.watchdog[1:3])
+	// expiration flags are left as 0
+	initial := binary.LittleEndian.Uint16(b.watchdog[4:6])
+	binary.LittleEndian.PutUint16(rsp[4:6], initial)
+	present := initial
+	if b.watchdogRunning {
+		elapsed := time.Since(b.watchdogReset) / ipmi.WatchdogCountdownUnit
+		if elapsed >= time.Duration(initial) {
+			present = 0
+		} else {
+			present = initial - uint16(elapsed)
+		}
+	}
+	binary.LittleEndian.PutUint16(rsp[6:8], present)
+	return rsp
+}
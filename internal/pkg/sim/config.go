@@ -0,0 +1,256 @@
+package sim
+
+import (
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// maxUsers is the number of user IDs supported. User 1 is anonymous, and
+	// user 2 has the configured credentials.
+	maxUsers = 4
+
+	// completionCodeParameterNotSupported and
+	// completionCodeParameterReadOnly are returned by the Set LAN and SOL
+	// Configuration Parameters commands.
+	completionCodeParameterNotSupported ipmi.CompletionCode = 0x80
+	completionCodeParameterReadOnly     ipmi.CompletionCode = 0x82
+)
+
+// user is the state of a user ID. Changes do not affect authentication, which
+// always uses the configured credentials.
+type user struct {
+	name      string
+	password  [16]byte
+	enabled   bool
+	privilege ipmi.PrivilegeLevel
+}
+
+// initConfig sets the initial configuration state: chassis capabilities
+// reflecting the config, with the BMC providing all chassis management
+// functions, an enabled administrator user with the configured credentials, a
+// statically addressed LAN channel 1 generating ARPs, SOL disabled at 115.2
+// kbps, and PEF enabled with all actions allowed.
+func (b *BMC) initConfig() {
+	b.chassisCapabilities = []byte{0x00, 0x20, 0x20, 0x20, 0x20, 0x20}
+	if b.config.DiagnosticInterrupt {
+		b.chassisCapabilities[0] |= 1 << 2
+	}
+	if b.config.FrontPanelDisableAllowed != 0 {
+		b.chassisCapabilities[0] |= 1 << 1
+	}
+
+	for i := range b.users {
+		b.users[i].privilege = ipmi.PrivilegeLevelNoAccess
+	}
+	b.users[1] = user{
+		name:      b.config.Username,
+		enabled:   true,
+		privilege: ipmi.PrivilegeLevelAdministrator,
+	}
+	copy(b.users[1].password[:], b.config.Password)
+
+	b.lanParameters = map[uint8][]byte{
+		uint8(ipmi.LANConfigurationParameterIPAddressSource): {
+			uint8(ipmi.IPAddressSourceStatic),
+		},
+		uint8(ipmi.LANConfigurationParameterIPAddress):             {0, 0, 0, 0},
+		uint8(ipmi.LANConfigurationParameterSubnetMask):            {0, 0, 0, 0},
+		uint8(ipmi.LANConfigurationParameterDefaultGatewayAddress): {0, 0, 0, 0},
+		// gratuitous ARPs and ARP responses, every 2 seconds
+		uint8(ipmi.LANConfigurationParameterBMCGeneratedARPControl): {0x03},
+		uint8(ipmi.LANConfigurationParameterGratuitousARPInterval):  {0x04},
+	}
+	if b.config.MACAddress != nil {
+		b.lanParameters[uint8(ipmi.LANConfigurationParameterMACAddress)] =
+			append([]byte(nil), b.config.MACAddress...)
+	}
+	b.solParameters = map[uint8][]byte{
+		uint8(ipmi.SOLConfigurationParameterEnable): {0x00},
+		uint8(ipmi.SOLConfigurationParameterNonVolatileBitRate): {
+			uint8(ipmi.SOLBitRate115200),
+		},
+	}
+	b.pefParameters = map[uint8][]byte{
+		uint8(ipmi.PEFConfigurationParameterControl): {
+			uint8(ipmi.PEFControlEnable),
+		},
+		uint8(ipmi.PEFConfigurationParameterActionGlobalControl): {0x3f},
+	}
+	b.systemInfo = map[[2]uint8][]byte{}
+}
+
+// getParameter handles the Get LAN, SOL and PEF Configuration Parameters
+// commands, returning the parameter's revision and data.
+func getParameter(parameters map[uint8][]byte, parameter uint8) (ipmi.CompletionCode, []byte) {
+	data, ok := parameters[parameter]
+	if !ok {
+		return ipmi.CompletionCodeNotPresent, nil
+	}
+	return ipmi.CompletionCodeNormal, append([]byte{0x11}, data...)
+}
+
+// setParameter handles the Set LAN, SOL and PEF Configuration Parameters
+// commands. Only parameters in writable can be set, and only to data of the
+// same length.
+func setParameter(parameters map[uint8][]byte, writable map[uint8]bool, parameter uint8, data []byte) (ipmi.CompletionCode, []byte) {
+	current, ok := parameters[parameter]
+	switch {
+	case !ok:
+		return completionCodeParameterNotSupported, nil
+	case !writable[parameter]:
+		return completionCodeParameterReadOnly, nil
+	case len(data) != len(current):
+		return ipmi.CompletionCodeRequestDataLengthInvalid, nil
+	}
+	copy(current, data)
+	return ipmi.CompletionCodeNormal, nil
+}
+
+// channelParameter handles the Get and Set LAN and SOL Configuration
+// Parameters commands, which address parameters of channel 1.
+func channelParameter(parameters map[uint8][]byte, writable map[uint8]bool, set bool, req []byte) (ipmi.CompletionCode, []byte) {
+	// channel, parameter, then set and block selectors or data
+	if len(req) < 2 || (!set && len(req) < 4) {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	if req[0]&0xf != 1 {
+		return ipmi.CompletionCodeNotPresent, nil
+	}
+	if set {
+		return setParameter(parameters, writable, req[1], req[2:])
+	}
+	return getParameter(parameters, req[1])
+}
+
+// systemInfoParameter handles the Get and Set System Info Parameters
+// commands. Any parameter can be set, one block at a time, and parameters that
+// have not been set are not supported.
+func (b *BMC) systemInfoParameter(set bool, req []byte) (ipmi.CompletionCode, []byte) {
+	// get: revision only, parameter, set selector, block selector
+	// set: parameter, set selector, data
+	if len(req) < 2 || (!set && len(req) < 4) {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	if set {
+		key := [2]uint8{req[0], req[1]}
+		b.systemInfo[key] = append([]byte(nil), req[2:]...)
+		return ipmi.CompletionCodeNormal, nil
+	}
+	data, ok := b.systemInfo[[2]uint8{req[1], req[2]}]
+	if !ok {
+		return completionCodeParameterNotSupported, nil
+	}
+	return ipmi.CompletionCodeNormal, append([]byte{0x11, req[2]}, data...)
+}
+
+var (
+	// writableLANParameters, writableSOLParameters and writablePEFParameters
+	// are the parameters that can be set.
+	writableLANParameters = map[uint8]bool{
+		uint8(ipmi.LANConfigurationParameterIPAddressSource):        true,
+		uint8(ipmi.LANConfigurationParameterIPAddress):              true,
+		uint8(ipmi.LANConfigurationParameterSubnetMask):             true,
+		uint8(ipmi.LANConfigurationParameterDefaultGatewayAddress):  true,
+		uint8(ipmi.LANConfigurationParameterBMCGeneratedARPControl): true,
+		uint8(ipmi.LANConfigurationParameterGratuitousARPInterval):  true,
+	}
+	writableSOLParameters = map[uint8]bool{
+		uint8(ipmi.SOLConfigurationParameterEnable):             true,
+		uint8(ipmi.SOLConfigurationParameterNonVolatileBitRate): true,
+	}
+	writablePEFParameters = map[uint8]bool{
+		uint8(ipmi.PEFConfigurationParameterControl):             true,
+		uint8(ipmi.PEFConfigurationParameterActionGlobalControl): true,
+	}
+)
+
+// userCommand handles the Get and Set User Name, Get and Set User Access and
+// Set User Password commands. Access is the same on every channel.
+func (b *BMC) userCommand(op ipmi.Operation, req []byte) (ipmi.CompletionCode, []byte) {
+	// the user ID is in the first byte of all but the access commands
+	idOffset := 0
+	if op == ipmi.OperationGetUserAccessReq ||
+		op == ipmi.OperationSetUserAccessReq {
+		idOffset = 1
+	}
+	if len(req) <= idOffset {
+		return ipmi.CompletionCodeRequestTruncated, nil
+	}
+	id := int(req[idOffset] & 0x3f)
+	if id < 1 || id > maxUsers {
+		return ipmi.CompletionCodeNotPresent, nil
+	}
+	u := &b.users[id-1]
+
+	switch op {
+	case ipmi.OperationGetUserNameReq:
+		rsp := make([]byte, 16)
+		copy(rsp, u.name)
+		return ipmi.CompletionCodeNormal, rsp
+	case ipmi.OperationSetUserNameReq:
+		if len(req) < 17 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		name := req[1:17]
+		for i, c := range name {
+			if c == 0 {
+				name = name[:i]
+				break
+			}
+		}
+		u.name = string(name)
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationGetUserAccessReq:
+		rsp := []byte{maxUsers, 0, 0, uint8(u.privilege)}
+		for _, other := range b.users {
+			if other.enabled {
+				rsp[1]++
+			}
+		}
+		if u.enabled {
+			rsp[1] |= uint8(ipmi.UserStatusEnabled) << 6
+		} else {
+			rsp[1] |= uint8(ipmi.UserStatusDisabled) << 6
+		}
+		if u.privilege != ipmi.PrivilegeLevelNoAccess {
+			rsp[3] |= 1 << 4 // IPMI messaging
+		}
+		return ipmi.CompletionCodeNormal, rsp
+	case ipmi.OperationSetUserAccessReq:
+		if len(req) < 3 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		u.privilege = ipmi.PrivilegeLevel(req[2] & 0xf)
+		return ipmi.CompletionCodeNormal, nil
+	case ipmi.OperationSetUserPasswordReq:
+		if len(req) < 2 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		operation := ipmi.UserPasswordOperation(req[1] & 0x3)
+		if operation == ipmi.UserPasswordOperationSetPassword ||
+			operation == ipmi.UserPasswordOperationTestPassword {
+			if req[0]&(1<<7) != 0 {
+				// 20 byte passwords are not supported
+				return ipmi.CompletionCodeRequestDataLengthInvalid, nil
+			}
+			if len(req) < 18 {
+				return ipmi.CompletionCodeRequestTruncated, nil
+			}
+		}
+		switch operation {
+		case ipmi.UserPasswordOperationDisableUser:
+			u.enabled = false
+		case ipmi.UserPasswordOperationEnableUser:
+			u.enabled = true
+		case ipmi.UserPasswordOperationSetPassword:
+			copy(u.password[:], req[2:18])
+		case ipmi.UserPasswordOperationTestPassword:
+			if string(u.password[:]) != string(req[2:18]) {
+				return ipmi.CompletionCodePasswordMismatch, nil
+			}
+		}
+		return ipmi.CompletionCodeNormal, nil
+	default:
+		return ipmi.CompletionCodeUnrecognisedCommand, nil
+	}
+}
@@ -0,0 +1,71 @@
+package sim
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// dcmiCommand executes a DCMI command, returning the completion code and
+// response data, excluding the group extension identifier.
+func (b *BMC) dcmiCommand(command ipmi.CommandNumber, req []byte) (ipmi.CompletionCode, []byte) {
+	if b.config.Power == 0 {
+		return ipmi.CompletionCodeUnrecognisedCommand, nil
+	}
+	switch command {
+	case 0x01: // Get DCMI Capabilities Info
+		if len(req) < 1 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		if req[0] != 0x01 {
+			// only Supported DCMI Capabilities
+			return ipmi.CompletionCodeParameterOutOfRange, nil
+		}
+		// DCMI 1.5, parameter revision 2, no mandatory platform capabilities,
+		// power management, in-band system interface and primary LAN channel
+		return ipmi.CompletionCodeNormal, []byte{0x01, 0x05, 0x02, 0x00,
+			0x01, 0x09}
+	case 0x02: // Get Power Reading
+		if len(req) < 3 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		// the same reading over any period
+		period := time.Second
+		if req[0] == 0x02 {
+			// enhanced; the period must be in seconds
+			period = time.Second * time.Duration(req[1]&0x3f)
+		}
+		rsp := make([]byte, 17)
+		for i := 0; i < 8; i += 2 {
+			binary.LittleEndian.PutUint16(rsp[i:i+2], b.config.Power)
+		}
+		binary.LittleEndian.PutUint32(rsp[8:12], uint32(time.Now().Unix()))
+		binary.LittleEndian.PutUint32(rsp[12:16],
+			uint32(period/time.Millisecond))
+		rsp[16] = 1 << 6 // active
+		return ipmi.CompletionCodeNormal, rsp
+	case 0x03: // Get Power Limit
+		// the same as the request, with one fewer leading reserved byte
+		rsp := append([]byte(nil), b.powerLimit[1:]...)
+		if !b.powerLimitActive {
+			// no active set power limit
+			return 0x80, rsp
+		}
+		return ipmi.CompletionCodeNormal, rsp
+	case 0x04: // Set Power Limit
+		if len(req) < len(b.powerLimit) {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		copy(b.powerLimit[:], req)
+		return ipmi.CompletionCodeNormal, nil
+	case 0x05: // Activate/Deactivate Power Limit
+		if len(req) < 3 {
+			return ipmi.CompletionCodeRequestTruncated, nil
+		}
+		b.powerLimitActive = req[0] == 0x01
+		return ipmi.CompletionCodeNormal, nil
+	default:
+		return ipmi.CompletionCodeUnrecognisedCommand, nil
+	}
+}
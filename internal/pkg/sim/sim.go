@@ -0,0 +1,734 @@
+// Package sim implements a simulated BMC, which speaks enough IPMI v2.0 over a
+// local UDP socket to answer presence pings, establish RMCP+ sessions, read sensors, walk the SDR
+// Repository, be inventoried and control power. It exists to exercise the library end-to-end in benchmarks and
+// tests without hardware, so it trusts its input far more than a real BMC
+// should, and only supports cipher suite 3 (RAKP-HMAC-SHA1, HMAC-SHA1-96,
+// AES-CBC-128) and its subsets.
+package sim
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sequencer"
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+var serializeOptions = gopacket.SerializeOptions{
+	FixLengths:       true,
+	ComputeChecksums: true,
+}
+
+// Config describes the simulated BMC.
+type Config struct {
+
+	// Username and Password are the credentials of the only user that can
+	// log in, user 2. The user has administrator privileges.
+	Username string
+	Password string
+
+	// GUID is returned by Get System GUID, and used in RAKP messages.
+	GUID [16]byte
+
+	// SDRs contains the raw records in the SDR Repository, including their
+	// headers, in order. FullSensorRecord() can be used to build these.
+	SDRs [][]byte
+
+	// SEL contains the initial 16-byte records in the System Event Log, in
+	// order. Further records can be added with AddSELRecord().
+	SEL [][]byte
+
+	// Readings maps sensor number to the raw value returned by Get Sensor
+	// Reading. Sensors not in the map return a completion code of 0xcb.
+	Readings map[uint8]uint8
+
+	// ReadingFactors maps the number of a non-linear sensor to a function
+	// returning the 7-byte Get Sensor Reading Factors response data for a raw
+	// reading. Requests for sensors not in the map return a completion code of
+	// 0xcb.
+	ReadingFactors map[uint8]func(reading uint8) []byte
+
+	// States maps sensor number to the state byte returned alongside its
+	// reading: the ipmi.ThresholdStatus of threshold sensors, or offsets 0-7 of
+	// discrete sensors. Sensors not in the map have no states asserted. States
+	// are latched until cleared with Re-arm Sensor Events.
+	States map[uint8]uint8
+
+	// FRU is the content of FRU device 0. If nil, the BMC does not advertise
+	// FRU support. Read FRU Data requests for more than 16 bytes are rejected
+	// with a completion code of 0xca, as some BMCs do.
+	FRU []byte
+
+	// MACAddress is returned as the MAC address of LAN channel 1.
+	MACAddress net.HardwareAddr
+	// Intrusion is reported as an active chassis intrusion by Get Chassis
+	// Status.
+	Intrusion bool
+
+	// FrontPanelDisableAllowed is a bitfield of the front panel buttons that
+	// can be disabled with Set Front Panel Enables, in the bit order of that
+	// command. If 0, Get Chassis Status omits the front panel byte, and the
+	// command is rejected; otherwise Get Chassis Capabilities initially
+	// advertises front panel lockout.
+	FrontPanelDisableAllowed uint8
+
+	// IgnoreFrontPanelEnables simulates a BMC that accepts valid Set Front
+	// Panel Enables commands without applying them.
+	IgnoreFrontPanelEnables bool
+
+	// PoweredOn is the initial power state of the chassis. Chassis Control
+	// commands change it immediately.
+	PoweredOn bool
+
+	// IgnoreSoftPowerOff simulates an OS that does not respond to ACPI
+	// shutdown requests: soft power off commands succeed, but have no effect.
+	IgnoreSoftPowerOff bool
+
+	// IgnoredPowerOns is the number of power on commands that succeed without
+	// effect before the chassis powers on, simulating a machine not yet ready.
+	IgnoredPowerOns int
+	// DiagnosticInterrupt indicates the chassis supports diagnostic
+	// interrupts, as reported by Get Chassis Capabilities.
+	DiagnosticInterrupt bool
+
+	// Power is the power consumption in watts returned by DCMI Get Power
+	// Reading. If 0, the BMC does not support DCMI. Power limits can be set
+	// and activated, but are not enforced.
+	Power uint16
+
+	// Manufacturer is returned by Get Device ID. OEM commands of the
+	// manufacturer are not implemented.
+	Manufacturer iana.Enterprise
+
+	// ResponseDelays delays the responses to commands within a session with
+	// the listed operations, simulating responses arriving after the remote
+	// console has given up waiting. Other packets continue to be handled in
+	// the meantime.
+	ResponseDelays map[ipmi.Operation]time.Duration
+}
+
+// BMC is a running simulated BMC. Create instances with New().
+type BMC struct {
+	config Config
+	conn   *net.UDPConn
+
+	// sessions is keyed by the managed system (i.e. our) session ID. It is
+	// only accessed by the serve goroutine.
+	sessions      map[uint32]*session
+	lastSessionID uint32
+
+	// sessionsClosed is the number of sessions closed with Close Session.
+	// Access with atomic.
+	sessionsClosed int32
+
+	// dropSessions is set to 1 by DropSessions(), and cleared by the serve
+	// goroutine once it has done so. Access with atomic.
+	dropSessions int32
+
+	// poweredOn and ignoredPowerOns are the current chassis state, initialised
+	// from the config. They are only accessed by the serve goroutine.
+	poweredOn       bool
+	ignoredPowerOns int
+
+	// bootFlags is the boot flags system boot option parameter, also only
+	// accessed by the serve goroutine.
+	bootFlags [5]byte
+
+	// watchdog is the request data of the last Set Watchdog Timer command, or
+	// nil if the timer has not been configured. The timer was last reset at
+	// watchdogReset if watchdogRunning. These are also only accessed by the
+	// serve goroutine. Expiration is not simulated.
+	watchdog        []byte
+	watchdogRunning bool
+	watchdogReset   time.Time
+
+	// states is the latched state byte of each sensor, initialised from the
+	// config, and frontPanelDisabled the buttons currently disabled. They are
+	// also only accessed by the serve goroutine.
+	states             map[uint8]uint8
+	frontPanelDisabled uint8
+
+	// powerRestorePolicy, chassisCapabilities (the Get Chassis Capabilities
+	// response data), users and the LAN, SOL, PEF and system info
+	// parameters are the configuration that can be changed with Set commands,
+	// with parameters keyed by number, and system info parameters also by set
+	// selector. They are also only accessed by the serve goroutine. Only
+	// channel 1 has LAN and SOL parameters.
+	powerRestorePolicy  ipmi.PowerRestorePolicy
+	chassisCapabilities []byte
+	users               [maxUsers]user
+	lanParameters       map[uint8][]byte
+	solParameters       map[uint8][]byte
+	pefParameters       map[uint8][]byte
+	systemInfo          map[[2]uint8][]byte
+
+	// powerLimit is the request data of the last DCMI Set Power Limit
+	// command, and powerLimitActive whether it has been activated. They are
+	// also only accessed by the serve goroutine.
+	powerLimit       [14]byte
+	powerLimitActive bool
+
+	// udpPackets, rmcpPackets and transmittedPackets are the counters
+	// returned by Get IP/UDP/RMCP Statistics, saturating at 0xffff. They are
+	// also only accessed by the serve goroutine. Every packet received is a
+	// UDP packet, so the IP counters are the same.
+	udpPackets         uint16
+	rmcpPackets        uint16
+	transmittedPackets uint16
+
+	// arpsSuspended is the request data of the last Suspend BMC ARPs
+	// command, masked to the gratuitous ARP and ARP response bits. It is also
+	// only accessed by the serve goroutine.
+	arpsSuspended uint8
+
+	// responseDelay is how long the response to the packet being handled
+	// should be delayed by. It is only accessed by the serve goroutine.
+	responseDelay time.Duration
+
+	// chassisControls is every control received in a Chassis Control
+	// command, in order, so tests can tell whether a command was executed
+	// more than once.
+	chassisControlsMu sync.Mutex
+	chassisControls   []ipmi.ChassisControl
+
+	// sel is the current System Event Log, which can be appended to while the
+	// BMC is running.
+	selMu sync.Mutex
+	sel   [][]byte
+
+	// sessionless decodes packets outside a session.
+	sessionless *ipmi.V2Parser
+
+	buffer gopacket.SerializeBuffer
+	wg     sync.WaitGroup
+}
+
+// session is the state of an RMCP+ session, from its Open Session Request
+// onwards. Established is set once RAKP Message 3 has been verified.
+type session struct {
+	remoteConsoleID uint32
+	id              uint32
+
+	authentication  ipmi.AuthenticationAlgorithm
+	integrity       ipmi.IntegrityAlgorithm
+	confidentiality ipmi.ConfidentialityAlgorithm
+
+	remoteConsoleRandom [16]byte
+	managedSystemRandom [16]byte
+	role                uint8
+	username            string
+
+	established bool
+	sequence    uint32
+
+	integrityHash hash.Hash
+	cipher        gopacket.SerializableLayer
+	parser        *ipmi.V2Parser
+}
+
+// New starts a simulated BMC listening on an ephemeral port on the loopback
+// interface. The config must not be modified after this is called.
+func New(config *Config) (*BMC, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+	b := &BMC{
+		config:      *config,
+		conn:        conn,
+		sessions:    map[uint32]*session{},
+		sessionless: ipmi.NewV2Parser(nil),
+		buffer:      gopacket.NewSerializeBuffer(),
+
+		poweredOn:       config.PoweredOn,
+		ignoredPowerOns: config.IgnoredPowerOns,
+		sel:             append([][]byte(nil), config.SEL...),
+		states:          map[uint8]uint8{},
+	}
+	for number, state := range config.States {
+		b.states[number] = state
+	}
+	b.initConfig()
+	b.wg.Add(1)
+	go b.serve()
+	return b, nil
+}
+
+// AddSELRecord appends a 16-byte record to the System Event Log.
+// SystemEventRecord() can be used to build one.
+func (b *BMC) AddSELRecord(record []byte) {
+	b.selMu.Lock()
+	defer b.selMu.Unlock()
+	b.sel = append(b.sel, record)
+}
+
+// ChassisControls returns the control of every Chassis Control command
+// received, in order, including those that had no effect.
+func (b *BMC) ChassisControls() []ipmi.ChassisControl {
+	b.chassisControlsMu.Lock()
+	defer b.chassisControlsMu.Unlock()
+	return append([]ipmi.ChassisControl(nil), b.chassisControls...)
+}
+
+// SessionsClosed returns the number of sessions closed with Close Session,
+// rather than timing out or never being established.
+func (b *BMC) SessionsClosed() int {
+	return int(atomic.LoadInt32(&b.sessionsClosed))
+}
+
+// DropSessions causes the BMC to forget all sessions before handling the next
+// packet, as if it had been reset, so further packets within them are ignored.
+func (b *BMC) DropSessions() {
+	atomic.StoreInt32(&b.dropSessions, 1)
+}
+
+// Addr returns the IP:port the BMC is listening on, suitable for passing to
+// bmc.Dial().
+func (b *BMC) Addr() string {
+	return b.conn.LocalAddr().String()
+}
+
+// Close stops the BMC, waiting for it to finish handling any packet in
+// progress.
+func (b *BMC) Close() error {
+	err := b.conn.Close()
+	b.wg.Wait()
+	return err
+}
+
+func (b *BMC) serve() {
+	defer b.wg.Done()
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+			return
+		}
+		incrementCounter(&b.udpPackets)
+		b.responseDelay = 0
+		if response := b.handle(buf[:n]); response != nil {
+			if b.responseDelay != 0 {
+				// the buffer is reused for the next response
+				response = append([]byte(nil), response...)
+				time.AfterFunc(b.responseDelay, func() {
+					b.conn.WriteToUDP(response, addr)
+				})
+				incrementCounter(&b.transmittedPackets)
+				continue
+			}
+			// best effort, like UDP itself
+			if _, err := b.conn.WriteToUDP(response, addr); err == nil {
+				incrementCounter(&b.transmittedPackets)
+			}
+		}
+	}
+}
+
+// incrementCounter adds 1 to c, unless it is already at its maximum value.
+func incrementCounter(c *uint16) {
+	if *c != math.MaxUint16 {
+		*c++
+	}
+}
+
+// handle returns the response to a packet, or nil if it should be ignored.
+func (b *BMC) handle(packet []byte) []byte {
+	if atomic.CompareAndSwapInt32(&b.dropSessions, 1, 0) {
+		b.sessions = map[uint32]*session{}
+	}
+	if len(packet) >= 4 && packet[0] == layers.RMCPVersion1 {
+		incrementCounter(&b.rmcpPackets)
+	}
+	if len(packet) >= 4 && layers.RMCPClass(packet[3]) == layers.RMCPClassASF {
+		return b.presencePong(packet)
+	}
+	// RMCP header (4), auth type (1), payload type (1), [OEM IANA (4), OEM
+	// payload ID (2)], session ID (4)
+	if len(packet) < 10 ||
+		ipmi.AuthenticationType(packet[4]) != ipmi.AuthenticationTypeRMCPPlus {
+		return nil
+	}
+	offset := 6
+	if ipmi.PayloadType(packet[5]&0x3f) == ipmi.PayloadTypeOEM {
+		offset += 6
+	}
+	if len(packet) < offset+4 {
+		return nil
+	}
+	id := binary.LittleEndian.Uint32(packet[offset : offset+4])
+	if id == 0 {
+		return b.handleSessionless(packet)
+	}
+	if s, ok := b.sessions[id]; ok && s.established {
+		return b.handleSession(s, packet)
+	}
+	return nil
+}
+
+func (b *BMC) handleSessionless(packet []byte) []byte {
+	if err := b.sessionless.DecodeLayers(packet, nil); err != nil {
+		return nil
+	}
+	payload := b.sessionless.V2Session.LayerPayload()
+	switch b.sessionless.V2Session.PayloadType {
+	case ipmi.PayloadTypeIPMI:
+		code, data := b.command(nil, &b.sessionless.Message)
+		return b.serializeMessage(nil, &b.sessionless.Message, code, data)
+	case ipmi.PayloadTypeOpenSessionReq:
+		return b.serializePayload(ipmi.PayloadDescriptorOpenSessionRsp,
+			b.openSession(payload))
+	case ipmi.PayloadTypeRAKPMessage1:
+		return b.serializePayload(ipmi.PayloadDescriptorRAKPMessage2,
+			b.rakpMessage1(payload))
+	case ipmi.PayloadTypeRAKPMessage3:
+		return b.serializePayload(ipmi.PayloadDescriptorRAKPMessage4,
+			b.rakpMessage3(payload))
+	default:
+		return nil
+	}
+}
+
+func (b *BMC) handleSession(s *session, packet []byte) []byte {
+	if err := s.parser.DecodeLayers(packet, nil); err != nil {
+		// includes invalid signatures
+		return nil
+	}
+	switch t := s.parser.V2Session.PayloadType; {
+	case t == ipmi.PayloadTypeIPMI:
+		b.responseDelay = b.config.ResponseDelays[s.parser.Message.Operation]
+		code, data := b.command(s, &s.parser.Message)
+		return b.serializeMessage(s, &s.parser.Message, code, data)
+	case t.IsOEM():
+		return b.echoOEMPayload(s)
+	default:
+		return nil
+	}
+}
+
+// echoOEMPayload returns an OEM payload to the remote console unchanged. The
+// BMC defines no OEM payloads of its own; this exercises their plumbing.
+func (b *BMC) echoOEMPayload(s *session) []byte {
+	payload := s.parser.V2Session.LayerPayload()
+	if s.parser.V2Session.Encrypted {
+		cipher, ok := s.cipher.(*ipmi.AES128CBC)
+		if !ok {
+			return nil
+		}
+		if err := cipher.DecodeFromBytes(payload, gopacket.NilDecodeFeedback); err != nil {
+			return nil
+		}
+		payload = cipher.LayerPayload()
+	}
+	ls := b.sessionLayers(s, s.parser.V2Session.PayloadDescriptor)
+	ls = append(ls, gopacket.Payload(payload))
+	if err := gopacket.SerializeLayers(b.buffer, serializeOptions, ls...); err != nil {
+		return nil
+	}
+	return b.buffer.Bytes()
+}
+
+// presencePong returns the response to an ASF Presence Ping, or nil if the
+// packet is some other ASF message.
+func (b *BMC) presencePong(packet []byte) []byte {
+	// RMCP header (4), IANA enterprise number (4), message type (1), tag (1),
+	// reserved (1), data length (1)
+	if len(packet) < 12 ||
+		binary.BigEndian.Uint32(packet[4:8]) != layers.ASFRMCPEnterprise ||
+		packet[8] != layers.ASFDataIdentifierPresencePing.Type {
+		return nil
+	}
+	err := gopacket.SerializeLayers(b.buffer, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff,
+			Class:    layers.RMCPClassASF,
+		},
+		&layers.ASF{
+			ASFDataIdentifier: layers.ASFDataIdentifierPresencePong,
+			Tag:               packet[9],
+		},
+		&layers.ASFPresencePong{
+			Enterprise: layers.ASFRMCPEnterprise,
+			IPMI:       true,
+			ASFv1:      true,
+		},
+	)
+	if err != nil {
+		return nil
+	}
+	return b.buffer.Bytes()
+}
+
+// serializePayload wraps a session setup payload in a session-less packet.
+func (b *BMC) serializePayload(d ipmi.PayloadDescriptor, payload []byte) []byte {
+	if payload == nil {
+		return nil
+	}
+	err := gopacket.SerializeLayers(b.buffer, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&ipmi.V2Session{
+			PayloadDescriptor: d,
+		},
+		gopacket.Payload(payload),
+	)
+	if err != nil {
+		return nil
+	}
+	return b.buffer.Bytes()
+}
+
+// serializeMessage builds the response to an IPMI message, within a session if
+// s is non-nil.
+func (b *BMC) serializeMessage(s *session, req *ipmi.Message, code ipmi.CompletionCode, data []byte) []byte {
+	rsp := sequencer.Response(req, code)
+	ls := append(b.sessionLayers(s, ipmi.PayloadDescriptorIPMI),
+		&rsp,
+		gopacket.Payload(data),
+	)
+	if err := gopacket.SerializeLayers(b.buffer, serializeOptions, ls...); err != nil {
+		return nil
+	}
+	return b.buffer.Bytes()
+}
+
+// sessionLayers returns the layers wrapping a payload sent to the remote
+// console, within a session if s is non-nil.
+func (b *BMC) sessionLayers(s *session, d ipmi.PayloadDescriptor) []gopacket.SerializableLayer {
+	sessionLayer := &ipmi.V2Session{
+		PayloadDescriptor: d,
+	}
+	ls := []gopacket.SerializableLayer{
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff,
+			Class:    layers.RMCPClassIPMI,
+		},
+		sessionLayer,
+	}
+	if s != nil {
+		// 0 is skipped when the sequence number wraps, as it is used
+		// outside sessions
+		s.sequence++
+		if s.sequence == 0 {
+			s.sequence++
+		}
+		sessionLayer.ID = s.remoteConsoleID
+		sessionLayer.Sequence = s.sequence
+		sessionLayer.Authenticated = s.integrityHash != nil
+		sessionLayer.IntegrityAlgorithm = s.integrityHash
+		if s.cipher != nil {
+			sessionLayer.Encrypted = true
+			ls = append(ls, s.cipher)
+		}
+	}
+	return ls
+}
+
+// openSession handles an Open Session Request, returning the response payload.
+func (b *BMC) openSession(req []byte) []byte {
+	// tag, privilege, reserved (2), remote console session ID (4), then three
+	// 8-byte algorithm payloads
+	if len(req) < 32 {
+		return nil
+	}
+	rsp := make([]byte, 36)
+	rsp[0] = req[0]
+	copy(rsp[4:8], req[4:8])
+
+	s := &session{
+		remoteConsoleID: binary.LittleEndian.Uint32(req[4:8]),
+		authentication:  ipmi.AuthenticationAlgorithm(req[12]),
+		integrity:       ipmi.IntegrityAlgorithm(req[20]),
+		confidentiality: ipmi.ConfidentialityAlgorithm(req[28]),
+	}
+	if s.authentication != ipmi.AuthenticationAlgorithmHMACSHA1 ||
+		(s.integrity != ipmi.IntegrityAlgorithmNone &&
+			s.integrity != ipmi.IntegrityAlgorithmHMACSHA196) ||
+		(s.confidentiality != ipmi.ConfidentialityAlgorithmNone &&
+			s.confidentiality != ipmi.ConfidentialityAlgorithmAESCBC128) {
+		rsp[1] = uint8(ipmi.StatusCodeNoCipherSuiteMatch)
+		return rsp[:8]
+	}
+
+	b.lastSessionID++
+	s.id = b.lastSessionID
+	b.sessions[s.id] = s
+
+	// grant the requested level, as if the user and channel allowed it
+	level := ipmi.PrivilegeLevel(req[1] & 0xf)
+	if level == ipmi.PrivilegeLevelHighest {
+		level = ipmi.PrivilegeLevelAdministrator
+	}
+	rsp[2] = uint8(level)
+	binary.LittleEndian.PutUint32(rsp[8:12], s.id)
+	copy(rsp[12:36], req[8:32]) // echo the algorithms, which we accept
+	return rsp
+}
+
+// rakpMessage1 handles RAKP Message 1, returning RAKP Message 2.
+func (b *BMC) rakpMessage1(req []byte) []byte {
+	// tag, reserved (3), managed system session ID (4), remote console random
+	// (16), role, reserved (2), username length, username
+	if len(req) < 28 || len(req) < 28+int(req[27]) {
+		return nil
+	}
+	rsp := make([]byte, 8, 60)
+	rsp[0] = req[0]
+	s, ok := b.sessions[binary.LittleEndian.Uint32(req[4:8])]
+	if !ok {
+		rsp[1] = uint8(ipmi.StatusCodeInvalidSessionID)
+		return rsp
+	}
+	binary.LittleEndian.PutUint32(rsp[4:8], s.remoteConsoleID)
+
+	copy(s.remoteConsoleRandom[:], req[8:24])
+	s.role = req[24]
+	s.username = string(req[28 : 28+int(req[27])])
+	if s.username != b.config.Username {
+		rsp[1] = uint8(ipmi.StatusCodeUnauthorisedName)
+		return rsp
+	}
+	if _, err := rand.Read(s.managedSystemRandom[:]); err != nil {
+		return nil
+	}
+
+	rsp = append(rsp, s.managedSystemRandom[:]...)
+	rsp = append(rsp, b.config.GUID[:]...)
+
+	h := hmac.New(sha1.New, []byte(b.config.Password))
+	buf := [4]byte{}
+	binary.LittleEndian.PutUint32(buf[:], s.remoteConsoleID)
+	h.Write(buf[:])
+	binary.LittleEndian.PutUint32(buf[:], s.id)
+	h.Write(buf[:])
+	h.Write(s.remoteConsoleRandom[:])
+	h.Write(s.managedSystemRandom[:])
+	h.Write(b.config.GUID[:])
+	s.writeRoleAndUsername(h)
+	return h.Sum(rsp)
+}
+
+// rakpMessage3 handles RAKP Message 3, returning RAKP Message 4. If the auth
+// code is valid, the session becomes established.
+func (b *BMC) rakpMessage3(req []byte) []byte {
+	// tag, status, reserved (2), managed system session ID (4), auth code
+	if len(req) < 8 {
+		return nil
+	}
+	rsp := make([]byte, 8, 20)
+	rsp[0] = req[0]
+	s, ok := b.sessions[binary.LittleEndian.Uint32(req[4:8])]
+	if !ok {
+		rsp[1] = uint8(ipmi.StatusCodeInvalidSessionID)
+		return rsp
+	}
+	binary.LittleEndian.PutUint32(rsp[4:8], s.remoteConsoleID)
+	if ipmi.StatusCode(req[1]) != ipmi.StatusCodeOK {
+		delete(b.sessions, s.id)
+		return nil
+	}
+
+	password := []byte(b.config.Password)
+	h := hmac.New(sha1.New, password)
+	h.Write(s.managedSystemRandom[:])
+	buf := [4]byte{}
+	binary.LittleEndian.PutUint32(buf[:], s.remoteConsoleID)
+	h.Write(buf[:])
+	s.writeRoleAndUsername(h)
+	if !hmac.Equal(req[8:], h.Sum(nil)) {
+		rsp[1] = uint8(ipmi.StatusCodeInvalidIntegrityCheckValue)
+		return rsp
+	}
+
+	// no BMC key, so K_G is the password
+	h = hmac.New(sha1.New, password)
+	h.Write(s.remoteConsoleRandom[:])
+	h.Write(s.managedSystemRandom[:])
+	s.writeRoleAndUsername(h)
+	sik := h.Sum(nil)
+	if err := s.establish(sik); err != nil {
+		return nil
+	}
+
+	h = hmac.New(sha1.New, sik)
+	h.Write(s.remoteConsoleRandom[:])
+	binary.LittleEndian.PutUint32(buf[:], s.id)
+	h.Write(buf[:])
+	h.Write(b.config.GUID[:])
+	return append(rsp, h.Sum(nil)[:12]...)
+}
+
+func (s *session) writeRoleAndUsername(h hash.Hash) {
+	h.Write([]byte{s.role, uint8(len(s.username))})
+	h.Write([]byte(s.username))
+}
+
+// establish derives the session keys from the SIK, and marks the session as
+// ready for IPMI messages.
+func (s *session) establish(sik []byte) error {
+	k := func(n uint8) []byte {
+		h := hmac.New(sha1.New, sik)
+		for i := 0; i < h.Size(); i++ {
+			h.Write([]byte{n})
+		}
+		return h.Sum(nil)
+	}
+
+	var confidentiality *ipmi.AES128CBC
+	if s.confidentiality == ipmi.ConfidentialityAlgorithmAESCBC128 {
+		key := [16]byte{}
+		copy(key[:], k(2))
+		var err error
+		if confidentiality, err = ipmi.NewAES128CBC(key); err != nil {
+			return err
+		}
+		s.parser = ipmi.NewV2Parser(confidentiality)
+		s.cipher = confidentiality
+	} else {
+		s.parser = ipmi.NewV2Parser(nil)
+	}
+	if s.integrity == ipmi.IntegrityAlgorithmHMACSHA196 {
+		s.integrityHash = truncatedHash{
+			Hash:   hmac.New(sha1.New, k(1)),
+			length: 12,
+		}
+		s.parser.V2Session.IntegrityAlgorithm = s.integrityHash
+	}
+	s.established = true
+	return nil
+}
+
+// truncatedHash implements HMAC-SHA1-96, the first 12 bytes of HMAC-SHA1.
+type truncatedHash struct {
+	hash.Hash
+	length int
+}
+
+func (t truncatedHash) Sum(b []byte) []byte {
+	return t.Hash.Sum(b)[:len(b)+t.length]
+}
+
+func (t truncatedHash) Size() int {
+	return t.length
+}
@@ -0,0 +1,1570 @@
+package sim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/pkg/dcmi"
+	"github.com/kuiwang02/bmc/pkg/dell"
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+// testFRU is a FRU Inventory Device with board and product areas.
+var testFRU = []byte{
+	0x01, 0x00, 0x00, 0x01, 0x06, 0x00, 0x00, 0xf8, 0x01, 0x05, 0x00,
+	0x00, 0x00, 0x00, 0xc4, 0x41, 0x63, 0x6d, 0x65, 0xc5, 0x42, 0x6f,
+	0x61, 0x72, 0x64, 0xc7, 0x42, 0x53, 0x4e, 0x30, 0x30, 0x30, 0x31,
+	0xc5, 0x42, 0x50, 0x4e, 0x2d, 0x31, 0xc1, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0xe4, 0x01, 0x05, 0x00, 0xc4, 0x41, 0x63, 0x6d,
+	0x65, 0xc6, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0xc5, 0x50, 0x50,
+	0x4e, 0x2d, 0x31, 0xc3, 0x31, 0x2e, 0x30, 0xc7, 0x50, 0x53, 0x4e,
+	0x30, 0x30, 0x30, 0x31, 0xc0, 0xc1, 0x00, 0x00, 0x00, 0x00, 0x26,
+}
+
+// newTestTransport starts a simulated BMC with the provided config and dials
+// it. The Username and Password default to "admin" and "hunter2"
+// respectively. Both are closed when the test finishes.
+func newTestTransport(t *testing.T, config *Config) (*BMC, *bmc.V2SessionlessTransport) {
+	t.Helper()
+	if config.Username == "" {
+		config.Username = "admin"
+	}
+	if config.Password == "" {
+		config.Password = "hunter2"
+	}
+	sim, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sim.Close() })
+
+	machine, err := bmc.DialV2(sim.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { machine.Close() })
+	return sim, machine
+}
+
+// newTestSession starts a simulated BMC with the provided config, and
+// establishes an administrator session with it using the configured
+// credentials. The session is closed when the test finishes.
+func newTestSession(t *testing.T, config *Config) (*BMC, *bmc.V2Session) {
+	t.Helper()
+	return newTestSessionWithOpts(t, config, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		},
+	})
+}
+
+// newTestSessionWithOpts is like newTestSession, but establishes the session
+// with the provided options. The configured credentials are used if opts
+// specifies none.
+func newTestSessionWithOpts(t *testing.T, config *Config, opts *bmc.V2SessionOpts) (*BMC, *bmc.V2Session) {
+	t.Helper()
+	sim, machine := newTestTransport(t, config)
+	if opts.Username == "" {
+		opts.Username = config.Username
+	}
+	if opts.Password == nil {
+		opts.Password = []byte(config.Password)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sess, err := machine.NewV2Session(ctx, opts)
+	if err != nil {
+		t.Fatalf("NewV2Session() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		sess.Close(ctx)
+	})
+	return sim, sess
+}
+
+func TestSession(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		GUID: [16]byte{0x1, 0x2, 0x3},
+		SDRs: [][]byte{
+			FullSensorRecord(1, 10, "Inlet Temp"),
+			FullSensorRecord(2, 11, "CPU Temp"),
+		},
+		Readings: map[uint8]uint8{10: 21, 11: 45},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	guid, err := sess.GetSystemGUID(ctx)
+	if err != nil {
+		t.Fatalf("GetSystemGUID() failed: %v", err)
+	}
+	if guid[2] != 0x3 {
+		t.Errorf("GetSystemGUID() = %v, want %v", guid, [16]byte{0x1, 0x2, 0x3})
+	}
+
+	reading, err := sess.GetSensorReading(ctx, 11)
+	if err != nil {
+		t.Fatalf("GetSensorReading() failed: %v", err)
+	}
+	if reading.Reading != 45 {
+		t.Errorf("GetSensorReading() = %v, want 45", reading.Reading)
+	}
+
+	repo, err := bmc.RetrieveSDRRepository(ctx, sess)
+	if err != nil {
+		t.Fatalf("RetrieveSDRRepository() failed: %v", err)
+	}
+	if len(repo) != 2 || repo[2].Identity != "CPU Temp" {
+		t.Errorf("RetrieveSDRRepository() = %v, want 2 records", repo)
+	}
+}
+
+func TestSendCommandRaw(t *testing.T) {
+	sim, machine := newTestTransport(t, &Config{
+		GUID: [16]byte{0x1, 0x2, 0x3},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := &ipmi.GetSystemGUIDCmd{}
+	m, err := machine.SendCommandRaw(ctx, cmd)
+	if err != nil {
+		t.Fatalf("SendCommandRaw() failed: %v", err)
+	}
+	if m.Function != ipmi.NetworkFunctionAppRsp ||
+		m.Command != ipmi.OperationGetSystemGUIDReq.Command {
+		t.Errorf("SendCommandRaw() returned operation %v, want %v response",
+			m.Operation, ipmi.OperationGetSystemGUIDReq)
+	}
+	if m.RemoteAddress != ipmi.SoftwareIDRemoteConsole1.Address() ||
+		m.LocalAddress != ipmi.SlaveAddressBMC.Address() {
+		t.Errorf("SendCommandRaw() returned addresses %v -> %v, want BMC -> "+
+			"remote console", m.LocalAddress, m.RemoteAddress)
+	}
+	if m.Sequence != 1 {
+		t.Errorf("SendCommandRaw() returned sequence %v, want 1", m.Sequence)
+	}
+	if m.CompletionCode != ipmi.CompletionCodeNormal {
+		t.Errorf("SendCommandRaw() returned code %v, want %v", m.CompletionCode,
+			ipmi.CompletionCodeNormal)
+	}
+	if cmd.Rsp.GUID != sim.config.GUID {
+		t.Errorf("SendCommandRaw() decoded GUID %v, want %v", cmd.Rsp.GUID,
+			sim.config.GUID)
+	}
+
+	// the returned message must not alias the connection's buffers
+	payload := append([]byte(nil), m.LayerPayload()...)
+	if _, err := machine.SendCommandRaw(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesCmd{}); err != nil {
+		t.Fatalf("SendCommandRaw() failed: %v", err)
+	}
+	if string(m.LayerPayload()) != string(payload) {
+		t.Errorf("message payload changed to %v after another command, want "+
+			"%v", m.LayerPayload(), payload)
+	}
+}
+
+func TestCommandWithAddress(t *testing.T) {
+	_, machine := newTestTransport(t, &Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// the simulator responds as whichever controller is addressed
+	target := ipmi.SlaveAddress(0x41).Address()
+	cmd := ipmi.CommandWithAddress(&ipmi.GetSystemGUIDCmd{}, target, 1)
+	m, err := machine.SendCommandRaw(ctx, cmd)
+	if err != nil {
+		t.Fatalf("SendCommandRaw() failed: %v", err)
+	}
+	if m.LocalAddress != target || m.LocalLUN != 1 {
+		t.Errorf("response from %v LUN %v, want %v LUN 1", m.LocalAddress,
+			m.LocalLUN, target)
+	}
+
+	// subsequent commands go to the BMC
+	m, err = machine.SendCommandRaw(ctx, &ipmi.GetSystemGUIDCmd{})
+	if err != nil {
+		t.Fatalf("SendCommandRaw() failed: %v", err)
+	}
+	if m.LocalAddress != ipmi.SlaveAddressBMC.Address() {
+		t.Errorf("response from %v, want BMC", m.LocalAddress)
+	}
+}
+
+func TestInventory(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		GUID:       [16]byte{0x1, 0x2, 0x3},
+		FRU:        testFRU,
+		MACAddress: net.HardwareAddr{0x00, 0x25, 0x90, 0x12, 0x34, 0x56},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inventory, err := bmc.Inventory(ctx, sess)
+	if err != nil {
+		t.Fatalf("Inventory() failed: %v", err)
+	}
+	if inventory.GUID[2] != 0x3 {
+		t.Errorf("GUID = %v, want %v", inventory.GUID, [16]byte{0x1, 0x2, 0x3})
+	}
+	if inventory.FirmwareVersion != "1.2" {
+		t.Errorf("FirmwareVersion = %q, want %q", inventory.FirmwareVersion,
+			"1.2")
+	}
+	if got := inventory.IPMIVersion(); got != "2.0" {
+		t.Errorf("IPMIVersion() = %q, want %q", got, "2.0")
+	}
+	if got := inventory.MACAddress.String(); got != "00:25:90:12:34:56" {
+		t.Errorf("MACAddress = %v, want 00:25:90:12:34:56", got)
+	}
+	if inventory.SystemFirmwareVersion != "" || inventory.DCMIVersion != "" {
+		t.Errorf("unsupported fields populated: %+v", inventory)
+	}
+	if inventory.FRU == nil || inventory.FRU.Board == nil ||
+		inventory.FRU.Product == nil {
+		t.Fatalf("FRU = %+v, want board and product areas", inventory.FRU)
+	}
+	if inventory.FRU.Chassis != nil {
+		t.Errorf("FRU.Chassis = %v, want nil", inventory.FRU.Chassis)
+	}
+	if got := inventory.FRU.Board.SerialNumber; got != "BSN0001" {
+		t.Errorf("FRU.Board.SerialNumber = %q, want %q", got, "BSN0001")
+	}
+	if got := inventory.FRU.Product.Name; got != "Server" {
+		t.Errorf("FRU.Product.Name = %q, want %q", got, "Server")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		FRU:                 testFRU,
+		Power:               250,
+		DiagnosticInterrupt: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caps, err := bmc.Capabilities(ctx, sess)
+	if err != nil {
+		t.Fatalf("Capabilities() failed: %v", err)
+	}
+	want := &bmc.MachineCapabilities{
+		IPMIVersion:         "2.0",
+		FRUInventory:        true,
+		SDRRepository:       true,
+		Sensors:             true,
+		DiagnosticInterrupt: true,
+		Channel:             1,
+		IPMIv2:              true,
+		SOL:                 true,
+		DCMIVersion:         "1.5",
+		DCMIPowerManagement: true,
+	}
+	if diff := cmp.Diff(want, caps); diff != "" {
+		t.Errorf("Capabilities() = %+v, want %+v: %v", caps, want, diff)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	sdrs := [][]byte{
+		FullSensorRecord(1, 10, "Inlet Temp"),
+		FullSensorRecord(2, 11, "CPU Temp"),
+	}
+	_, sess := newTestSession(t, &Config{
+		SDRs: sdrs,
+		FRU:  testFRU,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sdrBuf := &bytes.Buffer{}
+	if err := bmc.SaveSDRRepository(ctx, sess, sdrBuf); err != nil {
+		t.Fatalf("SaveSDRRepository() failed: %v", err)
+	}
+	if want := bytes.Join(sdrs, nil); !bytes.Equal(sdrBuf.Bytes(), want) {
+		t.Errorf("SaveSDRRepository() wrote %v, want %v", sdrBuf.Bytes(), want)
+	}
+	repo, err := bmc.LoadSDRRepository(sdrBuf)
+	if err != nil {
+		t.Fatalf("LoadSDRRepository() failed: %v", err)
+	}
+	if len(repo) != 2 || repo[1].Identity != "Inlet Temp" ||
+		repo[2].Identity != "CPU Temp" {
+		t.Errorf("LoadSDRRepository() = %v, want 2 records", repo)
+	}
+
+	fruBuf := &bytes.Buffer{}
+	if err := bmc.SaveFRU(ctx, sess, 0, fruBuf); err != nil {
+		t.Fatalf("SaveFRU() failed: %v", err)
+	}
+	if !bytes.Equal(fruBuf.Bytes(), testFRU) {
+		t.Errorf("SaveFRU() wrote %v, want %v", fruBuf.Bytes(), testFRU)
+	}
+	loaded, err := bmc.LoadFRUInventory(fruBuf)
+	if err != nil {
+		t.Fatalf("LoadFRUInventory() failed: %v", err)
+	}
+	live, err := bmc.ReadFRUInventory(ctx, sess, 0)
+	if err != nil {
+		t.Fatalf("ReadFRUInventory() failed: %v", err)
+	}
+	if diff := cmp.Diff(live, loaded); diff != "" {
+		t.Errorf("LoadFRUInventory() = %+v, want %+v: %v", loaded, live, diff)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	sim, sess := newTestSession(t, &Config{
+		SEL: [][]byte{
+			SystemEventRecord(1, ipmi.SensorTypeProcessor, 1, 0x0),
+			SystemEventRecord(2, ipmi.SensorTypePowerSupply, 2, 0x1),
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	newCtx, newCancel := context.WithCancel(ctx)
+	events, err := bmc.Events(newCtx, sess, &bmc.EventsOpts{
+		PollInterval: 10 * time.Millisecond,
+		Errors: func(err error) {
+			t.Errorf("Events() polling failed: %v", err)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Events() failed: %v", err)
+	}
+	sim.AddSELRecord(SystemEventRecord(3, ipmi.SensorTypeMemory, 3, 0x5))
+	event := <-events
+	if event.Source != bmc.EventSourceSEL || event.Record.ID != 3 ||
+		event.Record.SensorType != ipmi.SensorTypeMemory ||
+		event.Record.EventData[0] != 0x5 {
+		t.Errorf("Events() emitted %v %+v, want record 3", event.Source,
+			event.Record)
+	}
+	newCancel()
+	for range events {
+		// drain until closed
+	}
+
+	replayCtx, replayCancel := context.WithCancel(ctx)
+	defer replayCancel()
+	events, err = bmc.Events(replayCtx, sess, &bmc.EventsOpts{
+		PollInterval: 10 * time.Millisecond,
+		Replay:       true,
+	})
+	if err != nil {
+		t.Fatalf("Events() failed: %v", err)
+	}
+	second := (*ipmi.SELEventRecord)(nil)
+	for want := ipmi.RecordID(1); want <= 3; want++ {
+		event := <-events
+		if event.Record.ID != want {
+			t.Errorf("Events() with replay emitted record %v, want %v",
+				event.Record.ID, want)
+		}
+		if want == 2 {
+			second = event.Record
+		}
+	}
+	replayCancel()
+	for range events {
+		// drain until closed
+	}
+
+	// resuming, as after a restart, with state persisted as bytes
+	after := &ipmi.SELEventRecord{}
+	if err := after.DecodeFromBytes(second.Contents,
+		gopacket.NilDecodeFeedback); err != nil {
+		t.Fatal(err)
+	}
+	afterCtx, afterCancel := context.WithCancel(ctx)
+	defer afterCancel()
+	events, err = bmc.Events(afterCtx, sess, &bmc.EventsOpts{
+		PollInterval: 10 * time.Millisecond,
+		After:        after,
+	})
+	if err != nil {
+		t.Fatalf("Events() failed: %v", err)
+	}
+	if event := <-events; event.Record.ID != 3 {
+		t.Errorf("Events() after record 2 emitted record %v, want 3",
+			event.Record.ID)
+	}
+}
+
+func TestDiagnosticInterrupt(t *testing.T) {
+	tests := []struct {
+		supported bool
+		want      error
+	}{
+		{true, nil},
+		{false, bmc.ErrDiagnosticInterruptUnsupported},
+	}
+	for _, test := range tests {
+		_, sess := newTestSession(t, &Config{
+			DiagnosticInterrupt: test.supported,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := bmc.DiagnosticInterrupt(ctx, sess); err != test.want {
+			t.Errorf("DiagnosticInterrupt() with support %v = %v, want %v",
+				test.supported, err, test.want)
+		}
+	}
+}
+
+func TestOEMPayload(t *testing.T) {
+	_, sess := newTestSession(t, &Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type received struct {
+		d       ipmi.PayloadDescriptor
+		payload []byte
+	}
+	got := make(chan received, 1)
+	if err := sess.HandleOEMPayload(ipmi.PayloadDescriptor{
+		PayloadType: ipmi.PayloadTypeOEM3,
+	}, func(_ *bmc.V2Session, d ipmi.PayloadDescriptor, payload []byte) {
+		got <- received{d, payload}
+	}); err != nil {
+		t.Fatalf("HandleOEMPayload() failed: %v", err)
+	}
+	if err := sess.HandleOEMPayload(ipmi.PayloadDescriptorIPMI, nil); !errors.Is(err, bmc.ErrNotOEMPayload) {
+		t.Errorf("HandleOEMPayload(IPMI) = %v, want %v", err,
+			bmc.ErrNotOEMPayload)
+	}
+
+	// the simulator echoes OEM payloads
+	send := func(d ipmi.PayloadDescriptor, payload []byte) bmc.UnsolicitedPacket {
+		t.Helper()
+		if err := sess.SendOEMPayload(ctx, d, payload); err != nil {
+			t.Fatalf("SendOEMPayload() failed: %v", err)
+		}
+		p, err := sess.Recv(ctx)
+		if err != nil {
+			t.Fatalf("Recv() failed: %v", err)
+		}
+		return p
+	}
+	payload := []byte("virtual media")
+	p := send(ipmi.PayloadDescriptor{
+		PayloadType: ipmi.PayloadTypeOEM3,
+	}, payload)
+	if err := sess.DispatchOEMPayload(p); err != nil {
+		t.Fatalf("DispatchOEMPayload() failed: %v", err)
+	}
+	r := <-got
+	if r.d.PayloadType != ipmi.PayloadTypeOEM3 || !bytes.Equal(r.payload, payload) {
+		t.Errorf("handler received %v %#v, want %v %#v", r.d, r.payload,
+			ipmi.PayloadTypeOEM3, payload)
+	}
+
+	p = send(ipmi.PayloadDescriptor{
+		PayloadType: ipmi.PayloadTypeOEM,
+		Enterprise:  iana.EnterpriseSuperMicro,
+		PayloadID:   1,
+	}, payload)
+	if err := sess.DispatchOEMPayload(p); !errors.Is(err, bmc.ErrUnhandledPayload) {
+		t.Errorf("DispatchOEMPayload() = %v, want %v", err,
+			bmc.ErrUnhandledPayload)
+	}
+
+	// replays are rejected
+	if err := sess.DispatchOEMPayload(p); errors.Is(err, bmc.ErrUnhandledPayload) {
+		t.Errorf("DispatchOEMPayload() accepted a replayed packet")
+	}
+
+	// commands are unaffected
+	if _, err := sess.GetDeviceID(ctx); err != nil {
+		t.Errorf("GetDeviceID() failed: %v", err)
+	}
+}
+
+// testFirmware is returned by testOEM's FirmwareInventory().
+var testFirmware = []bmc.FirmwareComponent{
+	{Name: "BMC", Version: "1.2"},
+	{Name: "UEFI", Version: "2.40"},
+}
+
+// testOEM is a fake OEM implementing virtual media, recording the image
+// attached, reporting a shared management NIC, and listing its firmware.
+type testOEM struct {
+	url string
+}
+
+func (*testOEM) Name() string {
+	return "Test"
+}
+
+func (*testOEM) FirmwareInventory(context.Context, bmc.Session) ([]bmc.FirmwareComponent, error) {
+	return testFirmware, nil
+}
+
+func (*testOEM) ManagementNIC(context.Context, bmc.Session) (bmc.NICMode, error) {
+	return bmc.NICModeShared, nil
+}
+
+func (m *testOEM) AttachMedia(ctx context.Context, s bmc.Session, u *url.URL) error {
+	// check the session is usable from within an implementation
+	if _, err := s.GetDeviceID(ctx); err != nil {
+		return err
+	}
+	m.url = u.String()
+	return nil
+}
+
+func (m *testOEM) DetachMedia(context.Context, bmc.Session) error {
+	m.url = ""
+	return nil
+}
+
+func TestOEM(t *testing.T) {
+	// not assigned by IANA, so not registered by any package
+	const manufacturer = iana.Enterprise(0xfffffe)
+	oem := &testOEM{}
+	bmc.RegisterOEM(manufacturer, oem)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session := func(manufacturer iana.Enterprise) *bmc.V2Session {
+		t.Helper()
+		_, sess := newTestSession(t, &Config{
+			Manufacturer: manufacturer,
+		})
+		return sess
+	}
+
+	sess := session(manufacturer)
+	if err := sess.AttachMedia(ctx, "install.iso"); err == nil {
+		t.Errorf("AttachMedia() accepted a relative URL")
+	}
+	const image = "nfs://192.0.2.1/srv/install.iso"
+	if err := sess.AttachMedia(ctx, image); err != nil {
+		t.Fatalf("AttachMedia() failed: %v", err)
+	}
+	if oem.url != image {
+		t.Errorf("attached %q, want %q", oem.url, image)
+	}
+	if err := sess.DetachMedia(ctx); err != nil {
+		t.Fatalf("DetachMedia() failed: %v", err)
+	}
+	if oem.url != "" {
+		t.Errorf("%q still attached after DetachMedia()", oem.url)
+	}
+	if mode, err := sess.ManagementNIC(ctx); err != nil || mode != bmc.NICModeShared {
+		t.Errorf("ManagementNIC() = %v, %v, want %v", mode, err,
+			bmc.NICModeShared)
+	}
+	if _, err := sess.LCDText(ctx); !errors.Is(err, bmc.ErrOEMUnsupported) {
+		t.Errorf("LCDText() = %v, want %v", err, bmc.ErrOEMUnsupported)
+	}
+	inventory, err := bmc.Inventory(ctx, sess)
+	if err != nil {
+		t.Fatalf("Inventory() failed: %v", err)
+	}
+	if diff := cmp.Diff(testFirmware, inventory.Firmware); diff != "" {
+		t.Errorf("Inventory() firmware differs: %v", diff)
+	}
+
+	sess = session(iana.EnterpriseReserved)
+	if err := sess.AttachMedia(ctx, image); !errors.Is(err, bmc.ErrOEMUnsupported) {
+		t.Errorf("AttachMedia() = %v, want %v", err, bmc.ErrOEMUnsupported)
+	}
+}
+
+func TestDellLCD(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		Manufacturer: iana.EnterpriseDell,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// spans several blocks
+	for _, text := range []string{"rack 12, unit 30: db-primary-01", "R740"} {
+		if err := sess.SetLCDText(ctx, text); err != nil {
+			t.Fatalf("SetLCDText(%q) failed: %v", text, err)
+		}
+		got, err := sess.LCDText(ctx)
+		if err != nil {
+			t.Fatalf("LCDText() failed: %v", err)
+		}
+		if got != text {
+			t.Errorf("LCDText() = %q, want %q", got, text)
+		}
+	}
+	if err := dell.SetLCDText(ctx, sess, strings.Repeat("x",
+		dell.MaxLCDTextLength+1)); err == nil {
+		t.Errorf("SetLCDText() accepted text longer than %v characters",
+			dell.MaxLCDTextLength)
+	}
+
+	if err := bmc.Identify(ctx, sess, 15*time.Second); err != nil {
+		t.Errorf("Identify() failed: %v", err)
+	}
+	if err := bmc.Identify(ctx, sess, time.Hour); err == nil {
+		t.Errorf("Identify() accepted an interval over 255 seconds")
+	}
+}
+
+func TestBootFlags(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	flags, err := bmc.GetBootFlags(ctx, sess)
+	if err != nil {
+		t.Fatalf("GetBootFlags() failed: %v", err)
+	}
+	if flags.Valid {
+		t.Errorf("GetBootFlags() = %v, want invalid flags initially", flags)
+	}
+
+	if err := bmc.SetBootFlags(ctx, sess, &ipmi.BootFlags{
+		EFI:    true,
+		Device: ipmi.BootDevicePXE,
+	}); err != nil {
+		t.Fatalf("SetBootFlags() failed: %v", err)
+	}
+	flags, err = bmc.GetBootFlags(ctx, sess)
+	if err != nil {
+		t.Fatalf("GetBootFlags() failed: %v", err)
+	}
+	if !flags.Valid || flags.Persistent || !flags.EFI ||
+		flags.Device != ipmi.BootDevicePXE {
+		t.Errorf("GetBootFlags() = %v, want valid, one-time, EFI PXE boot",
+			flags)
+	}
+}
+
+func TestWatchdogTimer(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := bmc.ResetWatchdogTimer(ctx, sess); !errors.Is(err,
+		bmc.ErrWatchdogNotInitialised) {
+		t.Fatalf("ResetWatchdogTimer() = %v, want %v", err,
+			bmc.ErrWatchdogNotInitialised)
+	}
+
+	if err := bmc.SetWatchdogTimer(ctx, sess, &ipmi.SetWatchdogTimerReq{
+		TimerUse:         ipmi.WatchdogTimerUseSMSOS,
+		TimeoutAction:    ipmi.WatchdogTimeoutActionHardReset,
+		InitialCountdown: bmc.WatchdogCountdown(time.Minute),
+	}); err != nil {
+		t.Fatalf("SetWatchdogTimer() failed: %v", err)
+	}
+	timer, err := bmc.GetWatchdogTimer(ctx, sess)
+	if err != nil {
+		t.Fatalf("GetWatchdogTimer() failed: %v", err)
+	}
+	if timer.Running || timer.TimerUse != ipmi.WatchdogTimerUseSMSOS ||
+		timer.TimeoutAction != ipmi.WatchdogTimeoutActionHardReset ||
+		timer.InitialCountdown != 600 {
+		t.Errorf("GetWatchdogTimer() = %+v, want stopped SMS/OS hard reset "+
+			"timer with initial countdown 600", timer)
+	}
+
+	if err := bmc.ResetWatchdogTimer(ctx, sess); err != nil {
+		t.Fatalf("ResetWatchdogTimer() failed: %v", err)
+	}
+	timer, err = bmc.GetWatchdogTimer(ctx, sess)
+	if err != nil {
+		t.Fatalf("GetWatchdogTimer() failed: %v", err)
+	}
+	if !timer.Running || timer.PresentCountdown > timer.InitialCountdown {
+		t.Errorf("GetWatchdogTimer() = %+v, want running timer", timer)
+	}
+}
+
+func TestDCMIPowerLimit(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{
+		Power: 250,
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	commander := dcmi.NewSessionCommander(sess)
+
+	reading, err := commander.GetPowerReading(ctx, &dcmi.GetPowerReadingReq{
+		Mode: dcmi.SystemPowerStatisticsModeNormal,
+	})
+	if err != nil {
+		t.Fatalf("GetPowerReading() failed: %v", err)
+	}
+	if reading.Instantaneous != 250 {
+		t.Errorf("GetPowerReading() instantaneous = %v, want 250",
+			reading.Instantaneous)
+	}
+
+	want := &dcmi.SetPowerLimitReq{
+		ExceptionAction: dcmi.ExceptionActionLogEvent,
+		Limit:           200,
+		CorrectionTime:  time.Second * 2,
+		SamplingPeriod:  time.Second * 5,
+	}
+	if err := commander.SetPowerLimit(ctx, want); err != nil {
+		t.Fatalf("SetPowerLimit() failed: %v", err)
+	}
+	limit, active, err := commander.GetPowerLimit(ctx)
+	if err != nil {
+		t.Fatalf("GetPowerLimit() failed: %v", err)
+	}
+	if active {
+		t.Errorf("GetPowerLimit() active before activation")
+	}
+	if limit.ExceptionAction != want.ExceptionAction ||
+		limit.Limit != want.Limit ||
+		limit.CorrectionTime != want.CorrectionTime ||
+		limit.SamplingPeriod != want.SamplingPeriod {
+		t.Errorf("GetPowerLimit() = %+v, want %+v", limit, want)
+	}
+
+	if err := commander.ActivateDeactivatePowerLimit(ctx,
+		&dcmi.ActivateDeactivatePowerLimitReq{
+			Activate: true,
+		}); err != nil {
+		t.Fatalf("ActivateDeactivatePowerLimit() failed: %v", err)
+	}
+	if _, active, err = commander.GetPowerLimit(ctx); err != nil {
+		t.Fatalf("GetPowerLimit() failed: %v", err)
+	}
+	if !active {
+		t.Errorf("GetPowerLimit() inactive after activation")
+	}
+}
+
+func TestRetrieveFRUDeviceLocators(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{
+		SDRs: [][]byte{
+			FullSensorRecord(1, 10, "Inlet Temp"),
+			FRUDeviceLocatorRecord(2, 1, "PSU1"),
+			FRUDeviceLocatorRecord(3, 2, "PSU2"),
+		},
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	records, err := bmc.RetrieveFRUDeviceLocators(ctx, sess)
+	if err != nil {
+		t.Fatalf("RetrieveFRUDeviceLocators() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("RetrieveFRUDeviceLocators() returned %v records, want 2",
+			len(records))
+	}
+	for i, want := range []string{"PSU1", "PSU2"} {
+		record := records[i]
+		if record.Identity != want || record.DeviceID != uint8(i+1) ||
+			!record.Logical ||
+			record.DeviceAccessAddress != ipmi.SlaveAddressBMC {
+			t.Errorf("record %v = %+v, want logical device %v (%v) on the BMC",
+				i, record, i+1, want)
+		}
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		MACAddress: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	policy := ipmi.PowerRestorePolicyPriorState
+	enabled := true
+	bitRate := ipmi.SOLBitRate115200
+	config := &bmc.Config{
+		PowerRestorePolicy: &policy,
+		Users: []*bmc.UserConfig{
+			{
+				// already matches
+				ID:             2,
+				Name:           "admin",
+				Password:       "hunter2",
+				Enabled:        &enabled,
+				PrivilegeLimit: ipmi.PrivilegeLevelAdministrator,
+				Channel:        1,
+			},
+			{
+				ID:             3,
+				Name:           "operator",
+				Password:       "s3cret",
+				Enabled:        &enabled,
+				PrivilegeLimit: ipmi.PrivilegeLevelOperator,
+				Channel:        1,
+			},
+		},
+		SOL: &bmc.SOLConfig{
+			Channel: 1,
+			Enabled: &enabled,
+			BitRate: &bitRate,
+		},
+		LAN: &bmc.LANConfig{
+			Channel:         1,
+			IPAddressSource: ipmi.IPAddressSourceStatic,
+			IPAddress:       net.IPv4(192, 0, 2, 10),
+			SubnetMask:      net.IPv4(255, 255, 255, 0),
+		},
+	}
+
+	changes, err := bmc.DiffConfig(ctx, sess, config)
+	if err != nil {
+		t.Fatalf("DiffConfig() failed: %v", err)
+	}
+	want := []string{
+		"power restore policy: 0(Remain off) -> 1(Return to prior state)",
+		`user 3 name: "" -> "operator"`,
+		"user 3 password: (redacted) -> (redacted)",
+		"user 3 status: 2(Disabled) -> 1(Enabled)",
+		"user 3 channel 1 privilege limit: No Access -> Operator",
+		"SOL enabled: false -> true",
+		"LAN IP address: 0.0.0.0 -> 192.0.2.10",
+		"LAN subnet mask: 0.0.0.0 -> 255.255.255.0",
+	}
+	got := []string{}
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffConfig() mismatch (-want +got):\n%v", diff)
+	}
+
+	applied, err := bmc.ApplyConfig(ctx, sess, config)
+	if err != nil {
+		t.Fatalf("ApplyConfig() failed: %v", err)
+	}
+	if len(applied) != len(want) {
+		t.Errorf("ApplyConfig() made %v changes, want %v", len(applied),
+			len(want))
+	}
+	applied, err = bmc.ApplyConfig(ctx, sess, config)
+	if err != nil {
+		t.Fatalf("second ApplyConfig() failed: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("second ApplyConfig() made changes %v, want none", applied)
+	}
+
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if status.PowerRestorePolicy != policy {
+		t.Errorf("power restore policy = %v, want %v",
+			status.PowerRestorePolicy, policy)
+	}
+}
+
+func TestExportConfig(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// connect returns an administrator session with a new simulated BMC
+	connect := func() bmc.Session {
+		_, sess := newTestSession(t, &Config{})
+		return sess
+	}
+
+	source := connect()
+	policy := ipmi.PowerRestorePolicyPowerOn
+	enabled := true
+	actions := ipmi.PEFActionAlert | ipmi.PEFActionPowerCycle
+	if _, err := bmc.ApplyConfig(ctx, source, &bmc.Config{
+		PowerRestorePolicy: &policy,
+		Users: []*bmc.UserConfig{
+			{
+				ID:             3,
+				Name:           "operator",
+				Password:       "s3cret",
+				Enabled:        &enabled,
+				PrivilegeLimit: ipmi.PrivilegeLevelOperator,
+				Channel:        1,
+			},
+		},
+		PEF: &bmc.PEFConfig{
+			Actions: &actions,
+		},
+		Boot: &bmc.BootConfig{
+			Device: ipmi.BootDevicePXE,
+			EFI:    true,
+		},
+		SOL: &bmc.SOLConfig{
+			Channel: 1,
+			Enabled: &enabled,
+		},
+		LAN: &bmc.LANConfig{
+			Channel:        1,
+			IPAddress:      net.IPv4(192, 0, 2, 10),
+			SubnetMask:     net.IPv4(255, 255, 255, 0),
+			DefaultGateway: net.IPv4(192, 0, 2, 1),
+		},
+	}); err != nil {
+		t.Fatalf("ApplyConfig() failed: %v", err)
+	}
+
+	exported, err := bmc.ExportConfig(ctx, source)
+	if err != nil {
+		t.Fatalf("ExportConfig() failed: %v", err)
+	}
+	if exported.PowerRestorePolicy == nil || *exported.PowerRestorePolicy != policy {
+		t.Errorf("exported power restore policy = %v, want %v",
+			exported.PowerRestorePolicy, policy)
+	}
+	if len(exported.Users) != 4 || exported.Users[2].Name != "operator" ||
+		exported.Users[2].Password != "" {
+		t.Errorf("exported users = %+v, want 4 including operator without "+
+			"a password", exported.Users)
+	}
+
+	// restore onto a BMC with the default configuration, via JSON
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &bmc.Config{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	target := connect()
+	if _, err := bmc.ApplyConfig(ctx, target, snapshot); err != nil {
+		t.Fatalf("ApplyConfig() of snapshot failed: %v", err)
+	}
+	restored, err := bmc.ExportConfig(ctx, target)
+	if err != nil {
+		t.Fatalf("ExportConfig() failed: %v", err)
+	}
+	if diff := cmp.Diff(exported, restored); diff != "" {
+		t.Errorf("restored config mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{
+		SDRs: [][]byte{
+			FullSensorRecord(1, 10, "Inlet Temp"),
+			FullSensorRecord(2, 11, "CPU Temp"),
+			FullSensorRecord(3, 12, "DIMM Temp"),
+		},
+		Readings: map[uint8]uint8{10: 38, 11: 45},
+		States: map[uint8]uint8{
+			10: uint8(ipmi.ThresholdStatusUpperNonCritical),
+		},
+		SEL: [][]byte{
+			ThresholdEventRecord(1, 11, 0x9, false),
+			SystemEventRecord(2, ipmi.SensorTypeMemory, 3, 0x5),
+			ThresholdEventRecord(3, 12, 0x9, false),
+			ThresholdEventRecord(4, 0x30, 0x1, false),
+			ThresholdEventRecord(5, 11, 0x9, true),
+		},
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report, err := bmc.Health(ctx, sess)
+	if err != nil {
+		t.Fatalf("Health() failed: %v", err)
+	}
+	want := &bmc.HealthReport{
+		Status: bmc.HealthStatusCritical,
+		Reasons: []bmc.HealthReason{
+			{
+				Status: bmc.HealthStatusWarning,
+				Description: "sensor Inlet Temp is at or beyond " +
+					"[Upper Non-Critical]",
+			},
+			{
+				Status: bmc.HealthStatusCritical,
+				Description: "SEL record 3: sensor DIMM Temp crossed " +
+					"[Upper Critical] without recovering",
+			},
+			{
+				Status: bmc.HealthStatusWarning,
+				Description: "SEL record 4: sensor 0x30 crossed " +
+					"[Lower Non-Critical] without recovering",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, report); diff != "" {
+		t.Errorf("Health() = %v, want %v: %v", report, want, diff)
+	}
+}
+
+func TestChassisIntrusion(t *testing.T) {
+	_, sess := newTestSession(t, &Config{
+		SDRs: [][]byte{
+			FullSensorRecord(1, 10, "Inlet Temp"),
+			DiscreteSensorRecord(2, 0x73, ipmi.SensorTypePhysicalSecurity,
+				"Chassis Intru"),
+		},
+		Readings:                 map[uint8]uint8{10: 21, 0x73: 0},
+		States:                   map[uint8]uint8{0x73: 0x01},
+		Intrusion:                true,
+		FrontPanelDisableAllowed: 0x3, // reset and power off
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the sensor does not auto re-arm, so the intrusion is latched until
+	// re-armed
+	for _, rearm := range []bool{false, true} {
+		intrusion, err := bmc.GetChassisIntrusion(ctx, sess,
+			&bmc.IntrusionOpts{
+				Rearm: rearm,
+			})
+		if err != nil {
+			t.Fatalf("GetChassisIntrusion() failed: %v", err)
+		}
+		want := &bmc.ChassisIntrusion{
+			Active: true,
+			Sensors: []bmc.IntrusionSensor{
+				{
+					Name:      "Chassis Intru",
+					Number:    0x73,
+					Intrusion: true,
+				},
+			},
+		}
+		if diff := cmp.Diff(want, intrusion); diff != "" {
+			t.Errorf("GetChassisIntrusion() with rearm %v = %+v, want %+v: %v",
+				rearm, intrusion, want, diff)
+		}
+	}
+	intrusion, err := bmc.GetChassisIntrusion(ctx, sess, nil)
+	if err != nil {
+		t.Fatalf("GetChassisIntrusion() failed: %v", err)
+	}
+	if len(intrusion.Sensors) != 1 || intrusion.Sensors[0].Intrusion {
+		t.Errorf("GetChassisIntrusion() after re-arm = %+v, want no "+
+			"sensor intrusion", intrusion.Sensors)
+	}
+
+	err = bmc.SetFrontPanelEnables(ctx, sess, &ipmi.SetFrontPanelEnablesReq{
+		DisableStandbyButton: true,
+	})
+	if err != bmc.ErrFrontPanelButtonDisableUnsupported {
+		t.Errorf("SetFrontPanelEnables() for standby = %v, want %v", err,
+			bmc.ErrFrontPanelButtonDisableUnsupported)
+	}
+	if err := bmc.SetFrontPanelEnables(ctx, sess, &ipmi.SetFrontPanelEnablesReq{
+		DisablePowerOffButton: true,
+	}); err != nil {
+		t.Fatalf("SetFrontPanelEnables() failed: %v", err)
+	}
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if !status.PowerOffButtonDisabled || status.ResetButtonDisabled ||
+		!status.ResetButtonDisableAllowed || status.StandbyButtonDisableAllowed {
+		t.Errorf("GetChassisStatus() = %+v, want only power off disabled",
+			status)
+	}
+}
+
+func TestLockFrontPanel(t *testing.T) {
+	tests := []struct {
+		name   string
+		ignore bool
+	}{
+		{"applied", false},
+		{"ignored", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, sess := newTestSession(t, &Config{
+				FrontPanelDisableAllowed: 0x3, // reset and power off
+				IgnoreFrontPanelEnables:  test.ignore,
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if test.ignore {
+				if err := bmc.LockFrontPanel(ctx, sess, true); err != bmc.ErrFrontPanelLockoutNotApplied {
+					t.Errorf("LockFrontPanel() on BMC ignoring the change = %v, "+
+						"want %v", err, bmc.ErrFrontPanelLockoutNotApplied)
+				}
+				return
+			}
+
+			// stop advertising lockout, so it is refused
+			caps, err := sess.GetChassisCapabilities(ctx)
+			if err != nil {
+				t.Fatalf("GetChassisCapabilities() failed: %v", err)
+			}
+			if !caps.ProvidesFrontPanelLockout {
+				t.Errorf("GetChassisCapabilities() = %+v, want front panel lockout",
+					caps)
+			}
+			cmd := &ipmi.SetChassisCapabilitiesCmd{
+				Req: ipmi.SetChassisCapabilitiesReq{
+					ProvidesIntrusionSensor:       caps.ProvidesIntrusionSensor,
+					FRUInfoDeviceAddress:          caps.FRUInfoDeviceAddress,
+					SDRDeviceAddress:              caps.SDRDeviceAddress,
+					SELDeviceAddress:              caps.SELDeviceAddress,
+					SystemManagementDeviceAddress: caps.SystemManagementDeviceAddress,
+					BridgeDeviceAddress:           caps.BridgeDeviceAddress,
+				},
+			}
+			if err := bmc.ValidateResponse(sess.SendCommand(ctx, cmd)); err != nil {
+				t.Fatalf("Set Chassis Capabilities failed: %v", err)
+			}
+			if err := bmc.LockFrontPanel(ctx, sess, true); err != bmc.ErrFrontPanelButtonDisableUnsupported {
+				t.Errorf("LockFrontPanel() without lockout capability = %v, want %v",
+					err, bmc.ErrFrontPanelButtonDisableUnsupported)
+			}
+
+			cmd.Req.ProvidesFrontPanelLockout = true
+			if err := bmc.ValidateResponse(sess.SendCommand(ctx, cmd)); err != nil {
+				t.Fatalf("Set Chassis Capabilities failed: %v", err)
+			}
+			for _, lock := range []bool{true, false} {
+				if err := bmc.LockFrontPanel(ctx, sess, lock); err != nil {
+					t.Fatalf("LockFrontPanel(%v) failed: %v", lock, err)
+				}
+				status, err := sess.GetChassisStatus(ctx)
+				if err != nil {
+					t.Fatalf("GetChassisStatus() failed: %v", err)
+				}
+				if status.PowerOffButtonDisabled != lock ||
+					status.ResetButtonDisabled != lock {
+					t.Errorf("GetChassisStatus() after LockFrontPanel(%v) = %+v, "+
+						"want power off and reset disabled %v", lock, status, lock)
+				}
+			}
+		})
+	}
+}
+
+func TestLANStatistics(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &Config{}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := bmc.ClearLANStatistics(ctx, sess, ipmi.ChannelPresentInterface)
+	if err != nil {
+		t.Fatalf("ClearLANStatistics() failed: %v", err)
+	}
+	// at least session establishment and this command
+	if stats.UDPPacketsReceived < 4 ||
+		stats.ValidRMCPPacketsReceived != stats.UDPPacketsReceived ||
+		stats.IPPacketsTransmitted != stats.UDPPacketsReceived-1 {
+		t.Errorf("ClearLANStatistics() = %+v, want at least 4 packets "+
+			"received, all valid, and all but the last answered", stats)
+	}
+
+	stats, err = bmc.GetLANStatistics(ctx, sess, 1)
+	if err != nil {
+		t.Fatalf("GetLANStatistics() failed: %v", err)
+	}
+	// the clear response and this command
+	want := &ipmi.GetIPUDPRMCPStatisticsRsp{
+		IPPacketsReceived:        1,
+		IPPacketsTransmitted:     1,
+		UDPPacketsReceived:       1,
+		ValidRMCPPacketsReceived: 1,
+	}
+	if diff := cmp.Diff(want, stats); diff != "" {
+		t.Errorf("GetLANStatistics() after clear = %+v, want %+v: %v",
+			stats, want, diff)
+	}
+
+	if _, err := bmc.GetLANStatistics(ctx, sess, 2); err == nil {
+		t.Errorf("GetLANStatistics() of non-LAN channel succeeded")
+	}
+}
+
+func TestARPControl(t *testing.T) {
+	_, sess := newTestSession(t, &Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config, err := bmc.GetARPConfig(ctx, sess, 1)
+	if err != nil {
+		t.Fatalf("GetARPConfig() failed: %v", err)
+	}
+	want := &bmc.ARPConfig{
+		GratuitousARPs:        true,
+		ARPResponses:          true,
+		GratuitousARPInterval: 2 * time.Second,
+	}
+	if diff := cmp.Diff(want, config); diff != "" {
+		t.Errorf("GetARPConfig() = %+v, want %+v: %v", config, want, diff)
+	}
+
+	status, err := bmc.SuspendARPs(ctx, sess, &ipmi.SuspendBMCARPsReq{
+		Channel:               1,
+		SuspendGratuitousARPs: true,
+	})
+	if err != nil {
+		t.Fatalf("SuspendARPs() failed: %v", err)
+	}
+	if status.GratuitousARPsOccurring || !status.ARPResponsesOccurring {
+		t.Errorf("SuspendARPs() = %+v, want only ARP responses", status)
+	}
+
+	want = &bmc.ARPConfig{
+		ARPResponses:          true,
+		GratuitousARPInterval: 10 * time.Second,
+	}
+	if err := bmc.SetARPConfig(ctx, sess, 1, want); err != nil {
+		t.Fatalf("SetARPConfig() failed: %v", err)
+	}
+	config, err = bmc.GetARPConfig(ctx, sess, 1)
+	if err != nil {
+		t.Fatalf("GetARPConfig() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, config); diff != "" {
+		t.Errorf("GetARPConfig() after set = %+v, want %+v: %v", config,
+			want, diff)
+	}
+
+	// resuming does not enable ARPs that are disabled
+	status, err = bmc.SuspendARPs(ctx, sess, &ipmi.SuspendBMCARPsReq{
+		Channel: 1,
+	})
+	if err != nil {
+		t.Fatalf("SuspendARPs() failed: %v", err)
+	}
+	if status.GratuitousARPsOccurring || !status.ARPResponsesOccurring {
+		t.Errorf("SuspendARPs() to resume = %+v, want only ARP responses",
+			status)
+	}
+
+	if err := bmc.SetARPConfig(ctx, sess, 1, &bmc.ARPConfig{
+		GratuitousARPInterval: 128 * time.Second,
+	}); err == nil {
+		t.Errorf("SetARPConfig() with interval too long succeeded")
+	}
+}
+
+func TestSensorReadingPrecision(t *testing.T) {
+	record := FullSensorRecord(1, 10, "Inlet Temp")
+	body := record[5:]
+	body[20] = 4    // tolerance ±2 raw counts
+	body[22] = 50   // accuracy 0.5%
+	body[24] = 0xf0 // RExp -1
+	_, sess := newTestSessionWithOpts(t, &Config{
+		SDRs:     [][]byte{record},
+		Readings: map[uint8]uint8{10: 215},
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo, err := bmc.RetrieveSDRRepository(ctx, sess)
+	if err != nil {
+		t.Fatalf("RetrieveSDRRepository() failed: %v", err)
+	}
+	if len(repo) != 1 {
+		t.Fatalf("RetrieveSDRRepository() = %v, want 1 record", repo)
+	}
+	for _, fsr := range repo {
+		reader, err := bmc.NewSensorReader(fsr)
+		if err != nil {
+			t.Fatalf("NewSensorReader() failed: %v", err)
+		}
+		reading, err := reader.(bmc.DetailedSensorReader).ReadDetailed(ctx,
+			sess)
+		if err != nil {
+			t.Fatalf("ReadDetailed() failed: %v", err)
+		}
+		// round away floating point error
+		got := *reading
+		for _, f := range []*float64{&got.Value, &got.Tolerance,
+			&got.Resolution, &got.AccuracyPercentage} {
+			*f = math.Round(*f*1000) / 1000
+		}
+		want := bmc.SensorReading{
+			Value:              21.5,
+			Tolerance:          0.2,
+			Resolution:         0.1,
+			AccuracyPercentage: 0.5,
+		}
+		if got != want {
+			t.Errorf("ReadDetailed() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestNonLinearSensorReader(t *testing.T) {
+	low := FullSensorRecord(1, 10, "Low")
+	low[5+18] = uint8(ipmi.LinearisationNonLinear)
+	high := FullSensorRecord(2, 11, "High")
+	high[5+18] = uint8(ipmi.LinearisationNonLinear)
+	var factorsRequests int32
+	factors := func(reading uint8) []byte {
+		atomic.AddInt32(&factorsRequests, 1)
+		if reading < 128 {
+			// M 2, tolerance ±1 raw count, applying up to 127
+			return []byte{128, 2, 2, 0, 0, 0, 0}
+		}
+		// M 3, accuracy 1%
+		return []byte{0, 3, 0, 0, 0x24, 0x10, 0}
+	}
+	_, sess := newTestSessionWithOpts(t, &Config{
+		SDRs:     [][]byte{low, high},
+		Readings: map[uint8]uint8{10: 100, 11: 200},
+		ReadingFactors: map[uint8]func(uint8) []byte{
+			10: factors,
+			11: factors,
+		},
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo, err := bmc.RetrieveSDRRepository(ctx, sess)
+	if err != nil {
+		t.Fatalf("RetrieveSDRRepository() failed: %v", err)
+	}
+	want := map[uint8]bmc.SensorReading{
+		10: {
+			Value:      200,
+			Tolerance:  2,
+			Resolution: 2,
+		},
+		11: {
+			Value:              600,
+			Resolution:         3,
+			AccuracyPercentage: 1,
+		},
+	}
+	for _, fsr := range repo {
+		reader, err := bmc.NewSensorReader(fsr)
+		if err != nil {
+			t.Fatalf("NewSensorReader() failed: %v", err)
+		}
+		// the second read should use cached factors
+		for i := 0; i < 2; i++ {
+			reading, err := reader.(bmc.DetailedSensorReader).ReadDetailed(ctx,
+				sess)
+			if err != nil {
+				t.Fatalf("ReadDetailed() failed: %v", err)
+			}
+			if *reading != want[fsr.Number] {
+				t.Errorf("sensor %v ReadDetailed() = %+v, want %+v",
+					fsr.Number, *reading, want[fsr.Number])
+			}
+		}
+	}
+	if n := atomic.LoadInt32(&factorsRequests); n != 2 {
+		t.Errorf("sent %v Get Sensor Reading Factors commands, want 2", n)
+	}
+}
+
+func TestVerifySDRRepository(t *testing.T) {
+	tests := []struct {
+		name string
+		sdrs [][]byte
+		want []ipmi.RecordID
+	}{
+		{
+			"consistent",
+			[][]byte{
+				FullSensorRecord(1, 10, "Inlet Temp"),
+				FullSensorRecord(2, 11, "Exhaust Temp"),
+			},
+			nil,
+		},
+		{
+			// the second record's next record ID points to itself, which
+			// would otherwise cause enumeration to continue forever
+			"loop",
+			[][]byte{
+				FullSensorRecord(1, 10, "Inlet Temp"),
+				FullSensorRecord(2, 11, "Exhaust Temp"),
+				FullSensorRecord(2, 12, "PSU Temp"),
+			},
+			[]ipmi.RecordID{2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, sess := newTestSessionWithOpts(t, &Config{
+				SDRs: test.sdrs,
+			}, &bmc.V2SessionOpts{
+				SessionOpts: bmc.SessionOpts{
+					MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+				},
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				5*time.Second)
+			defer cancel()
+
+			problems, err := bmc.VerifySDRRepository(ctx, sess)
+			if err != nil {
+				t.Fatalf("VerifySDRRepository() failed: %v", err)
+			}
+			var got []ipmi.RecordID
+			for _, problem := range problems {
+				got = append(got, problem.RecordID)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("VerifySDRRepository() = %v, mismatch (-want "+
+					"+got):\n%v", problems, diff)
+			}
+		})
+	}
+}
+
+func TestSensorFilter(t *testing.T) {
+	cpu0 := FullSensorRecord(2, 11, "CPU0 Temp")
+	cpu0[5+3] = uint8(ipmi.EntityIDProcessor)
+	cpu0[5+4] = 1
+	cpu1 := FullSensorRecord(3, 12, "CPU1 Temp")
+	cpu1[5+3] = uint8(ipmi.EntityIDProcessor)
+	cpu1[5+4] = 2
+	fan := FullSensorRecord(4, 13, "Fan1")
+	fan[5+7] = uint8(ipmi.SensorTypeFan)
+	fan[5+1] = 1 // LUN 1
+	_, sess := newTestSessionWithOpts(t, &Config{
+		SDRs: [][]byte{
+			FullSensorRecord(1, 10, "Inlet Temp"),
+			cpu0,
+			cpu1,
+			fan,
+			FRUDeviceLocatorRecord(5, 1, "PSU1"),
+		},
+	}, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		filter *bmc.SensorFilter
+		want   []uint8
+	}{
+		{&bmc.SensorFilter{}, []uint8{10, 11, 12, 13}},
+		{
+			&bmc.SensorFilter{
+				SensorTypes: []ipmi.SensorType{ipmi.SensorTypeTemperature},
+			},
+			[]uint8{10, 11, 12},
+		},
+		{
+			&bmc.SensorFilter{
+				Entities:  []ipmi.EntityID{ipmi.EntityIDProcessor},
+				Instances: []ipmi.EntityInstance{2},
+			},
+			[]uint8{12},
+		},
+		{
+			&bmc.SensorFilter{
+				OwnerAddresses: []ipmi.Address{
+					ipmi.SlaveAddressBMC.Address(),
+				},
+				OwnerLUNs: []ipmi.LUN{1},
+			},
+			[]uint8{13},
+		},
+		{
+			&bmc.SensorFilter{
+				Name: regexp.MustCompile(`^CPU\d`),
+			},
+			[]uint8{11, 12},
+		},
+		{
+			&bmc.SensorFilter{
+				SensorTypes: []ipmi.SensorType{ipmi.SensorTypeFan},
+				Name:        regexp.MustCompile(`CPU`),
+			},
+			nil,
+		},
+	}
+	for _, test := range tests {
+		repo, err := bmc.RetrieveFilteredSDRRepository(ctx, sess, test.filter)
+		if err != nil {
+			t.Fatalf("RetrieveFilteredSDRRepository(%+v) failed: %v",
+				test.filter, err)
+		}
+		var got []uint8
+		for _, fsr := range repo {
+			got = append(got, fsr.Number)
+		}
+		sort.Slice(got, func(i, j int) bool {
+			return got[i] < got[j]
+		})
+		if !cmp.Equal(got, test.want) {
+			t.Errorf("RetrieveFilteredSDRRepository(%+v) returned sensors "+
+				"%v, want %v", test.filter, got, test.want)
+		}
+	}
+}
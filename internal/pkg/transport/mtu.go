@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	// ethernetMTU is assumed for the path beyond the local interface, which
+	// cannot be discovered without sending packets. Interfaces supporting
+	// jumbo frames are common inside data centres, but rarely reach BMCs.
+	ethernetMTU = 1500
+
+	ipv4HeaderSize = 20
+	ipv6HeaderSize = 40
+	udpHeaderSize  = 8
+)
+
+// MaxPayloadSize returns the largest UDP payload that can be sent to raddr
+// without IP fragmentation, based on the MTU of the interface packets to it
+// leave through, and the size of the IP header for its address family. The MTU
+// is capped at 1500 bytes, which is also used if the interface cannot be
+// determined, so the result is at most 1472 bytes for IPv4, and 1452 for IPv6.
+// Nothing is sent.
+func MaxPayloadSize(raddr *net.UDPAddr) int {
+	headers := ipv4HeaderSize + udpHeaderSize
+	if raddr.IP.To4() == nil {
+		headers = ipv6HeaderSize + udpHeaderSize
+	}
+	mtu := ethernetMTU
+	if ifaceMTU, err := interfaceMTU(raddr); err == nil && ifaceMTU < mtu {
+		mtu = ifaceMTU
+	}
+	return mtu - headers
+}
+
+// interfaceMTU returns the MTU of the interface the OS would route packets to
+// raddr through. Connecting a UDP socket chooses the route and local address
+// without sending anything.
+func interfaceMTU(raddr *net.UDPAddr) (int, error) {
+	conn, err := net.DialUDP("udp", nil, raddr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	local := conn.LocalAddr().(*net.UDPAddr).IP
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, err
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(local) {
+				return iface.MTU, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no interface has local address %v", local)
+}
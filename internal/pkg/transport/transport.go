@@ -4,8 +4,12 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,6 +51,14 @@ var (
 		Buckets: prometheus.ExponentialBuckets(22, 1.17, 10), // 90.38
 	})
 
+	addressChanges = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "address_changes_total",
+		Help: "The number of times a re-resolved hostname returned a new " +
+			"address, causing the transport to reconnect.",
+	})
+
 	responseLatency = promauto.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespace,
 		Subsystem: subsystem,
@@ -56,91 +68,321 @@ var (
 	})
 )
 
+const (
+	// reresolveUnansweredWrites is the number of consecutive writes without
+	// any packet being received in between, after which we suspect the BMC
+	// has moved, and re-resolve its hostname.
+	reresolveUnansweredWrites = 3
+
+	// minReresolveInterval is the minimum time between re-resolutions of a
+	// BMC's hostname, so a BMC that is simply down does not result in a DNS
+	// query for every packet.
+	minReresolveInterval = time.Second * 10
+)
+
+var (
+	// lookupIPAddr resolves hostnames. It is a variable so tests can control
+	// the addresses returned.
+	lookupIPAddr = net.DefaultResolver.LookupIPAddr
+)
+
 type transport struct {
-	conn *net.UDPConn
+	// lastWrite is the time of the last successful Write() in Unix
+	// nanoseconds, or 0 if a packet has been received since. It is used to
+	// observe response latency, and must be first for 64-bit alignment.
+	lastWrite int64
+
+	// unanswered is the number of consecutive writes without a packet being
+	// received in between.
+	unanswered int32
+
+	// stale is 1 if a network error, e.g. ICMP host unreachable, has
+	// occurred since the hostname was last resolved.
+	stale int32
+
+	// host and port are the original address, used to re-resolve the BMC's
+	// hostname. host is empty if an IP literal was provided, in which case
+	// the address never changes.
+	host, port string
+
+	// mu protects conn, closed and lastResolve. conn can be replaced by
+	// Write() while Receive() is blocked reading from the old one.
+	mu          sync.Mutex
+	conn        *net.UDPConn
+	closed      bool
+	lastResolve time.Time
 
 	// recvBuf is used for reading bytes off the wire. This means we do not
 	// allocate any memory in the hot path, but causes a race condition if the
-	// transport is used concurrently.
+	// transport is read from concurrently.
 	//
 	// Note that sending session-less commands alongside session-based ones, or
 	// creating multiple Sessions, requires synchronisation over both the
-	// sending and receiving of the response. To maximise throughout for a
-	// single BMC, you can open multiple Connections, however take care not to
-	// overwhelm the BMC - they are only recommended to have a packet buffer of
-	// length 2 (6.10.1, v2.0) and support 4 simultaneous sessions (6.12, v2.0).
+	// sending and receiving of the response; the bmc package does this by
+	// having a single goroutine read from the transport. To maximise
+	// throughput for a single BMC, you can open multiple Connections, however
+	// take care not to overwhelm the BMC - they are only recommended to have a
+	// packet buffer of length 2 (6.10.1, v2.0) and support 4 simultaneous
+	// sessions (6.12, v2.0).
 	recvBuf [512]byte
 }
 
 // New establishes a connection to a UDP endpoint. Most implementations should
-// defer a call to Close() immediately after the error check.
+// defer a call to Close() immediately after the error check. The context
+// bounds DNS resolution; if it expires, its error is returned.
 //
-// It is strongly recommended to use an IP address literal rather than hostname,
-// as the exporter only re-connects on error, so may hold onto the original
-// address long after DNS changes. If a hostname is passed, A records take
-// priority over AAAA to follow the Go design decision referenced in issue #35.
-// To force IPv6, hardcode the IP literal. We assume a BMC has a single address,
-// so no attempt is made to try successive A records if multiple ones are
-// returned.
-func New(addr string) (Transport, error) {
-	raddr, err := net.ResolveUDPAddr("udp", addr)
+// It is recommended to use an IP address literal rather than hostname where
+// possible. If a hostname is passed, it is re-resolved after network errors or
+// several consecutive unanswered writes, and the transport transparently
+// reconnects if the address has changed, as DHCP-addressed BMCs can move. A
+// records take priority over AAAA to follow the Go design decision referenced
+// in issue #35. To force IPv6, hardcode the IP literal. If multiple addresses
+// are returned, the first is used; to choose between them, use Resolve() and
+// Dial().
+func New(ctx context.Context, addr string) (Transport, error) {
+	raddrs, err := Resolve(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
+	return Dial(raddrs[0], addr)
+}
 
+// Dial is like New, but connects to raddr, which must be one of the addresses
+// returned by Resolve() for addr. addr is retained to re-resolve the hostname;
+// the transport continues using raddr for as long as it remains among the
+// hostname's addresses.
+func Dial(raddr *net.UDPAddr, addr string) (Transport, error) {
 	conn, err := net.DialUDP("udp", nil, raddr)
 	if err != nil {
 		return nil, err
 	}
-	return &transport{
-		conn: conn,
-	}, nil
+	t := &transport{
+		conn:        conn,
+		lastResolve: time.Now(),
+	}
+	if host, port, _ := net.SplitHostPort(addr); !isIPLiteral(host) {
+		t.host = host
+		t.port = port
+	}
+	return t, nil
+}
+
+// isIPLiteral returns whether the host part of an address is an IP address,
+// possibly with a zone, rather than a hostname.
+func isIPLiteral(host string) bool {
+	return net.ParseIP(strings.SplitN(host, "%", 2)[0]) != nil
 }
 
-// Address returns the remote IP:port of the endpoint.
+// resolve returns the preferred address of addr; see Resolve().
+func resolve(ctx context.Context, addr string) (*net.UDPAddr, error) {
+	raddrs, err := Resolve(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return raddrs[0], nil
+}
+
+// Resolve is the equivalent of net.ResolveUDPAddr(), but respects the context,
+// and returns every address of a hostname, IPv4 before IPv6, otherwise in the
+// order returned by the resolver. IP literals, including those with a zone, do
+// not require a lookup so are passed straight through. At least one address is
+// returned if the error is nil.
+func Resolve(ctx context.Context, addr string) ([]*net.UDPAddr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if isIPLiteral(host) {
+		raddr, err := net.ResolveUDPAddr("udp", addr)
+		if err != nil {
+			return nil, err
+		}
+		return []*net.UDPAddr{raddr}, nil
+	}
+
+	portNum, err := net.DefaultResolver.LookupPort(ctx, "udp", port)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := lookupIPAddr(ctx, host)
+	if err != nil {
+		if ctx.Err() != nil {
+			// surface this rather than an opaque DNS error
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no addresses found for %v", host)
+	}
+	raddrs := make([]*net.UDPAddr, 0, len(ips))
+	for _, v4 := range []bool{true, false} {
+		for _, ip := range ips {
+			if (ip.IP.To4() != nil) == v4 {
+				raddrs = append(raddrs, &net.UDPAddr{
+					IP:   ip.IP,
+					Port: portNum,
+					Zone: ip.Zone,
+				})
+			}
+		}
+	}
+	return raddrs, nil
+}
+
+// currentConn returns the socket currently in use.
+func (t *transport) currentConn() *net.UDPConn {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.conn
+}
+
+// maybeReresolve re-resolves the hostname if we suspect the BMC has moved,
+// replacing the socket if its address has changed. Failure to resolve is not
+// an error; we continue using the existing address.
+func (t *transport) maybeReresolve(ctx context.Context) {
+	if t.host == "" {
+		return
+	}
+	if atomic.LoadInt32(&t.stale) == 0 &&
+		atomic.LoadInt32(&t.unanswered) < reresolveUnansweredWrites {
+		return
+	}
+	t.mu.Lock()
+	if time.Since(t.lastResolve) < minReresolveInterval {
+		t.mu.Unlock()
+		return
+	}
+	t.lastResolve = time.Now()
+	t.mu.Unlock()
+
+	raddrs, err := Resolve(ctx, net.JoinHostPort(t.host, t.port))
+	if err != nil {
+		return
+	}
+	atomic.StoreInt32(&t.stale, 0)
+	atomic.StoreInt32(&t.unanswered, 0)
+	// the current address may not be the preferred one if chosen by the
+	// caller, so only move if the hostname no longer has it
+	current := t.currentConn().RemoteAddr().(*net.UDPAddr)
+	for _, raddr := range raddrs {
+		if raddr.IP.Equal(current.IP) && raddr.Port == current.Port &&
+			raddr.Zone == current.Zone {
+			return
+		}
+	}
+	raddr := raddrs[0]
+	conn, err := net.DialUDP("udp", nil, raddr)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		conn.Close()
+		return
+	}
+	old := t.conn
+	t.conn = conn
+	t.mu.Unlock()
+	// unblocks any Receive() in progress, which will move to the new socket
+	old.Close()
+	addressChanges.Inc()
+}
+
+// Address returns the remote IP:port of the endpoint. This can change if a
+// hostname was provided.
 func (t *transport) Address() net.Addr {
-	return t.conn.RemoteAddr()
+	return t.currentConn().RemoteAddr()
 }
 
 // Send sends the supplied data to the remote host, blocking until it receives a
 // reply packet, which is then returned. An error is returned if a transport
 // error occurs or the context expires.
 func (t *transport) Send(ctx context.Context, b []byte) ([]byte, error) {
-	// write
+	if err := t.Write(ctx, b); err != nil {
+		return nil, err
+	}
+	return t.Receive(ctx)
+}
+
+// Write sends the supplied data to the remote host without waiting for a
+// reply. An error is returned if a transport error occurs or the context
+// expires.
+func (t *transport) Write(ctx context.Context, b []byte) error {
+	t.maybeReresolve(ctx)
+	conn := t.currentConn()
 	if deadline, ok := ctx.Deadline(); ok {
-		if err := t.conn.SetWriteDeadline(deadline); err != nil {
-			return nil, err
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return err
 		}
 	}
-	n, err := t.conn.Write(b)
+	n, err := conn.Write(b)
 	if err != nil {
-		return nil, err
+		t.markStale(err)
+		return err
 	}
 	if n != len(b) {
-		return nil, fmt.Errorf("wrote incomplete message (%v/%v bytes)", n,
-			len(b))
+		return fmt.Errorf("wrote incomplete message (%v/%v bytes)", n, len(b))
+	}
+	if atomic.SwapInt64(&t.lastWrite, time.Now().UnixNano()) != 0 {
+		// nothing received since the previous write
+		atomic.AddInt32(&t.unanswered, 1)
 	}
-	sent := time.Now()
 	transmitBytes.Observe(float64(len(b)))
+	return nil
+}
 
-	// read
-	if deadline, ok := ctx.Deadline(); ok {
-		if err := t.conn.SetReadDeadline(deadline); err != nil {
+// Receive blocks until a packet is received from the remote host, returning
+// its data. An error is returned if a transport error occurs or the context
+// expires. The returned slice is only valid until the next call to Send or
+// Receive.
+func (t *transport) Receive(ctx context.Context) ([]byte, error) {
+	deadline, _ := ctx.Deadline() // zero value clears any previous deadline
+	for {
+		conn := t.currentConn()
+		if err := conn.SetReadDeadline(deadline); err != nil {
 			return nil, err
 		}
+		n, _, err := conn.ReadFromUDP(t.recvBuf[:])
+		if err != nil {
+			if t.currentConn() != conn {
+				// replaced by Write() after the BMC moved
+				continue
+			}
+			t.markStale(err)
+			return nil, err
+		}
+		atomic.StoreInt32(&t.unanswered, 0)
+		if sent := atomic.SwapInt64(&t.lastWrite, 0); sent != 0 {
+			// only the first packet after a write is regarded as its response
+			responseLatency.Observe(time.Since(time.Unix(0, sent)).Seconds())
+		}
+		receiveBytes.Observe(float64(n))
+		return t.recvBuf[:n], nil
 	}
-	n, _, err = t.conn.ReadFromUDP(t.recvBuf[:])
-	if err != nil {
-		return nil, err
-	}
-	responseLatency.Observe(time.Since(sent).Seconds())
-	receiveBytes.Observe(float64(n))
+}
 
-	return t.recvBuf[:n], nil
+// markStale records that the hostname should be re-resolved before the next
+// write, unless err is a timeout or the transport was closed.
+func (t *transport) markStale(err error) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() ||
+		errors.Is(err, net.ErrClosed) {
+		return
+	}
+	atomic.StoreInt32(&t.stale, 1)
 }
 
 // Close cleanly shuts down the transport, rendering it unusable.
 func (t *transport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
 	return t.conn.Close()
 }
 
@@ -161,6 +403,17 @@ type Transport interface {
 	// will be returned.
 	Send(context.Context, []byte) ([]byte, error)
 
+	// Write encapsulates the provided data in a UDP packet and sends it to the
+	// BMC's address, without waiting for a reply. This may be called
+	// concurrently with Receive.
+	Write(context.Context, []byte) error
+
+	// Receive blocks until a packet is received from the BMC without sending
+	// anything, returning its data. If the context has no deadline, this
+	// blocks until a packet arrives or the transport is closed. The same error
+	// semantics as Send apply.
+	Receive(context.Context) ([]byte, error)
+
 	// Close cleanly shuts down the underlying connection, returning any error
 	// that occurs. It is envisaged that this call is deferred as soon as the
 	// transport is successfully created.
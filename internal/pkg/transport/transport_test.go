@@ -0,0 +1,203 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		addr string
+		want *net.UDPAddr
+	}{
+		{"127.0.0.1:623", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 623}},
+		{"[::1]:624", &net.UDPAddr{IP: net.IPv6loopback, Port: 624}},
+		{"[fe80::1%lo]:623", &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 623, Zone: "lo"}},
+	}
+	for _, test := range tests {
+		got, err := resolve(context.Background(), test.addr)
+		if err != nil {
+			t.Errorf("resolve(%v) returned error %v", test.addr, err)
+			continue
+		}
+		if !got.IP.Equal(test.want.IP) || got.Port != test.want.Port ||
+			got.Zone != test.want.Zone {
+			t.Errorf("resolve(%v) = %v, want %v", test.addr, got, test.want)
+		}
+	}
+}
+
+func TestResolveContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := resolve(ctx, "bmc.example.com:623"); !errors.Is(err, context.Canceled) {
+		t.Errorf("resolve() with cancelled context returned %v, want %v", err,
+			context.Canceled)
+	}
+}
+
+func TestResolveMultiple(t *testing.T) {
+	defer func(f func(context.Context, string) ([]net.IPAddr, error)) {
+		lookupIPAddr = f
+	}(lookupIPAddr)
+	lookupIPAddr = func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{
+			{IP: net.ParseIP("2001:db8::1")},
+			{IP: net.IPv4(192, 0, 2, 1)},
+			{IP: net.ParseIP("fe80::1"), Zone: "eth0"},
+			{IP: net.IPv4(192, 0, 2, 2)},
+		}, nil
+	}
+
+	got, err := Resolve(context.Background(), "bmc.example.com:623")
+	if err != nil {
+		t.Fatalf("Resolve() returned error %v", err)
+	}
+	want := []string{"192.0.2.1:623", "192.0.2.2:623", "[2001:db8::1]:623",
+		"[fe80::1%eth0]:623"}
+	if len(got) != len(want) {
+		t.Fatalf("Resolve() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i].String() != want[i] {
+			t.Errorf("Resolve()[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReresolve(t *testing.T) {
+	old, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer old.Close()
+	port := old.LocalAddr().(*net.UDPAddr).Port
+	moved, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2), Port: port})
+	if err != nil {
+		t.Skipf("cannot listen on 127.0.0.2: %v", err)
+	}
+	defer moved.Close()
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, addr, err := moved.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			moved.WriteToUDP(buf[:n], addr)
+		}
+	}()
+
+	current := old.LocalAddr().(*net.UDPAddr).IP
+	defer func(f func(context.Context, string) ([]net.IPAddr, error)) {
+		lookupIPAddr = f
+	}(lookupIPAddr)
+	lookupIPAddr = func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: current}}, nil
+	}
+
+	ctx := context.Background()
+	tr, err := New(ctx, net.JoinHostPort("bmc.example.com", strconv.Itoa(port)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+	// allow an immediate re-resolution
+	tr.(*transport).lastResolve = time.Time{}
+
+	// the old address never responds
+	for i := 0; i < reresolveUnansweredWrites+1; i++ {
+		if err := tr.Write(ctx, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current = moved.LocalAddr().(*net.UDPAddr).IP
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	data, err := tr.Send(timeoutCtx, []byte{0xff})
+	if err != nil {
+		t.Fatalf("Send() after move returned %v", err)
+	}
+	if len(data) != 1 || data[0] != 0xff {
+		t.Errorf("Send() = %v, want [255]", data)
+	}
+	if got := tr.Address().String(); got != moved.LocalAddr().String() {
+		t.Errorf("Address() = %v, want %v", got, moved.LocalAddr())
+	}
+}
+
+func TestReresolveKeepsChosenAddress(t *testing.T) {
+	defer func(f func(context.Context, string) ([]net.IPAddr, error)) {
+		lookupIPAddr = f
+	}(lookupIPAddr)
+	lookupIPAddr = func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{
+			{IP: net.IPv4(127, 0, 0, 2)},
+			{IP: net.IPv4(127, 0, 0, 1)},
+		}, nil
+	}
+
+	ctx := context.Background()
+	raddrs, err := Resolve(ctx, "bmc.example.com:623")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := Dial(raddrs[1], "bmc.example.com:623")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+	tr.(*transport).lastResolve = time.Time{}
+	tr.(*transport).stale = 1
+
+	tr.(*transport).maybeReresolve(ctx)
+	if got := tr.Address().String(); got != "127.0.0.1:623" {
+		t.Errorf("Address() = %v, want 127.0.0.1:623", got)
+	}
+}
+
+func TestInterfaceMTU(t *testing.T) {
+	var loopback *net.Interface
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagLoopback != 0 {
+			loopback = &ifaces[i]
+			break
+		}
+	}
+	if loopback == nil {
+		t.Skip("no loopback interface")
+	}
+	got, err := interfaceMTU(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 623})
+	if err != nil {
+		t.Fatalf("interfaceMTU() failed: %v", err)
+	}
+	if got != loopback.MTU {
+		t.Errorf("interfaceMTU(127.0.0.1) = %v, want %v", got, loopback.MTU)
+	}
+}
+
+func TestMaxPayloadSize(t *testing.T) {
+	tests := []struct {
+		raddr *net.UDPAddr
+		want  int
+	}{
+		// loopback MTUs exceed 1500, so these are capped
+		{&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 623}, 1472},
+		{&net.UDPAddr{IP: net.IPv6loopback, Port: 623}, 1452},
+	}
+	for _, test := range tests {
+		if got := MaxPayloadSize(test.raddr); got != test.want {
+			t.Errorf("MaxPayloadSize(%v) = %v, want %v", test.raddr, got,
+				test.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package bmc
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// physicalSecurityIntrusionStates are the offsets of the Physical
+	// Security sensor type that indicate an intrusion: general chassis (0),
+	// drive bay (1), I/O card area (2), processor area (3) and fan area (6).
+	// LAN leash lost (4) and unauthorised dock (5) are not intrusions.
+	physicalSecurityIntrusionStates uint16 = 0x4f
+)
+
+// IntrusionOpts contains optional parameters for GetChassisIntrusion(). The
+// zero value is valid.
+type IntrusionOpts struct {
+
+	// Rearm re-arms each intrusion sensor that does not auto re-arm after it
+	// is read. Such sensors latch an intrusion until re-armed, so this
+	// emulates auto re-arm, with each intrusion reported once, and a chassis
+	// that remains open reported again. Whether a sensor auto re-arms is fixed
+	// in its SDR, so cannot be configured on the BMC.
+	Rearm bool
+}
+
+// IntrusionSensor is the state of a chassis intrusion sensor.
+type IntrusionSensor struct {
+
+	// Name is the sensor's ID string, e.g. "Chassis Intru".
+	Name string
+
+	// Number is the sensor's number.
+	Number uint8
+
+	// Intrusion indicates the sensor has an intrusion asserted.
+	Intrusion bool
+
+	// AutoRearm indicates the BMC clears the intrusion itself once the
+	// chassis is closed. If false, the intrusion remains asserted until the
+	// sensor is re-armed.
+	AutoRearm bool
+}
+
+// ChassisIntrusion summarises the physical security of a chassis.
+type ChassisIntrusion struct {
+
+	// Active is the chassis intrusion flag of Get Chassis Status. Not all
+	// BMCs set it, even if they have an intrusion sensor.
+	Active bool
+
+	// Sensors contains the state of each Physical Security sensor in the SDR
+	// Repository, in record ID order. Sensors that cannot be read are
+	// omitted.
+	Sensors []IntrusionSensor
+}
+
+// Intrusion returns whether any source indicates an intrusion.
+func (c *ChassisIntrusion) Intrusion() bool {
+	if c.Active {
+		return true
+	}
+	for _, sensor := range c.Sensors {
+		if sensor.Intrusion {
+			return true
+		}
+	}
+	return false
+}
+
+// GetChassisIntrusion reads the chassis intrusion status from Get Chassis
+// Status and every Physical Security sensor, for physical security
+// monitoring. Only sensors with Full Sensor Records are found, as those are
+// all the SDR Repository contains. If opts is nil, the zero value is used.
+func GetChassisIntrusion(ctx context.Context, s Session, opts *IntrusionOpts) (*ChassisIntrusion, error) {
+	if opts == nil {
+		opts = &IntrusionOpts{}
+	}
+	status, err := s.GetChassisStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := RetrieveSDRRepository(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	recordIDs := make([]ipmi.RecordID, 0, len(repo))
+	for recordID, fsr := range repo {
+		if fsr.SensorType == ipmi.SensorTypePhysicalSecurity {
+			recordIDs = append(recordIDs, recordID)
+		}
+	}
+	sort.Slice(recordIDs, func(i, j int) bool {
+		return recordIDs[i] < recordIDs[j]
+	})
+
+	intrusion := &ChassisIntrusion{
+		Active: status.Intrusion,
+	}
+	for _, recordID := range recordIDs {
+		fsr := repo[recordID]
+		cmd := &ipmi.GetSensorReadingCmd{
+			Req: ipmi.GetSensorReadingReq{
+				Number: fsr.Number,
+			},
+		}
+		if err := ValidateResponse(s.SendCommand(ctx,
+			ipmi.CommandWithLUN(cmd, fsr.OwnerLUN))); err != nil {
+			if isSessionError(err) {
+				return nil, err
+			}
+			continue
+		}
+		if cmd.Rsp.ReadingUnavailable || !cmd.Rsp.ScanningEnabled {
+			continue
+		}
+		sensor := IntrusionSensor{
+			Name:      fsr.Identity,
+			Number:    fsr.Number,
+			Intrusion: cmd.Rsp.States&physicalSecurityIntrusionStates != 0,
+			AutoRearm: fsr.AutoRearm,
+		}
+		intrusion.Sensors = append(intrusion.Sensors, sensor)
+		if opts.Rearm && sensor.Intrusion && !sensor.AutoRearm {
+			rearmCmd := &ipmi.RearmSensorEventsCmd{
+				Req: ipmi.RearmSensorEventsReq{
+					Number: fsr.Number,
+				},
+			}
+			if err := ValidateResponse(s.SendCommand(ctx,
+				ipmi.CommandWithLUN(rearmCmd, fsr.OwnerLUN))); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return intrusion, nil
+}
@@ -0,0 +1,214 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+var (
+	// operationGetDCMICapabilitiesInfoReq is duplicated from the dcmi package,
+	// which imports this one.
+	operationGetDCMICapabilitiesInfoReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodeDCMI,
+		Command:  0x01,
+	}
+)
+
+// MachineInventory describes a machine as seen by its BMC, combining the
+// results of several commands. Only DeviceID and GUID are guaranteed to be
+// populated; other fields are left as their zero value if the BMC does not
+// support the underlying command.
+type MachineInventory struct {
+
+	// DeviceID is the response to Get Device ID, containing the manufacturer,
+	// product ID, IPMI version and which devices the BMC supports.
+	DeviceID *ipmi.GetDeviceIDRsp
+
+	// GUID is the system GUID, as returned by Get System GUID. Its byte order
+	// varies between manufacturers.
+	GUID [16]byte
+
+	// FirmwareVersion is the BMC firmware version, built from DeviceID by
+	// FirmwareVersion().
+	FirmwareVersion string
+
+	// SystemFirmwareVersion is the free-form System Firmware Version system
+	// info parameter. Most BMCs other than OpenBMC do not support this.
+	SystemFirmwareVersion string
+
+	// Firmware contains the versions of all firmware components, if the OEM
+	// extensions registered for the manufacturer implement FirmwareInventory.
+	Firmware []FirmwareComponent
+
+	// AuthenticationCapabilities is the response to Get Channel Authentication
+	// Capabilities for the channel being used, indicating which IPMI versions
+	// and authentication types the BMC supports.
+	AuthenticationCapabilities *ipmi.GetChannelAuthenticationCapabilitiesRsp
+
+	// FRU contains the chassis, board and product information areas of FRU
+	// device 0, e.g. the board serial number.
+	FRU *FRUInventory
+
+	// MACAddress is the MAC address of the LAN channel being used.
+	MACAddress net.HardwareAddr
+
+	// DCMIVersion is the DCMI specification version the BMC conforms to, e.g.
+	// "1.5", or empty if it does not support DCMI.
+	DCMIVersion string
+}
+
+// IPMIVersion returns the IPMI version reported by the BMC in Get Device ID,
+// e.g. "2.0".
+func (i *MachineInventory) IPMIVersion() string {
+	return fmt.Sprintf("%v.%v", i.DeviceID.MajorIPMIVersion,
+		i.DeviceID.MinorIPMIVersion)
+}
+
+// Inventory retrieves everything this library knows how to find out about a
+// machine that does not change while it is running, in one call. It is aimed
+// at asset management systems. Commands that are optional in the spec are
+// allowed to fail, leaving the corresponding fields empty, unless the context
+// expires, in which case the context error is returned. This sends a dozen or
+// more commands, depending on the size of the FRU data.
+func Inventory(ctx context.Context, s Session) (*MachineInventory, error) {
+	deviceID, err := s.GetDeviceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	guid, err := s.GetSystemGUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inventory := &MachineInventory{
+		DeviceID:        deviceID,
+		GUID:            guid,
+		FirmwareVersion: FirmwareVersion(deviceID),
+	}
+
+	// the remaining commands are optional; we stop only if the context has
+	// expired
+	if version, err := s.GetSystemFirmwareVersion(ctx); err == nil {
+		inventory.SystemFirmwareVersion = version
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	caps, err := s.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		})
+	if err == nil {
+		inventory.AuthenticationCapabilities = caps
+		if mac, err := getLANMACAddress(ctx, s, caps.Channel); err == nil {
+			inventory.MACAddress = mac
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if deviceID.SupportsFRUInventoryDevice {
+		if fru, err := ReadFRUInventory(ctx, s, 0); err == nil {
+			inventory.FRU = fru
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	if version, err := getDCMIVersion(ctx, s); err == nil {
+		inventory.DCMIVersion = version
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if o, ok := LookupOEM(deviceID.Manufacturer); ok {
+		if fi, ok := o.(FirmwareInventory); ok {
+			if firmware, err := fi.FirmwareInventory(ctx, s); err == nil {
+				inventory.Firmware = firmware
+			} else if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return inventory, nil
+}
+
+// getLANMACAddress retrieves the MAC Address LAN configuration parameter of a
+// channel.
+func getLANMACAddress(ctx context.Context, c Connection, channel ipmi.Channel) (net.HardwareAddr, error) {
+	cmd := &ipmi.GetLANConfigurationParametersCmd{
+		Req: ipmi.GetLANConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: ipmi.LANConfigurationParameterMACAddress,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	data := cmd.Rsp.LayerPayload()
+	if len(data) < 6 {
+		return nil, fmt.Errorf("MAC address parameter must be 6 bytes, got %v",
+			len(data))
+	}
+	mac := make(net.HardwareAddr, 6)
+	copy(mac, data)
+	return mac, nil
+}
+
+// getDCMICapabilitiesInfoCmd sends a Get DCMI Capabilities Info command for
+// the Supported DCMI Capabilities parameter, leaving the response
+// uninterpreted. Use the dcmi package to decode it in full.
+type getDCMICapabilitiesInfoCmd struct {
+	req gopacket.Payload
+	rsp gopacket.Payload
+}
+
+func (*getDCMICapabilitiesInfoCmd) Name() string {
+	return "Get DCMI Capabilities Info"
+}
+
+func (*getDCMICapabilitiesInfoCmd) Operation() *ipmi.Operation {
+	return &operationGetDCMICapabilitiesInfoReq
+}
+
+func (c *getDCMICapabilitiesInfoCmd) Request() gopacket.SerializableLayer {
+	return &c.req
+}
+
+func (c *getDCMICapabilitiesInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.rsp
+}
+
+// getDCMIVersion returns the version of the DCMI specification the BMC
+// conforms to, e.g. "1.5". This returns an error if DCMI is unsupported.
+func getDCMIVersion(ctx context.Context, c Connection) (string, error) {
+	rsp, err := getSupportedDCMICapabilities(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v.%v", rsp[0], rsp[1]), nil
+}
+
+// getSupportedDCMICapabilities returns the Supported DCMI Capabilities
+// parameter, which is at least 6 bytes long: the major version, minor version
+// and parameter revision, followed by the mandatory platform, optional
+// platform and manageability access capabilities. This returns an error if
+// DCMI is unsupported.
+func getSupportedDCMICapabilities(ctx context.Context, c Connection) ([]byte, error) {
+	cmd := &getDCMICapabilitiesInfoCmd{
+		req: gopacket.Payload{0x01}, // Supported DCMI Capabilities
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	if len(cmd.rsp) < 6 {
+		return nil, fmt.Errorf("Get DCMI Capabilities Info response must be "+
+			"at least 6 bytes, got %v", len(cmd.rsp))
+	}
+	return cmd.rsp, nil
+}
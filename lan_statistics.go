@@ -0,0 +1,44 @@
+package bmc
+
+import (
+	"context"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket/layers"
+)
+
+// GetLANStatistics retrieves the IP, UDP and RMCP packet counters of a LAN
+// channel with the Get IP/UDP/RMCP Statistics command. Comparing them with the
+// number of packets sent shows whether a lossy management network is dropping
+// packets before they reach the BMC, or the BMC is discarding them.
+// ipmi.ChannelPresentInterface can be used to refer to the channel of the
+// session. Note each counter is only 16 bits wide, and stops rather than
+// wrapping, so a busy BMC's counters may need to be cleared first to be
+// useful.
+func GetLANStatistics(ctx context.Context, s Session, channel ipmi.Channel) (*ipmi.GetIPUDPRMCPStatisticsRsp, error) {
+	return lanStatistics(ctx, s, channel, false)
+}
+
+// ClearLANStatistics resets the packet counters of a LAN channel to 0,
+// returning their values immediately beforehand.
+func ClearLANStatistics(ctx context.Context, s Session, channel ipmi.Channel) (*ipmi.GetIPUDPRMCPStatisticsRsp, error) {
+	return lanStatistics(ctx, s, channel, true)
+}
+
+// lanStatistics sends a Get IP/UDP/RMCP Statistics command. The response is a
+// copy, owned by the caller.
+func lanStatistics(ctx context.Context, s Session, channel ipmi.Channel, clear bool) (*ipmi.GetIPUDPRMCPStatisticsRsp, error) {
+	cmd := &ipmi.GetIPUDPRMCPStatisticsCmd{
+		Req: ipmi.GetIPUDPRMCPStatisticsReq{
+			Channel: channel,
+			Clear:   clear,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	rsp := cmd.Rsp
+	rsp.BaseLayer = layers.BaseLayer{}
+	return &rsp, nil
+}
@@ -0,0 +1,42 @@
+package bmc
+
+import (
+	"context"
+)
+
+// LCD is implemented by OEM extensions that can display text on a front-panel
+// LCD, e.g. to show the hostname of a machine so datacenter technicians can
+// find it. The specification has no equivalent, so this is only available for
+// manufacturers whose extensions are registered with RegisterOEM().
+type LCD interface {
+
+	// LCDText returns the user-defined text on the LCD. This is not
+	// necessarily displayed, as the LCD may be configured to show something
+	// else, e.g. the service tag.
+	LCDText(ctx context.Context, s Session) (string, error)
+
+	// SetLCDText sets the user-defined text on the LCD.
+	SetLCDText(ctx context.Context, s Session, text string) error
+}
+
+// LCDText returns the user-defined text on the front-panel LCD, using the OEM
+// extensions registered for the BMC's manufacturer. If there are none
+// implementing LCD, an error wrapping ErrOEMUnsupported is returned.
+func (s *V2Session) LCDText(ctx context.Context) (string, error) {
+	lcd, err := oemFeature[LCD](ctx, s, "LCD")
+	if err != nil {
+		return "", err
+	}
+	return lcd.LCDText(ctx, s)
+}
+
+// SetLCDText sets the user-defined text on the front-panel LCD, using the OEM
+// extensions registered for the BMC's manufacturer. If there are none
+// implementing LCD, an error wrapping ErrOEMUnsupported is returned.
+func (s *V2Session) SetLCDText(ctx context.Context, text string) error {
+	lcd, err := oemFeature[LCD](ctx, s, "LCD")
+	if err != nil {
+		return err
+	}
+	return lcd.SetLCDText(ctx, s, text)
+}
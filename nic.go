@@ -0,0 +1,59 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+)
+
+// NICMode describes which physical network interface a BMC uses for
+// management traffic. Many servers can share a host NIC with the BMC via
+// NC-SI, which is a common source of connectivity problems: the BMC may be
+// unreachable when the host's driver resets the NIC, or the host's VLAN
+// configuration may not apply to the BMC. The specification has no way to
+// retrieve this, so it is only available via OEM extensions.
+type NICMode uint8
+
+const (
+	// NICModeDedicated indicates the BMC uses its own management port.
+	NICModeDedicated NICMode = iota
+
+	// NICModeShared indicates the BMC shares a host NIC.
+	NICModeShared
+
+	// NICModeFailover indicates the BMC uses its dedicated port if it has a
+	// link, otherwise a shared host NIC.
+	NICModeFailover
+)
+
+func (m NICMode) String() string {
+	switch m {
+	case NICModeDedicated:
+		return "Dedicated"
+	case NICModeShared:
+		return "Shared"
+	case NICModeFailover:
+		return "Failover"
+	default:
+		return fmt.Sprintf("Unknown(%v)", uint8(m))
+	}
+}
+
+// ManagementNIC is implemented by OEM extensions that can determine which
+// network interface the BMC uses, registered with RegisterOEM().
+type ManagementNIC interface {
+
+	// ManagementNIC returns the configured NIC mode of the BMC.
+	ManagementNIC(ctx context.Context, s Session) (NICMode, error)
+}
+
+// ManagementNIC returns whether the BMC uses a dedicated or shared network
+// interface, using the OEM extensions registered for its manufacturer. If
+// there are none implementing ManagementNIC, an error wrapping
+// ErrOEMUnsupported is returned.
+func (s *V2Session) ManagementNIC(ctx context.Context) (NICMode, error) {
+	nic, err := oemFeature[ManagementNIC](ctx, s, "management NIC detection")
+	if err != nil {
+		return 0, err
+	}
+	return nic.ManagementNIC(ctx, s)
+}
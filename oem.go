@@ -0,0 +1,90 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+)
+
+var (
+	// ErrOEMUnsupported is returned when a feature implemented with OEM
+	// commands is requested of a BMC whose manufacturer has no registered
+	// implementation of it.
+	ErrOEMUnsupported = errors.New("feature not supported for this " +
+		"manufacturer")
+
+	// oems contains the OEM extensions registered with RegisterOEM(), keyed
+	// by the manufacturer reported in Get Device ID.
+	oems   = map[iana.Enterprise]OEM{}
+	oemsMu sync.RWMutex
+)
+
+// OEM provides features a BMC's manufacturer implements with OEM commands
+// rather than those in the specification. Packages implementing a
+// manufacturer's extensions register an OEM for its enterprise number, and
+// implement the interfaces of the features they support, e.g. VirtualMedia.
+// Callers find features with OEMFor() and a type assertion, or the
+// convenience methods on V2Session, e.g. AttachMedia(). Implementations are
+// best-effort: OEM commands are rarely documented, and vary between firmware
+// versions.
+type OEM interface {
+
+	// Name returns a human-readable name for the extensions, e.g.
+	// "Super Micro", used in error messages.
+	Name() string
+}
+
+// RegisterOEM sets the OEM extensions used for BMCs reporting manufacturer in
+// Get Device ID, replacing any existing registration. Manufacturers using
+// more than one enterprise number should be registered under each. It is safe
+// to call concurrently, but would normally be called from an init function.
+func RegisterOEM(manufacturer iana.Enterprise, o OEM) {
+	oemsMu.Lock()
+	defer oemsMu.Unlock()
+	oems[manufacturer] = o
+}
+
+// LookupOEM returns the OEM extensions registered for a manufacturer, and
+// whether there were any.
+func LookupOEM(manufacturer iana.Enterprise) (OEM, bool) {
+	oemsMu.RLock()
+	defer oemsMu.RUnlock()
+	o, ok := oems[manufacturer]
+	return o, ok
+}
+
+// OEMFor returns the OEM extensions registered for the manufacturer of the
+// BMC at the other end of a session, found with Get Device ID. If there are
+// none, an error wrapping ErrOEMUnsupported is returned.
+func OEMFor(ctx context.Context, s Session) (OEM, error) {
+	id, err := s.GetDeviceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o, ok := LookupOEM(id.Manufacturer)
+	if !ok {
+		return nil, fmt.Errorf("%w: no OEM extensions registered for %v",
+			ErrOEMUnsupported, id.Manufacturer)
+	}
+	return o, nil
+}
+
+// oemFeature returns the OEM extensions for the BMC at the other end of a
+// session as the interface of a feature, described by name in the error if
+// they do not implement it.
+func oemFeature[T any](ctx context.Context, s Session, name string) (T, error) {
+	var feature T
+	o, err := OEMFor(ctx, s)
+	if err != nil {
+		return feature, err
+	}
+	feature, ok := o.(T)
+	if !ok {
+		return feature, fmt.Errorf("%w: %v extensions do not implement %v",
+			ErrOEMUnsupported, o.Name(), name)
+	}
+	return feature, nil
+}
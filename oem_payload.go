@@ -0,0 +1,218 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+var (
+	// ErrNotOEMPayload is returned when sending or dispatching a payload whose
+	// type is defined by the specification, e.g. IPMI or SOL, via the OEM
+	// payload methods.
+	ErrNotOEMPayload = errors.New("not an OEM payload type")
+
+	// ErrUnhandledPayload is returned by DispatchOEMPayload() if no handler is
+	// registered for the payload received.
+	ErrUnhandledPayload = errors.New("no handler registered for payload")
+)
+
+// OEMPayloadHandler processes an OEM payload received within a session. The
+// payload has been authenticated and decrypted, and is owned by the handler.
+// Handlers are called without the session's locks held, so may send commands
+// or further payloads.
+type OEMPayloadHandler func(s *V2Session, d ipmi.PayloadDescriptor, payload []byte)
+
+// oemPayloadKey returns the key under which a handler for an OEM payload is
+// registered. The Enterprise and PayloadID fields are only on the wire for OEM
+// explicit payloads, so are cleared for the OEM payload types.
+func oemPayloadKey(d ipmi.PayloadDescriptor) (ipmi.PayloadDescriptor, error) {
+	switch {
+	case !d.PayloadType.IsOEM():
+		return ipmi.PayloadDescriptor{}, fmt.Errorf("%w: %v", ErrNotOEMPayload,
+			d.PayloadType)
+	case d.PayloadType == ipmi.PayloadTypeOEM:
+		return d, nil
+	default:
+		return ipmi.PayloadDescriptor{
+			PayloadType: d.PayloadType,
+		}, nil
+	}
+}
+
+// HandleOEMPayload registers h to be called for OEM payloads matching d that
+// are passed to DispatchOEMPayload(), replacing any existing handler; a nil
+// handler removes it. For OEM explicit payloads, the Enterprise and PayloadID
+// fields must match; for PayloadTypeOEM0 through PayloadTypeOEM7, only the
+// type is significant. The meaning of the latter varies by BMC, and can be
+// discovered with Get Channel OEM Payload Info. This allows vendor-specific
+// payloads to be implemented outside this package.
+func (s *V2Session) HandleOEMPayload(d ipmi.PayloadDescriptor, h OEMPayloadHandler) error {
+	key, err := oemPayloadKey(d)
+	if err != nil {
+		return err
+	}
+	s.oemPayloadHandlersMu.Lock()
+	defer s.oemPayloadHandlersMu.Unlock()
+	if h == nil {
+		delete(s.oemPayloadHandlers, key)
+		return nil
+	}
+	if s.oemPayloadHandlers == nil {
+		s.oemPayloadHandlers = map[ipmi.PayloadDescriptor]OEMPayloadHandler{}
+	}
+	s.oemPayloadHandlers[key] = h
+	return nil
+}
+
+// GetChannelOEMPayloadInfo sends a Get Channel OEM Payload Info command to the
+// BMC, to find the OEM payload a payload type number in the range 0x20 through
+// 0x27 is used for. This is specified in 24.11 of IPMI v2.0.
+func (s *V2Session) GetChannelOEMPayloadInfo(ctx context.Context, r *ipmi.GetChannelOEMPayloadInfoReq) (*ipmi.GetChannelOEMPayloadInfoRsp, error) {
+	cmd := &ipmi.GetChannelOEMPayloadInfoCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+// SendOEMPayload sends an OEM payload to the BMC within the session, signed and
+// encrypted like commands. OEM payloads have no general notion of a response,
+// so this returns once the packet has been written; any reply is received as
+// an unsolicited packet, and can be passed to DispatchOEMPayload(). The
+// connection's command timeout bounds the write.
+func (s *V2Session) SendOEMPayload(ctx context.Context, d ipmi.PayloadDescriptor, payload []byte) error {
+	d, err := oemPayloadKey(d)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.demux.isClosed() {
+		return ErrTransportClosed
+	}
+	if s.closed {
+		return ErrSessionClosed
+	}
+
+	protection := payloadProtectionFromContext(ctx)
+	s.rmcpLayer = layers.RMCP{
+		Version:  layers.RMCPVersion1,
+		Sequence: 0xFF, // do not send us an ACK
+		Class:    layers.RMCPClassIPMI,
+	}
+	s.v2SessionLayer = ipmi.V2Session{
+		Encrypted:          !protection.unencrypted,
+		Authenticated:      !protection.unauthenticated,
+		ID:                 s.RemoteID,
+		PayloadDescriptor:  d,
+		IntegrityAlgorithm: s.integrityAlgorithm,
+	}
+	sequenceNumbers := &s.AuthenticatedSequenceNumbers
+	if !s.v2SessionLayer.Authenticated {
+		sequenceNumbers = &s.UnauthenticatedSequenceNumbers
+	}
+	sequence, ok := sequenceNumbers.nextInbound(closeSessionSequenceReserve)
+	if !ok {
+		return ErrSequenceNumbersExhausted
+	}
+	s.v2SessionLayer.Sequence = sequence
+
+	ls := []gopacket.SerializableLayer{&s.rmcpLayer, &s.v2SessionLayer}
+	if s.v2SessionLayer.Encrypted {
+		ls = append(ls, s.confidentialityLayer)
+	}
+	ls = append(ls, gopacket.Payload(payload))
+	if err := gopacket.SerializeLayers(s.buffer, serializeOptions, ls...); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout(ctx, s.timeout))
+	defer cancel()
+	start := time.Now()
+	s.bytesSent += uint64(len(s.buffer.Bytes()))
+	if err := s.demux.Write(ctx, s.buffer.Bytes()); err != nil {
+		return stageTimeoutError(TimeoutStageSend, start, err)
+	}
+	return nil
+}
+
+// DispatchOEMPayload verifies and decrypts an unsolicited packet received
+// within the session, e.g. via Recv(), then calls the handler registered for
+// its payload with HandleOEMPayload(). ErrNotOEMPayload is returned for
+// packets with other payload types, which the caller should process itself,
+// and ErrUnhandledPayload if there is no handler. Packets that fail
+// verification, e.g. replays, are dropped and an error returned.
+func (s *V2Session) DispatchOEMPayload(p UnsolicitedPacket) error {
+	if !p.PayloadType.IsOEM() {
+		return fmt.Errorf("%w: %v", ErrNotOEMPayload, p.PayloadType)
+	}
+	d, payload, err := s.decodeOEMPayload(p.Data)
+	if err != nil {
+		return err
+	}
+
+	key, err := oemPayloadKey(d)
+	if err != nil {
+		return err
+	}
+	s.oemPayloadHandlersMu.RLock()
+	h := s.oemPayloadHandlers[key]
+	s.oemPayloadHandlersMu.RUnlock()
+	if h == nil {
+		return fmt.Errorf("%w: %v", ErrUnhandledPayload, d)
+	}
+	h(s, d, payload)
+	return nil
+}
+
+// decodeOEMPayload decodes a packet containing an OEM payload, returning its
+// descriptor, and the decrypted payload. The packet is not modified.
+func (s *V2Session) decodeOEMPayload(data []byte) (ipmi.PayloadDescriptor, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// decryption happens in place
+	data = append([]byte(nil), data...)
+
+	s.bytesReceived += uint64(len(data))
+	s.v2SessionLayer = ipmi.V2Session{
+		IntegrityAlgorithm:       s.integrityAlgorithm,
+		ConfidentialityLayerType: s.confidentialityLayer.LayerType(),
+	}
+	if _, err := s.decode(data, &s.layers); err != nil {
+		if errors.Is(err, ipmi.ErrInvalidSignature) {
+			sessionPacketsDroppedIntegrity.Inc()
+		}
+		return ipmi.PayloadDescriptor{}, nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	if err := s.verifyInbound(); err != nil {
+		return ipmi.PayloadDescriptor{}, nil, err
+	}
+	if !s.v2SessionLayer.PayloadType.IsOEM() {
+		return ipmi.PayloadDescriptor{}, nil, fmt.Errorf("%w: %v",
+			ErrNotOEMPayload, s.v2SessionLayer.PayloadType)
+	}
+
+	// the session layer only selects the confidentiality layer for IPMI
+	// messages, so OEM payloads are decrypted here
+	payload := s.v2SessionLayer.LayerPayload()
+	if s.v2SessionLayer.Encrypted {
+		if err := s.confidentialityLayer.DecodeFromBytes(payload,
+			gopacket.NilDecodeFeedback); err != nil {
+			return ipmi.PayloadDescriptor{}, nil, fmt.Errorf("%w: %w",
+				ErrDecode, err)
+		}
+		payload = s.confidentialityLayer.LayerPayload()
+	}
+	return s.v2SessionLayer.PayloadDescriptor, payload, nil
+}
@@ -0,0 +1,54 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// IsOpenBMC returns whether a Get Device ID response indicates the BMC is
+// running OpenBMC. This is based on the manufacturer ID, so will not detect
+// vendor firmware derived from OpenBMC that reports the vendor's own
+// enterprise number. OpenBMC supports reading SDRs in a single request and
+// retrieving the firmware version string via GetSystemFirmwareVersion().
+func IsOpenBMC(r *ipmi.GetDeviceIDRsp) bool {
+	return r.Manufacturer == iana.EnterpriseOpenBMC
+}
+
+// getSystemFirmwareVersion retrieves the System Firmware Version system info
+// parameter. This is the only way to obtain the full version string on
+// OpenBMC, as Get Device ID has room for only a few numeric components.
+func getSystemFirmwareVersion(ctx context.Context, c Connection) (string, error) {
+	return GetSystemInfoString(ctx, c,
+		ipmi.SystemInfoParameterSystemFirmwareVersion)
+}
+
+// decodeSystemInfoString interprets a string system info parameter according
+// to its encoding, specified in table 22-16a of IPMI v2.0.
+func decodeSystemInfoString(encoding uint8, b []byte) (string, error) {
+	switch encoding {
+	case 0: // ASCII+Latin1
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return string(runes), nil
+	case 1: // UTF-8
+		return string(b), nil
+	case 2: // UNICODE, assumed to be little-endian UTF-16
+		if len(b)%2 != 0 {
+			return "", fmt.Errorf("UNICODE string must have an even number "+
+				"of bytes, got %v", len(b))
+		}
+		units := make([]uint16, len(b)/2)
+		for i := range units {
+			units[i] = uint16(b[i*2]) | uint16(b[i*2+1])<<8
+		}
+		return string(utf16.Decode(units)), nil
+	default:
+		return "", fmt.Errorf("unsupported string encoding: %v", encoding)
+	}
+}
@@ -0,0 +1,33 @@
+package bmc
+
+import (
+	"testing"
+)
+
+func TestDecodeSystemInfoString(t *testing.T) {
+	tests := []struct {
+		encoding uint8
+		in       []byte
+		want     string
+		wantErr  bool
+	}{
+		{0, []byte("2.12.0"), "2.12.0", false},
+		{0, []byte{0x63, 0x61, 0x66, 0xe9}, "café", false},
+		{1, []byte("café"), "café", false},
+		{2, []byte{0x32, 0x00, 0x2e, 0x00, 0x31, 0x00}, "2.1", false},
+		{2, []byte{0x32}, "", true},
+		{3, []byte("2.12.0"), "", true},
+	}
+	for _, test := range tests {
+		got, err := decodeSystemInfoString(test.encoding, test.in)
+		if (err != nil) != test.wantErr {
+			t.Errorf("decodeSystemInfoString(%v, %v) returned error %v, "+
+				"wanted error: %v", test.encoding, test.in, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("decodeSystemInfoString(%v, %v) = %q, want %q",
+				test.encoding, test.in, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package bmc
+
+import (
+	"context"
+)
+
+// payloadProtectionKey is the context key under which per-command overrides of
+// payload encryption and authentication are stored.
+type payloadProtectionKey struct{}
+
+// payloadProtection records which of the negotiated protections to omit for
+// commands sent with a given context.
+type payloadProtection struct {
+	unencrypted     bool
+	unauthenticated bool
+}
+
+// WithoutEncryption returns a context causing commands sent within a session
+// to have their payload sent in the clear, with the encrypted bit unset, even
+// if a confidentiality algorithm was negotiated. This works around BMCs that
+// mishandle encrypted payloads for specific commands, e.g. some SOL
+// configuration commands. The BMC may reject unencrypted payloads depending on
+// its configuration. Responses are decoded regardless of whether they are
+// encrypted.
+func WithoutEncryption(ctx context.Context) context.Context {
+	p := payloadProtectionFromContext(ctx)
+	p.unencrypted = true
+	return context.WithValue(ctx, payloadProtectionKey{}, p)
+}
+
+// WithoutAuthentication returns a context causing commands sent within a
+// session to omit the authentication trailer, even if an integrity algorithm
+// was negotiated. Such packets use the session's unauthenticated sequence
+// numbers. Note that responses from the BMC must still be authenticated if an
+// integrity algorithm was negotiated.
+func WithoutAuthentication(ctx context.Context) context.Context {
+	p := payloadProtectionFromContext(ctx)
+	p.unauthenticated = true
+	return context.WithValue(ctx, payloadProtectionKey{}, p)
+}
+
+// payloadProtectionFromContext returns the overrides in the context, or the
+// zero value if there are none.
+func payloadProtectionFromContext(ctx context.Context) payloadProtection {
+	p, _ := ctx.Value(payloadProtectionKey{}).(payloadProtection)
+	return p
+}
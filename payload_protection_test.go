@@ -0,0 +1,74 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+func TestPayloadProtectionFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := payloadProtectionFromContext(ctx); got != (payloadProtection{}) {
+		t.Errorf("payloadProtectionFromContext(Background()) = %+v, want "+
+			"zero value", got)
+	}
+	ctx = WithoutEncryption(ctx)
+	want := payloadProtection{
+		unencrypted: true,
+	}
+	if got := payloadProtectionFromContext(ctx); got != want {
+		t.Errorf("payloadProtectionFromContext(WithoutEncryption()) = %+v, "+
+			"want %+v", got, want)
+	}
+	ctx = WithoutAuthentication(ctx)
+	want.unauthenticated = true
+	if got := payloadProtectionFromContext(ctx); got != want {
+		t.Errorf("payloadProtectionFromContext(WithoutAuthentication("+
+			"WithoutEncryption())) = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildWithoutProtection(t *testing.T) {
+	// a confidentiality algorithm was negotiated, but must not be used
+	confidentiality, err := ipmi.NewAES128CBC([16]byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &V2Session{
+		v2ConnectionShared: &v2ConnectionShared{
+			buffer: gopacket.NewSerializeBuffer(),
+		},
+		RemoteID:             0x01020304,
+		confidentialityLayer: confidentiality,
+	}
+	c := &ipmi.GetDeviceIDCmd{}
+	request := s.newRequest(c)
+	if err := s.build(c, &request, payloadProtection{
+		unencrypted:     true,
+		unauthenticated: true,
+	}); err != nil {
+		t.Fatalf("build() failed: %v", err)
+	}
+
+	// RMCP header (4), auth type, payload type
+	packet := s.buffer.Bytes()
+	if len(packet) < 6 {
+		t.Fatalf("build() produced %v bytes, want at least 6", len(packet))
+	}
+	if encrypted := packet[5]&0x80 != 0; encrypted {
+		t.Errorf("payload type %#x has encrypted bit set", packet[5])
+	}
+	if authenticated := packet[5]&0x40 != 0; authenticated {
+		t.Errorf("payload type %#x has authenticated bit set", packet[5])
+	}
+	if s.UnauthenticatedSequenceNumbers.Inbound != 1 ||
+		s.AuthenticatedSequenceNumbers.Inbound != 0 {
+		t.Errorf("inbound sequence numbers = %v authenticated, %v "+
+			"unauthenticated; want 0, 1",
+			s.AuthenticatedSequenceNumbers.Inbound,
+			s.UnauthenticatedSequenceNumbers.Inbound)
+	}
+}
@@ -0,0 +1,82 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kuiwang02/bmc/internal/pkg/transport"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+var (
+	// errNoPresencePong is returned by PresencePing() if the BMC responds
+	// with something other than a Presence Pong.
+	errNoPresencePong = errors.New("no presence pong layer in response")
+)
+
+// PresencePing sends an ASF Presence Ping to the BMC, returning its Presence
+// Pong, which describes the entities and interactions it supports. This is
+// specified in section 3.2.4.2 of ASF v2.0 and 13.2.3 of IPMI v2.0. It does
+// not involve the IPMI stack, so succeeding indicates only that the BMC's
+// network interface is up; use Ping() on a session to check the session
+// remains usable.
+func (s *V2SessionlessTransport) PresencePing(ctx context.Context) (*layers.ASFPresencePong, error) {
+	return presencePing(ctx, s.Transport)
+}
+
+func presencePing(ctx context.Context, t transport.Transport) (*layers.ASFPresencePong, error) {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	if err := gopacket.SerializeLayers(buf, opts,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff, // do not send an ACK
+			Class:    layers.RMCPClassASF,
+		},
+		&layers.ASF{
+			ASFDataIdentifier: layers.ASFDataIdentifierPresencePing,
+		},
+	); err != nil {
+		return nil, err
+	}
+	bytes, err := t.Send(ctx, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	packet := gopacket.NewPacket(bytes, layers.LayerTypeRMCP, gopacket.DecodeOptions{
+		Lazy:   true,
+		NoCopy: true,
+	})
+	pongLayer := packet.Layer(layers.LayerTypeASFPresencePong)
+	if pongLayer == nil {
+		return nil, errNoPresencePong
+	}
+	return pongLayer.(*layers.ASFPresencePong), nil
+}
+
+// Ping checks the session is still usable by sending a Get Session Info
+// command for it, which is cheap for the BMC to answer, and has no side
+// effects. A nil error means the BMC recognised the session. Unlike a
+// keepalive, the purpose is to detect a session the BMC has dropped, e.g.
+// after a reset, before a more important command times out; it does however
+// also reset the BMC's inactivity timer.
+func (s *V2Session) Ping(ctx context.Context) error {
+	rsp, err := s.GetSessionInfo(ctx, &ipmi.GetSessionInfoReq{
+		Index: ipmi.SessionIndexCurrent,
+	})
+	if err != nil {
+		return err
+	}
+	// handle is not reliable; see GetSessionInfoRsp
+	if rsp.UserID == 0 {
+		return fmt.Errorf("%w: BMC reports no active session", ErrSessionClosed)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestPing(t *testing.T) {
+	simBMC, err := sim.New(&sim.Config{
+		Username: "admin",
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simBMC.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	machine, err := DialV2Context(ctx, simBMC.Addr(), &DialOpts{
+		CommandTimeout: time.Millisecond * 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer machine.Close()
+
+	pong, err := machine.PresencePing(ctx)
+	if err != nil {
+		t.Fatalf("PresencePing() failed: %v", err)
+	}
+	if !pong.IPMI {
+		t.Errorf("PresencePing() = %+v, want IPMI support", pong)
+	}
+
+	sess, err := machine.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter2"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() failed: %v", err)
+	}
+	if err := sess.Ping(ctx); err != nil {
+		t.Errorf("Ping() failed: %v", err)
+	}
+
+	// the BMC ignores packets for sessions it does not know about
+	simBMC.DropSessions()
+	if err := sess.Ping(ctx); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Ping() after reset = %v, want %v", err, ErrTimeout)
+	}
+
+	// the transport is unaffected
+	if _, err := machine.PresencePing(ctx); err != nil {
+		t.Errorf("PresencePing() after reset failed: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package dcmi
+
+import (
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ActivateDeactivatePowerLimitReq represents the Activate/Deactivate Power
+// Limit command, specified in 6.6.4 of DCMI v1.0, v1.1 and v1.5. The limit
+// enforced is the one last configured with Set Power Limit. The response has
+// no data.
+type ActivateDeactivatePowerLimitReq struct {
+	layers.BaseLayer
+
+	// Activate causes the power limit to be enforced. If false, it is
+	// deactivated, and power consumption is not limited.
+	Activate bool
+}
+
+func (*ActivateDeactivatePowerLimitReq) LayerType() gopacket.LayerType {
+	return layerTypeActivateDeactivatePowerLimitReq
+}
+
+func (a *ActivateDeactivatePowerLimitReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = 0x00
+	if a.Activate {
+		bytes[0] = 0x01
+	}
+	// reserved
+	bytes[1] = 0x00
+	bytes[2] = 0x00
+	return nil
+}
+
+type ActivateDeactivatePowerLimitCmd struct {
+	Req ActivateDeactivatePowerLimitReq
+}
+
+// Name returns "Activate/Deactivate Power Limit".
+func (*ActivateDeactivatePowerLimitCmd) Name() string {
+	return "Activate/Deactivate Power Limit"
+}
+
+func (*ActivateDeactivatePowerLimitCmd) Operation() *ipmi.Operation {
+	return &operationActivateDeactivatePowerLimitReq
+}
+
+func (c *ActivateDeactivatePowerLimitCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*ActivateDeactivatePowerLimitCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,38 @@
+package dcmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestActivateDeactivatePowerLimitReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		in   *ActivateDeactivatePowerLimitReq
+		want []byte
+	}{
+		{
+			&ActivateDeactivatePowerLimitReq{},
+			[]byte{0x00, 0x00, 0x00},
+		},
+		{
+			&ActivateDeactivatePowerLimitReq{
+				Activate: true,
+			},
+			[]byte{0x01, 0x00, 0x00},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.in.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.in, err, test.want)
+			continue
+		}
+		got := sb.Bytes()
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package dcmi
+
+import (
+	"fmt"
+)
+
+// ExceptionAction is what the BMC does if a power limit is exceeded and cannot
+// be brought back under the limit within the correction time, specified in
+// 6.6.2 of DCMI v1.5. Values 0x02 through 0x10 are OEM-defined.
+type ExceptionAction uint8
+
+const (
+	// ExceptionActionNone means only the power limit is enforced; nothing
+	// further happens if it cannot be.
+	ExceptionActionNone ExceptionAction = 0x00
+
+	// ExceptionActionHardPowerOff turns the system off and logs an event to
+	// the SEL.
+	ExceptionActionHardPowerOff ExceptionAction = 0x01
+
+	// ExceptionActionLogEvent only logs an event to the SEL.
+	ExceptionActionLogEvent ExceptionAction = 0x11
+)
+
+// Description returns a human-friendly name for the action.
+func (e ExceptionAction) Description() string {
+	switch {
+	case e == ExceptionActionNone:
+		return "No action"
+	case e == ExceptionActionHardPowerOff:
+		return "Hard power off and log event"
+	case e == ExceptionActionLogEvent:
+		return "Log event"
+	case e >= 0x02 && e <= 0x10:
+		return "OEM"
+	default:
+		return "Unknown"
+	}
+}
+
+func (e ExceptionAction) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(e), e.Description())
+}
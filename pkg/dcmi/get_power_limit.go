@@ -0,0 +1,115 @@
+package dcmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// CompletionCodeNoActivePowerLimit is returned by Get Power Limit if the power
+// limit is not active. The response data is still present, and contains the
+// limit that would be enforced if it were activated.
+const CompletionCodeNoActivePowerLimit ipmi.CompletionCode = 0x80
+
+// GetPowerLimitReq represents the Get Power Limit command, specified in 6.6.2
+// of DCMI v1.0, v1.1 and v1.5. It has no parameters.
+type GetPowerLimitReq struct {
+	layers.BaseLayer
+}
+
+func (*GetPowerLimitReq) LayerType() gopacket.LayerType {
+	return layerTypeGetPowerLimitReq
+}
+
+func (*GetPowerLimitReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	// reserved
+	bytes[0] = 0x00
+	bytes[1] = 0x00
+	return nil
+}
+
+// GetPowerLimitRsp represents the response to a Get Power Limit command,
+// specified in 6.6.2. Whether the limit is being enforced is indicated by the
+// completion code: see CompletionCodeNoActivePowerLimit.
+type GetPowerLimitRsp struct {
+	layers.BaseLayer
+
+	// ExceptionAction is what happens if the limit cannot be maintained
+	// within the correction time.
+	ExceptionAction ExceptionAction
+
+	// Limit is the maximum power consumption of the system in watts.
+	Limit uint16
+
+	// CorrectionTime is the maximum time the system may exceed the limit
+	// before the exception action is taken. It has millisecond resolution.
+	CorrectionTime time.Duration
+
+	// SamplingPeriod is the period over which power is averaged to determine
+	// whether the limit has been exceeded. It has second resolution.
+	SamplingPeriod time.Duration
+}
+
+func (*GetPowerLimitRsp) LayerType() gopacket.LayerType {
+	return layerTypeGetPowerLimitRsp
+}
+
+func (g *GetPowerLimitRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetPowerLimitRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetPowerLimitRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 13 {
+		df.SetTruncated()
+		return fmt.Errorf("power limit response must be 13 bytes, got %v",
+			len(data))
+	}
+
+	// bytes 0 and 1 are reserved
+	g.ExceptionAction = ExceptionAction(data[2])
+	g.Limit = binary.LittleEndian.Uint16(data[3:5])
+	g.CorrectionTime = time.Millisecond *
+		time.Duration(binary.LittleEndian.Uint32(data[5:9]))
+	// bytes 9 and 10 are reserved
+	g.SamplingPeriod = time.Second *
+		time.Duration(binary.LittleEndian.Uint16(data[11:13]))
+
+	g.BaseLayer.Contents = data[:13]
+	g.BaseLayer.Payload = data[13:]
+	return nil
+}
+
+type GetPowerLimitCmd struct {
+	Req GetPowerLimitReq
+	Rsp GetPowerLimitRsp
+}
+
+// Name returns "Get Power Limit".
+func (*GetPowerLimitCmd) Name() string {
+	return "Get Power Limit"
+}
+
+func (*GetPowerLimitCmd) Operation() *ipmi.Operation {
+	return &operationGetPowerLimitReq
+}
+
+func (c *GetPowerLimitCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetPowerLimitCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
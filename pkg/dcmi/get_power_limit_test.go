@@ -0,0 +1,72 @@
+package dcmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetPowerLimitReqSerializeTo(t *testing.T) {
+	sb := gopacket.NewSerializeBuffer()
+	if err := (&GetPowerLimitReq{}).SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	if got, want := sb.Bytes(), []byte{0x00, 0x00}; !bytes.Equal(got, want) {
+		t.Errorf("serialize = %v, want %v", got, want)
+	}
+}
+
+func TestGetPowerLimitRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetPowerLimitRsp // nil if error
+	}{
+		{
+			[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00},
+			nil,
+		},
+		{
+			[]byte{
+				0x00, 0x00,
+				0x11,
+				0x5e, 0x01,
+				0xe8, 0x03, 0x00, 0x00,
+				0x00, 0x00,
+				0x05, 0x00,
+				0xff,
+			},
+			&GetPowerLimitRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x00, 0x11, 0x5e, 0x01, 0xe8, 0x03,
+						0x00, 0x00, 0x00, 0x00, 0x05, 0x00},
+					Payload: []byte{0xff},
+				},
+				ExceptionAction: ExceptionActionLogEvent,
+				Limit:           350,
+				CorrectionTime:  time.Second,
+				SamplingPeriod:  time.Second * 5,
+			},
+		},
+	}
+	layer := &GetPowerLimitRsp{}
+	for _, test := range tests {
+		err := layer.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("decode %v succeeded with %v, wanted error", test.in,
+				layer)
+		case err != nil && test.want != nil:
+			t.Errorf("decode %v failed with %v, wanted %v", test.in, err,
+				test.want)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, layer); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, layer, test.want, diff)
+			}
+		}
+	}
+}
@@ -70,7 +70,9 @@ type GetPowerReadingRsp struct {
 	Avg uint16
 
 	// Timestamp indicates when the power readings are for. If using enhanced
-	// power statistics, this is the end of the averaging window.
+	// power statistics, this is the end of the averaging window. It is the
+	// zero value if unspecified, and converted as described by
+	// ipmi.Timestamp.Time(), assuming the BMC keeps UTC.
 	Timestamp time.Time
 
 	// Period is the sampling period over which the controller is reporting
@@ -109,10 +111,7 @@ func (g *GetPowerReadingRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeed
 	g.Max = binary.LittleEndian.Uint16(data[4:6])
 	g.Avg = binary.LittleEndian.Uint16(data[6:8])
 
-	// TODO possibly handle unspecified (0xffffffff) and relative to system
-	// startup (<0x20000000) numbers of seconds - unclear how prevalent these
-	// are. Rules are in section 37 of IPMI v2.0. Don't forget tests.
-	g.Timestamp = time.Unix(int64(binary.LittleEndian.Uint32(data[8:12])), 0)
+	g.Timestamp = ipmi.Timestamp(binary.LittleEndian.Uint32(data[8:12])).Time(0)
 	g.Period = time.Millisecond *
 		time.Duration(binary.LittleEndian.Uint32(data[12:16]))
 	g.Active = data[16]&(1<<6) != 0
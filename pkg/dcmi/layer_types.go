@@ -88,4 +88,31 @@ var (
 			}),
 		},
 	)
+	layerTypeGetPowerLimitReq = gopacket.RegisterLayerType(
+		2010,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Power Limit Request",
+		},
+	)
+	layerTypeGetPowerLimitRsp = gopacket.RegisterLayerType(
+		2011,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Power Limit Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPowerLimitRsp{}
+			}),
+		},
+	)
+	layerTypeSetPowerLimitReq = gopacket.RegisterLayerType(
+		2012,
+		gopacket.LayerTypeMetadata{
+			Name: "Set Power Limit Request",
+		},
+	)
+	layerTypeActivateDeactivatePowerLimitReq = gopacket.RegisterLayerType(
+		2013,
+		gopacket.LayerTypeMetadata{
+			Name: "Activate/Deactivate Power Limit Request",
+		},
+	)
 )
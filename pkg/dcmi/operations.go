@@ -15,9 +15,58 @@ var (
 		Body:     ipmi.BodyCodeDCMI,
 		Command:  0x02,
 	}
+	operationGetPowerLimitReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodeDCMI,
+		Command:  0x03,
+	}
+	operationSetPowerLimitReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodeDCMI,
+		Command:  0x04,
+	}
+	operationActivateDeactivatePowerLimitReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodeDCMI,
+		Command:  0x05,
+	}
 	operationGetDCMISensorInfoReq = ipmi.Operation{
 		Function: ipmi.NetworkFunctionGroupReq,
 		Body:     ipmi.BodyCodeDCMI,
 		Command:  0x07,
 	}
 )
+
+func init() {
+	for _, op := range []ipmi.Operation{
+		operationGetDCMICapabilitiesInfoReq,
+		operationGetPowerReadingReq,
+		operationGetPowerLimitReq,
+		operationGetDCMISensorInfoReq,
+	} {
+		ipmi.RegisterPrivilegeLevel(op, ipmi.PrivilegeLevelUser)
+	}
+	for _, op := range []ipmi.Operation{
+		operationSetPowerLimitReq,
+		operationActivateDeactivatePowerLimitReq,
+	} {
+		ipmi.RegisterPrivilegeLevel(op, ipmi.PrivilegeLevelOperator)
+		ipmi.RegisterOperationEffect(op, ipmi.OperationEffectStateChanging)
+	}
+	// Get Power Reading is omitted so its decoder can explain 0-byte
+	// responses, and Get DCMI Capabilities Info's length depends on the
+	// parameter
+	ipmi.RegisterResponseLength(operationGetPowerLimitReq,
+		ipmi.ResponseLength{Min: 13, Max: 13})
+	ipmi.RegisterResponseLength(operationGetDCMISensorInfoReq,
+		ipmi.ResponseLength{Min: 2})
+	ipmi.RegisterCompletionCode(operationGetPowerLimitReq,
+		CompletionCodeNoActivePowerLimit, "No Active Power Limit")
+	for code, description := range map[ipmi.CompletionCode]string{
+		CompletionCodePowerLimitOutOfRange:     "Power Limit Out of Range",
+		CompletionCodeCorrectionTimeOutOfRange: "Correction Time Out of Range",
+		CompletionCodeSamplingPeriodOutOfRange: "Sampling Period Out of Range",
+	} {
+		ipmi.RegisterCompletionCode(operationSetPowerLimitReq, code, description)
+	}
+}
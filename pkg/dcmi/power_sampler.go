@@ -0,0 +1,161 @@
+package dcmi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// PowerSample is a single instantaneous power reading.
+type PowerSample struct {
+
+	// Timestamp is when the reading was taken, according to the local clock.
+	// BMC clocks are frequently wrong, so are not used.
+	Timestamp time.Time
+
+	// Watts is the power consumption at Timestamp.
+	Watts uint16
+}
+
+// PowerSource returns the current power consumption in watts. It allows a
+// PowerSampler to be used with any command that reports instantaneous power,
+// e.g. DCMI's Get Power Reading, or Intel Node Manager's Get Node Manager
+// Statistics. A source can also be wrapped to observe failed readings, which
+// the sampler otherwise skips.
+type PowerSource func(context.Context) (uint16, error)
+
+// NewPowerSource returns a PowerSource that sends Get Power Reading in normal
+// mode, using the instantaneous reading.
+func NewPowerSource(c SessionCommands) PowerSource {
+	req := &GetPowerReadingReq{
+		Mode: SystemPowerStatisticsModeNormal,
+	}
+	return func(ctx context.Context) (uint16, error) {
+		rsp, err := c.GetPowerReading(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		return rsp.Instantaneous, nil
+	}
+}
+
+// errNoPowerSamples is returned by PowerSampler.Statistics() if no readings
+// fall within the window.
+var errNoPowerSamples = errors.New("no power samples within window")
+
+// PowerSampler collects instantaneous power readings and computes the minimum,
+// maximum and average over a sliding window. This emulates the enhanced system
+// power statistics of Get Power Reading, for BMCs that do not support them, or
+// do not support the desired period; its statistics are returned in the same
+// form, so can be used interchangeably. Only samples taken within the window
+// are retained. It is safe for concurrent use.
+type PowerSampler struct {
+	source PowerSource
+	window time.Duration
+
+	mu      sync.Mutex
+	samples []PowerSample
+}
+
+// NewPowerSampler creates a sampler reading from source, computing statistics
+// over the provided window, e.g. 5 minutes. For the statistics to be
+// representative, the window should be several times the sampling interval.
+func NewPowerSampler(source PowerSource, window time.Duration) *PowerSampler {
+	return &PowerSampler{
+		source: source,
+		window: window,
+	}
+}
+
+// Sample takes a reading from the source and adds it to the window, returning
+// it. Nothing is added if an error is returned.
+func (s *PowerSampler) Sample(ctx context.Context) (PowerSample, error) {
+	watts, err := s.source(ctx)
+	if err != nil {
+		return PowerSample{}, err
+	}
+	sample := PowerSample{
+		Timestamp: time.Now(),
+		Watts:     watts,
+	}
+	s.Add(sample)
+	return sample, nil
+}
+
+// Add adds a sample taken by other means to the window, discarding samples
+// that fall outside it as a result. Samples must be added in chronological
+// order.
+func (s *PowerSampler) Add(sample PowerSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, sample)
+	cutoff := sample.Timestamp.Add(-s.window)
+	i := 0
+	for i < len(s.samples) && s.samples[i].Timestamp.Before(cutoff) {
+		i++
+	}
+	// copy rather than reslice, so the backing array does not grow forever
+	s.samples = append(s.samples[:0], s.samples[i:]...)
+}
+
+// Run samples immediately, then at the provided interval, until the context
+// expires, at which point its error is returned. Failed readings are skipped.
+func (s *PowerSampler) Run(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		_, _ = s.Sample(ctx)
+		timer.Reset(interval)
+	}
+}
+
+// Samples returns a copy of the samples currently within the window, oldest
+// first.
+func (s *PowerSampler) Samples() []PowerSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PowerSample(nil), s.samples...)
+}
+
+// Statistics summarises the samples within the window. Instantaneous is the
+// most recent sample, Timestamp is when it was taken, and Period is the time
+// between the oldest and most recent samples, which will be less than the
+// window until it has filled. The average is the mean of the samples, so
+// assumes they were taken at a regular interval. Active is always true. An
+// error is returned if there are no samples.
+func (s *PowerSampler) Statistics() (*GetPowerReadingRsp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.samples) == 0 {
+		return nil, errNoPowerSamples
+	}
+	oldest := s.samples[0]
+	latest := s.samples[len(s.samples)-1]
+	rsp := &GetPowerReadingRsp{
+		Instantaneous: latest.Watts,
+		Min:           latest.Watts,
+		Max:           latest.Watts,
+		Timestamp:     latest.Timestamp,
+		Period:        latest.Timestamp.Sub(oldest.Timestamp),
+		Active:        true,
+	}
+	sum := uint64(0)
+	for _, sample := range s.samples {
+		if sample.Watts < rsp.Min {
+			rsp.Min = sample.Watts
+		}
+		if sample.Watts > rsp.Max {
+			rsp.Max = sample.Watts
+		}
+		sum += uint64(sample.Watts)
+	}
+	n := uint64(len(s.samples))
+	rsp.Avg = uint16((sum + n/2) / n)
+	return rsp, nil
+}
@@ -0,0 +1,68 @@
+package dcmi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPowerSamplerStatistics(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	sampler := NewPowerSampler(nil, time.Minute)
+	if _, err := sampler.Statistics(); err == nil {
+		t.Errorf("Statistics() with no samples succeeded, wanted error")
+	}
+	// the first two fall out of the window
+	for i, watts := range []uint16{500, 50, 200, 100, 301} {
+		sampler.Add(PowerSample{
+			Timestamp: start.Add(time.Duration(i) * 30 * time.Second),
+			Watts:     watts,
+		})
+	}
+	got, err := sampler.Statistics()
+	if err != nil {
+		t.Fatalf("Statistics() = %v", err)
+	}
+	want := &GetPowerReadingRsp{
+		Instantaneous: 301,
+		Min:           100,
+		Max:           301,
+		Avg:           200,
+		Timestamp:     start.Add(2 * time.Minute),
+		Period:        time.Minute,
+		Active:        true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Statistics() mismatch (-want +got):\n%v", diff)
+	}
+	if n := len(sampler.Samples()); n != 3 {
+		t.Errorf("Samples() returned %v samples, want 3", n)
+	}
+}
+
+func TestPowerSamplerSample(t *testing.T) {
+	readings := []uint16{120, 0}
+	errs := []error{nil, errors.New("no reading")}
+	sampler := NewPowerSampler(func(context.Context) (uint16, error) {
+		watts, err := readings[0], errs[0]
+		readings, errs = readings[1:], errs[1:]
+		return watts, err
+	}, time.Hour)
+	ctx := context.Background()
+	sample, err := sampler.Sample(ctx)
+	if err != nil {
+		t.Fatalf("Sample() = %v", err)
+	}
+	if sample.Watts != 120 || sample.Timestamp.IsZero() {
+		t.Errorf("Sample() = %+v, want 120 W with timestamp", sample)
+	}
+	if _, err := sampler.Sample(ctx); err == nil {
+		t.Errorf("Sample() with failing source succeeded, wanted error")
+	}
+	if n := len(sampler.Samples()); n != 1 {
+		t.Errorf("Samples() returned %v samples, want 1", n)
+	}
+}
@@ -21,6 +21,33 @@ func (s sessionCommander) GetPowerReading(ctx context.Context, r *GetPowerReadin
 	return &cmd.Rsp, nil
 }
 
+func (s sessionCommander) GetPowerLimit(ctx context.Context) (*GetPowerLimitRsp, bool, error) {
+	cmd := &GetPowerLimitCmd{}
+	code, err := s.SendCommand(ctx, cmd)
+	if err == nil && code == CompletionCodeNoActivePowerLimit {
+		// the response is still populated
+		return &cmd.Rsp, false, nil
+	}
+	if err := bmc.ValidateResponse(code, err); err != nil {
+		return nil, false, err
+	}
+	return &cmd.Rsp, true, nil
+}
+
+func (s sessionCommander) SetPowerLimit(ctx context.Context, r *SetPowerLimitReq) error {
+	cmd := &SetPowerLimitCmd{
+		Req: *r,
+	}
+	return bmc.ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+func (s sessionCommander) ActivateDeactivatePowerLimit(ctx context.Context, r *ActivateDeactivatePowerLimitReq) error {
+	cmd := &ActivateDeactivatePowerLimitCmd{
+		Req: *r,
+	}
+	return bmc.ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
 func (s sessionCommander) GetDCMISensorInfo(ctx context.Context, r *GetDCMISensorInfoReq) (*GetDCMISensorInfoRsp, error) {
 	cmd := &GetDCMISensorInfoCmd{
 		Req: *r,
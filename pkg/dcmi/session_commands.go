@@ -11,5 +11,11 @@ type SessionCommands interface {
 
 	GetPowerReading(context.Context, *GetPowerReadingReq) (*GetPowerReadingRsp, error)
 
+	// GetPowerLimit returns the configured power limit, and whether it is
+	// active, i.e. being enforced.
+	GetPowerLimit(context.Context) (*GetPowerLimitRsp, bool, error)
+	SetPowerLimit(context.Context, *SetPowerLimitReq) error
+	ActivateDeactivatePowerLimit(context.Context, *ActivateDeactivatePowerLimitReq) error
+
 	GetDCMISensorInfo(context.Context, *GetDCMISensorInfoReq) (*GetDCMISensorInfoRsp, error)
 }
@@ -0,0 +1,96 @@
+package dcmi
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// CompletionCodePowerLimitOutOfRange is returned by Set Power Limit if
+	// the BMC cannot enforce the requested limit.
+	CompletionCodePowerLimitOutOfRange ipmi.CompletionCode = 0x84
+
+	// CompletionCodeCorrectionTimeOutOfRange is returned by Set Power Limit
+	// if the correction time is too short or long for the BMC.
+	CompletionCodeCorrectionTimeOutOfRange ipmi.CompletionCode = 0x85
+
+	// CompletionCodeSamplingPeriodOutOfRange is returned by Set Power Limit
+	// if the statistics sampling period is not supported.
+	CompletionCodeSamplingPeriodOutOfRange ipmi.CompletionCode = 0x89
+)
+
+// SetPowerLimitReq represents the Set Power Limit command, specified in 6.6.3
+// of DCMI v1.0, v1.1 and v1.5. It configures the limit, but does not activate
+// it; see ActivateDeactivatePowerLimitReq. If the limit is already active, the
+// new limit takes effect immediately. The response has no data.
+type SetPowerLimitReq struct {
+	layers.BaseLayer
+
+	// ExceptionAction is what to do if the limit cannot be maintained within
+	// the correction time.
+	ExceptionAction ExceptionAction
+
+	// Limit is the maximum power consumption of the system in watts.
+	Limit uint16
+
+	// CorrectionTime is the maximum time the system may exceed the limit
+	// before the exception action is taken. It is truncated to millisecond
+	// resolution.
+	CorrectionTime time.Duration
+
+	// SamplingPeriod is the period over which power is averaged to determine
+	// whether the limit has been exceeded. It is truncated to second
+	// resolution.
+	SamplingPeriod time.Duration
+}
+
+func (*SetPowerLimitReq) LayerType() gopacket.LayerType {
+	return layerTypeSetPowerLimitReq
+}
+
+func (s *SetPowerLimitReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(14)
+	if err != nil {
+		return err
+	}
+	// reserved
+	bytes[0] = 0x00
+	bytes[1] = 0x00
+	bytes[2] = 0x00
+	bytes[3] = uint8(s.ExceptionAction)
+	binary.LittleEndian.PutUint16(bytes[4:6], s.Limit)
+	binary.LittleEndian.PutUint32(bytes[6:10],
+		uint32(s.CorrectionTime/time.Millisecond))
+	// reserved
+	bytes[10] = 0x00
+	bytes[11] = 0x00
+	binary.LittleEndian.PutUint16(bytes[12:14],
+		uint16(s.SamplingPeriod/time.Second))
+	return nil
+}
+
+type SetPowerLimitCmd struct {
+	Req SetPowerLimitReq
+}
+
+// Name returns "Set Power Limit".
+func (*SetPowerLimitCmd) Name() string {
+	return "Set Power Limit"
+}
+
+func (*SetPowerLimitCmd) Operation() *ipmi.Operation {
+	return &operationSetPowerLimitReq
+}
+
+func (c *SetPowerLimitCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetPowerLimitCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
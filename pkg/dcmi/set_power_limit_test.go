@@ -0,0 +1,49 @@
+package dcmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetPowerLimitReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		in   *SetPowerLimitReq
+		want []byte
+	}{
+		{
+			&SetPowerLimitReq{},
+			make([]byte, 14),
+		},
+		{
+			&SetPowerLimitReq{
+				ExceptionAction: ExceptionActionHardPowerOff,
+				Limit:           350,
+				CorrectionTime:  time.Second + time.Microsecond, // truncated
+				SamplingPeriod:  time.Second * 5,
+			},
+			[]byte{
+				0x00, 0x00, 0x00,
+				0x01,
+				0x5e, 0x01,
+				0xe8, 0x03, 0x00, 0x00,
+				0x00, 0x00,
+				0x05, 0x00,
+			},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.in.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.in, err, test.want)
+			continue
+		}
+		got := sb.Bytes()
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
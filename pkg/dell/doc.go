@@ -0,0 +1,7 @@
+// Package dell implements OEM extensions for Dell PowerEdge servers, managed
+// by iDRAC. Importing this package registers the extensions for BMCs reporting
+// Dell's enterprise number, so that e.g. bmc.V2Session's SetLCDText() sets the
+// text on the front-panel LCD. iDRAC implements the standard Chassis Identify
+// command, so identification uses bmc.Identify(). The OEM commands are not
+// publicly documented; they are those used by ipmitool's delloem commands.
+package dell
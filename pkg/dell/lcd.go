@@ -0,0 +1,38 @@
+package dell
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// SystemInfoParameterLCDString is the OEM system info parameter containing
+	// the user-defined LCD text, in the format of a standard string
+	// parameter.
+	SystemInfoParameterLCDString ipmi.SystemInfoParameter = 0xc1
+
+	// MaxLCDTextLength is the longest text iDRAC will accept for the LCD,
+	// in characters.
+	MaxLCDTextLength = 62
+)
+
+// LCDText returns the user-defined text on the front-panel LCD. The LCD only
+// shows it if configured to display user-defined text, e.g. via the iDRAC
+// web interface or racadm.
+func LCDText(ctx context.Context, c bmc.Connection) (string, error) {
+	return bmc.GetSystemInfoString(ctx, c, SystemInfoParameterLCDString)
+}
+
+// SetLCDText sets the user-defined text on the front-panel LCD, which must
+// be at most MaxLCDTextLength characters.
+func SetLCDText(ctx context.Context, c bmc.Connection, text string) error {
+	if n := utf8.RuneCountInString(text); n > MaxLCDTextLength {
+		return fmt.Errorf("LCD text must be at most %v characters, got %v",
+			MaxLCDTextLength, n)
+	}
+	return bmc.SetSystemInfoString(ctx, c, SystemInfoParameterLCDString, text)
+}
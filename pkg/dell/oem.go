@@ -0,0 +1,31 @@
+package dell
+
+import (
+	"context"
+
+	"github.com/kuiwang02/bmc"
+	"github.com/kuiwang02/bmc/pkg/iana"
+)
+
+// OEM implements the Dell extensions supported by this package. It is
+// registered for iana.EnterpriseDell when the package is imported.
+type OEM struct{}
+
+func init() {
+	bmc.RegisterOEM(iana.EnterpriseDell, OEM{})
+}
+
+// Name returns "Dell".
+func (OEM) Name() string {
+	return "Dell"
+}
+
+// LCDText calls LCDText(), implementing bmc.LCD.
+func (OEM) LCDText(ctx context.Context, s bmc.Session) (string, error) {
+	return LCDText(ctx, s)
+}
+
+// SetLCDText calls SetLCDText(), implementing bmc.LCD.
+func (OEM) SetLCDText(ctx context.Context, s bmc.Session, text string) error {
+	return SetLCDText(ctx, s, text)
+}
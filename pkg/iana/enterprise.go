@@ -2,6 +2,7 @@ package iana
 
 import (
 	"fmt"
+	"sync"
 )
 
 // Enterprise represents an IANA Private Enterprise Number. A list of
@@ -12,6 +13,17 @@ import (
 type Enterprise uint32
 
 const (
+	// EnterpriseReserved is reserved by IANA, and is returned by some BMCs
+	// that do not set a manufacturer.
+	EnterpriseReserved Enterprise = 0
+
+	// EnterpriseIBM is the enterprise number of IBM.
+	EnterpriseIBM Enterprise = 2
+
+	// EnterpriseHP is the enterprise number of Hewlett-Packard, still used by
+	// the BMCs of many Hewlett Packard Enterprise servers.
+	EnterpriseHP Enterprise = 11
+
 	// EnterpriseIntel is the enterprise number of Intel Corporation.
 	EnterpriseIntel Enterprise = 343
 
@@ -29,26 +41,58 @@ const (
 	// Ltd.
 	EnterpriseGigaByte Enterprise = 15370
 
+	// EnterpriseLenovo is the enterprise number of Lenovo Enterprise
+	// Business Group.
+	EnterpriseLenovo Enterprise = 19046
+
 	// EnterpriseAten is the enterprise number of ATEN International Co., Ltd.
 	EnterpriseAten Enterprise = 21317
+
+	// EnterpriseHPE is the enterprise number of Hewlett Packard Enterprise.
+	EnterpriseHPE Enterprise = 47196
+
+	// EnterpriseOpenBMC is the enterprise number of the OpenBMC Project. Note
+	// that vendors shipping OpenBMC-derived firmware often report their own
+	// enterprise number instead.
+	EnterpriseOpenBMC Enterprise = 49622
 )
 
 var (
 	// enterpriseOrganisations contains a few common Enterprise Numbers along
 	// with their official organisation names to handle the majority of cases.
+	// Further names can be added with RegisterEnterprise().
 	enterpriseOrganisations = map[Enterprise]string{
+		EnterpriseReserved:   "Reserved",
+		EnterpriseIBM:        "IBM",
+		EnterpriseHP:         "Hewlett-Packard",
 		EnterpriseIntel:      "Intel Corporation",
 		EnterpriseDell:       "Dell Inc.",
 		EnterpriseQuanta:     "Quanta Computer Inc.",
 		EnterpriseSuperMicro: "Super Micro Computer Inc.",
 		EnterpriseGigaByte:   "GIGA-BYTE TECHNOLOGY CO., LTD",
+		EnterpriseLenovo:     "Lenovo Enterprise Business Group",
 		EnterpriseAten:       "ATEN INTERNATIONAL CO., LTD.",
+		EnterpriseHPE:        "Hewlett Packard Enterprise",
+		EnterpriseOpenBMC:    "OpenBMC Project",
 	}
+	enterpriseOrganisationsMu sync.RWMutex
 )
 
+// RegisterEnterprise sets the organisation name returned for an enterprise
+// number, replacing any existing name. It allows users to name the enterprises
+// they encounter that this package does not know about. It is safe to call
+// concurrently, but would normally be called from an init function.
+func RegisterEnterprise(e Enterprise, organisation string) {
+	enterpriseOrganisationsMu.Lock()
+	defer enterpriseOrganisationsMu.Unlock()
+	enterpriseOrganisations[e] = organisation
+}
+
 // Organisation returns the official name of the organisation behind a given
 // enterprise number, or "Unknown" if it is not recognised.
 func (e Enterprise) Organisation() string {
+	enterpriseOrganisationsMu.RLock()
+	defer enterpriseOrganisationsMu.RUnlock()
 	if name, ok := enterpriseOrganisations[e]; ok {
 		return name
 	}
@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// AddSDRReq represents an Add SDR command, specified in section 27.13 and
+// 33.13 of IPMI v1.5 and v2.0 respectively. It adds a complete record to the
+// SDR Repository in a single request. If the record does not fit in the BMC's
+// input buffer, Partial Add SDR must be used instead.
+type AddSDRReq struct {
+	layers.BaseLayer
+
+	// Record is the entire SDR to add, including the header. The record ID in
+	// the header is ignored by the BMC, which assigns its own.
+	Record []byte
+}
+
+func (*AddSDRReq) LayerType() gopacket.LayerType {
+	return LayerTypeAddSDRReq
+}
+
+func (r *AddSDRReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(len(r.Record))
+	if err != nil {
+		return err
+	}
+	copy(bytes, r.Record)
+	return nil
+}
+
+// AddSDRRsp contains the record ID assigned to the added SDR.
+type AddSDRRsp struct {
+	layers.BaseLayer
+
+	// RecordID is the ID the BMC assigned to the new record.
+	RecordID RecordID
+}
+
+func (*AddSDRRsp) LayerType() gopacket.LayerType {
+	return LayerTypeAddSDRRsp
+}
+
+func (r *AddSDRRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*AddSDRRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *AddSDRRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+
+	r.RecordID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type AddSDRCmd struct {
+	Req AddSDRReq
+	Rsp AddSDRRsp
+}
+
+// Name returns "Add SDR".
+func (*AddSDRCmd) Name() string {
+	return "Add SDR"
+}
+
+// Operation returns OperationAddSDRReq.
+func (*AddSDRCmd) Operation() *Operation {
+	return &OperationAddSDRReq
+}
+
+func (c *AddSDRCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *AddSDRCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -16,13 +16,27 @@ func (a Address) IsSoftwareID() bool {
 	return !a.IsSlaveAddress()
 }
 
+// SlaveAddress returns the 7-bit slave address held by the address, and
+// whether the address is in fact a slave address. If it is not, the returned
+// value is meaningless.
+func (a Address) SlaveAddress() (SlaveAddress, bool) {
+	return SlaveAddress(a >> 1), a.IsSlaveAddress()
+}
+
+// SoftwareID returns the 7-bit software ID held by the address, and whether the
+// address is in fact a software ID. If it is not, the returned value is
+// meaningless.
+func (a Address) SoftwareID() (SoftwareID, bool) {
+	return SoftwareID(a >> 1), a.IsSoftwareID()
+}
+
 func (a Address) String() string {
 	switch {
 	case a.IsSlaveAddress():
-		sa := SlaveAddress(a >> 1)
+		sa, _ := a.SlaveAddress()
 		return fmt.Sprintf("%v(%v)", uint8(sa), sa.String())
 	default: // only two cases
-		swid := SoftwareID(a >> 1)
+		swid, _ := a.SoftwareID()
 		return fmt.Sprintf("%v(%v)", uint8(swid), swid.String())
 	}
 }
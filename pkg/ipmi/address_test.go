@@ -0,0 +1,61 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		addr         Address
+		slaveAddress SlaveAddress
+		isSlave      bool
+		softwareID   SoftwareID
+		isSoftwareID bool
+		wantString   string
+	}{
+		{
+			addr:         SlaveAddressBMC.Address(),
+			slaveAddress: SlaveAddressBMC,
+			isSlave:      true,
+			softwareID:   SoftwareID(0x10),
+			wantString:   "16(BMC)",
+		},
+		{
+			addr:         Address(0x2c),
+			slaveAddress: SlaveAddress(0x16),
+			isSlave:      true,
+			softwareID:   SoftwareID(0x16),
+			wantString:   "22(0x16)",
+		},
+		{
+			addr:         SoftwareIDRemoteConsole1.Address(),
+			slaveAddress: SlaveAddress(0x40),
+			softwareID:   SoftwareIDRemoteConsole1,
+			isSoftwareID: true,
+			wantString:   "64(Remote Console #1)",
+		},
+		{
+			addr:         SoftwareIDRemoteConsole7.Address(),
+			slaveAddress: SlaveAddress(0x46),
+			softwareID:   SoftwareIDRemoteConsole7,
+			isSoftwareID: true,
+			wantString:   "70(Remote Console #7)",
+		},
+	}
+	for _, test := range tests {
+		sa, ok := test.addr.SlaveAddress()
+		if sa != test.slaveAddress || ok != test.isSlave {
+			t.Errorf("%#x.SlaveAddress() = %v, %v; want %v, %v", uint8(test.addr),
+				sa, ok, test.slaveAddress, test.isSlave)
+		}
+		swid, ok := test.addr.SoftwareID()
+		if swid != test.softwareID || ok != test.isSoftwareID {
+			t.Errorf("%#x.SoftwareID() = %v, %v; want %v, %v", uint8(test.addr),
+				swid, ok, test.softwareID, test.isSoftwareID)
+		}
+		if got := test.addr.String(); got != test.wantString {
+			t.Errorf("%#x.String() = %v, want %v", uint8(test.addr), got,
+				test.wantString)
+		}
+	}
+}
@@ -94,8 +94,6 @@ func (a *AES128CBC) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.Serial
 	}
 	trailer[padLength] = uint8(padLength)
 
-	toEncrypt := b.Bytes() // includes confidentiality trailer
-
 	// secure random IV for confidentiality header
 	iv, err := b.PrependBytes(a.cipher.BlockSize())
 	if err != nil {
@@ -105,7 +103,9 @@ func (a *AES128CBC) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.Serial
 		return err
 	}
 
-	// encrypt everything after IV
+	// encrypt everything after IV, including the confidentiality trailer. This
+	// must be taken after prepending, as prepending can reallocate the buffer
+	toEncrypt := b.Bytes()[a.cipher.BlockSize():]
 	mode := cipher.NewCBCEncrypter(a.cipher, iv)
 	mode.CryptBlocks(toEncrypt, toEncrypt)
 	return nil
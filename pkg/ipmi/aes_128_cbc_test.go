@@ -128,3 +128,39 @@ func TestAES128CBCDecodeFromBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestAES128CBCSerializeTo(t *testing.T) {
+	table := [][]byte{
+		{},
+		{0x01, 0x02, 0x03, 0x04},
+		{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b,
+			0x0c, 0x0d, 0x0e, 0x0f},
+	}
+	for _, message := range table {
+		layer, err := NewAES128CBC([16]byte{0x01, 0x02, 0x03})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// a fresh buffer has no room to prepend the IV, so must reallocate
+		sb := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(sb, gopacket.SerializeOptions{},
+			layer, gopacket.Payload(message)); err != nil {
+			t.Errorf("serialize %v = error %v, want nil", message, err)
+			continue
+		}
+		wire := sb.Bytes()
+		if bytes.Contains(wire, message) && len(message) > 0 {
+			t.Errorf("serialize %v = %v, which contains the plaintext",
+				message, wire)
+			continue
+		}
+		if err := layer.DecodeFromBytes(wire, gopacket.NilDecodeFeedback); err != nil {
+			t.Errorf("decode %v = error %v, want %v", wire, err, message)
+			continue
+		}
+		if !bytes.Equal(layer.Payload, message) {
+			t.Errorf("decode %v = %v, want %v", wire, layer.Payload, message)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// BootDevice overrides the device the system boots from, specified in data 2
+// of the boot flags parameter in table 28-14 of IPMI v2.0. It is a 4-bit uint
+// on the wire.
+type BootDevice uint8
+
+const (
+	// BootDeviceNone leaves the system's configured boot order unchanged.
+	BootDeviceNone BootDevice = 0x0
+
+	// BootDevicePXE forces a network boot.
+	BootDevicePXE BootDevice = 0x1
+
+	// BootDeviceDisk forces a boot from the default hard drive.
+	BootDeviceDisk BootDevice = 0x2
+
+	// BootDeviceDiskSafeMode forces a boot from the default hard drive,
+	// requesting safe mode.
+	BootDeviceDiskSafeMode BootDevice = 0x3
+
+	// BootDeviceDiagnosticPartition forces a boot from the default
+	// diagnostic partition.
+	BootDeviceDiagnosticPartition BootDevice = 0x4
+
+	// BootDeviceCDROM forces a boot from the default CD/DVD drive.
+	BootDeviceCDROM BootDevice = 0x5
+
+	// BootDeviceBIOSSetup causes the system to enter the BIOS or UEFI setup
+	// utility rather than booting.
+	BootDeviceBIOSSetup BootDevice = 0x6
+
+	// BootDeviceRemoteFloppy forces a boot from remotely connected
+	// floppy or primary removable media.
+	BootDeviceRemoteFloppy BootDevice = 0x7
+
+	// BootDeviceRemoteCDROM forces a boot from remotely connected CD/DVD
+	// media, e.g. virtual media mounted via the BMC.
+	BootDeviceRemoteCDROM BootDevice = 0x8
+
+	// BootDeviceRemoteMedia forces a boot from remotely connected primary
+	// media.
+	BootDeviceRemoteMedia BootDevice = 0x9
+
+	// BootDeviceRemoteDisk forces a boot from a remotely connected hard
+	// drive.
+	BootDeviceRemoteDisk BootDevice = 0xb
+
+	// BootDeviceFloppy forces a boot from floppy or primary removable media.
+	BootDeviceFloppy BootDevice = 0xf
+)
+
+func (d BootDevice) Description() string {
+	switch d {
+	case BootDeviceNone:
+		return "No override"
+	case BootDevicePXE:
+		return "PXE"
+	case BootDeviceDisk:
+		return "Hard drive"
+	case BootDeviceDiskSafeMode:
+		return "Hard drive, safe mode"
+	case BootDeviceDiagnosticPartition:
+		return "Diagnostic partition"
+	case BootDeviceCDROM:
+		return "CD/DVD"
+	case BootDeviceBIOSSetup:
+		return "BIOS setup"
+	case BootDeviceRemoteFloppy:
+		return "Remote floppy/primary removable media"
+	case BootDeviceRemoteCDROM:
+		return "Remote CD/DVD"
+	case BootDeviceRemoteMedia:
+		return "Remote primary media"
+	case BootDeviceRemoteDisk:
+		return "Remote hard drive"
+	case BootDeviceFloppy:
+		return "Floppy/primary removable media"
+	default:
+		return "Unknown"
+	}
+}
+
+func (d BootDevice) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(d), d.Description())
+}
+
+// BootFlags is the boot flags system boot option parameter (5), specified in
+// table 28-14 of IPMI v2.0. It directs the BIOS to boot from a particular
+// device on the next boot, or every boot. Only the commonly supported fields
+// are exposed; the remaining bits are sent as zero.
+type BootFlags struct {
+	layers.BaseLayer
+
+	// Valid indicates the flags should be honoured. If false, the BIOS
+	// ignores the remaining fields. Most BMCs automatically clear this after
+	// 60 seconds unless the system is restarted, as configured by the BMC
+	// boot flag valid bit clearing parameter.
+	Valid bool
+
+	// Persistent indicates the flags apply to all future boots, rather than
+	// only the next one.
+	Persistent bool
+
+	// EFI indicates the BIOS should boot in UEFI mode rather than legacy PC
+	// compatible mode.
+	EFI bool
+
+	// Device is the device to boot from.
+	Device BootDevice
+}
+
+func (*BootFlags) LayerType() gopacket.LayerType {
+	return LayerTypeBootFlags
+}
+
+func (f *BootFlags) CanDecode() gopacket.LayerClass {
+	return f.LayerType()
+}
+
+func (*BootFlags) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (f *BootFlags) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 5 {
+		df.SetTruncated()
+		return fmt.Errorf("boot flags must be 5 bytes, got %v", len(data))
+	}
+
+	f.BaseLayer.Contents = data[:5]
+	f.BaseLayer.Payload = data[5:]
+
+	f.Valid = data[0]&(1<<7) != 0
+	f.Persistent = data[0]&(1<<6) != 0
+	f.EFI = data[0]&(1<<5) != 0
+	f.Device = BootDevice(data[1] >> 2 & 0xf)
+	return nil
+}
+
+func (f *BootFlags) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(5)
+	if err != nil {
+		return err
+	}
+	bytes[0] = 0
+	if f.Valid {
+		bytes[0] |= 1 << 7
+	}
+	if f.Persistent {
+		bytes[0] |= 1 << 6
+	}
+	if f.EFI {
+		bytes[0] |= 1 << 5
+	}
+	bytes[1] = uint8(f.Device&0xf) << 2
+	bytes[2] = 0
+	bytes[3] = 0
+	bytes[4] = 0
+	return nil
+}
+
+func (f *BootFlags) String() string {
+	return fmt.Sprintf("BootFlags(Valid: %v, Persistent: %v, EFI: %v, "+
+		"Device: %v)", f.Valid, f.Persistent, f.EFI, f.Device)
+}
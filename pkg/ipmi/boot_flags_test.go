@@ -0,0 +1,126 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmitest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestBootFlagsSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *BootFlags
+		want  []byte
+	}{
+		{
+			&BootFlags{},
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			&BootFlags{
+				Valid:  true,
+				Device: BootDevicePXE,
+			},
+			[]byte{0x80, 0x04, 0x00, 0x00, 0x00},
+		},
+		{
+			&BootFlags{
+				Valid:      true,
+				Persistent: true,
+				EFI:        true,
+				Device:     BootDeviceBIOSSetup,
+			},
+			[]byte{0xe0, 0x18, 0x00, 0x00, 0x00},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestBootFlagsDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *BootFlags
+	}{
+		// too short
+		{
+			make([]byte, 4),
+			nil,
+		},
+		{
+			[]byte{0xa0, 0x08, 0x00, 0x00, 0x00},
+			&BootFlags{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xa0, 0x08, 0x00, 0x00, 0x00},
+					Payload:  []byte{},
+				},
+				Valid:  true,
+				EFI:    true,
+				Device: BootDeviceDisk,
+			},
+		},
+		{
+			// CMOS clear, lock keyboard and screen blank are ignored
+			[]byte{0x40, 0xc6, 0x00, 0x00, 0x00},
+			&BootFlags{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x40, 0xc6, 0x00, 0x00, 0x00},
+					Payload:  []byte{},
+				},
+				Persistent: true,
+				Device:     BootDevicePXE,
+			},
+		},
+	}
+	for _, test := range tests {
+		flags := &BootFlags{}
+		err := flags.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, flags); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, flags, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestBootFlagsRoundTrip(t *testing.T) {
+	fresh := func() ipmitest.Layer {
+		return &BootFlags{}
+	}
+	ipmitest.RoundTrip(t, &BootFlags{
+		Valid:  true,
+		Device: BootDeviceRemoteCDROM,
+	}, fresh, []byte{0x80, 0x20, 0x00, 0x00, 0x00})
+	ipmitest.RoundTrip(t, &BootFlags{
+		Persistent: true,
+		EFI:        true,
+		Device:     BootDevicePXE,
+	}, fresh, []byte{0x60, 0x04, 0x00, 0x00, 0x00})
+}
+
+func FuzzBootFlagsDecodeFromBytes(f *testing.F) {
+	ipmitest.SeedCorpus(f,
+		[]byte{0xa0, 0x08, 0x00, 0x00, 0x00},
+		[]byte{0x40, 0xc6, 0x00, 0x00, 0x00},
+	)
+	ipmitest.FuzzDecode(f, func() gopacket.DecodingLayer {
+		return &BootFlags{}
+	})
+}
@@ -0,0 +1,48 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// BootOptionParameter identifies a system boot option, set and retrieved via
+// the Set and Get System Boot Options commands. Values are specified in table
+// 28-14 of IPMI v2.0. It is a 7-bit uint on the wire.
+type BootOptionParameter uint8
+
+const (
+	BootOptionParameterSetInProgress               BootOptionParameter = 0
+	BootOptionParameterServicePartitionSelector    BootOptionParameter = 1
+	BootOptionParameterServicePartitionScan        BootOptionParameter = 2
+	BootOptionParameterBMCBootFlagValidBitClearing BootOptionParameter = 3
+	BootOptionParameterBootInfoAcknowledge         BootOptionParameter = 4
+	BootOptionParameterBootFlags                   BootOptionParameter = 5
+	BootOptionParameterBootInitiatorInfo           BootOptionParameter = 6
+	BootOptionParameterBootInitiatorMailbox        BootOptionParameter = 7
+)
+
+func (p BootOptionParameter) Description() string {
+	switch p {
+	case BootOptionParameterSetInProgress:
+		return "Set In Progress"
+	case BootOptionParameterServicePartitionSelector:
+		return "Service Partition Selector"
+	case BootOptionParameterServicePartitionScan:
+		return "Service Partition Scan"
+	case BootOptionParameterBMCBootFlagValidBitClearing:
+		return "BMC Boot Flag Valid Bit Clearing"
+	case BootOptionParameterBootInfoAcknowledge:
+		return "Boot Info Acknowledge"
+	case BootOptionParameterBootFlags:
+		return "Boot Flags"
+	case BootOptionParameterBootInitiatorInfo:
+		return "Boot Initiator Info"
+	case BootOptionParameterBootInitiatorMailbox:
+		return "Boot Initiator Mailbox"
+	default:
+		return "Unknown"
+	}
+}
+
+func (p BootOptionParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
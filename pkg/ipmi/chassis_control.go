@@ -35,7 +35,10 @@ const (
 
 	// ChassisControlDiagnosticInterrupt pulses a diagnostic interrupt to the
 	// CPU(s), usually causing a diagnostic dump. The exact interrupt delivered
-	// is architecture-dependent.
+	// is architecture-dependent; on x86 it is an NMI. This is the "pulse
+	// Diagnostic Interrupt" command of the spec. Not all chassis support it;
+	// check ProvidesDiagnosticInterrupt in the Get Chassis Capabilities
+	// response.
 	ChassisControlDiagnosticInterrupt
 
 	// ChassisControlSoftPowerOff emulates a fatal over-temperature, causing a
@@ -0,0 +1,62 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ChassisIdentifyReq represents a Chassis Identify command, specified in
+// section 28.5 of IPMI v2.0. It causes the chassis to physically identify
+// itself, typically by blinking a front-panel light, so it can be located in a
+// datacenter.
+type ChassisIdentifyReq struct {
+	layers.BaseLayer
+
+	// Interval is the number of seconds to identify for, after which the
+	// chassis stops. An interval of 0 stops identifying immediately. Ignored
+	// if Force is set.
+	Interval uint8
+
+	// Force causes the chassis to identify until explicitly stopped. This
+	// field was added in IPMI v2.0, and is optional.
+	Force bool
+}
+
+func (*ChassisIdentifyReq) LayerType() gopacket.LayerType {
+	return LayerTypeChassisIdentifyReq
+}
+
+func (c *ChassisIdentifyReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = c.Interval
+	bytes[1] = 0
+	if c.Force {
+		bytes[1] = 1
+	}
+	return nil
+}
+
+type ChassisIdentifyCmd struct {
+	Req ChassisIdentifyReq
+}
+
+// Name returns "Chassis Identify".
+func (*ChassisIdentifyCmd) Name() string {
+	return "Chassis Identify"
+}
+
+// Operation returns &OperationChassisIdentifyReq.
+func (*ChassisIdentifyCmd) Operation() *Operation {
+	return &OperationChassisIdentifyReq
+}
+
+func (c *ChassisIdentifyCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*ChassisIdentifyCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,17 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmitest"
+)
+
+func TestChassisIdentifyReqSerializeTo(t *testing.T) {
+	ipmitest.Serialize(t, &ChassisIdentifyReq{}, []byte{0x00, 0x00})
+	ipmitest.Serialize(t, &ChassisIdentifyReq{
+		Interval: 15,
+	}, []byte{0x0f, 0x00})
+	ipmitest.Serialize(t, &ChassisIdentifyReq{
+		Force: true,
+	}, []byte{0x00, 0x01})
+}
@@ -0,0 +1,86 @@
+package ipmi
+
+// CipherSuite is the combination of algorithms identified by a cipher suite
+// ID, as defined in table 22-20 of IPMI v2.0. BMCs and tools such as ipmitool
+// commonly refer to these by ID, e.g. 3 or 17.
+type CipherSuite struct {
+	Authentication  AuthenticationAlgorithm
+	Integrity       IntegrityAlgorithm
+	Confidentiality ConfidentialityAlgorithm
+}
+
+// IsSecure returns whether the cipher suite provides both integrity and
+// confidentiality, without relying on MD5.
+func (c CipherSuite) IsSecure() bool {
+	return c.Authentication != AuthenticationAlgorithmHMACMD5 &&
+		c.Integrity != IntegrityAlgorithmNone &&
+		c.Integrity != IntegrityAlgorithmHMACMD5128 &&
+		c.Integrity != IntegrityAlgorithmMD5128 &&
+		c.Confidentiality != ConfidentialityAlgorithmNone
+}
+
+var (
+	// cipherSuites contains the cipher suites whose algorithms are all
+	// supported by the library, by ID.
+	cipherSuites = map[uint8]CipherSuite{
+		1: {
+			Authentication:  AuthenticationAlgorithmHMACSHA1,
+			Integrity:       IntegrityAlgorithmNone,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		2: {
+			Authentication:  AuthenticationAlgorithmHMACSHA1,
+			Integrity:       IntegrityAlgorithmHMACSHA196,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		3: {
+			Authentication:  AuthenticationAlgorithmHMACSHA1,
+			Integrity:       IntegrityAlgorithmHMACSHA196,
+			Confidentiality: ConfidentialityAlgorithmAESCBC128,
+		},
+		6: {
+			Authentication:  AuthenticationAlgorithmHMACMD5,
+			Integrity:       IntegrityAlgorithmNone,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		7: {
+			Authentication:  AuthenticationAlgorithmHMACMD5,
+			Integrity:       IntegrityAlgorithmHMACMD5128,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		8: {
+			Authentication:  AuthenticationAlgorithmHMACMD5,
+			Integrity:       IntegrityAlgorithmHMACMD5128,
+			Confidentiality: ConfidentialityAlgorithmAESCBC128,
+		},
+		15: {
+			Authentication:  AuthenticationAlgorithmHMACSHA256,
+			Integrity:       IntegrityAlgorithmNone,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		16: {
+			Authentication:  AuthenticationAlgorithmHMACSHA256,
+			Integrity:       IntegrityAlgorithmHMACSHA256128,
+			Confidentiality: ConfidentialityAlgorithmNone,
+		},
+		17: {
+			Authentication:  AuthenticationAlgorithmHMACSHA256,
+			Integrity:       IntegrityAlgorithmHMACSHA256128,
+			Confidentiality: ConfidentialityAlgorithmAESCBC128,
+		},
+	}
+)
+
+// LookupCipherSuite returns the algorithms of the cipher suite with the
+// provided ID. The second return value is false if the ID is unknown, or
+// requires an algorithm the library does not implement, e.g. xRC4.
+func LookupCipherSuite(id uint8) (CipherSuite, bool) {
+	suite, ok := cipherSuites[id]
+	return suite, ok
+}
+
+// CipherSuiteIDs returns the IDs of the cipher suites LookupCipherSuite()
+// recognises, in ascending order.
+func CipherSuiteIDs() []uint8 {
+	return []uint8{1, 2, 3, 6, 7, 8, 15, 16, 17}
+}
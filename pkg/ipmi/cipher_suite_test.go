@@ -0,0 +1,31 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestCipherSuiteIDs(t *testing.T) {
+	ids := CipherSuiteIDs()
+	if len(ids) != len(cipherSuites) {
+		t.Errorf("CipherSuiteIDs() returned %v IDs, but %v suites are defined",
+			len(ids), len(cipherSuites))
+	}
+	for i, id := range ids {
+		if _, ok := LookupCipherSuite(id); !ok {
+			t.Errorf("LookupCipherSuite(%v) not found", id)
+		}
+		if i > 0 && ids[i-1] >= id {
+			t.Errorf("CipherSuiteIDs() not in ascending order: %v", ids)
+		}
+	}
+}
+
+func TestCipherSuiteIsSecure(t *testing.T) {
+	for _, id := range CipherSuiteIDs() {
+		suite, _ := LookupCipherSuite(id)
+		want := id == 3 || id == 17
+		if got := suite.IsSecure(); got != want {
+			t.Errorf("cipher suite %v IsSecure() = %v, want %v", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ClearSDRRepositoryReq represents a Clear SDR Repository command, specified
+// in section 27.16 and 33.16 of IPMI v1.5 and v2.0 respectively. Like Clear
+// SEL, erasure is asynchronous, and its status must be polled using the same
+// reservation ID. The actions and progress values are shared with Clear SEL.
+type ClearSDRRepositoryReq struct {
+	layers.BaseLayer
+
+	// ReservationID is a token obtained via Reserve SDR Repository.
+	ReservationID ReservationID
+
+	// Action indicates whether to start erasure or retrieve its status.
+	Action ClearSELAction
+}
+
+func (*ClearSDRRepositoryReq) LayerType() gopacket.LayerType {
+	return LayerTypeClearSDRRepositoryReq
+}
+
+func (r *ClearSDRRepositoryReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(r.ReservationID))
+	bytes[2] = 'C'
+	bytes[3] = 'L'
+	bytes[4] = 'R'
+	bytes[5] = uint8(r.Action)
+	return nil
+}
+
+// ClearSDRRepositoryRsp represents the response to a Clear SDR Repository
+// request.
+type ClearSDRRepositoryRsp struct {
+	layers.BaseLayer
+
+	// Progress indicates whether the erasure has finished.
+	Progress ErasureProgress
+}
+
+func (*ClearSDRRepositoryRsp) LayerType() gopacket.LayerType {
+	return LayerTypeClearSDRRepositoryRsp
+}
+
+func (r *ClearSDRRepositoryRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ClearSDRRepositoryRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ClearSDRRepositoryRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Progress = ErasureProgress(data[0] & 0xf)
+	return nil
+}
+
+type ClearSDRRepositoryCmd struct {
+	Req ClearSDRRepositoryReq
+	Rsp ClearSDRRepositoryRsp
+}
+
+// Name returns "Clear SDR Repository".
+func (*ClearSDRRepositoryCmd) Name() string {
+	return "Clear SDR Repository"
+}
+
+// Operation returns OperationClearSDRRepositoryReq.
+func (*ClearSDRRepositoryCmd) Operation() *Operation {
+	return &OperationClearSDRRepositoryReq
+}
+
+func (c *ClearSDRRepositoryCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ClearSDRRepositoryCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
+
+// Effect returns OperationEffectNone if the request retrieves the erasure
+// status, otherwise the effect of OperationClearSDRRepositoryReq.
+func (c *ClearSDRRepositoryCmd) Effect() OperationEffect {
+	if c.Req.Action == ClearSELActionGetErasureStatus {
+		return OperationEffectNone
+	}
+	return OperationClearSDRRepositoryReq.Effect()
+}
@@ -0,0 +1,152 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ClearSELAction indicates whether a Clear SEL request starts erasure of the
+// SEL, or merely queries the progress of a previously initiated erasure. It is
+// a 1 byte uint on the wire.
+type ClearSELAction uint8
+
+const (
+	// ClearSELActionGetErasureStatus retrieves the status of an in-progress
+	// erasure, without initiating a new one.
+	ClearSELActionGetErasureStatus ClearSELAction = 0x00
+
+	// ClearSELActionInitiateErase starts erasing the SEL.
+	ClearSELActionInitiateErase ClearSELAction = 0xaa
+)
+
+func (a ClearSELAction) String() string {
+	switch a {
+	case ClearSELActionGetErasureStatus:
+		return "Get Erasure Status"
+	case ClearSELActionInitiateErase:
+		return "Initiate Erase"
+	default:
+		return fmt.Sprintf("%#x(Unknown)", uint8(a))
+	}
+}
+
+// ErasureProgress indicates whether an erasure of the SEL is complete. It
+// occupies the lower nibble of the byte on the wire.
+type ErasureProgress uint8
+
+const (
+	ErasureProgressInProgress ErasureProgress = 0x0
+	ErasureProgressCompleted  ErasureProgress = 0x1
+)
+
+func (p ErasureProgress) String() string {
+	switch p {
+	case ErasureProgressInProgress:
+		return "Erasure in Progress"
+	case ErasureProgressCompleted:
+		return "Erase Completed"
+	default:
+		return fmt.Sprintf("%#x(Unknown)", uint8(p))
+	}
+}
+
+// ClearSELReq represents a Clear SEL command, specified in section 25.9 and
+// 31.9 of IPMI v1.5 and v2.0 respectively. Erasure is asynchronous: the BMC
+// responds to the initiating request immediately, and the status must be
+// polled with the same reservation ID until it reports completion.
+type ClearSELReq struct {
+	layers.BaseLayer
+
+	// ReservationID is a token obtained via Reserve SEL. It is required for
+	// both initiating erasure and retrieving its status.
+	ReservationID ReservationID
+
+	// Action indicates whether to start erasure or retrieve its status.
+	Action ClearSELAction
+}
+
+func (*ClearSELReq) LayerType() gopacket.LayerType {
+	return LayerTypeClearSELReq
+}
+
+func (r *ClearSELReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(r.ReservationID))
+	// the spec requires "CLR" as a guard against accidental erasure
+	bytes[2] = 'C'
+	bytes[3] = 'L'
+	bytes[4] = 'R'
+	bytes[5] = uint8(r.Action)
+	return nil
+}
+
+// ClearSELRsp represents the response to a Clear SEL request.
+type ClearSELRsp struct {
+	layers.BaseLayer
+
+	// Progress indicates whether the erasure has finished.
+	Progress ErasureProgress
+}
+
+func (*ClearSELRsp) LayerType() gopacket.LayerType {
+	return LayerTypeClearSELRsp
+}
+
+func (r *ClearSELRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ClearSELRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ClearSELRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Progress = ErasureProgress(data[0] & 0xf)
+	return nil
+}
+
+type ClearSELCmd struct {
+	Req ClearSELReq
+	Rsp ClearSELRsp
+}
+
+// Name returns "Clear SEL".
+func (*ClearSELCmd) Name() string {
+	return "Clear SEL"
+}
+
+// Operation returns OperationClearSELReq.
+func (*ClearSELCmd) Operation() *Operation {
+	return &OperationClearSELReq
+}
+
+func (c *ClearSELCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ClearSELCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
+
+// Effect returns OperationEffectNone if the request retrieves the erasure
+// status, otherwise the effect of OperationClearSELReq.
+func (c *ClearSELCmd) Effect() OperationEffect {
+	if c.Req.Action == ClearSELActionGetErasureStatus {
+		return OperationEffectNone
+	}
+	return OperationClearSELReq.Effect()
+}
@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestClearSELReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *ClearSELReq
+		want  []byte
+	}{
+		{
+			&ClearSELReq{
+				ReservationID: 0x1234,
+				Action:        ClearSELActionInitiateErase,
+			},
+			[]byte{0x34, 0x12, 0x43, 0x4c, 0x52, 0xaa},
+		},
+		{
+			&ClearSELReq{
+				ReservationID: 0x0001,
+				Action:        ClearSELActionGetErasureStatus,
+			},
+			[]byte{0x01, 0x00, 0x43, 0x4c, 0x52, 0x00},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestClearSELRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *ClearSELRsp
+	}{
+		{
+			[]byte{},
+			nil,
+		},
+		{
+			[]byte{0x00},
+			&ClearSELRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00},
+					Payload:  []byte{},
+				},
+				Progress: ErasureProgressInProgress,
+			},
+		},
+		{
+			[]byte{0xf1, 0x02},
+			&ClearSELRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xf1},
+					Payload:  []byte{0x02},
+				},
+				Progress: ErasureProgressCompleted,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &ClearSELRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -42,3 +42,88 @@ type Command interface {
 	// additional memory.
 	Response() gopacket.DecodingLayer
 }
+
+// LUNCommand is an optional interface implemented by commands that must be sent
+// to a logical unit other than the BMC's own (LUN 0), for example the SEL or
+// sensors of a satellite controller exposed behind LUN 1-3. If a command does
+// not implement this interface, it is sent to LUNBMC.
+type LUNCommand interface {
+	Command
+
+	// LUN returns the responder's logical unit number to address the request
+	// to.
+	LUN() LUN
+}
+
+// lunCommand wraps a Command to implement LUNCommand.
+type lunCommand struct {
+	Command
+	lun LUN
+}
+
+func (c *lunCommand) LUN() LUN {
+	return c.lun
+}
+
+// Effect returns the effect of the wrapped command, which may implement
+// EffectCommand, so wrapping a command never changes how it is treated.
+func (c *lunCommand) Effect() OperationEffect {
+	if ec, ok := c.Command.(EffectCommand); ok {
+		return ec.Effect()
+	}
+	return c.Operation().Effect()
+}
+
+// CommandWithLUN returns a command that behaves identically to c, but is sent to
+// the provided LUN rather than LUN 0. The LUN must be in the range 0-3. As the
+// returned value wraps c, the response is still available via the original
+// command. If c is an AddressedCommand, the returned command is too, with the
+// same responder address.
+func CommandWithLUN(c Command, lun LUN) LUNCommand {
+	if ac, ok := c.(AddressedCommand); ok {
+		return CommandWithAddress(ac, ac.ResponderAddress(), lun)
+	}
+	return &lunCommand{
+		Command: c,
+		lun:     lun,
+	}
+}
+
+// AddressedCommand is an optional interface implemented by commands that must
+// be sent directly to a controller on the IPMB rather than to the BMC, by
+// setting the responder address in the message header, e.g. a FRU controller
+// in an ATCA shelf. This is an alternative to encapsulating the command in a
+// Send Message request, and is expected by some shelf managers. If a command
+// does not implement this interface, it is sent to the connection's default
+// responder address.
+type AddressedCommand interface {
+	LUNCommand
+
+	// ResponderAddress returns the slave address or software ID of the
+	// controller to address the request to.
+	ResponderAddress() Address
+}
+
+// addressedCommand wraps a Command to implement AddressedCommand.
+type addressedCommand struct {
+	lunCommand
+	address Address
+}
+
+func (c *addressedCommand) ResponderAddress() Address {
+	return c.address
+}
+
+// CommandWithAddress returns a command that behaves identically to c, but is
+// sent directly to the provided responder address and LUN, e.g.
+// SlaveAddress(0x41).Address(). As with CommandWithLUN, the response is still
+// available via the original command.
+func CommandWithAddress(c Command, address Address, lun LUN) AddressedCommand {
+	return &addressedCommand{
+		lunCommand: lunCommand{
+			Command: c,
+			lun:     lun,
+		},
+		address: address,
+	}
+}
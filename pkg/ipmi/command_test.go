@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestWrappedCommandEffect(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		want    OperationEffect
+	}{
+		{
+			name:    "operation",
+			command: CommandWithLUN(&ChassisControlCmd{}, LUNBMC),
+			want:    OperationEffectDestructive,
+		},
+		{
+			name: "initiate erase",
+			command: CommandWithLUN(&ClearSELCmd{
+				Req: ClearSELReq{
+					Action: ClearSELActionInitiateErase,
+				},
+			}, LUNBMC),
+			want: OperationEffectDestructive,
+		},
+		{
+			name: "get erasure status",
+			command: CommandWithLUN(&ClearSELCmd{
+				Req: ClearSELReq{
+					Action: ClearSELActionGetErasureStatus,
+				},
+			}, LUNBMC),
+			want: OperationEffectNone,
+		},
+		{
+			name: "addressed get erasure status",
+			command: CommandWithAddress(&ClearSELCmd{
+				Req: ClearSELReq{
+					Action: ClearSELActionGetErasureStatus,
+				},
+			}, SlaveAddress(0x41).Address(), LUNBMC),
+			want: OperationEffectNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ec, ok := test.command.(EffectCommand)
+			if !ok {
+				t.Fatalf("%T does not implement EffectCommand", test.command)
+			}
+			if got := ec.Effect(); got != test.want {
+				t.Errorf("Effect() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCommandWithLUNKeepsAddress(t *testing.T) {
+	address := SlaveAddress(0x41).Address()
+	c := CommandWithLUN(CommandWithAddress(&GetDeviceIDCmd{}, address,
+		LUNBMC), LUN(2))
+	ac, ok := c.(AddressedCommand)
+	if !ok {
+		t.Fatalf("CommandWithLUN() of an AddressedCommand returned %T, "+
+			"want AddressedCommand", c)
+	}
+	if got := ac.ResponderAddress(); got != address {
+		t.Errorf("ResponderAddress() = %v, want %v", got, address)
+	}
+	if got := ac.LUN(); got != LUN(2) {
+		t.Errorf("LUN() = %v, want %v", got, LUN(2))
+	}
+}
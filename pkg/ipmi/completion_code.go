@@ -22,40 +22,142 @@ const (
     // is untested.
 	CompletionCodeInvalidSessionID CompletionCode = 0x87
 
-	CompletionCodeNodeBusy            CompletionCode = 0xc0
-	CompletionCodeUnrecognisedCommand CompletionCode = 0xc1
-	CompletionCodeTimeout             CompletionCode = 0xc3
+	CompletionCodeNodeBusy             CompletionCode = 0xc0
+	CompletionCodeUnrecognisedCommand  CompletionCode = 0xc1
+	CompletionCodeInvalidCommandForLUN CompletionCode = 0xc2
+	CompletionCodeTimeout              CompletionCode = 0xc3
+
+	// CompletionCodeOutOfSpace indicates the BMC has insufficient storage
+	// for the request, e.g. adding a SEL entry when the SEL is full.
+	CompletionCodeOutOfSpace CompletionCode = 0xc4
+
+	// CompletionCodeReservationCancelled indicates the reservation ID provided
+	// in the request is no longer valid, e.g. because another party obtained a
+	// reservation in the meantime. A new reservation must be obtained.
+	CompletionCodeReservationCancelled CompletionCode = 0xc5
 
 	// CompletionCodeRequestTruncated means the request ended prematurely. Did
 	// you forget to add the final request data layer?
 	CompletionCodeRequestTruncated CompletionCode = 0xc6
 
+	// CompletionCodeRequestDataLengthInvalid indicates the request data is
+	// the wrong length for the command, e.g. because too many bytes were
+	// provided.
+	CompletionCodeRequestDataLengthInvalid CompletionCode = 0xc7
+
+	// CompletionCodeRequestDataFieldLengthLimitExceeded indicates a
+	// variable-length field in the request is too long.
+	CompletionCodeRequestDataFieldLengthLimitExceeded CompletionCode = 0xc8
+
+	// CompletionCodeParameterOutOfRange indicates a field in the request is
+	// outside the range the BMC supports.
+	CompletionCodeParameterOutOfRange CompletionCode = 0xc9
+
+	// CompletionCodeCannotReturnRequestedDataBytes indicates the response to
+	// the request would not fit in a message, e.g. because too many bytes were
+	// requested with Read FRU Data. Retrying with a smaller count may succeed.
+	CompletionCodeCannotReturnRequestedDataBytes CompletionCode = 0xca
+
+	// CompletionCodeNotPresent indicates the requested sensor, data or record
+	// does not exist, e.g. a sensor number absent from the SDR Repository.
+	CompletionCodeNotPresent CompletionCode = 0xcb
+
+	// CompletionCodeInvalidDataField indicates a field in the request has a
+	// value the command does not accept, e.g. a reserved enumeration value.
+	CompletionCodeInvalidDataField CompletionCode = 0xcc
+
+	// CompletionCodeIllegalForSensorOrRecordType indicates the command cannot
+	// be used with the specified sensor or record, e.g. reading the
+	// thresholds of a discrete sensor.
+	CompletionCodeIllegalForSensorOrRecordType CompletionCode = 0xcd
+
+	CompletionCodeResponseUnavailable CompletionCode = 0xce
+
+	// CompletionCodeDuplicateRequest indicates the BMC refused to execute a
+	// request it had already received, typically because the response was
+	// lost and the request was retried with the same sequence number.
+	CompletionCodeDuplicateRequest CompletionCode = 0xcf
+
+	// CompletionCodeSDRRepositoryInUpdateMode indicates the command cannot
+	// be executed while the SDR Repository is being updated.
+	CompletionCodeSDRRepositoryInUpdateMode CompletionCode = 0xd0
+
+	// CompletionCodeFirmwareUpdateMode indicates the command cannot be
+	// executed while the device's firmware is being updated.
+	CompletionCodeFirmwareUpdateMode CompletionCode = 0xd1
+
+	// CompletionCodeInitializationInProgress indicates the BMC, or its
+	// initialisation agent, is still initialising.
+	CompletionCodeInitializationInProgress CompletionCode = 0xd2
+
+	// CompletionCodeDestinationUnavailable indicates a bridged request could
+	// not be delivered, e.g. because the channel does not exist.
+	CompletionCodeDestinationUnavailable CompletionCode = 0xd3
+
 	// CompletionCodeInsufficientPrivileges indicates the channel or effective
 	// user privilege level is insufficient to execute the command, or the
 	// request was blocked by the firmware firewall.
 	CompletionCodeInsufficientPrivileges CompletionCode = 0xd4
 
+	// CompletionCodeIllegalInPresentState indicates the command, or a
+	// parameter of it, is not supported in the BMC's present state, e.g.
+	// activating SOL when the payload is disabled.
+	CompletionCodeIllegalInPresentState CompletionCode = 0xd5
+
+	// CompletionCodeSubFunctionUnavailable indicates the requested
+	// sub-function of the command has been disabled or is unavailable, e.g.
+	// due to licensing.
+	CompletionCodeSubFunctionUnavailable CompletionCode = 0xd6
+
 	CompletionCodeUnspecified CompletionCode = 0xff
 )
 
 var (
 	completionCodeDescriptions = map[CompletionCode]string{
-		CompletionCodeNormal:                 "Normal",
-		CompletionCodeInvalidSessionID:       "Invalid Session ID",
-		CompletionCodeNodeBusy:               "Node Busy",
-		CompletionCodeUnrecognisedCommand:    "Unrecognised Command",
-		CompletionCodeTimeout:                "Timeout",
-		CompletionCodeRequestTruncated:       "Request Truncated",
-		CompletionCodeInsufficientPrivileges: "Insufficient Privileges",
-		CompletionCodeUnspecified:            "Unspecified Error",
+		CompletionCodeNormal:                              "Normal",
+		CompletionCodeInvalidSessionID:                    "Invalid Session ID",
+		CompletionCodeNodeBusy:                            "Node Busy",
+		CompletionCodeUnrecognisedCommand:                 "Unrecognised Command",
+		CompletionCodeInvalidCommandForLUN:                "Invalid Command for LUN",
+		CompletionCodeTimeout:                             "Timeout",
+		CompletionCodeOutOfSpace:                          "Out of Space",
+		CompletionCodeReservationCancelled:                "Reservation Cancelled",
+		CompletionCodeRequestTruncated:                    "Request Truncated",
+		CompletionCodeRequestDataLengthInvalid:            "Request Data Length Invalid",
+		CompletionCodeRequestDataFieldLengthLimitExceeded: "Request Data Field Length Limit Exceeded",
+		CompletionCodeParameterOutOfRange:                 "Parameter Out of Range",
+		CompletionCodeCannotReturnRequestedDataBytes:      "Cannot Return Requested Data Bytes",
+		CompletionCodeNotPresent:                          "Not Present",
+		CompletionCodeInvalidDataField:                    "Invalid Data Field",
+		CompletionCodeIllegalForSensorOrRecordType:        "Illegal for Sensor or Record Type",
+		CompletionCodeResponseUnavailable:                 "Response Unavailable",
+		CompletionCodeDuplicateRequest:                    "Duplicate Request",
+		CompletionCodeSDRRepositoryInUpdateMode:           "SDR Repository in Update Mode",
+		CompletionCodeFirmwareUpdateMode:                  "Firmware Update Mode",
+		CompletionCodeInitializationInProgress:            "Initialization in Progress",
+		CompletionCodeDestinationUnavailable:              "Destination Unavailable",
+		CompletionCodeInsufficientPrivileges:              "Insufficient Privileges",
+		CompletionCodeIllegalInPresentState:               "Illegal in Present State",
+		CompletionCodeSubFunctionUnavailable:              "Sub-Function Unavailable",
+		CompletionCodeUnspecified:                         "Unspecified Error",
 	}
 )
 
+// Description returns the name of the code from Table 5-2. Codes specific to
+// a command or manufacturer are described by range only; use DescriptionFor()
+// to resolve them.
 func (c CompletionCode) Description() string {
 	if description, ok := completionCodeDescriptions[c]; ok {
 		return description
 	}
-	return "Unknown"
+	switch {
+	case c >= 0x01 && c <= 0x7e:
+		return "Device-Specific"
+	case c >= 0x80 && c <= 0xbe:
+		return "Command-Specific"
+	default:
+		return "Unknown"
+	}
 }
 
 // IsTemporary returns whether the code indicates a retry may produce a
@@ -0,0 +1,98 @@
+package ipmi
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+)
+
+// oemCompletionCode identifies a completion code whose meaning is defined by a
+// manufacturer for a given command.
+type oemCompletionCode struct {
+	manufacturer iana.Enterprise
+	operation    Operation
+	code         CompletionCode
+}
+
+var (
+	// commandCompletionCodes contains the descriptions of command-specific
+	// codes (0x80-0xbe) defined by the specification, keyed by request
+	// operation.
+	commandCompletionCodes = map[Operation]map[CompletionCode]string{
+		OperationSetUserPasswordReq: {
+			CompletionCodePasswordMismatch:     "Password Mismatch",
+			CompletionCodePasswordSizeMismatch: "Password Size Mismatch",
+		},
+		OperationResetWatchdogTimerReq: {
+			CompletionCodeWatchdogNotInitialised: "Watchdog Not Initialised",
+		},
+		OperationGetChannelOEMPayloadInfoReq: {
+			0x80: "OEM Payload IANA and/or Payload ID Not Supported",
+			0x81: "Payload Type Not Supported",
+		},
+	}
+
+	// oemCompletionCodes contains the descriptions of codes defined by
+	// manufacturers, which take precedence over commandCompletionCodes.
+	oemCompletionCodes = map[oemCompletionCode]string{}
+
+	completionCodesMu sync.RWMutex
+)
+
+// RegisterCompletionCode sets the description of a command-specific completion
+// code defined by the specification, replacing any existing description. As
+// with RegisterRetryPolicy(), it is intended for packages implementing
+// commands outside this one, and would normally be called from an init
+// function.
+func RegisterCompletionCode(op Operation, code CompletionCode, description string) {
+	completionCodesMu.Lock()
+	defer completionCodesMu.Unlock()
+	codes, ok := commandCompletionCodes[op]
+	if !ok {
+		codes = map[CompletionCode]string{}
+		commandCompletionCodes[op] = codes
+	}
+	codes[code] = description
+}
+
+// RegisterOEMCompletionCode sets the description of a completion code returned
+// by a manufacturer's BMCs in response to a request operation, replacing any
+// existing description. This allows device- and command-specific codes whose
+// meaning varies by manufacturer, e.g. Dell's use of the 0x80 range for
+// standard commands, to be named in error messages. It is safe to call
+// concurrently, but would normally be called from an init function.
+func RegisterOEMCompletionCode(manufacturer iana.Enterprise, op Operation, code CompletionCode, description string) {
+	completionCodesMu.Lock()
+	defer completionCodesMu.Unlock()
+	oemCompletionCodes[oemCompletionCode{
+		manufacturer: manufacturer,
+		operation:    op,
+		code:         code,
+	}] = description
+}
+
+// DescriptionFor returns the name of the code in a response to a request with
+// the provided operation, from a BMC made by manufacturer, as returned in Get
+// Device ID. Descriptions registered for the manufacturer take precedence,
+// followed by those specific to the command, falling back to Description().
+func (c CompletionCode) DescriptionFor(op *Operation, manufacturer iana.Enterprise) string {
+	completionCodesMu.RLock()
+	defer completionCodesMu.RUnlock()
+	if description, ok := oemCompletionCodes[oemCompletionCode{
+		manufacturer: manufacturer,
+		operation:    *op,
+		code:         c,
+	}]; ok {
+		return description
+	}
+	if description, ok := commandCompletionCodes[*op][c]; ok {
+		return description
+	}
+	return c.Description()
+}
+
+// StringFor is equivalent to String(), but uses DescriptionFor().
+func (c CompletionCode) StringFor(op *Operation, manufacturer iana.Enterprise) string {
+	return fmt.Sprintf("%#.2x(%v)", uint8(c), c.DescriptionFor(op, manufacturer))
+}
@@ -0,0 +1,77 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+)
+
+func TestCompletionCodeDescription(t *testing.T) {
+	tests := []struct {
+		code CompletionCode
+		want string
+	}{
+		{CompletionCodeNormal, "Normal"},
+		{CompletionCodeInvalidDataField, "Invalid Data Field"},
+		{CompletionCodeSubFunctionUnavailable, "Sub-Function Unavailable"},
+		{0x01, "Device-Specific"},
+		{0x7e, "Device-Specific"},
+		{0x7f, "Unknown"},
+		{0x80, "Command-Specific"},
+		{0xbe, "Command-Specific"},
+		{0xd7, "Unknown"},
+	}
+	for _, test := range tests {
+		if got := test.code.Description(); got != test.want {
+			t.Errorf("%#x.Description() = %v, want %v", uint8(test.code),
+				got, test.want)
+		}
+	}
+}
+
+func TestCompletionCodeDescriptionFor(t *testing.T) {
+	op := Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0xfe,
+	}
+	RegisterCompletionCode(op, 0x80, "Standard")
+	RegisterOEMCompletionCode(iana.EnterpriseDell, op, 0x80, "Dell")
+	RegisterOEMCompletionCode(iana.EnterpriseDell, op, 0x01, "Dell OEM")
+	defer func() {
+		completionCodesMu.Lock()
+		delete(commandCompletionCodes, op)
+		for key := range oemCompletionCodes {
+			if key.operation == op {
+				delete(oemCompletionCodes, key)
+			}
+		}
+		completionCodesMu.Unlock()
+	}()
+
+	tests := []struct {
+		code         CompletionCode
+		op           *Operation
+		manufacturer iana.Enterprise
+		want         string
+	}{
+		{0x80, &op, iana.EnterpriseDell, "Dell"},
+		{0x80, &op, iana.EnterpriseIntel, "Standard"},
+		{0x01, &op, iana.EnterpriseDell, "Dell OEM"},
+		{0x01, &op, iana.EnterpriseIntel, "Device-Specific"},
+		{CompletionCodeNodeBusy, &op, iana.EnterpriseDell, "Node Busy"},
+		{CompletionCodePasswordMismatch, &OperationSetUserPasswordReq, 0, "Password Mismatch"},
+		{CompletionCodeWatchdogNotInitialised, &OperationResetWatchdogTimerReq, 0, "Watchdog Not Initialised"},
+		{0x80, &OperationGetDeviceIDReq, 0, "Command-Specific"},
+	}
+	for _, test := range tests {
+		if got := test.code.DescriptionFor(test.op, test.manufacturer); got != test.want {
+			t.Errorf("%#x.DescriptionFor(%v, %v) = %v, want %v",
+				uint8(test.code), test.op, test.manufacturer, got, test.want)
+		}
+	}
+
+	want := "0x80(Dell)"
+	if got := CompletionCode(0x80).StringFor(&op, iana.EnterpriseDell); got != want {
+		t.Errorf("StringFor() = %v, want %v", got, want)
+	}
+}
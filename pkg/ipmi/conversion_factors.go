@@ -2,6 +2,8 @@ package ipmi
 
 import (
 	"math"
+
+	"github.com/kuiwang02/bmc/internal/pkg/complement"
 )
 
 // ConversionFactors contains inputs to the linear formula in 30.3 and 36.3 of
@@ -52,3 +54,27 @@ func (f *ConversionFactors) ConvertReading(raw int16) float64 {
 	b10k1 := float64(f.B) * math.Pow10(int(f.BExp))
 	return (float64(mX) + b10k1) * math.Pow10(int(f.RExp))
 }
+
+// Resolution returns the difference between readings converted from
+// consecutive raw values, i.e. the smallest change in reading the sensor can
+// report, before linearisation.
+func (f *ConversionFactors) Resolution() float64 {
+	return math.Abs(float64(f.M)) * math.Pow10(int(f.RExp))
+}
+
+// decode parses the M, B, BExp and RExp fields from the 6-byte wire format
+// shared by bytes 25-30 of the Full Sensor Record and bytes 3-8 of the Get
+// Sensor Reading Factors response. The tolerance and accuracy fields
+// interleaved with them are left to the caller.
+func (f *ConversionFactors) decode(data []byte) {
+	buf := [...]byte{data[1] >> 6, data[0]}
+	f.M = complement.Twos(buf, 10)
+	buf[1] = data[2]
+	buf[0] = data[3] >> 6
+	f.B = complement.Twos(buf, 10)
+	buf[0] = 0
+	buf[1] = data[5] >> 4
+	f.RExp = int8(complement.Twos(buf, 4))
+	buf[1] = data[5] & 0xf
+	f.BExp = int8(complement.Twos(buf, 4))
+}
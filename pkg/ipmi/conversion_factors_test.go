@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"math"
 	"testing"
 )
 
@@ -28,3 +29,20 @@ func TestConversionFactorsConvertReading(t *testing.T) {
 		}
 	}
 }
+
+func TestConversionFactorsResolution(t *testing.T) {
+	tests := []struct {
+		cf   ConversionFactors
+		want float64
+	}{
+		{ConversionFactors{1, 0, 0, 0}, 1},
+		{ConversionFactors{100, 0, 0, 0}, 100},
+		{ConversionFactors{15, 179, 0, -3}, 0.015},
+		{ConversionFactors{-2, 0, 0, 1}, 20},
+	}
+	for _, test := range tests {
+		if got := test.cf.Resolution(); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("%+v.Resolution() = %v, want %v", test.cf, got, test.want)
+		}
+	}
+}
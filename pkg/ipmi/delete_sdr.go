@@ -0,0 +1,92 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// DeleteSDRReq represents a Delete SDR command, specified in section 27.15 and
+// 33.15 of IPMI v1.5 and v2.0 respectively. Support for this command is
+// indicated by GetSDRRepositoryInfoRsp.SupportsDelete.
+type DeleteSDRReq struct {
+	layers.BaseLayer
+
+	// ReservationID is a token obtained via Reserve SDR Repository.
+	ReservationID ReservationID
+
+	// RecordID identifies the record to delete.
+	RecordID RecordID
+}
+
+func (*DeleteSDRReq) LayerType() gopacket.LayerType {
+	return LayerTypeDeleteSDRReq
+}
+
+func (r *DeleteSDRReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(r.ReservationID))
+	binary.LittleEndian.PutUint16(bytes[2:4], uint16(r.RecordID))
+	return nil
+}
+
+// DeleteSDRRsp contains the ID of the deleted record.
+type DeleteSDRRsp struct {
+	layers.BaseLayer
+
+	// RecordID is the ID of the record that was deleted.
+	RecordID RecordID
+}
+
+func (*DeleteSDRRsp) LayerType() gopacket.LayerType {
+	return LayerTypeDeleteSDRRsp
+}
+
+func (r *DeleteSDRRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*DeleteSDRRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *DeleteSDRRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+
+	r.RecordID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type DeleteSDRCmd struct {
+	Req DeleteSDRReq
+	Rsp DeleteSDRRsp
+}
+
+// Name returns "Delete SDR".
+func (*DeleteSDRCmd) Name() string {
+	return "Delete SDR"
+}
+
+// Operation returns OperationDeleteSDRReq.
+func (*DeleteSDRCmd) Operation() *Operation {
+	return &OperationDeleteSDRReq
+}
+
+func (c *DeleteSDRCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *DeleteSDRCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -18,7 +18,7 @@ type EntityID uint8
 const (
 	EntityIDUnspecified EntityID = iota
 	EntityIDOther
-	_
+	EntityIDUnknown
 	EntityIDProcessor
 	EntityIDDisk
 	EntityIDPeripheralBay
@@ -32,12 +32,46 @@ const (
 	EntityIDBackPanelBoard
 	EntityIDPowerSystemBoard
 	EntityIDDriveBackplane
-
-	EntityIDSystemChassis EntityID = 0x17
-	EntityIDCoolingDevice EntityID = 0x1d
-	EntityIDMemoryDevice  EntityID = 0x20
-
-	EntityIDAirInlet EntityID = 0x37
+	EntityIDSystemInternalExpansionBoard
+	EntityIDOtherSystemBoard
+	EntityIDProcessorBoard
+	EntityIDPowerUnit
+	EntityIDPowerModule
+	EntityIDPowerManagementBoard
+	EntityIDChassisBackPanelBoard
+	EntityIDSystemChassis
+	EntityIDSubChassis
+	EntityIDOtherChassisBoard
+	EntityIDDiskDriveBay
+	EntityIDChassisPeripheralBay
+	EntityIDDeviceBay
+	EntityIDCoolingDevice
+	EntityIDCoolingUnit
+	EntityIDCableInterconnect
+	EntityIDMemoryDevice
+	EntityIDSystemManagementSoftware
+	EntityIDSystemFirmware
+	EntityIDOperatingSystem
+	EntityIDSystemBus
+	EntityIDGroup
+	EntityIDRemoteManagementCommunicationDevice
+	EntityIDExternalEnvironment
+	EntityIDBattery
+	EntityIDProcessingBlade
+	EntityIDConnectivitySwitch
+	EntityIDProcessorMemoryModule
+	EntityIDIOModule
+	EntityIDProcessorIOModule
+	EntityIDManagementControllerFirmware
+	EntityIDIPMIChannel
+	EntityIDPCIBus
+	EntityIDPCIExpressBus
+	EntityIDSCSIBus
+	EntityIDSATASASBus
+	EntityIDProcessorFrontSideBus
+	EntityIDRealTimeClock
+	_
+	EntityIDAirInlet
 
 	// EntityIDDCMIAirInlet allows associating temperature sensors to the
 	// airflow at an air inlet. This is effectively deprecated, used by DCMI
@@ -55,36 +89,85 @@ const (
 
 var (
 	entityIdDescriptions = map[EntityID]string{
-		EntityIDUnspecified:            "Unspecified",
-		EntityIDOther:                  "Other",
-		EntityIDProcessor:              "Processor",
-		EntityIDDisk:                   "Disk (Bay)",
-		EntityIDPeripheralBay:          "Peripheral Bay",
-		EntityIDSystemManagementModule: "System Management Module",
-		EntityIDSystemBoard:            "System Board",
-		EntityIDMemoryModule:           "Memory Module",
-		EntityIDProcessorModule:        "Processor Module",
-		EntityIDPowerSupply:            "Power Supply",
-		EntityIDAddInCard:              "Add-in Card",
-		EntityIDFrontPanelBoard:        "Front Panel Board",
-		EntityIDBackPanelBoard:         "Back Panel Board",
-		EntityIDPowerSystemBoard:       "Power System Board",
-		EntityIDDriveBackplane:         "Drive Backplane",
-		EntityIDSystemChassis:          "System Chassis",
-		EntityIDCoolingDevice:          "Cooling Device",
-		EntityIDMemoryDevice:           "Memory Device",
-		EntityIDAirInlet:               "Air Inlet",
-		EntityIDDCMIAirInlet:           "Air Inlet (DCMI)",
-		EntityIDDCMIProcessor:          "Processor (DCMI)",
-		EntityIDDCMISystemBoard:        "System Board (DCMI)",
+		EntityIDUnspecified:                         "Unspecified",
+		EntityIDOther:                               "Other",
+		EntityIDUnknown:                             "Unknown",
+		EntityIDProcessor:                           "Processor",
+		EntityIDDisk:                                "Disk (Bay)",
+		EntityIDPeripheralBay:                       "Peripheral Bay",
+		EntityIDSystemManagementModule:              "System Management Module",
+		EntityIDSystemBoard:                         "System Board",
+		EntityIDMemoryModule:                        "Memory Module",
+		EntityIDProcessorModule:                     "Processor Module",
+		EntityIDPowerSupply:                         "Power Supply",
+		EntityIDAddInCard:                           "Add-in Card",
+		EntityIDFrontPanelBoard:                     "Front Panel Board",
+		EntityIDBackPanelBoard:                      "Back Panel Board",
+		EntityIDPowerSystemBoard:                    "Power System Board",
+		EntityIDDriveBackplane:                      "Drive Backplane",
+		EntityIDSystemInternalExpansionBoard:        "System Internal Expansion Board",
+		EntityIDOtherSystemBoard:                    "Other System Board",
+		EntityIDProcessorBoard:                      "Processor Board",
+		EntityIDPowerUnit:                           "Power Unit/Domain",
+		EntityIDPowerModule:                         "Power Module/DC-to-DC Converter",
+		EntityIDPowerManagementBoard:                "Power Management/Distribution Board",
+		EntityIDChassisBackPanelBoard:               "Chassis Back Panel Board",
+		EntityIDSystemChassis:                       "System Chassis",
+		EntityIDSubChassis:                          "Sub-chassis",
+		EntityIDOtherChassisBoard:                   "Other Chassis Board",
+		EntityIDDiskDriveBay:                        "Disk Drive Bay",
+		EntityIDChassisPeripheralBay:                "Peripheral Bay (Chassis)",
+		EntityIDDeviceBay:                           "Device Bay",
+		EntityIDCoolingDevice:                       "Cooling Device",
+		EntityIDCoolingUnit:                         "Cooling Unit/Domain",
+		EntityIDCableInterconnect:                   "Cable/Interconnect",
+		EntityIDMemoryDevice:                        "Memory Device",
+		EntityIDSystemManagementSoftware:            "System Management Software",
+		EntityIDSystemFirmware:                      "System Firmware",
+		EntityIDOperatingSystem:                     "Operating System",
+		EntityIDSystemBus:                           "System Bus",
+		EntityIDGroup:                               "Group",
+		EntityIDRemoteManagementCommunicationDevice: "Remote Management Communication Device",
+		EntityIDExternalEnvironment:                 "External Environment",
+		EntityIDBattery:                             "Battery",
+		EntityIDProcessingBlade:                     "Processing Blade",
+		EntityIDConnectivitySwitch:                  "Connectivity Switch",
+		EntityIDProcessorMemoryModule:               "Processor/Memory Module",
+		EntityIDIOModule:                            "I/O Module",
+		EntityIDProcessorIOModule:                   "Processor/IO Module",
+		EntityIDManagementControllerFirmware:        "Management Controller Firmware",
+		EntityIDIPMIChannel:                         "IPMI Channel",
+		EntityIDPCIBus:                              "PCI Bus",
+		EntityIDPCIExpressBus:                       "PCI Express Bus",
+		EntityIDSCSIBus:                             "SCSI Bus (Parallel)",
+		EntityIDSATASASBus:                          "SATA/SAS Bus",
+		EntityIDProcessorFrontSideBus:               "Processor/Front-side Bus",
+		EntityIDRealTimeClock:                       "Real Time Clock",
+		EntityIDAirInlet:                            "Air Inlet",
+		EntityIDDCMIAirInlet:                        "Air Inlet (DCMI)",
+		EntityIDDCMIProcessor:                       "Processor (DCMI)",
+		EntityIDDCMISystemBoard:                     "System Board (DCMI)",
 	}
 )
 
+// Description returns the name of the entity in the specification. Values in
+// the chassis-specific (0x90-0xaf), board-set specific (0xb0-0xcf) and OEM
+// (0xd0-0xff) ranges are described by their range, as their meaning depends
+// on the manufacturer; other unlisted values are "Reserved".
 func (e EntityID) Description() string {
 	if desc, ok := entityIdDescriptions[e]; ok {
 		return desc
 	}
-	return "Unknown"
+	switch {
+	case e >= 0xd0:
+		return "OEM"
+	case e >= 0xb0:
+		return "Board-set Specific"
+	case e >= 0x90:
+		return "Chassis-specific"
+	default:
+		return "Reserved"
+	}
 }
 
 func (e EntityID) String() string {
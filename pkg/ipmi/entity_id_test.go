@@ -0,0 +1,31 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestEntityIDString(t *testing.T) {
+	tests := []struct {
+		id   EntityID
+		want string
+	}{
+		{EntityIDUnknown, "0x2(Unknown)"},
+		{EntityIDSystemChassis, "0x17(System Chassis)"},
+		{EntityIDCoolingDevice, "0x1d(Cooling Device)"},
+		{EntityIDMemoryDevice, "0x20(Memory Device)"},
+		{EntityIDRealTimeClock, "0x35(Real Time Clock)"},
+		{0x36, "0x36(Reserved)"},
+		{EntityIDAirInlet, "0x37(Air Inlet)"},
+		{EntityIDDCMISystemBoard, "0x42(System Board (DCMI))"},
+		{0x43, "0x43(Reserved)"},
+		{0x90, "0x90(Chassis-specific)"},
+		{0xcf, "0xcf(Board-set Specific)"},
+		{0xd0, "0xd0(OEM)"},
+	}
+	for _, test := range tests {
+		if got := test.id.String(); got != test.want {
+			t.Errorf("String(%d) = %v, want %v", uint8(test.id), got,
+				test.want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package ipmi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// This file implements decoding of the FRU information areas specified in the
+// Platform Management FRU Information Storage Definition v1.0, which is
+// referenced by IPMI v1.5 and v2.0 but not part of them. All multi-byte
+// integers are little-endian, and offsets and lengths are in multiples of 8
+// bytes.
+
+const (
+	// fruEndOfFields is the type/length byte that terminates the variable
+	// fields of an information area.
+	fruEndOfFields = 0xc1
+
+	// FRULanguageCodeEnglish is the default language of board and product
+	// information areas, specified in Table 15-1 of the FRU spec. 0 also means
+	// English.
+	FRULanguageCodeEnglish = 25
+)
+
+var (
+	// fruEpoch is the reference time for board manufacturing dates.
+	fruEpoch = time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// FRUCommonHeader is at the start of every FRU Inventory Device, and contains
+// the offsets of the information areas within it. It is specified in section 8
+// of the FRU spec. Offsets have been multiplied out into bytes; 0 indicates
+// the area is absent.
+type FRUCommonHeader struct {
+	layers.BaseLayer
+
+	// FormatVersion is the version of the common header. This is 1 for v1.0
+	// of the FRU spec.
+	FormatVersion uint8
+
+	InternalUseOffset uint16
+	ChassisInfoOffset uint16
+	BoardInfoOffset   uint16
+	ProductInfoOffset uint16
+	MultiRecordOffset uint16
+}
+
+func (*FRUCommonHeader) LayerType() gopacket.LayerType {
+	return LayerTypeFRUCommonHeader
+}
+
+func (h *FRUCommonHeader) CanDecode() gopacket.LayerClass {
+	return h.LayerType()
+}
+
+func (*FRUCommonHeader) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (h *FRUCommonHeader) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		df.SetTruncated()
+		return fmt.Errorf("FRU common header must be 8 bytes, got %v", len(data))
+	}
+	if err := fruChecksum(data[:8]); err != nil {
+		return err
+	}
+
+	h.BaseLayer.Contents = data[:8]
+	h.BaseLayer.Payload = data[8:]
+	h.FormatVersion = data[0] & 0xf
+	if h.FormatVersion != 1 {
+		return fmt.Errorf("unsupported FRU common header format version %v",
+			h.FormatVersion)
+	}
+	h.InternalUseOffset = uint16(data[1]) * 8
+	h.ChassisInfoOffset = uint16(data[2]) * 8
+	h.BoardInfoOffset = uint16(data[3]) * 8
+	h.ProductInfoOffset = uint16(data[4]) * 8
+	h.MultiRecordOffset = uint16(data[5]) * 8
+	return nil
+}
+
+// FRUChassisInfoArea describes the chassis, specified in section 10 of the FRU
+// spec.
+type FRUChassisInfoArea struct {
+	layers.BaseLayer
+
+	// Type is the SMBIOS System Enclosure or Chassis type, e.g. 0x17 for rack
+	// mount chassis.
+	Type uint8
+
+	PartNumber   string
+	SerialNumber string
+
+	// Custom contains any additional, manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUChassisInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUChassisInfoArea
+}
+
+func (a *FRUChassisInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUChassisInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUChassisInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data, 3, df)
+	if err != nil {
+		return err
+	}
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	a.Type = area[2]
+	a.Custom, err = decodeFRUFields(area[3:], &a.PartNumber, &a.SerialNumber)
+	return err
+}
+
+// FRUBoardInfoArea describes the board the FRU Inventory Device is on, usually
+// the mainboard, specified in section 11 of the FRU spec.
+type FRUBoardInfoArea struct {
+	layers.BaseLayer
+
+	// Language is the language code of the fields in the area, specified in
+	// Table 15-1 of the FRU spec. 0 and 25 are English.
+	Language uint8
+
+	// Manufactured is the time the board was made, with minute precision. This
+	// is the zero value if unspecified.
+	Manufactured time.Time
+
+	Manufacturer string
+	ProductName  string
+	SerialNumber string
+	PartNumber   string
+
+	// FRUFileID identifies the file used to program the FRU Inventory Device.
+	// It is usually empty.
+	FRUFileID string
+
+	// Custom contains any additional, manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUBoardInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUBoardInfoArea
+}
+
+func (a *FRUBoardInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUBoardInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUBoardInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data, 6, df)
+	if err != nil {
+		return err
+	}
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	a.Language = area[2]
+	minutes := uint32(area[3]) | uint32(area[4])<<8 | uint32(area[5])<<16
+	if minutes == 0 {
+		a.Manufactured = time.Time{}
+	} else {
+		a.Manufactured = fruEpoch.Add(time.Duration(minutes) * time.Minute)
+	}
+	a.Custom, err = decodeFRUFields(area[6:], &a.Manufacturer,
+		&a.ProductName, &a.SerialNumber, &a.PartNumber, &a.FRUFileID)
+	return err
+}
+
+// FRUProductInfoArea describes the product the FRU is part of, usually the
+// whole system, specified in section 12 of the FRU spec.
+type FRUProductInfoArea struct {
+	layers.BaseLayer
+
+	// Language is the language code of the fields in the area, specified in
+	// Table 15-1 of the FRU spec. 0 and 25 are English.
+	Language uint8
+
+	Manufacturer string
+	Name         string
+
+	// PartNumber is the part or model number of the product.
+	PartNumber string
+
+	Version      string
+	SerialNumber string
+	AssetTag     string
+
+	// FRUFileID identifies the file used to program the FRU Inventory Device.
+	// It is usually empty.
+	FRUFileID string
+
+	// Custom contains any additional, manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUProductInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUProductInfoArea
+}
+
+func (a *FRUProductInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUProductInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUProductInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data, 3, df)
+	if err != nil {
+		return err
+	}
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	a.Language = area[2]
+	a.Custom, err = decodeFRUFields(area[3:], &a.Manufacturer,
+		&a.Name, &a.PartNumber, &a.Version, &a.SerialNumber, &a.AssetTag,
+		&a.FRUFileID)
+	return err
+}
+
+// FRUAreaLength returns the length in bytes of a chassis, board or product
+// information area given at least its first 2 bytes, so the rest of the area
+// can be read.
+func FRUAreaLength(data []byte) (int, error) {
+	if len(data) < 2 {
+		return 0, fmt.Errorf("need 2 bytes to determine FRU area length, "+
+			"got %v", len(data))
+	}
+	return int(data[1]) * 8, nil
+}
+
+// fruArea validates the header and checksum of a chassis, board or product
+// information area at the start of data, returning the area. The fixed fields
+// of the area, including the version and length, are min bytes long.
+func fruArea(data []byte, min int, df gopacket.DecodeFeedback) ([]byte, error) {
+	length, err := FRUAreaLength(data)
+	if err != nil {
+		df.SetTruncated()
+		return nil, err
+	}
+	if version := data[0] & 0xf; version != 1 {
+		return nil, fmt.Errorf("unsupported FRU area format version %v",
+			version)
+	}
+	if length <= min {
+		return nil, fmt.Errorf("FRU area length of %v bytes is too short for "+
+			"its %v bytes of fixed fields", length, min)
+	}
+	if len(data) < length {
+		df.SetTruncated()
+		return nil, fmt.Errorf("FRU area is %v bytes long, got %v", length,
+			len(data))
+	}
+	area := data[:length]
+	if err := fruChecksum(area); err != nil {
+		return nil, err
+	}
+	return area, nil
+}
+
+// fruChecksum returns an error if b does not sum to 0, modulo 256, as the FRU
+// header and each area must.
+func fruChecksum(b []byte) error {
+	sum := uint8(0)
+	for _, c := range b {
+		sum += c
+	}
+	if sum != 0 {
+		return fmt.Errorf("FRU %w: %#x should be %#x", ErrInvalidChecksum,
+			b[len(b)-1], b[len(b)-1]-sum)
+	}
+	return nil
+}
+
+// decodeFRUFields decodes type/length-prefixed fields into the provided
+// strings in order, returning any further custom fields. Areas end with a 0xc1
+// byte, followed by padding and the checksum. Fields missing from the end of
+// the area are left empty.
+func decodeFRUFields(b []byte, fields ...*string) ([]string, error) {
+	custom := []string{}
+	for i := 0; len(b) > 0 && b[0] != fruEndOfFields; i++ {
+		field, n, err := decodeFRUField(b)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %w", i, err)
+		}
+		b = b[n:]
+		if i < len(fields) {
+			*fields[i] = field
+		} else {
+			custom = append(custom, field)
+		}
+	}
+	if len(custom) == 0 {
+		return nil, nil
+	}
+	return custom, nil
+}
+
+// decodeFRUField decodes the field at the start of b, returning it and the
+// number of bytes consumed, including the type/length byte. The type/length
+// byte is specified in section 13 of the FRU spec.
+func decodeFRUField(b []byte) (string, int, error) {
+	length := int(b[0] & 0x3f)
+	data := b[1:]
+	if len(data) < length {
+		return "", 0, fmt.Errorf("field is %v bytes long, but only %v remain",
+			length, len(data))
+	}
+	data = data[:length]
+
+	switch b[0] >> 6 {
+	case 0: // binary or unspecified
+		return hex.EncodeToString(data), 1 + length, nil
+	case 1:
+		s, _, err := decodeBCDPlus(data, length*2)
+		return s, 1 + length, err
+	case 2:
+		s, _, err := decodePacked6BitAscii(data, length*8/6)
+		return s, 1 + length, err
+	default:
+		// 8-bit ASCII+Latin 1 for English, otherwise 2-byte Unicode. Like
+		// ipmitool, we treat both as Latin 1, as no other language has been
+		// observed in the wild
+		runes := make([]rune, len(data))
+		for i, c := range data {
+			runes[i] = rune(c)
+		}
+		return string(runes), 1 + length, nil
+	}
+}
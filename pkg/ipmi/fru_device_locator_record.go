@@ -0,0 +1,104 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUDeviceLocatorRecord is specified in 43.8 of IPMI v2.0. It identifies a
+// FRU Inventory Device, and how to access it. This layer represents the record
+// key and record body sections.
+type FRUDeviceLocatorRecord struct {
+	layers.BaseLayer
+
+	// DeviceAccessAddress is the slave address of the controller used to
+	// access the device, e.g. SlaveAddressBMC.
+	DeviceAccessAddress SlaveAddress
+
+	// Logical indicates the device is a logical FRU device, accessed via FRU
+	// commands to the controller at DeviceAccessAddress. Otherwise, it is a
+	// SEEPROM on a private or IPMB bus, accessed via Master Write-Read.
+	Logical bool
+
+	// DeviceID is the FRU device ID of a logical device, or the 7-bit slave
+	// address of a non-logical one.
+	DeviceID uint8
+
+	// AccessLUN is the LUN to address FRU commands to for logical devices.
+	AccessLUN LUN
+
+	// PrivateBusID is the private bus the device is on, for non-logical
+	// devices.
+	PrivateBusID uint8
+
+	// Channel is the channel the controller at DeviceAccessAddress is on. This
+	// is 0 for the primary IPMB.
+	Channel Channel
+
+	// DeviceType and DeviceTypeModifier are from Table 43-12, e.g. 0x10 and
+	// 0x00 for an IPMI FRU Inventory device.
+	DeviceType         uint8
+	DeviceTypeModifier uint8
+
+	// Entity and Instance identify the physical entity the device describes.
+	Entity   EntityID
+	Instance EntityInstance
+
+	// Identity is a descriptive string for the device, up to 16 characters.
+	Identity string
+}
+
+func (*FRUDeviceLocatorRecord) LayerType() gopacket.LayerType {
+	return LayerTypeFRUDeviceLocatorRecord
+}
+
+func (r *FRUDeviceLocatorRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*FRUDeviceLocatorRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *FRUDeviceLocatorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 11 {
+		df.SetTruncated()
+		return fmt.Errorf("FRU Device Locator Records are at least 11 bytes "+
+			"long, got %v", len(data))
+	}
+
+	// to go from the offsets here to the byte numbers in the specification,
+	// add 6, e.g. data[4] -> byte 10 in the table.
+
+	r.DeviceAccessAddress = SlaveAddress(data[0] >> 1)
+	r.Logical = data[2]&(1<<7) != 0
+	if r.Logical {
+		r.DeviceID = data[1]
+	} else {
+		r.DeviceID = data[1] >> 1
+	}
+	r.AccessLUN = LUN(data[2] >> 3 & 0x3)
+	r.PrivateBusID = data[2] & 0x7
+	r.Channel = Channel(data[3] >> 4)
+	r.DeviceType = data[5]
+	r.DeviceTypeModifier = data[6]
+	r.Entity = EntityID(data[7])
+	r.Instance = EntityInstance(data[8])
+
+	encoding := StringEncoding(data[10] >> 6)
+	decoder, err := encoding.Decoder()
+	if err != nil {
+		return err
+	}
+	characters := int(data[10] & 0x1f)
+	identity, consumed, err := decoder.Decode(data[11:], characters)
+	if err != nil {
+		return err
+	}
+	r.Identity = identity
+	r.BaseLayer.Contents = data[:11+consumed]
+	r.BaseLayer.Payload = data[11+consumed:]
+	return nil
+}
@@ -0,0 +1,77 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestFRUDeviceLocatorRecordDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *FRUDeviceLocatorRecord
+	}{
+		// too short
+		{
+			make([]byte, 10),
+			nil,
+		},
+		{
+			// logical device 2 behind the BMC, LUN 0
+			[]byte{0x20, 0x02, 0x80, 0x00, 0x00, 0x10, 0x00, 0x0a, 0x61, 0x00,
+				0xc4, 'P', 'S', 'U', '1', 0xff},
+			&FRUDeviceLocatorRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x20, 0x02, 0x80, 0x00, 0x00, 0x10, 0x00,
+						0x0a, 0x61, 0x00, 0xc4, 'P', 'S', 'U', '1'},
+					Payload: []byte{0xff},
+				},
+				DeviceAccessAddress: SlaveAddressBMC,
+				Logical:             true,
+				DeviceID:            2,
+				DeviceType:          0x10,
+				Entity:              EntityIDPowerSupply,
+				Instance:            0x61,
+				Identity:            "PSU1",
+			},
+		},
+		{
+			// SEEPROM at 0xa0 on private bus 1 of a satellite controller
+			[]byte{0x82, 0xa0, 0x09, 0x20, 0x00, 0x10, 0x01, 0x07, 0x01, 0x00,
+				0xc2, 'M', 'B'},
+			&FRUDeviceLocatorRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x82, 0xa0, 0x09, 0x20, 0x00, 0x10, 0x01,
+						0x07, 0x01, 0x00, 0xc2, 'M', 'B'},
+					Payload: []byte{},
+				},
+				DeviceAccessAddress: 0x41,
+				DeviceID:            0x50,
+				AccessLUN:           1,
+				PrivateBusID:        1,
+				Channel:             2,
+				DeviceType:          0x10,
+				DeviceTypeModifier:  0x01,
+				Entity:              EntityIDSystemBoard,
+				Instance:            1,
+				Identity:            "MB",
+			},
+		},
+	}
+	for _, test := range tests {
+		record := &FRUDeviceLocatorRecord{}
+		err := record.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, record); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, record, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+var (
+	fruBoardInfoArea = []byte{
+		0x01, 0x08, 0x00, 0xd6, 0x2e, 0xba, 0xca, 0x53, 0x75, 0x70, 0x65,
+		0x72, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0xc8, 0x58, 0x31, 0x31, 0x44,
+		0x50, 0x69, 0x2d, 0x4e, 0xcc, 0x48, 0x4d, 0x31, 0x39, 0x41, 0x53,
+		0x30, 0x30, 0x34, 0x33, 0x32, 0x31, 0xc8, 0x58, 0x31, 0x31, 0x44,
+		0x50, 0x69, 0x2d, 0x4e, 0x00, 0xc8, 0x52, 0x65, 0x76, 0x20, 0x31,
+		0x2e, 0x31, 0x30, 0xc1, 0x00, 0x00, 0x00, 0x00, 0x33,
+	}
+	fruProductInfoArea = []byte{
+		0x01, 0x06, 0x00, 0xca, 0x53, 0x75, 0x70, 0x65, 0x72, 0x6d, 0x69,
+		0x63, 0x72, 0x6f, 0xc9, 0x53, 0x59, 0x53, 0x2d, 0x36, 0x30, 0x32,
+		0x39, 0x50, 0xcc, 0x53, 0x59, 0x53, 0x2d, 0x36, 0x30, 0x32, 0x39,
+		0x50, 0x2d, 0x54, 0x52, 0x42, 0x12, 0x34, 0xc4, 0x53, 0x31, 0x32,
+		0x33, 0xc0, 0xc1, 0x4e,
+	}
+	fruChassisInfoArea = []byte{
+		0x01, 0x03, 0x17, 0xc8, 0x43, 0x53, 0x45, 0x2d, 0x38, 0x32, 0x39,
+		0x55, 0xc7, 0x43, 0x38, 0x32, 0x39, 0x30, 0x4c, 0x4b, 0xc1, 0x00,
+		0x00, 0xe8,
+	}
+)
+
+func TestFRUCommonHeaderDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *FRUCommonHeader
+	}{
+		{
+			// too short
+			[]byte{0x01, 0x00, 0x01, 0x04, 0x0c, 0x00, 0x00},
+			nil,
+		},
+		{
+			// bad checksum
+			[]byte{0x01, 0x00, 0x01, 0x04, 0x0c, 0x00, 0x00, 0xed},
+			nil,
+		},
+		{
+			// unsupported version
+			[]byte{0x02, 0x00, 0x01, 0x04, 0x0c, 0x00, 0x00, 0xed},
+			nil,
+		},
+		{
+			[]byte{0x01, 0x00, 0x01, 0x04, 0x0c, 0x00, 0x00, 0xee, 0xff},
+			&FRUCommonHeader{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x00, 0x01, 0x04, 0x0c, 0x00, 0x00, 0xee},
+					Payload:  []byte{0xff},
+				},
+				FormatVersion:     1,
+				ChassisInfoOffset: 8,
+				BoardInfoOffset:   32,
+				ProductInfoOffset: 96,
+			},
+		},
+	}
+	for _, test := range tests {
+		hdr := &FRUCommonHeader{}
+		err := hdr.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, hdr); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, hdr, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestFRUChassisInfoAreaDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *FRUChassisInfoArea
+	}{
+		{
+			// truncated
+			fruChassisInfoArea[:16],
+			nil,
+		},
+		{
+			fruChassisInfoArea,
+			&FRUChassisInfoArea{
+				BaseLayer: layers.BaseLayer{
+					Contents: fruChassisInfoArea,
+					Payload:  []byte{},
+				},
+				Type:         0x17,
+				PartNumber:   "CSE-829U",
+				SerialNumber: "C8290LK",
+			},
+		},
+	}
+	for _, test := range tests {
+		area := &FRUChassisInfoArea{}
+		err := area.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, area); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, area, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestFRUBoardInfoAreaDecodeFromBytes(t *testing.T) {
+	corrupt := append([]byte{}, fruBoardInfoArea...)
+	corrupt[10]++
+	overrun := append([]byte{}, fruBoardInfoArea...)
+	overrun[6] = 0xff // 63-byte Latin 1 manufacturer
+	overrun[63] -= 0xff - 0xca
+	tests := []struct {
+		in   []byte
+		want *FRUBoardInfoArea
+	}{
+		{
+			// too short to contain length
+			[]byte{0x01},
+			nil,
+		},
+		{
+			// bad checksum
+			corrupt,
+			nil,
+		},
+		{
+			// field extends beyond area
+			overrun,
+			nil,
+		},
+		{
+			// empty
+			[]byte{0x01, 0x01, 0x19, 0x00, 0x00, 0x00, 0xc1, 0x24},
+			&FRUBoardInfoArea{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x01, 0x19, 0x00, 0x00, 0x00, 0xc1, 0x24},
+					Payload:  []byte{},
+				},
+				Language: FRULanguageCodeEnglish,
+			},
+		},
+		{
+			append(fruBoardInfoArea, 0x01),
+			&FRUBoardInfoArea{
+				BaseLayer: layers.BaseLayer{
+					Contents: fruBoardInfoArea,
+					Payload:  []byte{0x01},
+				},
+				Manufactured: time.Date(2019, 3, 14, 9, 26, 0, 0, time.UTC),
+				Manufacturer: "Supermicro",
+				ProductName:  "X11DPi-N",
+				SerialNumber: "HM19AS004321",
+				PartNumber:   "X11DPi-N",
+				Custom:       []string{"Rev 1.10"},
+			},
+		},
+	}
+	for _, test := range tests {
+		area := &FRUBoardInfoArea{}
+		err := area.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, area); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, area, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestFRUProductInfoAreaDecodeFromBytes(t *testing.T) {
+	area := &FRUProductInfoArea{}
+	if err := area.DecodeFromBytes(fruProductInfoArea, gopacket.NilDecodeFeedback); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	want := &FRUProductInfoArea{
+		BaseLayer: layers.BaseLayer{
+			Contents: fruProductInfoArea,
+			Payload:  []byte{},
+		},
+		Manufacturer: "Supermicro",
+		Name:         "SYS-6029P",
+		PartNumber:   "SYS-6029P-TR",
+		Version:      "1234", // BCD plus
+		SerialNumber: "S123",
+	}
+	if diff := cmp.Diff(want, area); diff != "" {
+		t.Errorf("decode = %v, want %v: %v", area, want, diff)
+	}
+}
@@ -2,6 +2,7 @@ package ipmi
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/kuiwang02/bmc/internal/pkg/complement"
 
@@ -53,6 +54,11 @@ type FullSensorRecord struct {
 	// entity's status can be obtained via an Entity Presence sensor.
 	Ignore bool
 
+	// AutoRearm indicates whether the BMC re-arms the sensor's events itself.
+	// If false, a state remains latched until Re-arm Sensor Events is sent.
+	// This is a property of the sensor, so cannot be configured.
+	AutoRearm bool
+
 	// SensorType indicates what is being measured. For analogue sensors, this
 	// is the dimension, e.g. temperature. For discrete sensors, there are many
 	// values to pinpoint exactly what is being exposed.
@@ -86,6 +92,10 @@ type FullSensorRecord struct {
 	// means unused.
 	ModifierUnit SensorUnit
 
+	// ModifierRelation is the 2-bit Modifier Unit field in Sensor Units 1,
+	// indicating how ModifierUnit combines with BaseUnit.
+	ModifierRelation ModifierRelation
+
 	// Linearisation indicates whether the sensor is linear, linearised or
 	// non-linear. This controls post-processing after applying the linear
 	// conversion formula to the raw reading.
@@ -153,6 +163,33 @@ type FullSensorRecord struct {
 	// TODO ignored many fields
 }
 
+// Unit returns the unit of the sensor's readings, combining its base unit,
+// modifier unit, rate and percentage fields.
+func (r *FullSensorRecord) Unit() Unit {
+	return Unit{
+		Base:             r.BaseUnit,
+		Modifier:         r.ModifierUnit,
+		ModifierRelation: r.ModifierRelation,
+		Rate:             r.RateUnit,
+		Percentage:       r.IsPercentage,
+	}
+}
+
+// AbsoluteTolerance returns the tolerance of readings in the same units as
+// readings converted with ConvertReading(), i.e. before linearisation. A
+// reading of x is accurate to x ± the returned value.
+func (r *FullSensorRecord) AbsoluteTolerance() float64 {
+	return float64(r.Tolerance) / 2 * r.Resolution()
+}
+
+// AccuracyPercentage returns the accuracy of the sensor as a percentage of the
+// reading, or 0 if unspecified. The specification defines Accuracy as an
+// unsigned 10-bit value, so it is reinterpreted as such.
+func (r *FullSensorRecord) AccuracyPercentage() float64 {
+	accuracy := uint16(r.Accuracy) & 0x3ff
+	return float64(accuracy) / 100 * math.Pow10(int(r.AccuracyExp))
+}
+
 func (*FullSensorRecord) LayerType() gopacket.LayerType {
 	return LayerTypeFullSensorRecord
 }
@@ -185,13 +222,14 @@ func (r *FullSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedba
 	r.Instance = EntityInstance(data[4] & 0x7f)
 
 	r.Ignore = data[6]&(1<<7) != 0
+	r.AutoRearm = data[6]&(1<<6) != 0
 
 	r.SensorType = SensorType(data[7])
 	r.OutputType = OutputType(data[8])
 
 	r.AnalogDataFormat = AnalogDataFormat(data[15] >> 6)
 	r.RateUnit = RateUnit((data[15] & 0x38) >> 3)
-	// modifier unit when needed
+	r.ModifierRelation = ModifierRelation((data[15] & 0x6) >> 1)
 	r.IsPercentage = data[15]&1 != 0
 
 	r.BaseUnit = SensorUnit(data[16])
@@ -199,22 +237,12 @@ func (r *FullSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedba
 
 	r.Linearisation = Linearisation(data[18] & 0x7f)
 
-	buf := [...]byte{data[20] >> 6, data[19]}
-	r.M = complement.Twos(buf, 10)
+	r.ConversionFactors.decode(data[19:25])
 	r.Tolerance = uint8(data[20] & 0x3f)
-	buf[1] = data[21]
-	buf[0] = data[22] >> 6
-	r.B = complement.Twos(buf, 10)
-	buf[1] = data[22]&0x3f | ((data[23] & 0xf0) << 2)
-	buf[0] = (data[23] & 0xf0) >> 6
+	buf := [...]byte{(data[23] & 0xf0) >> 6, data[22]&0x3f | ((data[23] & 0xf0) << 2)}
 	r.Accuracy = complement.Twos(buf, 10)
 	r.AccuracyExp = uint8(data[23]&0xc) >> 2
 	r.Direction = SensorDirection(data[23] & 0x3)
-	buf[0] = 0
-	buf[1] = data[24] >> 4
-	r.RExp = int8(complement.Twos(buf, 4))
-	buf[1] = data[24] & 0xf
-	r.BExp = int8(complement.Twos(buf, 4))
 
 	r.NominalReadingSpecified = data[25]&1 != 0
 	r.NormalMaxSpecified = data[25]&(1<<1) != 0
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -86,6 +87,7 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsContainerEntity:       false,
 				Instance:                1,
 				Ignore:                  false,
+				AutoRearm:               true,
 				SensorType:              SensorTypeTemperature,
 				OutputType:              OutputTypeThreshold,
 				AnalogDataFormat:        AnalogDataFormatTwosComplement,
@@ -195,6 +197,7 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsContainerEntity:       true,
 				Instance:                96,
 				Ignore:                  true,
+				AutoRearm:               true,
 				SensorType:              SensorTypeCurrent,
 				OutputType:              OutputTypeThreshold,
 				AnalogDataFormat:        AnalogDataFormatUnsigned,
@@ -202,6 +205,7 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsPercentage:            true,
 				BaseUnit:                SensorUnitAmps,
 				ModifierUnit:            SensorUnitKilopascals,
+				ModifierRelation:        ModifierRelationMultiply,
 				Linearisation:           LinearisationExp10,
 				Tolerance:               53,
 				Accuracy:                -342,
@@ -234,3 +238,42 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestFullSensorRecordPrecision(t *testing.T) {
+	tests := []struct {
+		fsr           FullSensorRecord
+		wantTolerance float64
+		wantAccuracy  float64
+	}{
+		{FullSensorRecord{}, 0, 0},
+		{
+			FullSensorRecord{
+				ConversionFactors: ConversionFactors{M: 5, RExp: -1},
+				Tolerance:         3,
+				Accuracy:          50,
+			},
+			0.75,
+			0.5,
+		},
+		{
+			FullSensorRecord{
+				ConversionFactors: ConversionFactors{M: 1},
+				Tolerance:         1,
+				Accuracy:          -342, // 0x2aa when unsigned
+				AccuracyExp:       3,
+			},
+			0.5,
+			6820,
+		},
+	}
+	for _, test := range tests {
+		if got := test.fsr.AbsoluteTolerance(); math.Abs(got-test.wantTolerance) > 1e-12 {
+			t.Errorf("AbsoluteTolerance() = %v, want %v", got,
+				test.wantTolerance)
+		}
+		if got := test.fsr.AccuracyPercentage(); math.Abs(got-test.wantAccuracy) > 1e-9 {
+			t.Errorf("AccuracyPercentage() = %v, want %v", got,
+				test.wantAccuracy)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package ipmi
+
+// Simple commands are generated from commands.json by ipmigen. Generated
+// layers use layer types from 1500 onwards, so they never collide with those
+// registered by hand in layer_types.go.
+//go:generate go run ../../internal/cmd/ipmigen commands.json
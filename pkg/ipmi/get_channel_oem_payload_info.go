@@ -0,0 +1,112 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetChannelOEMPayloadInfoReq implements the Get Channel OEM Payload Info
+// command, specified in section 24.11 of IPMI v2.0. It asks the BMC which OEM
+// payload a payload type number in the range 0x20 through 0x27 maps to on a
+// channel, or conversely, which number an OEM explicit payload has been
+// assigned. The meaning of these numbers varies by BMC, so this is the only
+// reliable way to find them.
+type GetChannelOEMPayloadInfoReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel to query. Use ChannelPresentInterface to query
+	// the channel the request is sent over.
+	Channel Channel
+
+	// PayloadDescriptor identifies the payload. PayloadType must be
+	// PayloadTypeOEM, in which case Enterprise and PayloadID identify the
+	// payload, or one of PayloadTypeOEM0 through PayloadTypeOEM7, in which case
+	// they are ignored.
+	PayloadDescriptor
+}
+
+func (*GetChannelOEMPayloadInfoReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetChannelOEMPayloadInfoReq
+}
+
+func (g *GetChannelOEMPayloadInfoReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(7)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(g.Channel) & 0xf
+	bytes[1] = uint8(g.PayloadType) & 0x3f
+	enterprise, payloadID := iana.Enterprise(0), uint16(0)
+	if g.PayloadType == PayloadTypeOEM {
+		enterprise, payloadID = g.Enterprise, g.PayloadID
+	}
+	bytes[2] = uint8(enterprise)
+	bytes[3] = uint8(enterprise >> 8)
+	bytes[4] = uint8(enterprise >> 16)
+	binary.LittleEndian.PutUint16(bytes[5:7], payloadID)
+	return nil
+}
+
+// GetChannelOEMPayloadInfoRsp contains the OEM payload that a payload type
+// number maps to. When PayloadType is one of PayloadTypeOEM0 through
+// PayloadTypeOEM7, Enterprise and PayloadID identify the payload it carries;
+// when it is PayloadTypeOEM, the payload must be sent as OEM explicit.
+type GetChannelOEMPayloadInfoRsp struct {
+	layers.BaseLayer
+	PayloadDescriptor
+}
+
+func (*GetChannelOEMPayloadInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetChannelOEMPayloadInfoRsp
+}
+
+func (g *GetChannelOEMPayloadInfoRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetChannelOEMPayloadInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetChannelOEMPayloadInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 6 {
+		df.SetTruncated()
+		return fmt.Errorf("Get Channel OEM Payload Info response must be 6 "+
+			"bytes, got %v", len(data))
+	}
+	g.PayloadType = PayloadType(data[0] & 0x3f)
+	g.Enterprise = iana.Enterprise(uint32(data[1]) | uint32(data[2])<<8 |
+		uint32(data[3])<<16)
+	g.PayloadID = binary.LittleEndian.Uint16(data[4:6])
+	g.BaseLayer.Contents = data[:6]
+	g.BaseLayer.Payload = data[6:]
+	return nil
+}
+
+type GetChannelOEMPayloadInfoCmd struct {
+	Req GetChannelOEMPayloadInfoReq
+	Rsp GetChannelOEMPayloadInfoRsp
+}
+
+// Name returns "Get Channel OEM Payload Info".
+func (*GetChannelOEMPayloadInfoCmd) Name() string {
+	return "Get Channel OEM Payload Info"
+}
+
+// Operation returns &OperationGetChannelOEMPayloadInfoReq.
+func (*GetChannelOEMPayloadInfoCmd) Operation() *Operation {
+	return &OperationGetChannelOEMPayloadInfoReq
+}
+
+func (c *GetChannelOEMPayloadInfoCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetChannelOEMPayloadInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,117 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetChannelOEMPayloadInfoReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *GetChannelOEMPayloadInfoReq
+		want  []byte
+	}{
+		{
+			&GetChannelOEMPayloadInfoReq{
+				Channel: ChannelPresentInterface,
+				PayloadDescriptor: PayloadDescriptor{
+					PayloadType: PayloadTypeOEM2,
+					Enterprise:  iana.EnterpriseSuperMicro,
+					PayloadID:   1,
+				},
+			},
+			[]byte{0x0e, 0x22, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			&GetChannelOEMPayloadInfoReq{
+				Channel: 1,
+				PayloadDescriptor: PayloadDescriptor{
+					PayloadType: PayloadTypeOEM,
+					Enterprise:  iana.EnterpriseSuperMicro,
+					PayloadID:   0x0102,
+				},
+			},
+			[]byte{0x01, 0x02, 0x7c, 0x2a, 0x00, 0x02, 0x01},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed: %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestGetChannelOEMPayloadInfoRspDecodeFromBytes(t *testing.T) {
+	table := []struct {
+		in   []byte
+		want *GetChannelOEMPayloadInfoRsp
+	}{
+		{
+			[]byte{0x20, 0x7c, 0x2a, 0x00, 0x02},
+			nil, // should be 6 bytes
+		},
+		{
+			[]byte{0x20, 0x7c, 0x2a, 0x00, 0x02, 0x01},
+			&GetChannelOEMPayloadInfoRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x20, 0x7c, 0x2a, 0x00, 0x02, 0x01},
+					Payload:  []byte{},
+				},
+				PayloadDescriptor: PayloadDescriptor{
+					PayloadType: PayloadTypeOEM0,
+					Enterprise:  iana.EnterpriseSuperMicro,
+					PayloadID:   0x0102,
+				},
+			},
+		},
+	}
+	layer := &GetChannelOEMPayloadInfoRsp{}
+	for _, test := range table {
+		err := layer.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, layer); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, layer, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestPayloadTypeIsOEM(t *testing.T) {
+	table := []struct {
+		payloadType PayloadType
+		want        bool
+		str         string
+	}{
+		{PayloadTypeIPMI, false, "IPMI"},
+		{PayloadTypeOEM, true, "OEM Explicit"},
+		{PayloadTypeRAKPMessage4, false, "RAKP Message 4"},
+		{PayloadTypeOEM0, true, "OEM0"},
+		{PayloadTypeOEM7, true, "OEM7"},
+		{0x28, false, "Unknown"},
+	}
+	for _, test := range table {
+		if got := test.payloadType.IsOEM(); got != test.want {
+			t.Errorf("%#x.IsOEM() = %v, want %v", uint8(test.payloadType),
+				got, test.want)
+		}
+		if got := test.payloadType.String(); got != test.str {
+			t.Errorf("%#x.String() = %v, want %v", uint8(test.payloadType),
+				got, test.str)
+		}
+	}
+}
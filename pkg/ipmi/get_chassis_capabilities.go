@@ -0,0 +1,114 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetChassisCapabilitiesRsp represents the response to a Get Chassis
+// Capabilities command, specified in section 22.1 and 28.1 of IPMI v1.5 and
+// 2.0 respectively. It indicates which optional chassis features are present,
+// and the addresses of the devices providing chassis management functions.
+type GetChassisCapabilitiesRsp struct {
+	layers.BaseLayer
+
+	// ProvidesPowerInterlock indicates the chassis has a power interlock,
+	// e.g. a switch that cuts power when the lid is removed.
+	ProvidesPowerInterlock bool
+
+	// ProvidesDiagnosticInterrupt indicates the chassis can deliver a
+	// diagnostic interrupt (front panel NMI) to the CPU(s), i.e. that
+	// ChassisControlDiagnosticInterrupt is supported.
+	ProvidesDiagnosticInterrupt bool
+
+	// ProvidesFrontPanelLockout indicates the front panel buttons can be
+	// disabled.
+	ProvidesFrontPanelLockout bool
+
+	// ProvidesIntrusionSensor indicates the chassis has a physical security
+	// sensor, reporting whether it has been opened.
+	ProvidesIntrusionSensor bool
+
+	// FRUInfoDeviceAddress is the address of the device providing the chassis
+	// FRU information.
+	FRUInfoDeviceAddress SlaveAddress
+
+	// SDRDeviceAddress is the address of the device holding the SDR
+	// Repository.
+	SDRDeviceAddress SlaveAddress
+
+	// SELDeviceAddress is the address of the device holding the SEL.
+	SELDeviceAddress SlaveAddress
+
+	// SystemManagementDeviceAddress is the address of the system management
+	// device, usually the BMC.
+	SystemManagementDeviceAddress SlaveAddress
+
+	// BridgeDeviceAddress is the address of the device providing the bridge
+	// function. If the BMC omits this field, it is assumed to be the BMC
+	// itself.
+	BridgeDeviceAddress SlaveAddress
+}
+
+func (*GetChassisCapabilitiesRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetChassisCapabilitiesRsp
+}
+
+func (r *GetChassisCapabilitiesRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetChassisCapabilitiesRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetChassisCapabilitiesRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 5 {
+		df.SetTruncated()
+		return fmt.Errorf("Get Chassis Capabilities response must be at "+
+			"least 5 bytes, got %v", len(data))
+	}
+
+	length := 5
+	r.BridgeDeviceAddress = SlaveAddressBMC
+	if len(data) >= 6 {
+		r.BridgeDeviceAddress = SlaveAddress(data[5] >> 1)
+		length = 6
+	}
+	r.BaseLayer.Contents = data[:length]
+	r.BaseLayer.Payload = data[length:]
+
+	r.ProvidesPowerInterlock = data[0]&(1<<3) != 0
+	r.ProvidesDiagnosticInterrupt = data[0]&(1<<2) != 0
+	r.ProvidesFrontPanelLockout = data[0]&(1<<1) != 0
+	r.ProvidesIntrusionSensor = data[0]&1 != 0
+	r.FRUInfoDeviceAddress = SlaveAddress(data[1] >> 1)
+	r.SDRDeviceAddress = SlaveAddress(data[2] >> 1)
+	r.SELDeviceAddress = SlaveAddress(data[3] >> 1)
+	r.SystemManagementDeviceAddress = SlaveAddress(data[4] >> 1)
+	return nil
+}
+
+type GetChassisCapabilitiesCmd struct {
+	Rsp GetChassisCapabilitiesRsp
+}
+
+// Name returns "Get Chassis Capabilities".
+func (*GetChassisCapabilitiesCmd) Name() string {
+	return "Get Chassis Capabilities"
+}
+
+// Operation returns &OperationGetChassisCapabilitiesReq.
+func (*GetChassisCapabilitiesCmd) Operation() *Operation {
+	return &OperationGetChassisCapabilitiesReq
+}
+
+func (*GetChassisCapabilitiesCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetChassisCapabilitiesCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,69 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetChassisCapabilitiesRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetChassisCapabilitiesRsp
+	}{
+		{
+			// too short
+			[]byte{0x00, 0x20, 0x20, 0x20},
+			nil,
+		},
+		{
+			// bridge device address omitted
+			[]byte{0x04, 0x20, 0x20, 0x20, 0x20},
+			&GetChassisCapabilitiesRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x04, 0x20, 0x20, 0x20, 0x20},
+					Payload:  []byte{},
+				},
+				ProvidesDiagnosticInterrupt:   true,
+				FRUInfoDeviceAddress:          SlaveAddressBMC,
+				SDRDeviceAddress:              SlaveAddressBMC,
+				SELDeviceAddress:              SlaveAddressBMC,
+				SystemManagementDeviceAddress: SlaveAddressBMC,
+				BridgeDeviceAddress:           SlaveAddressBMC,
+			},
+		},
+		{
+			[]byte{0x0b, 0x20, 0x22, 0x24, 0x20, 0x26},
+			&GetChassisCapabilitiesRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x0b, 0x20, 0x22, 0x24, 0x20, 0x26},
+					Payload:  []byte{},
+				},
+				ProvidesPowerInterlock:        true,
+				ProvidesFrontPanelLockout:     true,
+				ProvidesIntrusionSensor:       true,
+				FRUInfoDeviceAddress:          SlaveAddressBMC,
+				SDRDeviceAddress:              0x11,
+				SELDeviceAddress:              0x12,
+				SystemManagementDeviceAddress: SlaveAddressBMC,
+				BridgeDeviceAddress:           0x13,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetChassisCapabilitiesRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package ipmi
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -90,6 +91,69 @@ func (s ChassisIdentifyState) String() string {
 	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
 }
 
+// LastPowerEvent is a set of flags describing the last event that changed the
+// system power state, from the Last Power Event field of the Get Chassis
+// Status response. The flags are not mutually exclusive. This is a 5-bit
+// field on the wire.
+type LastPowerEvent uint8
+
+const (
+	// LastPowerEventACFailed indicates the last power down was caused by an
+	// interruption in mains power to the system (not a PSU failure).
+	LastPowerEventACFailed LastPowerEvent = 1 << iota
+
+	// LastPowerEventOverload indicates the last power down was caused by a
+	// power overload.
+	LastPowerEventOverload
+
+	// LastPowerEventInterlock indicates the last power down was caused by the
+	// activation of a chassis panel interlock switch.
+	LastPowerEventInterlock
+
+	// LastPowerEventFault indicates the last power down was caused by a power
+	// fault.
+	LastPowerEventFault
+
+	// LastPowerEventCommand indicates the last power on was requested via
+	// IPMI.
+	LastPowerEventCommand
+)
+
+var lastPowerEventDescriptions = []struct {
+	event       LastPowerEvent
+	description string
+}{
+	{LastPowerEventACFailed, "AC failed"},
+	{LastPowerEventOverload, "Overload"},
+	{LastPowerEventInterlock, "Interlock"},
+	{LastPowerEventFault, "Fault"},
+	{LastPowerEventCommand, "Power on via IPMI"},
+}
+
+// Has returns whether all flags in o are set in e.
+func (e LastPowerEvent) Has(o LastPowerEvent) bool {
+	return e&o == o
+}
+
+// Description returns a human-readable representation of the set flags, or
+// "None" if no flags are set.
+func (e LastPowerEvent) Description() string {
+	descriptions := []string{}
+	for _, d := range lastPowerEventDescriptions {
+		if e.Has(d.event) {
+			descriptions = append(descriptions, d.description)
+		}
+	}
+	if len(descriptions) == 0 {
+		return "None"
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func (e LastPowerEvent) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(e), e.Description())
+}
+
 // GetChassisStatusRsp represents the managed system's response to a Get Chassis
 // Status command, specified in 22.2 and 28.2 of IPMI v1.5 and v2.0
 // respectively.
@@ -124,8 +188,10 @@ type GetChassisStatusRsp struct {
 	// was issued via IPMI.
 	PoweredOnByIPMI bool
 
-	// considered having a LastPowerDownCause struct, however the spec suggests
-	// these states are not mutually exclusive.
+	// LastPowerEvent contains the flags of the Last Power Event field. The
+	// flags are also available individually in PoweredOnByIPMI and the
+	// LastPowerDown* fields.
+	LastPowerEvent LastPowerEvent
 
 	// LastPowerDownFault indicates whether the last power down was caused by a
 	// power fault.
@@ -224,6 +290,7 @@ func (s *GetChassisStatusRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFee
 	s.PowerOverload = data[0]&(1<<1) != 0
 	s.PoweredOn = data[0]&1 != 0
 
+	s.LastPowerEvent = LastPowerEvent(data[1] & 0x1f)
 	s.PoweredOnByIPMI = data[1]&(1<<4) != 0
 	s.LastPowerDownFault = data[1]&(1<<3) != 0
 	s.LastPowerDownInterlock = data[1]&(1<<2) != 0
@@ -40,6 +40,7 @@ func TestGetChassisStatusRspDecodeFromBytes(t *testing.T) {
 				PowerRestorePolicy:          PowerRestorePolicyPriorState,
 				PowerFault:                  true,
 				PowerOverload:               true,
+				LastPowerEvent:              LastPowerEventOverload | LastPowerEventFault,
 				LastPowerDownFault:          true,
 				LastPowerDownOverload:       true,
 				ChassisIdentifyState:        ChassisIdentifyStateUnknown,
@@ -63,6 +64,7 @@ func TestGetChassisStatusRspDecodeFromBytes(t *testing.T) {
 				Interlock:                               true,
 				PoweredOn:                               true,
 				PoweredOnByIPMI:                         true,
+				LastPowerEvent:                          LastPowerEventACFailed | LastPowerEventInterlock | LastPowerEventCommand,
 				LastPowerDownInterlock:                  true,
 				LastPowerDownSupplyFailure:              true,
 				ChassisIdentifyState:                    ChassisIdentifyStateTemporary,
@@ -90,3 +92,20 @@ func TestGetChassisStatusRspDecodeFromBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestLastPowerEventDescription(t *testing.T) {
+	tests := []struct {
+		in   LastPowerEvent
+		want string
+	}{
+		{0, "None"},
+		{LastPowerEventCommand, "Power on via IPMI"},
+		{LastPowerEventACFailed | LastPowerEventFault, "AC failed, Fault"},
+	}
+	for _, test := range tests {
+		if got := test.in.Description(); got != test.want {
+			t.Errorf("%#x.Description() = %q, want %q", uint8(test.in), got,
+				test.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetFRUInventoryAreaInfoReq implements the Get FRU Inventory Area Info
+// command, specified in 28.1 and 34.1 of IPMI v1.5 and v2.0 respectively. It
+// returns the size of a FRU Inventory Device, which is needed to know how much
+// data can be read with Read FRU Data.
+type GetFRUInventoryAreaInfoReq struct {
+	layers.BaseLayer
+
+	// DeviceID identifies the FRU Inventory Device to query. 0 is the device
+	// containing the FRU information of the BMC itself, which is usually the
+	// mainboard. Other IDs can be found in FRU Device Locator SDRs.
+	DeviceID uint8
+}
+
+func (*GetFRUInventoryAreaInfoReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetFRUInventoryAreaInfoReq
+}
+
+func (r *GetFRUInventoryAreaInfoReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.DeviceID
+	return nil
+}
+
+// GetFRUInventoryAreaInfoRsp contains the size of a FRU Inventory Device, and
+// how it must be accessed.
+type GetFRUInventoryAreaInfoRsp struct {
+	layers.BaseLayer
+
+	// Size is the size of the FRU Inventory Device in bytes.
+	Size uint16
+
+	// WordAccess indicates the device is accessed in 16-bit words rather than
+	// bytes, so offsets and counts in Read FRU Data requests and responses are
+	// in words. This is rare in practice.
+	WordAccess bool
+}
+
+func (*GetFRUInventoryAreaInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetFRUInventoryAreaInfoRsp
+}
+
+func (r *GetFRUInventoryAreaInfoRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetFRUInventoryAreaInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetFRUInventoryAreaInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 3 {
+		df.SetTruncated()
+		return fmt.Errorf("Get FRU Inventory Area Info response must be 3 "+
+			"bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:3]
+	r.BaseLayer.Payload = data[3:]
+	r.Size = binary.LittleEndian.Uint16(data[0:2])
+	r.WordAccess = data[2]&1 != 0
+	return nil
+}
+
+type GetFRUInventoryAreaInfoCmd struct {
+	Req GetFRUInventoryAreaInfoReq
+	Rsp GetFRUInventoryAreaInfoRsp
+}
+
+// Name returns "Get FRU Inventory Area Info".
+func (*GetFRUInventoryAreaInfoCmd) Name() string {
+	return "Get FRU Inventory Area Info"
+}
+
+// Operation returns &OperationGetFRUInventoryAreaInfoReq.
+func (*GetFRUInventoryAreaInfoCmd) Operation() *Operation {
+	return &OperationGetFRUInventoryAreaInfoReq
+}
+
+func (c *GetFRUInventoryAreaInfoCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetFRUInventoryAreaInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,117 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetLANConfigurationParametersReq implements the Get LAN Configuration
+// Parameters command, specified in 19.2 and 23.2 of IPMI v1.5 and v2.0
+// respectively. It retrieves a single parameter of a LAN channel, e.g. its MAC
+// or IP address.
+type GetLANConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly indicates the BMC should only return the parameter
+	// revision, omitting the parameter data.
+	RevisionOnly bool
+
+	// Channel is the LAN channel whose parameter to retrieve. The channel
+	// being used to communicate with the BMC is in the response to Get
+	// Channel Authentication Capabilities; ChannelPresentInterface is not
+	// accepted by all BMCs.
+	Channel Channel
+
+	// Parameter identifies the parameter to retrieve.
+	Parameter LANConfigurationParameter
+
+	// SetSelector selects a given set of the parameter, e.g. the destination
+	// for destination parameters. This is 0 for parameters without sets.
+	SetSelector uint8
+
+	// BlockSelector selects a block of the parameter. This is 0 for all
+	// parameters specified in v2.0.
+	BlockSelector uint8
+}
+
+func (*GetLANConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetLANConfigurationParametersReq
+}
+
+func (r *GetLANConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	if r.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(r.Parameter)
+	bytes[2] = r.SetSelector
+	bytes[3] = r.BlockSelector
+	return nil
+}
+
+// GetLANConfigurationParametersRsp represents the response to a Get LAN
+// Configuration Parameters request. The parameter data is left in the layer
+// payload, as its format depends on the parameter requested.
+type GetLANConfigurationParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The most-significant nibble is the
+	// present revision, and the least-significant nibble the oldest revision
+	// the parameter is backward compatible with. This is 0x11 for all
+	// parameters specified in v2.0.
+	Revision uint8
+}
+
+func (*GetLANConfigurationParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetLANConfigurationParametersRsp
+}
+
+func (r *GetLANConfigurationParametersRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetLANConfigurationParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetLANConfigurationParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Revision = data[0]
+	return nil
+}
+
+type GetLANConfigurationParametersCmd struct {
+	Req GetLANConfigurationParametersReq
+	Rsp GetLANConfigurationParametersRsp
+}
+
+// Name returns "Get LAN Configuration Parameters".
+func (*GetLANConfigurationParametersCmd) Name() string {
+	return "Get LAN Configuration Parameters"
+}
+
+// Operation returns &OperationGetLANConfigurationParametersReq.
+func (*GetLANConfigurationParametersCmd) Operation() *Operation {
+	return &OperationGetLANConfigurationParametersReq
+}
+
+func (c *GetLANConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetLANConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
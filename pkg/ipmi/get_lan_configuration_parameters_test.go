@@ -0,0 +1,45 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestGetLANConfigurationParametersReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *GetLANConfigurationParametersReq
+		want  []byte
+	}{
+		{
+			&GetLANConfigurationParametersReq{
+				Channel:   1,
+				Parameter: LANConfigurationParameterMACAddress,
+			},
+			[]byte{0x01, 0x05, 0x00, 0x00},
+		},
+		{
+			&GetLANConfigurationParametersReq{
+				RevisionOnly:  true,
+				Channel:       ChannelPresentInterface,
+				Parameter:     LANConfigurationParameterDestinationAddresses,
+				SetSelector:   3,
+				BlockSelector: 1,
+			},
+			[]byte{0x8e, 0x13, 0x03, 0x01},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
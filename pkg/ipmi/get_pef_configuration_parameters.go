@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPEFConfigurationParametersReq implements the Get PEF Configuration
+// Parameters command, specified in 15.3 and 30.4 of IPMI v1.5 and v2.0
+// respectively. It retrieves a single Platform Event Filtering parameter, e.g.
+// whether PEF is enabled.
+type GetPEFConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly indicates the BMC should only return the parameter
+	// revision, omitting the parameter data.
+	RevisionOnly bool
+
+	// Parameter identifies the parameter to retrieve.
+	Parameter PEFConfigurationParameter
+
+	// SetSelector selects a given set of the parameter, e.g. the entry of
+	// table parameters. This is 0 for parameters without sets.
+	SetSelector uint8
+
+	// BlockSelector selects a block of the parameter. This is 0 for
+	// parameters without blocks.
+	BlockSelector uint8
+}
+
+func (*GetPEFConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetPEFConfigurationParametersReq
+}
+
+func (r *GetPEFConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Parameter) & 0x7f
+	if r.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = r.SetSelector
+	bytes[2] = r.BlockSelector
+	return nil
+}
+
+// GetPEFConfigurationParametersRsp represents the response to a Get PEF
+// Configuration Parameters request. The parameter data is left in the layer
+// payload, as its format depends on the parameter requested.
+type GetPEFConfigurationParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The most-significant nibble is the
+	// present revision, and the least-significant nibble the oldest revision
+	// the parameter is backward compatible with. This is 0x11 for all
+	// parameters specified in v2.0.
+	Revision uint8
+}
+
+func (*GetPEFConfigurationParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetPEFConfigurationParametersRsp
+}
+
+func (r *GetPEFConfigurationParametersRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetPEFConfigurationParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetPEFConfigurationParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Revision = data[0]
+	return nil
+}
+
+type GetPEFConfigurationParametersCmd struct {
+	Req GetPEFConfigurationParametersReq
+	Rsp GetPEFConfigurationParametersRsp
+}
+
+// Name returns "Get PEF Configuration Parameters".
+func (*GetPEFConfigurationParametersCmd) Name() string {
+	return "Get PEF Configuration Parameters"
+}
+
+// Operation returns &OperationGetPEFConfigurationParametersReq.
+func (*GetPEFConfigurationParametersCmd) Operation() *Operation {
+	return &OperationGetPEFConfigurationParametersReq
+}
+
+func (c *GetPEFConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetPEFConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,57 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestGetPEFConfigurationParametersReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *GetPEFConfigurationParametersReq
+		want  []byte
+	}{
+		{
+			&GetPEFConfigurationParametersReq{
+				Parameter: PEFConfigurationParameterControl,
+			},
+			[]byte{0x01, 0x00, 0x00},
+		},
+		{
+			&GetPEFConfigurationParametersReq{
+				RevisionOnly: true,
+				Parameter:    PEFConfigurationParameterEventFilterTable,
+				SetSelector:  3,
+			},
+			[]byte{0x86, 0x03, 0x00},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %+v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestPEFActionString(t *testing.T) {
+	tests := []struct {
+		action PEFAction
+		want   string
+	}{
+		{0, "[]"},
+		{PEFActionAlert | PEFActionPowerCycle, "[Alert, Power Cycle]"},
+		{PEFActionReset | 0x80, "[Reset, 0x80]"},
+	}
+	for _, test := range tests {
+		if got := test.action.String(); got != test.want {
+			t.Errorf("%#x.String() = %v, want %v", uint8(test.action), got,
+				test.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPOHCounterRsp represents the response to a Get POH (Power-On Hours)
+// Counter command, specified in 22.12 and 28.14 of IPMI v1.5 and 2.0
+// respectively. The counter increments while the system is powered on, and is
+// usually stored in non-volatile memory, so survives AC loss.
+type GetPOHCounterRsp struct {
+	layers.BaseLayer
+
+	// MinutesPerCount is the number of minutes the system must be powered on
+	// for the counter to increment. This is usually 60.
+	MinutesPerCount uint8
+
+	// Counter is the raw counter reading. Use PoweredOn() to convert it into
+	// a duration.
+	Counter uint32
+}
+
+func (*GetPOHCounterRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetPOHCounterRsp
+}
+
+func (r *GetPOHCounterRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetPOHCounterRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetPOHCounterRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 5 {
+		df.SetTruncated()
+		return fmt.Errorf("Get POH Counter response must be 5 bytes, got %v",
+			len(data))
+	}
+
+	r.BaseLayer.Contents = data[:5]
+	r.BaseLayer.Payload = data[5:]
+	r.MinutesPerCount = data[0]
+	r.Counter = binary.LittleEndian.Uint32(data[1:5])
+	return nil
+}
+
+// PoweredOn returns the total time the system has been powered on for, with
+// a precision of MinutesPerCount.
+func (r *GetPOHCounterRsp) PoweredOn() time.Duration {
+	return time.Duration(r.Counter) * time.Duration(r.MinutesPerCount) *
+		time.Minute
+}
+
+type GetPOHCounterCmd struct {
+	Rsp GetPOHCounterRsp
+}
+
+// Name returns "Get POH Counter".
+func (*GetPOHCounterCmd) Name() string {
+	return "Get POH Counter"
+}
+
+// Operation returns &OperationGetPOHCounterReq.
+func (*GetPOHCounterCmd) Operation() *Operation {
+	return &OperationGetPOHCounterReq
+}
+
+func (*GetPOHCounterCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetPOHCounterCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
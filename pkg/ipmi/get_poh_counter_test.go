@@ -0,0 +1,58 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetPOHCounterRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetPOHCounterRsp
+	}{
+		{
+			// too short
+			[]byte{0x3c, 0x01, 0x02, 0x03},
+			nil,
+		},
+		{
+			[]byte{0x3c, 0x10, 0x27, 0x00, 0x00},
+			&GetPOHCounterRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x3c, 0x10, 0x27, 0x00, 0x00},
+					Payload:  []byte{},
+				},
+				MinutesPerCount: 60,
+				Counter:         10000,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetPOHCounterRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestGetPOHCounterRspPoweredOn(t *testing.T) {
+	rsp := &GetPOHCounterRsp{
+		MinutesPerCount: 60,
+		Counter:         10000,
+	}
+	if got, want := rsp.PoweredOn(), time.Hour*10000; got != want {
+		t.Errorf("PoweredOn() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSDRRepositoryAllocationInfoRsp represents the response to a Get SDR
+// Repository Allocation Info command, specified in section 27.10 and 33.10 of
+// IPMI v1.5 and v2.0 respectively. This command is useful for determining
+// whether a set of records will fit in the repository before attempting to add
+// them.
+type GetSDRRepositoryAllocationInfoRsp struct {
+	layers.BaseLayer
+
+	// Units is the total number of allocation units in the repository. 0
+	// means unspecified.
+	Units uint16
+
+	// UnitSize is the size of each allocation unit in bytes. 0 means
+	// unspecified.
+	UnitSize uint16
+
+	// FreeUnits is the number of unallocated units.
+	FreeUnits uint16
+
+	// LargestFreeBlock is the size of the largest contiguous free region, in
+	// allocation units.
+	LargestFreeBlock uint16
+
+	// MaxRecordSize is the maximum size of a record, in allocation units.
+	MaxRecordSize uint8
+}
+
+func (*GetSDRRepositoryAllocationInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSDRRepositoryAllocationInfoRsp
+}
+
+func (r *GetSDRRepositoryAllocationInfoRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSDRRepositoryAllocationInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSDRRepositoryAllocationInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 9 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 9 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:9]
+	r.BaseLayer.Payload = data[9:]
+
+	r.Units = binary.LittleEndian.Uint16(data[0:2])
+	r.UnitSize = binary.LittleEndian.Uint16(data[2:4])
+	r.FreeUnits = binary.LittleEndian.Uint16(data[4:6])
+	r.LargestFreeBlock = binary.LittleEndian.Uint16(data[6:8])
+	r.MaxRecordSize = data[8]
+	return nil
+}
+
+type GetSDRRepositoryAllocationInfoCmd struct {
+	Rsp GetSDRRepositoryAllocationInfoRsp
+}
+
+// Name returns "Get SDR Repository Allocation Info".
+func (*GetSDRRepositoryAllocationInfoCmd) Name() string {
+	return "Get SDR Repository Allocation Info"
+}
+
+// Operation returns OperationGetSDRRepositoryAllocationInfoReq.
+func (*GetSDRRepositoryAllocationInfoCmd) Operation() *Operation {
+	return &OperationGetSDRRepositoryAllocationInfoReq
+}
+
+func (*GetSDRRepositoryAllocationInfoCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSDRRepositoryAllocationInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
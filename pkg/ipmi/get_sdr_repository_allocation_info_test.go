@@ -0,0 +1,49 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSDRRepositoryAllocationInfoRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSDRRepositoryAllocationInfoRsp
+	}{
+		{
+			make([]byte, 8),
+			nil,
+		},
+		{
+			[]byte{0x00, 0x02, 0x10, 0x00, 0x80, 0x01, 0x40, 0x01, 0x04, 0xff},
+			&GetSDRRepositoryAllocationInfoRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x02, 0x10, 0x00, 0x80, 0x01, 0x40, 0x01, 0x04},
+					Payload:  []byte{0xff},
+				},
+				Units:            512,
+				UnitSize:         16,
+				FreeUnits:        384,
+				LargestFreeBlock: 320,
+				MaxRecordSize:    4,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSDRRepositoryAllocationInfoRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -31,7 +31,8 @@ type GetSDRRepositoryInfoRsp struct {
 	FreeSpace uint16
 
 	// LastAddition is the time when the last record was added to the
-	// repository. This will be the zero value if never.
+	// repository. This will be the zero value if never. It is converted as
+	// described by Timestamp.Time(), assuming the BMC keeps UTC.
 	LastAddition time.Time
 
 	// LastErase is the time when the last record was deleted from the
@@ -97,8 +98,8 @@ func (i *GetSDRRepositoryInfoRsp) DecodeFromBytes(data []byte, df gopacket.Decod
 	i.Version = bcd.Decode(data[0]&0xf)*10 + bcd.Decode(data[0]>>4)
 	i.Records = binary.LittleEndian.Uint16(data[1:3])
 	i.FreeSpace = binary.LittleEndian.Uint16(data[3:5])
-	i.LastAddition = time.Unix(int64(binary.LittleEndian.Uint32(data[5:9])), 0)
-	i.LastErase = time.Unix(int64(binary.LittleEndian.Uint32(data[9:13])), 0)
+	i.LastAddition = Timestamp(binary.LittleEndian.Uint32(data[5:9])).Time(0)
+	i.LastErase = Timestamp(binary.LittleEndian.Uint32(data[9:13])).Time(0)
 	i.Overflow = data[13]&(1<<7) != 0
 	i.SupportsModalUpdate = data[13]&(1<<6) != 0
 	i.SupportsNonModalUpdate = data[13]&(1<<5) != 0
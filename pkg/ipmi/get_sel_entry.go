@@ -0,0 +1,106 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELEntryReq represents a request to retrieve a single record from the
+// System Event Log. This command is specified in 25.5 and 31.5 of IPMI v1.5
+// and v2.0 respectively. SEL records share the Record ID semantics of SDRs,
+// including RecordIDFirst and RecordIDLast.
+type GetSELEntryReq struct {
+	layers.BaseLayer
+
+	// ReservationID is only required if Offset > 0.
+	ReservationID ReservationID
+
+	// RecordID is the ID of the record to read. RecordIDFirst and RecordIDLast
+	// retrieve the oldest and newest records respectively.
+	RecordID RecordID
+
+	// Offset is the number of bytes into the record to start reading from.
+	Offset uint8
+
+	// Length is the number of bytes to read. As with Get SDR, 0xff means the
+	// entire record. SEL records are always 16 bytes, so this is safe.
+	Length uint8
+}
+
+func (*GetSELEntryReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELEntryReq
+}
+
+func (r *GetSELEntryReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(r.ReservationID))
+	binary.LittleEndian.PutUint16(bytes[2:4], uint16(r.RecordID))
+	bytes[4] = r.Offset
+	bytes[5] = r.Length
+	return nil
+}
+
+// GetSELEntryRsp contains the next Record ID in the SEL, and wraps the record
+// data requested. If the entire record was requested, the payload can be
+// decoded as a SELEventRecord.
+type GetSELEntryRsp struct {
+	layers.BaseLayer
+
+	// Next is the Record ID of the next record in the SEL. It is RecordIDLast
+	// if the returned record is the newest.
+	Next RecordID
+}
+
+func (*GetSELEntryRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELEntryRsp
+}
+
+func (r *GetSELEntryRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSELEntryRsp) NextLayerType() gopacket.LayerType {
+	return LayerTypeSELEventRecord
+}
+
+func (r *GetSELEntryRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes for the record ID, got %v",
+			len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+	r.Next = RecordID(binary.LittleEndian.Uint16(data[:2]))
+	return nil
+}
+
+type GetSELEntryCmd struct {
+	Req GetSELEntryReq
+	Rsp GetSELEntryRsp
+}
+
+// Name returns "Get SEL Entry".
+func (*GetSELEntryCmd) Name() string {
+	return "Get SEL Entry"
+}
+
+// Operation returns &OperationGetSELEntryReq.
+func (*GetSELEntryCmd) Operation() *Operation {
+	return &OperationGetSELEntryReq
+}
+
+func (c *GetSELEntryCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSELEntryCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,82 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// selTimeUTCOffsetUnspecified is returned by Get SEL Time UTC Offset if
+	// the BMC does not know its offset.
+	selTimeUTCOffsetUnspecified int16 = 0x7ff
+)
+
+// GetSELTimeUTCOffsetRsp is the response to a Get SEL Time UTC Offset command,
+// specified in 31.11a of IPMI v2.0. It returns the offset of the BMC's local
+// time from UTC, which is needed to convert a Timestamp into a time.Time.
+type GetSELTimeUTCOffsetRsp struct {
+	layers.BaseLayer
+
+	// Offset is how far the BMC's local time is ahead of UTC, between -24 and
+	// +24 hours, with a resolution of a minute. It is 0 if Unspecified.
+	Offset time.Duration
+
+	// Unspecified indicates the BMC does not know its offset, which is
+	// normally the case if it keeps UTC.
+	Unspecified bool
+}
+
+func (*GetSELTimeUTCOffsetRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELTimeUTCOffsetRsp
+}
+
+func (r *GetSELTimeUTCOffsetRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSELTimeUTCOffsetRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSELTimeUTCOffsetRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+	minutes := int16(binary.LittleEndian.Uint16(data[0:2]))
+	r.Unspecified = minutes == selTimeUTCOffsetUnspecified
+	r.Offset = 0
+	if !r.Unspecified {
+		r.Offset = time.Duration(minutes) * time.Minute
+	}
+	return nil
+}
+
+type GetSELTimeUTCOffsetCmd struct {
+	Rsp GetSELTimeUTCOffsetRsp
+}
+
+// Name returns "Get SEL Time UTC Offset".
+func (*GetSELTimeUTCOffsetCmd) Name() string {
+	return "Get SEL Time UTC Offset"
+}
+
+// Operation returns OperationGetSELTimeUTCOffsetReq.
+func (*GetSELTimeUTCOffsetCmd) Operation() *Operation {
+	return &OperationGetSELTimeUTCOffsetReq
+}
+
+func (*GetSELTimeUTCOffsetCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSELTimeUTCOffsetCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
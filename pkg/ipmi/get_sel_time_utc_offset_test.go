@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSELTimeUTCOffsetRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSELTimeUTCOffsetRsp
+	}{
+		{
+			[]byte{0x00},
+			nil,
+		},
+		{
+			[]byte{0x3c, 0x00},
+			&GetSELTimeUTCOffsetRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x3c, 0x00},
+					Payload:  []byte{},
+				},
+				Offset: time.Hour,
+			},
+		},
+		{
+			[]byte{0xd4, 0xfe},
+			&GetSELTimeUTCOffsetRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xd4, 0xfe},
+					Payload:  []byte{},
+				},
+				Offset: -5 * time.Hour,
+			},
+		},
+		{
+			[]byte{0xff, 0x07},
+			&GetSELTimeUTCOffsetRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xff, 0x07},
+					Payload:  []byte{},
+				},
+				Unspecified: true,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSELTimeUTCOffsetRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -51,6 +51,16 @@ type GetSensorReadingRsp struct {
 	// progress, or that the entity is not present. If set, the reading should
 	// be ignored.
 	ReadingUnavailable bool
+
+	// ThresholdStatus indicates which thresholds the reading is at or beyond.
+	// It is only meaningful for threshold sensors; discrete sensors use the
+	// same byte for their state.
+	ThresholdStatus ThresholdStatus
+
+	// States contains the asserted states of a discrete sensor, with bit n set
+	// if offset n is asserted. Offsets 8 to 14 are only set if the BMC returns
+	// them. It is not meaningful for threshold sensors.
+	States uint16
 }
 
 func (*GetSensorReadingRsp) LayerType() gopacket.LayerType {
@@ -76,9 +86,12 @@ func (r *GetSensorReadingRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFee
 	r.EventMessagesEnabled = data[1]&(1<<7) != 0
 	r.ScanningEnabled = data[1]&(1<<6) != 0
 	r.ReadingUnavailable = data[1]&(1<<5) != 0
+	r.ThresholdStatus = ThresholdStatus(data[2] & 0x3f)
+	r.States = uint16(data[2])
 
 	if len(data) > 3 {
 		// discrete reading sensors only section
+		r.States |= uint16(data[3]&0x7f) << 8
 		r.BaseLayer.Contents = data[:4]
 		r.BaseLayer.Payload = data[4:]
 	} else {
@@ -0,0 +1,131 @@
+package ipmi
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSensorReadingFactorsReq represents a Get Sensor Reading Factors command,
+// specified in 29.5 and 35.5 of v1.5 and v2.0 respectively. It is used to
+// retrieve the conversion factors of non-linear sensors, which vary with the
+// reading, so cannot be given in the SDR.
+type GetSensorReadingFactorsReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor whose factors to retrieve.
+	Number uint8
+
+	// Reading is the raw reading to retrieve the factors for, as returned by
+	// Get Sensor Reading.
+	Reading uint8
+}
+
+func (*GetSensorReadingFactorsReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorReadingFactorsReq
+}
+
+func (r *GetSensorReadingFactorsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	bytes[1] = r.Reading
+	return nil
+}
+
+// GetSensorReadingFactorsRsp contains the conversion factors applicable to a
+// raw reading of a non-linear sensor, along with the tolerance and accuracy at
+// that reading. The factors are in the same format as in the Full Sensor
+// Record.
+type GetSensorReadingFactorsRsp struct {
+	layers.BaseLayer
+	ConversionFactors
+
+	// NextReading is the next raw reading above the one requested for which a
+	// different set of factors applies. Readings from the requested one up to
+	// but excluding this one share the returned factors, so they can be
+	// cached. If it is not greater than the requested reading, the factors
+	// should be assumed to apply only to the requested reading.
+	NextReading uint8
+
+	// Tolerance gives the absolute accuracy of the sensor at the reading in
+	// +/- half raw counts. This is a 6-bit uint on the wire.
+	Tolerance uint8
+
+	// Accuracy gives the sensor accuracy at the reading in 0.01% increments
+	// when raised to AccuracyExp. This is a 10-bit uint on the wire.
+	Accuracy uint16
+
+	// AccuracyExp is the quantity Accuracy is raised to the power of to give
+	// the final accuracy.
+	AccuracyExp uint8
+}
+
+func (*GetSensorReadingFactorsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorReadingFactorsRsp
+}
+
+func (r *GetSensorReadingFactorsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSensorReadingFactorsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSensorReadingFactorsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 7 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 7 bytes, got %v", len(data))
+	}
+
+	r.NextReading = data[0]
+	r.ConversionFactors.decode(data[1:7])
+	r.Tolerance = data[2] & 0x3f
+	r.Accuracy = uint16(data[5]&0xf0)<<2 | uint16(data[4]&0x3f)
+	r.AccuracyExp = (data[5] & 0xc) >> 2
+
+	r.BaseLayer.Contents = data[:7]
+	r.BaseLayer.Payload = data[7:]
+	return nil
+}
+
+// AbsoluteTolerance returns the tolerance of readings converted with the
+// factors, in the same units, before linearisation. It is equivalent to
+// FullSensorRecord's method of the same name.
+func (r *GetSensorReadingFactorsRsp) AbsoluteTolerance() float64 {
+	return float64(r.Tolerance) / 2 * r.Resolution()
+}
+
+// AccuracyPercentage returns the accuracy of the sensor at the reading as a
+// percentage, or 0 if unspecified.
+func (r *GetSensorReadingFactorsRsp) AccuracyPercentage() float64 {
+	return float64(r.Accuracy) / 100 * math.Pow10(int(r.AccuracyExp))
+}
+
+type GetSensorReadingFactorsCmd struct {
+	Req GetSensorReadingFactorsReq
+	Rsp GetSensorReadingFactorsRsp
+}
+
+// Name returns "Get Sensor Reading Factors".
+func (*GetSensorReadingFactorsCmd) Name() string {
+	return "Get Sensor Reading Factors"
+}
+
+// Operation returns &OperationGetSensorReadingFactorsReq.
+func (*GetSensorReadingFactorsCmd) Operation() *Operation {
+	return &OperationGetSensorReadingFactorsReq
+}
+
+func (c *GetSensorReadingFactorsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSensorReadingFactorsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
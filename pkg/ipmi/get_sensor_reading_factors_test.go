@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSensorReadingFactorsReqSerializeTo(t *testing.T) {
+	layer := &GetSensorReadingFactorsReq{
+		Number:  0x21,
+		Reading: 0x80,
+	}
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %+v failed with %v", layer, err)
+	}
+	want := []byte{0x21, 0x80}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %+v = %v, want %v", layer, got, want)
+	}
+}
+
+func TestGetSensorReadingFactorsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSensorReadingFactorsRsp
+	}{
+		{
+			make([]byte, 6),
+			nil,
+		},
+		{
+			// M -2, tolerance 3, B 257, accuracy 0x3ff, accuracy exp 2,
+			// RExp -1, BExp 2
+			[]byte{0x40, 0xfe, 0xc3, 0x01, 0x7f, 0xf8, 0xf2, 0xaa},
+			&GetSensorReadingFactorsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x40, 0xfe, 0xc3, 0x01, 0x7f, 0xf8,
+						0xf2},
+					Payload: []byte{0xaa},
+				},
+				ConversionFactors: ConversionFactors{
+					M:    -2,
+					B:    257,
+					BExp: 2,
+					RExp: -1,
+				},
+				NextReading: 0x40,
+				Tolerance:   3,
+				Accuracy:    0x3ff,
+				AccuracyExp: 2,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSensorReadingFactorsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("decode %v succeeded with %+v, wanted error", test.in,
+				rsp)
+		case err != nil && test.want != nil:
+			t.Errorf("decode %v failed with %v, wanted %+v", test.in, err,
+				test.want)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %+v, want %+v: %v", test.in, rsp,
+					test.want, diff)
+			}
+		}
+	}
+}
@@ -80,6 +80,22 @@ func TestGetSensorReadingRspDecodeFromBytes(t *testing.T) {
 				EventMessagesEnabled: false,
 				ScanningEnabled:      true,
 				ReadingUnavailable:   false,
+				States:               0x100,
+			},
+		},
+		{
+			[]byte{0x5a, 0b11000000, 0b11011000},
+			&GetSensorReadingRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x5a, 0b11000000, 0b11011000},
+					Payload:  []byte{},
+				},
+				Reading:              90,
+				EventMessagesEnabled: true,
+				ScanningEnabled:      true,
+				ThresholdStatus: ThresholdStatusUpperNonCritical |
+					ThresholdStatusUpperCritical,
+				States: 0xd8,
 			},
 		},
 	}
@@ -0,0 +1,109 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSystemBootOptionsReq represents a Get System Boot Options request,
+// specified in section 28.13 of IPMI v2.0.
+type GetSystemBootOptionsReq struct {
+	layers.BaseLayer
+
+	// Parameter identifies the parameter to retrieve.
+	Parameter BootOptionParameter
+
+	// SetSelector selects a given set of the parameter, for parameters with
+	// multiple sets. This is 0 otherwise.
+	SetSelector uint8
+
+	// BlockSelector selects a block within a set, and is 0 for all but the
+	// boot initiator mailbox.
+	BlockSelector uint8
+}
+
+func (*GetSystemBootOptionsReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemBootOptionsReq
+}
+
+func (r *GetSystemBootOptionsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Parameter) & 0x7f
+	bytes[1] = r.SetSelector
+	bytes[2] = r.BlockSelector
+	return nil
+}
+
+// GetSystemBootOptionsRsp represents the response to a Get System Boot
+// Options request. The parameter data is left in the layer payload; if the
+// parameter is BootOptionParameterBootFlags, the next layer is BootFlags.
+type GetSystemBootOptionsRsp struct {
+	layers.BaseLayer
+
+	// Version is the parameter version. This is 1 for IPMI v2.0.
+	Version uint8
+
+	// Invalid indicates the parameter is marked invalid/locked.
+	Invalid bool
+
+	// Parameter is the parameter returned.
+	Parameter BootOptionParameter
+}
+
+func (*GetSystemBootOptionsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemBootOptionsRsp
+}
+
+func (r *GetSystemBootOptionsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (r *GetSystemBootOptionsRsp) NextLayerType() gopacket.LayerType {
+	if r.Parameter == BootOptionParameterBootFlags {
+		return LayerTypeBootFlags
+	}
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSystemBootOptionsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+
+	r.Version = data[0] & 0xf
+	r.Invalid = data[1]&(1<<7) != 0
+	r.Parameter = BootOptionParameter(data[1] & 0x7f)
+	return nil
+}
+
+type GetSystemBootOptionsCmd struct {
+	Req GetSystemBootOptionsReq
+	Rsp GetSystemBootOptionsRsp
+}
+
+// Name returns "Get System Boot Options".
+func (*GetSystemBootOptionsCmd) Name() string {
+	return "Get System Boot Options"
+}
+
+// Operation returns &OperationGetSystemBootOptionsReq.
+func (*GetSystemBootOptionsCmd) Operation() *Operation {
+	return &OperationGetSystemBootOptionsReq
+}
+
+func (c *GetSystemBootOptionsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSystemBootOptionsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
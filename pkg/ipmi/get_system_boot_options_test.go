@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSystemBootOptionsReqSerializeTo(t *testing.T) {
+	layer := &GetSystemBootOptionsReq{
+		Parameter: BootOptionParameterBootFlags,
+	}
+	want := []byte{0x05, 0x00, 0x00}
+
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %v failed with %v", layer, err)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
+
+func TestGetSystemBootOptionsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSystemBootOptionsRsp
+	}{
+		// too short
+		{
+			make([]byte, 1),
+			nil,
+		},
+		{
+			[]byte{0x01, 0x05, 0x80, 0x04, 0x00, 0x00, 0x00},
+			&GetSystemBootOptionsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x05},
+					Payload:  []byte{0x80, 0x04, 0x00, 0x00, 0x00},
+				},
+				Version:   1,
+				Parameter: BootOptionParameterBootFlags,
+			},
+		},
+		{
+			[]byte{0x01, 0x80, 0x00},
+			&GetSystemBootOptionsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x80},
+					Payload:  []byte{0x00},
+				},
+				Version:   1,
+				Invalid:   true,
+				Parameter: BootOptionParameterSetInProgress,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSystemBootOptionsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
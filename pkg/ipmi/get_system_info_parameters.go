@@ -0,0 +1,113 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSystemInfoParametersReq represents a Get System Info Parameters request,
+// specified in section 22.14b of IPMI v2.0. This command retrieves
+// information about the managed system, such as the firmware version string
+// and hostname. It is widely supported by OpenBMC. String parameters longer
+// than a single response are split into 16-byte blocks, each identified by a
+// set selector.
+type GetSystemInfoParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly indicates the BMC should only return the parameter
+	// revision, omitting the parameter data.
+	RevisionOnly bool
+
+	// Parameter identifies the parameter to retrieve.
+	Parameter SystemInfoParameter
+
+	// SetSelector selects a given block of the parameter, for parameters
+	// spanning multiple blocks. This is 0 for single-block parameters.
+	SetSelector uint8
+
+	// BlockSelector is unused by all currently specified parameters, and should
+	// be 0.
+	BlockSelector uint8
+}
+
+func (*GetSystemInfoParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemInfoParametersReq
+}
+
+func (r *GetSystemInfoParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = 0
+	if r.RevisionOnly {
+		bytes[0] = 1 << 7
+	}
+	bytes[1] = uint8(r.Parameter)
+	bytes[2] = r.SetSelector
+	bytes[3] = r.BlockSelector
+	return nil
+}
+
+// GetSystemInfoParametersRsp represents the response to a Get System Info
+// Parameters request. The parameter data is left in the layer payload, as its
+// format depends on the parameter requested.
+type GetSystemInfoParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The most-significant nibble is the
+	// present revision, and the least-significant nibble the oldest revision
+	// the parameter is backward compatible with. This is 0x11 for all
+	// parameters specified in v2.0.
+	Revision uint8
+}
+
+func (*GetSystemInfoParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemInfoParametersRsp
+}
+
+func (r *GetSystemInfoParametersRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSystemInfoParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSystemInfoParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Revision = data[0]
+	return nil
+}
+
+type GetSystemInfoParametersCmd struct {
+	Req GetSystemInfoParametersReq
+	Rsp GetSystemInfoParametersRsp
+}
+
+// Name returns "Get System Info Parameters".
+func (*GetSystemInfoParametersCmd) Name() string {
+	return "Get System Info Parameters"
+}
+
+// Operation returns OperationGetSystemInfoParametersReq.
+func (*GetSystemInfoParametersCmd) Operation() *Operation {
+	return &OperationGetSystemInfoParametersReq
+}
+
+func (c *GetSystemInfoParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSystemInfoParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,39 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// IPAddressSource is how a LAN channel obtains its IP address, specified in
+// table 23-4 of IPMI v2.0 as the IP Address Source LAN configuration
+// parameter. It is a 4-bit uint on the wire.
+type IPAddressSource uint8
+
+const (
+	IPAddressSourceUnspecified IPAddressSource = iota
+	IPAddressSourceStatic
+	IPAddressSourceDHCP
+	IPAddressSourceBIOS
+	IPAddressSourceOther
+)
+
+func (s IPAddressSource) Description() string {
+	switch s {
+	case IPAddressSourceUnspecified:
+		return "Unspecified"
+	case IPAddressSourceStatic:
+		return "Static"
+	case IPAddressSourceDHCP:
+		return "DHCP"
+	case IPAddressSourceBIOS:
+		return "BIOS"
+	case IPAddressSourceOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s IPAddressSource) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
@@ -0,0 +1,433 @@
+package ipmi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// loadIpmitoolCorpus parses testdata/ipmitool.txt into a map of name to wire
+// bytes.
+func loadIpmitoolCorpus(t *testing.T) map[string][]byte {
+	f, err := os.Open("testdata/ipmitool.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	corpus := map[string][]byte{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		wire, err := hex.DecodeString(strings.Join(fields[1:], ""))
+		if err != nil {
+			t.Fatalf("invalid bytes for %v: %v", fields[0], err)
+		}
+		corpus[fields[0]] = wire
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return corpus
+}
+
+func TestIpmitoolRequests(t *testing.T) {
+	corpus := loadIpmitoolCorpus(t)
+	table := []struct {
+		name string
+
+		// operation is nil for RMCP+ session setup payloads, which are not
+		// wrapped in a message.
+		operation *Operation
+		layer     gopacket.SerializableLayer
+	}{
+		{
+			"get-channel-authentication-capabilities-req",
+			&OperationGetChannelAuthenticationCapabilitiesReq,
+			&GetChannelAuthenticationCapabilitiesReq{
+				ExtendedData:      true,
+				Channel:           ChannelPresentInterface,
+				MaxPrivilegeLevel: PrivilegeLevelAdministrator,
+			},
+		},
+		{
+			"open-session-req",
+			nil,
+			&OpenSessionReq{
+				MaxPrivilegeLevel: PrivilegeLevelAdministrator,
+				SessionID:         0xa0a2a3a4,
+				AuthenticationPayloads: []AuthenticationPayload{{
+					Algorithm: AuthenticationAlgorithmHMACSHA1,
+				}},
+				IntegrityPayloads: []IntegrityPayload{{
+					Algorithm: IntegrityAlgorithmHMACSHA196,
+				}},
+				ConfidentialityPayloads: []ConfidentialityPayload{{
+					Algorithm: ConfidentialityAlgorithmAESCBC128,
+				}},
+			},
+		},
+		{
+			"rakp-message-1",
+			nil,
+			&RAKPMessage1{
+				ManagedSystemSessionID: 0x02a5c500,
+				RemoteConsoleRandom: [16]byte{0x8c, 0x1e, 0x55, 0x3d, 0x0b,
+					0x91, 0x27, 0x6a, 0xd2, 0x44, 0x38, 0xf7, 0x5e, 0x03, 0xa1,
+					0x99},
+				MaxPrivilegeLevel: PrivilegeLevelAdministrator,
+				Username:          "admin",
+			},
+		},
+		{
+			"rakp-message-3",
+			nil,
+			&RAKPMessage3{
+				Status:                 StatusCodeOK,
+				ManagedSystemSessionID: 0x02a5c500,
+				AuthCode: []byte{0x3a, 0xf1, 0x6e, 0x02, 0xb4, 0x9d, 0x57,
+					0xc8, 0x21, 0x0f, 0xe3, 0x46, 0x9a, 0x7b, 0x15, 0xd0, 0x88,
+					0x2c, 0x61, 0xbe},
+			},
+		},
+		{
+			"get-device-id-req",
+			&OperationGetDeviceIDReq,
+			nil,
+		},
+		{
+			"get-system-guid-req",
+			&OperationGetSystemGUIDReq,
+			nil,
+		},
+		{
+			"get-system-info-parameters-req",
+			&OperationGetSystemInfoParametersReq,
+			&GetSystemInfoParametersReq{
+				Parameter: SystemInfoParameterSystemFirmwareVersion,
+			},
+		},
+		{
+			"get-session-info-req",
+			&OperationGetSessionInfoReq,
+			&GetSessionInfoReq{
+				Index: SessionIndexCurrent,
+			},
+		},
+		{
+			"get-chassis-status-req",
+			&OperationGetChassisStatusReq,
+			nil,
+		},
+		{
+			"chassis-control-power-off-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlPowerOff,
+			},
+		},
+		{
+			"chassis-control-power-on-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlPowerOn,
+			},
+		},
+		{
+			"chassis-control-power-cycle-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlPowerCycle,
+			},
+		},
+		{
+			"chassis-control-hard-reset-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlHardReset,
+			},
+		},
+		{
+			"chassis-control-diagnostic-interrupt-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlDiagnosticInterrupt,
+			},
+		},
+		{
+			"chassis-control-soft-power-off-req",
+			&OperationChassisControlReq,
+			&ChassisControlReq{
+				ChassisControl: ChassisControlSoftPowerOff,
+			},
+		},
+		{
+			"get-sdr-repository-info-req",
+			&OperationGetSDRRepositoryInfoReq,
+			nil,
+		},
+		{
+			"get-sdr-repository-allocation-info-req",
+			&OperationGetSDRRepositoryAllocationInfoReq,
+			nil,
+		},
+		{
+			"reserve-sdr-repository-req",
+			&OperationReserveSDRRepositoryReq,
+			nil,
+		},
+		{
+			"get-sdr-header-req",
+			&OperationGetSDRReq,
+			&GetSDRReq{
+				ReservationID: 0x012f,
+				RecordID:      RecordIDFirst,
+				Length:        5,
+			},
+		},
+		{
+			"get-sdr-body-req",
+			&OperationGetSDRReq,
+			&GetSDRReq{
+				ReservationID: 0x012f,
+				RecordID:      RecordIDFirst,
+				Offset:        5,
+				Length:        16,
+			},
+		},
+		{
+			"get-sensor-reading-req",
+			&OperationGetSensorReadingReq,
+			&GetSensorReadingReq{
+				Number: 0x30,
+			},
+		},
+		{
+			"reserve-sel-req",
+			&OperationReserveSELReq,
+			nil,
+		},
+		{
+			"clear-sel-req",
+			&OperationClearSELReq,
+			&ClearSELReq{
+				ReservationID: 0x005a,
+				Action:        ClearSELActionInitiateErase,
+			},
+		},
+		{
+			"close-session-req",
+			&OperationCloseSessionReq,
+			&CloseSessionReq{
+				ID: 0x02a5c500,
+			},
+		},
+	}
+	for _, test := range table {
+		want, ok := corpus[test.name]
+		if !ok {
+			t.Errorf("%v: not in corpus", test.name)
+			continue
+		}
+
+		ls := []gopacket.SerializableLayer{}
+		if test.operation != nil {
+			if len(want) < 5 {
+				t.Errorf("%v: %v is too short to be a message", test.name, want)
+				continue
+			}
+			ls = append(ls, &Message{
+				Operation:     *test.operation,
+				RemoteAddress: SlaveAddressBMC.Address(),
+				LocalAddress:  SoftwareIDRemoteConsole1.Address(),
+				// ipmitool's sequence number
+				Sequence: want[4] >> 2,
+			})
+		}
+		if test.layer != nil {
+			ls = append(ls, test.layer)
+		}
+
+		sb := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(sb, gopacket.SerializeOptions{
+			FixLengths:       true,
+			ComputeChecksums: true,
+		}, ls...); err != nil {
+			t.Errorf("%v: serialize = error %v, want %v", test.name, err, want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("%v: serialize = %v, want %v", test.name, got, want)
+		}
+	}
+}
+
+func TestIpmitoolResponses(t *testing.T) {
+	corpus := loadIpmitoolCorpus(t)
+	table := []struct {
+		name string
+
+		// operation is nil for RMCP+ session setup payloads, which are not
+		// wrapped in a message.
+		operation *Operation
+		layer     gopacket.DecodingLayer
+		want      gopacket.DecodingLayer
+	}{
+		{
+			"get-channel-authentication-capabilities-rsp",
+			&OperationGetChannelAuthenticationCapabilitiesRsp,
+			&GetChannelAuthenticationCapabilitiesRsp{},
+			&GetChannelAuthenticationCapabilitiesRsp{
+				Channel:                 1,
+				ExtendedCapabilities:    true,
+				NonNullUsernamesEnabled: true,
+				SupportsV2:              true,
+			},
+		},
+		{
+			"open-session-rsp",
+			nil,
+			&OpenSessionRsp{},
+			&OpenSessionRsp{
+				Status:                 StatusCodeOK,
+				MaxPrivilegeLevel:      PrivilegeLevelAdministrator,
+				RemoteConsoleSessionID: 0xa0a2a3a4,
+				ManagedSystemSessionID: 0x02a5c500,
+				AuthenticationPayload: AuthenticationPayload{
+					Algorithm: AuthenticationAlgorithmHMACSHA1,
+				},
+				IntegrityPayload: IntegrityPayload{
+					Algorithm: IntegrityAlgorithmHMACSHA196,
+				},
+				ConfidentialityPayload: ConfidentialityPayload{
+					Algorithm: ConfidentialityAlgorithmAESCBC128,
+				},
+			},
+		},
+		{
+			"rakp-message-2",
+			nil,
+			&RAKPMessage2{},
+			&RAKPMessage2{
+				Status:                 StatusCodeOK,
+				RemoteConsoleSessionID: 0xa0a2a3a4,
+				ManagedSystemRandom: [16]byte{0x61, 0x2f, 0x94, 0x0e, 0xc3,
+					0x7d, 0x18, 0xb5, 0x4a, 0xe6, 0x20, 0x89, 0x5c, 0xf1, 0x36,
+					0xd7},
+				ManagedSystemGUID: [16]byte{0x44, 0x45, 0x4c, 0x4c, 0x33, 0x00,
+					0x10, 0x4e, 0x80, 0x4b, 0xb7, 0xc0, 0x4f, 0x4e, 0x31, 0x32},
+				AuthCode: []byte{0x0d, 0x72, 0xe9, 0x43, 0xa6, 0x18, 0xcf,
+					0x35, 0x91, 0x5b, 0x07, 0xee, 0x64, 0xb2, 0x29, 0x8a, 0xf4,
+					0x5d, 0x13, 0xc0},
+			},
+		},
+		{
+			"rakp-message-4",
+			nil,
+			&RAKPMessage4{},
+			&RAKPMessage4{
+				Status:                 StatusCodeOK,
+				RemoteConsoleSessionID: 0xa0a2a3a4,
+				ICV: []byte{0x7e, 0x19, 0xa3, 0x52, 0xcd, 0x04, 0xb8, 0x6f,
+					0x2a, 0x95, 0xe1, 0x47},
+			},
+		},
+		{
+			"get-device-id-rsp",
+			&OperationGetDeviceIDRsp,
+			&GetDeviceIDRsp{},
+			&GetDeviceIDRsp{
+				ID:                               0x20,
+				ProvidesSDRs:                     true,
+				Revision:                         1,
+				Available:                        true,
+				MajorFirmwareRevision:            3,
+				MinorFirmwareRevision:            58,
+				MajorIPMIVersion:                 2,
+				SupportsChassisDevice:            true,
+				SupportsIPMBEventGeneratorDevice: true,
+				SupportsIPMBEventReceiverDevice:  true,
+				SupportsFRUInventoryDevice:       true,
+				SupportsSELDevice:                true,
+				SupportsSDRRepositoryDevice:      true,
+				SupportsSensorDevice:             true,
+				Manufacturer:                     10876,
+				Product:                          0x0937,
+			},
+		},
+		{
+			"get-chassis-status-rsp",
+			&OperationGetChassisStatusRsp,
+			&GetChassisStatusRsp{},
+			&GetChassisStatusRsp{
+				PowerRestorePolicy: PowerRestorePolicyPowerOn,
+				PoweredOn:          true,
+				PoweredOnByIPMI:    true,
+				LastPowerEvent:     LastPowerEventCommand,
+				// the identify state is only valid if bit 6 is set
+				ChassisIdentifyState: ChassisIdentifyStateOff,
+			},
+		},
+		{
+			"get-sensor-reading-rsp",
+			&OperationGetSensorReadingRsp,
+			&GetSensorReadingRsp{},
+			&GetSensorReadingRsp{
+				Reading:              0x1b,
+				EventMessagesEnabled: true,
+				ScanningEnabled:      true,
+				// a threshold sensor, which sets the reserved bits
+				States: 0xc0,
+			},
+		},
+	}
+	for _, test := range table {
+		wire, ok := corpus[test.name]
+		if !ok {
+			t.Errorf("%v: not in corpus", test.name)
+			continue
+		}
+
+		payload := wire
+		if test.operation != nil {
+			message := &Message{}
+			if err := message.DecodeFromBytes(wire, gopacket.NilDecodeFeedback); err != nil {
+				t.Errorf("%v: decode message = error %v, want nil", test.name, err)
+				continue
+			}
+			if message.Operation != *test.operation {
+				t.Errorf("%v: operation = %v, want %v", test.name,
+					message.Operation, *test.operation)
+				continue
+			}
+			if message.CompletionCode != CompletionCodeNormal {
+				t.Errorf("%v: completion code = %v, want %v", test.name,
+					message.CompletionCode, CompletionCodeNormal)
+				continue
+			}
+			payload = message.LayerPayload()
+		}
+
+		if err := test.layer.DecodeFromBytes(payload, gopacket.NilDecodeFeedback); err != nil {
+			t.Errorf("%v: decode = error %v, want %v", test.name, err, test.want)
+			continue
+		}
+		if diff := cmp.Diff(test.want, test.layer,
+			cmpopts.IgnoreTypes(layers.BaseLayer{})); diff != "" {
+			t.Errorf("%v: decode = %v, want %v: %v", test.name, test.layer,
+				test.want, diff)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// LANConfigurationParameter identifies a parameter of a LAN channel, retrieved
+// via the Get LAN Configuration Parameters command. Values are specified in
+// table 19-4 and 23-4 of IPMI v1.5 and v2.0 respectively. It is a 1 byte uint
+// on the wire.
+type LANConfigurationParameter uint8
+
+const (
+	LANConfigurationParameterSetInProgress              LANConfigurationParameter = 0
+	LANConfigurationParameterAuthenticationTypeSupport  LANConfigurationParameter = 1
+	LANConfigurationParameterAuthenticationTypeEnables  LANConfigurationParameter = 2
+	LANConfigurationParameterIPAddress                  LANConfigurationParameter = 3
+	LANConfigurationParameterIPAddressSource            LANConfigurationParameter = 4
+	LANConfigurationParameterMACAddress                 LANConfigurationParameter = 5
+	LANConfigurationParameterSubnetMask                 LANConfigurationParameter = 6
+	LANConfigurationParameterIPv4HeaderParameters       LANConfigurationParameter = 7
+	LANConfigurationParameterPrimaryRMCPPort            LANConfigurationParameter = 8
+	LANConfigurationParameterSecondaryRMCPPort          LANConfigurationParameter = 9
+	LANConfigurationParameterBMCGeneratedARPControl     LANConfigurationParameter = 10
+	LANConfigurationParameterGratuitousARPInterval      LANConfigurationParameter = 11
+	LANConfigurationParameterDefaultGatewayAddress      LANConfigurationParameter = 12
+	LANConfigurationParameterDefaultGatewayMACAddress   LANConfigurationParameter = 13
+	LANConfigurationParameterBackupGatewayAddress       LANConfigurationParameter = 14
+	LANConfigurationParameterBackupGatewayMACAddress    LANConfigurationParameter = 15
+	LANConfigurationParameterCommunityString            LANConfigurationParameter = 16
+	LANConfigurationParameterDestinations               LANConfigurationParameter = 17
+	LANConfigurationParameterDestinationType            LANConfigurationParameter = 18
+	LANConfigurationParameterDestinationAddresses       LANConfigurationParameter = 19
+	LANConfigurationParameterVLANID                     LANConfigurationParameter = 20
+	LANConfigurationParameterVLANPriority               LANConfigurationParameter = 21
+	LANConfigurationParameterCipherSuiteEntrySupport    LANConfigurationParameter = 22
+	LANConfigurationParameterCipherSuiteEntries         LANConfigurationParameter = 23
+	LANConfigurationParameterCipherSuitePrivilegeLevels LANConfigurationParameter = 24
+	LANConfigurationParameterDestinationAddressVLANTags LANConfigurationParameter = 25
+)
+
+func (p LANConfigurationParameter) Description() string {
+	switch p {
+	case LANConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case LANConfigurationParameterAuthenticationTypeSupport:
+		return "Authentication Type Support"
+	case LANConfigurationParameterAuthenticationTypeEnables:
+		return "Authentication Type Enables"
+	case LANConfigurationParameterIPAddress:
+		return "IP Address"
+	case LANConfigurationParameterIPAddressSource:
+		return "IP Address Source"
+	case LANConfigurationParameterMACAddress:
+		return "MAC Address"
+	case LANConfigurationParameterSubnetMask:
+		return "Subnet Mask"
+	case LANConfigurationParameterIPv4HeaderParameters:
+		return "IPv4 Header Parameters"
+	case LANConfigurationParameterPrimaryRMCPPort:
+		return "Primary RMCP Port"
+	case LANConfigurationParameterSecondaryRMCPPort:
+		return "Secondary RMCP Port"
+	case LANConfigurationParameterBMCGeneratedARPControl:
+		return "BMC-generated ARP Control"
+	case LANConfigurationParameterGratuitousARPInterval:
+		return "Gratuitous ARP Interval"
+	case LANConfigurationParameterDefaultGatewayAddress:
+		return "Default Gateway Address"
+	case LANConfigurationParameterDefaultGatewayMACAddress:
+		return "Default Gateway MAC Address"
+	case LANConfigurationParameterBackupGatewayAddress:
+		return "Backup Gateway Address"
+	case LANConfigurationParameterBackupGatewayMACAddress:
+		return "Backup Gateway MAC Address"
+	case LANConfigurationParameterCommunityString:
+		return "Community String"
+	case LANConfigurationParameterDestinations:
+		return "Number of Destinations"
+	case LANConfigurationParameterDestinationType:
+		return "Destination Type"
+	case LANConfigurationParameterDestinationAddresses:
+		return "Destination Addresses"
+	case LANConfigurationParameterVLANID:
+		return "802.1q VLAN ID"
+	case LANConfigurationParameterVLANPriority:
+		return "802.1q VLAN Priority"
+	case LANConfigurationParameterCipherSuiteEntrySupport:
+		return "RMCP+ Messaging Cipher Suite Entry Support"
+	case LANConfigurationParameterCipherSuiteEntries:
+		return "RMCP+ Messaging Cipher Suite Entries"
+	case LANConfigurationParameterCipherSuitePrivilegeLevels:
+		return "RMCP+ Messaging Cipher Suite Privilege Levels"
+	case LANConfigurationParameterDestinationAddressVLANTags:
+		return "Destination Address VLAN TAGs"
+	}
+	if p >= 192 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (p LANConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
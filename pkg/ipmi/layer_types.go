@@ -224,4 +224,418 @@ var (
 			}),
 		},
 	)
+	LayerTypeReserveSELRsp = gopacket.RegisterLayerType(
+		1027,
+		gopacket.LayerTypeMetadata{
+			Name: "Reserve SEL Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ReserveSELRsp{}
+			}),
+		},
+	)
+	LayerTypeClearSELReq = gopacket.RegisterLayerType(
+		1028,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SEL Request",
+		},
+	)
+	LayerTypeClearSELRsp = gopacket.RegisterLayerType(
+		1029,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SEL Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ClearSELRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSDRRepositoryAllocationInfoRsp = gopacket.RegisterLayerType(
+		1030,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SDR Repository Allocation Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSDRRepositoryAllocationInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeReserveSDRRepositoryRsp = gopacket.RegisterLayerType(
+		1031,
+		gopacket.LayerTypeMetadata{
+			Name: "Reserve SDR Repository Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ReserveSDRRepositoryRsp{}
+			}),
+		},
+	)
+	LayerTypeAddSDRReq = gopacket.RegisterLayerType(
+		1032,
+		gopacket.LayerTypeMetadata{
+			Name: "Add SDR Request",
+		},
+	)
+	LayerTypeAddSDRRsp = gopacket.RegisterLayerType(
+		1033,
+		gopacket.LayerTypeMetadata{
+			Name: "Add SDR Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &AddSDRRsp{}
+			}),
+		},
+	)
+	LayerTypePartialAddSDRReq = gopacket.RegisterLayerType(
+		1034,
+		gopacket.LayerTypeMetadata{
+			Name: "Partial Add SDR Request",
+		},
+	)
+	LayerTypePartialAddSDRRsp = gopacket.RegisterLayerType(
+		1035,
+		gopacket.LayerTypeMetadata{
+			Name: "Partial Add SDR Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &PartialAddSDRRsp{}
+			}),
+		},
+	)
+	LayerTypeDeleteSDRReq = gopacket.RegisterLayerType(
+		1036,
+		gopacket.LayerTypeMetadata{
+			Name: "Delete SDR Request",
+		},
+	)
+	LayerTypeDeleteSDRRsp = gopacket.RegisterLayerType(
+		1037,
+		gopacket.LayerTypeMetadata{
+			Name: "Delete SDR Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &DeleteSDRRsp{}
+			}),
+		},
+	)
+	LayerTypeClearSDRRepositoryReq = gopacket.RegisterLayerType(
+		1038,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SDR Repository Request",
+		},
+	)
+	LayerTypeClearSDRRepositoryRsp = gopacket.RegisterLayerType(
+		1039,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SDR Repository Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ClearSDRRepositoryRsp{}
+			}),
+		},
+	)
+	LayerTypeRunInitializationAgentReq = gopacket.RegisterLayerType(
+		1040,
+		gopacket.LayerTypeMetadata{
+			Name: "Run Initialization Agent Request",
+		},
+	)
+	LayerTypeRunInitializationAgentRsp = gopacket.RegisterLayerType(
+		1041,
+		gopacket.LayerTypeMetadata{
+			Name: "Run Initialization Agent Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &RunInitializationAgentRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSystemInfoParametersReq = gopacket.RegisterLayerType(
+		1042,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Info Parameters Request",
+		},
+	)
+	LayerTypeGetSystemInfoParametersRsp = gopacket.RegisterLayerType(
+		1043,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Info Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSystemInfoParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeGetFRUInventoryAreaInfoReq = gopacket.RegisterLayerType(
+		1044,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU Inventory Area Info Request",
+		},
+	)
+	LayerTypeGetFRUInventoryAreaInfoRsp = gopacket.RegisterLayerType(
+		1045,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU Inventory Area Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetFRUInventoryAreaInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeReadFRUDataReq = gopacket.RegisterLayerType(
+		1046,
+		gopacket.LayerTypeMetadata{
+			Name: "Read FRU Data Request",
+		},
+	)
+	LayerTypeReadFRUDataRsp = gopacket.RegisterLayerType(
+		1047,
+		gopacket.LayerTypeMetadata{
+			Name: "Read FRU Data Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ReadFRUDataRsp{}
+			}),
+		},
+	)
+	LayerTypeGetLANConfigurationParametersReq = gopacket.RegisterLayerType(
+		1048,
+		gopacket.LayerTypeMetadata{
+			Name: "Get LAN Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetLANConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1049,
+		gopacket.LayerTypeMetadata{
+			Name: "Get LAN Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetLANConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeFRUCommonHeader = gopacket.RegisterLayerType(
+		1050,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Common Header",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUCommonHeader{}
+			}),
+		},
+	)
+	LayerTypeFRUChassisInfoArea = gopacket.RegisterLayerType(
+		1051,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Chassis Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUChassisInfoArea{}
+			}),
+		},
+	)
+	LayerTypeFRUBoardInfoArea = gopacket.RegisterLayerType(
+		1052,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Board Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUBoardInfoArea{}
+			}),
+		},
+	)
+	LayerTypeFRUProductInfoArea = gopacket.RegisterLayerType(
+		1053,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Product Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUProductInfoArea{}
+			}),
+		},
+	)
+	LayerTypeGetChassisCapabilitiesRsp = gopacket.RegisterLayerType(
+		1054,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Chassis Capabilities Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetChassisCapabilitiesRsp{}
+			}),
+		},
+	)
+	LayerTypeGetPOHCounterRsp = gopacket.RegisterLayerType(
+		1055,
+		gopacket.LayerTypeMetadata{
+			Name: "Get POH Counter Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPOHCounterRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSELEntryReq = gopacket.RegisterLayerType(
+		1056,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Entry Request",
+		},
+	)
+	LayerTypeGetSELEntryRsp = gopacket.RegisterLayerType(
+		1057,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Entry Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELEntryRsp{}
+			}),
+		},
+	)
+	LayerTypeSELEventRecord = gopacket.RegisterLayerType(
+		1058,
+		gopacket.LayerTypeMetadata{
+			Name: "SEL Event Record",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &SELEventRecord{}
+			}),
+		},
+	)
+	LayerTypeSetSystemBootOptionsReq = gopacket.RegisterLayerType(
+		1059,
+		gopacket.LayerTypeMetadata{
+			Name: "Set System Boot Options Request",
+		},
+	)
+	LayerTypeGetSystemBootOptionsReq = gopacket.RegisterLayerType(
+		1060,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Boot Options Request",
+		},
+	)
+	LayerTypeGetSystemBootOptionsRsp = gopacket.RegisterLayerType(
+		1061,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Boot Options Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSystemBootOptionsRsp{}
+			}),
+		},
+	)
+	LayerTypeBootFlags = gopacket.RegisterLayerType(
+		1062,
+		gopacket.LayerTypeMetadata{
+			Name: "Boot Flags",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &BootFlags{}
+			}),
+		},
+	)
+	LayerTypeFRUDeviceLocatorRecord = gopacket.RegisterLayerType(
+		1063,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Device Locator Record",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUDeviceLocatorRecord{}
+			}),
+		},
+	)
+	LayerTypeSetLANConfigurationParametersReq = gopacket.RegisterLayerType(
+		1064,
+		gopacket.LayerTypeMetadata{
+			Name: "Set LAN Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetSOLConfigurationParametersReq = gopacket.RegisterLayerType(
+		1065,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SOL Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetSOLConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1066,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SOL Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSOLConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSOLConfigurationParametersReq = gopacket.RegisterLayerType(
+		1067,
+		gopacket.LayerTypeMetadata{
+			Name: "Set SOL Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetPEFConfigurationParametersReq = gopacket.RegisterLayerType(
+		1068,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PEF Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetPEFConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1069,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PEF Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPEFConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeSetPEFConfigurationParametersReq = gopacket.RegisterLayerType(
+		1070,
+		gopacket.LayerTypeMetadata{
+			Name: "Set PEF Configuration Parameters Request",
+		},
+	)
+	LayerTypeRearmSensorEventsReq = gopacket.RegisterLayerType(
+		1071,
+		gopacket.LayerTypeMetadata{
+			Name: "Re-arm Sensor Events Request",
+		},
+	)
+	LayerTypeGetSELTimeUTCOffsetRsp = gopacket.RegisterLayerType(
+		1072,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Time UTC Offset Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELTimeUTCOffsetRsp{}
+			}),
+		},
+	)
+	LayerTypeGetChannelOEMPayloadInfoReq = gopacket.RegisterLayerType(
+		1073,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel OEM Payload Info Request",
+		},
+	)
+	LayerTypeGetChannelOEMPayloadInfoRsp = gopacket.RegisterLayerType(
+		1074,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel OEM Payload Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetChannelOEMPayloadInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSystemInfoParametersReq = gopacket.RegisterLayerType(
+		1075,
+		gopacket.LayerTypeMetadata{
+			Name: "Set System Info Parameters Request",
+		},
+	)
+	LayerTypeChassisIdentifyReq = gopacket.RegisterLayerType(
+		1076,
+		gopacket.LayerTypeMetadata{
+			Name: "Chassis Identify Request",
+		},
+	)
+	LayerTypeSuspendBMCARPsReq = gopacket.RegisterLayerType(
+		1079,
+		gopacket.LayerTypeMetadata{
+			Name: "Suspend BMC ARPs Request",
+		},
+	)
+	LayerTypeSuspendBMCARPsRsp = gopacket.RegisterLayerType(
+		1080,
+		gopacket.LayerTypeMetadata{
+			Name: "Suspend BMC ARPs Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &SuspendBMCARPsRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSensorReadingFactorsReq = gopacket.RegisterLayerType(
+		1081,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Reading Factors Request",
+		},
+	)
+	LayerTypeGetSensorReadingFactorsRsp = gopacket.RegisterLayerType(
+		1082,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Reading Factors Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSensorReadingFactorsRsp{}
+			}),
+		},
+	)
 )
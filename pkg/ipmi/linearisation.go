@@ -19,7 +19,10 @@ const (
 	LinearisationCube
 	LinearisationSqrt
 	LinearisationCubeRt
-	LinearisationNonLinear
+
+	// 0x0c through 0x6f are reserved.
+
+	LinearisationNonLinear Linearisation = 0x70
 
 	// 0x71 through 0x7f are reserved for non-linear, OEM defined
 	// linearisations. It is unclear why these cannot use
@@ -139,7 +142,7 @@ func (l Linearisation) IsLinear() bool {
 // final step before being used. A suitable implementation of this function is
 // returned by the Lineariser() method.
 func (l Linearisation) IsLinearised() bool {
-	return l > LinearisationLinear && l < LinearisationNonLinear
+	return l > LinearisationLinear && l <= LinearisationCubeRt
 }
 
 // IsNonLinear returns whether the underlying sensor is not consistent enough
@@ -148,7 +151,7 @@ func (l Linearisation) IsLinearised() bool {
 // sensors require Get Sensor Reading Factors to convert them into usable
 // values.
 func (l Linearisation) IsNonLinear() bool {
-	return l >= LinearisationNonLinear
+	return l >= LinearisationNonLinear && l <= 0x7f
 }
 
 // Lineariser returns a suitable Lineariser implementation that will turn the
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/kuiwang02/bmc/pkg/iana"
@@ -9,6 +10,29 @@ import (
 	"github.com/google/gopacket/layers"
 )
 
+var (
+	// ErrProbablyInsufficientPrivilege is returned when decoding a Group
+	// response with no body code and the Insufficient Privileges completion
+	// code. Some BMCs omit the body code when rejecting a Group command sent
+	// with too low a privilege level, e.g. User when Operator is required.
+	// Retrying will not help; the session must be established at a higher
+	// level.
+	ErrProbablyInsufficientPrivilege = errors.New("group response missing " +
+		"body code; the session privilege level is probably insufficient")
+
+	// ErrProbablyUnsupported is returned when decoding a Group response with
+	// no body code and any other completion code. This has been observed when
+	// the BMC does not support the command, e.g. DCMI commands on
+	// SuperMicro. Retrying will not help.
+	ErrProbablyUnsupported = errors.New("group response missing body " +
+		"code; the command is probably unsupported")
+
+	// ErrInvalidChecksum is wrapped by errors returned when decoding a message
+	// or FRU data whose checksum is incorrect. Messages with this error can be
+	// accepted by setting Message.IgnoreInvalidChecksums.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+)
+
 // Message represents an IPMI message, specified in 12.4 of the v1.5 spec and
 // 13.8 of the v2.0 spec. This is the layer within v1.5 sessions, and within
 // v2.0 sessions with the "IPMI" payload type. It carries addressing information
@@ -97,6 +121,25 @@ type Message struct {
 	// number and command. If this checksum is incorrect, the BMC drops the
 	// packet.
 	Checksum2 uint8
+
+	// IgnoreInvalidChecksums controls how checksum mismatches are handled when
+	// decoding. By default, a mismatch of either checksum fails the decode.
+	// Some BMCs are known to emit an incorrect Checksum1 on bridged responses
+	// despite the rest of the message being intact; setting this to true
+	// causes such messages to be decoded anyway, with the mismatch recorded in
+	// InvalidChecksum1 and InvalidChecksum2. This field is not touched by
+	// DecodeFromBytes, so can be set once on a reused layer.
+	IgnoreInvalidChecksums bool
+
+	// InvalidChecksum1 is set during decoding if Checksum1 did not match the
+	// data it covers. This can only be true if IgnoreInvalidChecksums is set,
+	// as otherwise decoding fails.
+	InvalidChecksum1 bool
+
+	// InvalidChecksum2 is set during decoding if Checksum2 did not match the
+	// data it covers. This can only be true if IgnoreInvalidChecksums is set,
+	// as otherwise decoding fails.
+	InvalidChecksum2 bool
 }
 
 func (*Message) LayerType() gopacket.LayerType {
@@ -135,9 +178,13 @@ func (m *Message) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 	m.Function = NetworkFunction(data[1] >> 2)
 	m.RemoteLUN = LUN(data[1] & 0x3)
 	m.Checksum1 = uint8(data[2])
+	m.InvalidChecksum1 = false
 	if want := checksum(data[:2]); m.Checksum1 != want {
-		return fmt.Errorf("invalid checksum1: got %v, want %v", m.Checksum1,
-			want)
+		if !m.IgnoreInvalidChecksums {
+			return fmt.Errorf("%w 1: got %v, want %v", ErrInvalidChecksum,
+				m.Checksum1, want)
+		}
+		m.InvalidChecksum1 = true
 	}
 
 	m.LocalAddress = Address(data[3])
@@ -147,9 +194,13 @@ func (m *Message) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 
 	// last checksum is always last byte
 	m.Checksum2 = uint8(data[len(data)-1])
+	m.InvalidChecksum2 = false
 	if want := checksum(data[3 : len(data)-1]); m.Checksum2 != want {
-		return fmt.Errorf("invalid checksum2: got %v, want %v", m.Checksum2,
-			want)
+		if !m.IgnoreInvalidChecksums {
+			return fmt.Errorf("%w 2: got %v, want %v", ErrInvalidChecksum,
+				m.Checksum2, want)
+		}
+		m.InvalidChecksum2 = true
 	}
 
 	if m.Function.IsRequest() {
@@ -158,6 +209,12 @@ func (m *Message) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
 	return m.decodeResponse(data, df)
 }
 
+// HasInvalidChecksum returns whether either checksum was found to be invalid
+// during decoding. This can only be true if IgnoreInvalidChecksums is set.
+func (m *Message) HasInvalidChecksum() bool {
+	return m.InvalidChecksum1 || m.InvalidChecksum2
+}
+
 func (m *Message) decodeRequest(data []byte, df gopacket.DecodeFeedback) error {
 	m.CompletionCode = 0
 	return m.decodeDataHeader(data, 6, df)
@@ -188,7 +245,14 @@ func (m *Message) decodeSpecialNetFns(data []byte, df gopacket.DecodeFeedback) (
 			// (e.g. user when operator is required), and when the BMC does not
 			// support the command (e.g. SuperMicro)
 			df.SetTruncated()
-			return 0, fmt.Errorf("data too short for body code")
+			if m.Function.IsRequest() {
+				return 0, fmt.Errorf("data too short for body code")
+			}
+			if m.CompletionCode == CompletionCodeInsufficientPrivileges {
+				return 0, ErrProbablyInsufficientPrivilege
+			}
+			return 0, fmt.Errorf("%w (completion code %v)",
+				ErrProbablyUnsupported, m.CompletionCode)
 		}
 		m.Body = BodyCode(data[0])
 		return 1, nil
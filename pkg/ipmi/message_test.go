@@ -2,6 +2,7 @@ package ipmi
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -207,3 +208,94 @@ func TestChecksum(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageIgnoreInvalidChecksums(t *testing.T) {
+	table := []struct {
+		wire   []byte
+		ignore bool
+		valid  bool
+		want1  bool
+		want2  bool
+	}{
+		{
+			[]byte{0x20, 0x1c, 0xc4, 0x81, 0xbe, 0x38, 0x22, 0x67},
+			false,
+			true,
+			false,
+			false,
+		},
+		{
+			// corrupt checksum1
+			[]byte{0x20, 0x1c, 0xc5, 0x81, 0xbe, 0x38, 0x22, 0x67},
+			false,
+			false,
+			false,
+			false,
+		},
+		{
+			[]byte{0x20, 0x1c, 0xc5, 0x81, 0xbe, 0x38, 0x22, 0x67},
+			true,
+			true,
+			true,
+			false,
+		},
+		{
+			// corrupt checksum2
+			[]byte{0x20, 0x1c, 0xc4, 0x81, 0xbe, 0x38, 0x22, 0x68},
+			true,
+			true,
+			false,
+			true,
+		},
+	}
+	for _, test := range table {
+		msg := &Message{
+			IgnoreInvalidChecksums: test.ignore,
+		}
+		err := msg.DecodeFromBytes(test.wire, gopacket.NilDecodeFeedback)
+		switch {
+		case err != nil && test.valid:
+			t.Errorf("decode %v failed with %v, wanted success", test.wire, err)
+		case err == nil && !test.valid:
+			t.Errorf("decode %v succeeded, wanted error", test.wire)
+		case !test.valid && !errors.Is(err, ErrInvalidChecksum):
+			t.Errorf("decode %v failed with %v, want ErrInvalidChecksum",
+				test.wire, err)
+		case err == nil:
+			if msg.InvalidChecksum1 != test.want1 ||
+				msg.InvalidChecksum2 != test.want2 {
+				t.Errorf("decode %v = (%v, %v) invalid checksums, want (%v, %v)",
+					test.wire, msg.InvalidChecksum1, msg.InvalidChecksum2,
+					test.want1, test.want2)
+			}
+			if got, want := msg.HasInvalidChecksum(), test.want1 || test.want2; got != want {
+				t.Errorf("HasInvalidChecksum() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestMessageMissingBodyCode(t *testing.T) {
+	table := []struct {
+		code CompletionCode
+		want error
+	}{
+		{CompletionCodeInsufficientPrivileges, ErrProbablyInsufficientPrivilege},
+		{CompletionCodeNormal, ErrProbablyUnsupported},
+		{CompletionCodeUnrecognisedCommand, ErrProbablyUnsupported},
+	}
+	for _, test := range table {
+		// group response with no body code
+		wire := []byte{0x81, uint8(NetworkFunctionGroupRsp) << 2, 0, 0x20, 0x04,
+			0x02, uint8(test.code), 0}
+		wire[2] = checksum(wire[:2])
+		wire[7] = checksum(wire[3:7])
+
+		msg := &Message{}
+		err := msg.DecodeFromBytes(wire, gopacket.NilDecodeFeedback)
+		if !errors.Is(err, test.want) {
+			t.Errorf("decode with completion code %v = %v, want %v", test.code,
+				err, test.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ModifierRelation indicates how the modifier unit of a sensor is combined
+// with its base unit, if at all. It is specified in byte 21 of the Full Sensor
+// Record table in 37.1 and 43.1 of v1.5 and v2.0 respectively. This is a 2-bit
+// uint on the wire.
+type ModifierRelation uint8
+
+const (
+	// ModifierRelationNone indicates the modifier unit is unused.
+	ModifierRelationNone ModifierRelation = iota
+
+	// ModifierRelationDivide indicates the unit is the base unit divided by
+	// the modifier unit, e.g. feet per minute.
+	ModifierRelationDivide
+
+	// ModifierRelationMultiply indicates the unit is the base unit multiplied
+	// by the modifier unit, e.g. foot-pounds.
+	ModifierRelationMultiply
+)
+
+func (m ModifierRelation) String() string {
+	switch m {
+	case ModifierRelationNone:
+		return "None"
+	case ModifierRelationDivide:
+		return "Divide"
+	case ModifierRelationMultiply:
+		return "Multiply"
+	default:
+		return fmt.Sprintf("Unknown(%v)", uint8(m))
+	}
+}
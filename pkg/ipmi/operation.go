@@ -102,6 +102,246 @@ var (
 		Function: NetworkFunctionAppRsp,
 		Command:  0x3d,
 	}
+	OperationReserveSELReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x42,
+	}
+	OperationReserveSELRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x42,
+	}
+	OperationClearSELReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x47,
+	}
+	OperationClearSELRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x47,
+	}
+	OperationGetSDRRepositoryAllocationInfoReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x21,
+	}
+	OperationGetSDRRepositoryAllocationInfoRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x21,
+	}
+	OperationReserveSDRRepositoryReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x22,
+	}
+	OperationReserveSDRRepositoryRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x22,
+	}
+	OperationAddSDRReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x24,
+	}
+	OperationAddSDRRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x24,
+	}
+	OperationPartialAddSDRReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x25,
+	}
+	OperationPartialAddSDRRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x25,
+	}
+	OperationDeleteSDRReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x26,
+	}
+	OperationDeleteSDRRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x26,
+	}
+	OperationClearSDRRepositoryReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x27,
+	}
+	OperationClearSDRRepositoryRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x27,
+	}
+	OperationRunInitializationAgentReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x2c,
+	}
+	OperationRunInitializationAgentRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x2c,
+	}
+	OperationGetSystemInfoParametersReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x59,
+	}
+	OperationGetSystemInfoParametersRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x59,
+	}
+	OperationGetFRUInventoryAreaInfoReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x10,
+	}
+	OperationGetFRUInventoryAreaInfoRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x10,
+	}
+	OperationReadFRUDataReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x11,
+	}
+	OperationReadFRUDataRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x11,
+	}
+	OperationGetLANConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x02,
+	}
+	OperationGetLANConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x02,
+	}
+	OperationGetChassisCapabilitiesReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x00,
+	}
+	OperationGetChassisCapabilitiesRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x00,
+	}
+	OperationGetPOHCounterReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x0f,
+	}
+	OperationGetPOHCounterRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x0f,
+	}
+	OperationGetSELEntryReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x43,
+	}
+	OperationGetSELEntryRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x43,
+	}
+	OperationSetSystemBootOptionsReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x08,
+	}
+	OperationSetSystemBootOptionsRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x08,
+	}
+	OperationGetSystemBootOptionsReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x09,
+	}
+	OperationGetSystemBootOptionsRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x09,
+	}
+	OperationSetLANConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x01,
+	}
+	OperationSetLANConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x01,
+	}
+	OperationGetSOLConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x22,
+	}
+	OperationGetSOLConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x22,
+	}
+	OperationSetSOLConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x21,
+	}
+	OperationSetSOLConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x21,
+	}
+	OperationGetPEFConfigurationParametersReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x13,
+	}
+	OperationGetPEFConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x13,
+	}
+	OperationSetPEFConfigurationParametersReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x12,
+	}
+	OperationSetPEFConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x12,
+	}
+	OperationRearmSensorEventsReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x2a,
+	}
+	OperationRearmSensorEventsRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x2a,
+	}
+	OperationGetSELTimeUTCOffsetReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x5c,
+	}
+	OperationGetSELTimeUTCOffsetRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x5c,
+	}
+	OperationGetChannelOEMPayloadInfoReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x50,
+	}
+	OperationGetChannelOEMPayloadInfoRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x50,
+	}
+	OperationSetSystemInfoParametersReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x58,
+	}
+	OperationSetSystemInfoParametersRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x58,
+	}
+	OperationChassisIdentifyReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x04,
+	}
+	OperationChassisIdentifyRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x04,
+	}
+	OperationSuspendBMCARPsReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x03,
+	}
+	OperationSuspendBMCARPsRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x03,
+	}
+	OperationGetSensorReadingFactorsReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x23,
+	}
+	OperationGetSensorReadingFactorsRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x23,
+	}
 
 	// operationLayerTypes tells us which layer comes next given a network
 	// function and command. It should never be modified during runtime, as
@@ -116,11 +356,40 @@ var (
 		OperationGetSDRRsp:                               LayerTypeGetSDRRsp,
 		OperationGetSensorReadingRsp:                     LayerTypeGetSensorReadingRsp,
 		OperationGetSessionInfoRsp:                       LayerTypeGetSessionInfoRsp,
+		OperationReserveSELRsp:                           LayerTypeReserveSELRsp,
+		OperationClearSELRsp:                             LayerTypeClearSELRsp,
+		OperationGetSDRRepositoryAllocationInfoRsp:       LayerTypeGetSDRRepositoryAllocationInfoRsp,
+		OperationReserveSDRRepositoryRsp:                 LayerTypeReserveSDRRepositoryRsp,
+		OperationAddSDRRsp:                               LayerTypeAddSDRRsp,
+		OperationPartialAddSDRRsp:                        LayerTypePartialAddSDRRsp,
+		OperationDeleteSDRRsp:                            LayerTypeDeleteSDRRsp,
+		OperationClearSDRRepositoryRsp:                   LayerTypeClearSDRRepositoryRsp,
+		OperationRunInitializationAgentRsp:               LayerTypeRunInitializationAgentRsp,
+		OperationGetSystemInfoParametersRsp:              LayerTypeGetSystemInfoParametersRsp,
+		OperationGetFRUInventoryAreaInfoRsp:              LayerTypeGetFRUInventoryAreaInfoRsp,
+		OperationReadFRUDataRsp:                          LayerTypeReadFRUDataRsp,
+		OperationGetLANConfigurationParametersRsp:        LayerTypeGetLANConfigurationParametersRsp,
+		OperationGetChassisCapabilitiesRsp:               LayerTypeGetChassisCapabilitiesRsp,
+		OperationGetPOHCounterRsp:                        LayerTypeGetPOHCounterRsp,
+		OperationGetSELEntryRsp:                          LayerTypeGetSELEntryRsp,
+		OperationGetSystemBootOptionsRsp:                 LayerTypeGetSystemBootOptionsRsp,
+		OperationGetSOLConfigurationParametersRsp:        LayerTypeGetSOLConfigurationParametersRsp,
+		OperationGetPEFConfigurationParametersRsp:        LayerTypeGetPEFConfigurationParametersRsp,
+		OperationGetSELTimeUTCOffsetRsp:                  LayerTypeGetSELTimeUTCOffsetRsp,
+		OperationGetChannelOEMPayloadInfoRsp:             LayerTypeGetChannelOEMPayloadInfoRsp,
+		OperationSuspendBMCARPsRsp:                       LayerTypeSuspendBMCARPsRsp,
+		OperationGetSensorReadingFactorsRsp:              LayerTypeGetSensorReadingFactorsRsp,
 	}
 )
 
 func (o Operation) String() string {
-	return fmt.Sprintf("%v, %v", o.Function, o.NextLayerType())
+	switch o.Function {
+	case NetworkFunctionOEMReq, NetworkFunctionOEMRsp:
+		return fmt.Sprintf("%v, %v, %v", o.Function, o.Enterprise,
+			o.NextLayerType())
+	default:
+		return fmt.Sprintf("%v, %v", o.Function, o.NextLayerType())
+	}
 }
 
 func (o Operation) NextLayerType() gopacket.LayerType {
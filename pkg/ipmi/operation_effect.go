@@ -0,0 +1,105 @@
+package ipmi
+
+import (
+	"sync"
+)
+
+// OperationEffect describes the impact of a request operation on the managed
+// system, allowing callers to treat commands differently without maintaining
+// their own list, e.g. to audit them. Effects are ordered by severity, so each
+// includes those before it.
+type OperationEffect uint8
+
+const (
+	// OperationEffectNone is the effect of operations not known to change the
+	// managed system, e.g. Get Device ID. It is also the effect of unknown
+	// operations, such as OEM commands without a registered effect.
+	OperationEffectNone OperationEffect = iota
+
+	// OperationEffectStateChanging is the effect of operations that modify
+	// the configuration or state of the managed system, e.g. setting boot
+	// options or arming the watchdog, but are neither disruptive nor
+	// irreversible.
+	OperationEffectStateChanging
+
+	// OperationEffectDestructive is the effect of operations that disrupt the
+	// managed system or irreversibly discard data, e.g. power cycling, clearing
+	// the SEL or changing user credentials.
+	OperationEffectDestructive
+)
+
+func (e OperationEffect) String() string {
+	switch e {
+	case OperationEffectNone:
+		return "None"
+	case OperationEffectStateChanging:
+		return "State Changing"
+	case OperationEffectDestructive:
+		return "Destructive"
+	default:
+		return "Unknown"
+	}
+}
+
+var (
+	// operationEffects contains the effect of each request operation that is
+	// not OperationEffectNone.
+	operationEffects = map[Operation]OperationEffect{
+		OperationSetChassisCapabilitiesReq:        OperationEffectStateChanging,
+		OperationChassisControlReq:                OperationEffectDestructive,
+		OperationChassisIdentifyReq:               OperationEffectStateChanging,
+		OperationSetFrontPanelEnablesReq:          OperationEffectStateChanging,
+		OperationSetPowerRestorePolicyReq:         OperationEffectStateChanging,
+		OperationSetSystemBootOptionsReq:          OperationEffectStateChanging,
+		OperationResetWatchdogTimerReq:            OperationEffectStateChanging,
+		OperationSetWatchdogTimerReq:              OperationEffectStateChanging,
+		OperationSetSystemInfoParametersReq:       OperationEffectStateChanging,
+		OperationSetLANConfigurationParametersReq: OperationEffectStateChanging,
+		OperationSetSOLConfigurationParametersReq: OperationEffectStateChanging,
+		OperationSetPEFConfigurationParametersReq: OperationEffectStateChanging,
+		OperationSuspendBMCARPsReq:                OperationEffectStateChanging,
+		OperationRearmSensorEventsReq:             OperationEffectStateChanging,
+		OperationAddSDRReq:                        OperationEffectStateChanging,
+		OperationPartialAddSDRReq:                 OperationEffectStateChanging,
+		OperationDeleteSDRReq:                     OperationEffectDestructive,
+		OperationClearSDRRepositoryReq:            OperationEffectDestructive,
+		OperationRunInitializationAgentReq:        OperationEffectStateChanging,
+		OperationClearSELReq:                      OperationEffectDestructive,
+		OperationSetSELTimeReq:                    OperationEffectStateChanging,
+		OperationSetUserAccessReq:                 OperationEffectDestructive,
+		OperationSetUserNameReq:                   OperationEffectDestructive,
+		OperationSetUserPasswordReq:               OperationEffectDestructive,
+	}
+	operationEffectsMu sync.RWMutex
+)
+
+// EffectCommand is an optional interface implemented by commands whose effect
+// depends on the request, not only the operation. For example, Clear SEL
+// either starts erasing the SEL, or retrieves the progress of an erasure. If a
+// command does not implement this interface, its effect is that of its
+// operation.
+type EffectCommand interface {
+	Command
+
+	// Effect returns the impact of sending the command's current request on
+	// the managed system.
+	Effect() OperationEffect
+}
+
+// RegisterOperationEffect sets the effect of a request operation, replacing
+// any existing effect. As with RegisterPrivilegeLevel(), it is intended for
+// packages implementing commands outside this one, and would normally be
+// called from an init function.
+func RegisterOperationEffect(op Operation, effect OperationEffect) {
+	operationEffectsMu.Lock()
+	defer operationEffectsMu.Unlock()
+	operationEffects[op] = effect
+}
+
+// Effect returns the impact of the request operation on the managed system.
+// Operations without a known effect return OperationEffectNone.
+func (o Operation) Effect() OperationEffect {
+	operationEffectsMu.RLock()
+	defer operationEffectsMu.RUnlock()
+	return operationEffects[o]
+}
@@ -0,0 +1,83 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestOperationEffect(t *testing.T) {
+	tests := []struct {
+		op   Operation
+		want OperationEffect
+	}{
+		{OperationGetDeviceIDReq, OperationEffectNone},
+		{OperationChassisControlReq, OperationEffectDestructive},
+		{OperationSetSystemBootOptionsReq, OperationEffectStateChanging},
+		{OperationSetUserPasswordReq, OperationEffectDestructive},
+		{
+			Operation{
+				Function: NetworkFunctionOEMReq,
+				Command:  0x01,
+			},
+			OperationEffectNone,
+		},
+	}
+	for _, test := range tests {
+		if got := test.op.Effect(); got != test.want {
+			t.Errorf("%v.Effect() = %v, want %v", test.op, got, test.want)
+		}
+	}
+}
+
+func TestEffectCommand(t *testing.T) {
+	tests := []struct {
+		cmd  EffectCommand
+		want OperationEffect
+	}{
+		{
+			&ClearSELCmd{
+				Req: ClearSELReq{
+					Action: ClearSELActionInitiateErase,
+				},
+			},
+			OperationEffectDestructive,
+		},
+		{
+			&ClearSELCmd{
+				Req: ClearSELReq{
+					Action: ClearSELActionGetErasureStatus,
+				},
+			},
+			OperationEffectNone,
+		},
+		{
+			&ClearSDRRepositoryCmd{
+				Req: ClearSDRRepositoryReq{
+					Action: ClearSELActionGetErasureStatus,
+				},
+			},
+			OperationEffectNone,
+		},
+		{
+			&SetUserPasswordCmd{
+				Req: SetUserPasswordReq{
+					Operation: UserPasswordOperationSetPassword,
+				},
+			},
+			OperationEffectDestructive,
+		},
+		{
+			&SetUserPasswordCmd{
+				Req: SetUserPasswordReq{
+					Operation: UserPasswordOperationTestPassword,
+				},
+			},
+			OperationEffectNone,
+		},
+	}
+	for _, test := range tests {
+		if got := test.cmd.Effect(); got != test.want {
+			t.Errorf("%v %+v Effect() = %v, want %v", test.cmd.Name(),
+				test.cmd.Request(), got, test.want)
+		}
+	}
+}
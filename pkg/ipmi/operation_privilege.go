@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"sync"
+)
+
+var (
+	// operationPrivilegeLevels contains the minimum privilege level required
+	// to execute each request operation within a session, as listed in
+	// Appendix G of IPMI v2.0.
+	operationPrivilegeLevels = map[Operation]PrivilegeLevel{
+		OperationGetChassisCapabilitiesReq:               PrivilegeLevelUser,
+		OperationGetChassisStatusReq:                     PrivilegeLevelUser,
+		OperationSetChassisCapabilitiesReq:               PrivilegeLevelAdministrator,
+		OperationChassisControlReq:                       PrivilegeLevelOperator,
+		OperationChassisIdentifyReq:                      PrivilegeLevelOperator,
+		OperationGetPOHCounterReq:                        PrivilegeLevelUser,
+		OperationSetSystemBootOptionsReq:                 PrivilegeLevelOperator,
+		OperationGetSystemBootOptionsReq:                 PrivilegeLevelOperator,
+		OperationGetDeviceIDReq:                          PrivilegeLevelUser,
+		OperationGetSystemGUIDReq:                        PrivilegeLevelUser,
+		OperationResetWatchdogTimerReq:                   PrivilegeLevelOperator,
+		OperationSetWatchdogTimerReq:                     PrivilegeLevelOperator,
+		OperationGetWatchdogTimerReq:                     PrivilegeLevelUser,
+		OperationGetChannelAuthenticationCapabilitiesReq: PrivilegeLevelCallback,
+		OperationGetSessionInfoReq:                       PrivilegeLevelUser,
+		OperationGetChannelOEMPayloadInfoReq:             PrivilegeLevelUser,
+		OperationCloseSessionReq:                         PrivilegeLevelCallback,
+		OperationGetSystemInfoParametersReq:              PrivilegeLevelUser,
+		OperationSetSystemInfoParametersReq:              PrivilegeLevelAdministrator,
+		OperationGetLANConfigurationParametersReq:        PrivilegeLevelOperator,
+		OperationGetIPUDPRMCPStatisticsReq:               PrivilegeLevelUser,
+		OperationSuspendBMCARPsReq:                       PrivilegeLevelAdministrator,
+		OperationGetSensorReadingReq:                     PrivilegeLevelUser,
+		OperationGetSensorReadingFactorsReq:              PrivilegeLevelUser,
+		OperationGetFRUInventoryAreaInfoReq:              PrivilegeLevelUser,
+		OperationReadFRUDataReq:                          PrivilegeLevelUser,
+		OperationGetSDRRepositoryInfoReq:                 PrivilegeLevelUser,
+		OperationGetSDRRepositoryAllocationInfoReq:       PrivilegeLevelUser,
+		OperationReserveSDRRepositoryReq:                 PrivilegeLevelUser,
+		OperationGetSDRReq:                               PrivilegeLevelUser,
+		OperationAddSDRReq:                               PrivilegeLevelOperator,
+		OperationPartialAddSDRReq:                        PrivilegeLevelOperator,
+		OperationDeleteSDRReq:                            PrivilegeLevelOperator,
+		OperationClearSDRRepositoryReq:                   PrivilegeLevelOperator,
+		OperationRunInitializationAgentReq:               PrivilegeLevelOperator,
+		OperationGetSELInfoReq:                           PrivilegeLevelUser,
+		OperationReserveSELReq:                           PrivilegeLevelUser,
+		OperationGetSELEntryReq:                          PrivilegeLevelUser,
+		OperationClearSELReq:                             PrivilegeLevelOperator,
+		OperationGetSELTimeReq:                           PrivilegeLevelUser,
+		OperationSetSELTimeReq:                           PrivilegeLevelOperator,
+	}
+	operationPrivilegeLevelsMu sync.RWMutex
+)
+
+// RegisterPrivilegeLevel sets the minimum privilege level required to execute
+// a request operation, replacing any existing level. As with
+// RegisterRetryPolicy(), it is intended for packages implementing commands
+// outside this one, and would normally be called from an init function.
+func RegisterPrivilegeLevel(op Operation, level PrivilegeLevel) {
+	operationPrivilegeLevelsMu.Lock()
+	defer operationPrivilegeLevelsMu.Unlock()
+	operationPrivilegeLevels[op] = level
+}
+
+// PrivilegeLevel returns the minimum session privilege level the specification
+// requires to execute the request operation. The second return value is false
+// if the level is unknown, e.g. for OEM commands, in which case the caller
+// should let the BMC decide.
+func (o Operation) PrivilegeLevel() (PrivilegeLevel, bool) {
+	operationPrivilegeLevelsMu.RLock()
+	defer operationPrivilegeLevelsMu.RUnlock()
+	level, ok := operationPrivilegeLevels[o]
+	return level, ok
+}
@@ -0,0 +1,32 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestOperationPrivilegeLevel(t *testing.T) {
+	tests := []struct {
+		op        Operation
+		wantLevel PrivilegeLevel
+		wantOK    bool
+	}{
+		{OperationGetDeviceIDReq, PrivilegeLevelUser, true},
+		{OperationChassisControlReq, PrivilegeLevelOperator, true},
+		{OperationCloseSessionReq, PrivilegeLevelCallback, true},
+		{OperationGetDeviceIDRsp, 0, false},
+		{
+			Operation{
+				Function: NetworkFunctionOEMReq,
+				Command:  0x01,
+			},
+			0, false,
+		},
+	}
+	for _, test := range tests {
+		level, ok := test.op.PrivilegeLevel()
+		if level != test.wantLevel || ok != test.wantOK {
+			t.Errorf("%v.PrivilegeLevel() = (%v, %v), want (%v, %v)", test.op,
+				level, ok, test.wantLevel, test.wantOK)
+		}
+	}
+}
@@ -19,21 +19,63 @@ const (
 	// that are used in events it generates.
 	OutputTypeThreshold
 
-	// many not implemented; we'll save this complexity for when we actually
-	// need it. Sensor classes in particular are all special cases of each other
-	// and so are meaningless due to the overlap.
+	// OutputTypeDMIUsageState and the following types up to
+	// OutputTypeACPIDevicePowerState are generic discrete types, whose
+	// offsets mean the same thing regardless of sensor type.
+	OutputTypeDMIUsageState
+	OutputTypeDigitalState
+	OutputTypePredictiveFailure
+	OutputTypeLimitExceeded
+	OutputTypePerformance
+	OutputTypeSeverity
+	OutputTypeDevicePresence
+	OutputTypeDeviceEnabled
+	OutputTypeAvailabilityState
+	OutputTypeRedundancy
+	OutputTypeACPIDevicePowerState
+
+	// OutputTypeSensorSpecific indicates a discrete sensor whose offsets are
+	// defined by its sensor type, e.g. chassis intrusion.
+	OutputTypeSensorSpecific OutputType = 0x6f
 )
 
 var (
 	outputTypeDescriptions = map[OutputType]string{
-		OutputTypeThreshold: "Threshold",
+		OutputTypeThreshold:            "Threshold",
+		OutputTypeDMIUsageState:        "DMI Usage State",
+		OutputTypeDigitalState:         "Digital State",
+		OutputTypePredictiveFailure:    "Predictive Failure",
+		OutputTypeLimitExceeded:        "Limit Exceeded",
+		OutputTypePerformance:          "Performance",
+		OutputTypeSeverity:             "Severity",
+		OutputTypeDevicePresence:       "Device Presence",
+		OutputTypeDeviceEnabled:        "Device Enabled",
+		OutputTypeAvailabilityState:    "Availability State",
+		OutputTypeRedundancy:           "Redundancy",
+		OutputTypeACPIDevicePowerState: "ACPI Device Power State",
+		OutputTypeSensorSpecific:       "Sensor-specific",
 	}
 )
 
+// IsGeneric returns whether the output type is a generic discrete type.
+func (o OutputType) IsGeneric() bool {
+	return o >= OutputTypeDMIUsageState && o <= OutputTypeACPIDevicePowerState
+}
+
+// IsOEM returns whether the output type is in the OEM range, 0x70-0x7f.
+func (o OutputType) IsOEM() bool {
+	return o >= 0x70 && o <= 0x7f
+}
+
+// Description returns the name of the output type, "OEM" if it is
+// OEM-defined, or "Unknown" otherwise.
 func (o OutputType) Description() string {
 	if desc, ok := outputTypeDescriptions[o]; ok {
 		return desc
 	}
+	if o.IsOEM() {
+		return "OEM"
+	}
 	return "Unknown"
 }
 
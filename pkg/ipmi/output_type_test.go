@@ -0,0 +1,44 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestOutputTypeString(t *testing.T) {
+	tests := []struct {
+		outputType OutputType
+		want       string
+	}{
+		{0, "0x0(Unknown)"},
+		{OutputTypeThreshold, "0x1(Threshold)"},
+		{OutputTypeACPIDevicePowerState, "0xc(ACPI Device Power State)"},
+		{0xd, "0xd(Unknown)"},
+		{OutputTypeSensorSpecific, "0x6f(Sensor-specific)"},
+		{0x70, "0x70(OEM)"},
+		{0x80, "0x80(Unknown)"},
+	}
+	for _, test := range tests {
+		if got := test.outputType.String(); got != test.want {
+			t.Errorf("String(%d) = %v, want %v", uint8(test.outputType), got,
+				test.want)
+		}
+	}
+}
+
+func TestOutputTypeIsGeneric(t *testing.T) {
+	tests := []struct {
+		outputType OutputType
+		want       bool
+	}{
+		{OutputTypeThreshold, false},
+		{OutputTypeDMIUsageState, true},
+		{OutputTypeACPIDevicePowerState, true},
+		{OutputTypeSensorSpecific, false},
+	}
+	for _, test := range tests {
+		if got := test.outputType.IsGeneric(); got != test.want {
+			t.Errorf("IsGeneric(%v) = %v, want %v", test.outputType, got,
+				test.want)
+		}
+	}
+}
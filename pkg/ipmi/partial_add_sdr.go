@@ -0,0 +1,112 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// PartialAddSDRReq represents a Partial Add SDR command, specified in section
+// 27.14 and 33.14 of IPMI v1.5 and v2.0 respectively. This allows adding a
+// record too large to fit in a single request. The first request must have a
+// RecordID and Offset of 0 and contain at least the record header; the BMC
+// responds with the ID it assigned, which must be used in subsequent requests.
+// The record is only committed once a request with Last set is received.
+type PartialAddSDRReq struct {
+	layers.BaseLayer
+
+	// ReservationID is a token obtained via Reserve SDR Repository.
+	ReservationID ReservationID
+
+	// RecordID is 0 for the first request, then the ID returned by the BMC in
+	// response to it for subsequent requests.
+	RecordID RecordID
+
+	// Offset is the offset into the record at which Data begins.
+	Offset uint8
+
+	// Last indicates this request contains the final bytes of the record,
+	// causing the BMC to commit it to the repository.
+	Last bool
+
+	// Data is the portion of the record to write at Offset.
+	Data []byte
+}
+
+func (*PartialAddSDRReq) LayerType() gopacket.LayerType {
+	return LayerTypePartialAddSDRReq
+}
+
+func (r *PartialAddSDRReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6 + len(r.Data))
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(r.ReservationID))
+	binary.LittleEndian.PutUint16(bytes[2:4], uint16(r.RecordID))
+	bytes[4] = r.Offset
+	bytes[5] = 0
+	if r.Last {
+		bytes[5] = 1
+	}
+	copy(bytes[6:], r.Data)
+	return nil
+}
+
+// PartialAddSDRRsp contains the record ID assigned to the record being added.
+type PartialAddSDRRsp struct {
+	layers.BaseLayer
+
+	// RecordID is the ID the BMC assigned to the record.
+	RecordID RecordID
+}
+
+func (*PartialAddSDRRsp) LayerType() gopacket.LayerType {
+	return LayerTypePartialAddSDRRsp
+}
+
+func (r *PartialAddSDRRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*PartialAddSDRRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *PartialAddSDRRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+
+	r.RecordID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type PartialAddSDRCmd struct {
+	Req PartialAddSDRReq
+	Rsp PartialAddSDRRsp
+}
+
+// Name returns "Partial Add SDR".
+func (*PartialAddSDRCmd) Name() string {
+	return "Partial Add SDR"
+}
+
+// Operation returns OperationPartialAddSDRReq.
+func (*PartialAddSDRCmd) Operation() *Operation {
+	return &OperationPartialAddSDRReq
+}
+
+func (c *PartialAddSDRCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *PartialAddSDRCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,45 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestPartialAddSDRReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *PartialAddSDRReq
+		want  []byte
+	}{
+		{
+			&PartialAddSDRReq{
+				ReservationID: 0x0102,
+				Data:          []byte{0x00, 0x00, 0x51, 0x01, 0x33},
+			},
+			[]byte{0x02, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x51, 0x01, 0x33},
+		},
+		{
+			&PartialAddSDRReq{
+				ReservationID: 0x0102,
+				RecordID:      0x0040,
+				Offset:        5,
+				Last:          true,
+				Data:          []byte{0xaa, 0xbb},
+			},
+			[]byte{0x02, 0x01, 0x40, 0x00, 0x05, 0x01, 0xaa, 0xbb},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package ipmi
 
+import (
+	"fmt"
+)
+
 // PayloadType identifies the layer immediately within the RMCP+ session
 // wrapper. Values are specified in 13.27.3 of the IPMI v2.0 spec. This is a
 // 6-bit uint on the wire.
@@ -10,6 +14,10 @@ const (
 
 	PayloadTypeIPMI PayloadType = 0x0
 
+	// PayloadTypeSOL is Serial Over LAN data, which the BMC may send at any
+	// time once SOL is activated.
+	PayloadTypeSOL PayloadType = 0x1
+
 	// PayloadTypeOEM means "check the OEM IANA and OEM payload ID to find out
 	// what this actually is".
 	PayloadTypeOEM PayloadType = 0x2
@@ -22,12 +30,33 @@ const (
 	PayloadTypeRAKPMessage2   PayloadType = 0x13
 	PayloadTypeRAKPMessage3   PayloadType = 0x14
 	PayloadTypeRAKPMessage4   PayloadType = 0x15
+
+	// "OEM" payload types, whose meaning is defined by the BMC's
+	// manufacturer, and can be discovered with Get Channel OEM Payload Info
+
+	PayloadTypeOEM0 PayloadType = 0x20
+	PayloadTypeOEM1 PayloadType = 0x21
+	PayloadTypeOEM2 PayloadType = 0x22
+	PayloadTypeOEM3 PayloadType = 0x23
+	PayloadTypeOEM4 PayloadType = 0x24
+	PayloadTypeOEM5 PayloadType = 0x25
+	PayloadTypeOEM6 PayloadType = 0x26
+	PayloadTypeOEM7 PayloadType = 0x27
 )
 
+// IsOEM returns whether the payload's format is defined by a manufacturer
+// rather than the specification, i.e. whether it is OEM explicit, or one of
+// PayloadTypeOEM0 through PayloadTypeOEM7.
+func (p PayloadType) IsOEM() bool {
+	return p == PayloadTypeOEM || (p >= PayloadTypeOEM0 && p <= PayloadTypeOEM7)
+}
+
 func (p PayloadType) String() string {
 	switch p {
 	case PayloadTypeIPMI:
 		return "IPMI"
+	case PayloadTypeSOL:
+		return "SOL"
 	case PayloadTypeOEM:
 		return "OEM Explicit"
 	case PayloadTypeOpenSessionReq:
@@ -42,7 +71,10 @@ func (p PayloadType) String() string {
 		return "RAKP Message 3"
 	case PayloadTypeRAKPMessage4:
 		return "RAKP Message 4"
+	case PayloadTypeOEM0, PayloadTypeOEM1, PayloadTypeOEM2, PayloadTypeOEM3,
+		PayloadTypeOEM4, PayloadTypeOEM5, PayloadTypeOEM6, PayloadTypeOEM7:
+		return fmt.Sprintf("OEM%v", uint8(p-PayloadTypeOEM0))
 	default:
-		return "Unknown" // possibly OEM (0x20 through 0x27)
+		return "Unknown"
 	}
 }
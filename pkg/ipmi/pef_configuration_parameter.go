@@ -0,0 +1,131 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PEFConfigurationParameter identifies a Platform Event Filtering parameter,
+// retrieved and set via the Get and Set PEF Configuration Parameters commands.
+// Values are specified in table 15-4 and 30-6 of IPMI v1.5 and v2.0
+// respectively. It is a 7-bit uint on the wire.
+type PEFConfigurationParameter uint8
+
+const (
+	PEFConfigurationParameterSetInProgress         PEFConfigurationParameter = 0
+	PEFConfigurationParameterControl               PEFConfigurationParameter = 1
+	PEFConfigurationParameterActionGlobalControl   PEFConfigurationParameter = 2
+	PEFConfigurationParameterStartupDelay          PEFConfigurationParameter = 3
+	PEFConfigurationParameterAlertStartupDelay     PEFConfigurationParameter = 4
+	PEFConfigurationParameterEventFilters          PEFConfigurationParameter = 5
+	PEFConfigurationParameterEventFilterTable      PEFConfigurationParameter = 6
+	PEFConfigurationParameterEventFilterTableData1 PEFConfigurationParameter = 7
+	PEFConfigurationParameterAlertPolicies         PEFConfigurationParameter = 8
+	PEFConfigurationParameterAlertPolicyTable      PEFConfigurationParameter = 9
+	PEFConfigurationParameterSystemGUID            PEFConfigurationParameter = 10
+	PEFConfigurationParameterAlertStrings          PEFConfigurationParameter = 11
+	PEFConfigurationParameterAlertStringKeys       PEFConfigurationParameter = 12
+	PEFConfigurationParameterAlertStringsTable     PEFConfigurationParameter = 13
+)
+
+func (p PEFConfigurationParameter) Description() string {
+	switch p {
+	case PEFConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case PEFConfigurationParameterControl:
+		return "PEF Control"
+	case PEFConfigurationParameterActionGlobalControl:
+		return "PEF Action Global Control"
+	case PEFConfigurationParameterStartupDelay:
+		return "PEF Startup Delay"
+	case PEFConfigurationParameterAlertStartupDelay:
+		return "PEF Alert Startup Delay"
+	case PEFConfigurationParameterEventFilters:
+		return "Number of Event Filters"
+	case PEFConfigurationParameterEventFilterTable:
+		return "Event Filter Table"
+	case PEFConfigurationParameterEventFilterTableData1:
+		return "Event Filter Table Data 1"
+	case PEFConfigurationParameterAlertPolicies:
+		return "Number of Alert Policy Entries"
+	case PEFConfigurationParameterAlertPolicyTable:
+		return "Alert Policy Table"
+	case PEFConfigurationParameterSystemGUID:
+		return "System GUID"
+	case PEFConfigurationParameterAlertStrings:
+		return "Number of Alert Strings"
+	case PEFConfigurationParameterAlertStringKeys:
+		return "Alert String Keys"
+	case PEFConfigurationParameterAlertStringsTable:
+		return "Alert Strings"
+	}
+	if p >= 96 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (p PEFConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
+
+// PEFControl is the PEF Control configuration parameter, a bitfield
+// controlling whether the BMC filters events at all.
+type PEFControl uint8
+
+const (
+	// PEFControlEnable enables Platform Event Filtering.
+	PEFControlEnable PEFControl = 1 << iota
+
+	// PEFControlEventMessages causes the BMC to log an event for each PEF
+	// action it takes.
+	PEFControlEventMessages
+
+	// PEFControlStartupDelay delays filtering after the system powers on or
+	// resets, by the PEF Startup Delay parameter.
+	PEFControlStartupDelay
+
+	// PEFControlAlertStartupDelay delays alerts after the system powers on
+	// or resets, by the PEF Alert Startup Delay parameter.
+	PEFControlAlertStartupDelay
+)
+
+func (c PEFControl) String() string {
+	return flagsString(uint8(c), []string{"Enable", "Event Messages",
+		"Startup Delay", "Alert Startup Delay"})
+}
+
+// PEFAction is the PEF Action Global Control configuration parameter, a
+// bitfield of the actions event filters are allowed to take. It is also used
+// in event filter entries.
+type PEFAction uint8
+
+const (
+	PEFActionAlert PEFAction = 1 << iota
+	PEFActionPowerDown
+	PEFActionReset
+	PEFActionPowerCycle
+	PEFActionOEM
+	PEFActionDiagnosticInterrupt
+)
+
+func (a PEFAction) String() string {
+	return flagsString(uint8(a), []string{"Alert", "Power Down", "Reset",
+		"Power Cycle", "OEM", "Diagnostic Interrupt"})
+}
+
+// flagsString renders a bitfield as the names of its set bits, from the least
+// significant, followed by any set bits without a name.
+func flagsString(value uint8, names []string) string {
+	set := []string{}
+	for i, name := range names {
+		if value&(1<<i) != 0 {
+			set = append(set, name)
+			value &^= 1 << i
+		}
+	}
+	if value != 0 {
+		set = append(set, fmt.Sprintf("%#x", value))
+	}
+	return fmt.Sprintf("[%v]", strings.Join(set, ", "))
+}
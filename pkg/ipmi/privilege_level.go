@@ -33,6 +33,11 @@ const (
 	PrivilegeLevelOperator
 	PrivilegeLevelAdministrator
 	PrivilegeLevelOEM
+
+	// PrivilegeLevelNoAccess is the privilege level limit of a user who may
+	// not use a channel at all. It is only valid in Get and Set User Access,
+	// as it cannot be requested for a session.
+	PrivilegeLevelNoAccess PrivilegeLevel = 0xf
 )
 
 func (p PrivilegeLevel) String() string {
@@ -49,6 +54,8 @@ func (p PrivilegeLevel) String() string {
 		return "Administrator"
 	case PrivilegeLevelOEM:
 		return "OEM"
+	case PrivilegeLevelNoAccess:
+		return "No Access"
 	default:
 		return "Unknown"
 	}
@@ -42,6 +42,29 @@ func (r RateUnit) Duration() time.Duration {
 	return 0
 }
 
+// Name returns the rate as a suffix for a unit, e.g. "per second", or an
+// empty string if there is no rate.
+func (r RateUnit) Name() string {
+	switch r {
+	case RateUnitNone:
+		return ""
+	case RateUnitPerMicrosecond:
+		return "per microsecond"
+	case RateUnitPerMillisecond:
+		return "per millisecond"
+	case RateUnitPerSecond:
+		return "per second"
+	case RateUnitPerMinute:
+		return "per minute"
+	case RateUnitPerHour:
+		return "per hour"
+	case RateUnitPerDay:
+		return "per day"
+	default:
+		return "per unknown"
+	}
+}
+
 func (r RateUnit) String() string {
 	formatted := "None"
 	if duration := r.Duration(); duration > 0 {
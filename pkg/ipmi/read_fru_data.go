@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ReadFRUDataReq implements the Read FRU Data command, specified in 28.2 and
+// 34.2 of IPMI v1.5 and v2.0 respectively. FRU Inventory Devices are typically
+// small EEPROMs; the data within them is decoded as FRU information areas,
+// e.g. FRUBoardInfoArea.
+type ReadFRUDataReq struct {
+	layers.BaseLayer
+
+	// DeviceID identifies the FRU Inventory Device to read from. 0 is the
+	// device containing the FRU information of the BMC itself.
+	DeviceID uint8
+
+	// Offset is the offset to read from, in bytes, or words if the device is
+	// word-accessed.
+	Offset uint16
+
+	// Count is the number of bytes (or words) to read. The BMC returns a
+	// completion code of 0xca if this exceeds what it can fit in a response;
+	// ~32 bytes is a safe value.
+	Count uint8
+}
+
+func (*ReadFRUDataReq) LayerType() gopacket.LayerType {
+	return LayerTypeReadFRUDataReq
+}
+
+func (r *ReadFRUDataReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.DeviceID
+	binary.LittleEndian.PutUint16(bytes[1:3], r.Offset)
+	bytes[3] = r.Count
+	return nil
+}
+
+// ReadFRUDataRsp contains data read from a FRU Inventory Device.
+type ReadFRUDataRsp struct {
+	layers.BaseLayer
+
+	// Count is the number of bytes (or words) returned. This may be fewer
+	// than requested, e.g. if the end of the device was reached.
+	Count uint8
+
+	// Data contains the bytes read. It aliases the packet, so must be copied
+	// if it is to be retained beyond the next command.
+	Data []byte
+}
+
+func (*ReadFRUDataRsp) LayerType() gopacket.LayerType {
+	return LayerTypeReadFRUDataRsp
+}
+
+func (r *ReadFRUDataRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ReadFRUDataRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ReadFRUDataRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Read FRU Data response must be at least 1 byte, "+
+			"got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data
+	r.BaseLayer.Payload = nil
+	r.Count = data[0]
+	r.Data = data[1:]
+	return nil
+}
+
+type ReadFRUDataCmd struct {
+	Req ReadFRUDataReq
+	Rsp ReadFRUDataRsp
+}
+
+// Name returns "Read FRU Data".
+func (*ReadFRUDataCmd) Name() string {
+	return "Read FRU Data"
+}
+
+// Operation returns &OperationReadFRUDataReq.
+func (*ReadFRUDataCmd) Operation() *Operation {
+	return &OperationReadFRUDataReq
+}
+
+func (c *ReadFRUDataCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ReadFRUDataCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
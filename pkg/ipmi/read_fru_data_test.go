@@ -0,0 +1,89 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestReadFRUDataReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *ReadFRUDataReq
+		want  []byte
+	}{
+		{
+			&ReadFRUDataReq{
+				Count: 8,
+			},
+			[]byte{0x00, 0x00, 0x00, 0x08},
+		},
+		{
+			&ReadFRUDataReq{
+				DeviceID: 2,
+				Offset:   0x0120,
+				Count:    32,
+			},
+			[]byte{0x02, 0x20, 0x01, 0x20},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestReadFRUDataRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *ReadFRUDataRsp
+	}{
+		{
+			[]byte{},
+			nil,
+		},
+		{
+			[]byte{0x00},
+			&ReadFRUDataRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00},
+				},
+				Data: []byte{},
+			},
+		},
+		{
+			[]byte{0x03, 0x01, 0x02, 0x03},
+			&ReadFRUDataRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x03, 0x01, 0x02, 0x03},
+				},
+				Count: 3,
+				Data:  []byte{0x01, 0x02, 0x03},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &ReadFRUDataRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// RearmSensorEventsReq implements the Re-arm Sensor Events command, specified
+// in 35.12 of IPMI v2.0. It clears a sensor's event status, so a state that is
+// still asserted generates a new event. Sensors that do not auto re-arm, e.g.
+// many chassis intrusion sensors, latch their state until this is sent. All of
+// the sensor's event status is re-armed.
+type RearmSensorEventsReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor to re-arm.
+	Number uint8
+}
+
+func (*RearmSensorEventsReq) LayerType() gopacket.LayerType {
+	return LayerTypeRearmSensorEventsReq
+}
+
+func (r *RearmSensorEventsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	bytes[1] = 0 // re-arm all event status
+	return nil
+}
+
+type RearmSensorEventsCmd struct {
+	Req RearmSensorEventsReq
+}
+
+// Name returns "Re-arm Sensor Events".
+func (*RearmSensorEventsCmd) Name() string {
+	return "Re-arm Sensor Events"
+}
+
+// Operation returns OperationRearmSensorEventsReq.
+func (*RearmSensorEventsCmd) Operation() *Operation {
+	return &OperationRearmSensorEventsReq
+}
+
+func (c *RearmSensorEventsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*RearmSensorEventsCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,32 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestRearmSensorEventsReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *RearmSensorEventsReq
+		want  []byte
+	}{
+		{
+			&RearmSensorEventsReq{
+				Number: 0x73,
+			},
+			[]byte{0x73, 0x00},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -28,7 +28,8 @@ const (
 
 var (
 	recordTypeLayerTypes = map[RecordType]gopacket.LayerType{
-		RecordTypeFullSensor: LayerTypeFullSensorRecord,
+		RecordTypeFullSensor:       LayerTypeFullSensorRecord,
+		RecordTypeFRUDeviceLocator: LayerTypeFRUDeviceLocatorRecord,
 	}
 	recordTypeDescriptions = map[RecordType]string{
 		RecordTypeFullSensor:                        "Full Sensor Record",
@@ -0,0 +1,70 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ReserveSELRsp represents the response to a Reserve SEL command, specified in
+// section 25.4 and 31.4 of IPMI v1.5 and v2.0 respectively. A reservation is
+// required before the SEL can be cleared, or records partially read or deleted.
+// The semantics of the returned token are the same as for SDR Repository
+// reservations, however the two are independent.
+type ReserveSELRsp struct {
+	layers.BaseLayer
+
+	// ReservationID is the token to include in subsequent commands requiring a
+	// reservation. It is cancelled by the next Reserve SEL command from any
+	// party, or by the SEL being cleared or a record being deleted.
+	ReservationID ReservationID
+}
+
+func (*ReserveSELRsp) LayerType() gopacket.LayerType {
+	return LayerTypeReserveSELRsp
+}
+
+func (r *ReserveSELRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ReserveSELRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ReserveSELRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+
+	r.ReservationID = ReservationID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type ReserveSELCmd struct {
+	Rsp ReserveSELRsp
+}
+
+// Name returns "Reserve SEL".
+func (*ReserveSELCmd) Name() string {
+	return "Reserve SEL"
+}
+
+// Operation returns OperationReserveSELReq.
+func (*ReserveSELCmd) Operation() *Operation {
+	return &OperationReserveSELReq
+}
+
+func (*ReserveSELCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *ReserveSELCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,122 @@
+package ipmi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ResponseLength bounds the length of the data of a response to an operation,
+// i.e. the bytes following the completion code. Declaring this for each
+// operation allows sessions to reject truncated responses consistently before
+// they reach a decoder.
+type ResponseLength struct {
+
+	// Min is the length of the shortest response with a normal completion code
+	// the decoder accepts. Shorter responses are rejected without being
+	// decoded.
+	Min int
+
+	// Max is the length of the longest response the specification permits, or
+	// 0 if the response is variable-length. Longer responses are still
+	// decoded, as some BMCs pad them, but may be counted by the caller.
+	Max int
+}
+
+// ResponseLengthError is returned when the data of a response is shorter than
+// the minimum length declared for its operation.
+type ResponseLengthError struct {
+
+	// Length is the length of the data received.
+	Length int
+
+	// Min is the minimum length declared for the operation.
+	Min int
+}
+
+func (e *ResponseLengthError) Error() string {
+	return fmt.Sprintf("response must be at least %v bytes, got %v", e.Min,
+		e.Length)
+}
+
+// Validate returns a *ResponseLengthError if data is shorter than l.Min.
+func (l ResponseLength) Validate(data []byte) error {
+	if len(data) < l.Min {
+		return &ResponseLengthError{
+			Length: len(data),
+			Min:    l.Min,
+		}
+	}
+	return nil
+}
+
+// Exceeded returns whether data is longer than l.Max, if it is set.
+func (l ResponseLength) Exceeded(data []byte) bool {
+	return l.Max != 0 && len(data) > l.Max
+}
+
+var (
+	// responseLengths contains the response length bounds of each request
+	// operation. Minimums match the checks in the corresponding decoders,
+	// which are retained so layers can be decoded outside a session.
+	responseLengths = map[Operation]ResponseLength{
+		OperationGetChassisCapabilitiesReq:               {Min: 5, Max: 6},
+		OperationGetChassisStatusReq:                     {Min: 3, Max: 4},
+		OperationGetPOHCounterReq:                        {Min: 5, Max: 5},
+		OperationGetSystemBootOptionsReq:                 {Min: 2},
+		OperationSetPowerRestorePolicyReq:                {Min: 1, Max: 1},
+		OperationGetDeviceIDReq:                          {Min: 11, Max: 15},
+		OperationGetSystemGUIDReq:                        {Min: 16, Max: 16},
+		OperationGetWatchdogTimerReq:                     {Min: 8, Max: 8},
+		OperationGetChannelAuthenticationCapabilitiesReq: {Min: 8, Max: 8},
+		OperationGetSessionInfoReq:                       {Min: 3},
+		OperationGetChannelOEMPayloadInfoReq:             {Min: 6, Max: 6},
+		OperationGetUserAccessReq:                        {Min: 4, Max: 4},
+		OperationGetUserNameReq:                          {Min: 16, Max: 16},
+		OperationGetSystemInfoParametersReq:              {Min: 1},
+		OperationGetSensorReadingReq:                     {Min: 3, Max: 4},
+		OperationGetSensorReadingFactorsReq:              {Min: 7, Max: 7},
+		OperationGetPEFConfigurationParametersReq:        {Min: 1},
+		OperationGetFRUInventoryAreaInfoReq:              {Min: 3, Max: 3},
+		OperationReadFRUDataReq:                          {Min: 1},
+		OperationGetSDRRepositoryInfoReq:                 {Min: 14, Max: 14},
+		OperationGetSDRRepositoryAllocationInfoReq:       {Min: 9, Max: 9},
+		OperationReserveSDRRepositoryReq:                 {Min: 2, Max: 2},
+		OperationGetSDRReq:                               {Min: 2},
+		OperationAddSDRReq:                               {Min: 2, Max: 2},
+		OperationPartialAddSDRReq:                        {Min: 2, Max: 2},
+		OperationDeleteSDRReq:                            {Min: 2, Max: 2},
+		OperationClearSDRRepositoryReq:                   {Min: 1, Max: 1},
+		OperationRunInitializationAgentReq:               {Min: 1, Max: 1},
+		OperationGetSELInfoReq:                           {Min: 14, Max: 14},
+		OperationReserveSELReq:                           {Min: 2, Max: 2},
+		OperationGetSELEntryReq:                          {Min: 2},
+		OperationClearSELReq:                             {Min: 1, Max: 1},
+		OperationGetSELTimeReq:                           {Min: 4, Max: 4},
+		OperationGetSELTimeUTCOffsetReq:                  {Min: 2, Max: 2},
+		OperationGetLANConfigurationParametersReq:        {Min: 1},
+		OperationGetIPUDPRMCPStatisticsReq:               {Min: 18, Max: 18},
+		OperationSuspendBMCARPsReq:                       {Min: 1, Max: 1},
+		OperationGetSOLConfigurationParametersReq:        {Min: 1},
+	}
+	responseLengthsMu sync.RWMutex
+)
+
+// RegisterResponseLength sets the response length bounds of a request
+// operation, replacing any existing bounds. As with RegisterPrivilegeLevel(),
+// it is intended for packages implementing commands outside this one, and
+// would normally be called from an init function.
+func RegisterResponseLength(op Operation, length ResponseLength) {
+	responseLengthsMu.Lock()
+	defer responseLengthsMu.Unlock()
+	responseLengths[op] = length
+}
+
+// ResponseLength returns the length bounds of the response to the request
+// operation. The second return value is false if none have been declared, in
+// which case the decoder is solely responsible for checking the length.
+func (o Operation) ResponseLength() (ResponseLength, bool) {
+	responseLengthsMu.RLock()
+	defer responseLengthsMu.RUnlock()
+	length, ok := responseLengths[o]
+	return length, ok
+}
@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestResponseLengthValidate(t *testing.T) {
+	length := ResponseLength{Min: 3, Max: 4}
+	tests := []struct {
+		data     []byte
+		valid    bool
+		exceeded bool
+	}{
+		{[]byte{}, false, false},
+		{[]byte{1, 2}, false, false},
+		{[]byte{1, 2, 3}, true, false},
+		{[]byte{1, 2, 3, 4}, true, false},
+		{[]byte{1, 2, 3, 4, 5}, true, true},
+	}
+	for _, test := range tests {
+		err := length.Validate(test.data)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("Validate(%v) = %v, want valid %v", test.data, err,
+				test.valid)
+		}
+		lengthErr := (*ResponseLengthError)(nil)
+		if err != nil && !errors.As(err, &lengthErr) {
+			t.Errorf("Validate(%v) = %v, want *ResponseLengthError",
+				test.data, err)
+		}
+		if got := length.Exceeded(test.data); got != test.exceeded {
+			t.Errorf("Exceeded(%v) = %v, want %v", test.data, got,
+				test.exceeded)
+		}
+	}
+}
+
+// TestResponseLengthsMatchDecoders ensures every command in this package with
+// a response declares its length, and that the declared bounds agree with the
+// decoder: it must reject one byte short of Min, and accept Min and Max bytes.
+func TestResponseLengthsMatchDecoders(t *testing.T) {
+	commands := []Command{
+		&AddSDRCmd{},
+		&ClearSDRRepositoryCmd{},
+		&ClearSELCmd{},
+		&DeleteSDRCmd{},
+		&GetChannelAuthenticationCapabilitiesCmd{},
+		&GetChannelOEMPayloadInfoCmd{},
+		&GetChassisCapabilitiesCmd{},
+		&GetChassisStatusCmd{},
+		&GetDeviceIDCmd{},
+		&GetFRUInventoryAreaInfoCmd{},
+		&GetIPUDPRMCPStatisticsCmd{},
+		&GetLANConfigurationParametersCmd{},
+		&GetPEFConfigurationParametersCmd{},
+		&GetPOHCounterCmd{},
+		&GetSDRCmd{},
+		&GetSDRRepositoryAllocationInfoCmd{},
+		&GetSDRRepositoryInfoCmd{},
+		&GetSELEntryCmd{},
+		&GetSELInfoCmd{},
+		&GetSELTimeCmd{},
+		&GetSELTimeUTCOffsetCmd{},
+		&GetSensorReadingCmd{},
+		&GetSensorReadingFactorsCmd{},
+		&GetSessionInfoCmd{},
+		&GetSOLConfigurationParametersCmd{},
+		&GetSystemBootOptionsCmd{},
+		&GetSystemGUIDCmd{},
+		&GetSystemInfoParametersCmd{},
+		&GetUserAccessCmd{},
+		&GetUserNameCmd{},
+		&GetWatchdogTimerCmd{},
+		&PartialAddSDRCmd{},
+		&ReadFRUDataCmd{},
+		&ReserveSDRRepositoryCmd{},
+		&ReserveSELCmd{},
+		&RunInitializationAgentCmd{},
+		&SetPowerRestorePolicyCmd{},
+		&SuspendBMCARPsCmd{},
+	}
+	for _, cmd := range commands {
+		length, ok := cmd.Operation().ResponseLength()
+		if !ok {
+			t.Errorf("%v has no response length", cmd.Name())
+			continue
+		}
+		decode := func(n int) error {
+			return cmd.Response().DecodeFromBytes(make([]byte, n),
+				gopacket.NilDecodeFeedback)
+		}
+		if err := decode(length.Min - 1); err == nil {
+			t.Errorf("%v decoded %v bytes, but Min is %v", cmd.Name(),
+				length.Min-1, length.Min)
+		}
+		if err := decode(length.Min); err != nil {
+			t.Errorf("%v failed to decode Min (%v) bytes: %v", cmd.Name(),
+				length.Min, err)
+		}
+		if length.Max != 0 {
+			if err := decode(length.Max); err != nil {
+				t.Errorf("%v failed to decode Max (%v) bytes: %v",
+					cmd.Name(), length.Max, err)
+			}
+		}
+	}
+}
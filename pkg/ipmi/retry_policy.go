@@ -0,0 +1,61 @@
+package ipmi
+
+import (
+	"sync"
+)
+
+// RetryPolicy overrides which completion codes are considered temporary for
+// an operation. Codes mapped to true are retried, codes mapped to false are
+// returned immediately, and codes not in the map fall back to
+// CompletionCode.IsTemporary().
+type RetryPolicy map[CompletionCode]bool
+
+var (
+	// retryPolicyNonIdempotent applies to commands with side effects that
+	// must not be repeated. A timeout (0xc3) means the BMC may have acted on
+	// the request without responding, so retrying could e.g. power cycle a
+	// machine twice. Node busy (0xc0) is still retried, as the request was
+	// rejected before being acted on.
+	retryPolicyNonIdempotent = RetryPolicy{
+		CompletionCodeTimeout: false,
+	}
+
+	// retryPolicies contains the policy of each request operation that
+	// differs from the default.
+	retryPolicies = map[Operation]RetryPolicy{
+		OperationChassisControlReq:         retryPolicyNonIdempotent,
+		OperationClearSELReq:               retryPolicyNonIdempotent,
+		OperationAddSDRReq:                 retryPolicyNonIdempotent,
+		OperationPartialAddSDRReq:          retryPolicyNonIdempotent,
+		OperationDeleteSDRReq:              retryPolicyNonIdempotent,
+		OperationClearSDRRepositoryReq:     retryPolicyNonIdempotent,
+		OperationRunInitializationAgentReq: retryPolicyNonIdempotent,
+	}
+	retryPoliciesMu sync.RWMutex
+)
+
+// RegisterRetryPolicy sets the retry policy of a request operation, replacing
+// any existing policy. It allows packages implementing commands outside this
+// one, e.g. group extensions, to declare how their completion codes should be
+// handled. It is safe to call concurrently, but would normally be called from
+// an init function.
+func RegisterRetryPolicy(op Operation, policy RetryPolicy) {
+	retryPoliciesMu.Lock()
+	defer retryPoliciesMu.Unlock()
+	retryPolicies[op] = policy
+}
+
+// IsTemporaryFor returns whether a response with the code to a request with
+// the provided operation may succeed if the request is retried. Unless
+// overridden by a RetryPolicy, this is the same as IsTemporary(), so node busy
+// (0xc0) and timeout (0xc3) are retried, while not present (0xcb) and
+// insufficient privileges (0xd4) are permanent.
+func (c CompletionCode) IsTemporaryFor(op *Operation) bool {
+	retryPoliciesMu.RLock()
+	temporary, ok := retryPolicies[*op][c]
+	retryPoliciesMu.RUnlock()
+	if ok {
+		return temporary
+	}
+	return c.IsTemporary()
+}
@@ -0,0 +1,60 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestCompletionCodeIsTemporaryFor(t *testing.T) {
+	tests := []struct {
+		code CompletionCode
+		op   *Operation
+		want bool
+	}{
+		{CompletionCodeNodeBusy, &OperationGetDeviceIDReq, true},
+		{CompletionCodeTimeout, &OperationGetDeviceIDReq, true},
+		{CompletionCodeNotPresent, &OperationGetDeviceIDReq, false},
+		{CompletionCodeInsufficientPrivileges, &OperationGetDeviceIDReq, false},
+		{CompletionCodeNormal, &OperationGetDeviceIDReq, false},
+		{CompletionCodeNodeBusy, &OperationChassisControlReq, true},
+		{CompletionCodeTimeout, &OperationChassisControlReq, false},
+		{CompletionCodeTimeout, &OperationClearSELReq, false},
+		{CompletionCodeNotPresent, &OperationClearSELReq, false},
+	}
+	for _, test := range tests {
+		if got := test.code.IsTemporaryFor(test.op); got != test.want {
+			t.Errorf("%v.IsTemporaryFor(%v) = %v, want %v", test.code,
+				test.op, got, test.want)
+		}
+	}
+}
+
+func TestRegisterRetryPolicy(t *testing.T) {
+	op := Operation{
+		Function: NetworkFunctionOEMReq,
+		Command:  0xfe,
+	}
+	RegisterRetryPolicy(op, RetryPolicy{
+		CompletionCodeNotPresent: true,
+		CompletionCodeNodeBusy:   false,
+	})
+	defer func() {
+		retryPoliciesMu.Lock()
+		delete(retryPolicies, op)
+		retryPoliciesMu.Unlock()
+	}()
+
+	tests := []struct {
+		code CompletionCode
+		want bool
+	}{
+		{CompletionCodeNotPresent, true},
+		{CompletionCodeNodeBusy, false},
+		{CompletionCodeTimeout, true},
+	}
+	for _, test := range tests {
+		if got := test.code.IsTemporaryFor(&op); got != test.want {
+			t.Errorf("%v.IsTemporaryFor(%v) = %v, want %v", test.code, op,
+				got, test.want)
+		}
+	}
+}
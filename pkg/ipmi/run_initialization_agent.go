@@ -0,0 +1,94 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// RunInitializationAgentReq represents a Run Initialization Agent command,
+// specified in section 27.17 and 33.17 of IPMI v1.5 and v2.0 respectively. The
+// initialization agent configures sensors according to the SDR Repository;
+// this is useful after the repository has been modified, to apply the changes
+// without resetting the BMC.
+type RunInitializationAgentReq struct {
+	layers.BaseLayer
+
+	// Run indicates whether to run the agent. If false, the request only
+	// retrieves the status of a previous run.
+	Run bool
+}
+
+func (*RunInitializationAgentReq) LayerType() gopacket.LayerType {
+	return LayerTypeRunInitializationAgentReq
+}
+
+func (r *RunInitializationAgentReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = 0
+	if r.Run {
+		bytes[0] = 1
+	}
+	return nil
+}
+
+// RunInitializationAgentRsp represents the response to a Run Initialization
+// Agent request.
+type RunInitializationAgentRsp struct {
+	layers.BaseLayer
+
+	// Completed indicates whether initialization has finished.
+	Completed bool
+}
+
+func (*RunInitializationAgentRsp) LayerType() gopacket.LayerType {
+	return LayerTypeRunInitializationAgentRsp
+}
+
+func (r *RunInitializationAgentRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*RunInitializationAgentRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *RunInitializationAgentRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 1 byte, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+
+	r.Completed = data[0]&1 != 0
+	return nil
+}
+
+type RunInitializationAgentCmd struct {
+	Req RunInitializationAgentReq
+	Rsp RunInitializationAgentRsp
+}
+
+// Name returns "Run Initialization Agent".
+func (*RunInitializationAgentCmd) Name() string {
+	return "Run Initialization Agent"
+}
+
+// Operation returns OperationRunInitializationAgentReq.
+func (*RunInitializationAgentCmd) Operation() *Operation {
+	return &OperationRunInitializationAgentReq
+}
+
+func (c *RunInitializationAgentCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *RunInitializationAgentCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,142 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/iana"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// SELRecordTypeSystemEvent is the record type of standard event records.
+	SELRecordTypeSystemEvent uint8 = 0x02
+
+	// selRecordTypeOEMTimestampedMin is the first OEM record type with a
+	// timestamp. Types from 0xe0 are OEM records without one.
+	selRecordTypeOEMTimestampedMin uint8 = 0xc0
+	selRecordTypeOEMMin            uint8 = 0xe0
+)
+
+// SELEventRecord is a 16-byte record in the System Event Log, specified in
+// 31.6.1 and 32 of IPMI v2.0. Only system event records (type 0x02) have the
+// event fields populated; for OEM records, the remaining bytes are left in
+// OEMData.
+type SELEventRecord struct {
+	layers.BaseLayer
+
+	// ID is the record's ID in the SEL.
+	ID RecordID
+
+	// Type is the record type. SELRecordTypeSystemEvent is the only standard
+	// type; 0xc0-0xdf are timestamped OEM records, and 0xe0-0xff are
+	// non-timestamped OEM records.
+	Type uint8
+
+	// Timestamp is when the record was added to the SEL. It is the zero value
+	// if the record type has no timestamp, or the BMC did not know the time.
+	// Timestamps before 0x20000000 seconds (early 1987) are relative to BMC
+	// initialisation rather than the epoch.
+	Timestamp time.Time
+
+	// GeneratorID identifies the software or IPMB device that generated the
+	// event: the low byte is the slave address or software ID, and the high
+	// byte contains the channel and LUN.
+	GeneratorID uint16
+
+	// EvMRev is the event message format version: 0x04 for IPMI v2.0 and
+	// 0x03 for v1.0.
+	EvMRev uint8
+
+	// SensorType is the type of sensor that generated the event.
+	SensorType SensorType
+
+	// SensorNumber identifies the sensor within the generator.
+	SensorNumber uint8
+
+	// Deassertion indicates the event is the sensor leaving a state rather
+	// than entering it.
+	Deassertion bool
+
+	// EventType is the event/reading type code, indicating how EventData is
+	// to be interpreted, e.g. 0x01 for threshold events, or 0x6f for
+	// sensor-specific events.
+	EventType uint8
+
+	// EventData contains the event data. The low nibble of the first byte is
+	// the offset of the state that triggered the event.
+	EventData [3]byte
+
+	// Manufacturer is the enterprise number of the manufacturer that defined
+	// a timestamped OEM record. It is also the first 3 bytes of OEMData. It is
+	// 0 for other record types.
+	Manufacturer iana.Enterprise
+
+	// OEMData contains the bytes after the timestamp of a timestamped OEM
+	// record, or after the type of a non-timestamped one.
+	OEMData []byte
+}
+
+func (*SELEventRecord) LayerType() gopacket.LayerType {
+	return LayerTypeSELEventRecord
+}
+
+func (r *SELEventRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*SELEventRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *SELEventRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 16 {
+		df.SetTruncated()
+		return fmt.Errorf("SEL records must be 16 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:16]
+	r.BaseLayer.Payload = data[16:]
+	r.ID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	r.Type = data[2]
+	r.Timestamp = time.Time{}
+	if r.Type < selRecordTypeOEMMin {
+		r.Timestamp = Timestamp(binary.LittleEndian.Uint32(data[3:7])).Time(0)
+	}
+
+	r.GeneratorID = 0
+	r.EvMRev = 0
+	r.SensorType = 0
+	r.SensorNumber = 0
+	r.Deassertion = false
+	r.EventType = 0
+	r.EventData = [3]byte{}
+	r.Manufacturer = 0
+	r.OEMData = nil
+	switch {
+	case r.Type >= selRecordTypeOEMMin:
+		r.OEMData = data[3:16]
+	case r.Type >= selRecordTypeOEMTimestampedMin:
+		r.Manufacturer = iana.Enterprise(uint32(data[7]) |
+			uint32(data[8])<<8 | uint32(data[9])<<16)
+		r.OEMData = data[7:16]
+	default:
+		r.GeneratorID = binary.LittleEndian.Uint16(data[7:9])
+		r.EvMRev = data[9]
+		r.SensorType = SensorType(data[10])
+		r.SensorNumber = data[11]
+		r.Deassertion = data[12]&(1<<7) != 0
+		r.EventType = data[12] & 0x7f
+		copy(r.EventData[:], data[13:16])
+	}
+	return nil
+}
+
+// IsSystemEvent returns whether the record is a standard system event record,
+// as opposed to an OEM record.
+func (r *SELEventRecord) IsSystemEvent() bool {
+	return r.Type == SELRecordTypeSystemEvent
+}
@@ -0,0 +1,142 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestSELEventRecordDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *SELEventRecord
+	}{
+		// too short
+		{
+			make([]byte, 15),
+			nil,
+		},
+		{
+			[]byte{
+				0x2a, 0x00,
+				0x02,
+				0x73, 0xb6, 0x44, 0x5d,
+				0x20, 0x00,
+				0x04,
+				0x07,
+				0x11,
+				0x6f,
+				0xa1, 0x02, 0xff,
+			},
+			&SELEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x2a, 0x00, 0x02, 0x73, 0xb6, 0x44,
+						0x5d, 0x20, 0x00, 0x04, 0x07, 0x11, 0x6f, 0xa1, 0x02,
+						0xff},
+					Payload: []byte{},
+				},
+				ID:           42,
+				Type:         SELRecordTypeSystemEvent,
+				Timestamp:    time.Unix(1564784243, 0),
+				GeneratorID:  0x20,
+				EvMRev:       0x04,
+				SensorType:   SensorTypeProcessor,
+				SensorNumber: 0x11,
+				EventType:    0x6f,
+				EventData:    [3]byte{0xa1, 0x02, 0xff},
+			},
+		},
+		{
+			// deassertion with unspecified timestamp
+			[]byte{
+				0x01, 0x01,
+				0x02,
+				0xff, 0xff, 0xff, 0xff,
+				0x41, 0x00,
+				0x04,
+				0x01,
+				0x30,
+				0x81,
+				0x57, 0x40, 0x50,
+			},
+			&SELEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x01, 0x02, 0xff, 0xff, 0xff,
+						0xff, 0x41, 0x00, 0x04, 0x01, 0x30, 0x81, 0x57, 0x40,
+						0x50},
+					Payload: []byte{},
+				},
+				ID:           257,
+				Type:         SELRecordTypeSystemEvent,
+				GeneratorID:  0x41,
+				EvMRev:       0x04,
+				SensorType:   SensorTypeTemperature,
+				SensorNumber: 0x30,
+				Deassertion:  true,
+				EventType:    0x01,
+				EventData:    [3]byte{0x57, 0x40, 0x50},
+			},
+		},
+		{
+			// timestamped OEM
+			[]byte{
+				0x02, 0x00,
+				0xc1,
+				0x73, 0xb6, 0x44, 0x5d,
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09,
+			},
+			&SELEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x02, 0x00, 0xc1, 0x73, 0xb6, 0x44,
+						0x5d, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+						0x09},
+					Payload: []byte{},
+				},
+				ID:           2,
+				Type:         0xc1,
+				Timestamp:    time.Unix(1564784243, 0),
+				Manufacturer: 0x030201,
+				OEMData: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+					0x08, 0x09},
+			},
+		},
+		{
+			// non-timestamped OEM
+			[]byte{
+				0x03, 0x00,
+				0xe0,
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,
+				0x0b, 0x0c, 0x0d,
+			},
+			&SELEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x03, 0x00, 0xe0, 0x01, 0x02, 0x03,
+						0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c,
+						0x0d},
+					Payload: []byte{},
+				},
+				ID:   3,
+				Type: 0xe0,
+				OEMData: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+					0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d},
+			},
+		},
+	}
+	for _, test := range tests {
+		record := &SELEventRecord{}
+		err := record.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, record); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, record, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
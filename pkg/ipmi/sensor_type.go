@@ -9,7 +9,7 @@ import (
 // temperature (0x01) could be that of a processor (0x07), however the types
 // after 0x04 are seemingly for discrete sensors, and have an additional
 // sub-type in the form of a sensor specific offset. See Table 36-3 and 42-3 in
-// v1.5 and v2.0 respectively.
+// v1.5 and v2.0 respectively. Values from 0xc0 are OEM-defined.
 type SensorType uint8
 
 const (
@@ -27,33 +27,104 @@ const (
 	SensorTypeOtherUnitsBasedSensor
 	SensorTypeMemory
 	SensorTypeDriveBay
-
-	// non-exhaustive
+	SensorTypePOSTMemoryResize
+	SensorTypeSystemFirmwareProgress
+	SensorTypeEventLoggingDisabled
+	SensorTypeWatchdog1
+	SensorTypeSystemEvent
+	SensorTypeCriticalInterrupt
+	SensorTypeButtonSwitch
+	SensorTypeModuleBoard
+	SensorTypeMicrocontrollerCoprocessor
+	SensorTypeAddInCard
+	SensorTypeChassis
+	SensorTypeChipSet
+	SensorTypeOtherFRU
+	SensorTypeCableInterconnect
+	SensorTypeTerminator
+	SensorTypeSystemBootRestartInitiated
+	SensorTypeBootError
+	SensorTypeBaseOSBootInstallationStatus
+	SensorTypeOSStopShutdown
+	SensorTypeSlotConnector
+	SensorTypeSystemACPIPowerState
+	SensorTypeWatchdog2
+	SensorTypePlatformAlert
+	SensorTypeEntityPresence
+	SensorTypeMonitorASIC
+	SensorTypeLAN
+	SensorTypeManagementSubsystemHealth
+	SensorTypeBattery
+	SensorTypeSessionAudit
+	SensorTypeVersionChange
+	SensorTypeFRUState
 )
 
 var (
 	sensorTypeDescriptions = map[SensorType]string{
-		SensorTypeTemperature:           "Temperature",
-		SensorTypeVoltage:               "Voltage",
-		SensorTypeCurrent:               "Current",
-		SensorTypeFan:                   "Fan",
-		SensorTypePhysicalSecurity:      "Physical Security",
-		SensorTypePlatformSecurity:      "Platform Security",
-		SensorTypeProcessor:             "Processor",
-		SensorTypePowerSupply:           "Power Supply",
-		SensorTypePowerUnit:             "Power Unit",
-		SensorTypeCoolingDevice:         "Cooling Device",
-		SensorTypeOtherUnitsBasedSensor: "Other Units-based Sensor",
-		SensorTypeMemory:                "Memory",
-		SensorTypeDriveBay:              "Drive Bay",
+		SensorTypeTemperature:                  "Temperature",
+		SensorTypeVoltage:                      "Voltage",
+		SensorTypeCurrent:                      "Current",
+		SensorTypeFan:                          "Fan",
+		SensorTypePhysicalSecurity:             "Physical Security",
+		SensorTypePlatformSecurity:             "Platform Security",
+		SensorTypeProcessor:                    "Processor",
+		SensorTypePowerSupply:                  "Power Supply",
+		SensorTypePowerUnit:                    "Power Unit",
+		SensorTypeCoolingDevice:                "Cooling Device",
+		SensorTypeOtherUnitsBasedSensor:        "Other Units-based Sensor",
+		SensorTypeMemory:                       "Memory",
+		SensorTypeDriveBay:                     "Drive Bay",
+		SensorTypePOSTMemoryResize:             "POST Memory Resize",
+		SensorTypeSystemFirmwareProgress:       "System Firmware Progress",
+		SensorTypeEventLoggingDisabled:         "Event Logging Disabled",
+		SensorTypeWatchdog1:                    "Watchdog 1",
+		SensorTypeSystemEvent:                  "System Event",
+		SensorTypeCriticalInterrupt:            "Critical Interrupt",
+		SensorTypeButtonSwitch:                 "Button/Switch",
+		SensorTypeModuleBoard:                  "Module/Board",
+		SensorTypeMicrocontrollerCoprocessor:   "Microcontroller/Coprocessor",
+		SensorTypeAddInCard:                    "Add-in Card",
+		SensorTypeChassis:                      "Chassis",
+		SensorTypeChipSet:                      "Chip Set",
+		SensorTypeOtherFRU:                     "Other FRU",
+		SensorTypeCableInterconnect:            "Cable/Interconnect",
+		SensorTypeTerminator:                   "Terminator",
+		SensorTypeSystemBootRestartInitiated:   "System Boot/Restart Initiated",
+		SensorTypeBootError:                    "Boot Error",
+		SensorTypeBaseOSBootInstallationStatus: "Base OS Boot/Installation Status",
+		SensorTypeOSStopShutdown:               "OS Stop/Shutdown",
+		SensorTypeSlotConnector:                "Slot/Connector",
+		SensorTypeSystemACPIPowerState:         "System ACPI Power State",
+		SensorTypeWatchdog2:                    "Watchdog 2",
+		SensorTypePlatformAlert:                "Platform Alert",
+		SensorTypeEntityPresence:               "Entity Presence",
+		SensorTypeMonitorASIC:                  "Monitor ASIC/IC",
+		SensorTypeLAN:                          "LAN",
+		SensorTypeManagementSubsystemHealth:    "Management Subsystem Health",
+		SensorTypeBattery:                      "Battery",
+		SensorTypeSessionAudit:                 "Session Audit",
+		SensorTypeVersionChange:                "Version Change",
+		SensorTypeFRUState:                     "FRU State",
 	}
 )
 
+// IsOEM returns whether the sensor type is in the OEM-reserved range, so its
+// meaning depends on the manufacturer.
+func (t SensorType) IsOEM() bool {
+	return t >= 0xc0
+}
+
+// Description returns the name of the sensor type in the specification, "OEM"
+// if it is OEM-defined, or "Reserved" otherwise.
 func (t SensorType) Description() string {
 	if desc, ok := sensorTypeDescriptions[t]; ok {
 		return desc
 	}
-	return "Unknown"
+	if t.IsOEM() {
+		return "OEM"
+	}
+	return "Reserved"
 }
 
 func (t SensorType) String() string {
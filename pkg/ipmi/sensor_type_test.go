@@ -0,0 +1,26 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestSensorTypeString(t *testing.T) {
+	tests := []struct {
+		sensorType SensorType
+		want       string
+	}{
+		{0, "0x0(Reserved)"},
+		{SensorTypeDriveBay, "0xd(Drive Bay)"},
+		{SensorTypeEventLoggingDisabled, "0x10(Event Logging Disabled)"},
+		{SensorTypeWatchdog2, "0x23(Watchdog 2)"},
+		{SensorTypeFRUState, "0x2c(FRU State)"},
+		{0x2d, "0x2d(Reserved)"},
+		{0xc0, "0xc0(OEM)"},
+	}
+	for _, test := range tests {
+		if got := test.sensorType.String(); got != test.want {
+			t.Errorf("String(%d) = %v, want %v", uint8(test.sensorType), got,
+				test.want)
+		}
+	}
+}
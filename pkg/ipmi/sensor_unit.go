@@ -198,8 +198,132 @@ var (
 		SensorUnitFatal:               "fatal",
 		SensorUnitGrams:               "g",
 	}
+
+	// sensorUnitNames contains the name of each unit, as displayed by
+	// ipmitool.
+	sensorUnitNames = map[SensorUnit]string{
+		SensorUnitCelsius:             "degrees C",
+		SensorUnitFahrenheit:          "degrees F",
+		SensorUnitKelvin:              "degrees K",
+		SensorUnitVolts:               "Volts",
+		SensorUnitAmps:                "Amps",
+		SensorUnitWatts:               "Watts",
+		SensorUnitJoules:              "Joules",
+		SensorUnitCoulombs:            "Coulombs",
+		SensorUnitVoltamperes:         "VA",
+		SensorUnitNits:                "Nits",
+		SensorUnitLumen:               "lumen",
+		SensorUnitLux:                 "lux",
+		SensorUnitCandela:             "Candela",
+		SensorUnitKilopascals:         "kPa",
+		SensorUnitPoundsPerSquareInch: "PSI",
+		SensorUnitNewtons:             "Newton",
+		SensorUnitCubicFeetPerMinute:  "CFM",
+		SensorUnitRotationsPerMinute:  "RPM",
+		SensorUnitHertz:               "Hz",
+		SensorUnitMicroseconds:        "microsecond",
+		SensorUnitMilliseconds:        "millisecond",
+		SensorUnitSeconds:             "second",
+		SensorUnitMinutes:             "minute",
+		SensorUnitHours:               "hour",
+		SensorUnitDays:                "day",
+		SensorUnitWeeks:               "week",
+		SensorUnitMils:                "mil",
+		SensorUnitInches:              "inches",
+		SensorUnitFeet:                "feet",
+		SensorUnitCubicInches:         "cu in",
+		SensorUnitCubicFeet:           "cu feet",
+		SensorUnitMillimeters:         "mm",
+		SensorUnitCentimeters:         "cm",
+		SensorUnitMeters:              "m",
+		SensorUnitCubicCentimeters:    "cu cm",
+		SensorUnitCubicMeters:         "cu m",
+		SensorUnitLiters:              "liters",
+		SensorUnitFluidOunces:         "fluid ounce",
+		SensorUnitRadians:             "radians",
+		SensorUnitSteradians:          "steradians",
+		SensorUnitRevolutions:         "revolutions",
+		SensorUnitCycles:              "cycles",
+		SensorUnitGravities:           "gravities",
+		SensorUnitOunces:              "ounce",
+		SensorUnitPounds:              "pound",
+		SensorUnitFeetPounds:          "ft-lb",
+		SensorUnitOunceInches:         "oz-in",
+		SensorUnitGauss:               "gauss",
+		SensorUnitGilberts:            "gilberts",
+		SensorUnitHenry:               "henry",
+		SensorUnitMillihenry:          "millihenry",
+		SensorUnitFarad:               "farad",
+		SensorUnitMicrofarad:          "microfarad",
+		SensorUnitOhms:                "ohms",
+		SensorUnitSiemens:             "siemens",
+		SensorUnitMoles:               "mole",
+		SensorUnitBecquerel:           "becquerel",
+		SensorUnitPartsPerMillion:     "PPM",
+		SensorUnitDecibels:            "Decibels",
+		SensorUnitDecibelsAFilter:     "DbA",
+		SensorUnitDecibelsCFilter:     "DbC",
+		SensorUnitGray:                "gray",
+		SensorUnitSieverts:            "sievert",
+		SensorUnitColorTempKelvin:     "color temp deg K",
+		SensorUnitBits:                "bit",
+		SensorUnitKilobits:            "kilobit",
+		SensorUnitMegabits:            "megabit",
+		SensorUnitGigabits:            "gigabit",
+		SensorUnitBytes:               "byte",
+		SensorUnitKilobytes:           "kilobyte",
+		SensorUnitMegabytes:           "megabyte",
+		SensorUnitGigabytes:           "gigabyte",
+		SensorUnitWords:               "word",
+		SensorUnitDwords:              "dword",
+		SensorUnitQwords:              "qword",
+		SensorUnitMemoryLines:         "line",
+		SensorUnitHits:                "hit",
+		SensorUnitMisses:              "miss",
+		SensorUnitRetries:             "retry",
+		SensorUnitResets:              "reset",
+		SensorUnitOverflows:           "overflow",
+		SensorUnitUnderruns:           "underrun",
+		SensorUnitCollisions:          "collision",
+		SensorUnitPackets:             "packets",
+		SensorUnitMessages:            "messages",
+		SensorUnitCharacters:          "characters",
+		SensorUnitErrors:              "error",
+		SensorUnitCorrectableErrors:   "correctable error",
+		SensorUnitUncorrectableErrors: "uncorrectable error",
+		SensorUnitFatal:               "fatal error",
+		SensorUnitGrams:               "grams",
+	}
+
+	// canonicalSensorUnits maps units with an SI prefix, and units of time,
+	// to the unprefixed unit or seconds, and the factor to multiply readings
+	// by. The specification does not say whether the byte units are powers
+	// of 1000 or 1024; the former is assumed.
+	canonicalSensorUnits = map[SensorUnit]struct {
+		unit   SensorUnit
+		factor float64
+	}{
+		SensorUnitMicroseconds:     {SensorUnitSeconds, 1e-6},
+		SensorUnitMilliseconds:     {SensorUnitSeconds, 1e-3},
+		SensorUnitMinutes:          {SensorUnitSeconds, 60},
+		SensorUnitHours:            {SensorUnitSeconds, 60 * 60},
+		SensorUnitDays:             {SensorUnitSeconds, 24 * 60 * 60},
+		SensorUnitWeeks:            {SensorUnitSeconds, 7 * 24 * 60 * 60},
+		SensorUnitMillimeters:      {SensorUnitMeters, 1e-3},
+		SensorUnitCentimeters:      {SensorUnitMeters, 1e-2},
+		SensorUnitCubicCentimeters: {SensorUnitCubicMeters, 1e-6},
+		SensorUnitMillihenry:       {SensorUnitHenry, 1e-3},
+		SensorUnitMicrofarad:       {SensorUnitFarad, 1e-6},
+		SensorUnitKilobits:         {SensorUnitBits, 1e3},
+		SensorUnitMegabits:         {SensorUnitBits, 1e6},
+		SensorUnitGigabits:         {SensorUnitBits, 1e9},
+		SensorUnitKilobytes:        {SensorUnitBytes, 1e3},
+		SensorUnitMegabytes:        {SensorUnitBytes, 1e6},
+		SensorUnitGigabytes:        {SensorUnitBytes, 1e9},
+	}
 )
 
+// Symbol returns the abbreviated form of the unit, e.g. "W" for Watts.
 func (s SensorUnit) Symbol() string {
 	if s == 0 {
 		return "Unspecified/Unused"
@@ -213,3 +337,26 @@ func (s SensorUnit) Symbol() string {
 func (s SensorUnit) String() string {
 	return fmt.Sprintf("%#v(%v)", uint8(s), s.Symbol())
 }
+
+// Name returns the unit's name, e.g. "Watts", as displayed by ipmitool.
+func (s SensorUnit) Name() string {
+	if s == 0 {
+		return "unspecified"
+	}
+	if name, ok := sensorUnitNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Canonical returns the equivalent unit without an SI prefix, or seconds for
+// units of time, e.g. bytes for kilobytes, and the factor to multiply readings
+// by to convert them into it. This is useful for exporters whose conventions
+// require base units, such as Prometheus. Other units are returned unchanged,
+// with a factor of 1.
+func (s SensorUnit) Canonical() (SensorUnit, float64) {
+	if canonical, ok := canonicalSensorUnits[s]; ok {
+		return canonical.unit, canonical.factor
+	}
+	return s, 1
+}
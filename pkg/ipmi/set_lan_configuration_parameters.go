@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetLANConfigurationParametersReq implements the Set LAN Configuration
+// Parameters command, specified in 19.1 and 23.1 of IPMI v1.5 and v2.0
+// respectively. It sets a single parameter of a LAN channel, e.g. its IP
+// address. Changes may take effect immediately, so changing the address of the
+// channel in use will likely break the session.
+type SetLANConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Channel is the LAN channel whose parameter to set.
+	Channel Channel
+
+	// Parameter identifies the parameter to set.
+	Parameter LANConfigurationParameter
+
+	// Data is the parameter data, whose format depends on the parameter. It is
+	// serialised after the parameter selector. It may be nil.
+	Data gopacket.SerializableLayer
+}
+
+func (*SetLANConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetLANConfigurationParametersReq
+}
+
+func (r *SetLANConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if r.Data != nil {
+		if err := r.Data.SerializeTo(b, opts); err != nil {
+			return err
+		}
+	}
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	bytes[1] = uint8(r.Parameter)
+	return nil
+}
+
+type SetLANConfigurationParametersCmd struct {
+	Req SetLANConfigurationParametersReq
+}
+
+// Name returns "Set LAN Configuration Parameters".
+func (*SetLANConfigurationParametersCmd) Name() string {
+	return "Set LAN Configuration Parameters"
+}
+
+// Operation returns &OperationSetLANConfigurationParametersReq.
+func (*SetLANConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetLANConfigurationParametersReq
+}
+
+func (c *SetLANConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetLANConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,41 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetLANConfigurationParametersReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *SetLANConfigurationParametersReq
+		want  []byte
+	}{
+		{
+			&SetLANConfigurationParametersReq{
+				Channel:   1,
+				Parameter: LANConfigurationParameterIPAddress,
+				Data:      gopacket.Payload{10, 0, 0, 42},
+			},
+			[]byte{0x01, 0x03, 0x0a, 0x00, 0x00, 0x2a},
+		},
+		{
+			&SetLANConfigurationParametersReq{
+				Channel:   ChannelPresentInterface,
+				Parameter: LANConfigurationParameterSetInProgress,
+			},
+			[]byte{0x0e, 0x00},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetPEFConfigurationParametersReq implements the Set PEF Configuration
+// Parameters command, specified in 15.2 and 30.3 of IPMI v1.5 and v2.0
+// respectively. It sets a single Platform Event Filtering parameter, e.g. the
+// actions filters are allowed to take.
+type SetPEFConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Parameter identifies the parameter to set.
+	Parameter PEFConfigurationParameter
+
+	// Data is the parameter data, whose format depends on the parameter. It is
+	// serialised after the parameter selector. It may be nil.
+	Data gopacket.SerializableLayer
+}
+
+func (*SetPEFConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetPEFConfigurationParametersReq
+}
+
+func (r *SetPEFConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if r.Data != nil {
+		if err := r.Data.SerializeTo(b, opts); err != nil {
+			return err
+		}
+	}
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Parameter) & 0x7f
+	return nil
+}
+
+type SetPEFConfigurationParametersCmd struct {
+	Req SetPEFConfigurationParametersReq
+}
+
+// Name returns "Set PEF Configuration Parameters".
+func (*SetPEFConfigurationParametersCmd) Name() string {
+	return "Set PEF Configuration Parameters"
+}
+
+// Operation returns &OperationSetPEFConfigurationParametersReq.
+func (*SetPEFConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetPEFConfigurationParametersReq
+}
+
+func (c *SetPEFConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetPEFConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,64 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSOLConfigurationParametersReq implements the Set SOL Configuration
+// Parameters command, specified in 26.2 of IPMI v2.0. It sets a single Serial
+// over LAN parameter of a channel, e.g. its bit rate.
+type SetSOLConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel whose parameter to set.
+	Channel Channel
+
+	// Parameter identifies the parameter to set.
+	Parameter SOLConfigurationParameter
+
+	// Data is the parameter data, whose format depends on the parameter. It is
+	// serialised after the parameter selector. It may be nil.
+	Data gopacket.SerializableLayer
+}
+
+func (*SetSOLConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSOLConfigurationParametersReq
+}
+
+func (r *SetSOLConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if r.Data != nil {
+		if err := r.Data.SerializeTo(b, opts); err != nil {
+			return err
+		}
+	}
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	bytes[1] = uint8(r.Parameter)
+	return nil
+}
+
+type SetSOLConfigurationParametersCmd struct {
+	Req SetSOLConfigurationParametersReq
+}
+
+// Name returns "Set SOL Configuration Parameters".
+func (*SetSOLConfigurationParametersCmd) Name() string {
+	return "Set SOL Configuration Parameters"
+}
+
+// Operation returns &OperationSetSOLConfigurationParametersReq.
+func (*SetSOLConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetSOLConfigurationParametersReq
+}
+
+func (c *SetSOLConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSOLConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,42 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetSOLConfigurationParametersReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *SetSOLConfigurationParametersReq
+		want  []byte
+	}{
+		{
+			&SetSOLConfigurationParametersReq{
+				Channel:   1,
+				Parameter: SOLConfigurationParameterNonVolatileBitRate,
+				Data:      gopacket.Payload{uint8(SOLBitRate115200)},
+			},
+			[]byte{0x01, 0x05, 0x0a},
+		},
+		{
+			&SetSOLConfigurationParametersReq{
+				Channel:   ChannelPresentInterface,
+				Parameter: SOLConfigurationParameterEnable,
+				Data:      gopacket.Payload{0x01},
+			},
+			[]byte{0x0e, 0x01, 0x01},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
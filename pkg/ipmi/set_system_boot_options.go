@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSystemBootOptionsReq represents a Set System Boot Options command,
+// specified in section 28.12 of IPMI v2.0. It sets parameters that direct
+// the BIOS on the next boot, e.g. which device to boot from.
+type SetSystemBootOptionsReq struct {
+	layers.BaseLayer
+
+	// Invalid marks the parameter as invalid/locked, rather than valid.
+	// This is normally false.
+	Invalid bool
+
+	// Parameter identifies the parameter to set.
+	Parameter BootOptionParameter
+
+	// Data is the parameter data, e.g. &BootFlags{}. It is serialised after
+	// the parameter selector. It may be nil.
+	Data gopacket.SerializableLayer
+}
+
+func (*SetSystemBootOptionsReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSystemBootOptionsReq
+}
+
+func (s *SetSystemBootOptionsReq) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if s.Data != nil {
+		if err := s.Data.SerializeTo(b, opts); err != nil {
+			return err
+		}
+	}
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Parameter) & 0x7f
+	if s.Invalid {
+		bytes[0] |= 1 << 7
+	}
+	return nil
+}
+
+type SetSystemBootOptionsCmd struct {
+	Req SetSystemBootOptionsReq
+}
+
+// Name returns "Set System Boot Options".
+func (*SetSystemBootOptionsCmd) Name() string {
+	return "Set System Boot Options"
+}
+
+// Operation returns &OperationSetSystemBootOptionsReq.
+func (*SetSystemBootOptionsCmd) Operation() *Operation {
+	return &OperationSetSystemBootOptionsReq
+}
+
+func (c *SetSystemBootOptionsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSystemBootOptionsCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
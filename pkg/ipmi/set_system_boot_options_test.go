@@ -0,0 +1,50 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetSystemBootOptionsReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *SetSystemBootOptionsReq
+		want  []byte
+	}{
+		{
+			&SetSystemBootOptionsReq{
+				Parameter: BootOptionParameterBootFlags,
+				Data: &BootFlags{
+					Valid:  true,
+					Device: BootDeviceDisk,
+				},
+			},
+			[]byte{0x05, 0x80, 0x08, 0x00, 0x00, 0x00},
+		},
+		{
+			&SetSystemBootOptionsReq{
+				Invalid:   true,
+				Parameter: BootOptionParameterSetInProgress,
+				Data:      gopacket.Payload{0x01},
+			},
+			[]byte{0x80, 0x01},
+		},
+		{
+			&SetSystemBootOptionsReq{
+				Parameter: BootOptionParameterBootInfoAcknowledge,
+			},
+			[]byte{0x04},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed with %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
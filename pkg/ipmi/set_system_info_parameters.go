@@ -0,0 +1,64 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSystemInfoParametersReq represents a Set System Info Parameters request,
+// specified in section 22.14a of IPMI v2.0. This command sets information
+// about the managed system, such as its hostname, usually on behalf of the
+// BIOS or OS. Some manufacturers define OEM parameters to configure their
+// hardware, e.g. the text on a front-panel LCD. String parameters are set
+// in 16-byte blocks, one per request.
+type SetSystemInfoParametersReq struct {
+	layers.BaseLayer
+
+	// Parameter identifies the parameter to set.
+	Parameter SystemInfoParameter
+
+	// Data is the parameter data, whose format depends on the parameter. For
+	// string parameters, this begins with the set selector of the block. It
+	// is serialised after the parameter selector. It may be nil.
+	Data gopacket.SerializableLayer
+}
+
+func (*SetSystemInfoParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSystemInfoParametersReq
+}
+
+func (r *SetSystemInfoParametersReq) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if r.Data != nil {
+		if err := r.Data.SerializeTo(b, opts); err != nil {
+			return err
+		}
+	}
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Parameter)
+	return nil
+}
+
+type SetSystemInfoParametersCmd struct {
+	Req SetSystemInfoParametersReq
+}
+
+// Name returns "Set System Info Parameters".
+func (*SetSystemInfoParametersCmd) Name() string {
+	return "Set System Info Parameters"
+}
+
+// Operation returns &OperationSetSystemInfoParametersReq.
+func (*SetSystemInfoParametersCmd) Operation() *Operation {
+	return &OperationSetSystemInfoParametersReq
+}
+
+func (c *SetSystemInfoParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSystemInfoParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -10,8 +10,19 @@ type SoftwareID uint8
 
 const (
 	// SoftwareIDRemoteConsole1 is the software ID of the first remote console.
-	// There are 7 in total.
+	// There are 7 in total. This is the requester ID used by remote consoles
+	// unless configured otherwise.
 	SoftwareIDRemoteConsole1 SoftwareID = 0x40
+	SoftwareIDRemoteConsole2 SoftwareID = 0x41
+	SoftwareIDRemoteConsole3 SoftwareID = 0x42
+	SoftwareIDRemoteConsole4 SoftwareID = 0x43
+	SoftwareIDRemoteConsole5 SoftwareID = 0x44
+	SoftwareIDRemoteConsole6 SoftwareID = 0x45
+	SoftwareIDRemoteConsole7 SoftwareID = 0x46
+
+	// SoftwareIDTerminalModeRemoteConsole is the software ID of terminal mode
+	// remote console software.
+	SoftwareIDTerminalModeRemoteConsole SoftwareID = 0x47
 )
 
 // Address converts a software ID into an address value suitable for inclusion
@@ -0,0 +1,94 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SOLConfigurationParameter identifies a Serial over LAN parameter of a
+// channel, retrieved and set via the Get and Set SOL Configuration Parameters
+// commands. Values are specified in table 26-5 of IPMI v2.0. It is a 1 byte
+// uint on the wire.
+type SOLConfigurationParameter uint8
+
+const (
+	SOLConfigurationParameterSetInProgress       SOLConfigurationParameter = 0
+	SOLConfigurationParameterEnable              SOLConfigurationParameter = 1
+	SOLConfigurationParameterAuthentication      SOLConfigurationParameter = 2
+	SOLConfigurationParameterCharacterAccumulate SOLConfigurationParameter = 3
+	SOLConfigurationParameterRetry               SOLConfigurationParameter = 4
+	SOLConfigurationParameterNonVolatileBitRate  SOLConfigurationParameter = 5
+	SOLConfigurationParameterVolatileBitRate     SOLConfigurationParameter = 6
+	SOLConfigurationParameterPayloadChannel      SOLConfigurationParameter = 7
+	SOLConfigurationParameterPayloadPort         SOLConfigurationParameter = 8
+)
+
+func (p SOLConfigurationParameter) Description() string {
+	switch p {
+	case SOLConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case SOLConfigurationParameterEnable:
+		return "SOL Enable"
+	case SOLConfigurationParameterAuthentication:
+		return "SOL Authentication"
+	case SOLConfigurationParameterCharacterAccumulate:
+		return "Character Accumulate Interval & Send Threshold"
+	case SOLConfigurationParameterRetry:
+		return "SOL Retry"
+	case SOLConfigurationParameterNonVolatileBitRate:
+		return "SOL Non-Volatile Bit Rate"
+	case SOLConfigurationParameterVolatileBitRate:
+		return "SOL Volatile Bit Rate"
+	case SOLConfigurationParameterPayloadChannel:
+		return "SOL Payload Channel"
+	case SOLConfigurationParameterPayloadPort:
+		return "SOL Payload Port Number"
+	}
+	if p >= 192 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (p SOLConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
+
+// SOLBitRate is the serial bit rate of an SOL session, specified as part of
+// the SOL Non-Volatile and Volatile Bit Rate parameters in table 26-5 of IPMI
+// v2.0. It is a 4-bit uint on the wire.
+type SOLBitRate uint8
+
+const (
+	// SOLBitRateSerial means the bit rate of the BMC's serial port
+	// configuration is used.
+	SOLBitRateSerial SOLBitRate = 0x0
+
+	SOLBitRate9600   SOLBitRate = 0x6
+	SOLBitRate19200  SOLBitRate = 0x7
+	SOLBitRate38400  SOLBitRate = 0x8
+	SOLBitRate57600  SOLBitRate = 0x9
+	SOLBitRate115200 SOLBitRate = 0xa
+)
+
+func (r SOLBitRate) Description() string {
+	switch r {
+	case SOLBitRateSerial:
+		return "Serial"
+	case SOLBitRate9600:
+		return "9600 bps"
+	case SOLBitRate19200:
+		return "19.2 kbps"
+	case SOLBitRate38400:
+		return "38.4 kbps"
+	case SOLBitRate57600:
+		return "57.6 kbps"
+	case SOLBitRate115200:
+		return "115.2 kbps"
+	default:
+		return "Unknown"
+	}
+}
+
+func (r SOLBitRate) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(r), r.Description())
+}
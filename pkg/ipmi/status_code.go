@@ -28,14 +28,25 @@ const (
 	// StatusCodeUnauthorisedName is sent in RAKP Message 2 to indicate the
 	// username was not found in the BMC's users table.
 	StatusCodeUnauthorisedName StatusCode = 0x0d
+
+	// StatusCodeInvalidIntegrityCheckValue is sent in RAKP Message 4 to
+	// indicate the auth code in RAKP Message 3 was incorrect.
+	StatusCodeInvalidIntegrityCheckValue StatusCode = 0x0f
+
+	// StatusCodeNoCipherSuiteMatch is sent in the RMCP+ Open Session Response
+	// to indicate the BMC supports none of the proposed combinations of
+	// algorithms.
+	StatusCodeNoCipherSuiteMatch StatusCode = 0x11
 )
 
 var (
 	statusCodeDescriptions = map[StatusCode]string{
-		StatusCodeOK:                    "Ok",
-		StatusCodeInsufficientResources: "Insufficient Resources",
-		StatusCodeInvalidSessionID:      "Invalid Session ID",
-		StatusCodeUnauthorisedName:      "Unauthorised User",
+		StatusCodeOK:                         "Ok",
+		StatusCodeInsufficientResources:      "Insufficient Resources",
+		StatusCodeInvalidSessionID:           "Invalid Session ID",
+		StatusCodeUnauthorisedName:           "Unauthorised User",
+		StatusCodeInvalidIntegrityCheckValue: "Invalid Integrity Check Value",
+		StatusCodeNoCipherSuiteMatch:         "No Cipher Suite Match",
 	}
 )
 
@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SuspendBMCARPsReq implements the Suspend BMC ARPs command, specified in 23.3
+// of IPMI v2.0. It temporarily stops the BMC generating gratuitous ARPs and/or
+// responding to ARP requests on a LAN channel, without changing the
+// BMC-generated ARP Control parameter, e.g. while the host's OS takes over the
+// IP address of a shared NIC. Suspension ends when this is sent with both
+// fields false, or the BMC or channel is reset.
+type SuspendBMCARPsReq struct {
+	layers.BaseLayer
+
+	// Channel is the LAN channel whose ARPs to suspend.
+	Channel Channel
+
+	// SuspendGratuitousARPs stops the BMC sending gratuitous ARPs.
+	SuspendGratuitousARPs bool
+
+	// SuspendARPResponses stops the BMC responding to ARP requests.
+	SuspendARPResponses bool
+}
+
+func (*SuspendBMCARPsReq) LayerType() gopacket.LayerType {
+	return LayerTypeSuspendBMCARPsReq
+}
+
+func (r *SuspendBMCARPsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	bytes[1] = 0
+	if r.SuspendGratuitousARPs {
+		bytes[1] |= 1 << 1
+	}
+	if r.SuspendARPResponses {
+		bytes[1] |= 1
+	}
+	return nil
+}
+
+// SuspendBMCARPsRsp represents the response to a Suspend BMC ARPs command,
+// containing the ARP status of the channel once the request has been applied.
+// ARPs occur if they are enabled by the BMC-generated ARP Control parameter
+// and are not suspended.
+type SuspendBMCARPsRsp struct {
+	layers.BaseLayer
+
+	// GratuitousARPsOccurring indicates the BMC is sending gratuitous ARPs.
+	GratuitousARPsOccurring bool
+
+	// ARPResponsesOccurring indicates the BMC is responding to ARP requests.
+	ARPResponsesOccurring bool
+}
+
+func (*SuspendBMCARPsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeSuspendBMCARPsRsp
+}
+
+func (r *SuspendBMCARPsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*SuspendBMCARPsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *SuspendBMCARPsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Suspend BMC ARPs response must be 1 byte, got %v",
+			len(data))
+	}
+
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+	r.GratuitousARPsOccurring = data[0]&(1<<1) != 0
+	r.ARPResponsesOccurring = data[0]&1 != 0
+	return nil
+}
+
+type SuspendBMCARPsCmd struct {
+	Req SuspendBMCARPsReq
+	Rsp SuspendBMCARPsRsp
+}
+
+// Name returns "Suspend BMC ARPs".
+func (*SuspendBMCARPsCmd) Name() string {
+	return "Suspend BMC ARPs"
+}
+
+// Operation returns &OperationSuspendBMCARPsReq.
+func (*SuspendBMCARPsCmd) Operation() *Operation {
+	return &OperationSuspendBMCARPsReq
+}
+
+func (c *SuspendBMCARPsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SuspendBMCARPsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,45 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmitest"
+
+	"github.com/google/gopacket/layers"
+)
+
+func TestSuspendBMCARPsReqSerializeTo(t *testing.T) {
+	ipmitest.Serialize(t, &SuspendBMCARPsReq{
+		Channel: 1,
+	}, []byte{0x01, 0x00})
+	ipmitest.Serialize(t, &SuspendBMCARPsReq{
+		Channel:               2,
+		SuspendGratuitousARPs: true,
+	}, []byte{0x02, 0x02})
+	ipmitest.Serialize(t, &SuspendBMCARPsReq{
+		Channel:               1,
+		SuspendGratuitousARPs: true,
+		SuspendARPResponses:   true,
+	}, []byte{0x01, 0x03})
+}
+
+func TestSuspendBMCARPsRspDecodeFromBytes(t *testing.T) {
+	ipmitest.Decode(t, &SuspendBMCARPsRsp{}, []byte{0x01},
+		&SuspendBMCARPsRsp{
+			BaseLayer: layers.BaseLayer{
+				Contents: []byte{0x01},
+				Payload:  []byte{},
+			},
+			ARPResponsesOccurring: true,
+		})
+	ipmitest.Decode(t, &SuspendBMCARPsRsp{}, []byte{0xfe},
+		&SuspendBMCARPsRsp{
+			BaseLayer: layers.BaseLayer{
+				Contents: []byte{0xfe},
+				Payload:  []byte{},
+			},
+			GratuitousARPsOccurring: true,
+		})
+	ipmitest.Decode(t, &SuspendBMCARPsRsp{}, []byte{},
+		(*SuspendBMCARPsRsp)(nil))
+}
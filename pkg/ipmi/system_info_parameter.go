@@ -0,0 +1,41 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SystemInfoParameter identifies a parameter retrievable via the Get System
+// Info Parameters command. Values are specified in table 22-16a of IPMI v2.0.
+// It is a 1 byte uint on the wire.
+type SystemInfoParameter uint8
+
+const (
+	SystemInfoParameterSetInProgress              SystemInfoParameter = 0
+	SystemInfoParameterSystemFirmwareVersion      SystemInfoParameter = 1
+	SystemInfoParameterSystemName                 SystemInfoParameter = 2
+	SystemInfoParameterPrimaryOperatingSystemName SystemInfoParameter = 3
+	SystemInfoParameterOperatingSystemName        SystemInfoParameter = 4
+)
+
+func (p SystemInfoParameter) Description() string {
+	switch p {
+	case SystemInfoParameterSetInProgress:
+		return "Set In Progress"
+	case SystemInfoParameterSystemFirmwareVersion:
+		return "System Firmware Version"
+	case SystemInfoParameterSystemName:
+		return "System Name"
+	case SystemInfoParameterPrimaryOperatingSystemName:
+		return "Primary Operating System Name"
+	case SystemInfoParameterOperatingSystemName:
+		return "Operating System Name"
+	}
+	if p >= 192 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (p SystemInfoParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
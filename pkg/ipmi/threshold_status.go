@@ -0,0 +1,30 @@
+package ipmi
+
+// ThresholdStatus is a bitfield of the thresholds a threshold sensor's reading
+// is at or beyond, as returned by Get Sensor Reading, specified in table 35-15
+// of IPMI v2.0. The bit positions match the threshold event offsets in table
+// 42-2 divided by two, so the threshold of a SEL event can be obtained with
+// ThresholdStatus(1 << (offset >> 1)).
+type ThresholdStatus uint8
+
+const (
+	ThresholdStatusLowerNonCritical ThresholdStatus = 1 << iota
+	ThresholdStatusLowerCritical
+	ThresholdStatusLowerNonRecoverable
+	ThresholdStatusUpperNonCritical
+	ThresholdStatusUpperCritical
+	ThresholdStatusUpperNonRecoverable
+)
+
+// Critical returns whether the reading is at or beyond a critical or
+// non-recoverable threshold, in either direction.
+func (s ThresholdStatus) Critical() bool {
+	return s&(ThresholdStatusLowerCritical|ThresholdStatusLowerNonRecoverable|
+		ThresholdStatusUpperCritical|ThresholdStatusUpperNonRecoverable) != 0
+}
+
+func (s ThresholdStatus) String() string {
+	return flagsString(uint8(s), []string{"Lower Non-Critical",
+		"Lower Critical", "Lower Non-Recoverable", "Upper Non-Critical",
+		"Upper Critical", "Upper Non-Recoverable"})
+}
@@ -0,0 +1,84 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timestamp is a time as represented on the wire, specified in 37 of IPMI
+// v2.0: an unsigned number of seconds since the epoch in the BMC's local time.
+// Values up to and including TimestampPostInitMax are instead the number of
+// seconds since the BMC was initialised, as it did not yet know the time, and
+// TimestampUnspecified means the time is not known at all. It is used by the
+// SEL, the SDR Repository and DCMI.
+type Timestamp uint32
+
+const (
+	// TimestampPostInitMax is the largest timestamp that is relative to BMC
+	// initialisation rather than the epoch.
+	TimestampPostInitMax Timestamp = 0x20000000 - 1
+
+	// TimestampUnspecified indicates the BMC does not know the time, e.g. it
+	// has not been set, or the record did not need one.
+	TimestampUnspecified Timestamp = 0xffffffff
+)
+
+// NewTimestamp returns the timestamp representing t, in a BMC whose local time
+// is utcOffset ahead of UTC. Times before early 1987 cannot be represented;
+// they, and times after 2106, are returned as TimestampUnspecified.
+func NewTimestamp(t time.Time, utcOffset time.Duration) Timestamp {
+	seconds := t.Add(utcOffset).Unix()
+	if seconds <= int64(TimestampPostInitMax) ||
+		seconds >= int64(TimestampUnspecified) {
+		return TimestampUnspecified
+	}
+	return Timestamp(seconds)
+}
+
+// IsUnspecified returns whether the timestamp is TimestampUnspecified.
+func (t Timestamp) IsUnspecified() bool {
+	return t == TimestampUnspecified
+}
+
+// IsPostInit returns whether the timestamp is relative to BMC initialisation,
+// rather than the epoch.
+func (t Timestamp) IsPostInit() bool {
+	return t <= TimestampPostInitMax
+}
+
+// SinceInit returns the time since BMC initialisation, and whether the
+// timestamp is relative to initialisation.
+func (t Timestamp) SinceInit() (time.Duration, bool) {
+	if !t.IsPostInit() {
+		return 0, false
+	}
+	return time.Duration(t) * time.Second, true
+}
+
+// Time converts the timestamp into a time.Time, given the offset of the BMC's
+// local time ahead of UTC, as returned by Get SEL Time UTC Offset; most BMCs
+// keep UTC, so this is usually 0. It returns the zero value if the timestamp
+// is unspecified. Timestamps relative to BMC initialisation are converted as
+// if they were since the epoch, ignoring the offset, giving a time before 1987
+// that preserves their order; use IsPostInit() to identify them.
+func (t Timestamp) Time(utcOffset time.Duration) time.Time {
+	switch {
+	case t.IsUnspecified():
+		return time.Time{}
+	case t.IsPostInit():
+		return time.Unix(int64(t), 0)
+	default:
+		return time.Unix(int64(t), 0).Add(-utcOffset)
+	}
+}
+
+func (t Timestamp) String() string {
+	switch {
+	case t.IsUnspecified():
+		return "Unspecified"
+	case t.IsPostInit():
+		return fmt.Sprintf("%v after initialisation", time.Duration(t)*time.Second)
+	default:
+		return t.Time(0).UTC().Format(time.RFC3339)
+	}
+}
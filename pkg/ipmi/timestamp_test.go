@@ -0,0 +1,65 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampTime(t *testing.T) {
+	tests := []struct {
+		timestamp Timestamp
+		utcOffset time.Duration
+		want      time.Time
+	}{
+		{TimestampUnspecified, 0, time.Time{}},
+		{0x5f5e1000, 0, time.Unix(0x5f5e1000, 0)},
+		{0x5f5e1000, time.Hour, time.Unix(0x5f5e1000-3600, 0)},
+		// relative to initialisation, so the offset does not apply
+		{120, time.Hour, time.Unix(120, 0)},
+	}
+	for _, test := range tests {
+		if got := test.timestamp.Time(test.utcOffset); !got.Equal(test.want) {
+			t.Errorf("%v.Time(%v) = %v, want %v", test.timestamp,
+				test.utcOffset, got, test.want)
+		}
+	}
+}
+
+func TestTimestampSinceInit(t *testing.T) {
+	tests := []struct {
+		timestamp Timestamp
+		want      time.Duration
+		wantOK    bool
+	}{
+		{0, 0, true},
+		{TimestampPostInitMax, time.Duration(TimestampPostInitMax) * time.Second, true},
+		{TimestampPostInitMax + 1, 0, false},
+		{TimestampUnspecified, 0, false},
+	}
+	for _, test := range tests {
+		got, ok := test.timestamp.SinceInit()
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("%v.SinceInit() = %v, %v, want %v, %v", test.timestamp,
+				got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestNewTimestamp(t *testing.T) {
+	tests := []struct {
+		t         time.Time
+		utcOffset time.Duration
+		want      Timestamp
+	}{
+		{time.Unix(0x5f5e1000, 0), 0, 0x5f5e1000},
+		{time.Unix(0x5f5e1000, 0), time.Hour, 0x5f5e1000 + 3600},
+		{time.Unix(100, 0), 0, TimestampUnspecified},
+		{time.Time{}, 0, TimestampUnspecified},
+	}
+	for _, test := range tests {
+		if got := NewTimestamp(test.t, test.utcOffset); got != test.want {
+			t.Errorf("NewTimestamp(%v, %v) = %v, want %v", test.t,
+				test.utcOffset, got, test.want)
+		}
+	}
+}
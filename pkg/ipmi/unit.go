@@ -0,0 +1,85 @@
+package ipmi
+
+import (
+	"strings"
+)
+
+// Unit is the complete unit of a sensor's readings, as described by the Sensor
+// Units 1, 2 and 3 fields of its SDR, e.g. "Watts", or "RPM per second". Use
+// FullSensorRecord.Unit() to obtain one.
+type Unit struct {
+
+	// Base is the primary unit, e.g. Watts.
+	Base SensorUnit
+
+	// Modifier is combined with Base as indicated by ModifierRelation. It is
+	// ignored if that is ModifierRelationNone.
+	Modifier SensorUnit
+
+	// ModifierRelation indicates whether Base is divided or multiplied by
+	// Modifier.
+	ModifierRelation ModifierRelation
+
+	// Rate is the time period readings are given over, if any.
+	Rate RateUnit
+
+	// Percentage indicates readings are a percentage of the unit, or if Base
+	// is unspecified, simply a percentage.
+	Percentage bool
+}
+
+// String returns the unit in the same form as ipmitool, with any rate
+// appended, e.g. "% Watts", "feet/minute" or "RPM per second".
+func (u Unit) String() string {
+	b := strings.Builder{}
+	if u.Percentage {
+		if u.Base == 0 && u.ModifierRelation == ModifierRelationNone {
+			b.WriteString("percent")
+		} else {
+			b.WriteString("% ")
+		}
+	}
+	if u.Base != 0 || !u.Percentage ||
+		u.ModifierRelation != ModifierRelationNone {
+		b.WriteString(u.Base.Name())
+	}
+	switch u.ModifierRelation {
+	case ModifierRelationDivide:
+		b.WriteString("/")
+		b.WriteString(u.Modifier.Name())
+	case ModifierRelationMultiply:
+		b.WriteString(" * ")
+		b.WriteString(u.Modifier.Name())
+	}
+	if u.Rate != RateUnitNone {
+		b.WriteString(" ")
+		b.WriteString(u.Rate.Name())
+	}
+	return b.String()
+}
+
+// Canonical returns the equivalent unit with its base and modifier units
+// converted by SensorUnit.Canonical(), and any rate converted to per second,
+// along with the factor to multiply readings by to convert them into it. For
+// example, kilobytes per minute becomes bytes per second, with a factor of
+// 1000/60.
+func (u Unit) Canonical() (Unit, float64) {
+	canonical := u
+	base, factor := u.Base.Canonical()
+	canonical.Base = base
+	if u.ModifierRelation != ModifierRelationNone {
+		modifier, modifierFactor := u.Modifier.Canonical()
+		canonical.Modifier = modifier
+		switch u.ModifierRelation {
+		case ModifierRelationDivide:
+			factor /= modifierFactor
+		case ModifierRelationMultiply:
+			factor *= modifierFactor
+		}
+	}
+	if duration := u.Rate.Duration(); duration > 0 {
+		canonical.Rate = RateUnitPerSecond
+		factor /= duration.Seconds()
+	}
+	return canonical, factor
+}
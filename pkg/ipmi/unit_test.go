@@ -0,0 +1,119 @@
+package ipmi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitString(t *testing.T) {
+	tests := []struct {
+		unit Unit
+		want string
+	}{
+		{Unit{}, "unspecified"},
+		{Unit{Base: SensorUnitWatts}, "Watts"},
+		{Unit{Base: SensorUnitAmps}, "Amps"},
+		{Unit{Base: SensorUnitCelsius}, "degrees C"},
+		{Unit{Base: SensorUnitGrams}, "grams"},
+		{Unit{Base: SensorUnitDecibels}, "Decibels"},
+		{Unit{Base: 59}, "unknown"},
+		{Unit{Base: 93}, "unknown"},
+		{Unit{Percentage: true}, "percent"},
+		{Unit{Base: SensorUnitWatts, Percentage: true}, "% Watts"},
+		{
+			Unit{
+				Base:             SensorUnitFeet,
+				Modifier:         SensorUnitMinutes,
+				ModifierRelation: ModifierRelationDivide,
+			},
+			"feet/minute",
+		},
+		{
+			Unit{
+				Base:             SensorUnitVolts,
+				Modifier:         SensorUnitAmps,
+				ModifierRelation: ModifierRelationMultiply,
+			},
+			"Volts * Amps",
+		},
+		{
+			Unit{
+				Base:     SensorUnitAmps,
+				Modifier: SensorUnitVolts,
+			},
+			"Amps",
+		},
+		{Unit{Base: SensorUnitRotationsPerMinute, Rate: RateUnitPerSecond},
+			"RPM per second"},
+		{Unit{Base: SensorUnitErrors, Rate: RateUnitPerHour}, "error per hour"},
+	}
+	for _, test := range tests {
+		if got := test.unit.String(); got != test.want {
+			t.Errorf("%+v.String() = %q, want %q", test.unit, got, test.want)
+		}
+	}
+}
+
+func TestUnitCanonical(t *testing.T) {
+	tests := []struct {
+		unit       Unit
+		want       Unit
+		wantFactor float64
+	}{
+		{
+			Unit{Base: SensorUnitWatts},
+			Unit{Base: SensorUnitWatts},
+			1,
+		},
+		{
+			Unit{Base: SensorUnitMilliseconds, Percentage: true},
+			Unit{Base: SensorUnitSeconds, Percentage: true},
+			1e-3,
+		},
+		{
+			Unit{Base: SensorUnitKilobytes, Rate: RateUnitPerMinute},
+			Unit{Base: SensorUnitBytes, Rate: RateUnitPerSecond},
+			1e3 / 60,
+		},
+		{
+			Unit{
+				Base:             SensorUnitMegabits,
+				Modifier:         SensorUnitMilliseconds,
+				ModifierRelation: ModifierRelationDivide,
+			},
+			Unit{
+				Base:             SensorUnitBits,
+				Modifier:         SensorUnitSeconds,
+				ModifierRelation: ModifierRelationDivide,
+			},
+			1e9,
+		},
+		{
+			Unit{
+				Base:             SensorUnitMillihenry,
+				Modifier:         SensorUnitHours,
+				ModifierRelation: ModifierRelationMultiply,
+			},
+			Unit{
+				Base:             SensorUnitHenry,
+				Modifier:         SensorUnitSeconds,
+				ModifierRelation: ModifierRelationMultiply,
+			},
+			3.6,
+		},
+		{
+			// the modifier is unused
+			Unit{Base: SensorUnitVolts, Modifier: SensorUnitKilobytes},
+			Unit{Base: SensorUnitVolts, Modifier: SensorUnitKilobytes},
+			1,
+		},
+	}
+	for _, test := range tests {
+		got, factor := test.unit.Canonical()
+		if got != test.want ||
+			math.Abs(factor-test.wantFactor) > 1e-9*test.wantFactor {
+			t.Errorf("%v.Canonical() = %+v, %v, want %+v, %v", test.unit, got,
+				factor, test.want, test.wantFactor)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// UserStatus indicates whether a user ID is enabled, as returned by Get User
+// Access. It is a 2-bit uint on the wire. Users are enabled and disabled with
+// Set User Password.
+type UserStatus uint8
+
+const (
+	// UserStatusUnspecified means the BMC does not report whether the user
+	// is enabled. This is the only value in IPMI v1.5.
+	UserStatusUnspecified UserStatus = iota
+
+	// UserStatusEnabled means the user was enabled via Set User Password.
+	UserStatusEnabled
+
+	// UserStatusDisabled means the user was disabled via Set User Password.
+	UserStatusDisabled
+)
+
+func (s UserStatus) Description() string {
+	switch s {
+	case UserStatusUnspecified:
+		return "Unspecified"
+	case UserStatusEnabled:
+		return "Enabled"
+	case UserStatusDisabled:
+		return "Disabled"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s UserStatus) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
+
+// UserPasswordOperation is the action performed by Set User Password,
+// specified in table 22-29 of IPMI v2.0. It is a 2-bit uint on the wire.
+type UserPasswordOperation uint8
+
+const (
+	UserPasswordOperationDisableUser UserPasswordOperation = iota
+	UserPasswordOperationEnableUser
+	UserPasswordOperationSetPassword
+
+	// UserPasswordOperationTestPassword checks the password against the one
+	// stored, without changing anything. The BMC returns
+	// CompletionCodePasswordMismatch if they differ.
+	UserPasswordOperationTestPassword
+)
+
+func (o UserPasswordOperation) Description() string {
+	switch o {
+	case UserPasswordOperationDisableUser:
+		return "Disable User"
+	case UserPasswordOperationEnableUser:
+		return "Enable User"
+	case UserPasswordOperationSetPassword:
+		return "Set Password"
+	case UserPasswordOperationTestPassword:
+		return "Test Password"
+	default:
+		return "Unknown"
+	}
+}
+
+func (o UserPasswordOperation) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(o), o.Description())
+}
+
+// Effect returns OperationEffectNone if the request tests the password,
+// otherwise the effect of OperationSetUserPasswordReq. It is defined here, as
+// SetUserPasswordCmd is generated.
+func (c *SetUserPasswordCmd) Effect() OperationEffect {
+	if c.Req.Operation == UserPasswordOperationTestPassword {
+		return OperationEffectNone
+	}
+	return OperationSetUserPasswordReq.Effect()
+}
+
+const (
+	// CompletionCodePasswordMismatch is returned by Set User Password when
+	// testing a password that does not match the one stored. It is specific
+	// to that command.
+	CompletionCodePasswordMismatch CompletionCode = 0x80
+
+	// CompletionCodePasswordSizeMismatch is returned by Set User Password when
+	// testing a password of a different size to the one stored, e.g. a 16
+	// byte password against a 20 byte one. It is specific to that command.
+	CompletionCodePasswordSizeMismatch CompletionCode = 0x81
+)
+
+// UserName converts the fixed-length name field of Get User Name into a string,
+// dropping the trailing padding. An empty string means the user ID has no name.
+func UserName(name [16]byte) string {
+	if i := bytes.IndexByte(name[:], 0); i != -1 {
+		return string(name[:i])
+	}
+	return string(name[:])
+}
@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// v2GetSystemGUIDRsp returns a session-less Get System GUID response packet.
+func v2GetSystemGUIDRsp(t testing.TB) []byte {
+	guid := [16]byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb,
+		0xc, 0xd, 0xe, 0xf, 0x10}
+	b := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(b, gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	},
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xff,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&V2Session{
+			PayloadDescriptor: PayloadDescriptorIPMI,
+		},
+		&Message{
+			Operation:     OperationGetSystemGUIDRsp,
+			RemoteAddress: SoftwareIDRemoteConsole1.Address(),
+			LocalAddress:  SlaveAddressBMC.Address(),
+		},
+		gopacket.Payload(guid[:]),
+	)
+	if err != nil {
+		t.Fatalf("serialise failed: %v", err)
+	}
+	return b.Bytes()
+}
+
+func TestV2Parser(t *testing.T) {
+	p := NewV2Parser(nil)
+	rsp := &GetSystemGUIDRsp{}
+	if err := p.DecodeLayers(v2GetSystemGUIDRsp(t), rsp); err != nil {
+		t.Fatalf("DecodeLayers() failed: %v", err)
+	}
+
+	wantDecoded := []gopacket.LayerType{
+		layers.LayerTypeRMCP,
+		LayerTypeSessionSelector,
+		LayerTypeV2Session,
+		LayerTypeMessage,
+	}
+	if diff := cmp.Diff(wantDecoded, p.Decoded); diff != "" {
+		t.Errorf("unexpected decoded layers (-want +got):\n%v", diff)
+	}
+	if p.Message.Operation != OperationGetSystemGUIDRsp {
+		t.Errorf("operation = %v, want %v", p.Message.Operation,
+			OperationGetSystemGUIDRsp)
+	}
+	if rsp.GUID[15] != 0x10 {
+		t.Errorf("GUID = %v, want final byte 0x10", rsp.GUID)
+	}
+}
+
+func BenchmarkV2ParserDecodeLayers(b *testing.B) {
+	packet := v2GetSystemGUIDRsp(b)
+	p := NewV2Parser(nil)
+	rsp := &GetSystemGUIDRsp{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := p.DecodeLayers(packet, rsp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkV2NewPacket(b *testing.B) {
+	packet := v2GetSystemGUIDRsp(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := gopacket.NewPacket(packet, layers.LayerTypeRMCP,
+			gopacket.DecodeOptions{NoCopy: true})
+		if err := p.ErrorLayer(); err != nil {
+			b.Fatal(err.Error())
+		}
+	}
+}
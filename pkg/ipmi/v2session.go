@@ -3,6 +3,7 @@ package ipmi
 import (
 	"crypto/hmac"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash"
 
@@ -12,6 +13,11 @@ import (
 	"github.com/google/gopacket/layers"
 )
 
+// ErrInvalidSignature is returned when decoding an authenticated session packet
+// whose signature does not match that calculated using the integrity
+// algorithm. The packet may have been corrupted or tampered with.
+var ErrInvalidSignature = errors.New("invalid signature")
+
 // V2Session represents an IPMI v2.0/RMCP+ session header. Its format is
 // specified in section 13.6 of the spec. N.B. the default instance of this
 // struct can only deal with unauthenticated packets. The IntegrityAlgorithm
@@ -184,7 +190,8 @@ func (s *V2Session) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) err
 	s.Signature = data[offset:]
 	signature := executeHash(s.IntegrityAlgorithm, data[:offset])
 	if !hmac.Equal(s.Signature, signature) {
-		return fmt.Errorf("invalid signature: want %v, got %v", signature, s.Signature)
+		return fmt.Errorf("%w: want %v, got %v", ErrInvalidSignature, signature,
+			s.Signature)
 	}
 
 	return nil
@@ -0,0 +1,117 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// WatchdogCountdownUnit is the resolution of watchdog timer countdowns.
+const WatchdogCountdownUnit = time.Millisecond * 100
+
+// CompletionCodeWatchdogNotInitialised is returned by Reset Watchdog Timer if
+// the timer has not been configured with Set Watchdog Timer since the BMC last
+// initialised. It is specific to that command.
+const CompletionCodeWatchdogNotInitialised CompletionCode = 0x80
+
+// WatchdogTimerUse indicates what the BMC watchdog timer is being used for,
+// specified in table 27-6 of IPMI v2.0. This determines which expiration flag
+// is set, and is logged with the expiration event. It is a 3-bit uint on the
+// wire.
+type WatchdogTimerUse uint8
+
+const (
+	WatchdogTimerUseBIOSFRB2 WatchdogTimerUse = 0x1
+	WatchdogTimerUseBIOSPOST WatchdogTimerUse = 0x2
+	WatchdogTimerUseOSLoad   WatchdogTimerUse = 0x3
+
+	// WatchdogTimerUseSMSOS is used by software running on the host once the
+	// OS has booted, e.g. a watchdog daemon. This is the usual value when
+	// petting the timer remotely.
+	WatchdogTimerUseSMSOS WatchdogTimerUse = 0x4
+
+	WatchdogTimerUseOEM WatchdogTimerUse = 0x5
+)
+
+func (u WatchdogTimerUse) Description() string {
+	switch u {
+	case WatchdogTimerUseBIOSFRB2:
+		return "BIOS FRB2"
+	case WatchdogTimerUseBIOSPOST:
+		return "BIOS/POST"
+	case WatchdogTimerUseOSLoad:
+		return "OS Load"
+	case WatchdogTimerUseSMSOS:
+		return "SMS/OS"
+	case WatchdogTimerUseOEM:
+		return "OEM"
+	default:
+		return "Unknown"
+	}
+}
+
+func (u WatchdogTimerUse) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(u), u.Description())
+}
+
+// WatchdogTimeoutAction is what the BMC does when the watchdog timer expires,
+// specified in table 27-6 of IPMI v2.0. It is a 3-bit uint on the wire.
+type WatchdogTimeoutAction uint8
+
+const (
+	// WatchdogTimeoutActionNone only logs the expiration, and sets the
+	// expiration flag.
+	WatchdogTimeoutActionNone       WatchdogTimeoutAction = 0x0
+	WatchdogTimeoutActionHardReset  WatchdogTimeoutAction = 0x1
+	WatchdogTimeoutActionPowerDown  WatchdogTimeoutAction = 0x2
+	WatchdogTimeoutActionPowerCycle WatchdogTimeoutAction = 0x3
+)
+
+func (a WatchdogTimeoutAction) Description() string {
+	switch a {
+	case WatchdogTimeoutActionNone:
+		return "No action"
+	case WatchdogTimeoutActionHardReset:
+		return "Hard reset"
+	case WatchdogTimeoutActionPowerDown:
+		return "Power down"
+	case WatchdogTimeoutActionPowerCycle:
+		return "Power cycle"
+	default:
+		return "Unknown"
+	}
+}
+
+func (a WatchdogTimeoutAction) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(a), a.Description())
+}
+
+// WatchdogPreTimeoutInterrupt is the interrupt raised shortly before the
+// watchdog timer expires, specified in table 27-6 of IPMI v2.0. It is a 3-bit
+// uint on the wire.
+type WatchdogPreTimeoutInterrupt uint8
+
+const (
+	WatchdogPreTimeoutInterruptNone      WatchdogPreTimeoutInterrupt = 0x0
+	WatchdogPreTimeoutInterruptSMI       WatchdogPreTimeoutInterrupt = 0x1
+	WatchdogPreTimeoutInterruptNMI       WatchdogPreTimeoutInterrupt = 0x2
+	WatchdogPreTimeoutInterruptMessaging WatchdogPreTimeoutInterrupt = 0x3
+)
+
+func (i WatchdogPreTimeoutInterrupt) Description() string {
+	switch i {
+	case WatchdogPreTimeoutInterruptNone:
+		return "None"
+	case WatchdogPreTimeoutInterruptSMI:
+		return "SMI"
+	case WatchdogPreTimeoutInterruptNMI:
+		return "NMI/Diagnostic Interrupt"
+	case WatchdogPreTimeoutInterruptMessaging:
+		return "Messaging Interrupt"
+	default:
+		return "Unknown"
+	}
+}
+
+func (i WatchdogPreTimeoutInterrupt) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(i), i.Description())
+}
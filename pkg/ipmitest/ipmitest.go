@@ -0,0 +1,123 @@
+// Package ipmitest provides helpers for testing gopacket layers, such as the
+// commands in the ipmi and dcmi packages, against golden byte sequences. It
+// replaces the table-driven serialise and decode loops otherwise repeated in
+// every command's tests, and seeds fuzz tests with the same sequences.
+package ipmitest
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// Layer is a layer that can be both serialised and decoded, e.g. a data
+// structure shared between a request and response.
+type Layer interface {
+	gopacket.SerializableLayer
+	gopacket.DecodingLayer
+}
+
+// Serialize asserts that a layer serialises to want, without options.
+func Serialize(t testing.TB, layer gopacket.SerializableLayer, want []byte) {
+	t.Helper()
+	got, err := serialize(layer)
+	if err != nil {
+		t.Errorf("serialize %v failed with %v", layer, err)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
+
+// Decode asserts that decoding data into layer yields want. If want is nil,
+// including a nil pointer, decoding is asserted to fail. Options are passed to
+// cmp.Diff(); by default, layers are compared in full, including the contents
+// and payload of their BaseLayer.
+func Decode(t testing.TB, layer gopacket.DecodingLayer, data []byte, want gopacket.DecodingLayer, opts ...cmp.Option) {
+	t.Helper()
+	err := layer.DecodeFromBytes(data, gopacket.NilDecodeFeedback)
+	switch {
+	case isNil(want):
+		if err == nil {
+			t.Errorf("expected error decoding %v, got none", data)
+		}
+	case err != nil:
+		t.Errorf("decode %v failed with %v", data, err)
+	default:
+		if diff := cmp.Diff(want, layer, opts...); diff != "" {
+			t.Errorf("decode %v = %v, want %v: %v", data, layer, want, diff)
+		}
+	}
+}
+
+// RoundTrip asserts that a layer serialises to want, and that want decodes
+// into a new layer returned by fresh equal to the original, ignoring the
+// contents and payload of their BaseLayer. Further options are passed to
+// cmp.Diff().
+func RoundTrip(t testing.TB, layer Layer, fresh func() Layer, want []byte, opts ...cmp.Option) {
+	t.Helper()
+	Serialize(t, layer, want)
+	decoded := fresh()
+	if err := decoded.DecodeFromBytes(want, gopacket.NilDecodeFeedback); err != nil {
+		t.Errorf("decode %v failed with %v", want, err)
+		return
+	}
+	opts = append(opts, cmpopts.IgnoreTypes(layers.BaseLayer{}))
+	if diff := cmp.Diff(layer, decoded, opts...); diff != "" {
+		t.Errorf("round trip of %v = %v: %v", layer, decoded, diff)
+	}
+}
+
+// SeedCorpus adds golden byte sequences, e.g. those passed to Decode(), to a
+// fuzz test's seed corpus.
+func SeedCorpus(f *testing.F, seeds ...[]byte) {
+	f.Helper()
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+}
+
+// FuzzDecode fuzzes a layer's DecodeFromBytes method, which must not panic
+// on any input. A new layer is obtained from fresh for each input. If decoding
+// succeeds, the layer's contents must be taken from the input. Call
+// SeedCorpus() first.
+func FuzzDecode(f *testing.F, fresh func() gopacket.DecodingLayer) {
+	f.Helper()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		layer := fresh()
+		if err := layer.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+			return
+		}
+		if l, ok := layer.(gopacket.Layer); ok {
+			if contents := l.LayerContents(); len(contents) > len(data) {
+				t.Errorf("decode %v yielded %v bytes of contents", data,
+					len(contents))
+			}
+		}
+	})
+}
+
+// serialize returns the bytes of a layer serialised without options.
+func serialize(layer gopacket.SerializableLayer) ([]byte, error) {
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		return nil, err
+	}
+	return sb.Bytes(), nil
+}
+
+// isNil returns whether a layer is a nil interface or nil pointer, allowing
+// typed table fields to be passed to Decode().
+func isNil(layer gopacket.DecodingLayer) bool {
+	if layer == nil {
+		return true
+	}
+	v := reflect.ValueOf(layer)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,87 @@
+package ipmitest
+
+import (
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// recorder is a testing.TB that records whether the test failed, rather than
+// failing the real test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (*recorder) Helper() {}
+
+func (r *recorder) Errorf(string, ...interface{}) {
+	r.failed = true
+}
+
+func TestSerialize(t *testing.T) {
+	layer := &ipmi.BootFlags{
+		Valid:  true,
+		Device: ipmi.BootDevicePXE,
+	}
+	r := &recorder{TB: t}
+	Serialize(r, layer, []byte{0x80, 0x04, 0x00, 0x00, 0x00})
+	if r.failed {
+		t.Errorf("Serialize() failed for matching bytes")
+	}
+	r = &recorder{TB: t}
+	Serialize(r, layer, []byte{0x80, 0x08, 0x00, 0x00, 0x00})
+	if !r.failed {
+		t.Errorf("Serialize() passed for differing bytes")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	data := []byte{0x80, 0x04, 0x00, 0x00, 0x00}
+	want := &ipmi.BootFlags{}
+	if err := want.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		t.Fatal(err)
+	}
+	table := []struct {
+		data       []byte
+		want       *ipmi.BootFlags
+		wantFailed bool
+	}{
+		{data, want, false},
+		{[]byte{0x80, 0x08, 0x00, 0x00, 0x00}, want, true},
+		{data[:4], nil, false},
+		{data, nil, true},
+	}
+	for _, test := range table {
+		r := &recorder{TB: t}
+		Decode(r, &ipmi.BootFlags{}, test.data, test.want)
+		if r.failed != test.wantFailed {
+			t.Errorf("Decode(%v, %v) failed = %v, want %v", test.data,
+				test.want, r.failed, test.wantFailed)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	fresh := func() Layer {
+		return &ipmi.BootFlags{}
+	}
+	layer := &ipmi.BootFlags{
+		Valid:      true,
+		Persistent: true,
+		EFI:        true,
+		Device:     ipmi.BootDeviceBIOSSetup,
+	}
+	r := &recorder{TB: t}
+	RoundTrip(r, layer, fresh, []byte{0xe0, 0x18, 0x00, 0x00, 0x00})
+	if r.failed {
+		t.Errorf("RoundTrip() failed for a valid layer")
+	}
+	r = &recorder{TB: t}
+	RoundTrip(r, layer, fresh, []byte{0xe0, 0x18, 0x00, 0x00})
+	if !r.failed {
+		t.Errorf("RoundTrip() passed for differing bytes")
+	}
+}
@@ -0,0 +1,7 @@
+// Package picmg implements the PICMG Group Extension commands used by
+// AdvancedTCA and AdvancedMC hardware, specified in PICMG 3.0 R3.0. These
+// commands are sent with the Group Extension network function and the PICMG
+// body code. FRU device IDs refer to FRUs managed by the addressed IPM
+// controller; use ipmi.CommandWithAddress() to reach controllers other than
+// the shelf manager.
+package picmg
@@ -0,0 +1,55 @@
+package picmg
+
+import (
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUControlReq implements the FRU Control command, used to reset, reboot or
+// quiesce a FRU. The response contains no data beyond the PICMG identifier.
+type FRUControlReq struct {
+	layers.BaseLayer
+
+	// FRUDeviceID identifies the FRU to control.
+	FRUDeviceID uint8
+
+	// Option is the action to perform.
+	Option FRUControlOption
+}
+
+func (*FRUControlReq) LayerType() gopacket.LayerType {
+	return layerTypeFRUControlReq
+}
+
+func (r *FRUControlReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.FRUDeviceID
+	bytes[1] = uint8(r.Option)
+	return nil
+}
+
+type FRUControlCmd struct {
+	Req FRUControlReq
+}
+
+// Name returns "FRU Control".
+func (*FRUControlCmd) Name() string {
+	return "FRU Control"
+}
+
+func (*FRUControlCmd) Operation() *ipmi.Operation {
+	return &operationFRUControlReq
+}
+
+func (c *FRUControlCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*FRUControlCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,40 @@
+package picmg
+
+import (
+	"fmt"
+)
+
+// FRUControlOption is the action requested of a FRU in a FRU Control command.
+// FRUs are only required to support FRUControlOptionColdReset; the Get FRU
+// Control Capabilities command indicates which others are available.
+type FRUControlOption uint8
+
+const (
+	FRUControlOptionColdReset                FRUControlOption = 0x00
+	FRUControlOptionWarmReset                FRUControlOption = 0x01
+	FRUControlOptionGracefulReboot           FRUControlOption = 0x02
+	FRUControlOptionIssueDiagnosticInterrupt FRUControlOption = 0x03
+	FRUControlOptionQuiesce                  FRUControlOption = 0x04
+)
+
+// Description returns a human-friendly name for the option.
+func (o FRUControlOption) Description() string {
+	switch o {
+	case FRUControlOptionColdReset:
+		return "Cold Reset"
+	case FRUControlOptionWarmReset:
+		return "Warm Reset"
+	case FRUControlOptionGracefulReboot:
+		return "Graceful Reboot"
+	case FRUControlOptionIssueDiagnosticInterrupt:
+		return "Issue Diagnostic Interrupt"
+	case FRUControlOptionQuiesce:
+		return "Quiesce"
+	default:
+		return "Unknown"
+	}
+}
+
+func (o FRUControlOption) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(o), o.Description())
+}
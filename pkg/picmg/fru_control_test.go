@@ -0,0 +1,39 @@
+package picmg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestFRUControlReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		in   *FRUControlReq
+		want []byte
+	}{
+		{
+			&FRUControlReq{},
+			[]byte{0x00, 0x00},
+		},
+		{
+			&FRUControlReq{
+				FRUDeviceID: 1,
+				Option:      FRUControlOptionQuiesce,
+			},
+			[]byte{0x01, 0x04},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.in.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.in, err, test.want)
+			continue
+		}
+		got := sb.Bytes()
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
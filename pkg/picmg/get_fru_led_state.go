@@ -0,0 +1,138 @@
+package picmg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetFRULEDStateReq implements the Get FRU LED State command, which returns
+// the local control and override states of an LED.
+type GetFRULEDStateReq struct {
+	layers.BaseLayer
+
+	// FRUDeviceID identifies the FRU whose LED to query.
+	FRUDeviceID uint8
+
+	// LED is the LED to query. LEDIDAll is not valid.
+	LED LEDID
+}
+
+func (*GetFRULEDStateReq) LayerType() gopacket.LayerType {
+	return layerTypeGetFRULEDStateReq
+}
+
+func (r *GetFRULEDStateReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.FRUDeviceID
+	bytes[1] = uint8(r.LED)
+	return nil
+}
+
+// GetFRULEDStateRsp represents the response to a Get FRU LED State command.
+// The state the LED is currently displaying is the lamp test if enabled, else
+// the override state if enabled, else the local control state.
+type GetFRULEDStateRsp struct {
+	layers.BaseLayer
+
+	// HasLocalControl indicates whether the FRU controls the LED itself when
+	// not overridden. If false, LocalControl is meaningless.
+	HasLocalControl bool
+
+	// OverrideEnabled indicates whether the LED is in the Override state set
+	// by a Set FRU LED State command.
+	OverrideEnabled bool
+
+	// LampTestEnabled indicates whether a lamp test is in progress.
+	LampTestEnabled bool
+
+	// LocalControl is the state of the LED under the control of the FRU.
+	LocalControl LEDState
+
+	// Override is the state set by the last Set FRU LED State command. It is
+	// only populated if OverrideEnabled or LampTestEnabled is true.
+	Override LEDState
+
+	// LampTestDuration is the duration of the lamp test in progress, with a
+	// resolution of 100ms. It is only populated if LampTestEnabled is true.
+	LampTestDuration time.Duration
+}
+
+func (*GetFRULEDStateRsp) LayerType() gopacket.LayerType {
+	return layerTypeGetFRULEDStateRsp
+}
+
+func (r *GetFRULEDStateRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetFRULEDStateRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetFRULEDStateRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("Get FRU LED State response must be at least 4 "+
+			"bytes, got %v", len(data))
+	}
+	r.HasLocalControl = data[0]&1 != 0
+	r.OverrideEnabled = data[0]&(1<<1) != 0
+	r.LampTestEnabled = data[0]&(1<<2) != 0
+
+	length := 4
+	if r.OverrideEnabled || r.LampTestEnabled {
+		length = 7
+	}
+	if r.LampTestEnabled {
+		length = 8
+	}
+	if len(data) < length {
+		df.SetTruncated()
+		return fmt.Errorf("Get FRU LED State response with states %#x must "+
+			"be at least %v bytes, got %v", data[0], length, len(data))
+	}
+
+	r.LocalControl.decodeFromBytes(data[1:4])
+	r.Override = LEDState{}
+	if length >= 7 {
+		r.Override.decodeFromBytes(data[4:7])
+	}
+	r.LampTestDuration = 0
+	if length >= 8 {
+		r.LampTestDuration = time.Duration(data[7]&0x7f) * 100 *
+			time.Millisecond
+	}
+	r.BaseLayer.Contents = data[:length]
+	r.BaseLayer.Payload = data[length:]
+	return nil
+}
+
+type GetFRULEDStateCmd struct {
+	Req GetFRULEDStateReq
+	Rsp GetFRULEDStateRsp
+}
+
+// Name returns "Get FRU LED State".
+func (*GetFRULEDStateCmd) Name() string {
+	return "Get FRU LED State"
+}
+
+func (*GetFRULEDStateCmd) Operation() *ipmi.Operation {
+	return &operationGetFRULEDStateReq
+}
+
+func (c *GetFRULEDStateCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetFRULEDStateCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,112 @@
+package picmg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetFRULEDStateReqSerializeTo(t *testing.T) {
+	layer := &GetFRULEDStateReq{
+		FRUDeviceID: 1,
+		LED:         LEDID2,
+	}
+	want := []byte{0x01, 0x02}
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %v = error %v, want %v", layer, err, want)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
+
+func TestGetFRULEDStateRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetFRULEDStateRsp // nil if error
+	}{
+		{
+			[]byte{0x01, 0xff, 0x00},
+			nil,
+		},
+		{
+			// override enabled, but override state missing
+			[]byte{0x03, 0xff, 0x00, 0x01},
+			nil,
+		},
+		{
+			[]byte{0x01, 0xff, 0x00, 0x01},
+			&GetFRULEDStateRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0xff, 0x00, 0x01},
+					Payload:  []byte{},
+				},
+				HasLocalControl: true,
+				LocalControl: LEDState{
+					Function: LEDFunctionOn,
+					Color:    LEDColorBlue,
+				},
+			},
+		},
+		{
+			[]byte{0x03, 0x00, 0x00, 0x02, 0x64, 0x32, 0x04},
+			&GetFRULEDStateRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x03, 0x00, 0x00, 0x02, 0x64, 0x32, 0x04},
+					Payload:  []byte{},
+				},
+				HasLocalControl: true,
+				OverrideEnabled: true,
+				LocalControl: LEDState{
+					Function: LEDFunctionOff,
+					Color:    LEDColorRed,
+				},
+				Override: LEDState{
+					Function:   0x64,
+					OnDuration: 500 * time.Millisecond,
+					Color:      LEDColorAmber,
+				},
+			},
+		},
+		{
+			[]byte{0x04, 0x00, 0x00, 0x01, 0xff, 0x00, 0x01, 0x1e},
+			&GetFRULEDStateRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x04, 0x00, 0x00, 0x01, 0xff, 0x00, 0x01, 0x1e},
+					Payload:  []byte{},
+				},
+				LampTestEnabled: true,
+				LocalControl: LEDState{
+					Function: LEDFunctionOff,
+					Color:    LEDColorBlue,
+				},
+				Override: LEDState{
+					Function: LEDFunctionOn,
+					Color:    LEDColorBlue,
+				},
+				LampTestDuration: 3 * time.Second,
+			},
+		},
+	}
+	layer := &GetFRULEDStateRsp{}
+	for _, test := range tests {
+		err := layer.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("decode %v succeeded with %v, wanted error", test.in,
+				layer)
+		case err != nil && test.want != nil:
+			t.Errorf("decode %v failed with %v, wanted %v", test.in, err,
+				test.want)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, layer); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, layer, test.want, diff)
+			}
+		}
+	}
+}
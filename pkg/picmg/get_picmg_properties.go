@@ -0,0 +1,85 @@
+package picmg
+
+import (
+	"fmt"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPICMGPropertiesRsp represents the response to a Get PICMG Properties
+// command, which is the conventional way to determine whether an IPM
+// controller implements the PICMG extensions.
+type GetPICMGPropertiesRsp struct {
+	layers.BaseLayer
+
+	// MajorVersion is the major version of the PICMG extensions implemented by
+	// the controller, e.g. 2 for AdvancedTCA.
+	MajorVersion uint8
+
+	// MinorVersion is the minor version of the PICMG extensions implemented by
+	// the controller.
+	MinorVersion uint8
+
+	// MaxFRUDeviceID is the highest FRU device ID managed by the controller.
+	MaxFRUDeviceID uint8
+
+	// IPMCFRUDeviceID is the FRU device ID of the controller itself, which is
+	// usually 0.
+	IPMCFRUDeviceID uint8
+}
+
+func (*GetPICMGPropertiesRsp) LayerType() gopacket.LayerType {
+	return layerTypeGetPICMGPropertiesRsp
+}
+
+func (r *GetPICMGPropertiesRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetPICMGPropertiesRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetPICMGPropertiesRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 3 {
+		df.SetTruncated()
+		return fmt.Errorf("Get PICMG Properties response must be 3 bytes, "+
+			"got %v", len(data))
+	}
+	r.BaseLayer.Contents = data[:3]
+	r.BaseLayer.Payload = data[3:]
+	r.MajorVersion = data[0] & 0xf
+	r.MinorVersion = data[0] >> 4
+	r.MaxFRUDeviceID = data[1]
+	r.IPMCFRUDeviceID = data[2]
+	return nil
+}
+
+// Version returns the PICMG extension version in major.minor form, e.g. 2.2.
+func (r *GetPICMGPropertiesRsp) Version() string {
+	return fmt.Sprintf("%v.%v", r.MajorVersion, r.MinorVersion)
+}
+
+type GetPICMGPropertiesCmd struct {
+	Rsp GetPICMGPropertiesRsp
+}
+
+// Name returns "Get PICMG Properties".
+func (*GetPICMGPropertiesCmd) Name() string {
+	return "Get PICMG Properties"
+}
+
+func (*GetPICMGPropertiesCmd) Operation() *ipmi.Operation {
+	return &operationGetPICMGPropertiesReq
+}
+
+func (*GetPICMGPropertiesCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetPICMGPropertiesCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,72 @@
+package picmg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetPICMGPropertiesRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetPICMGPropertiesRsp // nil if error
+	}{
+		{
+			[]byte{0x22, 0x01},
+			nil,
+		},
+		{
+			[]byte{0x22, 0x01, 0x00},
+			&GetPICMGPropertiesRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x22, 0x01, 0x00},
+					Payload:  []byte{},
+				},
+				MajorVersion:   2,
+				MinorVersion:   2,
+				MaxFRUDeviceID: 1,
+			},
+		},
+		{
+			[]byte{0x35, 0x04, 0x02},
+			&GetPICMGPropertiesRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x35, 0x04, 0x02},
+					Payload:  []byte{},
+				},
+				MajorVersion:    5,
+				MinorVersion:    3,
+				MaxFRUDeviceID:  4,
+				IPMCFRUDeviceID: 2,
+			},
+		},
+	}
+	layer := &GetPICMGPropertiesRsp{}
+	for _, test := range tests {
+		err := layer.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("decode %v succeeded with %v, wanted error", test.in,
+				layer)
+		case err != nil && test.want != nil:
+			t.Errorf("decode %v failed with %v, wanted %v", test.in, err,
+				test.want)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, layer); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, layer, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestGetPICMGPropertiesRspVersion(t *testing.T) {
+	rsp := &GetPICMGPropertiesRsp{
+		MajorVersion: 2,
+		MinorVersion: 2,
+	}
+	if got := rsp.Version(); got != "2.2" {
+		t.Errorf("Version() = %v, want 2.2", got)
+	}
+}
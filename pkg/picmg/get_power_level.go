@@ -0,0 +1,128 @@
+package picmg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPowerLevelReq implements the Get Power Level command, which returns the
+// power a FRU draws at each of its power levels, and the level it is
+// currently at.
+type GetPowerLevelReq struct {
+	layers.BaseLayer
+
+	// FRUDeviceID identifies the FRU to query.
+	FRUDeviceID uint8
+
+	// Type is the set of power draw levels to retrieve.
+	Type PowerType
+}
+
+func (*GetPowerLevelReq) LayerType() gopacket.LayerType {
+	return layerTypeGetPowerLevelReq
+}
+
+func (r *GetPowerLevelReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.FRUDeviceID
+	bytes[1] = uint8(r.Type)
+	return nil
+}
+
+// GetPowerLevelRsp represents the response to a Get Power Level command.
+type GetPowerLevelRsp struct {
+	layers.BaseLayer
+
+	// DynamicReconfiguration indicates whether the FRU supports changing its
+	// power level while powered.
+	DynamicReconfiguration bool
+
+	// Level is the FRU's current power level for steady state types, or the
+	// desired level for the desired types. 0 means the FRU is off.
+	Level uint8
+
+	// StabilisationDelay is how long the FRU takes to reach stable power
+	// after a level change, with a resolution of 100ms.
+	StabilisationDelay time.Duration
+
+	// Levels contains the power drawn at each level in watts, starting with
+	// level 1. There are between 1 and 20 levels.
+	Levels []float64
+}
+
+func (*GetPowerLevelRsp) LayerType() gopacket.LayerType {
+	return layerTypeGetPowerLevelRsp
+}
+
+func (r *GetPowerLevelRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetPowerLevelRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetPowerLevelRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("Get Power Level response must be at least 4 "+
+			"bytes, got %v", len(data))
+	}
+	levels := len(data) - 3
+	if levels > 20 {
+		levels = 20
+	}
+
+	r.DynamicReconfiguration = data[0]&(1<<7) != 0
+	r.Level = data[0] & 0x1f
+	r.StabilisationDelay = time.Duration(data[1]) * 100 * time.Millisecond
+
+	// the multiplier is in tenths of a watt
+	multiplier := float64(data[2]) / 10
+	r.Levels = make([]float64, levels)
+	for i := range r.Levels {
+		r.Levels[i] = float64(data[3+i]) * multiplier
+	}
+	r.BaseLayer.Contents = data[:3+levels]
+	r.BaseLayer.Payload = data[3+levels:]
+	return nil
+}
+
+// Watts returns the power drawn at the current level, or 0 if the FRU is off
+// or the level is out of range.
+func (r *GetPowerLevelRsp) Watts() float64 {
+	if r.Level == 0 || int(r.Level) > len(r.Levels) {
+		return 0
+	}
+	return r.Levels[r.Level-1]
+}
+
+type GetPowerLevelCmd struct {
+	Req GetPowerLevelReq
+	Rsp GetPowerLevelRsp
+}
+
+// Name returns "Get Power Level".
+func (*GetPowerLevelCmd) Name() string {
+	return "Get Power Level"
+}
+
+func (*GetPowerLevelCmd) Operation() *ipmi.Operation {
+	return &operationGetPowerLevelReq
+}
+
+func (c *GetPowerLevelCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetPowerLevelCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,101 @@
+package picmg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetPowerLevelReqSerializeTo(t *testing.T) {
+	layer := &GetPowerLevelReq{
+		FRUDeviceID: 1,
+		Type:        PowerTypeDesiredEarly,
+	}
+	want := []byte{0x01, 0x03}
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %v = error %v, want %v", layer, err, want)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
+
+func TestGetPowerLevelRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetPowerLevelRsp // nil if error
+	}{
+		{
+			[]byte{0x01, 0x00, 0x0a},
+			nil,
+		},
+		{
+			[]byte{0x01, 0x0a, 0x0a, 0x28},
+			&GetPowerLevelRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x0a, 0x0a, 0x28},
+					Payload:  []byte{},
+				},
+				Level:              1,
+				StabilisationDelay: time.Second,
+				Levels:             []float64{40},
+			},
+		},
+		{
+			[]byte{0x82, 0x05, 0x14, 0x0a, 0x14, 0x1e},
+			&GetPowerLevelRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x82, 0x05, 0x14, 0x0a, 0x14, 0x1e},
+					Payload:  []byte{},
+				},
+				DynamicReconfiguration: true,
+				Level:                  2,
+				StabilisationDelay:     500 * time.Millisecond,
+				Levels:                 []float64{20, 40, 60},
+			},
+		},
+	}
+	layer := &GetPowerLevelRsp{}
+	for _, test := range tests {
+		err := layer.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("decode %v succeeded with %v, wanted error", test.in,
+				layer)
+		case err != nil && test.want != nil:
+			t.Errorf("decode %v failed with %v, wanted %v", test.in, err,
+				test.want)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, layer); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, layer, test.want, diff)
+			}
+		}
+	}
+}
+
+func TestGetPowerLevelRspWatts(t *testing.T) {
+	tests := []struct {
+		level uint8
+		want  float64
+	}{
+		{0, 0},
+		{1, 20},
+		{3, 60},
+		{4, 0},
+	}
+	for _, test := range tests {
+		rsp := &GetPowerLevelRsp{
+			Level:  test.level,
+			Levels: []float64{20, 40, 60},
+		}
+		if got := rsp.Watts(); got != test.want {
+			t.Errorf("Watts() at level %v = %v, want %v", test.level, got,
+				test.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package picmg
+
+import (
+	"github.com/kuiwang02/bmc/pkg/layerexts"
+
+	"github.com/google/gopacket"
+)
+
+var (
+	layerTypeGetPICMGPropertiesRsp = gopacket.RegisterLayerType(
+		2100,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PICMG Properties Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPICMGPropertiesRsp{}
+			}),
+		},
+	)
+	layerTypeFRUControlReq = gopacket.RegisterLayerType(
+		2101,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Control Request",
+		},
+	)
+	layerTypeSetFRULEDStateReq = gopacket.RegisterLayerType(
+		2102,
+		gopacket.LayerTypeMetadata{
+			Name: "Set FRU LED State Request",
+		},
+	)
+	layerTypeGetFRULEDStateReq = gopacket.RegisterLayerType(
+		2103,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU LED State Request",
+		},
+	)
+	layerTypeGetFRULEDStateRsp = gopacket.RegisterLayerType(
+		2104,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU LED State Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetFRULEDStateRsp{}
+			}),
+		},
+	)
+	layerTypeGetPowerLevelReq = gopacket.RegisterLayerType(
+		2105,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Power Level Request",
+		},
+	)
+	layerTypeGetPowerLevelRsp = gopacket.RegisterLayerType(
+		2106,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Power Level Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPowerLevelRsp{}
+			}),
+		},
+	)
+)
@@ -0,0 +1,188 @@
+package picmg
+
+import (
+	"fmt"
+	"time"
+)
+
+// LEDID identifies an LED on a FRU. Every FRU has a blue hot swap LED, and
+// LEDs 1 to 3 are optionally the red/amber out of service LED and two
+// application-specific LEDs; higher IDs are OEM-defined.
+type LEDID uint8
+
+const (
+	LEDIDBlue LEDID = 0x00
+	LEDID1    LEDID = 0x01
+	LEDID2    LEDID = 0x02
+	LEDID3    LEDID = 0x03
+
+	// LEDIDAll addresses every LED on the FRU. It is only valid in a Set FRU
+	// LED State request.
+	LEDIDAll LEDID = 0xff
+)
+
+// LEDFunction describes what an LED is doing. Values between
+// LEDFunctionBlinkMin and LEDFunctionBlinkMax indicate the LED is blinking,
+// and give its off-duration in tens of milliseconds.
+type LEDFunction uint8
+
+const (
+	LEDFunctionOff      LEDFunction = 0x00
+	LEDFunctionBlinkMin LEDFunction = 0x01
+	LEDFunctionBlinkMax LEDFunction = 0xfa
+
+	// LEDFunctionLampTest runs a lamp test for the on-duration, after which
+	// the LED returns to its previous state. It is only valid in a Set FRU
+	// LED State request.
+	LEDFunctionLampTest LEDFunction = 0xfb
+
+	// LEDFunctionRestoreLocalControl returns the LED to the control of the
+	// FRU. It is only valid in a Set FRU LED State request.
+	LEDFunctionRestoreLocalControl LEDFunction = 0xfc
+
+	LEDFunctionOn LEDFunction = 0xff
+)
+
+// LEDFunctionBlink returns the function for an LED blinking with the given
+// off-duration, which is rounded down to tens of milliseconds and clamped to
+// the supported range.
+func LEDFunctionBlink(off time.Duration) LEDFunction {
+	tens := off / (10 * time.Millisecond)
+	switch {
+	case tens < time.Duration(LEDFunctionBlinkMin):
+		return LEDFunctionBlinkMin
+	case tens > time.Duration(LEDFunctionBlinkMax):
+		return LEDFunctionBlinkMax
+	default:
+		return LEDFunction(tens)
+	}
+}
+
+// IsBlink returns whether the function indicates the LED is blinking.
+func (f LEDFunction) IsBlink() bool {
+	return f >= LEDFunctionBlinkMin && f <= LEDFunctionBlinkMax
+}
+
+// OffDuration returns how long a blinking LED is off for each cycle. It
+// returns 0 if the LED is not blinking.
+func (f LEDFunction) OffDuration() time.Duration {
+	if !f.IsBlink() {
+		return 0
+	}
+	return time.Duration(f) * 10 * time.Millisecond
+}
+
+// Description returns a human-friendly name for the function.
+func (f LEDFunction) Description() string {
+	switch {
+	case f == LEDFunctionOff:
+		return "Off"
+	case f.IsBlink():
+		return "Blink"
+	case f == LEDFunctionLampTest:
+		return "Lamp Test"
+	case f == LEDFunctionRestoreLocalControl:
+		return "Restore Local Control"
+	case f == LEDFunctionOn:
+		return "On"
+	default:
+		return "Unknown"
+	}
+}
+
+func (f LEDFunction) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(f), f.Description())
+}
+
+// LEDColor is the colour of an LED. Multi-colour LEDs can be set to any of
+// the colours they support.
+type LEDColor uint8
+
+const (
+	LEDColorBlue   LEDColor = 0x1
+	LEDColorRed    LEDColor = 0x2
+	LEDColorGreen  LEDColor = 0x3
+	LEDColorAmber  LEDColor = 0x4
+	LEDColorOrange LEDColor = 0x5
+	LEDColorWhite  LEDColor = 0x6
+
+	// LEDColorUnchanged leaves the colour of the LED as it is. It is only
+	// valid in a Set FRU LED State request.
+	LEDColorUnchanged LEDColor = 0xe
+
+	// LEDColorDefault sets the LED to its default colour. It is only valid in
+	// a Set FRU LED State request.
+	LEDColorDefault LEDColor = 0xf
+)
+
+// Description returns a human-friendly name for the colour.
+func (c LEDColor) Description() string {
+	switch c {
+	case LEDColorBlue:
+		return "Blue"
+	case LEDColorRed:
+		return "Red"
+	case LEDColorGreen:
+		return "Green"
+	case LEDColorAmber:
+		return "Amber"
+	case LEDColorOrange:
+		return "Orange"
+	case LEDColorWhite:
+		return "White"
+	case LEDColorUnchanged:
+		return "Do Not Change"
+	case LEDColorDefault:
+		return "Default"
+	default:
+		return "Unknown"
+	}
+}
+
+func (c LEDColor) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(c), c.Description())
+}
+
+// LEDState is the function, on-duration and colour of an LED.
+type LEDState struct {
+	Function LEDFunction
+
+	// OnDuration is how long a blinking LED is on for each cycle, with a
+	// resolution of 10ms, or the duration of a lamp test, with a resolution
+	// of 100ms and a maximum of 12.7s. It is ignored for other functions.
+	OnDuration time.Duration
+
+	Color LEDColor
+}
+
+// onDurationUnit returns the resolution of the on-duration field for the
+// function.
+func (s *LEDState) onDurationUnit() time.Duration {
+	if s.Function == LEDFunctionLampTest {
+		return 100 * time.Millisecond
+	}
+	return 10 * time.Millisecond
+}
+
+// serializeTo encodes the state into 3 bytes.
+func (s *LEDState) serializeTo(b []byte) {
+	b[0] = uint8(s.Function)
+	b[1] = 0
+	switch {
+	case s.Function.IsBlink():
+		b[1] = uint8(s.OnDuration / s.onDurationUnit())
+	case s.Function == LEDFunctionLampTest:
+		b[1] = uint8(s.OnDuration/s.onDurationUnit()) & 0x7f
+	}
+	b[2] = uint8(s.Color) & 0xf
+}
+
+// decodeFromBytes decodes the state from 3 bytes.
+func (s *LEDState) decodeFromBytes(b []byte) {
+	s.Function = LEDFunction(b[0])
+	s.OnDuration = 0
+	if s.Function.IsBlink() {
+		s.OnDuration = time.Duration(b[1]) * s.onDurationUnit()
+	}
+	s.Color = LEDColor(b[2] & 0xf)
+}
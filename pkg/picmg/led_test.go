@@ -0,0 +1,43 @@
+package picmg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLEDFunctionBlink(t *testing.T) {
+	tests := []struct {
+		off  time.Duration
+		want LEDFunction
+	}{
+		{0, LEDFunctionBlinkMin},
+		{10 * time.Millisecond, 0x01},
+		{255 * time.Millisecond, 0x19},
+		{2500 * time.Millisecond, LEDFunctionBlinkMax},
+		{time.Minute, LEDFunctionBlinkMax},
+	}
+	for _, test := range tests {
+		if got := LEDFunctionBlink(test.off); got != test.want {
+			t.Errorf("LEDFunctionBlink(%v) = %v, want %v", test.off, got,
+				test.want)
+		}
+	}
+}
+
+func TestLEDFunctionOffDuration(t *testing.T) {
+	tests := []struct {
+		in   LEDFunction
+		want time.Duration
+	}{
+		{LEDFunctionOff, 0},
+		{0x32, 500 * time.Millisecond},
+		{LEDFunctionBlinkMax, 2500 * time.Millisecond},
+		{LEDFunctionLampTest, 0},
+		{LEDFunctionOn, 0},
+	}
+	for _, test := range tests {
+		if got := test.in.OffDuration(); got != test.want {
+			t.Errorf("%v.OffDuration() = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
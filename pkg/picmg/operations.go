@@ -0,0 +1,63 @@
+package picmg
+
+import (
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+var (
+	operationGetPICMGPropertiesReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodePICMG,
+		Command:  0x00,
+	}
+	operationFRUControlReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodePICMG,
+		Command:  0x04,
+	}
+	operationSetFRULEDStateReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodePICMG,
+		Command:  0x07,
+	}
+	operationGetFRULEDStateReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodePICMG,
+		Command:  0x08,
+	}
+	operationGetPowerLevelReq = ipmi.Operation{
+		Function: ipmi.NetworkFunctionGroupReq,
+		Body:     ipmi.BodyCodePICMG,
+		Command:  0x12,
+	}
+)
+
+func init() {
+	ipmi.RegisterPrivilegeLevel(operationGetPICMGPropertiesReq,
+		ipmi.PrivilegeLevelUser)
+	ipmi.RegisterPrivilegeLevel(operationFRUControlReq,
+		ipmi.PrivilegeLevelOperator)
+	ipmi.RegisterPrivilegeLevel(operationSetFRULEDStateReq,
+		ipmi.PrivilegeLevelOperator)
+	ipmi.RegisterPrivilegeLevel(operationGetFRULEDStateReq,
+		ipmi.PrivilegeLevelUser)
+	ipmi.RegisterPrivilegeLevel(operationGetPowerLevelReq,
+		ipmi.PrivilegeLevelUser)
+
+	ipmi.RegisterResponseLength(operationGetPICMGPropertiesReq,
+		ipmi.ResponseLength{Min: 3})
+	ipmi.RegisterResponseLength(operationGetFRULEDStateReq,
+		ipmi.ResponseLength{Min: 4})
+	ipmi.RegisterResponseLength(operationGetPowerLevelReq,
+		ipmi.ResponseLength{Min: 4})
+
+	// FRU Control can reset or power cycle a FRU, so must not be repeated if
+	// the shelf manager may have acted on it
+	ipmi.RegisterRetryPolicy(operationFRUControlReq, ipmi.RetryPolicy{
+		ipmi.CompletionCodeTimeout: false,
+	})
+	ipmi.RegisterOperationEffect(operationFRUControlReq,
+		ipmi.OperationEffectDestructive)
+	ipmi.RegisterOperationEffect(operationSetFRULEDStateReq,
+		ipmi.OperationEffectStateChanging)
+}
@@ -0,0 +1,42 @@
+package picmg
+
+import (
+	"fmt"
+)
+
+// PowerType selects which set of power draw levels a Get Power Level command
+// returns. Steady state levels apply once a FRU has finished powering up;
+// early levels apply for the first few seconds after power is enabled.
+type PowerType uint8
+
+const (
+	// PowerTypeSteadyState requests the power the FRU draws at each level.
+	PowerTypeSteadyState PowerType = 0x00
+
+	// PowerTypeDesiredSteadyState requests the power the FRU would like to
+	// draw at each level.
+	PowerTypeDesiredSteadyState PowerType = 0x01
+
+	PowerTypeEarly        PowerType = 0x02
+	PowerTypeDesiredEarly PowerType = 0x03
+)
+
+// Description returns a human-friendly name for the type.
+func (p PowerType) Description() string {
+	switch p {
+	case PowerTypeSteadyState:
+		return "Steady State Power Draw Levels"
+	case PowerTypeDesiredSteadyState:
+		return "Desired Steady State Draw Levels"
+	case PowerTypeEarly:
+		return "Early Power Draw Levels"
+	case PowerTypeDesiredEarly:
+		return "Desired Early Levels"
+	default:
+		return "Unknown"
+	}
+}
+
+func (p PowerType) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
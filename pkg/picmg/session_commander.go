@@ -0,0 +1,65 @@
+package picmg
+
+import (
+	"context"
+
+	"github.com/kuiwang02/bmc"
+)
+
+type sessionCommander struct {
+	bmc.Session
+}
+
+func (s sessionCommander) GetPICMGProperties(ctx context.Context) (*GetPICMGPropertiesRsp, error) {
+	cmd := &GetPICMGPropertiesCmd{}
+	if err := bmc.ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func (s sessionCommander) FRUControl(ctx context.Context, r *FRUControlReq) error {
+	cmd := &FRUControlCmd{
+		Req: *r,
+	}
+	return bmc.ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+func (s sessionCommander) GetFRULEDState(ctx context.Context, r *GetFRULEDStateReq) (*GetFRULEDStateRsp, error) {
+	cmd := &GetFRULEDStateCmd{
+		Req: *r,
+	}
+	if err := bmc.ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func (s sessionCommander) SetFRULEDState(ctx context.Context, r *SetFRULEDStateReq) error {
+	cmd := &SetFRULEDStateCmd{
+		Req: *r,
+	}
+	return bmc.ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+func (s sessionCommander) GetPowerLevel(ctx context.Context, r *GetPowerLevelReq) (*GetPowerLevelRsp, error) {
+	cmd := &GetPowerLevelCmd{
+		Req: *r,
+	}
+	if err := bmc.ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+// NewSessionCommander wraps a session-based connection in a context that
+// provides high-level access to PICMG commands. These are only implemented by
+// AdvancedTCA and AdvancedMC controllers; other BMCs will return an invalid
+// command completion code. When sending repeated commands, it is recommended
+// to use the SendCommand() method on the connection directly to reduce the
+// number of allocations.
+func NewSessionCommander(s bmc.Session) SessionCommands {
+	return &sessionCommander{
+		Session: s,
+	}
+}
@@ -0,0 +1,18 @@
+package picmg
+
+import (
+	"context"
+)
+
+// SessionCommands represents the high-level API for PICMG commands that can
+// be executed within a session.
+type SessionCommands interface {
+	GetPICMGProperties(context.Context) (*GetPICMGPropertiesRsp, error)
+
+	FRUControl(context.Context, *FRUControlReq) error
+
+	GetFRULEDState(context.Context, *GetFRULEDStateReq) (*GetFRULEDStateRsp, error)
+	SetFRULEDState(context.Context, *SetFRULEDStateReq) error
+
+	GetPowerLevel(context.Context, *GetPowerLevelReq) (*GetPowerLevelRsp, error)
+}
@@ -0,0 +1,61 @@
+package picmg
+
+import (
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetFRULEDStateReq implements the Set FRU LED State command, which overrides
+// the local control state of an LED, e.g. to identify a board to an operator
+// in a datacenter. The override persists until LEDFunctionRestoreLocalControl
+// is set. The response contains no data beyond the PICMG identifier.
+type SetFRULEDStateReq struct {
+	layers.BaseLayer
+
+	// FRUDeviceID identifies the FRU whose LED to set.
+	FRUDeviceID uint8
+
+	// LED is the LED to set, or LEDIDAll to set all LEDs on the FRU.
+	LED LEDID
+
+	// State is the desired state of the LED.
+	State LEDState
+}
+
+func (*SetFRULEDStateReq) LayerType() gopacket.LayerType {
+	return layerTypeSetFRULEDStateReq
+}
+
+func (r *SetFRULEDStateReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(5)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.FRUDeviceID
+	bytes[1] = uint8(r.LED)
+	r.State.serializeTo(bytes[2:5])
+	return nil
+}
+
+type SetFRULEDStateCmd struct {
+	Req SetFRULEDStateReq
+}
+
+// Name returns "Set FRU LED State".
+func (*SetFRULEDStateCmd) Name() string {
+	return "Set FRU LED State"
+}
+
+func (*SetFRULEDStateCmd) Operation() *ipmi.Operation {
+	return &operationSetFRULEDStateReq
+}
+
+func (c *SetFRULEDStateCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetFRULEDStateCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
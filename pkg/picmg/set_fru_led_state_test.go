@@ -0,0 +1,73 @@
+package picmg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetFRULEDStateReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		in   *SetFRULEDStateReq
+		want []byte
+	}{
+		{
+			&SetFRULEDStateReq{
+				LED: LEDIDBlue,
+				State: LEDState{
+					Function:   LEDFunctionOn,
+					OnDuration: time.Second, // should be ignored
+					Color:      LEDColorDefault,
+				},
+			},
+			[]byte{0x00, 0x00, 0xff, 0x00, 0x0f},
+		},
+		{
+			&SetFRULEDStateReq{
+				FRUDeviceID: 2,
+				LED:         LEDID1,
+				State: LEDState{
+					Function:   LEDFunctionBlink(500 * time.Millisecond),
+					OnDuration: 250 * time.Millisecond,
+					Color:      LEDColorAmber,
+				},
+			},
+			[]byte{0x02, 0x01, 0x32, 0x19, 0x04},
+		},
+		{
+			&SetFRULEDStateReq{
+				LED: LEDIDAll,
+				State: LEDState{
+					Function:   LEDFunctionLampTest,
+					OnDuration: 5 * time.Second,
+					Color:      LEDColorUnchanged,
+				},
+			},
+			[]byte{0x00, 0xff, 0xfb, 0x32, 0x0e},
+		},
+		{
+			&SetFRULEDStateReq{
+				LED: LEDIDBlue,
+				State: LEDState{
+					Function: LEDFunctionRestoreLocalControl,
+					Color:    LEDColorUnchanged,
+				},
+			},
+			[]byte{0x00, 0x00, 0xfc, 0x00, 0x0e},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.in.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.in, err, test.want)
+			continue
+		}
+		got := sb.Bytes()
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
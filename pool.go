@@ -0,0 +1,374 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// defaultPoolIdleTimeout is how long a pooled session may go unused before
+	// it is closed if the pool's IdleTimeout is unset. BMCs typically close
+	// sessions after 60 seconds of inactivity.
+	defaultPoolIdleTimeout = time.Second * 30
+
+	// defaultPoolHealthCheckAfter is how long a pooled session may go unused
+	// before it is pinged prior to reuse if the pool's HealthCheckAfter is
+	// unset.
+	defaultPoolHealthCheckAfter = time.Second * 5
+
+	// poolCloseTimeout bounds closing a session that is no longer needed, as
+	// this happens outside of any caller's context.
+	poolCloseTimeout = time.Second * 5
+)
+
+var (
+	poolSessionsClosed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "sessions_closed_total",
+			Help: "The number of pooled sessions closed, by whether they " +
+				"were idle, had failed, or the pool was closed.",
+		},
+		[]string{"reason"},
+	)
+	poolHealthCheckFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "health_check_failures_total",
+			Help: "The number of idle pooled sessions discarded because " +
+				"they failed to respond to a ping before reuse.",
+		},
+	)
+)
+
+// ErrPoolClosed is returned by Pool.Do() once the pool has been closed.
+var ErrPoolClosed = errors.New("pool closed")
+
+// Pool maintains at most one session with each BMC, shared by all callers.
+// BMCs typically support only a handful of concurrent sessions, and take
+// seconds to establish each one, so a process operating on the same BMCs
+// repeatedly, e.g. a daemon serving requests on their behalf, should use a
+// Pool rather than establishing a session per operation. Sessions are
+// established on first use, and closed after being idle, or if an operation
+// fails in a way that leaves them in an unknown state. Sessions that have been
+// idle for a while are pinged before reuse, so one the BMC has dropped, e.g.
+// after a reset, is replaced rather than causing the caller's operation to
+// time out.
+type Pool struct {
+
+	// SessionOpts returns the options to use to establish a session with the
+	// BMC at addr, allowing credentials to vary between BMCs. It is required.
+	SessionOpts func(addr string) (*V2SessionOpts, error)
+
+	// DialOpts are passed to DialV2Context() for each BMC. May be nil.
+	DialOpts *DialOpts
+
+	// IdleTimeout is how long a session may go unused before it is closed,
+	// freeing its slot on the BMC. Defaults to 30 seconds.
+	IdleTimeout time.Duration
+
+	// HealthCheckAfter is how long a session may go unused before it is
+	// pinged to check it is still usable prior to being reused. If the ping
+	// fails, the session is discarded, and a new one established. Defaults to
+	// 5 seconds. If negative, sessions are never checked.
+	HealthCheckAfter time.Duration
+
+	mu     sync.Mutex
+	bmcs   map[string]*poolBMC
+	closed bool
+
+	// closing tracks sessions being closed in the background.
+	closing sync.WaitGroup
+}
+
+// poolBMC is the state of a single BMC in a pool.
+type poolBMC struct {
+
+	// sem is held while establishing a session, so concurrent callers wait for
+	// one session rather than establishing one each. It is a channel rather
+	// than a mutex so waiting respects the caller's context.
+	sem chan struct{}
+
+	// current is the session new callers use, or nil if one must be
+	// established. It is guarded by the pool's mutex.
+	current *pooledSession
+}
+
+// pooledSession is a session shared by callers of a pool.
+type pooledSession struct {
+	machine *V2SessionlessTransport
+	sess    *V2Session
+
+	// users is the number of calls to Do() currently using the session.
+	users int
+
+	// idle fires once the session has been unused for the idle timeout. It is
+	// nil while the session is in use.
+	idle *time.Timer
+
+	// lastUsed is when the last user of the session returned, or when it was
+	// established.
+	lastUsed time.Time
+}
+
+// Do calls f with a session for the BMC at addr, establishing one if necessary.
+// The session may be used concurrently by other callers, so must not be closed
+// by f; commands are serialised by the session. If f returns an error
+// suggesting the session is no longer usable, e.g. a timeout, the session is
+// discarded, and the next call establishes a new one. f is not retried, as it
+// may not be idempotent. The error returned is that of establishing the
+// session, or of f.
+func (p *Pool) Do(ctx context.Context, addr string, f func(context.Context, Session) error) error {
+	ps, err := p.acquire(ctx, addr)
+	if err != nil {
+		return err
+	}
+	err = f(ctx, ps.sess)
+	p.release(addr, ps, isSessionError(err))
+	return err
+}
+
+// Close closes all idle sessions, and causes sessions in use to be closed once
+// their callers return. It waits until all sessions have been closed, or the
+// context expires, in which case the context's error is returned. Subsequent
+// calls to Do() return ErrPoolClosed.
+func (p *Pool) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	for _, b := range p.bmcs {
+		if b.current == nil {
+			continue
+		}
+		if b.current.users == 0 {
+			p.closeSession(b.current, "pool_closed")
+		}
+		b.current = nil
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.closing.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire returns the current session for addr, establishing one if
+// necessary, and registers the caller as a user. An existing session that has
+// been idle for longer than the health check threshold is pinged first, and
+// discarded if that fails.
+func (p *Pool) acquire(ctx context.Context, addr string) (*pooledSession, error) {
+	for {
+		ps, check, err := p.acquireSession(ctx, addr)
+		if err != nil || !check {
+			return ps, err
+		}
+		err = ps.sess.Ping(ctx)
+		if err == nil {
+			return ps, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// the session may be fine; we ran out of time to find out
+			p.release(addr, ps, false)
+			return nil, ctxErr
+		}
+		poolHealthCheckFailures.Inc()
+		p.release(addr, ps, true)
+	}
+}
+
+// acquireSession is the body of acquire(), returning whether the session must
+// be health checked before use.
+func (p *Pool) acquireSession(ctx context.Context, addr string) (*pooledSession, bool, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, false, ErrPoolClosed
+	}
+	if p.bmcs == nil {
+		p.bmcs = map[string]*poolBMC{}
+	}
+	b, ok := p.bmcs[addr]
+	if !ok {
+		b = &poolBMC{
+			sem: make(chan struct{}, 1),
+		}
+		p.bmcs[addr] = b
+	}
+	if ps := b.current; ps != nil {
+		check := p.needsHealthCheck(ps)
+		p.use(ps)
+		p.mu.Unlock()
+		return ps, check, nil
+	}
+	p.mu.Unlock()
+
+	select {
+	case b.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+	defer func() { <-b.sem }()
+
+	// another caller may have established a session while we waited
+	p.mu.Lock()
+	if ps := b.current; ps != nil {
+		check := p.needsHealthCheck(ps)
+		p.use(ps)
+		p.mu.Unlock()
+		return ps, check, nil
+	}
+	p.mu.Unlock()
+
+	ps, err := p.establish(ctx, addr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		p.closeSession(ps, "pool_closed")
+		return nil, false, ErrPoolClosed
+	}
+	b.current = ps
+	p.use(ps)
+	return ps, false, nil
+}
+
+// establish dials the BMC at addr and establishes a session with it.
+func (p *Pool) establish(ctx context.Context, addr string) (*pooledSession, error) {
+	opts, err := p.SessionOpts(addr)
+	if err != nil {
+		return nil, err
+	}
+	machine, err := DialV2Context(ctx, addr, p.DialOpts)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := machine.NewV2Session(ctx, opts)
+	if err != nil {
+		machine.Close()
+		return nil, err
+	}
+	return &pooledSession{
+		machine:  machine,
+		sess:     sess,
+		lastUsed: time.Now(),
+	}, nil
+}
+
+// use registers a caller of a session. The pool's mutex must be held.
+func (p *Pool) use(ps *pooledSession) {
+	ps.users++
+	if ps.idle != nil {
+		ps.idle.Stop()
+		ps.idle = nil
+	}
+}
+
+// release deregisters a caller of a session, discarding the session if failed
+// is true. The session is closed if it is no longer current, and has no other
+// users, otherwise an idle timer is started once the last user returns.
+func (p *Pool) release(addr string, ps *pooledSession, failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ps.users--
+	b := p.bmcs[addr]
+	current := b.current == ps
+	if current && failed {
+		b.current = nil
+		current = false
+	}
+	if ps.users > 0 {
+		return
+	}
+	ps.lastUsed = time.Now()
+	if !current {
+		reason := "failed"
+		if p.closed {
+			reason = "pool_closed"
+		}
+		p.closeSession(ps, reason)
+		return
+	}
+	ps.idle = time.AfterFunc(p.idleTimeout(), func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// the session may have been used, or discarded, in the meantime
+		if b.current != ps || ps.users > 0 {
+			return
+		}
+		b.current = nil
+		p.closeSession(ps, "idle")
+	})
+}
+
+// closeSession closes a session that is no longer current and has no users, in
+// the background, so the pool's mutex is not held while waiting for the BMC.
+func (p *Pool) closeSession(ps *pooledSession, reason string) {
+	poolSessionsClosed.WithLabelValues(reason).Inc()
+	p.closing.Add(1)
+	go func() {
+		defer p.closing.Done()
+		ctx, cancel := context.WithTimeout(context.Background(),
+			poolCloseTimeout)
+		defer cancel()
+		// the BMC will eventually time out the session if this fails
+		_ = ps.sess.Close(ctx)
+		ps.machine.Close()
+	}()
+}
+
+// needsHealthCheck returns whether a session must be pinged before being
+// handed to a caller. Sessions in use by another caller are not checked, as
+// that caller would discover any problem. The pool's mutex must be held.
+func (p *Pool) needsHealthCheck(ps *pooledSession) bool {
+	after := p.HealthCheckAfter
+	switch {
+	case after < 0:
+		return false
+	case after == 0:
+		after = defaultPoolHealthCheckAfter
+	}
+	return ps.users == 0 && time.Since(ps.lastUsed) >= after
+}
+
+func (p *Pool) idleTimeout() time.Duration {
+	if p.IdleTimeout <= 0 {
+		return defaultPoolIdleTimeout
+	}
+	return p.IdleTimeout
+}
+
+// isSessionError returns whether an error returned by an operation using a
+// session suggests the session may no longer be usable. After a timeout, the
+// BMC may have expired the session, or still be processing a request, and some
+// BMCs tear their send buffer if sent another command, so it is safest to
+// start again. A session that has exhausted its sequence numbers must also be
+// replaced. Errors due to completion codes are not session errors, as the BMC
+// must have responded.
+func isSessionError(err error) bool {
+	netErr := net.Error(nil)
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, ErrTransportClosed) ||
+		errors.Is(err, ErrSequenceNumbersExhausted) ||
+		errors.As(err, &netErr)
+}
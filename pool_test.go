@@ -0,0 +1,194 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestIsSessionError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("received non-normal completion code: 0xc1"), false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("wrapped: %w", context.Canceled), true},
+		{ErrTransportClosed, true},
+		{ErrSequenceNumbersExhausted, true},
+		{&net.OpError{Op: "write", Err: errors.New("connection refused")}, true},
+	}
+	for _, test := range tests {
+		if got := isSessionError(test.err); got != test.want {
+			t.Errorf("isSessionError(%v) = %v, want %v", test.err, got,
+				test.want)
+		}
+	}
+}
+
+func TestPoolNeedsHealthCheck(t *testing.T) {
+	idle := &pooledSession{
+		lastUsed: time.Now().Add(-time.Minute),
+	}
+	recent := &pooledSession{
+		lastUsed: time.Now(),
+	}
+	inUse := &pooledSession{
+		users:    1,
+		lastUsed: time.Now().Add(-time.Minute),
+	}
+	tests := []struct {
+		name  string
+		after time.Duration
+		ps    *pooledSession
+		want  bool
+	}{
+		{"idle", 0, idle, true},
+		{"recently used", 0, recent, false},
+		{"in use", 0, inUse, false},
+		{"disabled", -1, idle, false},
+		{"custom threshold", time.Hour, idle, false},
+	}
+	for _, test := range tests {
+		p := &Pool{
+			HealthCheckAfter: test.after,
+		}
+		if got := p.needsHealthCheck(test.ps); got != test.want {
+			t.Errorf("%v: needsHealthCheck() = %v, want %v", test.name, got,
+				test.want)
+		}
+	}
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	simBMC, err := sim.New(&sim.Config{
+		Username: "admin",
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simBMC.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pool := &Pool{
+		SessionOpts: func(string) (*V2SessionOpts, error) {
+			return &V2SessionOpts{
+				SessionOpts: SessionOpts{
+					Username:          "admin",
+					Password:          []byte("hunter2"),
+					MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+				},
+			}, nil
+		},
+		DialOpts: &DialOpts{
+			CommandTimeout: time.Millisecond * 100,
+		},
+		HealthCheckAfter: time.Nanosecond,
+	}
+	defer pool.Close(ctx)
+	session := func() Session {
+		t.Helper()
+		got := Session(nil)
+		if err := pool.Do(ctx, simBMC.Addr(), func(ctx context.Context, s Session) error {
+			got = s
+			_, err := s.GetDeviceID(ctx)
+			return err
+		}); err != nil {
+			t.Fatalf("Do() failed: %v", err)
+		}
+		return got
+	}
+
+	first := session()
+	if second := session(); second != first {
+		t.Errorf("Do() discarded a healthy session")
+	}
+
+	// rather than the caller timing out, a new session is established
+	simBMC.DropSessions()
+	if session() == first {
+		t.Errorf("Do() reused a session the BMC no longer recognises")
+	}
+}
+
+func TestPool(t *testing.T) {
+	simBMC, err := sim.New(&sim.Config{
+		Username:  "admin",
+		Password:  "hunter2",
+		PoweredOn: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simBMC.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pool := &Pool{
+		SessionOpts: func(string) (*V2SessionOpts, error) {
+			return &V2SessionOpts{
+				SessionOpts: SessionOpts{
+					Username:          "admin",
+					Password:          []byte("hunter2"),
+					MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+				},
+			}, nil
+		},
+		IdleTimeout: time.Millisecond * 100,
+	}
+	session := func() Session {
+		t.Helper()
+		got := Session(nil)
+		if err := pool.Do(ctx, simBMC.Addr(), func(ctx context.Context, s Session) error {
+			got = s
+			_, err := s.GetChassisStatus(ctx)
+			return err
+		}); err != nil {
+			t.Fatalf("Do() failed: %v", err)
+		}
+		return got
+	}
+
+	first := session()
+	if second := session(); second != first {
+		t.Errorf("Do() established a second session rather than reusing the first")
+	}
+
+	// a timeout leaves the session in an unknown state, so it is discarded
+	err = pool.Do(ctx, simBMC.Addr(), func(context.Context, Session) error {
+		return context.DeadlineExceeded
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() = %v, want the error of the function", err)
+	}
+	afterTimeout := session()
+	if afterTimeout == first {
+		t.Errorf("Do() reused a session after a timeout")
+	}
+
+	time.Sleep(time.Millisecond * 300)
+	if session() == afterTimeout {
+		t.Errorf("Do() reused a session after the idle timeout")
+	}
+
+	if err := pool.Close(ctx); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	err = pool.Do(ctx, simBMC.Addr(), func(context.Context, Session) error {
+		return nil
+	})
+	if !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Do() after Close() = %v, want %v", err, ErrPoolClosed)
+	}
+}
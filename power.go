@@ -0,0 +1,243 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+var (
+	// ErrPowerStateNotReached is returned by EnsurePowerState() if the BMC
+	// accepted all commands sent, but the chassis did not reach the desired
+	// power state.
+	ErrPowerStateNotReached = errors.New("chassis did not reach the desired " +
+		"power state")
+)
+
+// PowerState is the power state of a chassis as far as IPMI is concerned:
+// either on, or off (S4/S5).
+type PowerState bool
+
+const (
+	PowerStateOff PowerState = false
+	PowerStateOn  PowerState = true
+)
+
+func (p PowerState) String() string {
+	if p {
+		return "on"
+	}
+	return "off"
+}
+
+// PowerOpts configures EnsurePowerState(). The zero value is valid, and
+// results in the defaults described for each field.
+type PowerOpts struct {
+
+	// SkipSoftOff causes powering off to use a hard power off immediately,
+	// rather than first asking the OS to shut down cleanly.
+	SkipSoftOff bool
+
+	// SoftOffGracePeriod is how long to wait after a soft power off for the
+	// OS to shut down, before falling back to a hard power off. Defaults to 2
+	// minutes.
+	SoftOffGracePeriod time.Duration
+
+	// PowerOnAttempts is the number of times to send a power on command
+	// before giving up. Some machines ignore power on requests made too soon
+	// after AC is restored or the chassis powers off. Defaults to 3.
+	PowerOnAttempts int
+
+	// ConfirmTimeout is how long to wait for the chassis to reach the desired
+	// state after a hard power off or power on command. Defaults to 30
+	// seconds.
+	ConfirmTimeout time.Duration
+
+	// PollInterval is how often to retrieve the chassis status while waiting
+	// for a state change. Defaults to 2 seconds.
+	PollInterval time.Duration
+}
+
+func (o *PowerOpts) softOffGracePeriod() time.Duration {
+	if o.SoftOffGracePeriod == 0 {
+		return time.Minute * 2
+	}
+	return o.SoftOffGracePeriod
+}
+
+func (o *PowerOpts) powerOnAttempts() int {
+	if o.PowerOnAttempts == 0 {
+		return 3
+	}
+	return o.PowerOnAttempts
+}
+
+func (o *PowerOpts) confirmTimeout() time.Duration {
+	if o.ConfirmTimeout == 0 {
+		return time.Second * 30
+	}
+	return o.ConfirmTimeout
+}
+
+func (o *PowerOpts) pollInterval() time.Duration {
+	if o.PollInterval == 0 {
+		return time.Second * 2
+	}
+	return o.PollInterval
+}
+
+// PowerTransition records a chassis control command sent by
+// EnsurePowerState(), and its outcome.
+type PowerTransition struct {
+
+	// Control is the command sent.
+	Control ipmi.ChassisControl
+
+	// Err is the error returned by the BMC in response to the command, if
+	// any. The chassis will not have changed state.
+	Err error
+
+	// Confirmed indicates the chassis was observed in the desired power state
+	// after the command.
+	Confirmed bool
+
+	// Elapsed is the time from sending the command to observing the desired
+	// power state, or giving up.
+	Elapsed time.Duration
+}
+
+func (t PowerTransition) String() string {
+	switch {
+	case t.Err != nil:
+		return fmt.Sprintf("%v: %v", t.Control.Description(), t.Err)
+	case t.Confirmed:
+		return fmt.Sprintf("%v: confirmed after %v", t.Control.Description(),
+			t.Elapsed)
+	default:
+		return fmt.Sprintf("%v: unconfirmed after %v",
+			t.Control.Description(), t.Elapsed)
+	}
+}
+
+// EnsurePowerState brings the chassis into the desired power state, if it is
+// not already in it, returning the commands sent. Powering off first tries a
+// soft power off, allowing the OS to shut down cleanly, falling back to a hard
+// power off if the OS does not do so within the grace period, or the BMC does
+// not support soft power off. Powering on is retried if the chassis does not
+// power on. The returned transitions are valid even if an error is returned. If
+// every command was accepted but the chassis did not reach the desired state,
+// the error wraps ErrPowerStateNotReached. The context should allow for the
+// grace period and timeouts in opts; if it expires, its error is returned.
+// opts may be nil to use the defaults.
+func EnsurePowerState(ctx context.Context, s Session, desired PowerState, opts *PowerOpts) ([]PowerTransition, error) {
+	if opts == nil {
+		opts = &PowerOpts{}
+	}
+	status, err := s.GetChassisStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if PowerState(status.PoweredOn) == desired {
+		return nil, nil
+	}
+
+	transitions := []PowerTransition{}
+	if desired == PowerStateOn {
+		for i := 0; i < opts.powerOnAttempts(); i++ {
+			transition, err := changePowerState(ctx, s,
+				ipmi.ChassisControlPowerOn, desired, opts.confirmTimeout(),
+				opts.pollInterval())
+			transitions = append(transitions, transition)
+			if err != nil {
+				return transitions, err
+			}
+			if transition.Err != nil {
+				return transitions, transition.Err
+			}
+			if transition.Confirmed {
+				return transitions, nil
+			}
+		}
+		return transitions, fmt.Errorf("%w after %v power on attempts",
+			ErrPowerStateNotReached, len(transitions))
+	}
+
+	if !opts.SkipSoftOff {
+		transition, err := changePowerState(ctx, s,
+			ipmi.ChassisControlSoftPowerOff, desired,
+			opts.softOffGracePeriod(), opts.pollInterval())
+		transitions = append(transitions, transition)
+		if err != nil {
+			return transitions, err
+		}
+		if transition.Confirmed {
+			return transitions, nil
+		}
+		// either unsupported, or the OS did not shut down in time
+	}
+	transition, err := changePowerState(ctx, s, ipmi.ChassisControlPowerOff,
+		desired, opts.confirmTimeout(), opts.pollInterval())
+	transitions = append(transitions, transition)
+	if err != nil {
+		return transitions, err
+	}
+	if transition.Err != nil {
+		return transitions, transition.Err
+	}
+	if !transition.Confirmed {
+		return transitions, fmt.Errorf("%w after hard power off",
+			ErrPowerStateNotReached)
+	}
+	return transitions, nil
+}
+
+// changePowerState sends a chassis control command, then polls the chassis
+// status until it reaches the desired state, or the timeout elapses. An error
+// is returned only if the context expires or the chassis status cannot be
+// retrieved; errors sending the command itself are recorded in the
+// transition.
+func changePowerState(
+	ctx context.Context,
+	s Session,
+	control ipmi.ChassisControl,
+	desired PowerState,
+	timeout, interval time.Duration,
+) (PowerTransition, error) {
+	transition := PowerTransition{
+		Control: control,
+	}
+	start := time.Now()
+	if err := s.ChassisControl(ctx, control); err != nil {
+		if ctx.Err() != nil {
+			return transition, ctx.Err()
+		}
+		transition.Err = err
+		return transition, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	deadline, _ := waitCtx.Deadline()
+	err := WaitFor(waitCtx, interval, func(ctx context.Context) (bool, error) {
+		status, err := s.GetChassisStatus(ctx)
+		if err != nil {
+			return false, err
+		}
+		return PowerState(status.PoweredOn) == desired, nil
+	})
+	transition.Elapsed = time.Since(start)
+	switch {
+	case err == nil:
+		transition.Confirmed = true
+	case ctx.Err() != nil:
+		return transition, ctx.Err()
+	case time.Now().Before(deadline):
+		// failed to get chassis status. We don't use waitCtx.Err(), as the
+		// socket deadline can be hit slightly before the context's timer
+		return transition, err
+	}
+	return transition, nil
+}
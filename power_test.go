@@ -0,0 +1,124 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+func TestEnsurePowerState(t *testing.T) {
+	opts := &PowerOpts{
+		SoftOffGracePeriod: time.Millisecond * 50,
+		ConfirmTimeout:     time.Millisecond * 50,
+		PollInterval:       time.Millisecond * 5,
+	}
+	tests := []struct {
+		name    string
+		config  sim.Config
+		desired PowerState
+		opts    *PowerOpts
+		want    []ipmi.ChassisControl // confirmed is implied for the last
+		wantErr error
+	}{
+		{
+			name:    "already on",
+			config:  sim.Config{PoweredOn: true},
+			desired: PowerStateOn,
+			opts:    opts,
+		},
+		{
+			name:    "soft off",
+			config:  sim.Config{PoweredOn: true},
+			desired: PowerStateOff,
+			opts:    opts,
+			want:    []ipmi.ChassisControl{ipmi.ChassisControlSoftPowerOff},
+		},
+		{
+			name: "soft off ignored",
+			config: sim.Config{
+				PoweredOn:          true,
+				IgnoreSoftPowerOff: true,
+			},
+			desired: PowerStateOff,
+			opts:    opts,
+			want: []ipmi.ChassisControl{
+				ipmi.ChassisControlSoftPowerOff,
+				ipmi.ChassisControlPowerOff,
+			},
+		},
+		{
+			name:    "skip soft off",
+			config:  sim.Config{PoweredOn: true},
+			desired: PowerStateOff,
+			opts: &PowerOpts{
+				SkipSoftOff:  true,
+				PollInterval: time.Millisecond * 5,
+			},
+			want: []ipmi.ChassisControl{ipmi.ChassisControlPowerOff},
+		},
+		{
+			name:    "power on retried",
+			config:  sim.Config{IgnoredPowerOns: 2},
+			desired: PowerStateOn,
+			opts:    opts,
+			want: []ipmi.ChassisControl{
+				ipmi.ChassisControlPowerOn,
+				ipmi.ChassisControlPowerOn,
+				ipmi.ChassisControlPowerOn,
+			},
+		},
+		{
+			name:    "power on failed",
+			config:  sim.Config{IgnoredPowerOns: 3},
+			desired: PowerStateOn,
+			opts:    opts,
+			want: []ipmi.ChassisControl{
+				ipmi.ChassisControlPowerOn,
+				ipmi.ChassisControlPowerOn,
+				ipmi.ChassisControlPowerOn,
+			},
+			wantErr: ErrPowerStateNotReached,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			simBMC, sess := newTestSession(t, &test.config)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			transitions, err := EnsurePowerState(ctx, sess, test.desired,
+				test.opts)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("EnsurePowerState() = %v, want %v", err, test.wantErr)
+			}
+			got := []ipmi.ChassisControl{}
+			for _, transition := range transitions {
+				got = append(got, transition.Control)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("EnsurePowerState() sent %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("EnsurePowerState() sent %v, want %v", got,
+						test.want)
+				}
+				confirmed := i == len(got)-1 && test.wantErr == nil
+				if transitions[i].Confirmed != confirmed {
+					t.Errorf("transition %v = %v, want confirmed: %v", i,
+						transitions[i], confirmed)
+				}
+			}
+
+			// each command was executed once
+			if received := simBMC.ChassisControls(); len(received) != len(got) {
+				t.Errorf("BMC received %v, want %v", received, got)
+			}
+		})
+	}
+}
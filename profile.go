@@ -0,0 +1,244 @@
+package bmc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// Profile captures how to talk to a particular BMC or model of BMC, so
+// settings discovered by trial and error, e.g. a longer establishment timeout
+// or a quirk, can be kept alongside its address rather than in code. It
+// contains no credentials. Fields left as their zero value use the library's
+// defaults. A Profile can be serialised as JSON with SaveProfile(), and read
+// back with LoadProfile(); durations are written as strings, e.g. "1.5s".
+type Profile struct {
+
+	// CipherSuite is the ID of the cipher suite to propose, as understood by
+	// ipmi.LookupCipherSuite(), e.g. 17. If 0, all secure algorithms supported
+	// by the library are proposed, and the BMC chooses.
+	CipherSuite uint8
+
+	// AllowLegacyAlgorithms is copied to V2SessionOpts. It must be set to use
+	// a cipher suite relying on MD5, e.g. 7.
+	AllowLegacyAlgorithms bool
+
+	// DialTimeout, CommandTimeout, EstablishmentTimeout and AddressStagger
+	// are copied to the corresponding fields of DialOpts.
+	DialTimeout          time.Duration
+	CommandTimeout       time.Duration
+	EstablishmentTimeout time.Duration
+	AddressStagger       time.Duration
+
+	// MaxPacketSize is copied to DialOpts. It only needs to be set for paths
+	// with an MTU below 1500 bytes beyond the local network.
+	MaxPacketSize int
+
+	// EstablishmentMaxElapsedTime is copied to SessionOpts, and bounds how
+	// long session establishment is retried if the BMC is temporarily out of
+	// resources. Retries of individual commands are governed by the
+	// ipmi.RetryPolicy of each operation, which is not per-target.
+	EstablishmentMaxElapsedTime time.Duration
+
+	// MaxPrivilegeLevel is the upper privilege limit for sessions.
+	MaxPrivilegeLevel ipmi.PrivilegeLevel
+
+	// PasswordCompatibility is copied to SessionOpts.
+	PasswordCompatibility PasswordCompatibility
+
+	// Quirks is the set of firmware bugs the BMC is known to exhibit.
+	Quirks Quirks
+}
+
+// profileJSON is the serialised form of a Profile. It differs only in
+// durations being strings, which are more readable than nanoseconds.
+type profileJSON struct {
+	CipherSuite                 uint8                 `json:"cipherSuite,omitempty"`
+	AllowLegacyAlgorithms       bool                  `json:"allowLegacyAlgorithms,omitempty"`
+	DialTimeout                 string                `json:"dialTimeout,omitempty"`
+	CommandTimeout              string                `json:"commandTimeout,omitempty"`
+	EstablishmentTimeout        string                `json:"establishmentTimeout,omitempty"`
+	AddressStagger              string                `json:"addressStagger,omitempty"`
+	MaxPacketSize               int                   `json:"maxPacketSize,omitempty"`
+	EstablishmentMaxElapsedTime string                `json:"establishmentMaxElapsedTime,omitempty"`
+	MaxPrivilegeLevel           ipmi.PrivilegeLevel   `json:"maxPrivilegeLevel,omitempty"`
+	PasswordCompatibility       PasswordCompatibility `json:"passwordCompatibility,omitempty"`
+	Quirks                      Quirks                `json:"quirks,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings.
+func (p *Profile) MarshalJSON() ([]byte, error) {
+	formatDuration := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return d.String()
+	}
+	return json.Marshal(&profileJSON{
+		CipherSuite:                 p.CipherSuite,
+		AllowLegacyAlgorithms:       p.AllowLegacyAlgorithms,
+		DialTimeout:                 formatDuration(p.DialTimeout),
+		CommandTimeout:              formatDuration(p.CommandTimeout),
+		EstablishmentTimeout:        formatDuration(p.EstablishmentTimeout),
+		AddressStagger:              formatDuration(p.AddressStagger),
+		MaxPacketSize:               p.MaxPacketSize,
+		EstablishmentMaxElapsedTime: formatDuration(p.EstablishmentMaxElapsedTime),
+		MaxPrivilegeLevel:           p.MaxPrivilegeLevel,
+		PasswordCompatibility:       p.PasswordCompatibility,
+		Quirks:                      p.Quirks,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, parsing durations with
+// time.ParseDuration(). Unknown fields are rejected, so a misspelt setting is
+// not silently ignored.
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	j := &profileJSON{}
+	if err := d.Decode(j); err != nil {
+		return err
+	}
+	durations := []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"dialTimeout", j.DialTimeout, &p.DialTimeout},
+		{"commandTimeout", j.CommandTimeout, &p.CommandTimeout},
+		{"establishmentTimeout", j.EstablishmentTimeout, &p.EstablishmentTimeout},
+		{"addressStagger", j.AddressStagger, &p.AddressStagger},
+		{"establishmentMaxElapsedTime", j.EstablishmentMaxElapsedTime, &p.EstablishmentMaxElapsedTime},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			*d.dst = 0
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("%v: %w", d.name, err)
+		}
+		*d.dst = parsed
+	}
+	p.CipherSuite = j.CipherSuite
+	p.AllowLegacyAlgorithms = j.AllowLegacyAlgorithms
+	p.MaxPacketSize = j.MaxPacketSize
+	p.MaxPrivilegeLevel = j.MaxPrivilegeLevel
+	p.PasswordCompatibility = j.PasswordCompatibility
+	p.Quirks = j.Quirks
+	return nil
+}
+
+// validate returns an error if the profile cannot be used to establish a
+// session.
+func (p *Profile) validate() error {
+	if p.CipherSuite != 0 {
+		suite, ok := ipmi.LookupCipherSuite(p.CipherSuite)
+		if !ok {
+			return fmt.Errorf("unsupported cipher suite %v", p.CipherSuite)
+		}
+		if suite.Authentication == ipmi.AuthenticationAlgorithmHMACMD5 &&
+			!p.AllowLegacyAlgorithms {
+			return fmt.Errorf("cipher suite %v uses MD5; set "+
+				"AllowLegacyAlgorithms to use it", p.CipherSuite)
+		}
+	}
+	if p.MaxPrivilegeLevel > ipmi.PrivilegeLevelOEM {
+		return fmt.Errorf("invalid max privilege level %v",
+			p.MaxPrivilegeLevel)
+	}
+	if p.MaxPacketSize < 0 {
+		return fmt.Errorf("max packet size cannot be negative, got %v",
+			p.MaxPacketSize)
+	}
+	if p.PasswordCompatibility > PasswordCompatibilityTruncate16 {
+		return fmt.Errorf("invalid password compatibility %v",
+			p.PasswordCompatibility)
+	}
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"dial timeout", p.DialTimeout},
+		{"command timeout", p.CommandTimeout},
+		{"establishment timeout", p.EstablishmentTimeout},
+		{"establishment max elapsed time", p.EstablishmentMaxElapsedTime},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("%v cannot be negative, got %v", d.name,
+				d.value)
+		}
+	}
+	return nil
+}
+
+// DialOpts returns options for DialV2Context() reflecting the profile.
+func (p *Profile) DialOpts() *DialOpts {
+	return &DialOpts{
+		Timeout:              p.DialTimeout,
+		CommandTimeout:       p.CommandTimeout,
+		EstablishmentTimeout: p.EstablishmentTimeout,
+		AddressStagger:       p.AddressStagger,
+		MaxPacketSize:        p.MaxPacketSize,
+	}
+}
+
+// V2SessionOpts returns options for NewV2Session() reflecting the profile,
+// for the provided credentials. An error is returned if the profile is
+// invalid, e.g. its cipher suite is not supported.
+func (p *Profile) V2SessionOpts(username string, password []byte) (*V2SessionOpts, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	opts := &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username:                    username,
+			Password:                    password,
+			MaxPrivilegeLevel:           p.MaxPrivilegeLevel,
+			PasswordCompatibility:       p.PasswordCompatibility,
+			EstablishmentMaxElapsedTime: p.EstablishmentMaxElapsedTime,
+		},
+		AllowLegacyAlgorithms: p.AllowLegacyAlgorithms,
+		Quirks:                p.Quirks,
+	}
+	if p.CipherSuite != 0 {
+		suite, _ := ipmi.LookupCipherSuite(p.CipherSuite)
+		opts.AuthenticationAlgorithms = []ipmi.AuthenticationAlgorithm{
+			suite.Authentication,
+		}
+		opts.IntegrityAlgorithms = []ipmi.IntegrityAlgorithm{suite.Integrity}
+		opts.ConfidentialityAlgorithms = []ipmi.ConfidentialityAlgorithm{
+			suite.Confidentiality,
+		}
+	}
+	return opts, nil
+}
+
+// SaveProfile writes p to w as indented JSON.
+func SaveProfile(w io.Writer, p *Profile) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(p)
+}
+
+// LoadProfile reads a profile written by SaveProfile(), returning an error if
+// it is invalid.
+func LoadProfile(r io.Reader) (*Profile, error) {
+	p := &Profile{}
+	if err := json.NewDecoder(r).Decode(p); err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,96 @@
+package bmc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	profile := &Profile{
+		CipherSuite:                 17,
+		CommandTimeout:              1500 * time.Millisecond,
+		EstablishmentTimeout:        5 * time.Second,
+		AddressStagger:              -1,
+		MaxPacketSize:               1400,
+		EstablishmentMaxElapsedTime: time.Minute,
+		MaxPrivilegeLevel:           ipmi.PrivilegeLevelOperator,
+		PasswordCompatibility:       PasswordCompatibilityTruncate20,
+		Quirks:                      QuirkRAKP2UsernamePadded | QuirkIgnoreRAKP4ICV,
+	}
+	buf := &bytes.Buffer{}
+	if err := SaveProfile(buf, profile); err != nil {
+		t.Fatalf("SaveProfile() = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"commandTimeout": "1.5s"`) {
+		t.Errorf("durations not written as strings: %v", buf.String())
+	}
+	loaded, err := LoadProfile(buf)
+	if err != nil {
+		t.Fatalf("LoadProfile() = %v", err)
+	}
+	if diff := cmp.Diff(profile, loaded); diff != "" {
+		t.Errorf("LoadProfile(SaveProfile()) mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestLoadProfileInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"unknown field", `{"cipherSuit": 3}`},
+		{"unsupported cipher suite", `{"cipherSuite": 4}`},
+		{"legacy cipher suite", `{"cipherSuite": 8}`},
+		{"invalid duration", `{"commandTimeout": "5"}`},
+		{"negative timeout", `{"commandTimeout": "-1s"}`},
+		{"negative max packet size", `{"maxPacketSize": -1}`},
+		{"invalid privilege level", `{"maxPrivilegeLevel": 6}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := LoadProfile(strings.NewReader(test.json)); err == nil {
+				t.Errorf("LoadProfile(%v) succeeded, wanted error", test.json)
+			}
+		})
+	}
+}
+
+func TestProfileV2SessionOpts(t *testing.T) {
+	profile := &Profile{
+		CipherSuite:           8,
+		AllowLegacyAlgorithms: true,
+		MaxPrivilegeLevel:     ipmi.PrivilegeLevelAdministrator,
+		Quirks:                QuirkIgnoreRAKP4ICV,
+	}
+	opts, err := profile.V2SessionOpts("admin", []byte("secret"))
+	if err != nil {
+		t.Fatalf("V2SessionOpts() = %v", err)
+	}
+	want := &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username:          "admin",
+			Password:          []byte("secret"),
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		},
+		AuthenticationAlgorithms: []ipmi.AuthenticationAlgorithm{
+			ipmi.AuthenticationAlgorithmHMACMD5,
+		},
+		IntegrityAlgorithms: []ipmi.IntegrityAlgorithm{
+			ipmi.IntegrityAlgorithmHMACMD5128,
+		},
+		ConfidentialityAlgorithms: []ipmi.ConfidentialityAlgorithm{
+			ipmi.ConfidentialityAlgorithmAESCBC128,
+		},
+		AllowLegacyAlgorithms: true,
+		Quirks:                QuirkIgnoreRAKP4ICV,
+	}
+	if diff := cmp.Diff(want, opts); diff != "" {
+		t.Errorf("V2SessionOpts() mismatch (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,32 @@
+package bmc
+
+// Quirks is a set of known deviations from the specification that a BMC's
+// firmware exhibits, which the library should tolerate. Quirks are opt-in, as
+// tolerating them weakens validation for BMCs that do not need it. Multiple
+// quirks can be combined with bitwise OR.
+type Quirks uint32
+
+const (
+	// QuirkRAKP2UsernamePadded indicates the BMC computes the RAKP Message 2
+	// auth code over the username padded with 0x00 to 16 bytes, with a
+	// ULength_M of 16, rather than the username as sent in RAKP Message 1.
+	// This is exhibited by some Super Micro firmware, and manifests as
+	// ErrIncorrectPassword despite the password being correct. When set, both
+	// the spec-compliant and padded forms are accepted.
+	QuirkRAKP2UsernamePadded Quirks = 1 << iota
+
+	// QuirkIgnoreRAKP4ICV indicates the BMC sends an incorrect integrity
+	// check value in RAKP Message 4, despite the session otherwise working.
+	// When set, a mismatch does not cause session establishment to fail;
+	// instead, it is logged with the standard logger, and
+	// bmc_session_rakp4_icv_mismatches_total is incremented. This
+	// mirrors ipmitool's tolerance. Note the RAKP4 ICV is the only proof the
+	// BMC knows the SIK, so this should only be enabled for known-affected
+	// firmware.
+	QuirkIgnoreRAKP4ICV
+)
+
+// Has returns whether all the quirks in o are present in q.
+func (q Quirks) Has(o Quirks) bool {
+	return q&o == o
+}
@@ -0,0 +1,41 @@
+package bmc
+
+import (
+	"context"
+)
+
+// requestMetadataKey is the context key under which RequestMetadata is
+// stored.
+type requestMetadataKey struct{}
+
+// RequestMetadata describes why commands are being sent, and on whose behalf.
+// It is carried through a context with WithRequestMetadata(), so a daemon
+// sharing sessions between many callers can record who triggered each
+// operation without changing the signature of every function in between. The
+// library does not interpret it, or send it to the BMC; it is made available
+// to subsystems emitting events about commands, and can be read by the
+// caller's own logging with RequestMetadataFromContext().
+type RequestMetadata struct {
+
+	// TraceID correlates the commands with the request that caused them,
+	// e.g. an HTTP request ID or distributed tracing trace ID.
+	TraceID string
+
+	// User identifies who the commands are being sent for, e.g. the
+	// authenticated user of an API. This is unrelated to the username the
+	// session was established with, which is often shared.
+	User string
+}
+
+// WithRequestMetadata returns a context carrying the provided metadata,
+// replacing any already in ctx.
+func WithRequestMetadata(ctx context.Context, m RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, m)
+}
+
+// RequestMetadataFromContext returns the metadata in the context, and whether
+// there was any. If not, the zero value is returned.
+func RequestMetadataFromContext(ctx context.Context) (RequestMetadata, bool) {
+	m, ok := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return m, ok
+}
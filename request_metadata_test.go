@@ -0,0 +1,26 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestMetadataFromContext(t *testing.T) {
+	if m, ok := RequestMetadataFromContext(context.Background()); ok {
+		t.Errorf("RequestMetadataFromContext() of empty context = %+v, want "+
+			"none", m)
+	}
+	want := RequestMetadata{
+		TraceID: "4bf92f3577b34da6",
+		User:    "alice",
+	}
+	ctx := WithRequestMetadata(context.Background(), RequestMetadata{
+		User: "bob",
+	})
+	ctx = WithRequestMetadata(ctx, want)
+	got, ok := RequestMetadataFromContext(ctx)
+	if !ok || got != want {
+		t.Errorf("RequestMetadataFromContext() = %+v, %v; want %+v, true", got,
+			ok, want)
+	}
+}
@@ -14,6 +14,12 @@ import (
 var (
 	errSDRRepositoryModified = errors.New(
 		"the SDR Repository was modified during enumeration")
+
+	// errSDRRepositoryLoop is returned by walkRawSDRs() if a record's next
+	// record ID points to a record already retrieved, which would otherwise
+	// cause enumeration to continue forever.
+	errSDRRepositoryLoop = errors.New(
+		"the SDR Repository contains a loop of next record IDs")
 )
 
 // SDRRepository is a retrieved SDR Repository. For the time being, this is a
@@ -27,18 +33,41 @@ type SDRRepository map[ipmi.RecordID]*ipmi.FullSensorRecord
 // change mid-way through iteration, which would invalidate records retrieved so
 // far. The session-configured timeout is used for individual commands.
 func RetrieveSDRRepository(ctx context.Context, s Session) (SDRRepository, error) {
-	var repo *SDRRepository
-	err := backoff.Retry(func() error {
-		initialInfo, err := s.GetSDRRepositoryInfo(ctx)
+	return RetrieveFilteredSDRRepository(ctx, s, &SensorFilter{})
+}
+
+// RetrieveFilteredSDRRepository is like RetrieveSDRRepository(), but only
+// returns Full Sensor Records selected by the filter. Records are filtered as
+// they are retrieved, so the remainder are never decoded.
+func RetrieveFilteredSDRRepository(ctx context.Context, s Session, filter *SensorFilter) (SDRRepository, error) {
+	var repo SDRRepository
+	err := retrySDRWalk(ctx, s, func() error {
+		// we could error here if unsupported SDR Repo version; no such cases
+		// currently exist
+		candidateRepo, err := walkSDRs(ctx, s, filter)
 		if err != nil {
 			return err
 		}
-		// we could error here if unsupported SDR Repo version; no such cases
-		// currently exist
-		candidateRepo, err := walkSDRs(ctx, s)
+		repo = candidateRepo
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// retrySDRWalk calls walk, which should iterate over the SDR Repository, until
+// it succeeds without the repository changing, backing off between attempts.
+func retrySDRWalk(ctx context.Context, s Session, walk func() error) error {
+	return backoff.Retry(func() error {
+		initialInfo, err := s.GetSDRRepositoryInfo(ctx)
 		if err != nil {
 			return err
 		}
+		if err := walk(); err != nil {
+			return err
+		}
 		finalInfo, err := s.GetSDRRepositoryInfo(ctx)
 		if err != nil {
 			return err
@@ -48,19 +77,38 @@ func RetrieveSDRRepository(ctx context.Context, s Session) (SDRRepository, error
 			// tough luck, start again
 			return errSDRRepositoryModified
 		}
-		repo = &candidateRepo
 		return nil
 	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+}
+
+// walkSDRs iterates over the SDR Repository, decoding Full Sensor Records
+// selected by filter. It is not concerned with the repo changing behind its
+// back.
+func walkSDRs(ctx context.Context, s Session, filter *SensorFilter) (SDRRepository, error) {
+	repo := SDRRepository{} // we could set a size; it's a micro-optimisation
+	err := walkRawSDRs(ctx, s, func(id ipmi.RecordID, data []byte) error {
+		if !filter.matchesKey(data) {
+			return nil
+		}
+		fsr, err := decodeFullSensorRecord(data)
+		if err != nil {
+			return err
+		}
+		if fsr != nil && filter.Matches(fsr) {
+			repo[id] = fsr
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return *repo, nil
+	return repo, nil
 }
 
-// walkSDRs iterates over the SDR Repository. It is not concerned with the repo
-// changing behind its back.
-func walkSDRs(ctx context.Context, s Session) (SDRRepository, error) {
-	repo := SDRRepository{} // we could set a size; it's a micro-optimisation
+// walkRawSDRs calls f with the requested record ID and data, including the
+// header, of each record in the SDR Repository. The data is only valid until f
+// returns.
+func walkRawSDRs(ctx context.Context, s Session, f func(ipmi.RecordID, []byte) error) error {
 	getSDRCmd := &ipmi.GetSDRCmd{
 		Req: ipmi.GetSDRReq{
 			RecordID: ipmi.RecordIDFirst,
@@ -72,26 +120,74 @@ func walkSDRs(ctx context.Context, s Session) (SDRRepository, error) {
 	// implementations do not. The final SDR seems to have two RecordIDs - a
 	// "normal" one and ipmi.RecordIDLast, so retrieving ipmi.RecordIDLast will
 	// duplicate it.
+	requested := map[ipmi.RecordID]bool{}
 	for getSDRCmd.Req.RecordID != ipmi.RecordIDLast {
+		if requested[getSDRCmd.Req.RecordID] {
+			// retrying will not help
+			return backoff.Permanent(fmt.Errorf("%w: record %v requested "+
+				"twice", errSDRRepositoryLoop, getSDRCmd.Req.RecordID))
+		}
+		requested[getSDRCmd.Req.RecordID] = true
 		if err := ValidateResponse(s.SendCommand(ctx, getSDRCmd)); err != nil {
 			// if we get a 0xca or 0xff, we need to implement reservations and
 			// partial reading - hopefully we'll be alright - yet to see a SDR
 			// >70 bytes long - they're specified as 64 after all.
-			return nil, err
-		}
-
-		packet := gopacket.NewPacket(getSDRCmd.Rsp.Payload, ipmi.LayerTypeSDR,
-			gopacket.DecodeOptions{
-				Lazy: true,
-				// we can't set NoCopy because we reuse getSDRCmd.Rsp
-			})
-		if packet == nil {
-			return nil, fmt.Errorf("invalid SDR: %v", getSDRCmd)
+			return err
 		}
-		if fsrLayer := packet.Layer(ipmi.LayerTypeFullSensorRecord); fsrLayer != nil {
-			repo[getSDRCmd.Req.RecordID] = fsrLayer.(*ipmi.FullSensorRecord)
+		if err := f(getSDRCmd.Req.RecordID, getSDRCmd.Rsp.Payload); err != nil {
+			return err
 		}
 		getSDRCmd.Req.RecordID = getSDRCmd.Rsp.Next
 	}
-	return repo, nil
+	return nil
+}
+
+// RetrieveFRUDeviceLocators returns the FRU Device Locator Records in the
+// BMC's SDR Repository, in retrieval order. These identify FRU Inventory
+// Devices other than device 0, e.g. power supplies. As with
+// RetrieveSDRRepository(), enumeration is retried if the repository changes.
+func RetrieveFRUDeviceLocators(ctx context.Context, s Session) ([]*ipmi.FRUDeviceLocatorRecord, error) {
+	var records []*ipmi.FRUDeviceLocatorRecord
+	err := retrySDRWalk(ctx, s, func() error {
+		records = nil
+		return walkRawSDRs(ctx, s, func(_ ipmi.RecordID, data []byte) error {
+			layer, err := decodeSDRLayer(data, ipmi.LayerTypeFRUDeviceLocatorRecord)
+			if err != nil {
+				return err
+			}
+			if layer != nil {
+				records = append(records, layer.(*ipmi.FRUDeviceLocatorRecord))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// decodeFullSensorRecord decodes an SDR including its header, returning nil if
+// it is not a Full Sensor Record. The data is copied.
+func decodeFullSensorRecord(data []byte) (*ipmi.FullSensorRecord, error) {
+	layer, err := decodeSDRLayer(data, ipmi.LayerTypeFullSensorRecord)
+	if err != nil || layer == nil {
+		return nil, err
+	}
+	return layer.(*ipmi.FullSensorRecord), nil
+}
+
+// decodeSDRLayer decodes an SDR including its header, returning the layer of
+// the provided type, or nil if the record is of a different type. The data is
+// copied.
+func decodeSDRLayer(data []byte, t gopacket.LayerType) (gopacket.Layer, error) {
+	packet := gopacket.NewPacket(data, ipmi.LayerTypeSDR,
+		gopacket.DecodeOptions{
+			Lazy: true,
+			// we can't set NoCopy because the data is reused
+		})
+	if packet == nil {
+		return nil, fmt.Errorf("invalid SDR: %v", data)
+	}
+	return packet.Layer(t), nil
 }
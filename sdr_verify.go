@@ -0,0 +1,304 @@
+package bmc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+var (
+	// sdrMinBodyLengths contains the length of the fixed fields of each record
+	// type, excluding the 5-byte header. Records of other types are not
+	// checked.
+	sdrMinBodyLengths = map[ipmi.RecordType]int{
+		ipmi.RecordTypeFullSensor:                        43,
+		ipmi.RecordTypeCompactSensor:                     27,
+		ipmi.RecordTypeEventOnly:                         12,
+		ipmi.RecordTypeEntityAssociation:                 11,
+		ipmi.RecordTypeDeviceRelativeEntityAssociation:   27,
+		ipmi.RecordTypeGenericDeviceLocator:              11,
+		ipmi.RecordTypeFRUDeviceLocator:                  11,
+		ipmi.RecordTypeManagementControllerDeviceLocator: 11,
+		ipmi.RecordTypeManagementControllerConfirmation:  27,
+		ipmi.RecordTypeBMCMessageChannelInfo:             11,
+	}
+)
+
+// SDRProblem is an inconsistency in an SDR Repository found by
+// VerifySDRRepository().
+type SDRProblem struct {
+
+	// RecordID identifies the record the problem was found in, as requested
+	// from the BMC, or taken from the record header when verifying a dump.
+	// It is ipmi.RecordIDLast for trailing data in a dump.
+	RecordID ipmi.RecordID
+
+	// Description explains the problem.
+	Description string
+}
+
+func (p SDRProblem) String() string {
+	return fmt.Sprintf("record %v: %v", p.RecordID, p.Description)
+}
+
+// VerifySDRRepository retrieves every record in the BMC's SDR Repository and
+// checks it for corruption, returning the problems found in retrieval order.
+// As SDRs have no checksum, records are checked against their header and the
+// length of their type, and cross-referenced: record IDs and sensors must be
+// unique, and entities contained by Entity Association Records must be
+// described by another record. Corrupt repositories often manifest as sensors
+// silently missing from RetrieveSDRRepository(), so the problems are intended
+// to be included in a report to the vendor. An error is only returned if the
+// repository could not be retrieved.
+func VerifySDRRepository(ctx context.Context, s Session) ([]SDRProblem, error) {
+	var problems []SDRProblem
+	err := retrySDRWalk(ctx, s, func() error {
+		v := newSDRVerifier()
+		last := ipmi.RecordIDFirst
+		err := walkRawSDRs(ctx, s, func(id ipmi.RecordID, data []byte) error {
+			v.verify(id, data, true)
+			last = id
+			return nil
+		})
+		if errors.Is(err, errSDRRepositoryLoop) {
+			v.problem(last, "next record ID points to an earlier record, "+
+				"so later records cannot be retrieved")
+		} else if err != nil {
+			return err
+		}
+		problems = v.finish()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// VerifySDRDump is like VerifySDRRepository(), but checks a file written by
+// SaveSDRRepository() or ipmitool's "sdr dump", so a repository can be
+// verified offline, e.g. one attached to a support case. Checking stops at a
+// truncated record, as the position of subsequent records is unknown.
+func VerifySDRDump(r io.Reader) ([]SDRProblem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	v := newSDRVerifier()
+	for offset := 0; offset < len(data); {
+		if len(data)-offset < 5 {
+			v.problem(ipmi.RecordIDLast, fmt.Sprintf("%v bytes of trailing "+
+				"data at offset %v", len(data)-offset, offset))
+			break
+		}
+		end := offset + 5 + int(data[offset+4])
+		if end > len(data) {
+			// the verifier would also report this, but we cannot continue
+			end = len(data)
+		}
+		v.verify(ipmi.RecordIDFirst, data[offset:end], false)
+		offset = end
+	}
+	return v.finish(), nil
+}
+
+// sdrEntity identifies an entity, e.g. the second processor.
+type sdrEntity struct {
+	id       ipmi.EntityID
+	instance ipmi.EntityInstance
+}
+
+func (e sdrEntity) String() string {
+	return fmt.Sprintf("%v instance %v", e.id, uint8(e.instance))
+}
+
+// sdrSensor identifies a sensor within the system.
+type sdrSensor struct {
+	owner  ipmi.Address
+	lun    ipmi.LUN
+	number uint8
+}
+
+// sdrAssociation is an Entity Association Record retained for checking once
+// all records have been seen.
+type sdrAssociation struct {
+	recordID ipmi.RecordID
+	body     []byte
+}
+
+// sdrVerifier accumulates records and the problems found with them. Checks
+// that depend on other records are deferred to finish().
+type sdrVerifier struct {
+	problems []SDRProblem
+
+	// recordIDs maps the ID in each record header to whether it has been
+	// seen.
+	recordIDs map[ipmi.RecordID]bool
+
+	// sensors maps sensors to the ID of the record describing them.
+	sensors map[sdrSensor]ipmi.RecordID
+
+	// entities contains entities described by sensor and device locator
+	// records, and the containers of entity associations.
+	entities map[sdrEntity]bool
+
+	associations []sdrAssociation
+}
+
+func newSDRVerifier() *sdrVerifier {
+	return &sdrVerifier{
+		recordIDs: map[ipmi.RecordID]bool{},
+		sensors:   map[sdrSensor]ipmi.RecordID{},
+		entities:  map[sdrEntity]bool{},
+	}
+}
+
+func (v *sdrVerifier) problem(id ipmi.RecordID, description string) {
+	v.problems = append(v.problems, SDRProblem{
+		RecordID:    id,
+		Description: description,
+	})
+}
+
+// verify checks a record including its header. requested is the ID it was
+// retrieved with, which is checked against the header if checkRequested is
+// true. The data is not retained.
+func (v *sdrVerifier) verify(requested ipmi.RecordID, data []byte, checkRequested bool) {
+	if len(data) < 5 {
+		v.problem(requested, fmt.Sprintf("record is %v bytes, shorter than "+
+			"the 5-byte header", len(data)))
+		return
+	}
+	id := ipmi.RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	if !checkRequested {
+		requested = id
+	}
+	if checkRequested && requested != ipmi.RecordIDFirst && id != requested {
+		v.problem(requested, fmt.Sprintf("header has record ID %v", id))
+	}
+	if id == ipmi.RecordIDFirst || id == ipmi.RecordIDLast {
+		v.problem(requested, fmt.Sprintf("header has reserved record ID %v",
+			id))
+	} else if v.recordIDs[id] {
+		v.problem(requested, fmt.Sprintf("duplicate record ID %v", id))
+	}
+	v.recordIDs[id] = true
+
+	if data[2] != 0x51 {
+		v.problem(requested, fmt.Sprintf("unknown SDR version %#x", data[2]))
+	}
+	recordType := ipmi.RecordType(data[3])
+	body := data[5:]
+	if length := int(data[4]); length != len(body) {
+		v.problem(requested, fmt.Sprintf("header gives length %v, but %v "+
+			"bytes follow it", length, len(body)))
+		if len(body) > length {
+			body = body[:length]
+		}
+	}
+	if minLength, ok := sdrMinBodyLengths[recordType]; ok &&
+		len(body) < minLength {
+		v.problem(requested, fmt.Sprintf("%v is %v bytes, must be at least "+
+			"%v", recordType.Description(), len(body), minLength))
+		return
+	}
+
+	switch recordType {
+	case ipmi.RecordTypeFullSensor, ipmi.RecordTypeCompactSensor,
+		ipmi.RecordTypeEventOnly:
+		v.sensor(requested, body)
+		v.entities[sdrEntity{ipmi.EntityID(body[3]),
+			ipmi.EntityInstance(body[4] & 0x7f)}] = true
+	case ipmi.RecordTypeGenericDeviceLocator, ipmi.RecordTypeFRUDeviceLocator,
+		ipmi.RecordTypeManagementControllerDeviceLocator:
+		v.entities[sdrEntity{ipmi.EntityID(body[7]),
+			ipmi.EntityInstance(body[8] & 0x7f)}] = true
+	case ipmi.RecordTypeEntityAssociation:
+		v.entities[sdrEntity{ipmi.EntityID(body[0]),
+			ipmi.EntityInstance(body[1])}] = true
+		v.associations = append(v.associations, sdrAssociation{
+			recordID: requested,
+			body:     append([]byte(nil), body...),
+		})
+	}
+	if recordType == ipmi.RecordTypeFullSensor {
+		if _, err := decodeFullSensorRecord(data[:5+len(body)]); err != nil {
+			v.problem(requested, err.Error())
+		}
+	}
+}
+
+// sensor checks a sensor record's key does not duplicate that of another.
+// Compact Sensor Records sharing a record for a range of sensors are only
+// checked for their first sensor.
+func (v *sdrVerifier) sensor(id ipmi.RecordID, body []byte) {
+	key := sdrSensor{
+		owner:  ipmi.Address(body[0]),
+		lun:    ipmi.LUN(body[1] & 0x3),
+		number: body[2],
+	}
+	if other, ok := v.sensors[key]; ok {
+		v.problem(id, fmt.Sprintf("sensor %v on LUN %v of %v is also "+
+			"described by record %v", key.number, uint8(key.lun), key.owner,
+			other))
+		return
+	}
+	v.sensors[key] = id
+}
+
+// finish runs checks requiring all records to have been seen, and returns
+// the problems found.
+func (v *sdrVerifier) finish() []SDRProblem {
+	for _, association := range v.associations {
+		v.association(association)
+	}
+	return v.problems
+}
+
+// association checks that each entity contained by an Entity Association
+// Record is described by another record. If the record specifies ranges, at
+// least one entity in each range must be described.
+func (v *sdrVerifier) association(a sdrAssociation) {
+	isRange := a.body[2]&(1<<7) != 0
+	pairs := a.body[3:11]
+	if !isRange {
+		for i := 0; i < len(pairs); i += 2 {
+			entity := sdrEntity{ipmi.EntityID(pairs[i]),
+				ipmi.EntityInstance(pairs[i+1])}
+			if entity.id != ipmi.EntityIDUnspecified && !v.entities[entity] {
+				v.problem(a.recordID, fmt.Sprintf("contained entity %v is "+
+					"not described by any record", entity))
+			}
+		}
+		return
+	}
+	for i := 0; i < len(pairs); i += 4 {
+		first := sdrEntity{ipmi.EntityID(pairs[i]),
+			ipmi.EntityInstance(pairs[i+1])}
+		last := sdrEntity{ipmi.EntityID(pairs[i+2]),
+			ipmi.EntityInstance(pairs[i+3])}
+		if first.id == ipmi.EntityIDUnspecified {
+			continue
+		}
+		if first.id != last.id || first.instance > last.instance {
+			v.problem(a.recordID, fmt.Sprintf("invalid contained entity "+
+				"range %v to %v", first, last))
+			continue
+		}
+		found := false
+		for instance := int(first.instance); instance <= int(last.instance); instance++ {
+			if v.entities[sdrEntity{first.id, ipmi.EntityInstance(instance)}] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.problem(a.recordID, fmt.Sprintf("no entity in contained range "+
+				"%v to %v is described by any record", first, last))
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package bmc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testSDR builds a record with a valid header around body.
+func testSDR(id ipmi.RecordID, recordType ipmi.RecordType, body []byte) []byte {
+	record := make([]byte, 5, 5+len(body))
+	binary.LittleEndian.PutUint16(record[0:2], uint16(id))
+	record[2] = 0x51
+	record[3] = uint8(recordType)
+	record[4] = uint8(len(body))
+	return append(record, body...)
+}
+
+// testSensorSDR builds a Full Sensor Record for the provided sensor number and
+// entity.
+func testSensorSDR(id ipmi.RecordID, number uint8, entity ipmi.EntityID, instance ipmi.EntityInstance) []byte {
+	body := make([]byte, 43)
+	body[0] = uint8(ipmi.SlaveAddressBMC.Address())
+	body[2] = number
+	body[3] = uint8(entity)
+	body[4] = uint8(instance)
+	return testSDR(id, ipmi.RecordTypeFullSensor, body)
+}
+
+// testAssociationSDR builds an Entity Association Record for the system board
+// containing the provided entity ID and instance pairs.
+func testAssociationSDR(id ipmi.RecordID, isRange bool, pairs ...uint8) []byte {
+	body := make([]byte, 11)
+	body[0] = uint8(ipmi.EntityIDSystemBoard)
+	body[1] = 1
+	if isRange {
+		body[2] = 1 << 7
+	}
+	copy(body[3:], pairs)
+	return testSDR(id, ipmi.RecordTypeEntityAssociation, body)
+}
+
+func TestVerifySDRDump(t *testing.T) {
+	cpu := ipmi.EntityIDProcessor
+	tests := []struct {
+		name    string
+		records [][]byte
+		want    []ipmi.RecordID
+	}{
+		{
+			"empty",
+			nil,
+			nil,
+		},
+		{
+			"consistent",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				testSensorSDR(2, 2, cpu, 2),
+				testAssociationSDR(3, false, uint8(cpu), 1, uint8(cpu), 2),
+				testAssociationSDR(4, true, uint8(cpu), 2, uint8(cpu), 4),
+			},
+			nil,
+		},
+		{
+			"duplicate record ID and sensor",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				testSensorSDR(1, 1, cpu, 1),
+			},
+			[]ipmi.RecordID{1, 1},
+		},
+		{
+			"reserved record ID",
+			[][]byte{
+				testSensorSDR(0, 1, cpu, 1),
+			},
+			[]ipmi.RecordID{0},
+		},
+		{
+			"dangling association",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				testAssociationSDR(2, false, uint8(cpu), 1, uint8(cpu), 2),
+			},
+			[]ipmi.RecordID{2},
+		},
+		{
+			"dangling range",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				testAssociationSDR(2, true, uint8(cpu), 2, uint8(cpu), 4),
+			},
+			[]ipmi.RecordID{2},
+		},
+		{
+			"invalid range",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				testAssociationSDR(2, true, uint8(cpu), 1,
+					uint8(ipmi.EntityIDMemoryDevice), 1),
+			},
+			[]ipmi.RecordID{2},
+		},
+		{
+			"short record",
+			[][]byte{
+				testSDR(1, ipmi.RecordTypeFullSensor, make([]byte, 20)),
+			},
+			[]ipmi.RecordID{1},
+		},
+		{
+			"truncated",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1)[:40],
+			},
+			// length mismatch, then too short
+			[]ipmi.RecordID{1, 1},
+		},
+		{
+			"trailing data",
+			[][]byte{
+				testSensorSDR(1, 1, cpu, 1),
+				{0x02, 0x00},
+			},
+			[]ipmi.RecordID{ipmi.RecordIDLast},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems, err := VerifySDRDump(bytes.NewReader(
+				bytes.Join(test.records, nil)))
+			if err != nil {
+				t.Fatalf("VerifySDRDump() = %v", err)
+			}
+			var got []ipmi.RecordID
+			for _, problem := range problems {
+				got = append(got, problem.RecordID)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("VerifySDRDump() = %v, mismatch (-want +got):\n%v",
+					problems, diff)
+			}
+		})
+	}
+}
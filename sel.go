@@ -0,0 +1,69 @@
+package bmc
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// selErasurePollInterval is how often we check the progress of an
+	// in-progress SEL erasure. Most BMCs complete immediately; those backed by
+	// slow flash can take several seconds.
+	selErasurePollInterval = time.Millisecond * 500
+)
+
+// clearSEL reserves the SEL, initiates its erasure, then polls for completion,
+// calling progress (if non-nil) with each status received. As erasure is
+// asynchronous, it is possible for the initiating command to succeed, but the
+// context to expire before completion is observed; in this case, the SEL will
+// probably still be cleared.
+func clearSEL(ctx context.Context, c Connection, progress func(ipmi.ErasureProgress)) error {
+	reserveCmd := &ipmi.ReserveSELCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, reserveCmd)); err != nil {
+		return err
+	}
+	clearCmd := &ipmi.ClearSELCmd{
+		Req: ipmi.ClearSELReq{
+			ReservationID: reserveCmd.Rsp.ReservationID,
+			Action:        ipmi.ClearSELActionInitiateErase,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, clearCmd)); err != nil {
+		return err
+	}
+	clearCmd.Req.Action = ipmi.ClearSELActionGetErasureStatus
+	first := true
+	return WaitFor(ctx, selErasurePollInterval, func(ctx context.Context) (bool, error) {
+		// the response to the initiating request carries a status, so we can
+		// skip the first poll
+		if !first {
+			if err := ValidateResponse(c.SendCommand(ctx, clearCmd)); err != nil {
+				return false, err
+			}
+		}
+		first = false
+		if progress != nil {
+			progress(clearCmd.Rsp.Progress)
+		}
+		return clearCmd.Rsp.Progress == ipmi.ErasureProgressCompleted, nil
+	})
+}
+
+// RetrieveSEL reads every record in the System Event Log, oldest first. Records
+// added while the log is being read may or may not be included. To be notified
+// of new records as they are added, use Events() instead.
+func RetrieveSEL(ctx context.Context, s Session) ([]*ipmi.SELEventRecord, error) {
+	p := &selPoller{
+		conn: s,
+	}
+	records := []*ipmi.SELEventRecord{}
+	if err := p.poll(ctx, func(r *ipmi.SELEventRecord) bool {
+		records = append(records, r)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,83 @@
+package bmc
+
+import (
+	"regexp"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// SensorFilter selects Full Sensor Records during an SDR Repository walk. Each
+// non-empty field must match for a record to be selected, and a record matches
+// a slice field if it matches any element, so the zero value selects every
+// record. All fields other than Name are checked before the record is
+// decoded, so exporters interested in a handful of sensors need not decode or
+// read the rest.
+type SensorFilter struct {
+
+	// Entities selects sensors monitoring one of these entity types, e.g.
+	// ipmi.EntityIDProcessor.
+	Entities []ipmi.EntityID
+
+	// Instances selects sensors monitoring one of these entity instances.
+	// This is most useful in conjunction with Entities, e.g. to select only
+	// the first processor.
+	Instances []ipmi.EntityInstance
+
+	// SensorTypes selects sensors measuring one of these types, e.g.
+	// ipmi.SensorTypeTemperature.
+	SensorTypes []ipmi.SensorType
+
+	// OwnerAddresses selects sensors owned by one of these controllers, e.g.
+	// the BMC itself.
+	OwnerAddresses []ipmi.Address
+
+	// OwnerLUNs selects sensors accessed via one of these LUNs of their
+	// owner.
+	OwnerLUNs []ipmi.LUN
+
+	// Name selects sensors whose ID string matches the regular expression.
+	Name *regexp.Regexp
+}
+
+// matchesKey returns whether a raw SDR, including its header, is a Full Sensor
+// Record matching all of the filter's fields other than Name. Records too
+// short to contain the fields are passed through, so decoding can reject
+// them.
+func (f *SensorFilter) matchesKey(data []byte) bool {
+	const header = 5
+	if len(data) < header+8 {
+		return true
+	}
+	if ipmi.RecordType(data[3]) != ipmi.RecordTypeFullSensor {
+		return false
+	}
+	body := data[header:]
+	return matchesAny(f.OwnerAddresses, ipmi.Address(body[0])) &&
+		matchesAny(f.OwnerLUNs, ipmi.LUN(body[1]&0x3)) &&
+		matchesAny(f.Entities, ipmi.EntityID(body[3])) &&
+		matchesAny(f.Instances, ipmi.EntityInstance(body[4]&0x7f)) &&
+		matchesAny(f.SensorTypes, ipmi.SensorType(body[7]))
+}
+
+// Matches returns whether a decoded record is selected by the filter.
+func (f *SensorFilter) Matches(r *ipmi.FullSensorRecord) bool {
+	return matchesAny(f.OwnerAddresses, r.OwnerAddress) &&
+		matchesAny(f.OwnerLUNs, r.OwnerLUN) &&
+		matchesAny(f.Entities, r.Entity) &&
+		matchesAny(f.Instances, r.Instance) &&
+		matchesAny(f.SensorTypes, r.SensorType) &&
+		(f.Name == nil || f.Name.MatchString(r.Identity))
+}
+
+// matchesAny returns whether values is empty or contains v.
+func matchesAny[T comparable](values []T, v T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
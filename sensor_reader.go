@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket/layers"
 )
 
 var (
@@ -35,15 +38,56 @@ type SensorReader interface {
 	Read(context.Context, Session) (float64, error)
 }
 
+// SensorReading is a converted sensor reading, along with its precision as
+// described by the sensor's SDR. Monitoring systems can use the precision to
+// set alert hysteresis, so a reading alternating between adjacent raw values
+// does not cause an alert to flap.
+type SensorReading struct {
+
+	// Value is the reading, with conversion factors and linearisation
+	// applied.
+	Value float64
+
+	// Tolerance is the absolute tolerance of the reading: the true value is
+	// within Value ± Tolerance. It is 0 if the SDR does not specify one.
+	Tolerance float64
+
+	// Resolution is the smallest change in Value the sensor can report,
+	// i.e. the difference between readings converted from adjacent raw
+	// values. For linearised sensors, this varies with Value; it is the step
+	// from Value to the next higher raw value.
+	Resolution float64
+
+	// AccuracyPercentage is the accuracy of the sensor, as a percentage of
+	// Value. It is 0 if the SDR does not specify one.
+	AccuracyPercentage float64
+}
+
+func (r *SensorReading) String() string {
+	return fmt.Sprintf("%v ± %v", r.Value, r.Tolerance)
+}
+
+// DetailedSensorReader is implemented by SensorReaders that can also return
+// the precision of a reading. All SensorReaders returned by NewSensorReader()
+// implement it.
+type DetailedSensorReader interface {
+	SensorReader
+
+	// ReadDetailed is like Read, but also returns the tolerance, resolution
+	// and accuracy of the reading.
+	ReadDetailed(context.Context, Session) (*SensorReading, error)
+}
+
 // NewSensorReader returns an appropriate SensorReader implementation for a
 // given SDR.
 func NewSensorReader(r *ipmi.FullSensorRecord) (SensorReader, error) {
-	// TODO non-linear
 	switch {
 	case r.Linearisation.IsLinear():
 		return newLinearSensorReader(r)
 	case r.Linearisation.IsLinearised():
 		return newLinearisedSensorReader(r)
+	case r.Linearisation.IsNonLinear():
+		return newNonLinearSensorReader(r)
 	default:
 		return nil, fmt.Errorf("unsupported sensor linearisation: %v",
 			r.Linearisation)
@@ -55,8 +99,18 @@ func NewSensorReader(r *ipmi.FullSensorRecord) (SensorReader, error) {
 // factors to apply.
 type linearSensorReader struct {
 	readingCmd ipmi.GetSensorReadingCmd
-	parser     ipmi.AnalogDataFormatParser
-	factors    ipmi.ConversionFactors
+
+	// cmd is what is actually sent; it wraps readingCmd to target the sensor
+	// owner's LUN.
+	cmd ipmi.Command
+
+	parser  ipmi.AnalogDataFormatParser
+	factors ipmi.ConversionFactors
+
+	// tolerance and accuracy are the SDR's absolute tolerance and accuracy
+	// percentage.
+	tolerance float64
+	accuracy  float64
 }
 
 func newLinearSensorReader(r *ipmi.FullSensorRecord) (*linearSensorReader, error) {
@@ -65,19 +119,47 @@ func newLinearSensorReader(r *ipmi.FullSensorRecord) (*linearSensorReader, error
 		// sensor does not provide analog readings
 		return nil, err
 	}
-	return &linearSensorReader{
+	reader := &linearSensorReader{
 		readingCmd: ipmi.GetSensorReadingCmd{
 			Req: ipmi.GetSensorReadingReq{
 				Number: r.Number,
 			},
 		},
-		factors: r.ConversionFactors,
-		parser:  parser,
-	}, nil
+		factors:   r.ConversionFactors,
+		parser:    parser,
+		tolerance: r.AbsoluteTolerance(),
+		accuracy:  r.AccuracyPercentage(),
+	}
+	reader.cmd = ipmi.CommandWithLUN(&reader.readingCmd, r.OwnerLUN)
+	return reader, nil
 }
 
 func (r *linearSensorReader) Read(ctx context.Context, s Session) (float64, error) {
-	if err := ValidateResponse(s.SendCommand(ctx, &r.readingCmd)); err != nil {
+	reading, err := r.ReadDetailed(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return reading.Value, nil
+}
+
+func (r *linearSensorReader) ReadDetailed(ctx context.Context, s Session) (*SensorReading, error) {
+	parsed, err := r.readRaw(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return &SensorReading{
+		Value:              r.factors.ConvertReading(parsed),
+		Tolerance:          r.tolerance,
+		Resolution:         r.factors.Resolution(),
+		AccuracyPercentage: r.accuracy,
+	}, nil
+}
+
+// readRaw retrieves the current raw reading of the sensor, returning it
+// parsed according to the analog data format. The unparsed reading remains in
+// r.readingCmd.Rsp.
+func (r *linearSensorReader) readRaw(ctx context.Context, s Session) (int16, error) {
+	if err := ValidateResponse(s.SendCommand(ctx, r.cmd)); err != nil {
 		// some BMCs return an empty response when the component is not present
 		return 0, err
 	}
@@ -87,8 +169,7 @@ func (r *linearSensorReader) Read(ctx context.Context, s Session) (float64, erro
 	if !r.readingCmd.Rsp.ScanningEnabled {
 		return 0, ErrSensorScanningDisabled
 	}
-	parsed := r.parser.Parse(r.readingCmd.Rsp.Reading)
-	return r.factors.ConvertReading(parsed), nil
+	return r.parser.Parse(r.readingCmd.Rsp.Reading), nil
 }
 
 // linearisedSensorReader implements a reader for linearised sensors. These are
@@ -121,3 +202,112 @@ func (r *linearisedSensorReader) Read(ctx context.Context, s Session) (float64,
 	}
 	return r.lineariser.Linearise(reading), nil
 }
+
+// ReadDetailed linearises the tolerance and resolution by applying the
+// linearisation formula to their bounds around the linear reading, as the
+// formula would distort them if applied directly. The tolerance is the larger
+// of the resulting distances from the reading, ignoring a bound outside the
+// formula's domain, e.g. below 0 for a square root.
+func (r *linearisedSensorReader) ReadDetailed(ctx context.Context, s Session) (*SensorReading, error) {
+	reading, err := r.linearReader.ReadDetailed(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	linear := reading.Value
+	reading.Value = r.lineariser.Linearise(linear)
+	tolerance := 0.0
+	for _, bound := range []float64{linear - reading.Tolerance,
+		linear + reading.Tolerance} {
+		distance := math.Abs(r.lineariser.Linearise(bound) - reading.Value)
+		if !math.IsNaN(distance) && !math.IsInf(distance, 0) &&
+			distance > tolerance {
+			tolerance = distance
+		}
+	}
+	reading.Tolerance = tolerance
+	reading.Resolution = math.Abs(r.lineariser.Linearise(
+		linear+reading.Resolution) - reading.Value)
+	return reading, nil
+}
+
+// nonLinearSensorReader implements a reader for non-linear sensors. The
+// conversion factors of these sensors vary with the raw reading, so the SDR's
+// are meaningless; they must be retrieved with Get Sensor Reading Factors for
+// each reading. Factors are cached for the range of readings the BMC indicates
+// they apply to, so a stable sensor only costs one additional command the
+// first time it is read.
+type nonLinearSensorReader struct {
+	linearReader *linearSensorReader
+
+	number uint8
+	lun    ipmi.LUN
+
+	// factors maps raw readings to the response containing their factors.
+	factors map[uint8]*ipmi.GetSensorReadingFactorsRsp
+}
+
+func newNonLinearSensorReader(r *ipmi.FullSensorRecord) (*nonLinearSensorReader, error) {
+	reader, err := newLinearSensorReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &nonLinearSensorReader{
+		linearReader: reader,
+		number:       r.Number,
+		lun:          r.OwnerLUN,
+		factors:      map[uint8]*ipmi.GetSensorReadingFactorsRsp{},
+	}, nil
+}
+
+func (r *nonLinearSensorReader) Read(ctx context.Context, s Session) (float64, error) {
+	reading, err := r.ReadDetailed(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return reading.Value, nil
+}
+
+// ReadDetailed uses the tolerance and accuracy returned alongside the
+// factors, as these also vary with the reading.
+func (r *nonLinearSensorReader) ReadDetailed(ctx context.Context, s Session) (*SensorReading, error) {
+	parsed, err := r.linearReader.readRaw(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	factors, err := r.readingFactors(ctx, s, r.linearReader.readingCmd.Rsp.Reading)
+	if err != nil {
+		return nil, err
+	}
+	return &SensorReading{
+		Value:              factors.ConvertReading(parsed),
+		Tolerance:          factors.AbsoluteTolerance(),
+		Resolution:         factors.Resolution(),
+		AccuracyPercentage: factors.AccuracyPercentage(),
+	}, nil
+}
+
+// readingFactors returns the factors for a raw reading, retrieving them from
+// the BMC if they are not cached.
+func (r *nonLinearSensorReader) readingFactors(ctx context.Context, s Session, reading uint8) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	if factors, ok := r.factors[reading]; ok {
+		return factors, nil
+	}
+	cmd := &ipmi.GetSensorReadingFactorsCmd{
+		Req: ipmi.GetSensorReadingFactorsReq{
+			Number:  r.number,
+			Reading: reading,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx,
+		ipmi.CommandWithLUN(cmd, r.lun))); err != nil {
+		return nil, err
+	}
+	factors := &cmd.Rsp
+	// do not retain the packet buffer
+	factors.BaseLayer = layers.BaseLayer{}
+	r.factors[reading] = factors
+	for next := int(reading) + 1; next < int(factors.NextReading); next++ {
+		r.factors[uint8(next)] = factors
+	}
+	return factors, nil
+}
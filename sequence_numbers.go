@@ -1,5 +1,9 @@
 package bmc
 
+import (
+	"math"
+)
+
 // sequenceNumbers maintains a pair of sequence numbers for a session. In IPMI
 // v1.5, there is one set for all packets. In IPMI v2.0, there is one set for
 // authenticated packets, and another for unauthenticated packets. The first
@@ -16,4 +20,69 @@ type sequenceNumbers struct {
 	// Outbound is the sequence number of the last packet the managed system
 	// sent to the remote console.
 	Outbound uint32
+
+	// outboundWindow is a bitmap of which of the outboundWindowSize sequence
+	// numbers preceding and including Outbound have been received. Bit 0
+	// corresponds to Outbound itself.
+	outboundWindow uint32
+}
+
+const (
+	// outboundWindowSize is the number of sequence numbers at or below the
+	// highest seen that we accept, provided each is seen only once. This
+	// tolerates reordering while rejecting replays. Section 6.12.13 of IPMI
+	// v2.0 suggests 8 for the BMC; we are a little more lenient.
+	outboundWindowSize = 16
+
+	// closeSessionSequenceReserve is the number of inbound sequence numbers
+	// kept back once a session's are nearly exhausted, so it can still be
+	// closed, including retries of Close Session.
+	closeSessionSequenceReserve = 256
+)
+
+// nextInbound increments and returns the inbound sequence number, for a packet
+// about to be sent to the managed system. IPMI has no means of re-keying a
+// session, and reusing a number would allow a captured packet to be replayed,
+// so rather than wrapping, it returns false without incrementing if the
+// number would leave fewer than reserve remaining. The session must then be
+// re-established.
+func (s *sequenceNumbers) nextInbound(reserve uint32) (uint32, bool) {
+	if s.Inbound >= math.MaxUint32-reserve {
+		return 0, false
+	}
+	s.Inbound++
+	return s.Inbound, true
+}
+
+// acceptOutbound validates a sequence number received from the managed
+// system, recording it if it is acceptable. A number is acceptable if it is
+// higher than any seen before, or falls within the window below the highest and
+// has not been seen already. The caller must have already verified the packet's
+// integrity. Numbers are compared modulo 2^32 as in RFC 1982, so a managed
+// system whose counter wraps, with or without skipping 0, continues to be
+// accepted: a number is higher if it is less than 2^31 ahead. The first number
+// received is always accepted.
+func (s *sequenceNumbers) acceptOutbound(sequence uint32) bool {
+	shift := sequence - s.Outbound
+	if shift != 0 && (shift < 1<<31 || s.outboundWindow == 0) {
+		if shift >= 32 {
+			s.outboundWindow = 0
+		} else {
+			s.outboundWindow <<= shift
+		}
+		s.outboundWindow |= 1
+		s.Outbound = sequence
+		return true
+	}
+	behind := s.Outbound - sequence
+	if behind >= outboundWindowSize {
+		return false
+	}
+	bit := uint32(1) << behind
+	if s.outboundWindow&bit != 0 {
+		// replay
+		return false
+	}
+	s.outboundWindow |= bit
+	return true
 }
@@ -0,0 +1,83 @@
+package bmc
+
+import (
+	"testing"
+)
+
+func TestSequenceNumbersAcceptOutbound(t *testing.T) {
+	tests := []struct {
+		sequence uint32
+		want     bool
+	}{
+		{1, true},
+		{1, false}, // replay
+		{3, true},
+		{2, true}, // reordered
+		{2, false},
+		{20, true},
+		{5, true},   // 15 behind
+		{4, false},  // 16 behind
+		{0, false},  // far behind
+		{100, true}, // window reset
+		{20, false},
+		{99, true},
+	}
+	s := sequenceNumbers{}
+	for i, test := range tests {
+		if got := s.acceptOutbound(test.sequence); got != test.want {
+			t.Errorf("%v: acceptOutbound(%v) = %v, want %v", i, test.sequence,
+				got, test.want)
+		}
+	}
+}
+
+func TestSequenceNumbersAcceptOutboundRollover(t *testing.T) {
+	tests := []struct {
+		sequence uint32
+		want     bool
+	}{
+		{0xffffffff, true},
+		{1, true}, // wrapped, skipping 0
+		{0xffffffff, false},
+		{0, true}, // reordered
+		{2, true},
+		{0xfffffff0, false}, // far behind
+		{0x80000002, false}, // too far ahead to be newer
+		{0x80000001, true},
+	}
+	s := sequenceNumbers{
+		Outbound:       0xfffffffe,
+		outboundWindow: 1,
+	}
+	for i, test := range tests {
+		if got := s.acceptOutbound(test.sequence); got != test.want {
+			t.Errorf("%v: acceptOutbound(%#x) = %v, want %v", i, test.sequence,
+				got, test.want)
+		}
+	}
+}
+
+func TestSequenceNumbersAcceptOutboundFirst(t *testing.T) {
+	s := sequenceNumbers{}
+	if !s.acceptOutbound(0xdeadbeef) {
+		t.Error("acceptOutbound() rejected the first sequence number")
+	}
+}
+
+func TestSequenceNumbersNextInbound(t *testing.T) {
+	s := sequenceNumbers{
+		Inbound: 0xfffffffd,
+	}
+	if got, ok := s.nextInbound(1); !ok || got != 0xfffffffe {
+		t.Errorf("nextInbound(1) = %#x, %v; want 0xfffffffe, true", got, ok)
+	}
+	if got, ok := s.nextInbound(1); ok {
+		t.Errorf("nextInbound(1) = %#x, true; want exhausted", got)
+	}
+	if got, ok := s.nextInbound(0); !ok || got != 0xffffffff {
+		t.Errorf("nextInbound(0) = %#x, %v; want 0xffffffff, true", got, ok)
+	}
+	if got, ok := s.nextInbound(0); ok {
+		t.Errorf("nextInbound(0) = %#x, true; want exhausted", got)
+	}
+}
@@ -2,6 +2,7 @@ package bmc
 
 import (
 	"context"
+	"time"
 
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 
@@ -38,6 +39,20 @@ var (
 		Help: "The number of times session establishment did not produce " +
 			"a usable session-based connection.",
 	})
+	sessionOpenRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "open_retries_total",
+		Help: "The number of times session establishment was restarted " +
+			"after a temporary failure.",
+	})
+	sessionRAKP4ICVMismatches = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "rakp4_icv_mismatches_total",
+		Help: "The number of sessions established despite an invalid RAKP " +
+			"Message 4 ICV, due to QuirkIgnoreRAKP4ICV.",
+	})
 	sessionsOpen = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Subsystem: "sessions",
@@ -60,6 +75,23 @@ type Session interface {
 	// chooses its own identifier in v2.0, so they likely differ.
 	ID() uint32
 
+	// Ping checks the BMC still recognises the session, returning an error if
+	// not, so callers can detect a dead session before it causes a more
+	// important command to time out.
+	Ping(context.Context) error
+
+	// SendAsync sends a command once within the session without waiting for a
+	// response, returning once the packet has been written. It is intended
+	// for commands whose response we would ignore anyway and which some BMCs
+	// never send, e.g. Cold Reset, or a Chassis Control hard reset, where
+	// SendCommand() would otherwise wait for its full timeout and report a
+	// failure. As nothing is retried, there is no indication the BMC received
+	// the command; callers should confirm its effect by other means. A late
+	// response is discarded: if it arrives while a subsequent command awaits
+	// its own, it does not match that command's request, so the command keeps
+	// waiting rather than being sent again.
+	SendAsync(context.Context, ipmi.Command) error
+
 	// Close closes the session by sending a Close Session command to the BMC.
 	// As the underlying transport/socket is used but not managed by
 	// connections, it is left open in case the user wants to continue issuing
@@ -69,6 +101,61 @@ type Session interface {
 	Close(context.Context) error
 }
 
+// PasswordCompatibility describes how a password is processed before being
+// used as K_[UID]. BMCs differ in how they treat passwords longer than 16 or 20
+// bytes: some reject them, while others silently truncate them when they are
+// set, so the stored key is a prefix of what the user typed. Trailing 0x00
+// bytes need no special handling, as HMAC zero-pads keys anyway.
+type PasswordCompatibility uint8
+
+const (
+	// PasswordCompatibilityAuto uses the password as provided. If that does
+	// not match the BMC's, truncation to 20 then 16 bytes are tried in turn.
+	// RAKP Message 2 lets us check each candidate locally, so this costs no
+	// extra round trips.
+	PasswordCompatibilityAuto PasswordCompatibility = iota
+
+	// PasswordCompatibilityExact uses the password exactly as provided.
+	PasswordCompatibilityExact
+
+	// PasswordCompatibilityTruncate20 truncates the password to 20 bytes, the
+	// maximum length of an IPMI v2.0 password.
+	PasswordCompatibilityTruncate20
+
+	// PasswordCompatibilityTruncate16 truncates the password to 16 bytes, the
+	// maximum length of an IPMI v1.5 password, which some BMCs apply to v2.0
+	// sessions too.
+	PasswordCompatibilityTruncate16
+)
+
+// candidates returns the keys to try for a given password, in order of
+// preference.
+func (c PasswordCompatibility) candidates(password []byte) [][]byte {
+	truncate := func(n int) []byte {
+		if len(password) > n {
+			return password[:n]
+		}
+		return password
+	}
+	switch c {
+	case PasswordCompatibilityExact:
+		return [][]byte{password}
+	case PasswordCompatibilityTruncate20:
+		return [][]byte{truncate(20)}
+	case PasswordCompatibilityTruncate16:
+		return [][]byte{truncate(16)}
+	default:
+		candidates := [][]byte{password}
+		if len(password) > 20 {
+			candidates = append(candidates, truncate(20))
+		}
+		if len(password) > 16 {
+			candidates = append(candidates, truncate(16))
+		}
+		return candidates
+	}
+}
+
 // SessionOpts contains session-establishment options common to IPMI v1.5 and
 // 2.0. A value of this type is required to establish a version-agnostic
 // session.
@@ -101,6 +188,45 @@ type SessionOpts struct {
 	// know when to intervene.
 	MaxPrivilegeLevel ipmi.PrivilegeLevel
 
+	// PasswordCompatibility controls how Password is interpreted if it is
+	// longer than some BMCs store. The default, PasswordCompatibilityAuto,
+	// tries each known variant and should only need changing to rule out
+	// unexpected matches.
+	PasswordCompatibility PasswordCompatibility
+
+	// EstablishmentMaxElapsedTime is the maximum time to spend retrying
+	// session establishment if the BMC indicates a temporary failure, e.g.
+	// insufficient resources because all session slots are in use. Retries use
+	// jittered exponential backoff. The context passed to establishment is
+	// also respected. Zero, the default, disables retries.
+	EstablishmentMaxElapsedTime time.Duration
+
+	// AuditSink, if non-nil, is passed a record of each destructive command
+	// sent within the session, e.g. Chassis Control and Clear SEL, whether or
+	// not it succeeds. Commands are identified as destructive by
+	// ipmi.Operation.Effect(), unless they implement ipmi.EffectCommand, so
+	// e.g. retrieving the progress of a SEL erasure is not audited.
+	AuditSink AuditSink
+
+	// DryRun prevents destructive commands, identified as for AuditSink, from
+	// being sent within the session, allowing automation to be validated
+	// against production BMCs. Other commands are sent normally. Each
+	// destructive command is instead passed to AuditSink, with
+	// AuditRecord.DryRun set, and returns ipmi.CompletionCodeNormal without
+	// decoding a response, leaving the command's response at its prior value.
+	// As the managed system does not change, functions waiting for an effect,
+	// e.g. EnsurePowerState() confirming the new state, will time out.
+	DryRun bool
+
+	// ReadOnly causes commands that change the state of the managed system,
+	// i.e. those with an effect of ipmi.OperationEffectStateChanging or
+	// above, identified as for AuditSink, to be rejected with
+	// ErrReadOnlySession without being sent. This guards monitoring daemons
+	// against bugs that would otherwise e.g. power off machines. Commands
+	// without a registered effect, such as most OEM commands, are allowed, so
+	// this is not a substitute for a user with a lower privilege level.
+	ReadOnly bool
+
 	// timeout is inherited from the session-less connection used to create the
 	// session, which also controls the time allowed for each attempt of the
 	// session establishment commands
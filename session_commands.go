@@ -27,6 +27,16 @@ type SessionCommands interface {
 	// specified in 22.2 and 28.2 of IPMI v1.5 and 2.0 respectively.
 	GetChassisStatus(context.Context) (*ipmi.GetChassisStatusRsp, error)
 
+	// GetChassisCapabilities sends a Get Chassis Capabilities command to the
+	// BMC, indicating which optional chassis features are present. This is
+	// specified in 22.1 and 28.1 of IPMI v1.5 and 2.0 respectively.
+	GetChassisCapabilities(context.Context) (*ipmi.GetChassisCapabilitiesRsp, error)
+
+	// GetPOHCounter retrieves the power-on hours counter, i.e. how long the
+	// system has been powered on for in total. It is specified in 22.12 and
+	// 28.14 of IPMI v1.5 and 2.0 respectively.
+	GetPOHCounter(context.Context) (*ipmi.GetPOHCounterRsp, error)
+
 	// ChassisControl provides power up, power down and reset control. It is
 	// specified in 22.3 and 28.3 of IPMI v1.5 and 2.0 respectively.
 	ChassisControl(context.Context, ipmi.ChassisControl) error
@@ -43,6 +53,26 @@ type SessionCommands interface {
 	// it requires the SDR.
 	GetSensorReading(context.Context, uint8) (*ipmi.GetSensorReadingRsp, error)
 
+	// GetSensorReadingFactors retrieves the conversion factors applicable to a
+	// raw reading of a non-linear sensor, given the sensor number followed by
+	// the reading. It is specified in 29.5 and 35.5 of IPMI v1.5 and 2.0
+	// respectively. NewSensorReader() uses this transparently for non-linear
+	// sensors.
+	GetSensorReadingFactors(context.Context, uint8, uint8) (*ipmi.GetSensorReadingFactorsRsp, error)
+
+	// GetSystemFirmwareVersion retrieves the System Firmware Version parameter
+	// via the Get System Info Parameters command, specified in 22.14b of IPMI
+	// v2.0. Unlike the version in Get Device ID, this is a free-form string,
+	// e.g. "2.12.0-dev-1234-g5678abcd" on OpenBMC.
+	GetSystemFirmwareVersion(context.Context) (string, error)
+
+	// ClearSEL erases all entries in the System Event Log. This obtains a SEL
+	// reservation, initiates erasure, then polls until the BMC reports it has
+	// completed, calling the provided function (if non-nil) with each status
+	// retrieved. Clear SEL is specified in 25.9 and 31.9 of IPMI v1.5 and 2.0
+	// respectively.
+	ClearSEL(context.Context, func(ipmi.ErasureProgress)) error
+
 	// closeSession sends a Close Session command to the BMC. It is unexported
 	// as calling it randomly would leave the session in an invalid state. Call
 	// Close() on the session itself to invoke this.
@@ -0,0 +1,142 @@
+package bmc
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// sessionLabels identify each open session. Remote console session IDs
+	// are only unique among sessions on a connection, and a target may have
+	// several connections, so sessions are instead numbered in the order they
+	// were established or resumed within the process.
+	sessionLabels = []string{"target", "session"}
+
+	sessionAge = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session", "age_seconds"),
+		"The time since the session was established or resumed.",
+		sessionLabels, nil)
+	sessionCommands = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session", "commands_total"),
+		"The number of commands sent within the session, excluding "+
+			"retransmissions.",
+		sessionLabels, nil)
+	sessionRetransmissions = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session", "retransmissions_total"),
+		"The number of times a command packet has been re-sent within the "+
+			"session, because we did not receive a valid response.",
+		sessionLabels, nil)
+	sessionLastActivity = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session", "last_activity_timestamp_seconds"),
+		"The Unix time at which a command was last sent within the session, "+
+			"or a response last received, whichever is later.",
+		sessionLabels, nil)
+
+	// openSessionMetrics reports the per-session metrics of every session
+	// open in the process. A session appearing with a low age and few
+	// commands, then disappearing, repeatedly for one target suggests the BMC
+	// is dropping sessions, and they are being re-established.
+	openSessionMetrics = &sessionCollector{
+		sessions: map[*V2Session]struct{}{},
+	}
+
+	// lastSessionNumber is the number of the session most recently
+	// established or resumed in the process. It is accessed atomically.
+	lastSessionNumber uint64
+)
+
+func init() {
+	prometheus.MustRegister(openSessionMetrics)
+}
+
+// sessionStats are the counters behind the per-session metrics. They are
+// accessed atomically, as the session's lock is held for the duration of each
+// command, and scrapes must not wait for it.
+type sessionStats struct {
+
+	// number identifies the session in the process, and does not change.
+	number uint64
+
+	// established is when the session was established or resumed, according
+	// to the connection's clock.
+	established time.Time
+
+	commands, retransmissions uint64
+
+	// lastActivity is the Unix time in nanoseconds of the last command sent
+	// or response received.
+	lastActivity int64
+}
+
+func newSessionStats(now time.Time) sessionStats {
+	return sessionStats{
+		number:       atomic.AddUint64(&lastSessionNumber, 1),
+		established:  now,
+		lastActivity: now.UnixNano(),
+	}
+}
+
+// active records activity within the session at the provided time.
+func (s *sessionStats) active(now time.Time) {
+	atomic.StoreInt64(&s.lastActivity, now.UnixNano())
+}
+
+// sessionCollector is a prometheus.Collector emitting metrics for each open
+// session. Values are read at scrape time, so age stays current, and series
+// disappear when their session is closed.
+type sessionCollector struct {
+	mu       sync.Mutex
+	sessions map[*V2Session]struct{}
+}
+
+func (c *sessionCollector) add(s *V2Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[s] = struct{}{}
+}
+
+func (c *sessionCollector) remove(s *V2Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, s)
+}
+
+func (c *sessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sessionAge
+	ch <- sessionCommands
+	ch <- sessionRetransmissions
+	ch <- sessionLastActivity
+}
+
+func (c *sessionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for s := range c.sessions {
+		// sessions are not closed if their transport is closed first
+		if s.demux.isClosed() {
+			delete(c.sessions, s)
+			continue
+		}
+		labels := []string{
+			s.demux.Address().String(),
+			strconv.FormatUint(s.stats.number, 10),
+		}
+		ch <- prometheus.MustNewConstMetric(sessionAge,
+			prometheus.GaugeValue,
+			s.now().Sub(s.stats.established).Seconds(), labels...)
+		ch <- prometheus.MustNewConstMetric(sessionCommands,
+			prometheus.CounterValue,
+			float64(atomic.LoadUint64(&s.stats.commands)), labels...)
+		ch <- prometheus.MustNewConstMetric(sessionRetransmissions,
+			prometheus.CounterValue,
+			float64(atomic.LoadUint64(&s.stats.retransmissions)), labels...)
+		ch <- prometheus.MustNewConstMetric(sessionLastActivity,
+			prometheus.GaugeValue,
+			float64(atomic.LoadInt64(&s.stats.lastActivity))/float64(time.Second),
+			labels...)
+	}
+}
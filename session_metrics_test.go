@@ -0,0 +1,128 @@
+package bmc
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sessionMetrics returns the values of a per-session metric for each open
+// session with the target, in no particular order.
+func sessionMetrics(t *testing.T, name, target string) []float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather() failed: %v", err)
+	}
+	values := []float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "target" || label.GetValue() != target {
+					continue
+				}
+				if counter := metric.GetCounter(); counter != nil {
+					values = append(values, counter.GetValue())
+				} else {
+					values = append(values, metric.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestSessionMetrics(t *testing.T) {
+	simBMC, err := sim.New(&sim.Config{
+		Username: "admin",
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simBMC.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	machine, err := DialV2Context(ctx, simBMC.Addr(), &DialOpts{
+		Clock: fixedClock(now),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer machine.Close()
+
+	sess, err := machine.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter2"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sess.GetDeviceID(ctx); err != nil {
+			t.Fatalf("GetDeviceID() failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"bmc_session_age_seconds", 0},
+		{"bmc_session_commands_total", 2},
+		{"bmc_session_retransmissions_total", 0},
+		{"bmc_session_last_activity_timestamp_seconds", float64(now.Unix())},
+	}
+	for _, test := range tests {
+		got := sessionMetrics(t, test.name, simBMC.Addr())
+		if len(got) != 1 || got[0] != test.want {
+			t.Errorf("%v = %v, want [%v]", test.name, got, test.want)
+		}
+	}
+
+	// a second connection's first session has the same remote console
+	// session ID as the first's, but must be reported separately
+	other, err := DialV2(simBMC.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	otherSess, err := other.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter2"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() on second connection failed: %v", err)
+	}
+	got := sessionMetrics(t, "bmc_session_commands_total", simBMC.Addr())
+	sort.Float64s(got)
+	if diff := cmp.Diff([]float64{0, 2}, got); diff != "" {
+		t.Errorf("bmc_session_commands_total mismatch (-want +got):\n%v",
+			diff)
+	}
+
+	if err := sess.Close(ctx); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if err := otherSess.Close(ctx); err != nil {
+		t.Fatalf("Close() on second connection failed: %v", err)
+	}
+	if got := sessionMetrics(t, "bmc_session_age_seconds", simBMC.Addr()); len(got) != 0 {
+		t.Error("metrics of closed sessions are still reported")
+	}
+}
@@ -0,0 +1,265 @@
+package bmc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+const (
+	// resumptionVersion is the first byte of exported sessions, allowing the
+	// format to change. It is also authenticated as additional data.
+	resumptionVersion = 0x01
+)
+
+var (
+	// ErrInvalidResumption is returned when an exported session cannot be
+	// decrypted, either because the key is wrong, or it has been corrupted or
+	// tampered with.
+	ErrInvalidResumption = errors.New("exported session could not be " +
+		"decrypted; wrong key, or corrupt")
+
+	// ErrAuditSinkRequired is returned when resuming a session that was
+	// exported with an audit sink without providing one in ResumeOpts, which
+	// would otherwise allow its destructive commands to go unaudited.
+	ErrAuditSinkRequired = errors.New("exported session had an audit sink, " +
+		"but none was provided on resumption")
+)
+
+// ResumeOpts contains options for resuming an exported session. The
+// session's DryRun and ReadOnly restrictions are always preserved, so are not
+// included.
+type ResumeOpts struct {
+
+	// AuditSink, if non-nil, is passed a record of each destructive command
+	// sent within the resumed session, as for SessionOpts.AuditSink. Sinks
+	// cannot be exported, so it is required if the exported session had one.
+	AuditSink AuditSink
+}
+
+// v2SessionResumption is the plaintext of an exported session. It contains
+// everything required to derive the session's keys, so must never be stored
+// or transmitted unencrypted.
+type v2SessionResumption struct {
+	Address                  string
+	LocalID                  uint32
+	RemoteID                 uint32
+	SIK                      []byte
+	AuthenticationAlgorithm  ipmi.AuthenticationAlgorithm
+	IntegrityAlgorithm       ipmi.IntegrityAlgorithm
+	ConfidentialityAlgorithm ipmi.ConfidentialityAlgorithm
+	MaxPrivilegeLevel        ipmi.PrivilegeLevel
+
+	// the outbound windows are not preserved; the resumed session treats
+	// every sequence number up to the highest seen as received
+	AuthenticatedInbound    uint32
+	AuthenticatedOutbound   uint32
+	UnauthenticatedInbound  uint32
+	UnauthenticatedOutbound uint32
+
+	// DryRun and ReadOnly are preserved so the restrictions cannot be
+	// escaped by resuming the session
+	DryRun   bool `json:",omitempty"`
+	ReadOnly bool `json:",omitempty"`
+
+	// Audited indicates the session had an audit sink, which must be
+	// provided again on resumption
+	Audited bool `json:",omitempty"`
+}
+
+// Export serialises the session's IDs, keys and sequence numbers into an
+// opaque blob encrypted with AES-GCM under key, which must be 16, 24 or 32
+// bytes. The session can be resumed in another process with
+// V2SessionlessTransport.ResumeV2Session(). This is intended for short-lived
+// CLI invocations against BMCs that only allow a few concurrent sessions,
+// where establishing a session per invocation would exhaust them. Exporting
+// waits for any command in progress, then marks the session closed in this
+// process without sending Close Session, so subsequent commands and Close()
+// return ErrSessionClosed. The blob must be resumed at most once: each
+// resumption must be exported again for the next, as reusing an old blob
+// sends sequence numbers the BMC has already seen.
+//
+// The blob is as sensitive as the password: anyone able to decrypt it can
+// issue commands in the session with its privilege level until the BMC times
+// it out. Keep the key separate from the blob, e.g. in an environment
+// variable or OS keyring, and store the blob with restrictive permissions.
+func (s *V2Session) Export(key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.closing {
+		return nil, ErrSessionClosed
+	}
+
+	blob, err := sealResumption(&v2SessionResumption{
+		Address:                  s.demux.transport.Address().String(),
+		LocalID:                  s.LocalID,
+		RemoteID:                 s.RemoteID,
+		SIK:                      s.SIK,
+		AuthenticationAlgorithm:  s.AuthenticationAlgorithm,
+		IntegrityAlgorithm:       s.IntegrityAlgorithm,
+		ConfidentialityAlgorithm: s.ConfidentialityAlgorithm,
+		MaxPrivilegeLevel:        s.maxPrivilegeLevel,
+		AuthenticatedInbound:     s.AuthenticatedSequenceNumbers.Inbound,
+		AuthenticatedOutbound:    s.AuthenticatedSequenceNumbers.Outbound,
+		UnauthenticatedInbound:   s.UnauthenticatedSequenceNumbers.Inbound,
+		UnauthenticatedOutbound:  s.UnauthenticatedSequenceNumbers.Outbound,
+		DryRun:                   s.dryRun,
+		ReadOnly:                 s.readOnly,
+		Audited:                  s.auditSink != nil,
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+	s.closed = true
+	sessionsOpen.Dec()
+	s.removeSession(s)
+	return blob, nil
+}
+
+// ResumeV2Session recreates a session exported with V2Session.Export() on a
+// connection to the same BMC. No commands are sent, so if the BMC has timed
+// out or closed the session in the meantime, this is only discovered when the
+// first command fails; callers should fall back to establishing a new session.
+// ErrInvalidResumption is returned if the blob cannot be decrypted with key,
+// and ErrAuditSinkRequired if the session was exported with an audit sink and
+// opts does not provide one. opts may be nil. See Export() for the security
+// implications of exported sessions.
+func (s *V2SessionlessTransport) ResumeV2Session(blob, key []byte, opts *ResumeOpts) (*V2Session, error) {
+	r, err := openResumption(blob, key)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ResumeOpts{}
+	}
+	if r.Audited && opts.AuditSink == nil {
+		return nil, ErrAuditSinkRequired
+	}
+	if addr := s.Address().String(); r.Address != addr {
+		return nil, fmt.Errorf("session was exported from a connection to "+
+			"%v, not %v", r.Address, addr)
+	}
+
+	hashGenerator, err := algorithmAuthenticationHashGenerator(
+		r.AuthenticationAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	keyMaterialGen := additionalKeyMaterialGenerator{
+		hash: hashGenerator.K(r.SIK),
+	}
+	hasher, err := algorithmHasher(r.IntegrityAlgorithm, keyMaterialGen)
+	if err != nil {
+		return nil, err
+	}
+	cipherLayer, err := algorithmCipher(r.ConfidentialityAlgorithm,
+		keyMaterialGen)
+	if err != nil {
+		return nil, err
+	}
+
+	// avoid sessions subsequently opened on this connection being assigned
+	// the same ID
+	for {
+		last := atomic.LoadUint32(&s.lastSessionID)
+		if last >= r.LocalID || atomic.CompareAndSwapUint32(
+			&s.lastSessionID, last, r.LocalID) {
+			break
+		}
+	}
+
+	sess := &V2Session{
+		v2ConnectionShared: &s.v2ConnectionShared,
+		LocalID:            r.LocalID,
+		RemoteID:           r.RemoteID,
+		AuthenticatedSequenceNumbers: sequenceNumbers{
+			Inbound:        r.AuthenticatedInbound,
+			Outbound:       r.AuthenticatedOutbound,
+			outboundWindow: ^uint32(0),
+		},
+		UnauthenticatedSequenceNumbers: sequenceNumbers{
+			Inbound:        r.UnauthenticatedInbound,
+			Outbound:       r.UnauthenticatedOutbound,
+			outboundWindow: ^uint32(0),
+		},
+		SIK:                            r.SIK,
+		AuthenticationAlgorithm:        r.AuthenticationAlgorithm,
+		IntegrityAlgorithm:             r.IntegrityAlgorithm,
+		ConfidentialityAlgorithm:       r.ConfidentialityAlgorithm,
+		AdditionalKeyMaterialGenerator: keyMaterialGen,
+		integrityAlgorithm:             hasher,
+		confidentialityLayer:           cipherLayer,
+		timeout:                        s.timeout,
+		maxPrivilegeLevel:              r.MaxPrivilegeLevel,
+		stats:                          newSessionStats(s.now()),
+		dryRun:                         r.DryRun,
+		readOnly:                       r.ReadOnly,
+		auditSink:                      opts.AuditSink,
+	}
+	sess.decode = ipmi.NewV2DecodingLayerFunc(&sess.rmcpLayer,
+		&sess.sessionSelectorLayer, &sess.v2SessionLayer, cipherLayer,
+		&sess.messageLayer)
+	sessionsOpen.Inc()
+	s.addSession(sess)
+	return sess, nil
+}
+
+// sealResumption encrypts an exported session.
+func sealResumption(r *v2SessionResumption, key []byte) ([]byte, error) {
+	aead, err := newResumptionAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := make([]byte, 1+aead.NonceSize(), 1+aead.NonceSize()+
+		len(plaintext)+aead.Overhead())
+	blob[0] = resumptionVersion
+	if _, err := rand.Read(blob[1:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(blob, blob[1:], plaintext, blob[:1]), nil
+}
+
+// openResumption decrypts and parses an exported session.
+func openResumption(blob, key []byte) (*v2SessionResumption, error) {
+	aead, err := newResumptionAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) < 1+aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidResumption
+	}
+	if blob[0] != resumptionVersion {
+		return nil, fmt.Errorf("unsupported exported session version %v",
+			blob[0])
+	}
+	nonce := blob[1 : 1+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, blob[1+aead.NonceSize():],
+		blob[:1])
+	if err != nil {
+		return nil, ErrInvalidResumption
+	}
+	r := &v2SessionResumption{}
+	if err := json.Unmarshal(plaintext, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func newResumptionAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
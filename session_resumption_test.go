@@ -0,0 +1,240 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestResumptionRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	want := &v2SessionResumption{
+		Address:                  "127.0.0.1:623",
+		LocalID:                  1,
+		RemoteID:                 0xdeadbeef,
+		SIK:                      []byte{0x01, 0x02, 0x03},
+		AuthenticationAlgorithm:  ipmi.AuthenticationAlgorithmHMACSHA1,
+		IntegrityAlgorithm:       ipmi.IntegrityAlgorithmHMACSHA196,
+		ConfidentialityAlgorithm: ipmi.ConfidentialityAlgorithmAESCBC128,
+		MaxPrivilegeLevel:        ipmi.PrivilegeLevelAdministrator,
+		AuthenticatedInbound:     10,
+		AuthenticatedOutbound:    9,
+		DryRun:                   true,
+		ReadOnly:                 true,
+	}
+	blob, err := sealResumption(want, key)
+	if err != nil {
+		t.Fatalf("sealResumption() failed: %v", err)
+	}
+	if bytes.Contains(blob, want.SIK) {
+		t.Error("sealResumption() output contains the SIK in plaintext")
+	}
+	got, err := openResumption(blob, key)
+	if err != nil {
+		t.Fatalf("openResumption() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("openResumption() = %v, want %v: %v", got, want, diff)
+	}
+
+	// each export must use a fresh nonce
+	other, err := sealResumption(want, key)
+	if err != nil {
+		t.Fatalf("sealResumption() failed: %v", err)
+	}
+	if bytes.Equal(blob, other) {
+		t.Error("sealResumption() is deterministic")
+	}
+}
+
+func TestOpenResumptionInvalid(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 16)
+	blob, err := sealResumption(&v2SessionResumption{}, key)
+	if err != nil {
+		t.Fatalf("sealResumption() failed: %v", err)
+	}
+
+	tampered := append([]byte(nil), blob...)
+	tampered[len(tampered)-1] ^= 1
+	tests := []struct {
+		name string
+		blob []byte
+		key  []byte
+	}{
+		{"wrong key", blob, bytes.Repeat([]byte{0x43}, 16)},
+		{"tampered", tampered, key},
+		{"truncated", blob[:10], key},
+	}
+	for _, test := range tests {
+		if _, err := openResumption(test.blob, test.key); !errors.Is(err, ErrInvalidResumption) {
+			t.Errorf("openResumption() with %v = %v, want %v", test.name, err,
+				ErrInvalidResumption)
+		}
+	}
+
+	if _, err := openResumption(blob, key[:5]); err == nil {
+		t.Error("openResumption() with 5-byte key succeeded, want error")
+	}
+}
+
+func TestResumeV2Session(t *testing.T) {
+	simBMC, exported := newTestSession(t, &sim.Config{
+		GUID: [16]byte{0x1, 0x2, 0x3},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := exported.GetSystemGUID(ctx); err != nil {
+		t.Fatalf("GetSystemGUID() failed: %v", err)
+	}
+	key := []byte("0123456789abcdef")
+	blob, err := exported.Export(key)
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	if _, err := exported.GetSystemGUID(ctx); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("GetSystemGUID() after Export() = %v, want %v", err,
+			ErrSessionClosed)
+	}
+	if _, err := exported.Export(key); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("Export() after Export() = %v, want %v", err,
+			ErrSessionClosed)
+	}
+
+	// a new connection, as if in another process
+	machine, err := DialV2(simBMC.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer machine.Close()
+
+	if _, err := machine.ResumeV2Session(blob, []byte("fedcba9876543210"), nil); !errors.Is(err, ErrInvalidResumption) {
+		t.Errorf("ResumeV2Session() with wrong key = %v, want %v", err,
+			ErrInvalidResumption)
+	}
+	sess, err := machine.ResumeV2Session(blob, key, nil)
+	if err != nil {
+		t.Fatalf("ResumeV2Session() failed: %v", err)
+	}
+	defer sess.Close(ctx)
+	guid, err := sess.GetSystemGUID(ctx)
+	if err != nil {
+		t.Fatalf("GetSystemGUID() in resumed session failed: %v", err)
+	}
+	if guid[2] != 0x3 {
+		t.Errorf("GetSystemGUID() = %v, want %v", guid, [16]byte{0x1, 0x2, 0x3})
+	}
+}
+
+func TestResumeV2SessionAuditSink(t *testing.T) {
+	simBMC, exported := newTestSessionWithOpts(t, &sim.Config{}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+			AuditSink:         &recordingAuditSink{},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := []byte("0123456789abcdef")
+	blob, err := exported.Export(key)
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+
+	machine, err := DialV2(simBMC.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer machine.Close()
+
+	// resuming without a sink must not silently stop auditing
+	if _, err := machine.ResumeV2Session(blob, key, nil); !errors.Is(err, ErrAuditSinkRequired) {
+		t.Errorf("ResumeV2Session() without audit sink = %v, want %v", err,
+			ErrAuditSinkRequired)
+	}
+	sink := &recordingAuditSink{}
+	sess, err := machine.ResumeV2Session(blob, key, &ResumeOpts{
+		AuditSink: sink,
+	})
+	if err != nil {
+		t.Fatalf("ResumeV2Session() failed: %v", err)
+	}
+	defer sess.Close(ctx)
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOff); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	if len(sink.records) != 1 || sink.records[0].Command != "Chassis Control" {
+		t.Errorf("audit records = %+v, want 1 Chassis Control", sink.records)
+	}
+}
+
+func TestCloseExportRace(t *testing.T) {
+	simBMC, machine := newTestTransport(t, &sim.Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	open := testutil.ToFloat64(sessionsOpen)
+	for i := 0; i < 100; i++ {
+		sess, err := machine.NewV2Session(ctx, &V2SessionOpts{
+			SessionOpts: SessionOpts{
+				Username:          "admin",
+				Password:          []byte("hunter2"),
+				MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+			},
+		})
+		if err != nil {
+			t.Fatalf("NewV2Session() failed: %v", err)
+		}
+
+		// exactly one of the calls must take effect
+		closedBefore := simBMC.SessionsClosed()
+		start := make(chan struct{})
+		closes := make(chan error, 2)
+		exported := make(chan error, 1)
+		for j := 0; j < 2; j++ {
+			go func() {
+				<-start
+				closes <- sess.Close(ctx)
+			}()
+		}
+		go func() {
+			<-start
+			_, err := sess.Export([]byte("0123456789abcdef"))
+			exported <- err
+		}()
+		close(start)
+
+		succeeded := 0
+		for _, err := range []error{<-closes, <-closes, <-exported} {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, ErrSessionClosed):
+				t.Fatalf("Close() or Export() = %v, want nil or %v", err,
+					ErrSessionClosed)
+			}
+		}
+		if succeeded != 1 {
+			t.Fatalf("%v of Close(), Close() and Export() succeeded, want 1",
+				succeeded)
+		}
+		if got := simBMC.SessionsClosed() - closedBefore; got > 1 {
+			t.Fatalf("BMC closed the session %v times", got)
+		}
+	}
+	if got := testutil.ToFloat64(sessionsOpen); got != open {
+		t.Errorf("sessions open = %v, want %v", got, open)
+	}
+}
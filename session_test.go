@@ -0,0 +1,59 @@
+package bmc
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPasswordCompatibilityCandidates(t *testing.T) {
+	password21 := []byte("abcdefghijklmnopqrstu")
+	tests := []struct {
+		compat   PasswordCompatibility
+		password []byte
+		want     [][]byte
+	}{
+		{
+			PasswordCompatibilityAuto,
+			[]byte("short"),
+			[][]byte{[]byte("short")},
+		},
+		{
+			PasswordCompatibilityAuto,
+			password21[:18],
+			[][]byte{password21[:18], password21[:16]},
+		},
+		{
+			PasswordCompatibilityAuto,
+			password21,
+			[][]byte{password21, password21[:20], password21[:16]},
+		},
+		{
+			PasswordCompatibilityExact,
+			password21,
+			[][]byte{password21},
+		},
+		{
+			PasswordCompatibilityTruncate20,
+			password21,
+			[][]byte{password21[:20]},
+		},
+		{
+			PasswordCompatibilityTruncate16,
+			password21,
+			[][]byte{password21[:16]},
+		},
+		{
+			PasswordCompatibilityTruncate16,
+			nil,
+			[][]byte{nil},
+		},
+	}
+	for _, test := range tests {
+		got := test.compat.candidates(test.password)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("%v.candidates(%q) = %q, want %q: %v", test.compat,
+				test.password, got, test.want, diff)
+		}
+	}
+}
@@ -2,8 +2,17 @@ package bmc
 
 import (
 	"context"
+	"time"
 
 	"github.com/kuiwang02/bmc/internal/pkg/transport"
+
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// closeSessionsTimeout is the time allowed for closing all open sessions
+	// when closing a V2SessionlessTransport.
+	closeSessionsTimeout = time.Second * 5
 )
 
 // SessionlessTransport represents a session-less IPMI v1.5 or v2.0 LAN
@@ -27,6 +36,11 @@ type SessionlessTransport interface {
 	// NewSession opens a new session to the BMC using the underlying wrapper
 	// format. NewSession uses the sessionless methods for establishment.
 	NewSession(ctx context.Context, opts *SessionOpts) (Session, error)
+
+	// PresencePing sends an ASF Presence Ping to the BMC, returning its
+	// Presence Pong. This checks the BMC is reachable without involving the
+	// IPMI stack.
+	PresencePing(context.Context) (*layers.ASFPresencePong, error)
 }
 
 // V2SessionlessTransport is a session-less connection to a BMC using an IPMI
@@ -37,7 +51,28 @@ type V2SessionlessTransport struct {
 	*V2Sessionless
 }
 
+// MaxPacketSize returns the largest UDP payload in bytes that the connection
+// and its sessions will send; see DialOpts.MaxPacketSize. Callers building
+// large raw commands or OEM payloads can use it to split their data.
+func (s *V2SessionlessTransport) MaxPacketSize() int {
+	return s.demux.maxPacketSize
+}
+
+// Close closes any sessions established or resumed on the connection that have
+// not been closed, then the transport. Sessions are closed one at a time, with
+// closeSessionsTimeout allowed for all of them; the BMC will eventually time
+// out any that could not be closed. Further use of the connection or its
+// sessions returns ErrTransportClosed.
 func (s *V2SessionlessTransport) Close() error {
 	defer v2ConnectionsOpen.Dec()
+	if sessions := s.openSessions(); len(sessions) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(),
+			closeSessionsTimeout)
+		for _, sess := range sessions {
+			// errors are not returned, as the transport is closed regardless
+			_ = sess.Close(ctx)
+		}
+		cancel()
+	}
 	return s.Transport.Close()
 }
@@ -0,0 +1,93 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+func TestCloseTransportClosesSessions(t *testing.T) {
+	simBMC, machine := newTestTransport(t, &sim.Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sessions := []Session{}
+	for i := 0; i < 3; i++ {
+		sess, err := machine.NewSession(ctx, &SessionOpts{
+			Username:          "admin",
+			Password:          []byte("hunter2"),
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		})
+		if err != nil {
+			t.Fatalf("NewSession() failed: %v", err)
+		}
+		sessions = append(sessions, sess)
+	}
+	// closed sessions are not closed again
+	if err := sessions[0].Close(ctx); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if err := machine.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if got := simBMC.SessionsClosed(); got != 3 {
+		t.Errorf("SessionsClosed() = %v, want 3", got)
+	}
+	for _, sess := range sessions {
+		if _, err := sess.GetDeviceID(ctx); !errors.Is(err, ErrTransportClosed) {
+			t.Errorf("GetDeviceID() after closing transport = %v, want %v",
+				err, ErrTransportClosed)
+		}
+	}
+	if _, err := machine.GetSystemGUID(ctx); !errors.Is(err, ErrTransportClosed) {
+		t.Errorf("GetSystemGUID() after Close() = %v, want %v", err,
+			ErrTransportClosed)
+	}
+}
+
+func TestMaxPacketSize(t *testing.T) {
+	simBMC, machine := newTestTransport(t, &sim.Config{})
+	// the simulator listens on loopback, whose MTU exceeds Ethernet's
+	if got := machine.MaxPacketSize(); got != 1472 {
+		t.Errorf("MaxPacketSize() = %v, want 1472", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	limited, err := DialV2Context(ctx, simBMC.Addr(), &DialOpts{
+		MaxPacketSize: 64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer limited.Close()
+	if got := limited.MaxPacketSize(); got != 64 {
+		t.Errorf("MaxPacketSize() = %v, want 64", got)
+	}
+
+	// the simulator ignores the request data of Get System GUID
+	getSystemGUID := Command[*gopacket.Payload, *gopacket.Payload]{
+		Name:      "Get System GUID",
+		Operation: ipmi.OperationGetSystemGUIDReq,
+	}
+	large := gopacket.Payload(make([]byte, 64))
+	if _, err := getSystemGUID.Send(ctx, limited, &large); !errors.Is(err, ErrPacketTooLarge) {
+		t.Errorf("Send() of %v byte request = %v, want %v", len(large), err,
+			ErrPacketTooLarge)
+	}
+	if _, err := getSystemGUID.Send(ctx, machine, &large); err != nil {
+		t.Errorf("Send() of %v byte request with default limit failed: %v",
+			len(large), err)
+	}
+	if _, err := getSystemGUID.Send(ctx, limited, nil); err != nil {
+		t.Errorf("Send() of empty request failed: %v", err)
+	}
+}
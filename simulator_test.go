@@ -0,0 +1,74 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// newTestTransport starts a simulated BMC with the provided config and dials
+// it. The Username and Password default to "admin" and "hunter2"
+// respectively. Both are closed when the test finishes.
+func newTestTransport(t *testing.T, config *sim.Config) (*sim.BMC, *V2SessionlessTransport) {
+	t.Helper()
+	if config.Username == "" {
+		config.Username = "admin"
+	}
+	if config.Password == "" {
+		config.Password = "hunter2"
+	}
+	simBMC, err := sim.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { simBMC.Close() })
+
+	machine, err := DialV2(simBMC.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { machine.Close() })
+	return simBMC, machine
+}
+
+// newTestSession starts a simulated BMC with the provided config, and
+// establishes an administrator session with it using the configured
+// credentials. The session is closed when the test finishes.
+func newTestSession(t *testing.T, config *sim.Config) (*sim.BMC, *V2Session) {
+	t.Helper()
+	return newTestSessionWithOpts(t, config, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		},
+	})
+}
+
+// newTestSessionWithOpts is like newTestSession, but establishes the session
+// with the provided options. The configured credentials are used if opts
+// specifies none.
+func newTestSessionWithOpts(t *testing.T, config *sim.Config, opts *V2SessionOpts) (*sim.BMC, *V2Session) {
+	t.Helper()
+	simBMC, machine := newTestTransport(t, config)
+	if opts.Username == "" {
+		opts.Username = config.Username
+	}
+	if opts.Password == nil {
+		opts.Password = []byte(config.Password)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sess, err := machine.NewV2Session(ctx, opts)
+	if err != nil {
+		t.Fatalf("NewV2Session() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		sess.Close(ctx)
+	})
+	return simBMC, sess
+}
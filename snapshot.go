@@ -0,0 +1,102 @@
+package bmc
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// SaveSDRRepository writes every record in the BMC's SDR Repository to w, with
+// the same consistency guarantees as RetrieveSDRRepository(). The format is
+// each record, including its 5-byte header, one after the other, which is the
+// same as ipmitool's "sdr dump" command, so files can be loaded by either.
+// Nothing is written if an error is returned.
+func SaveSDRRepository(ctx context.Context, s Session, w io.Writer) error {
+	var records [][]byte
+	err := retrySDRWalk(ctx, s, func() error {
+		records = nil
+		return walkRawSDRs(ctx, s, func(_ ipmi.RecordID, data []byte) error {
+			records = append(records, append([]byte(nil), data...))
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if _, err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSDRRepository decodes an SDR Repository written by SaveSDRRepository()
+// or ipmitool's "sdr dump", allowing sensors to be interpreted offline. As
+// with RetrieveSDRRepository(), only Full Sensor Records are returned. Records
+// are indexed by the ID in their header.
+func LoadSDRRepository(r io.Reader) (SDRRepository, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	repo := SDRRepository{}
+	for offset := 0; offset < len(data); {
+		// record ID (2), version, type, remaining length
+		if len(data)-offset < 5 {
+			return nil, fmt.Errorf("truncated SDR header at offset %v", offset)
+		}
+		end := offset + 5 + int(data[offset+4])
+		if end > len(data) {
+			return nil, fmt.Errorf("SDR at offset %v is %v bytes, but only %v "+
+				"remain", offset, end-offset, len(data)-offset)
+		}
+		fsr, err := decodeFullSensorRecord(data[offset:end])
+		if err != nil {
+			return nil, fmt.Errorf("SDR at offset %v: %w", offset, err)
+		}
+		if fsr != nil {
+			id := ipmi.RecordID(binary.LittleEndian.Uint16(data[offset:]))
+			repo[id] = fsr
+		}
+		offset = end
+	}
+	return repo, nil
+}
+
+// SaveFRU writes the entire contents of a FRU Inventory Device to w. This is
+// the same format as ipmitool's "fru read" command. The size of the device is
+// as reported by the BMC, so may include unused space after the last area.
+// Nothing is written if an error is returned.
+func SaveFRU(ctx context.Context, s Session, deviceID uint8, w io.Writer) error {
+	r, err := newFRUReader(ctx, s, deviceID)
+	if err != nil {
+		return err
+	}
+	data, err := r.read(ctx, 0, r.size)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// LoadFRUInventory decodes the information areas of a FRU Inventory Device
+// image written by SaveFRU() or ipmitool's "fru read", as ReadFRUInventory()
+// would for a live BMC.
+func LoadFRUInventory(r io.Reader) (*FRUInventory, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFRUInventory(func(offset, n int) ([]byte, error) {
+		if offset+n > len(data) {
+			return nil, fmt.Errorf("cannot read %v bytes at offset %v from "+
+				"FRU image of %v bytes", n, offset, len(data))
+		}
+		return data[offset : offset+n : offset+n], nil
+	})
+}
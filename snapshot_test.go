@@ -0,0 +1,38 @@
+package bmc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLoadSDRRepositoryTruncated(t *testing.T) {
+	tests := [][]byte{
+		// header
+		{0x01, 0x00, 0x51},
+		// body
+		{0x01, 0x00, 0x51, 0x01, 0x10, 0x20},
+	}
+	for _, test := range tests {
+		if _, err := LoadSDRRepository(bytes.NewReader(test)); err == nil {
+			t.Errorf("LoadSDRRepository(%v) succeeded, want error", test)
+		}
+	}
+}
+
+func TestLoadSDRRepositoryEmpty(t *testing.T) {
+	repo, err := LoadSDRRepository(bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("LoadSDRRepository() failed: %v", err)
+	}
+	if len(repo) != 0 {
+		t.Errorf("LoadSDRRepository() = %v, want empty", repo)
+	}
+}
+
+func TestLoadFRUInventoryTruncated(t *testing.T) {
+	// common header pointing to a board area beyond the end of the image
+	image := []byte{0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0xfe}
+	if _, err := LoadFRUInventory(bytes.NewReader(image)); err == nil {
+		t.Errorf("LoadFRUInventory(%v) succeeded, want error", image)
+	}
+}
@@ -0,0 +1,107 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// systemInfoBlockLength is the number of bytes of a string system info
+	// parameter in each block, excluding the set selector.
+	systemInfoBlockLength = 16
+
+	// systemInfoMaxStringLength is the longest string that can be described
+	// by the length byte in the first block.
+	systemInfoMaxStringLength = 0xff
+)
+
+// GetSystemInfoString retrieves a string system info parameter, reassembling
+// it from as many blocks as required. This works for the parameters specified
+// in table 22-16a of IPMI v2.0 other than Set In Progress, and OEM parameters
+// using the same format, e.g. the LCD string of Dell servers.
+func GetSystemInfoString(ctx context.Context, c Connection, p ipmi.SystemInfoParameter) (string, error) {
+	cmd := &ipmi.GetSystemInfoParametersCmd{
+		Req: ipmi.GetSystemInfoParametersReq{
+			Parameter: p,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return "", err
+	}
+
+	// first block: set selector, encoding, string length, up to 14 bytes
+	data := cmd.Rsp.LayerPayload()
+	if len(data) < 3 {
+		return "", fmt.Errorf("first block of string parameter must be at "+
+			"least 3 bytes, got %v", len(data))
+	}
+	encoding := data[1] & 0xf
+	length := int(data[2])
+	str := make([]byte, 0, length)
+	str = append(str, data[3:]...)
+
+	// subsequent blocks: set selector, up to 16 bytes
+	for cmd.Req.SetSelector = 1; len(str) < length; cmd.Req.SetSelector++ {
+		if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+			return "", err
+		}
+		data := cmd.Rsp.LayerPayload()
+		if len(data) < 2 {
+			return "", fmt.Errorf("block %v of string parameter is empty",
+				cmd.Req.SetSelector)
+		}
+		str = append(str, data[1:]...)
+	}
+	if len(str) > length {
+		// final block is padded
+		str = str[:length]
+	}
+	return decodeSystemInfoString(encoding, str)
+}
+
+// SetSystemInfoString sets a string system info parameter, splitting it into
+// as many blocks as required, each sent in its own Set System Info Parameters
+// command. The string is encoded as ASCII+Latin1 if possible, otherwise UTF-8,
+// and the final block padded with zeros.
+func SetSystemInfoString(ctx context.Context, c Connection, p ipmi.SystemInfoParameter, s string) error {
+	encoding, str := encodeSystemInfoString(s)
+	if len(str) > systemInfoMaxStringLength {
+		return fmt.Errorf("encoded string must be at most %v bytes, got %v",
+			systemInfoMaxStringLength, len(str))
+	}
+
+	// the first block's encoding and length bytes displace string bytes
+	data := append([]byte{encoding, uint8(len(str))}, str...)
+	for selector := 0; len(data) > 0; selector++ {
+		block := make([]byte, 1+systemInfoBlockLength)
+		block[0] = uint8(selector)
+		data = data[copy(block[1:], data):]
+		cmd := &ipmi.SetSystemInfoParametersCmd{
+			Req: ipmi.SetSystemInfoParametersReq{
+				Parameter: p,
+				Data:      gopacket.Payload(block),
+			},
+		}
+		if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeSystemInfoString returns the encoding and bytes of a string system
+// info parameter, preferring ASCII+Latin1 as it is most widely supported.
+func encodeSystemInfoString(s string) (uint8, []byte) {
+	latin1 := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			return 1, []byte(s) // UTF-8
+		}
+		latin1 = append(latin1, byte(r))
+	}
+	return 0, latin1
+}
@@ -0,0 +1,31 @@
+package bmc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeSystemInfoString(t *testing.T) {
+	tests := []struct {
+		in           string
+		wantEncoding uint8
+		want         []byte
+	}{
+		{"", 0, []byte{}},
+		{"R740-01", 0, []byte("R740-01")},
+		{"café", 0, []byte{0x63, 0x61, 0x66, 0xe9}},
+		{"rack €", 1, []byte("rack €")},
+	}
+	for _, test := range tests {
+		encoding, got := encodeSystemInfoString(test.in)
+		if encoding != test.wantEncoding || !bytes.Equal(got, test.want) {
+			t.Errorf("encodeSystemInfoString(%q) = %v, %v, want %v, %v",
+				test.in, encoding, got, test.wantEncoding, test.want)
+			continue
+		}
+		if decoded, err := decodeSystemInfoString(encoding, got); err != nil || decoded != test.in {
+			t.Errorf("decodeSystemInfoString(%v, %v) = %q, %v, want %q",
+				encoding, got, decoded, err, test.in)
+		}
+	}
+}
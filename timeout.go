@@ -0,0 +1,217 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// commandTimeoutKey is the context key under which a per-attempt timeout
+// override is stored.
+type commandTimeoutKey struct{}
+
+// WithCommandTimeout returns a context that overrides the connection's
+// per-request timeout (see SetTimeout()) for any command sent with it. This is
+// intended for commands that legitimately take longer than usual for the BMC to
+// respond to, e.g. Clear SEL on a large log, or Cold Reset. Note the returned
+// context does not itself have a deadline - the overall time allowed including
+// retries is still governed by the parent's.
+func WithCommandTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, commandTimeoutKey{}, timeout)
+}
+
+// commandTimeout returns the per-attempt timeout to use for a request sent with
+// ctx, falling back to def if the context does not override it.
+func commandTimeout(ctx context.Context, def time.Duration) time.Duration {
+	if timeout, ok := ctx.Value(commandTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return def
+}
+
+// Timeouts describes the time allowed for commands sent with a context.
+type Timeouts struct {
+
+	// Attempt is the per-attempt timeout set with WithCommandTimeout(), or 0
+	// if the connection's timeout applies.
+	Attempt time.Duration
+
+	// Remaining is the time until the context's deadline, which bounds all
+	// attempts of a command, including retries. It is only meaningful if
+	// HasDeadline is true, and is negative once the deadline has passed.
+	Remaining time.Duration
+
+	// HasDeadline indicates the context has a deadline. If false, a command
+	// is retried until it succeeds or fails permanently.
+	HasDeadline bool
+}
+
+// ContextTimeouts returns the timeouts that apply to commands sent with ctx.
+func ContextTimeouts(ctx context.Context) Timeouts {
+	t := Timeouts{}
+	if timeout, ok := ctx.Value(commandTimeoutKey{}).(time.Duration); ok {
+		t.Attempt = timeout
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		t.Remaining = time.Until(deadline)
+		t.HasDeadline = true
+	}
+	return t
+}
+
+// WithTimeouts returns a context that limits each attempt of a command to
+// attempt, and the whole command, including retries, to total. It is
+// equivalent to calling WithCommandTimeout() on the result of
+// context.WithTimeout(). The cancel function must be called once the context
+// is no longer needed.
+func WithTimeouts(ctx context.Context, attempt, total time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, total)
+	return WithCommandTimeout(ctx, attempt), cancel
+}
+
+// ErrTimeout is matched by every TimeoutError, so timeouts can be identified
+// regardless of their stage or underlying error.
+var ErrTimeout = errors.New("timeout")
+
+// TimeoutStage is the part of a command exchange in progress when it timed
+// out.
+type TimeoutStage uint8
+
+const (
+	// TimeoutStageSend indicates the request could not be written to the
+	// socket in time.
+	TimeoutStageSend TimeoutStage = iota
+
+	// TimeoutStageAwaitResponse indicates the request was sent, but no
+	// response was received in time.
+	TimeoutStageAwaitResponse
+
+	// TimeoutStageDecode indicates responses were received, but none could be
+	// decoded or matched to the request before the deadline, e.g. because the
+	// BMC's integrity checks fail.
+	TimeoutStageDecode
+)
+
+func (s TimeoutStage) String() string {
+	switch s {
+	case TimeoutStageSend:
+		return "send"
+	case TimeoutStageAwaitResponse:
+		return "await response"
+	case TimeoutStageDecode:
+		return "decode"
+	default:
+		return fmt.Sprintf("TimeoutStage(%d)", uint8(s))
+	}
+}
+
+// TimeoutError is returned when a command or RMCP+ exchange times out, in place
+// of a generic deadline or i/o timeout error. It wraps the underlying error, so
+// errors.Is(err, context.DeadlineExceeded) continues to work for deadlines
+// from the context.
+type TimeoutError struct {
+
+	// Stage is the part of the exchange that timed out.
+	Stage TimeoutStage
+
+	// Elapsed is the time from the start of the attempt that timed out. For
+	// TimeoutStageDecode, it is the time since the first attempt, as the
+	// overall deadline passed across several attempts.
+	Elapsed time.Duration
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v timed out after %v: %v", e.Stage,
+		e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns whether target is ErrTimeout, or ErrDecode for
+// TimeoutStageDecode.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout ||
+		(target == ErrDecode && e.Stage == TimeoutStageDecode)
+}
+
+// Timeout always returns true, allowing TimeoutError to satisfy net.Error.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary always returns true, as a retry may succeed.
+func (e *TimeoutError) Temporary() bool {
+	return true
+}
+
+// isTimeout returns whether err is due to a deadline passing, either that of a
+// context or of the socket.
+func isTimeout(err error) bool {
+	netErr := net.Error(nil)
+	return errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// stageTimeoutError wraps err in a TimeoutError for the stage if it is a
+// timeout, otherwise returning it unchanged.
+func stageTimeoutError(stage TimeoutStage, start time.Time, err error) error {
+	if err == nil || !isTimeout(err) {
+		return err
+	}
+	return &TimeoutError{
+		Stage:   stage,
+		Elapsed: time.Since(start),
+		Err:     err,
+	}
+}
+
+// retryTimeoutError converts the error returned by backoff.Retry() once a
+// command's deadline passes into a TimeoutError, using the error of the final
+// attempt to determine the stage. If the final attempt received a temporary
+// completion code, the deadline is instead wrapped with ErrTimeout and that
+// attempt's error, so it also matches ErrBMCBusy. err is returned unchanged if
+// it is not a deadline.
+func retryTimeoutError(err, last error, start time.Time) error {
+	timeoutErr := (*TimeoutError)(nil)
+	switch {
+	case !errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &timeoutErr),
+		last == nil:
+		return err
+	case errors.Is(last, errRetryableCode):
+		return fmt.Errorf("%w: %w (last attempt: %w)", ErrTimeout, err, last)
+	case errors.As(last, &timeoutErr):
+		return last
+	default:
+		return &TimeoutError{
+			Stage:   TimeoutStageDecode,
+			Elapsed: time.Since(start),
+			Err:     fmt.Errorf("%w (last response: %w)", err, last),
+		}
+	}
+}
+
+// discardedTimeoutError converts a timeout awaiting a response into one with
+// TimeoutStageDecode if packets were received but discarded as not being the
+// response, including why the last was discarded. start is when the command
+// was first sent. err is returned unchanged if discarded is nil or it is not
+// a timeout awaiting a response.
+func discardedTimeoutError(err, discarded error, start time.Time) error {
+	timeoutErr := (*TimeoutError)(nil)
+	if discarded == nil || !errors.As(err, &timeoutErr) ||
+		timeoutErr.Stage != TimeoutStageAwaitResponse {
+		return err
+	}
+	return &TimeoutError{
+		Stage:   TimeoutStageDecode,
+		Elapsed: time.Since(start),
+		Err:     fmt.Errorf("%w (last response: %w)", timeoutErr.Err, discarded),
+	}
+}
@@ -0,0 +1,125 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestContextTimeouts(t *testing.T) {
+	if got := ContextTimeouts(context.Background()); got != (Timeouts{}) {
+		t.Errorf("ContextTimeouts(Background) = %+v, want zero value", got)
+	}
+
+	ctx, cancel := WithTimeouts(context.Background(), time.Second, time.Minute)
+	defer cancel()
+	got := ContextTimeouts(ctx)
+	if got.Attempt != time.Second {
+		t.Errorf("Attempt = %v, want %v", got.Attempt, time.Second)
+	}
+	if !got.HasDeadline || got.Remaining <= 0 || got.Remaining > time.Minute {
+		t.Errorf("Remaining = %v, HasDeadline = %v, want up to %v", got.Remaining,
+			got.HasDeadline, time.Minute)
+	}
+}
+
+func TestRetryTimeoutError(t *testing.T) {
+	start := time.Now()
+	attemptErr := &TimeoutError{
+		Stage: TimeoutStageAwaitResponse,
+		Err:   context.DeadlineExceeded,
+	}
+	decodeErr := errors.New("invalid checksum")
+	tests := []struct {
+		name      string
+		err, last error
+		wantStage TimeoutStage
+		wantErr   error
+	}{
+		{
+			name:    "not a timeout",
+			err:     decodeErr,
+			last:    decodeErr,
+			wantErr: decodeErr,
+		},
+		{
+			name:    "no attempt",
+			err:     context.DeadlineExceeded,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "attempt timed out",
+			err:     context.DeadlineExceeded,
+			last:    attemptErr,
+			wantErr: attemptErr,
+		},
+		{
+			name:      "undecodable responses",
+			err:       context.DeadlineExceeded,
+			last:      decodeErr,
+			wantStage: TimeoutStageDecode,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := retryTimeoutError(test.err, test.last, start)
+			if test.wantErr != nil {
+				if err != test.wantErr {
+					t.Errorf("retryTimeoutError() = %v, want %v", err,
+						test.wantErr)
+				}
+				return
+			}
+			timeoutErr := (*TimeoutError)(nil)
+			if !errors.As(err, &timeoutErr) {
+				t.Fatalf("retryTimeoutError() = %v, want TimeoutError", err)
+			}
+			if timeoutErr.Stage != test.wantStage {
+				t.Errorf("Stage = %v, want %v", timeoutErr.Stage, test.wantStage)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("%v does not wrap context.DeadlineExceeded", err)
+			}
+		})
+	}
+}
+
+func TestRetryTimeoutErrorBusy(t *testing.T) {
+	err := retryTimeoutError(context.DeadlineExceeded, errRetryableCode,
+		time.Now())
+	for _, target := range []error{context.DeadlineExceeded, ErrTimeout,
+		ErrBMCBusy} {
+		if !errors.Is(err, target) {
+			t.Errorf("retryTimeoutError() = %v, want match for %v", err,
+				target)
+		}
+	}
+}
+
+func TestTimeoutErrorIs(t *testing.T) {
+	for _, stage := range []TimeoutStage{TimeoutStageSend,
+		TimeoutStageAwaitResponse, TimeoutStageDecode} {
+		err := &TimeoutError{
+			Stage: stage,
+			Err:   context.DeadlineExceeded,
+		}
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("%v does not match ErrTimeout", err)
+		}
+		if got, want := errors.Is(err, ErrDecode), stage == TimeoutStageDecode; got != want {
+			t.Errorf("errors.Is(%v, ErrDecode) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestTimeoutErrorIsSessionError(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &TimeoutError{
+		Stage: TimeoutStageSend,
+		Err:   errors.New("i/o timeout"),
+	})
+	if !isSessionError(err) {
+		t.Errorf("isSessionError(%v) = false, want true", err)
+	}
+}
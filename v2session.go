@@ -3,10 +3,14 @@ package bmc
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/kuiwang02/bmc/internal/pkg/sequencer"
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 	"github.com/kuiwang02/bmc/pkg/layerexts"
 
@@ -16,6 +20,34 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+var (
+	// ErrInsufficientPrivilege is returned without sending a command if the
+	// session's privilege level is below the minimum the specification
+	// requires for it. Without this check, many BMCs respond with an empty
+	// body, which surfaces as a confusing truncation error.
+	ErrInsufficientPrivilege = errors.New("session privilege level is " +
+		"insufficient for command")
+
+	// ErrSessionClosed is returned when sending a command with a session
+	// that has been closed, and from closing it again. If the session's
+	// connection has been closed, ErrTransportClosed is returned instead. It
+	// is also wrapped by Ping() if the BMC reports the session is no longer
+	// active.
+	ErrSessionClosed = errors.New("session closed")
+
+	// ErrReadOnlySession is returned without sending a command if the session
+	// was established with SessionOpts.ReadOnly, and the command changes the
+	// state of the managed system.
+	ErrReadOnlySession = errors.New("session is read-only")
+
+	// ErrSequenceNumbersExhausted is returned without sending a command if the
+	// session has used almost all of its 2^32 sequence numbers. They cannot
+	// wrap without allowing replay, and IPMI has no means of re-keying, so
+	// the session must be closed, which remains possible, and re-established.
+	ErrSequenceNumbersExhausted = errors.New("session sequence numbers " +
+		"exhausted; re-establish the session")
+)
+
 // V2Session represents an established IPMI v2.0/RMCP+ session with a BMC.
 type V2Session struct {
 	v2ConnectionLayers
@@ -90,6 +122,99 @@ type V2Session struct {
 	// timeout is the time allowed per attempt of a command. The context passed
 	// in by the user controls end-to-end.
 	timeout time.Duration
+
+	// maxPrivilegeLevel is the maximum privilege level the BMC granted in
+	// response to our RMCP+ Open Session Request.
+	maxPrivilegeLevel ipmi.PrivilegeLevel
+
+	// bytesSent and bytesReceived are the total sizes of UDP payloads sent and
+	// received within this session, including retries.
+	bytesSent, bytesReceived uint64
+
+	// closed is set once Close() has been called, regardless of whether the
+	// BMC acknowledged it. It is protected by mu.
+	closed bool
+
+	// closing is set by Close() before sending Close Session, so a concurrent
+	// Close() or Export() finds the session already closed rather than
+	// closing or exporting it a second time. It is protected by mu.
+	closing bool
+
+	// oemPayloadHandlers contains the handlers registered with
+	// HandleOEMPayload(). It has its own lock, as mu is held for the duration
+	// of commands, which handlers may send.
+	oemPayloadHandlers   map[ipmi.PayloadDescriptor]OEMPayloadHandler
+	oemPayloadHandlersMu sync.RWMutex
+
+	// auditSink receives records of destructive commands. It is copied from
+	// SessionOpts, and may be nil.
+	auditSink AuditSink
+
+	// dryRun is copied from SessionOpts, and causes destructive commands to
+	// be audited without being sent.
+	dryRun bool
+
+	// readOnly is copied from SessionOpts, and causes state-changing
+	// commands to be rejected with ErrReadOnlySession.
+	readOnly bool
+
+	// stats backs the per-session metrics.
+	stats sessionStats
+}
+
+// V2SessionState is a point-in-time snapshot of a session's negotiated
+// parameters and counters. It is intended for debugging sessions that are
+// behaving unexpectedly without resorting to packet captures.
+type V2SessionState struct {
+
+	// LocalID is the remote console's session ID.
+	LocalID uint32
+
+	// RemoteID is the managed system's session ID.
+	RemoteID uint32
+
+	AuthenticationAlgorithm  ipmi.AuthenticationAlgorithm
+	IntegrityAlgorithm       ipmi.IntegrityAlgorithm
+	ConfidentialityAlgorithm ipmi.ConfidentialityAlgorithm
+
+	// MaxPrivilegeLevel is the maximum privilege level the BMC allowed for the
+	// session. This is the level in effect, as this library does not send Set
+	// Session Privilege Level.
+	MaxPrivilegeLevel ipmi.PrivilegeLevel
+
+	// InboundSequenceNumber and OutboundSequenceNumber are the last sequence
+	// numbers of authenticated packets sent to and received from the BMC
+	// respectively.
+	InboundSequenceNumber, OutboundSequenceNumber uint32
+
+	// UnauthenticatedInboundSequenceNumber and
+	// UnauthenticatedOutboundSequenceNumber are the equivalents for
+	// unauthenticated packets.
+	UnauthenticatedInboundSequenceNumber  uint32
+	UnauthenticatedOutboundSequenceNumber uint32
+
+	// BytesSent and BytesReceived are the total number of bytes of UDP payload
+	// exchanged within the session, including retries.
+	BytesSent, BytesReceived uint64
+}
+
+// State returns a snapshot of the session's current state. It must not be
+// called concurrently with sending a command.
+func (s *V2Session) State() V2SessionState {
+	return V2SessionState{
+		LocalID:                               s.LocalID,
+		RemoteID:                              s.RemoteID,
+		AuthenticationAlgorithm:               s.AuthenticationAlgorithm,
+		IntegrityAlgorithm:                    s.IntegrityAlgorithm,
+		ConfidentialityAlgorithm:              s.ConfidentialityAlgorithm,
+		MaxPrivilegeLevel:                     s.maxPrivilegeLevel,
+		InboundSequenceNumber:                 s.AuthenticatedSequenceNumbers.Inbound,
+		OutboundSequenceNumber:                s.AuthenticatedSequenceNumbers.Outbound,
+		UnauthenticatedInboundSequenceNumber:  s.UnauthenticatedSequenceNumbers.Inbound,
+		UnauthenticatedOutboundSequenceNumber: s.UnauthenticatedSequenceNumbers.Outbound,
+		BytesSent:                             s.bytesSent,
+		BytesReceived:                         s.bytesReceived,
+	}
 }
 
 // String returns a summary of the session's attributes on one line.
@@ -117,103 +242,356 @@ func (s *V2Session) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.Compl
 	defer timer.ObserveDuration()
 	commandAttempts.WithLabelValues(c.Name()).Inc()
 
+	// the layers are shared with any other sessions on the same connection
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := s.now()
+	if s.skipDryRun(c) {
+		if err := s.checkSendable(c); err != nil {
+			commandFailures.WithLabelValues(c.Name()).Inc()
+			s.audit(ctx, c, start, 0, err)
+			return 0, err
+		}
+		s.audit(ctx, c, start, ipmi.CompletionCodeNormal, nil)
+		return ipmi.CompletionCodeNormal, nil
+	}
 	if err := s.buildAndSend(ctx, c); err != nil {
 		commandFailures.WithLabelValues(c.Name()).Inc()
+		s.audit(ctx, c, start, 0, err)
 		return 0, err
 	}
 
 	code := s.messageLayer.CompletionCode
 
-	if c.Response() != nil {
-		if err := c.Response().DecodeFromBytes(s.messageLayer.LayerPayload(),
-			gopacket.NilDecodeFeedback); err != nil {
+	if err := decodeResponse(c, code, s.messageLayer.LayerPayload()); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		s.audit(ctx, c, start, code, err)
+		return code, err
+	}
+
+	s.audit(ctx, c, start, code, nil)
+	return code, nil
+}
+
+func (s *V2Session) SendCommandRaw(ctx context.Context, c ipmi.Command) (*ipmi.Message, error) {
+	timer := prometheus.NewTimer(commandDuration)
+	defer timer.ObserveDuration()
+	commandAttempts.WithLabelValues(c.Name()).Inc()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := s.now()
+	if s.skipDryRun(c) {
+		if err := s.checkSendable(c); err != nil {
+			commandFailures.WithLabelValues(c.Name()).Inc()
+			s.audit(ctx, c, start, 0, err)
+			return nil, err
+		}
+		// an empty response to the request; response network functions are
+		// always odd
+		op := *c.Operation()
+		op.Function |= 1
+		s.audit(ctx, c, start, ipmi.CompletionCodeNormal, nil)
+		return &ipmi.Message{
+			Operation:      op,
+			CompletionCode: ipmi.CompletionCodeNormal,
+		}, nil
+	}
+	if err := s.buildAndSend(ctx, c); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		s.audit(ctx, c, start, 0, err)
+		return nil, err
+	}
+
+	m := cloneMessage(&s.messageLayer)
+	if err := decodeResponse(c, m.CompletionCode, m.LayerPayload()); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		s.audit(ctx, c, start, m.CompletionCode, err)
+		return m, err
+	}
+	s.audit(ctx, c, start, m.CompletionCode, nil)
+	return m, nil
+}
+
+func (s *V2Session) SendAsync(ctx context.Context, c ipmi.Command) error {
+	commandAttempts.WithLabelValues(c.Name()).Inc()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the completion code is unknown, so the record only shows whether the
+	// request was sent
+	start := s.now()
+	if s.skipDryRun(c) {
+		err := s.checkSendable(c)
+		if err != nil {
 			commandFailures.WithLabelValues(c.Name()).Inc()
-			return code, err
 		}
+		s.audit(ctx, c, start, 0, err)
+		return err
 	}
+	if err := s.sendAsync(ctx, c); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		s.audit(ctx, c, start, 0, err)
+		return err
+	}
+	s.audit(ctx, c, start, 0, nil)
+	return nil
+}
 
-	return code, nil
+func (s *V2Session) sendAsync(ctx context.Context, c ipmi.Command) error {
+	if err := s.checkSendable(c); err != nil {
+		return err
+	}
+	request := s.newRequest(c)
+	if err := s.build(c, &request,
+		payloadProtectionFromContext(ctx)); err != nil {
+		return err
+	}
+	start := time.Now()
+	s.bytesSent += uint64(len(s.buffer.Bytes()))
+	if err := s.demux.Write(ctx, s.buffer.Bytes()); err != nil {
+		return stageTimeoutError(TimeoutStageSend, start, err)
+	}
+	atomic.AddUint64(&s.stats.commands, 1)
+	s.stats.active(s.now())
+	return nil
 }
 
-func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
-	s.rmcpLayer = layers.RMCP{
-		Version:  layers.RMCPVersion1,
-		Sequence: 0xFF, // do not send us an ACK
-		Class:    layers.RMCPClassIPMI,
+// checkSendable returns an error if the command cannot be sent within the
+// session, either because the session or its transport is closed, it is
+// read-only and the command changes state, or it has too low a privilege
+// level.
+func (s *V2Session) checkSendable(c ipmi.Command) error {
+	if s.demux.isClosed() {
+		return ErrTransportClosed
 	}
-	s.v2SessionLayer = ipmi.V2Session{
-		Encrypted:                true,
-		Authenticated:            true,
-		ID:                       s.RemoteID,
-		PayloadDescriptor:        ipmi.PayloadDescriptorIPMI,
-		IntegrityAlgorithm:       s.integrityAlgorithm,
-		ConfidentialityLayerType: s.confidentialityLayer.LayerType(),
+	if s.closed {
+		return ErrSessionClosed
+	}
+	if s.readOnly && commandEffect(c) >= ipmi.OperationEffectStateChanging {
+		return fmt.Errorf("%w: %v changes state", ErrReadOnlySession,
+			c.Name())
 	}
-	s.messageLayer = ipmi.Message{
-		Operation:     *c.Operation(),
-		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
-		RemoteLUN:     ipmi.LUNBMC,
-		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
-		Sequence:      1, // used at the session level
+	return checkPrivilegeLevel(c, s.maxPrivilegeLevel)
+}
+
+// skipDryRun returns whether the command must not be sent because the
+// session is a dry run and the command is destructive.
+func (s *V2Session) skipDryRun(c ipmi.Command) bool {
+	return s.dryRun && commandEffect(c) == ipmi.OperationEffectDestructive
+}
+
+func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
+	if err := s.checkSendable(c); err != nil {
+		return err
 	}
 
+	// the request keeps its sequence number across retries, so a late
+	// response to an earlier attempt is still accepted
+	request := s.newRequest(c)
+	protection := payloadProtectionFromContext(ctx)
+	start := time.Now()
+	last := error(nil)
 	firstAttempt := true
 	terminalErr := error(nil)
 	retryable := func() error {
 		if firstAttempt {
 			firstAttempt = false
+			atomic.AddUint64(&s.stats.commands, 1)
 		} else {
 			commandRetries.Inc()
+			atomic.AddUint64(&s.stats.retransmissions, 1)
 		}
 
-		// TODO handle AuthenticationAlgorithmNone properly
-		// TODO handle ConfidentialityAlgorithmNone properly
-		s.AuthenticatedSequenceNumbers.Inbound++
-		s.v2SessionLayer.Sequence = s.AuthenticatedSequenceNumbers.Inbound
-		if err := gopacket.SerializeLayers(s.buffer, serializeOptions,
-			&s.rmcpLayer,
-			// session selector only used when decoding
-			&s.v2SessionLayer,
-			s.confidentialityLayer,
-			&s.messageLayer,
-			serializableLayerOrEmpty(c.Request())); err != nil {
+		// the layers are also used for decoding, so must be rebuilt for each
+		// attempt, as a previous response may have overwritten them
+		if err := s.build(c, &request, protection); err != nil {
 			// this is not a retryable error
 			terminalErr = err
 			return nil
 		}
-		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
-		cancel()
+		requestCtx, cancel := context.WithTimeout(ctx, commandTimeout(ctx, s.timeout))
+		defer cancel()
+		s.bytesSent += uint64(len(s.buffer.Bytes()))
+		s.stats.active(s.now())
+		pending, err := s.send(requestCtx, s.LocalID)
 		if err != nil {
-			// session is now in an unknown state - if we send another command,
-			// some BMCs can tear their send buffer. The BMC may also ignore us
-			// completely if it does not support the command.
 			terminalErr = err
 			return nil
 		}
-		if _, err := s.decode(response, &s.layers); err != nil {
-			return err
-		}
-		types := layerexts.DecodedTypes(s.layers)
-		if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
-			return err
+		defer pending.close()
+
+		// packets that are not the response to this attempt, e.g. a late
+		// response to an earlier command, are discarded and we keep waiting;
+		// sending the request again could execute it twice
+		discarded := error(nil)
+		for {
+			response, err := pending.next(requestCtx)
+			s.bytesReceived += uint64(len(response))
+			if response != nil {
+				s.stats.active(s.now())
+			}
+			if err != nil {
+				// session is now in an unknown state - if we send another
+				// command, some BMCs can tear their send buffer. The BMC may
+				// also ignore us completely if it does not support the
+				// command.
+				terminalErr = discardedTimeoutError(err, discarded, start)
+				return nil
+			}
+			err = s.checkResponse(response, &request)
+			if err == nil {
+				break
+			}
+			if isPermanentDecodeError(err) {
+				terminalErr = err
+				return nil
+			}
+			discarded = err
 		}
+		observeChecksums(&s.messageLayer)
 		code := s.messageLayer.CompletionCode
 		// must increment here, otherwise we'll miss temporary codes at the
 		// higher levels
 		commandResponses.WithLabelValues(code.String()).Inc()
-		if code.IsTemporary() {
+		if code.IsTemporaryFor(c.Operation()) {
 			return errRetryableCode
 		}
 		return nil
 	}
 	s.backoff.Reset()
-	if err := backoff.Retry(retryable, backoff.WithContext(s.backoff, ctx)); err != nil {
-		return err
+	if err := backoff.Retry(func() error {
+		last = retryable()
+		return last
+	}, backoff.WithContext(s.backoff, ctx)); err != nil {
+		return retryTimeoutError(err, last, start)
 	}
 	return terminalErr
 }
 
+// newRequest returns the message layer of a request for a command, with the
+// next sequence number.
+func (s *V2Session) newRequest(c ipmi.Command) ipmi.Message {
+	return ipmi.Message{
+		Operation:              *c.Operation(),
+		RemoteAddress:          commandResponderAddress(c, s.responderAddress),
+		RemoteLUN:              commandLUN(c),
+		LocalAddress:           s.requesterAddress,
+		Sequence:               s.sequencer.Next(),
+		IgnoreInvalidChecksums: s.ignoreInvalidChecksums,
+	}
+}
+
+// build sets the layers of the session for a request, increments the
+// session's inbound sequence number, and serialises the packet into the
+// shared buffer.
+func (s *V2Session) build(c ipmi.Command, request *ipmi.Message, protection payloadProtection) error {
+	s.rmcpLayer = layers.RMCP{
+		Version:  layers.RMCPVersion1,
+		Sequence: 0xFF, // do not send us an ACK
+		Class:    layers.RMCPClassIPMI,
+	}
+	s.v2SessionLayer = ipmi.V2Session{
+		Encrypted:                !protection.unencrypted,
+		Authenticated:            !protection.unauthenticated,
+		ID:                       s.RemoteID,
+		PayloadDescriptor:        ipmi.PayloadDescriptorIPMI,
+		IntegrityAlgorithm:       s.integrityAlgorithm,
+		ConfidentialityLayerType: s.confidentialityLayer.LayerType(),
+	}
+	s.messageLayer = *request
+
+	// TODO handle AuthenticationAlgorithmNone properly
+	// TODO handle ConfidentialityAlgorithmNone properly
+	sequenceNumbers := &s.AuthenticatedSequenceNumbers
+	if !s.v2SessionLayer.Authenticated {
+		sequenceNumbers = &s.UnauthenticatedSequenceNumbers
+	}
+	// Close Session may use the numbers held back from other commands
+	reserve := uint32(closeSessionSequenceReserve)
+	if *c.Operation() == ipmi.OperationCloseSessionReq {
+		reserve = 0
+	}
+	sequence, ok := sequenceNumbers.nextInbound(reserve)
+	if !ok {
+		return ErrSequenceNumbersExhausted
+	}
+	s.v2SessionLayer.Sequence = sequence
+	return s.serialize(c)
+}
+
+// serialize builds the packet to send for a command into the shared buffer,
+// omitting the confidentiality layer if the session layer is not encrypted.
+func (s *V2Session) serialize(c ipmi.Command) error {
+	if !s.v2SessionLayer.Encrypted {
+		return gopacket.SerializeLayers(s.buffer, serializeOptions,
+			&s.rmcpLayer,
+			&s.v2SessionLayer,
+			&s.messageLayer,
+			serializableLayerOrEmpty(c.Request()))
+	}
+	return gopacket.SerializeLayers(s.buffer, serializeOptions,
+		&s.rmcpLayer,
+		// session selector only used when decoding
+		&s.v2SessionLayer,
+		s.confidentialityLayer,
+		&s.messageLayer,
+		serializableLayerOrEmpty(c.Request()))
+}
+
+// checkResponse decodes a packet received within the session, returning an
+// error if it is not the response to the request.
+func (s *V2Session) checkResponse(response []byte, request *ipmi.Message) error {
+	if _, err := s.decode(response, &s.layers); err != nil {
+		err = fmt.Errorf("%w: %w", ErrDecode, err)
+		if errors.Is(err, ipmi.ErrInvalidSignature) {
+			sessionPacketsDroppedIntegrity.Inc()
+		}
+		return err
+	}
+	if err := s.verifyInbound(); err != nil {
+		return err
+	}
+	types := layerexts.DecodedTypes(s.layers)
+	if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
+		return err
+	}
+	return sequencer.Match(request, &s.messageLayer)
+}
+
+// verifyInbound checks the session layer of a decoded packet is one we should
+// accept: it must be addressed to our session ID, be authenticated if an
+// integrity algorithm was negotiated (the signature itself is verified during
+// decoding), and have a sequence number we have not seen before. Packets
+// failing these checks are counted and an error returned, causing the packet
+// to be discarded.
+func (s *V2Session) verifyInbound() error {
+	if s.v2SessionLayer.ID != s.LocalID {
+		sessionPacketsDroppedSessionID.Inc()
+		return fmt.Errorf("packet has session ID %v, expected %v",
+			s.v2SessionLayer.ID, s.LocalID)
+	}
+	sequenceNumbers := &s.AuthenticatedSequenceNumbers
+	if !s.v2SessionLayer.Authenticated {
+		if s.IntegrityAlgorithm != ipmi.IntegrityAlgorithmNone {
+			sessionPacketsDroppedUnauthenticated.Inc()
+			return fmt.Errorf("received unauthenticated packet despite "+
+				"negotiating %v", s.IntegrityAlgorithm)
+		}
+		sequenceNumbers = &s.UnauthenticatedSequenceNumbers
+	}
+	if !sequenceNumbers.acceptOutbound(s.v2SessionLayer.Sequence) {
+		sessionPacketsDroppedSequence.Inc()
+		return fmt.Errorf("sequence number %v outside window or replayed "+
+			"(highest seen: %v)", s.v2SessionLayer.Sequence,
+			sequenceNumbers.Outbound)
+	}
+	return nil
+}
+
 func (s *V2Session) GetSystemGUID(ctx context.Context) ([16]byte, error) {
 	return getSystemGUID(ctx, s)
 }
@@ -251,6 +629,22 @@ func (s *V2Session) GetChassisStatus(ctx context.Context) (*ipmi.GetChassisStatu
 	return &cmd.Rsp, nil
 }
 
+func (s *V2Session) GetChassisCapabilities(ctx context.Context) (*ipmi.GetChassisCapabilitiesRsp, error) {
+	cmd := &ipmi.GetChassisCapabilitiesCmd{}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func (s *V2Session) GetPOHCounter(ctx context.Context) (*ipmi.GetPOHCounterRsp, error) {
+	cmd := &ipmi.GetPOHCounterCmd{}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
 func (s *V2Session) ChassisControl(ctx context.Context, c ipmi.ChassisControl) error {
 	cmd := &ipmi.ChassisControlCmd{
 		Req: ipmi.ChassisControlReq{
@@ -283,11 +677,65 @@ func (s *V2Session) GetSensorReading(ctx context.Context, sensor uint8) (*ipmi.G
 	return &cmd.Rsp, nil
 }
 
+func (s *V2Session) GetSensorReadingFactors(ctx context.Context, sensor uint8, reading uint8) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	cmd := &ipmi.GetSensorReadingFactorsCmd{
+		Req: ipmi.GetSensorReadingFactorsReq{
+			Number:  sensor,
+			Reading: reading,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func (s *V2Session) GetSystemFirmwareVersion(ctx context.Context) (string, error) {
+	return getSystemFirmwareVersion(ctx, s)
+}
+
+func (s *V2Session) ClearSEL(ctx context.Context, progress func(ipmi.ErasureProgress)) error {
+	return clearSEL(ctx, s, progress)
+}
+
+// Recv blocks until an unsolicited packet, e.g. SOL data, is received within
+// this session, or the context expires. Unlike commands, this is not subject to
+// the per-request timeout, so a context without a deadline can be used to wait
+// indefinitely. The first call diverts this session's unsolicited packets from
+// V2Sessionless.Unsolicited(); they are buffered until read, and dropped if the
+// buffer is full. Once the session or its transport is closed,
+// ErrTransportClosed is returned. Recv may be called concurrently with
+// SendCommand().
+func (s *V2Session) Recv(ctx context.Context) (UnsolicitedPacket, error) {
+	return s.demux.recv(ctx, s.demux.stream(s.LocalID))
+}
+
 func (s *V2Session) closeSession(ctx context.Context) error {
+	// mu cannot be held while sending Close Session, so the session is
+	// claimed for closing first
+	s.mu.Lock()
+	if s.demux.isClosed() {
+		s.mu.Unlock()
+		return ErrTransportClosed
+	}
+	if s.closed || s.closing {
+		s.mu.Unlock()
+		return ErrSessionClosed
+	}
+	s.closing = true
+	s.mu.Unlock()
+
 	// we decrement regardless of whether this command succeeds, as to not do so
 	// would be overly pessimistic - if it fails, there's nothing we can do;
 	// failures are better tracked as Close Session command errors
 	defer sessionsOpen.Dec()
+	defer s.demux.closeStream(s.LocalID)
+	defer func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+		s.removeSession(s)
+	}()
 	cmd := &ipmi.CloseSessionCmd{
 		Req: ipmi.CloseSessionReq{
 			ID: s.RemoteID,
@@ -0,0 +1,397 @@
+package bmc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// EstablishmentStep is a step of RMCP+ session establishment, each consisting
+// of a request and response exchanged with the BMC, specified in 13.17
+// through 13.23 of IPMI v2.0.
+type EstablishmentStep uint8
+
+const (
+	// EstablishmentStepCapabilities sends Get Channel Authentication
+	// Capabilities, and verifies the BMC supports IPMI v2.0.
+	EstablishmentStepCapabilities EstablishmentStep = iota
+
+	// EstablishmentStepOpenSession negotiates the algorithms of the session.
+	EstablishmentStepOpenSession
+
+	// EstablishmentStepRAKP1 exchanges random numbers, and verifies the BMC
+	// knows the password.
+	EstablishmentStepRAKP1
+
+	// EstablishmentStepRAKP3 proves the remote console knows the password,
+	// and verifies the BMC derived the same session integrity key.
+	EstablishmentStepRAKP3
+
+	// EstablishmentStepComplete indicates the session is established.
+	EstablishmentStepComplete
+)
+
+func (s EstablishmentStep) String() string {
+	switch s {
+	case EstablishmentStepCapabilities:
+		return "Get Channel Authentication Capabilities"
+	case EstablishmentStepOpenSession:
+		return "Open Session"
+	case EstablishmentStepRAKP1:
+		return "RAKP Message 1/2"
+	case EstablishmentStepRAKP3:
+		return "RAKP Message 3/4"
+	case EstablishmentStepComplete:
+		return "Complete"
+	default:
+		return fmt.Sprintf("Unknown(%v)", uint8(s))
+	}
+}
+
+// EstablishmentError is returned when RMCP+ session establishment fails,
+// identifying the step that failed. It wraps the underlying error, so
+// sentinels such as ErrIncorrectPassword can be matched with errors.Is().
+type EstablishmentError struct {
+	// Step is the step that failed.
+	Step EstablishmentStep
+
+	// Err is the reason the step failed.
+	Err error
+}
+
+func (e *EstablishmentError) Error() string {
+	return fmt.Sprintf("session establishment failed at %v: %v", e.Step,
+		e.Err)
+}
+
+func (e *EstablishmentError) Unwrap() error {
+	return e.Err
+}
+
+// establishmentExchanger sends the messages of RMCP+ session establishment,
+// returning the BMC's response. A non-OK status code is returned as a
+// *statusCodeError. It is implemented by V2Sessionless, and by canned
+// responses in tests.
+type establishmentExchanger interface {
+	GetChannelAuthenticationCapabilities(context.Context, *ipmi.GetChannelAuthenticationCapabilitiesReq) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error)
+	openSession(context.Context, *ipmi.OpenSessionReq) (*ipmi.OpenSessionRsp, error)
+	rakpMessage1(context.Context, *ipmi.RAKPMessage1) (*ipmi.RAKPMessage2, error)
+	rakpMessage3(context.Context, *ipmi.RAKPMessage3) (*ipmi.RAKPMessage4, error)
+}
+
+// establishment is an RMCP+ session part-way through establishment. Each
+// step's messages and derived keys are retained, so the state after any step
+// can be inspected, and each step tested against canned responses. An
+// establishment is not safe for concurrent use, and cannot be retried once a
+// step fails.
+type establishment struct {
+	exchanger establishmentExchanger
+	opts      V2SessionOpts
+	sessionID uint32
+
+	// rand is the source of the remote console random number, defaulting to
+	// crypto/rand.
+	rand io.Reader
+
+	// step is the next step to run.
+	step EstablishmentStep
+
+	// capabilities is set by the Get Channel Authentication Capabilities
+	// step.
+	capabilities *ipmi.GetChannelAuthenticationCapabilitiesRsp
+
+	// openSessionRsp is set by the Open Session step, and contains the
+	// negotiated algorithms.
+	openSessionRsp *ipmi.OpenSessionRsp
+
+	// rakpMessage1, rakpMessage2, hashGenerator and password are set by the
+	// RAKP Message 1/2 step. password is the candidate the BMC's auth code
+	// was computed with.
+	rakpMessage1  *ipmi.RAKPMessage1
+	rakpMessage2  *ipmi.RAKPMessage2
+	hashGenerator *authenticationAlgorithmParams
+	password      []byte
+
+	// sik is the session integrity key, set by the RAKP Message 3/4 step.
+	sik []byte
+}
+
+// newEstablishment validates options and applies their defaults, returning an
+// establishment ready to run its first step. The options are not modified.
+func newEstablishment(x establishmentExchanger, opts *V2SessionOpts, sessionID uint32) (*establishment, error) {
+	if err := checkLegacyAlgorithms(opts); err != nil {
+		return nil, err
+	}
+	o := *opts
+	if o.AuthenticationAlgorithms == nil {
+		o.AuthenticationAlgorithms = defaultAuthenticationAlgorithms
+		if o.AllowLegacyAlgorithms {
+			o.AuthenticationAlgorithms = append(
+				append([]ipmi.AuthenticationAlgorithm(nil),
+					defaultAuthenticationAlgorithms...),
+				legacyAuthenticationAlgorithms...)
+		}
+	}
+	if o.IntegrityAlgorithms == nil {
+		o.IntegrityAlgorithms = defaultIntegrityAlgorithms
+		if o.AllowLegacyAlgorithms {
+			o.IntegrityAlgorithms = append(
+				append([]ipmi.IntegrityAlgorithm(nil),
+					defaultIntegrityAlgorithms...),
+				legacyIntegrityAlgorithms...)
+		}
+	}
+	if o.ConfidentialityAlgorithms == nil {
+		o.ConfidentialityAlgorithms = defaultConfidentialityAlgorithms
+	}
+	return &establishment{
+		exchanger: x,
+		opts:      o,
+		sessionID: sessionID,
+		rand:      rand.Reader,
+	}, nil
+}
+
+// run runs the remaining steps, stopping at the first to fail. Its error is
+// wrapped in an *EstablishmentError identifying that step.
+func (e *establishment) run(ctx context.Context) error {
+	for e.step != EstablishmentStepComplete {
+		if err := e.next(ctx); err != nil {
+			return &EstablishmentError{
+				Step: e.step,
+				Err:  err,
+			}
+		}
+	}
+	return nil
+}
+
+// next runs the next step. The step is only advanced if it succeeds.
+func (e *establishment) next(ctx context.Context) error {
+	switch e.step {
+	case EstablishmentStepCapabilities:
+		return e.sendGetCapabilities(ctx)
+	case EstablishmentStepOpenSession:
+		return e.sendOpenSession(ctx)
+	case EstablishmentStepRAKP1:
+		return e.sendRAKPMessage1(ctx)
+	case EstablishmentStepRAKP3:
+		return e.sendRAKPMessage3(ctx)
+	default:
+		return errors.New("session establishment is already complete")
+	}
+}
+
+// sendGetCapabilities sends Get Channel Authentication Capabilities for the
+// present channel, returning an error if the BMC indicates it does not support
+// IPMI v2.0.
+func (e *establishment) sendGetCapabilities(ctx context.Context) error {
+	// Highest is not a valid level in this command
+	privilegeLevel := e.opts.MaxPrivilegeLevel
+	if privilegeLevel == ipmi.PrivilegeLevelHighest {
+		privilegeLevel = ipmi.PrivilegeLevelAdministrator
+	}
+	rsp, err := e.exchanger.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: privilegeLevel,
+		})
+	if err != nil {
+		return err
+	}
+	// BMCs that only understand IPMI v1.5 ignore the request for extended
+	// data, so we only know v2.0 is unsupported if it was returned
+	if rsp.ExtendedCapabilities && !rsp.SupportsV2 {
+		return errors.New("BMC does not support IPMI v2.0")
+	}
+	e.capabilities = rsp
+	e.step = EstablishmentStepOpenSession
+	return nil
+}
+
+// sendOpenSession sends an Open Session Request proposing the configured
+// algorithms.
+func (e *establishment) sendOpenSession(ctx context.Context) error {
+	authenticationPayloads := make([]ipmi.AuthenticationPayload,
+		len(e.opts.AuthenticationAlgorithms))
+	for i, algo := range e.opts.AuthenticationAlgorithms {
+		authenticationPayloads[i] = ipmi.AuthenticationPayload{
+			Algorithm: algo,
+		}
+	}
+
+	integrityPayloads := make([]ipmi.IntegrityPayload,
+		len(e.opts.IntegrityAlgorithms))
+	for i, algo := range e.opts.IntegrityAlgorithms {
+		integrityPayloads[i] = ipmi.IntegrityPayload{
+			Algorithm: algo,
+		}
+	}
+
+	confidentialityPayloads := make([]ipmi.ConfidentialityPayload,
+		len(e.opts.ConfidentialityAlgorithms))
+	for i, algo := range e.opts.ConfidentialityAlgorithms {
+		confidentialityPayloads[i] = ipmi.ConfidentialityPayload{
+			Algorithm: algo,
+		}
+	}
+
+	rsp, err := e.exchanger.openSession(ctx, &ipmi.OpenSessionReq{
+		MaxPrivilegeLevel:       e.opts.MaxPrivilegeLevel,
+		SessionID:               e.sessionID,
+		AuthenticationPayloads:  authenticationPayloads,
+		IntegrityPayloads:       integrityPayloads,
+		ConfidentialityPayloads: confidentialityPayloads,
+	})
+	if err != nil {
+		return err
+	}
+	e.openSessionRsp = rsp
+	e.step = EstablishmentStepRAKP1
+	return nil
+}
+
+// sendRAKPMessage1 sends RAKP Message 1, and verifies the auth code in RAKP
+// Message 2, returning ErrIncorrectPassword if it was not computed with the
+// password or any compatible variant.
+func (e *establishment) sendRAKPMessage1(ctx context.Context) error {
+	remoteConsoleRandom := [16]byte{}
+	if _, err := io.ReadFull(e.rand, remoteConsoleRandom[:]); err != nil {
+		return err
+	}
+	rakpMessage1 := &ipmi.RAKPMessage1{
+		ManagedSystemSessionID: e.openSessionRsp.ManagedSystemSessionID,
+		RemoteConsoleRandom:    remoteConsoleRandom,
+		PrivilegeLevelLookup:   e.opts.PrivilegeLevelLookup,
+		MaxPrivilegeLevel:      e.opts.MaxPrivilegeLevel,
+		Username:               e.opts.Username,
+	}
+	rakpMessage2, err := e.exchanger.rakpMessage1(ctx, rakpMessage1)
+	if err != nil {
+		return err
+	}
+
+	hashGenerator, err := algorithmAuthenticationHashGenerator(
+		e.openSessionRsp.AuthenticationPayload.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	// the BMC's auth code lets us verify the password locally, so we can try
+	// each variant without further round trips
+	padUsername := []bool{false}
+	if e.opts.Quirks.Has(QuirkRAKP2UsernamePadded) {
+		padUsername = append(padUsername, true)
+	}
+	for _, candidate := range e.opts.PasswordCompatibility.candidates(e.opts.Password) {
+		authCodeHash := hashGenerator.AuthCode(candidate)
+		for _, pad := range padUsername {
+			rakpMessage2AuthCode := calculateRAKPMessage2AuthCode(authCodeHash,
+				rakpMessage1, rakpMessage2, pad)
+			if hmac.Equal(rakpMessage2.AuthCode, rakpMessage2AuthCode) {
+				e.rakpMessage1, e.rakpMessage2 = rakpMessage1, rakpMessage2
+				e.hashGenerator = hashGenerator
+				e.password = candidate
+				e.step = EstablishmentStepRAKP3
+				return nil
+			}
+		}
+	}
+	return ErrIncorrectPassword
+}
+
+// sendRAKPMessage3 derives the session integrity key, sends RAKP Message 3, and
+// verifies the integrity check value in RAKP Message 4.
+func (e *establishment) sendRAKPMessage3(ctx context.Context) error {
+	authCodeHash := e.hashGenerator.AuthCode(e.password)
+	effectiveBMCKey := e.opts.KG
+	if len(effectiveBMCKey) == 0 {
+		effectiveBMCKey = e.password
+	}
+	sikHash := e.hashGenerator.SIK(effectiveBMCKey)
+	sik := calculateSIK(sikHash, e.rakpMessage1, e.rakpMessage2)
+	icvHash := e.hashGenerator.ICV(sik)
+
+	rakpMessage4, err := e.exchanger.rakpMessage3(ctx, &ipmi.RAKPMessage3{
+		Status:                 ipmi.StatusCodeOK,
+		ManagedSystemSessionID: e.openSessionRsp.ManagedSystemSessionID,
+		AuthCode: calculateRAKPMessage3AuthCode(
+			authCodeHash, e.rakpMessage1, e.rakpMessage2),
+	})
+	if err != nil {
+		return err
+	}
+	rakpMessage4ICV := calculateRAKPMessage4ICV(icvHash, e.rakpMessage1,
+		e.rakpMessage2)
+	if !hmac.Equal(rakpMessage4.ICV, rakpMessage4ICV) {
+		if !e.opts.Quirks.Has(QuirkIgnoreRAKP4ICV) {
+			return fmt.Errorf("RAKP4 ICV fail: got %v, want %v",
+				hex.EncodeToString(rakpMessage4.ICV),
+				hex.EncodeToString(rakpMessage4ICV))
+		}
+		log.Printf("bmc: accepting RAKP Message 4 with incorrect ICV %v "+
+			"(want %v) due to QuirkIgnoreRAKP4ICV",
+			hex.EncodeToString(rakpMessage4.ICV),
+			hex.EncodeToString(rakpMessage4ICV))
+		sessionRAKP4ICVMismatches.Inc()
+	}
+	e.sik = sik
+	e.step = EstablishmentStepComplete
+	return nil
+}
+
+// session returns the established session, which sends packets over the
+// shared connection, waiting up to timeout for each response.
+func (e *establishment) session(shared *v2ConnectionShared, timeout time.Duration) (*V2Session, error) {
+	if e.step != EstablishmentStepComplete {
+		return nil, fmt.Errorf("session establishment incomplete; next "+
+			"step is %v", e.step)
+	}
+	keyMaterialGen := additionalKeyMaterialGenerator{
+		hash: e.hashGenerator.K(e.sik),
+	}
+	hasher, err := algorithmHasher(e.openSessionRsp.IntegrityPayload.Algorithm,
+		keyMaterialGen)
+	if err != nil {
+		return nil, err
+	}
+	cipherLayer, err := algorithmCipher(
+		e.openSessionRsp.ConfidentialityPayload.Algorithm, keyMaterialGen)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &V2Session{
+		v2ConnectionShared:             shared,
+		LocalID:                        e.openSessionRsp.RemoteConsoleSessionID,
+		RemoteID:                       e.openSessionRsp.ManagedSystemSessionID,
+		SIK:                            e.sik,
+		AuthenticationAlgorithm:        e.openSessionRsp.AuthenticationPayload.Algorithm,
+		IntegrityAlgorithm:             e.openSessionRsp.IntegrityPayload.Algorithm,
+		ConfidentialityAlgorithm:       e.openSessionRsp.ConfidentialityPayload.Algorithm,
+		AdditionalKeyMaterialGenerator: keyMaterialGen,
+		integrityAlgorithm:             hasher,
+		confidentialityLayer:           cipherLayer,
+		timeout:                        timeout,
+		maxPrivilegeLevel:              e.openSessionRsp.MaxPrivilegeLevel,
+		stats:                          newSessionStats(shared.now()),
+		auditSink:                      e.opts.AuditSink,
+		dryRun:                         e.opts.DryRun,
+		readOnly:                       e.opts.ReadOnly,
+	}
+	// do not set properties of the session layer here, as it is overwritten
+	// each send
+	sess.decode = ipmi.NewV2DecodingLayerFunc(&sess.rmcpLayer,
+		&sess.sessionSelectorLayer, &sess.v2SessionLayer, cipherLayer,
+		&sess.messageLayer)
+	return sess, nil
+}
@@ -0,0 +1,272 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+)
+
+// cannedExchanger plays the BMC's side of session establishment for cipher
+// suite 3, computing responses from a password rather than sending packets.
+type cannedExchanger struct {
+	password []byte
+
+	// v1Only causes the BMC to indicate it does not support IPMI v2.0.
+	v1Only bool
+
+	// openSessionStatus is returned in the Open Session Response.
+	openSessionStatus ipmi.StatusCode
+
+	// corruptICV causes RAKP Message 4 to contain an invalid ICV.
+	corruptICV bool
+
+	// message1, message2 and params are retained from RAKP Message 1 to
+	// compute the ICV in RAKP Message 4.
+	message1 *ipmi.RAKPMessage1
+	message2 *ipmi.RAKPMessage2
+	params   *authenticationAlgorithmParams
+}
+
+func (x *cannedExchanger) GetChannelAuthenticationCapabilities(context.Context, *ipmi.GetChannelAuthenticationCapabilitiesReq) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
+	return &ipmi.GetChannelAuthenticationCapabilitiesRsp{
+		Channel:                 ipmi.Channel(1),
+		ExtendedCapabilities:    true,
+		NonNullUsernamesEnabled: true,
+		SupportsV1:              true,
+		SupportsV2:              !x.v1Only,
+	}, nil
+}
+
+func (x *cannedExchanger) openSession(_ context.Context, r *ipmi.OpenSessionReq) (*ipmi.OpenSessionRsp, error) {
+	if x.openSessionStatus != ipmi.StatusCodeOK {
+		return nil, &statusCodeError{Code: x.openSessionStatus}
+	}
+	return &ipmi.OpenSessionRsp{
+		MaxPrivilegeLevel:      r.MaxPrivilegeLevel,
+		RemoteConsoleSessionID: r.SessionID,
+		ManagedSystemSessionID: 0x01020304,
+		AuthenticationPayload: ipmi.AuthenticationPayload{
+			Algorithm: ipmi.AuthenticationAlgorithmHMACSHA1,
+		},
+		IntegrityPayload: ipmi.IntegrityPayload{
+			Algorithm: ipmi.IntegrityAlgorithmHMACSHA196,
+		},
+		ConfidentialityPayload: ipmi.ConfidentialityPayload{
+			Algorithm: ipmi.ConfidentialityAlgorithmAESCBC128,
+		},
+	}, nil
+}
+
+func (x *cannedExchanger) rakpMessage1(_ context.Context, r *ipmi.RAKPMessage1) (*ipmi.RAKPMessage2, error) {
+	params, err := algorithmAuthenticationHashGenerator(
+		ipmi.AuthenticationAlgorithmHMACSHA1)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &ipmi.RAKPMessage2{
+		ManagedSystemRandom: [16]byte{0xaa, 0xbb},
+		ManagedSystemGUID:   [16]byte{0xcc, 0xdd},
+	}
+	rsp.AuthCode = calculateRAKPMessage2AuthCode(params.AuthCode(x.password),
+		r, rsp, false)
+	x.message1, x.message2, x.params = r, rsp, params
+	return rsp, nil
+}
+
+func (x *cannedExchanger) rakpMessage3(context.Context, *ipmi.RAKPMessage3) (*ipmi.RAKPMessage4, error) {
+	sik := calculateSIK(x.params.SIK(x.password), x.message1, x.message2)
+	icv := calculateRAKPMessage4ICV(x.params.ICV(sik), x.message1,
+		x.message2)
+	if x.corruptICV {
+		icv[0] ^= 0xff
+	}
+	return &ipmi.RAKPMessage4{
+		ICV: icv,
+	}, nil
+}
+
+func TestEstablishment(t *testing.T) {
+	ctx := context.Background()
+	password := []byte("hunter2")
+	opts := &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username:          "admin",
+			Password:          password,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+		},
+	}
+	e, err := newEstablishment(&cannedExchanger{password: password}, opts, 7)
+	if err != nil {
+		t.Fatalf("newEstablishment() failed: %v", err)
+	}
+	if opts.AuthenticationAlgorithms != nil {
+		t.Errorf("newEstablishment() modified the options")
+	}
+	if _, err := e.session(&v2ConnectionShared{}, time.Second); err == nil {
+		t.Errorf("session() succeeded before establishment")
+	}
+
+	for _, want := range []EstablishmentStep{EstablishmentStepOpenSession,
+		EstablishmentStepRAKP1, EstablishmentStepRAKP3,
+		EstablishmentStepComplete} {
+		if err := e.next(ctx); err != nil {
+			t.Fatalf("step %v failed: %v", e.step, err)
+		}
+		if e.step != want {
+			t.Fatalf("step = %v, want %v", e.step, want)
+		}
+	}
+	if err := e.next(ctx); err == nil {
+		t.Errorf("next() succeeded after establishment")
+	}
+
+	sess, err := e.session(&v2ConnectionShared{}, time.Second)
+	if err != nil {
+		t.Fatalf("session() failed: %v", err)
+	}
+	if sess.LocalID != 7 || sess.RemoteID != 0x01020304 ||
+		sess.maxPrivilegeLevel != ipmi.PrivilegeLevelOperator {
+		t.Errorf("session() = local %v, remote %v, privilege %v, want 7, "+
+			"16909060, %v", sess.LocalID, sess.RemoteID,
+			sess.maxPrivilegeLevel, ipmi.PrivilegeLevelOperator)
+	}
+}
+
+func TestEstablishmentFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		exchanger *cannedExchanger
+		quirks    Quirks
+		wantStep  EstablishmentStep
+		wantErr   error // nil for any error
+		wantLog   bool
+	}{
+		{
+			name: "v1.5 only",
+			exchanger: &cannedExchanger{
+				password: []byte("hunter2"),
+				v1Only:   true,
+			},
+			wantStep: EstablishmentStepCapabilities,
+		},
+		{
+			name: "insufficient resources",
+			exchanger: &cannedExchanger{
+				password:          []byte("hunter2"),
+				openSessionStatus: ipmi.StatusCodeInsufficientResources,
+			},
+			wantStep: EstablishmentStepOpenSession,
+		},
+		{
+			name: "incorrect password",
+			exchanger: &cannedExchanger{
+				password: []byte("hunter3"),
+			},
+			wantStep: EstablishmentStepRAKP1,
+			wantErr:  ErrIncorrectPassword,
+		},
+		{
+			name: "invalid icv",
+			exchanger: &cannedExchanger{
+				password:   []byte("hunter2"),
+				corruptICV: true,
+			},
+			wantStep: EstablishmentStepRAKP3,
+		},
+		{
+			name: "invalid icv ignored",
+			exchanger: &cannedExchanger{
+				password:   []byte("hunter2"),
+				corruptICV: true,
+			},
+			quirks:   QuirkIgnoreRAKP4ICV,
+			wantStep: EstablishmentStepComplete,
+			wantLog:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := newEstablishment(test.exchanger, &V2SessionOpts{
+				SessionOpts: SessionOpts{
+					Username: "admin",
+					Password: []byte("hunter2"),
+				},
+				Quirks: test.quirks,
+			}, 1)
+			if err != nil {
+				t.Fatalf("newEstablishment() failed: %v", err)
+			}
+			logged := &bytes.Buffer{}
+			log.SetOutput(logged)
+			defer log.SetOutput(os.Stderr)
+			err = e.run(context.Background())
+			if gotLog := logged.Len() != 0; gotLog != test.wantLog {
+				t.Errorf("logged %q, want logged: %v", logged, test.wantLog)
+			}
+			if e.step != test.wantStep {
+				t.Errorf("stopped at step %v, want %v", e.step, test.wantStep)
+			}
+			switch {
+			case test.wantStep == EstablishmentStepComplete:
+				if err != nil {
+					t.Errorf("run() failed: %v", err)
+				}
+			case err == nil:
+				t.Errorf("run() succeeded, want error")
+			case test.wantErr != nil && !errors.Is(err, test.wantErr):
+				t.Errorf("run() = %v, want %v", err, test.wantErr)
+			}
+			var establishmentErr *EstablishmentError
+			if err != nil && (!errors.As(err, &establishmentErr) ||
+				establishmentErr.Step != test.wantStep) {
+				t.Errorf("run() = %v, want EstablishmentError at step %v",
+					err, test.wantStep)
+			}
+		})
+	}
+}
+
+func TestEstablishmentRand(t *testing.T) {
+	password := []byte("hunter2")
+	x := &cannedExchanger{password: password}
+	e, err := newEstablishment(x, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username: "admin",
+			Password: password,
+		},
+	}, 1)
+	if err != nil {
+		t.Fatalf("newEstablishment() failed: %v", err)
+	}
+	want := [16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09,
+		0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	e.rand = bytes.NewReader(want[:])
+	if err := e.run(context.Background()); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	if got := x.message1.RemoteConsoleRandom; got != want {
+		t.Errorf("RAKP Message 1 random = %x, want %x", got, want)
+	}
+
+	// a source that runs out must not leave the random number partially
+	// filled
+	e, err = newEstablishment(x, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username: "admin",
+			Password: password,
+		},
+	}, 2)
+	if err != nil {
+		t.Fatalf("newEstablishment() failed: %v", err)
+	}
+	e.rand = bytes.NewReader(want[:8])
+	if err := e.run(context.Background()); err == nil {
+		t.Error("run() with exhausted random source succeeded")
+	}
+}
@@ -2,15 +2,12 @@ package bmc
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 
-	"github.com/google/gopacket"
+	"github.com/cenkalti/backoff/v4"
 )
 
 var (
@@ -20,13 +17,11 @@ var (
 	defaultAuthenticationAlgorithms = []ipmi.AuthenticationAlgorithm{
 		//ipmi.AuthenticationAlgorithmNone,
 		ipmi.AuthenticationAlgorithmHMACSHA1,
-		//ipmi.AuthenticationAlgorithmHMACMD5,
 		//ipmi.AuthenticationAlgorithmHMACSHA256,
 	}
 	defaultIntegrityAlgorithms = []ipmi.IntegrityAlgorithm{
 		//ipmi.IntegrityAlgorithmNone,
 		ipmi.IntegrityAlgorithmHMACSHA196,
-		//ipmi.IntegrityAlgorithmHMACMD5128,
 		//ipmi.IntegrityAlgorithmMD5128,
 		//ipmi.IntegrityAlgorithmHMACSHA256128,
 	}
@@ -34,6 +29,20 @@ var (
 		//ipmi.ConfidentialityAlgorithmNone,
 		ipmi.ConfidentialityAlgorithmAESCBC128,
 	}
+
+	// legacyAuthenticationAlgorithms are only proposed if
+	// V2SessionOpts.AllowLegacyAlgorithms is set. When it is and no
+	// authentication algorithms are specified, these are proposed after the
+	// defaults.
+	legacyAuthenticationAlgorithms = []ipmi.AuthenticationAlgorithm{
+		ipmi.AuthenticationAlgorithmHMACMD5,
+	}
+
+	// legacyIntegrityAlgorithms are the integrity equivalent of
+	// legacyAuthenticationAlgorithms.
+	legacyIntegrityAlgorithms = []ipmi.IntegrityAlgorithm{
+		ipmi.IntegrityAlgorithmHMACMD5128,
+	}
 )
 
 // V2SessionOpts contains configurable parameters for RMCP+ session
@@ -70,6 +79,20 @@ type V2SessionOpts struct {
 	// propose for packet encryption. If this is unspecified, all supported
 	// algorithms will be proposed.
 	ConfidentialityAlgorithms []ipmi.ConfidentialityAlgorithm
+
+	// AllowLegacyAlgorithms permits proposing MD5-based algorithms, namely
+	// RAKP-HMAC-MD5 authentication and HMAC-MD5-128 integrity. These are
+	// required by some old hardware, e.g. iLO 2 and early iDRACs, that does not
+	// support SHA-1 cipher suites. If this is false, session establishment will
+	// fail if they are specified explicitly. If this is true and
+	// AuthenticationAlgorithms or IntegrityAlgorithms are unspecified, the
+	// legacy algorithms are proposed after the defaults.
+	AllowLegacyAlgorithms bool
+
+	// Quirks is the set of known firmware bugs to tolerate during session
+	// establishment and use. This should be left as zero unless the BMC is
+	// known to require otherwise.
+	Quirks Quirks
 }
 
 // NewSession establishes a new RMCP+ session. Two-key login is assumed to be
@@ -86,160 +109,99 @@ func (s *V2SessionlessTransport) NewSession(
 }
 
 // NewV2Session establishes a new RMCP+ session with fine-grained parameters.
-// This function does not modify the input options. The caller is responsible
-// for knowing that v2.0 is supported.
+// This function does not modify the input options. Establishment errors are
+// returned as an *EstablishmentError identifying the step that failed.
 func (s *V2SessionlessTransport) NewV2Session(ctx context.Context, opts *V2SessionOpts) (*V2Session, error) {
 	// all the effort is in establish(); this method exists to provide a single
 	// point for incrementing the failure count
 	sessionOpenAttempts.Inc()
-	sess, err := s.newV2Session(ctx, opts)
+	sess, err := s.newV2SessionWithRetries(ctx, opts)
 	if err != nil {
 		sessionOpenFailures.Inc()
 		return nil, err
 	}
 	sessionsOpen.Inc()
+	s.addSession(sess)
 	return sess, nil
 }
 
-// newV2Session negotiates a new session, returning it on success. It will
-// return ErrIncorrectPassword if the BMC appears to be using a different
-// password to the remote console.
-func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2SessionOpts) (*V2Session, error) {
-	if opts.AuthenticationAlgorithms == nil {
-		opts.AuthenticationAlgorithms = defaultAuthenticationAlgorithms
-	}
-	if opts.IntegrityAlgorithms == nil {
-		opts.IntegrityAlgorithms = defaultIntegrityAlgorithms
-	}
-	if opts.ConfidentialityAlgorithms == nil {
-		opts.ConfidentialityAlgorithms = defaultConfidentialityAlgorithms
-	}
-
-	authenticationPayloads := make([]ipmi.AuthenticationPayload,
-		len(opts.AuthenticationAlgorithms))
-	for i, algo := range opts.AuthenticationAlgorithms {
-		authenticationPayloads[i] = ipmi.AuthenticationPayload{
-			Algorithm: algo,
-		}
-	}
+// newV2SessionWithRetries calls newV2Session, retrying with backoff if the BMC
+// returns a temporary RMCP+ status code, as configured by
+// opts.EstablishmentMaxElapsedTime.
+func (s *V2SessionlessTransport) newV2SessionWithRetries(ctx context.Context, opts *V2SessionOpts) (*V2Session, error) {
+	if opts.EstablishmentMaxElapsedTime == 0 {
+		return s.newV2Session(ctx, opts)
+	}
+	b := s.newBackOff()
+	b.MaxElapsedTime = opts.EstablishmentMaxElapsedTime
+	return retryEstablishment(ctx, b, func() (*V2Session, error) {
+		return s.newV2Session(ctx, opts)
+	})
+}
 
-	integrityPayloads := make([]ipmi.IntegrityPayload,
-		len(opts.IntegrityAlgorithms))
-	for i, algo := range opts.IntegrityAlgorithms {
-		integrityPayloads[i] = ipmi.IntegrityPayload{
-			Algorithm: algo,
+// retryEstablishment calls establish until it succeeds, returns an error
+// other than a temporary RMCP+ status code, or b gives up. Each call after the
+// first is counted as a retry.
+func retryEstablishment(ctx context.Context, b backoff.BackOff, establish func() (*V2Session, error)) (*V2Session, error) {
+	var sess *V2Session
+	first := true
+	err := backoff.Retry(func() error {
+		if first {
+			first = false
+		} else {
+			sessionOpenRetries.Inc()
 		}
-	}
-
-	confidentialityPayloads := make([]ipmi.ConfidentialityPayload,
-		len(opts.ConfidentialityAlgorithms))
-	for i, algo := range opts.ConfidentialityAlgorithms {
-		confidentialityPayloads[i] = ipmi.ConfidentialityPayload{
-			Algorithm: algo,
+		var err error
+		sess, err = establish()
+		var statusErr *statusCodeError
+		if err != nil && !(errors.As(err, &statusErr) && statusErr.Temporary()) {
+			return backoff.Permanent(err)
 		}
-	}
-
-	openSessionRsp, err := s.openSession(ctx, &ipmi.OpenSessionReq{
-		MaxPrivilegeLevel:       opts.MaxPrivilegeLevel,
-		SessionID:               1,
-		AuthenticationPayloads:  authenticationPayloads,
-		IntegrityPayloads:       integrityPayloads,
-		ConfidentialityPayloads: confidentialityPayloads,
-	})
+		return err
+	}, backoff.WithContext(b, ctx))
 	if err != nil {
 		return nil, err
 	}
+	return sess, nil
+}
 
-	// RAKP Message 1, 2
-	remoteConsoleRandom := [16]byte{}
-	if _, err := rand.Read(remoteConsoleRandom[:]); err != nil {
-		return nil, err
-	}
-	rakpMessage1 := &ipmi.RAKPMessage1{
-		ManagedSystemSessionID: openSessionRsp.ManagedSystemSessionID,
-		RemoteConsoleRandom:    remoteConsoleRandom,
-		PrivilegeLevelLookup:   opts.PrivilegeLevelLookup,
-		MaxPrivilegeLevel:      opts.MaxPrivilegeLevel,
-		Username:               opts.Username,
-	}
-	rakpMessage2, err := s.rakpMessage1(ctx, rakpMessage1)
-	if err != nil {
-		return nil, err
-	}
-
-	hashGenerator, err := algorithmAuthenticationHashGenerator(
-		openSessionRsp.AuthenticationPayload.Algorithm)
-	if err != nil {
-		return nil, err
-	}
-
-	authCodeHash := hashGenerator.AuthCode(opts.Password)
-	rakpMessage2AuthCode := calculateRAKPMessage2AuthCode(authCodeHash,
-		rakpMessage1, rakpMessage2)
-	if !hmac.Equal(rakpMessage2.AuthCode, rakpMessage2AuthCode) {
-		return nil, ErrIncorrectPassword
-	}
-
-	effectiveBMCKey := opts.KG
-	if len(effectiveBMCKey) == 0 {
-		effectiveBMCKey = opts.Password
-	}
-	sikHash := hashGenerator.SIK(effectiveBMCKey)
-	sik := calculateSIK(sikHash, rakpMessage1, rakpMessage2)
-	icvHash := hashGenerator.ICV(sik)
-
-	rakpMessage4, err := s.rakpMessage3(ctx, &ipmi.RAKPMessage3{
-		Status:                 ipmi.StatusCodeOK,
-		ManagedSystemSessionID: openSessionRsp.ManagedSystemSessionID,
-		AuthCode: calculateRAKPMessage3AuthCode(
-			authCodeHash, rakpMessage1, rakpMessage2),
-	})
-	if err != nil {
-		return nil, err
+// checkLegacyAlgorithms returns an error if the options explicitly propose a
+// legacy algorithm without AllowLegacyAlgorithms being set.
+func checkLegacyAlgorithms(opts *V2SessionOpts) error {
+	if opts.AllowLegacyAlgorithms {
+		return nil
+	}
+	for _, proposed := range opts.AuthenticationAlgorithms {
+		for _, legacy := range legacyAuthenticationAlgorithms {
+			if proposed == legacy {
+				return fmt.Errorf("%v is a legacy authentication algorithm; "+
+					"set AllowLegacyAlgorithms to use it", proposed)
+			}
+		}
 	}
-	rakpMessage4ICV := calculateRAKPMessage4ICV(icvHash, rakpMessage1,
-		rakpMessage2)
-	if !hmac.Equal(rakpMessage4.ICV, rakpMessage4ICV) {
-		return nil, fmt.Errorf("RAKP4 ICV fail: got %v, want %v",
-			hex.EncodeToString(rakpMessage4.ICV),
-			hex.EncodeToString(rakpMessage4ICV))
+	for _, proposed := range opts.IntegrityAlgorithms {
+		for _, legacy := range legacyIntegrityAlgorithms {
+			if proposed == legacy {
+				return fmt.Errorf("%v is a legacy integrity algorithm; "+
+					"set AllowLegacyAlgorithms to use it", proposed)
+			}
+		}
 	}
+	return nil
+}
 
-	keyMaterialGen := additionalKeyMaterialGenerator{
-		hash: hashGenerator.K(sik),
-	}
-	hasher, err := algorithmHasher(openSessionRsp.IntegrityPayload.Algorithm,
-		keyMaterialGen)
+// newV2Session negotiates a new session, returning it on success. It will
+// return ErrIncorrectPassword if the BMC appears to be using a different
+// password to the remote console. The steps are implemented by establishment,
+// and errors are wrapped in an *EstablishmentError.
+func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2SessionOpts) (*V2Session, error) {
+	e, err := newEstablishment(s, opts, s.nextSessionID())
 	if err != nil {
 		return nil, err
 	}
-	cipherLayer, err := algorithmCipher(
-		openSessionRsp.ConfidentialityPayload.Algorithm, keyMaterialGen)
-	if err != nil {
+	e.rand = s.random()
+	if err := e.run(ctx); err != nil {
 		return nil, err
 	}
-
-	sess := &V2Session{
-		v2ConnectionShared:             &s.v2ConnectionShared,
-		LocalID:                        openSessionRsp.RemoteConsoleSessionID,
-		RemoteID:                       openSessionRsp.ManagedSystemSessionID,
-		SIK:                            sik,
-		AuthenticationAlgorithm:        openSessionRsp.AuthenticationPayload.Algorithm,
-		IntegrityAlgorithm:             openSessionRsp.IntegrityPayload.Algorithm,
-		ConfidentialityAlgorithm:       openSessionRsp.ConfidentialityPayload.Algorithm,
-		AdditionalKeyMaterialGenerator: keyMaterialGen,
-		integrityAlgorithm:             hasher,
-		confidentialityLayer:           cipherLayer,
-		timeout:                        s.timeout,
-	}
-	// do not set properties of the session layer here, as it is overwritten
-	// each send
-	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
-	dlc = dlc.Put(&sess.rmcpLayer)
-	dlc = dlc.Put(&sess.sessionSelectorLayer)
-	dlc = dlc.Put(&sess.v2SessionLayer)
-	dlc = dlc.Put(cipherLayer)
-	dlc = dlc.Put(&sess.messageLayer)
-	sess.decode = dlc.LayersDecoder(sess.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
-	return sess, nil
+	return e.session(&s.v2ConnectionShared, s.timeout)
 }
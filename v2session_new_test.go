@@ -0,0 +1,175 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+func TestCheckLegacyAlgorithms(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *V2SessionOpts
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			opts: &V2SessionOpts{},
+		},
+		{
+			name: "modern",
+			opts: &V2SessionOpts{
+				AuthenticationAlgorithms: []ipmi.AuthenticationAlgorithm{
+					ipmi.AuthenticationAlgorithmHMACSHA256,
+				},
+				IntegrityAlgorithms: []ipmi.IntegrityAlgorithm{
+					ipmi.IntegrityAlgorithmHMACSHA256128,
+				},
+			},
+		},
+		{
+			name: "md5 authentication without opt-in",
+			opts: &V2SessionOpts{
+				AuthenticationAlgorithms: []ipmi.AuthenticationAlgorithm{
+					ipmi.AuthenticationAlgorithmHMACMD5,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "md5 integrity without opt-in",
+			opts: &V2SessionOpts{
+				IntegrityAlgorithms: []ipmi.IntegrityAlgorithm{
+					ipmi.IntegrityAlgorithmHMACSHA196,
+					ipmi.IntegrityAlgorithmHMACMD5128,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "md5 with opt-in",
+			opts: &V2SessionOpts{
+				AuthenticationAlgorithms: []ipmi.AuthenticationAlgorithm{
+					ipmi.AuthenticationAlgorithmHMACMD5,
+				},
+				IntegrityAlgorithms: []ipmi.IntegrityAlgorithm{
+					ipmi.IntegrityAlgorithmHMACMD5128,
+				},
+				AllowLegacyAlgorithms: true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkLegacyAlgorithms(test.opts)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, wanted error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryEstablishment(t *testing.T) {
+	insufficientResources := &statusCodeError{
+		Code: ipmi.StatusCodeInsufficientResources,
+	}
+	unauthorisedName := &statusCodeError{
+		Code: ipmi.StatusCodeUnauthorisedName,
+	}
+	tests := []struct {
+		name      string
+		errs      []error // returned by each call; success thereafter
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "immediate success",
+			wantCalls: 1,
+		},
+		{
+			name:      "temporary",
+			errs:      []error{insufficientResources, insufficientResources},
+			wantCalls: 3,
+		},
+		{
+			name:      "permanent",
+			errs:      []error{insufficientResources, unauthorisedName},
+			wantCalls: 2,
+			wantErr:   unauthorisedName,
+		},
+		{
+			name:      "incorrect password",
+			errs:      []error{ErrIncorrectPassword},
+			wantCalls: 1,
+			wantErr:   ErrIncorrectPassword,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			sess, err := retryEstablishment(context.Background(),
+				&backoff.ZeroBackOff{}, func() (*V2Session, error) {
+					calls++
+					if calls <= len(test.errs) {
+						return nil, test.errs[calls-1]
+					}
+					return &V2Session{}, nil
+				})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("retryEstablishment() = %v, want %v", err,
+					test.wantErr)
+			}
+			if (sess != nil) != (test.wantErr == nil) {
+				t.Errorf("retryEstablishment() = %v, %v; want session: %v",
+					sess, err, test.wantErr == nil)
+			}
+			if calls != test.wantCalls {
+				t.Errorf("establish called %v times, want %v", calls,
+					test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryEstablishmentGivesUp(t *testing.T) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Millisecond
+	b.MaxElapsedTime = time.Millisecond * 50
+	calls := 0
+	_, err := retryEstablishment(context.Background(), b,
+		func() (*V2Session, error) {
+			calls++
+			return nil, &statusCodeError{
+				Code: ipmi.StatusCodeInsufficientResources,
+			}
+		})
+	var statusErr *statusCodeError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("retryEstablishment() = %v, want status code error", err)
+	}
+	if calls < 2 {
+		t.Errorf("establish called %v times, want retries", calls)
+	}
+}
+
+func TestIncorrectPassword(t *testing.T) {
+	_, machine := newTestTransport(t, &sim.Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := machine.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter3"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+	})
+	if !errors.Is(err, ErrIncorrectPassword) {
+		t.Errorf("NewSession() = %v, want %v", err, ErrIncorrectPassword)
+	}
+}
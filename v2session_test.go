@@ -0,0 +1,287 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kuiwang02/bmc/internal/pkg/sim"
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInsufficientPrivilege(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &sim.Config{}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := sess.GetChassisStatus(ctx); err != nil {
+		t.Errorf("GetChassisStatus() failed: %v", err)
+	}
+	err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOff)
+	if !errors.Is(err, ErrInsufficientPrivilege) {
+		t.Errorf("ChassisControl() with User privilege = %v, want %v", err,
+			ErrInsufficientPrivilege)
+	}
+}
+
+func TestSessionClosed(t *testing.T) {
+	_, machine := newTestTransport(t, &sim.Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sess, err := machine.NewSession(ctx, &SessionOpts{
+		Username:          "admin",
+		Password:          []byte("hunter2"),
+		MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() failed: %v", err)
+	}
+	if err := sess.Close(ctx); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if _, err := sess.GetDeviceID(ctx); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("GetDeviceID() after Close() = %v, want %v", err,
+			ErrSessionClosed)
+	}
+	if err := sess.Close(ctx); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("second Close() = %v, want %v", err, ErrSessionClosed)
+	}
+
+	// the connection remains usable
+	if _, err := machine.GetSystemGUID(ctx); err != nil {
+		t.Errorf("GetSystemGUID() after Close() failed: %v", err)
+	}
+}
+
+func TestSendAsync(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &sim.Config{
+		PoweredOn: true,
+	}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelOperator,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sess.SendAsync(ctx, &ipmi.ChassisControlCmd{
+		Req: ipmi.ChassisControlReq{
+			ChassisControl: ipmi.ChassisControlPowerOff,
+		},
+	}); err != nil {
+		t.Fatalf("SendAsync() failed: %v", err)
+	}
+
+	// the late response must not be mistaken for that of the next command
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if status.PoweredOn {
+		t.Errorf("GetChassisStatus() PoweredOn = true, want false")
+	}
+
+	if err := sess.SendAsync(ctx, &ipmi.SuspendBMCARPsCmd{
+		Req: ipmi.SuspendBMCARPsReq{
+			Channel: 1,
+		},
+	}); err == nil {
+		t.Errorf("SendAsync() above the session privilege level succeeded, " +
+			"want error")
+	}
+}
+
+func TestSendAsyncLateResponse(t *testing.T) {
+	simBMC, sess := newTestSession(t, &sim.Config{
+		ResponseDelays: map[ipmi.Operation]time.Duration{
+			ipmi.OperationChassisIdentifyReq: time.Millisecond * 100,
+			ipmi.OperationChassisControlReq:  time.Millisecond * 200,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the response to this arrives while waiting for Chassis Control's
+	if err := sess.SendAsync(ctx, &ipmi.ChassisIdentifyCmd{}); err != nil {
+		t.Fatalf("SendAsync() failed: %v", err)
+	}
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOn); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	want := []ipmi.ChassisControl{ipmi.ChassisControlPowerOn}
+	if diff := cmp.Diff(want, simBMC.ChassisControls()); diff != "" {
+		t.Errorf("Chassis Control commands received by the BMC mismatch "+
+			"(-want +got):\n%v", diff)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	sink := &recordingAuditSink{}
+	_, sess := newTestSessionWithOpts(t, &sim.Config{
+		PoweredOn: true,
+		SEL: [][]byte{
+			sim.SystemEventRecord(1, ipmi.SensorTypeProcessor, 1, 0x0),
+		},
+	}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+			AuditSink:         sink,
+			DryRun:            true,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOff); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	if len(sink.records) != 1 || !sink.records[0].DryRun {
+		t.Errorf("audit records = %+v, want 1 dry run", sink.records)
+	}
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if !status.PoweredOn {
+		t.Error("ChassisControl() in a dry run powered off the machine")
+	}
+
+	// only initiating erasure is skipped; polling its status is not
+	if err := sess.ClearSEL(ctx, nil); err != nil {
+		t.Fatalf("ClearSEL() failed: %v", err)
+	}
+	if len(sink.records) != 2 || !sink.records[1].DryRun {
+		t.Errorf("audit records = %+v, want 2 dry runs", sink.records)
+	}
+	records, err := RetrieveSEL(ctx, sess)
+	if err != nil {
+		t.Fatalf("RetrieveSEL() failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("ClearSEL() in a dry run erased the SEL")
+	}
+
+	// testing the password is sent, so the mismatch is found
+	changes, err := DiffConfig(ctx, sess, &Config{
+		Users: []*UserConfig{
+			{
+				ID:       2,
+				Password: "hunter3",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DiffConfig() failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Setting != "user 2 password" {
+		t.Errorf("DiffConfig() = %v, want user 2 password change", changes)
+	}
+	if len(sink.records) != 2 {
+		t.Errorf("audit records = %+v, want 2 dry runs", sink.records)
+	}
+}
+
+func TestReadOnlySession(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &sim.Config{
+		PoweredOn: true,
+	}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+			ReadOnly:          true,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerOff)
+	if !errors.Is(err, ErrReadOnlySession) {
+		t.Errorf("ChassisControl() in read-only session = %v, want %v", err,
+			ErrReadOnlySession)
+	}
+	if err := Identify(ctx, sess, time.Second); !errors.Is(err, ErrReadOnlySession) {
+		t.Errorf("Identify() in read-only session = %v, want %v", err,
+			ErrReadOnlySession)
+	}
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetChassisStatus() failed: %v", err)
+	}
+	if !status.PoweredOn {
+		t.Error("ChassisControl() in a read-only session powered off the machine")
+	}
+	changes, err := DiffConfig(ctx, sess, &Config{
+		Users: []*UserConfig{
+			{
+				ID:       2,
+				Password: "hunter3",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DiffConfig() in read-only session failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Setting != "user 2 password" {
+		t.Errorf("DiffConfig() = %v, want user 2 password change", changes)
+	}
+}
+
+func TestSequenceNumbersExhausted(t *testing.T) {
+	_, sess := newTestSessionWithOpts(t, &sim.Config{}, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sess.AuthenticatedSequenceNumbers.Inbound = math.MaxUint32 - 256
+	if _, err := sess.GetDeviceID(ctx); !errors.Is(err, ErrSequenceNumbersExhausted) {
+		t.Errorf("GetDeviceID() with exhausted sequence numbers = %v, want %v",
+			err, ErrSequenceNumbersExhausted)
+	}
+	if err := sess.Close(ctx); err != nil {
+		t.Errorf("Close() with exhausted sequence numbers failed: %v", err)
+	}
+}
+
+func TestLateResponse(t *testing.T) {
+	simBMC, sess := newTestSession(t, &sim.Config{
+		PoweredOn: true,
+		ResponseDelays: map[ipmi.Operation]time.Duration{
+			ipmi.OperationGetChassisStatusReq: time.Millisecond * 100,
+			ipmi.OperationChassisControlReq:   time.Millisecond * 200,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the response to this arrives while waiting for Chassis Control's
+	if _, err := sess.GetChassisStatus(WithCommandTimeout(ctx, time.Millisecond*50)); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("GetChassisStatus() = %v, want %v", err, ErrTimeout)
+	}
+	if err := sess.ChassisControl(ctx, ipmi.ChassisControlPowerCycle); err != nil {
+		t.Fatalf("ChassisControl() failed: %v", err)
+	}
+	want := []ipmi.ChassisControl{ipmi.ChassisControlPowerCycle}
+	if diff := cmp.Diff(want, simBMC.ChassisControls()); diff != "" {
+		t.Errorf("Chassis Control commands received by the BMC mismatch "+
+			"(-want +got):\n%v", diff)
+	}
+}
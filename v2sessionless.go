@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/kuiwang02/bmc/internal/pkg/transport"
+	"github.com/kuiwang02/bmc/internal/pkg/sequencer"
 	"github.com/kuiwang02/bmc/pkg/ipmi"
 	"github.com/kuiwang02/bmc/pkg/layerexts"
 
@@ -17,7 +20,8 @@ import (
 )
 
 var (
-	errRetryableCode = errors.New("completion code indicated temporary failure")
+	errRetryableCode = fmt.Errorf("%w: completion code indicated temporary "+
+		"failure", ErrBMCBusy)
 
 	// these not only save a map lookup each open, but also register the labels
 	v2ConnectionOpenAttempts = connectionOpenAttempts.WithLabelValues("2.0")
@@ -44,11 +48,19 @@ type v2ConnectionLayers struct {
 //
 // Note that a given BMC only supports a single command at a time, which is what
 // makes this possible - if a session is sending a command, the session-less
-// connection it was initiated from cannot send concurrently.
+// connection it was initiated from cannot send concurrently. This is enforced
+// by mu, which allows the session-less connection and any number of sessions
+// created from it to be used from different goroutines, sharing one socket.
 type v2ConnectionShared struct {
 
-	// transport is the underlying UDP socket for the connection.
-	transport transport.Transport
+	// mu is held for the duration of each command or RMCP+ payload exchange,
+	// from serialisation through to decoding the response, as the fields below
+	// and the layers of the connection are shared.
+	mu sync.Mutex
+
+	// demux receives from the underlying UDP socket for the connection,
+	// routing responses to the session awaiting them.
+	demux *demultiplexer
 
 	// buffer is used to build all packets to send during this connection.
 	// Reusing this between sends drastically reduces the number of allocations
@@ -65,6 +77,80 @@ type v2ConnectionShared struct {
 	// backoff saves allocating a backoff each request. We must call .Reset() to
 	// reset this between requests.
 	backoff backoff.BackOff
+
+	// clock and rand are set from DialOpts. If nil, the system clock and
+	// crypto/rand are used; access them via now() and random().
+	clock Clock
+	rand  io.Reader
+
+	// ignoreInvalidChecksums is copied into the message layer of each
+	// outgoing command. See ipmi.Message.IgnoreInvalidChecksums.
+	ignoreInvalidChecksums bool
+
+	// requesterAddress is the address we identify ourselves as in outgoing
+	// messages. Per section 5.5 of the v2.0 spec, this is a software ID for
+	// remote consoles, defaulting to remote console #1.
+	requesterAddress ipmi.Address
+
+	// responderAddress is the address of the target of outgoing messages,
+	// defaulting to the BMC's slave address of 0x20. Chassis containing
+	// multiple management controllers may require a different value.
+	responderAddress ipmi.Address
+
+	// sequencer allocates the requester sequence numbers of outgoing
+	// messages. It is shared by all sessions on the connection, as they use
+	// the same requester address.
+	sequencer sequencer.Sequencer
+
+	// lastSessionID is the remote console session ID most recently requested
+	// in an Open Session Request. Each session on the connection needs a
+	// distinct ID, as it is used to route responses. Access with atomic.
+	lastSessionID uint32
+
+	// sessions contains the sessions established or resumed on the
+	// connection that have not been closed, so they can be closed with it.
+	// It is protected by sessionsMu rather than mu, as mu is held while a
+	// session sends Close Session.
+	sessions   map[*V2Session]struct{}
+	sessionsMu sync.Mutex
+}
+
+// addSession tracks a session established on the connection.
+func (s *v2ConnectionShared) addSession(sess *V2Session) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	s.sessions[sess] = struct{}{}
+	openSessionMetrics.add(sess)
+}
+
+// removeSession stops tracking a session, once it has been closed.
+func (s *v2ConnectionShared) removeSession(sess *V2Session) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	delete(s.sessions, sess)
+	openSessionMetrics.remove(sess)
+}
+
+// openSessions returns the sessions on the connection that have not been
+// closed, in no particular order.
+func (s *v2ConnectionShared) openSessions() []*V2Session {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	sessions := make([]*V2Session, 0, len(s.sessions))
+	for sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	return sessions
+}
+
+// nextSessionID returns a remote console session ID not used by another
+// session on this connection. 0 is reserved for session-less packets.
+func (s *v2ConnectionShared) nextSessionID() uint32 {
+	for {
+		if id := atomic.AddUint32(&s.lastSessionID, 1); id != 0 {
+			return id
+		}
+	}
 }
 
 // V2Sessionless represents a session-less connection to a BMC using a "null"
@@ -77,25 +163,28 @@ type V2Sessionless struct {
 	// contrasts with the context, which includes retries.
 	timeout time.Duration
 
+	// establishmentTimeout overrides timeout for RMCP+ session establishment
+	// messages if non-zero.
+	establishmentTimeout time.Duration
+
 	// decode parses the layers in v2ConnectionShared.
 	decode gopacket.DecodingLayerFunc
 }
 
-func newV2Sessionless(t transport.Transport, timeout time.Duration) *V2Sessionless {
+func newV2Sessionless(d *demultiplexer, timeout time.Duration) *V2Sessionless {
 	s := &V2Sessionless{
 		v2ConnectionShared: v2ConnectionShared{
-			transport: t,
-			buffer:    gopacket.NewSerializeBuffer(),
-			backoff:   backoff.NewExponentialBackOff(),
+			demux:            d,
+			buffer:           gopacket.NewSerializeBuffer(),
+			backoff:          backoff.NewExponentialBackOff(),
+			requesterAddress: ipmi.SoftwareIDRemoteConsole1.Address(),
+			responderAddress: ipmi.SlaveAddressBMC.Address(),
+			sessions:         map[*V2Session]struct{}{},
 		},
 		timeout: timeout,
 	}
-	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
-	dlc = dlc.Put(&s.rmcpLayer)
-	dlc = dlc.Put(&s.sessionSelectorLayer)
-	dlc = dlc.Put(&s.v2SessionLayer)
-	dlc = dlc.Put(&s.messageLayer)
-	s.decode = dlc.LayersDecoder(s.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
+	s.decode = ipmi.NewV2DecodingLayerFunc(&s.rmcpLayer,
+		&s.sessionSelectorLayer, &s.v2SessionLayer, nil, &s.messageLayer)
 	return s
 }
 
@@ -103,14 +192,88 @@ func (s *V2Sessionless) Version() string {
 	return "2.0"
 }
 
+// exchange sends the packet in the buffer, then waits for a response addressed
+// to the provided session ID (0 for session-less), which it returns.
+func (s *v2ConnectionShared) exchange(ctx context.Context, sessionID uint32) ([]byte, error) {
+	return s.demux.exchange(ctx, sessionID, s.buffer.Bytes())
+}
+
+// send sends the packet in the buffer, returning a pendingResponse to wait for
+// packets addressed to the provided session ID (0 for session-less).
+func (s *v2ConnectionShared) send(ctx context.Context, sessionID uint32) (*pendingResponse, error) {
+	return s.demux.send(ctx, sessionID, s.buffer.Bytes())
+}
+
+// Unsolicited returns a channel on which packets not sent in response to a
+// request are delivered, e.g. SOL data. Packets for all sessions created from
+// this connection are delivered here, unless the session has called
+// V2Session.Recv(); the session ID can be used to tell them apart. If the
+// channel is not drained, packets are dropped once its buffer is full. The
+// channel is closed when the connection's transport is closed.
+func (s *V2Sessionless) Unsolicited() <-chan UnsolicitedPacket {
+	return s.demux.Unsolicited()
+}
+
+// Recv blocks until an unsolicited packet is received that is not within a
+// session that has called V2Session.Recv(), or the context expires. Unlike
+// commands, this is not subject to the per-request timeout, so a context
+// without a deadline can be used to wait indefinitely. Once the connection's
+// transport is closed, ErrTransportClosed is returned. This must not be called
+// concurrently with receiving from Unsolicited(), as they share a channel.
+func (s *V2Sessionless) Recv(ctx context.Context) (UnsolicitedPacket, error) {
+	return s.demux.recv(ctx, s.demux.Unsolicited())
+}
+
 // SetTimeout configures the per-request timeout for a given RMCP+ or IPMI
 // command. Methods will retry temporary errors until the context expires; this
-// configures how long we will wait for a response.
+// configures how long we will wait for a response. It can be overridden for
+// individual commands via WithCommandTimeout().
 func (s *V2Sessionless) SetTimeout(t time.Duration) {
 	s.timeout = t
 }
 
+// SetEstablishmentTimeout configures the per-request timeout for RMCP+ session
+// establishment messages (Open Session and RAKP Messages 1 and 3), separately
+// from commands. 0, the default, uses the timeout set by SetTimeout().
+func (s *V2Sessionless) SetEstablishmentTimeout(t time.Duration) {
+	s.establishmentTimeout = t
+}
+
+// SetIgnoreInvalidChecksums configures whether IPMI message checksum
+// mismatches in responses are tolerated rather than treated as corrupt packets.
+// This is off by default, and should only be enabled for BMCs known to emit
+// incorrect checksums, e.g. on bridged responses. Accepted mismatches are
+// counted in the bmc_message_checksum_errors_total metric. As sessions share
+// this setting with the session-less connection they were created from,
+// changing it affects those sessions too.
+func (s *V2Sessionless) SetIgnoreInvalidChecksums(ignore bool) {
+	s.ignoreInvalidChecksums = ignore
+}
+
+// SetRequesterAddress configures the address we send as the requester of IPMI
+// messages. This defaults to the software ID of remote console #1; it only
+// needs to be changed if multiple remote consoles sharing the same BMC must be
+// distinguishable, e.g. via SoftwareIDRemoteConsole2.Address(). As with
+// SetIgnoreInvalidChecksums, this is shared with sessions.
+func (s *V2Sessionless) SetRequesterAddress(a ipmi.Address) {
+	s.requesterAddress = a
+}
+
+// SetResponderAddress configures the address of the responder of IPMI
+// messages. This defaults to the BMC's slave address, 0x20; it can be set to
+// address another management controller accessible over the same LAN channel,
+// e.g. ipmi.SlaveAddress(0x16).Address() for a secondary BMC at 0x2c in a
+// dual-BMC chassis. As with SetIgnoreInvalidChecksums, this is shared with
+// sessions. To address individual commands elsewhere, wrap them with
+// ipmi.CommandWithAddress().
+func (s *V2Sessionless) SetResponderAddress(a ipmi.Address) {
+	s.responderAddress = a
+}
+
 func (s *V2Sessionless) buildAndSendPayload(ctx context.Context, p ipmi.Payload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.rmcpLayer = layers.RMCP{
 		Version:  layers.RMCPVersion1,
 		Sequence: 0xFF, // do not send us an ACK
@@ -132,15 +295,29 @@ func (s *V2Sessionless) buildAndSendPayload(ctx context.Context, p ipmi.Payload)
 		return err
 	}
 
+	timeout := s.timeout
+	if s.establishmentTimeout != 0 {
+		timeout = s.establishmentTimeout
+	}
 	s.backoff.Reset()
+	start := time.Now()
+	last := error(nil)
 	retryable := func() error {
-		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
+		requestCtx, cancel := context.WithTimeout(ctx, commandTimeout(ctx, timeout))
+		response, err := s.exchange(requestCtx, 0)
 		cancel()
 		if err != nil {
+			// an oversized packet will not shrink on retry
+			if errors.Is(err, ErrPacketTooLarge) {
+				return backoff.Permanent(err)
+			}
 			return err
 		}
 		if _, err := s.decode(response, &s.layers); err != nil {
+			err = fmt.Errorf("%w: %w", ErrDecode, err)
+			if isPermanentDecodeError(err) {
+				return backoff.Permanent(err)
+			}
 			return err
 		}
 		types := layerexts.DecodedTypes(s.layers)
@@ -149,12 +326,33 @@ func (s *V2Sessionless) buildAndSendPayload(ctx context.Context, p ipmi.Payload)
 		}
 		return nil
 	}
-	if err := backoff.Retry(retryable, backoff.WithContext(s.backoff, ctx)); err != nil {
-		return err
+	if err := backoff.Retry(func() error {
+		last = retryable()
+		return last
+	}, backoff.WithContext(s.backoff, ctx)); err != nil {
+		return retryTimeoutError(err, last, start)
+	}
+
+	if err := p.Response().DecodeFromBytes(s.v2SessionLayer.LayerPayload(),
+		gopacket.NilDecodeFeedback); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return nil
+}
+
+// checkResponse decodes a packet received outside a session, returning an
+// error if it is not the response to the request.
+func (s *V2Sessionless) checkResponse(response []byte, request *ipmi.Message) error {
+	if _, err := s.decode(response, &s.layers); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
 	}
 
-	return p.Response().DecodeFromBytes(
-		s.v2SessionLayer.LayerPayload(), gopacket.NilDecodeFeedback)
+	// ensure we got a message (we don't attempt to parse below message here)
+	types := layerexts.DecodedTypes(s.layers)
+	if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
+		return err
+	}
+	return sequencer.Match(request, &s.messageLayer)
 }
 
 // saves having to write two SerializeLayers calls in SendCommand
@@ -170,6 +368,9 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	defer timer.ObserveDuration()
 	commandAttempts.WithLabelValues(c.Name()).Inc()
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if err := s.buildAndSendCommand(ctx, c); err != nil {
 		commandFailures.WithLabelValues(c.Name()).Inc()
 		return 0, err
@@ -183,14 +384,11 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	// response if the code is non-normal.
 	code := s.messageLayer.CompletionCode
 
-	if c.Response() != nil {
-		// the command is expecting a response body in the success case - do our
-		// best; this may validly fail if the code is non-normal
-		if err := c.Response().DecodeFromBytes(s.messageLayer.LayerPayload(),
-			gopacket.NilDecodeFeedback); err != nil {
-			commandFailures.WithLabelValues(c.Name()).Inc()
-			return code, err
-		}
+	// if the command is expecting a response body in the success case, do our
+	// best; this may validly fail if the code is non-normal
+	if err := decodeResponse(c, code, s.messageLayer.LayerPayload()); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return code, err
 	}
 
 	// even if code is non-normal, if we didn't have any issues, we don't report
@@ -199,6 +397,28 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	return code, nil
 }
 
+func (s *V2Sessionless) SendCommandRaw(ctx context.Context, c ipmi.Command) (*ipmi.Message, error) {
+	timer := prometheus.NewTimer(commandDuration)
+	defer timer.ObserveDuration()
+	commandAttempts.WithLabelValues(c.Name()).Inc()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.buildAndSendCommand(ctx, c); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return nil, err
+	}
+
+	// the response layer decodes from the copy, so does not alias our buffer
+	m := cloneMessage(&s.messageLayer)
+	if err := decodeResponse(c, m.CompletionCode, m.LayerPayload()); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return m, err
+	}
+	return m, nil
+}
+
 func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command) error {
 	s.rmcpLayer = layers.RMCP{
 		Version:  layers.RMCPVersion1,
@@ -208,13 +428,15 @@ func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command)
 	s.v2SessionLayer = ipmi.V2Session{
 		PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
 	}
-	s.messageLayer = ipmi.Message{
-		Operation:     *c.Operation(),
-		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
-		RemoteLUN:     ipmi.LUNBMC,
-		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
-		Sequence:      1,
+	request := ipmi.Message{
+		Operation:              *c.Operation(),
+		RemoteAddress:          commandResponderAddress(c, s.responderAddress),
+		RemoteLUN:              commandLUN(c),
+		LocalAddress:           s.requesterAddress,
+		Sequence:               s.sequencer.Next(),
+		IgnoreInvalidChecksums: s.ignoreInvalidChecksums,
 	}
+	s.messageLayer = request
 
 	// we don't need to increment a sequence number between retries, so can
 	// serialise this just once
@@ -228,43 +450,71 @@ func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command)
 	}
 
 	s.backoff.Reset()
+	start := time.Now()
+	last := error(nil)
 	firstAttempt := true
-	return backoff.Retry(func() error {
+	retryable := func() error {
 		if firstAttempt {
 			firstAttempt = false
 		} else {
 			commandRetries.Inc()
 		}
 
-		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
-		cancel()
+		requestCtx, cancel := context.WithTimeout(ctx, commandTimeout(ctx, s.timeout))
+		defer cancel()
+		pending, err := s.send(requestCtx, 0)
 		if err != nil {
+			if errors.Is(err, ErrPacketTooLarge) {
+				return backoff.Permanent(err)
+			}
 			return err
 		}
-
-		// parse bytes
-		if _, err := s.decode(response, &s.layers); err != nil {
-			return err
-		}
-
-		// ensure we got a message (we don't attempt to parse below message
-		// here)
-		types := layerexts.DecodedTypes(s.layers)
-		if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
-			return err
+		defer pending.close()
+
+		// packets that are not the response to this attempt, e.g. a late
+		// response to an earlier command, are discarded and we keep waiting
+		discarded := error(nil)
+		for {
+			response, err := pending.next(requestCtx)
+			if err != nil {
+				err = discardedTimeoutError(err, discarded, start)
+				// the BMC may have acted on the request without its
+				// response reaching us, so it is only sent again if the
+				// command could safely be executed twice
+				if isTimeout(err) &&
+					!ipmi.CompletionCodeTimeout.IsTemporaryFor(c.Operation()) {
+					return backoff.Permanent(err)
+				}
+				return err
+			}
+			err = s.checkResponse(response, &request)
+			if err == nil {
+				break
+			}
+			if isPermanentDecodeError(err) {
+				return backoff.Permanent(err)
+			}
+			discarded = err
 		}
+		observeChecksums(&s.messageLayer)
 
 		code := s.messageLayer.CompletionCode
 		// must increment here, otherwise we'll miss temporary codes at the
 		// higher levels
 		commandResponses.WithLabelValues(code.String()).Inc()
 		// check completion code is permanent
-		if code.IsTemporary() {
+		if code.IsTemporaryFor(c.Operation()) {
 			return errRetryableCode
 		}
 		return nil
-	}, backoff.WithContext(s.backoff, ctx))
+	}
+	if err := backoff.Retry(func() error {
+		last = retryable()
+		return last
+	}, backoff.WithContext(s.backoff, ctx)); err != nil {
+		return retryTimeoutError(err, last, start)
+	}
+	return nil
 }
 
 func (s *V2Sessionless) GetSystemGUID(ctx context.Context) ([16]byte, error) {
@@ -307,6 +557,21 @@ func getChannelAuthenticationCapabilities(
 	return &cmd.Rsp, nil
 }
 
+// statusCodeError is returned when an RMCP+ Open Session Response or RAKP
+// message contains a non-OK status code.
+type statusCodeError struct {
+	Code ipmi.StatusCode
+}
+
+func (e *statusCodeError) Error() string {
+	return fmt.Sprintf("managed system returned non-OK status: %v", e.Code)
+}
+
+// Temporary returns whether retrying session establishment may succeed.
+func (e *statusCodeError) Temporary() bool {
+	return e.Code.IsTemporary()
+}
+
 func (s *V2Sessionless) openSession(ctx context.Context, r *ipmi.OpenSessionReq) (*ipmi.OpenSessionRsp, error) {
 	// if we were being *really* aggressive, we could store these payloads in
 	// the sessionless struct for reuse during any future session establishments
@@ -322,8 +587,7 @@ func (s *V2Sessionless) openSession(ctx context.Context, r *ipmi.OpenSessionReq)
 			rsp.Tag)
 	}
 	if rsp.Status != ipmi.StatusCodeOK {
-		return nil, fmt.Errorf("managed system returned non-OK status: %v",
-			rsp.Status)
+		return nil, &statusCodeError{Code: rsp.Status}
 	}
 	return rsp, nil
 }
@@ -341,8 +605,7 @@ func (s *V2Sessionless) rakpMessage1(ctx context.Context, r *ipmi.RAKPMessage1)
 			rsp.Tag)
 	}
 	if rsp.Status != ipmi.StatusCodeOK {
-		return nil, fmt.Errorf("managed system returned non-OK status: %v",
-			rsp.Status)
+		return nil, &statusCodeError{Code: rsp.Status}
 	}
 	return rsp, nil
 }
@@ -360,8 +623,7 @@ func (s *V2Sessionless) rakpMessage3(ctx context.Context, r *ipmi.RAKPMessage3)
 			rsp.Tag)
 	}
 	if rsp.Status != ipmi.StatusCodeOK {
-		return nil, fmt.Errorf("managed system returned non-OK status: %v",
-			rsp.Status)
+		return nil, &statusCodeError{Code: rsp.Status}
 	}
 	return rsp, nil
 }
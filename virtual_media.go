@@ -0,0 +1,52 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// VirtualMedia is implemented by OEM extensions that can present a disk image
+// on the network to the managed system as a virtual CD/DVD drive, e.g. to
+// install an operating system on a machine whose BMC lacks Redfish. The
+// specification has no equivalent, so this is only available for
+// manufacturers whose extensions are registered with RegisterOEM().
+type VirtualMedia interface {
+
+	// AttachMedia mounts the image at the URL, whose supported schemes are
+	// defined by the manufacturer, typically including nfs, smb and http(s).
+	// Any image already attached is replaced.
+	AttachMedia(ctx context.Context, s Session, url *url.URL) error
+
+	// DetachMedia unmounts any attached image. It is not an error if there
+	// is none.
+	DetachMedia(ctx context.Context, s Session) error
+}
+
+// AttachMedia mounts the disk image at rawURL as a virtual CD/DVD drive on the
+// managed system, using the OEM extensions registered for the BMC's
+// manufacturer. This is best-effort: if the manufacturer has no registered
+// implementation, an error wrapping ErrOEMUnsupported is returned.
+func (s *V2Session) AttachMedia(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("media URL must be absolute, got %q", rawURL)
+	}
+	vm, err := oemFeature[VirtualMedia](ctx, s, "virtual media")
+	if err != nil {
+		return err
+	}
+	return vm.AttachMedia(ctx, s, u)
+}
+
+// DetachMedia unmounts any image attached with AttachMedia().
+func (s *V2Session) DetachMedia(ctx context.Context) error {
+	vm, err := oemFeature[VirtualMedia](ctx, s, "virtual media")
+	if err != nil {
+		return err
+	}
+	return vm.DetachMedia(ctx, s)
+}
@@ -0,0 +1,33 @@
+package bmc
+
+import (
+	"context"
+	"time"
+)
+
+// WaitFor repeatedly calls poll at the provided interval until it indicates
+// completion by returning true, returns an error, or the context expires. It is
+// intended for long-running operations the BMC processes asynchronously, where
+// the initiating command returns immediately and a second command must be used
+// to retrieve progress, e.g. Clear SEL and its "get erasure status" action.
+// poll is called immediately, and the context is passed to it. If the context
+// expires, its error is returned.
+func WaitFor(ctx context.Context, interval time.Duration, poll func(context.Context) (bool, error)) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		done, err := poll(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		timer.Reset(interval)
+	}
+}
@@ -0,0 +1,64 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+	errPoll := errors.New("poll failed")
+	tests := []struct {
+		name      string
+		doneAfter int // calls
+		err       error
+		timeout   time.Duration
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "immediate",
+			doneAfter: 1,
+			timeout:   time.Second,
+			wantCalls: 1,
+		},
+		{
+			name:      "eventual",
+			doneAfter: 3,
+			timeout:   time.Second,
+			wantCalls: 3,
+		},
+		{
+			name:      "error",
+			doneAfter: 3,
+			err:       errPoll,
+			timeout:   time.Second,
+			wantCalls: 1,
+			wantErr:   errPoll,
+		},
+		{
+			name:      "timeout",
+			doneAfter: 1000,
+			timeout:   time.Millisecond * 20,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			calls := 0
+			err := WaitFor(ctx, time.Millisecond, func(context.Context) (bool, error) {
+				calls++
+				return calls >= test.doneAfter, test.err
+			})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+			if test.wantCalls != 0 && calls != test.wantCalls {
+				t.Errorf("poll called %v times, want %v", calls, test.wantCalls)
+			}
+		})
+	}
+}
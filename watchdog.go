@@ -0,0 +1,68 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kuiwang02/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket/layers"
+)
+
+var (
+	// ErrWatchdogNotInitialised is returned by ResetWatchdogTimer() if the
+	// timer has not been configured since the BMC was last reset, so cannot be
+	// started.
+	ErrWatchdogNotInitialised = errors.New("watchdog timer has not been " +
+		"configured; use Set Watchdog Timer first")
+)
+
+// WatchdogCountdown converts a duration to a watchdog timer countdown,
+// rounding up to the next 100ms. Durations too long to represent are clamped
+// to the maximum of about 1h49m.
+func WatchdogCountdown(d time.Duration) uint16 {
+	units := (d + ipmi.WatchdogCountdownUnit - 1) / ipmi.WatchdogCountdownUnit
+	if units < 0 {
+		return 0
+	}
+	if units > 0xffff {
+		return 0xffff
+	}
+	return uint16(units)
+}
+
+// GetWatchdogTimer retrieves the configuration and current countdown of the
+// BMC watchdog timer.
+func GetWatchdogTimer(ctx context.Context, s Session) (*ipmi.GetWatchdogTimerRsp, error) {
+	cmd := &ipmi.GetWatchdogTimerCmd{}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	// the response layer's contents belong to the session
+	rsp := cmd.Rsp
+	rsp.BaseLayer = layers.BaseLayer{}
+	return &rsp, nil
+}
+
+// SetWatchdogTimer configures the BMC watchdog timer. Unless req.DontStop is
+// set, this stops the timer; ResetWatchdogTimer() must then be called to start
+// it. Setting the timeout action to none and not resetting is therefore the
+// way to disarm the timer.
+func SetWatchdogTimer(ctx context.Context, s Session, req *ipmi.SetWatchdogTimerReq) error {
+	cmd := &ipmi.SetWatchdogTimerCmd{
+		Req: *req,
+	}
+	return ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+// ResetWatchdogTimer starts the BMC watchdog timer, or restarts its countdown
+// from the initial value if it is already running, i.e. pets it.
+// ErrWatchdogNotInitialised is returned if the timer has not been configured.
+func ResetWatchdogTimer(ctx context.Context, s Session) error {
+	code, err := s.SendCommand(ctx, &ipmi.ResetWatchdogTimerCmd{})
+	if err == nil && code == ipmi.CompletionCodeWatchdogNotInitialised {
+		return ErrWatchdogNotInitialised
+	}
+	return ValidateResponse(code, err)
+}
@@ -0,0 +1,27 @@
+package bmc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchdogCountdown(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want uint16
+	}{
+		{-time.Second, 0},
+		{0, 0},
+		{time.Millisecond, 1},
+		{time.Millisecond * 100, 1},
+		{time.Millisecond * 101, 2},
+		{time.Minute, 600},
+		{time.Hour * 2, 0xffff},
+	}
+	for _, test := range tests {
+		if got := WatchdogCountdown(test.in); got != test.want {
+			t.Errorf("WatchdogCountdown(%v) = %v, want %v", test.in, got,
+				test.want)
+		}
+	}
+}